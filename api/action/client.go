@@ -118,3 +118,37 @@ func (c *Client) ApplicationCharmActions(arg params.Entity) (map[string]params.A
 	}
 	return result.Actions, nil
 }
+
+// ScheduleActions arranges for actions to be enqueued at a future time,
+// and optionally repeated at a fixed interval.
+func (c *Client) ScheduleActions(arg params.ScheduleActions) (params.ActionScheduleResults, error) {
+	results := params.ActionScheduleResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduled actions")
+	}
+	err := c.facade.FacadeCall("ScheduleActions", arg, &results)
+	return results, err
+}
+
+// ListActionSchedules returns the action schedules in the model, ordered
+// by the time at which they will next run.
+func (c *Client) ListActionSchedules() ([]params.ActionSchedule, error) {
+	if c.BestAPIVersion() < 3 {
+		return nil, errors.NotSupportedf("scheduled actions")
+	}
+	var results params.ActionSchedules
+	if err := c.facade.FacadeCall("ListActionSchedules", nil, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return results.Schedules, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given ids.
+func (c *Client) RemoveActionSchedules(ids []string) (params.ErrorResults, error) {
+	results := params.ErrorResults{}
+	if c.BestAPIVersion() < 3 {
+		return results, errors.NotSupportedf("scheduled actions")
+	}
+	err := c.facade.FacadeCall("RemoveActionSchedules", params.ActionScheduleIds{Ids: ids}, &results)
+	return results, err
+}
@@ -127,3 +127,20 @@ func patchApplicationCharmActions(c *gc.C, apiCli *action.Client, patchResults [
 		},
 	)
 }
+
+func (s *actionSuite) TestListActionSchedules(c *gc.C) {
+	expected := []params.ActionSchedule{{Id: "some-id", Name: "backup"}}
+	cleanup := action.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Assert(req, gc.Equals, "ListActionSchedules")
+			c.Assert(paramsIn, gc.IsNil)
+			result := resp.(*params.ActionSchedules)
+			result.Schedules = expected
+			return nil
+		},
+	)
+	defer cleanup()
+	schedules, err := s.client.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, jc.DeepEquals, expected)
+}
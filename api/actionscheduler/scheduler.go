@@ -0,0 +1,25 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/juju/api/base"
+)
+
+const apiName = "ActionScheduler"
+
+// Facade allows calls to "ActionScheduler" endpoints.
+type Facade struct {
+	facade base.FacadeCaller
+}
+
+// NewFacade returns an "ActionScheduler" Facade.
+func NewFacade(caller base.APICaller) *Facade {
+	return &Facade{facade: base.NewFacadeCaller(caller, apiName)}
+}
+
+// RunDueActions calls "ActionScheduler.RunDueActions".
+func (f *Facade) RunDueActions() error {
+	return f.facade.FacadeCall("RunDueActions", nil, nil)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 )
 
@@ -198,10 +199,19 @@ type SetCharmConfig struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]storage.Constraints `json:"storage-constraints,omitempty"`
+
+	// Rolling holds the application's existing units on their current
+	// charm until they are released with ReleaseCharmUpgrade. This
+	// field is only understood by Application facade version 6 and
+	// greater.
+	Rolling bool
 }
 
 // SetCharm sets the charm for a given service.
 func (c *Client) SetCharm(cfg SetCharmConfig) error {
+	if cfg.Rolling && c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support rolling charm upgrades")
+	}
 	var storageConstraints map[string]params.StorageConstraints
 	if len(cfg.StorageConstraints) > 0 {
 		storageConstraints = make(map[string]params.StorageConstraints)
@@ -231,10 +241,129 @@ func (c *Client) SetCharm(cfg SetCharmConfig) error {
 		ForceUnits:         cfg.ForceUnits,
 		ResourceIDs:        cfg.ResourceIDs,
 		StorageConstraints: storageConstraints,
+		Rolling:            cfg.Rolling,
 	}
 	return c.facade.FacadeCall("SetCharm", args, nil)
 }
 
+// ReleaseCharmUpgrade allows the named units, held on their previous
+// charm by a rolling charm upgrade, to upgrade to the application's
+// charm.
+func (c *Client) ReleaseCharmUpgrade(application string, unitNames ...string) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.New("this juju controller does not support rolling charm upgrades")
+	}
+	release := params.ApplicationCharmUpgradeRelease{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		UnitTags:       make([]string, len(unitNames)),
+	}
+	for i, name := range unitNames {
+		if !names.IsValidUnit(name) {
+			return errors.NotValidf("unit ID %q", name)
+		}
+		release.UnitTags[i] = names.NewUnitTag(name).String()
+	}
+	args := params.ApplicationCharmUpgradeReleases{
+		Releases: []params.ApplicationCharmUpgradeRelease{release},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ReleaseCharmUpgrade", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// CharmUpgradeHold returns the charm URL on which units of the
+// application are held by a rolling charm upgrade, and the names of
+// those units. The URL is nil if there is no rolling charm upgrade in
+// progress.
+func (c *Client) CharmUpgradeHold(application string) (*charm.URL, []string, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, nil, errors.New("this juju controller does not support rolling charm upgrades")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.CharmUpgradeHoldResults
+	if err := c.facade.FacadeCall("CharmUpgradeHold", args, &results); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, nil, result.Error
+	}
+	if result.CharmURL == "" {
+		return nil, nil, nil
+	}
+	curl, err := charm.ParseURL(result.CharmURL)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	unitNames := make([]string, len(result.UnitTags))
+	for i, tagString := range result.UnitTags {
+		tag, err := names.ParseUnitTag(tagString)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		unitNames[i] = tag.Id()
+	}
+	return curl, unitNames, nil
+}
+
+// SetEgressRules replaces the destinations and ports to which the
+// application's machines may send outgoing traffic. Setting no rules
+// removes all of the application's egress rules.
+func (c *Client) SetEgressRules(application string, rules []network.EgressRule) error {
+	if c.BestAPIVersion() < 8 {
+		return errors.New("this juju controller does not support egress rules")
+	}
+	arg := params.ApplicationEgressRules{
+		ApplicationTag: names.NewApplicationTag(application).String(),
+		Rules:          make([]params.EgressRule, len(rules)),
+	}
+	for i, rule := range rules {
+		arg.Rules[i] = params.FromNetworkEgressRule(rule)
+	}
+	args := params.SetApplicationsEgressRules{
+		Args: []params.ApplicationEgressRules{arg},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetEgressRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// EgressRules returns the destinations and ports to which the
+// application's machines may send outgoing traffic.
+func (c *Client) EgressRules(application string) ([]network.EgressRule, error) {
+	if c.BestAPIVersion() < 8 {
+		return nil, errors.New("this juju controller does not support egress rules")
+	}
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: names.NewApplicationTag(application).String()}},
+	}
+	var results params.EgressRulesResults
+	if err := c.facade.FacadeCall("GetEgressRules", args, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if n := len(results.Results); n != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", n)
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, rule.NetworkEgressRule())
+	}
+	return rules, nil
+}
+
 // Update updates the application attributes, including charm URL,
 // minimum number of units, settings and constraints.
 func (c *Client) Update(args params.ApplicationUpdate) error {
@@ -408,6 +537,22 @@ func (c *Client) Expose(application string) error {
 	return c.facade.FacadeCall("Expose", params, nil)
 }
 
+// ExposeTo changes the juju-managed firewall to expose any ports that
+// were also explicitly marked by units as open, to the spaces and
+// CIDRs specified for each endpoint. The settings of any endpoints not
+// specified are left untouched. The empty endpoint name applies the
+// settings to all endpoints.
+func (c *Client) ExposeTo(application string, exposedEndpoints map[string]params.ExposedEndpoint) error {
+	if c.BestAPIVersion() < 7 {
+		return errors.New("this juju controller does not support restricting exposed applications")
+	}
+	params := params.ApplicationExpose{
+		ApplicationName:  application,
+		ExposedEndpoints: exposedEndpoints,
+	}
+	return c.facade.FacadeCall("Expose", params, nil)
+}
+
 // Unexpose changes the juju-managed firewall to unexpose any ports that
 // were also explicitly marked by units as open.
 func (c *Client) Unexpose(application string) error {
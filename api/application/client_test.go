@@ -18,6 +18,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/crossmodel"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
@@ -32,6 +33,13 @@ func newClient(f basetesting.APICallerFunc) *application.Client {
 	return application.NewClient(f)
 }
 
+func newClientWithVersion(f basetesting.APICallerFunc, version int) *application.Client {
+	return application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: f,
+		BestVersion:   version,
+	})
+}
+
 func (s *applicationSuite) TestSetServiceMetricCredentials(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -289,6 +297,182 @@ func (s *applicationSuite) TestServiceSetCharm(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *applicationSuite) TestExposeTo(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "Expose")
+		c.Assert(a, jc.DeepEquals, params.ApplicationExpose{
+			ApplicationName: "application",
+			ExposedEndpoints: map[string]params.ExposedEndpoint{
+				"":      {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+				"admin": {ExposeToSpaces: []string{"office"}},
+			},
+		})
+		return nil
+	}, 7)
+	err := client.ExposeTo("application", map[string]params.ExposedEndpoint{
+		"":      {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		"admin": {ExposeToSpaces: []string{"office"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestExposeToV6(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		return nil
+	}, 6)
+	err := client.ExposeTo("application", map[string]params.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support restricting exposed applications")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestSetEgressRules(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "SetEgressRules")
+		c.Assert(a, jc.DeepEquals, params.SetApplicationsEgressRules{
+			Args: []params.ApplicationEgressRules{{
+				ApplicationTag: "application-application",
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/8"},
+				}},
+			}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+		*(response.(*params.ErrorResults)) = params.ErrorResults{
+			Results: []params.ErrorResult{{}},
+		}
+		return nil
+	}, 8)
+	err := client.SetEgressRules("application", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "GetEgressRules")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-application"}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.EgressRulesResults{})
+		*(response.(*params.EgressRulesResults)) = params.EgressRulesResults{
+			Results: []params.EgressRulesResult{{
+				Rules: []params.EgressRule{{
+					PortRange:        params.PortRange{FromPort: 5432, ToPort: 5433, Protocol: "tcp"},
+					DestinationCIDRs: []string{"10.0.0.0/8"},
+				}},
+			}},
+		}
+		return nil
+	}, 8)
+	rules, err := client.EgressRules("application")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8"),
+	})
+}
+
+func (s *applicationSuite) TestEgressRulesV7(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		return nil
+	}, 7)
+	err := client.SetEgressRules("application", nil)
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support egress rules")
+	_, err = client.EgressRules("application")
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support egress rules")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestSetCharmRollingV5(c *gc.C) {
+	var called bool
+	client := application.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string, version int, id, request string, a, response interface{}) error {
+				called = true
+				return nil
+			},
+		),
+		BestVersion: 5, // v5 does not support rolling charm upgrades
+	})
+	err := client.SetCharm(application.SetCharmConfig{
+		ApplicationName: "application",
+		CharmID: charmstore.CharmID{
+			URL: charm.MustParseURL("trusty/application-1"),
+		},
+		Rolling: true,
+	})
+	c.Assert(err, gc.ErrorMatches, "this juju controller does not support rolling charm upgrades")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *applicationSuite) TestReleaseCharmUpgrade(c *gc.C) {
+	var called bool
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		called = true
+		c.Assert(request, gc.Equals, "ReleaseCharmUpgrade")
+		c.Assert(a, jc.DeepEquals, params.ApplicationCharmUpgradeReleases{
+			Releases: []params.ApplicationCharmUpgradeRelease{{
+				ApplicationTag: "application-foo",
+				UnitTags:       []string{"unit-foo-0", "unit-foo-1"},
+			}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.ErrorResults{})
+		out := response.(*params.ErrorResults)
+		*out = params.ErrorResults{Results: []params.ErrorResult{{
+			Error: &params.Error{Message: "boom"},
+		}}}
+		return nil
+	}, 6)
+	err := client.ReleaseCharmUpgrade("foo", "foo/0", "foo/1")
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *applicationSuite) TestReleaseCharmUpgradeInvalidUnit(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Fatal("unexpected API call")
+		return nil
+	}, 6)
+	err := client.ReleaseCharmUpgrade("foo", "foo")
+	c.Assert(err, gc.ErrorMatches, `unit ID "foo" not valid`)
+}
+
+func (s *applicationSuite) TestCharmUpgradeHold(c *gc.C) {
+	client := newClientWithVersion(func(objType string, version int, id, request string, a, response interface{}) error {
+		c.Assert(request, gc.Equals, "CharmUpgradeHold")
+		c.Assert(a, jc.DeepEquals, params.Entities{
+			Entities: []params.Entity{{Tag: "application-foo"}},
+		})
+		c.Assert(response, gc.FitsTypeOf, &params.CharmUpgradeHoldResults{})
+		out := response.(*params.CharmUpgradeHoldResults)
+		*out = params.CharmUpgradeHoldResults{Results: []params.CharmUpgradeHoldResult{{
+			CharmURL: "cs:trusty/foo-1",
+			UnitTags: []string{"unit-foo-1"},
+		}}}
+		return nil
+	}, 6)
+	curl, unitNames, err := client.CharmUpgradeHold("foo")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(curl, gc.DeepEquals, charm.MustParseURL("cs:trusty/foo-1"))
+	c.Assert(unitNames, jc.DeepEquals, []string{"foo/1"})
+}
+
 func (s *applicationSuite) TestDestroyDeprecated(c *gc.C) {
 	var called bool
 	client := newClient(func(objType string, version int, id, request string, a, response interface{}) error {
@@ -0,0 +1,35 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog provides access to the audit log api facade,
+// which is used to query the audit entries recorded by a controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the audit log API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the audit log API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "AuditLog")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// Query returns the audit entries which match the given filter,
+// oldest first.
+func (c *Client) Query(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	var result params.AuditLogResult
+	if err := c.facade.FacadeCall("Query", filter, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Entries, nil
+}
@@ -0,0 +1,69 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/auditlog"
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&auditLogMockSuite{})
+
+func (s *auditLogMockSuite) TestQuery(c *gc.C) {
+	after := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	filter := params.AuditLogFilter{
+		UserTag:   "user-bob",
+		Operation: "deploy",
+		After:     &after,
+		Limit:     5,
+	}
+	entry := params.AuditLogEntry{
+		JujuServerVersion: "2.3.0",
+		ModelTag:          coretesting.ModelTag.String(),
+		Timestamp:         after.Add(time.Minute),
+		OriginType:        "user",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	}
+
+	var called bool
+	client := auditlog.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "AuditLog")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "Query")
+			c.Check(a, jc.DeepEquals, filter)
+			result := response.(*params.AuditLogResult)
+			result.Entries = []params.AuditLogEntry{entry}
+			return nil
+		},
+	))
+	entries, err := client.Query(filter)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(entries, jc.DeepEquals, []params.AuditLogEntry{entry})
+}
+
+func (s *auditLogMockSuite) TestQueryError(c *gc.C) {
+	client := auditlog.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			return errors.New("boom")
+		},
+	))
+	_, err := client.Query(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -10,10 +10,15 @@ import (
 )
 
 // Create sends a request to create a backup of juju's state.  It
-// returns the metadata associated with the resulting backup.
-func (c *Client) Create(notes string) (*params.BackupsMetadataResult, error) {
+// returns the metadata associated with the resulting backup. The
+// archive is encrypted if an encryption key is given, and uploaded
+// to remote storage if a location is given; both need version 2 of
+// the Backups facade.
+func (c *Client) Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
+	if (args.EncryptionKey != "" || args.UploadTo != "") && c.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("encrypting or uploading backups with this controller")
+	}
 	var result params.BackupsMetadataResult
-	args := params.BackupsCreateArgs{Notes: notes}
 	if err := c.facade.FacadeCall("Create", args, &result); err != nil {
 		return nil, errors.Trace(err)
 	}
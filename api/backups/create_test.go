@@ -39,9 +39,32 @@ func (s *createSuite) TestCreate(c *gc.C) {
 	)
 	defer cleanup()
 
-	result, err := s.client.Create("important")
+	result, err := s.client.Create(params.BackupsCreateArgs{Notes: "important"})
 	c.Assert(err, jc.ErrorIsNil)
 
 	meta := backupstesting.UpdateNotes(s.Meta, "important")
 	s.checkMetadataResult(c, result, meta)
 }
+
+func (s *createSuite) TestCreateEncryptAndUpload(c *gc.C) {
+	cleanup := backups.PatchClientFacadeCall(s.client,
+		func(req string, paramsIn interface{}, resp interface{}) error {
+			c.Check(req, gc.Equals, "Create")
+			c.Check(paramsIn, jc.DeepEquals, params.BackupsCreateArgs{
+				EncryptionKey:     "sekrit",
+				UploadTo:          "s3://bucket",
+				UploadCredentials: map[string]string{"access-key": "AKID"},
+			})
+			*(resp.(*params.BackupsMetadataResult)) = apiserverbackups.ResultFromMetadata(s.Meta)
+			return nil
+		},
+	)
+	defer cleanup()
+
+	_, err := s.client.Create(params.BackupsCreateArgs{
+		EncryptionKey:     "sekrit",
+		UploadTo:          "s3://bucket",
+		UploadCredentials: map[string]string{"access-key": "AKID"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
@@ -81,7 +81,7 @@ func (c *Client) RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResu
 		logger.Errorf("could not clean up after failed backup upload: %v", finishErr)
 		return errors.Annotatef(err, "cannot upload backup file")
 	}
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, "", newClient)
 }
 
 // Restore performs restore using a backup id corresponding to a backup stored in the server.
@@ -90,7 +90,21 @@ func (c *Client) Restore(backupId string, newClient ClientConnection) error {
 		return errors.Trace(err)
 	}
 	logger.Debugf("Server in 'about to restore' mode")
-	return c.restore(backupId, newClient)
+	return c.restore(backupId, "", newClient)
+}
+
+// RestoreEncrypted performs restore using a backup id corresponding to
+// an encrypted backup stored in the server, which is decrypted with the
+// given key. It needs version 2 of the Backups facade.
+func (c *Client) RestoreEncrypted(backupId, encryptionKey string, newClient ClientConnection) error {
+	if c.BestAPIVersion() < 2 {
+		return errors.NotSupportedf("restoring encrypted backups with this controller")
+	}
+	if err := prepareRestore(newClient); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Debugf("Server in 'about to restore' mode")
+	return c.restore(backupId, encryptionKey, newClient)
 }
 
 func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, error) {
@@ -103,15 +117,17 @@ func restoreAttempt(client *Client, restoreArgs params.RestoreArgs) (error, erro
 // restore is responsible for triggering the whole restore process in a remote
 // machine. The backup information for the process should already be in the
 // server and loaded in the backup storage under the backupId id.
-// It takes backupId as the identifier for the remote backup file and a
-// client connection factory newClient (newClient should no longer be
-// necessary when lp:1399722 is sorted out).
-func (c *Client) restore(backupId string, newClient ClientConnection) error {
+// It takes backupId as the identifier for the remote backup file, the
+// key used to decrypt it if it is encrypted, and a client connection
+// factory newClient (newClient should no longer be necessary when
+// lp:1399722 is sorted out).
+func (c *Client) restore(backupId, encryptionKey string, newClient ClientConnection) error {
 	var err, remoteError error
 
 	// Restore
 	restoreArgs := params.RestoreArgs{
-		BackupId: backupId,
+		BackupId:      backupId,
+		EncryptionKey: encryptionKey,
 	}
 
 	cleanExit := false
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package bundle provides access to the bundle api facade.
+// This facade contains api calls that are specific to bundles.
+package bundle
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the bundle API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the bundle API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "Bundle")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// GetChanges returns the changes required to deploy the given
+// bundle YAML, along with any errors found verifying the bundle.
+func (c *Client) GetChanges(bundleDataYAML string) (params.BundleChangesResults, error) {
+	var result params.BundleChangesResults
+	args := params.BundleChangesParams{
+		BundleDataYAML: bundleDataYAML,
+	}
+	if err := c.facade.FacadeCall("GetChanges", args, &result); err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// ExportBundle exports the current model configuration as bundle YAML.
+func (c *Client) ExportBundle() (string, error) {
+	if c.BestAPIVersion() < 2 {
+		return "", errors.New("this controller does not support exporting bundles")
+	}
+	var result params.StringResult
+	if err := c.facade.FacadeCall("ExportBundle", nil, &result); err != nil {
+		return "", errors.Trace(err)
+	}
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.Result, nil
+}
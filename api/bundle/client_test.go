@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/bundle"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type bundleMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&bundleMockSuite{})
+
+func newClient(f basetesting.APICallerFunc, version int) *bundle.Client {
+	return bundle.NewClient(basetesting.BestVersionCaller{
+		APICallerFunc: f,
+		BestVersion:   version,
+	})
+}
+
+func (s *bundleMockSuite) TestGetChanges(c *gc.C) {
+	var called bool
+	client := newClient(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "GetChanges")
+			c.Check(a, jc.DeepEquals, params.BundleChangesParams{
+				BundleDataYAML: "applications: {}",
+			})
+			result := response.(*params.BundleChangesResults)
+			result.Errors = []string{"bad bundle"}
+			return nil
+		}, 2,
+	)
+	result, err := client.GetChanges("applications: {}")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result.Errors, jc.DeepEquals, []string{"bad bundle"})
+}
+
+func (s *bundleMockSuite) TestExportBundle(c *gc.C) {
+	var called bool
+	client := newClient(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "Bundle")
+			c.Check(request, gc.Equals, "ExportBundle")
+			c.Check(a, gc.IsNil)
+			result := response.(*params.StringResult)
+			result.Result = "applications: {}\n"
+			return nil
+		}, 2,
+	)
+	result, err := client.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(called, jc.IsTrue)
+	c.Assert(result, gc.Equals, "applications: {}\n")
+}
+
+func (s *bundleMockSuite) TestExportBundleError(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			result := response.(*params.StringResult)
+			result.Error = &params.Error{Message: "nothing to export"}
+			return nil
+		}, 2,
+	)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "nothing to export")
+}
+
+func (s *bundleMockSuite) TestExportBundleNotSupported(c *gc.C) {
+	client := newClient(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		}, 1,
+	)
+	_, err := client.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "this controller does not support exporting bundles")
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 // ControllerConfigAPI provides common client-side API functions
@@ -30,3 +31,16 @@ func (e *ControllerConfigAPI) ControllerConfig() (controller.Config, error) {
 	}
 	return controller.Config(result.Config), nil
 }
+
+// AuditForwardConfig returns the current audit forward syslog
+// configuration.
+func (e *ControllerConfigAPI) AuditForwardConfig() (*syslog.RawConfig, bool, error) {
+	// Audit forwarding is configured per controller, so piggyback off
+	// the ControllerConfig API.
+	controllerConfig, err := e.ControllerConfig()
+	if err != nil {
+		return nil, false, err
+	}
+	cfg, ok := controllerConfig.AuditFwdSyslog()
+	return cfg, ok, nil
+}
@@ -53,6 +53,13 @@ type DebugLogParams struct {
 	// StartTime should be a time in the past - only records with a
 	// log time on or after StartTime will be returned.
 	StartTime time.Time
+	// EndTime, if set, excludes records with a log time after it. The
+	// server stops once it has sent the matching records, as if NoTail
+	// had been set.
+	EndTime time.Time
+	// MessagePattern, if set, is a regular expression that the message
+	// of each returned record must match.
+	MessagePattern string
 }
 
 func (args DebugLogParams) URLQuery() url.Values {
@@ -80,11 +87,21 @@ func (args DebugLogParams) URLQuery() url.Values {
 	if !args.StartTime.IsZero() {
 		attrs.Set("startTime", args.StartTime.Format(time.RFC3339Nano))
 	}
+	if !args.EndTime.IsZero() {
+		attrs.Set("endTime", args.EndTime.Format(time.RFC3339Nano))
+	}
+	if args.MessagePattern != "" {
+		attrs.Set("messagePattern", args.MessagePattern)
+	}
 	return attrs
 }
 
-// LogMessage is a structured logging entry.
+// LogMessage is a structured logging entry. ID, ModelUUID and Version
+// are not sent by older controllers.
 type LogMessage struct {
+	ID        int64
+	ModelUUID string
+	Version   string
 	Entity    string
 	Timestamp time.Time
 	Severity  string
@@ -122,6 +139,9 @@ func StreamDebugLog(source base.StreamConnector, args DebugLogParams) (<-chan Lo
 				return
 			}
 			messages <- LogMessage{
+				ID:        msg.ID,
+				ModelUUID: msg.ModelUUID,
+				Version:   msg.Version,
 				Entity:    msg.Entity,
 				Timestamp: msg.Timestamp,
 				Severity:  msg.Severity,
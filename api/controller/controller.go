@@ -158,6 +158,17 @@ func (c *Client) WatchAllModels() (*api.AllWatcher, error) {
 	return api.NewAllModelWatcher(c.facade.RawAPICaller(), &info.AllWatcherId), nil
 }
 
+// ScheduledBackupStatus returns the outcome of the most recent
+// scheduled backups of the controller.
+func (c *Client) ScheduledBackupStatus() (params.ScheduledBackupStatus, error) {
+	var result params.ScheduledBackupStatus
+	if c.BestAPIVersion() < 4 {
+		return result, errors.NotSupportedf("scheduled backup status")
+	}
+	err := c.facade.FacadeCall("ScheduledBackupStatus", nil, &result)
+	return result, errors.Trace(err)
+}
+
 // GrantController grants a user access to the controller.
 func (c *Client) GrantController(user, access string) error {
 	return c.modifyControllerUser(params.GrantControllerAccess, user, access)
@@ -254,16 +265,60 @@ func (s *MigrationSpec) Validate() error {
 // but we don't need that at the client side yet (and may never) so
 // this call just supports starting one migration at a time.
 func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
+	args, err := makeMigrationArgs(spec)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	response := params.InitiateMigrationResults{}
+	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
+		return "", errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return "", errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return "", errors.Trace(result.Error)
+	}
+	return result.MigrationId, nil
+}
+
+// PrecheckMigration runs all of the prechecks for migrating the
+// specified model, without starting the migration, and returns a
+// description of every problem that would prevent the migration.
+func (c *Client) PrecheckMigration(spec MigrationSpec) ([]string, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("migration precheck report")
+	}
+	args, err := makeMigrationArgs(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	response := params.PrecheckMigrationResults{}
+	if err := c.facade.FacadeCall("PrecheckMigration", args, &response); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(response.Results) != 1 {
+		return nil, errors.New("unexpected number of results returned")
+	}
+	result := response.Results[0]
+	if result.Error != nil {
+		return nil, errors.Trace(result.Error)
+	}
+	return result.Failures, nil
+}
+
+func makeMigrationArgs(spec MigrationSpec) (params.InitiateMigrationArgs, error) {
 	if err := spec.Validate(); err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
 	macsJSON, err := macaroonsToJSON(spec.TargetMacaroons)
 	if err != nil {
-		return "", errors.Annotatef(err, "client-side validation failed")
+		return params.InitiateMigrationArgs{}, errors.Annotatef(err, "client-side validation failed")
 	}
 
-	args := params.InitiateMigrationArgs{
+	return params.InitiateMigrationArgs{
 		Specs: []params.MigrationSpec{{
 			ModelTag: names.NewModelTag(spec.ModelUUID).String(),
 			TargetInfo: params.MigrationTargetInfo{
@@ -275,19 +330,7 @@ func (c *Client) InitiateMigration(spec MigrationSpec) (string, error) {
 				Macaroons:     string(macsJSON),
 			},
 		}},
-	}
-	response := params.InitiateMigrationResults{}
-	if err := c.facade.FacadeCall("InitiateMigration", args, &response); err != nil {
-		return "", errors.Trace(err)
-	}
-	if len(response.Results) != 1 {
-		return "", errors.New("unexpected number of results returned")
-	}
-	result := response.Results[0]
-	if result.Error != nil {
-		return "", errors.Trace(result.Error)
-	}
-	return result.MigrationId, nil
+	}, nil
 }
 
 func macaroonsToJSON(macs []macaroon.Slice) (string, error) {
@@ -5,8 +5,9 @@ package controller_test
 
 import (
 	"encoding/json"
-	"errors"
+	"time"
 
+	"github.com/juju/errors"
 	jujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils"
@@ -186,6 +187,97 @@ func (s *Suite) TestHostedModelConfigs_FormatResults(c *gc.C) {
 	c.Assert(third.Error.Error(), gc.Equals, "validating CloudSpec: empty Type not valid")
 }
 
+func (s *Suite) TestScheduledBackupStatus(c *gc.C) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "ScheduledBackupStatus")
+			c.Check(arg, gc.IsNil)
+			*result.(*params.ScheduledBackupStatus) = params.ScheduledBackupStatus{
+				LastAttempt:  now,
+				LastSuccess:  now,
+				LastBackupID: "backup-id",
+			}
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	status, err := client.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.ScheduledBackupStatus{
+		LastAttempt:  now,
+		LastSuccess:  now,
+		LastBackupID: "backup-id",
+	})
+}
+
+func (s *Suite) TestScheduledBackupStatusNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 3,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.ScheduledBackupStatus()
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
+func (s *Suite) TestPrecheckMigration(c *gc.C) {
+	spec := makeSpec()
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "Controller")
+			c.Check(request, gc.Equals, "PrecheckMigration")
+			c.Check(arg, jc.DeepEquals, specToArgs(spec))
+			*result.(*params.PrecheckMigrationResults) = params.PrecheckMigrationResults{
+				Results: []params.PrecheckMigrationResult{{
+					Failures: []string{"source: boom", "target: splat"},
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	failures, err := client.PrecheckMigration(spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(failures, jc.DeepEquals, []string{"source: boom", "target: splat"})
+}
+
+func (s *Suite) TestPrecheckMigrationError(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			*result.(*params.PrecheckMigrationResults) = params.PrecheckMigrationResults{
+				Results: []params.PrecheckMigrationResult{{
+					Error: common.ServerError(errors.New("boom")),
+				}},
+			}
+			return nil
+		},
+		BestVersion: 5,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.PrecheckMigration(makeSpec())
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
+
+func (s *Suite) TestPrecheckMigrationNotSupported(c *gc.C) {
+	apiCaller := apitesting.BestVersionCaller{
+		APICallerFunc: func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Fatalf("unexpected API call")
+			return nil
+		},
+		BestVersion: 4,
+	}
+	client := controller.NewClient(apiCaller)
+	_, err := client.PrecheckMigration(makeSpec())
+	c.Assert(err, jc.Satisfies, errors.IsNotSupported)
+}
+
 func makeClient(results params.InitiateMigrationResults) (
 	*controller.Client, *jujutesting.Stub,
 ) {
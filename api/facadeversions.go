@@ -11,30 +11,34 @@ package api
 // New facades should start at 1.
 // Facades that existed before versioning start at 0.
 var facadeVersions = map[string]int{
-	"Action":                       2,
+	"Action":                       3,
+	"ActionPruner":                 1,
+	"ActionScheduler":              1,
 	"Agent":                        2,
 	"AgentTools":                   1,
 	"AllModelWatcher":              2,
 	"AllWatcher":                   1,
 	"Annotations":                  2,
-	"Application":                  5,
+	"Application":                  8,
 	"ApplicationOffers":            1,
 	"ApplicationScaler":            1,
-	"Backups":                      1,
+	"AuditLog":                     1,
+	"Backups":                      2,
 	"Block":                        2,
-	"Bundle":                       1,
+	"Bundle":                       2,
 	"CharmRevisionUpdater":         2,
 	"Charms":                       2,
 	"Cleaner":                      2,
 	"Client":                       1,
 	"Cloud":                        1,
-	"Controller":                   3,
+	"Controller":                   5,
 	"CrossModelRelations":          1,
 	"Deployer":                     1,
 	"DiskManager":                  2,
 	"EntityWatcher":                2,
 	"FilesystemAttachmentsWatcher": 2,
-	"Firewaller":                   4,
+	"FirewallRules":                1,
+	"Firewaller":                   7,
 	"HighAvailability":             2,
 	"HostKeyReporter":              1,
 	"ImageManager":                 2,
@@ -59,7 +63,7 @@ var facadeVersions = map[string]int{
 	"MigrationMaster":              1,
 	"MigrationMinion":              1,
 	"MigrationStatusWatcher":       1,
-	"MigrationTarget":              1,
+	"MigrationTarget":              2,
 	"ModelConfig":                  1,
 	"ModelManager":                 3,
 	"ModelUpgrader":                1,
@@ -80,13 +84,13 @@ var facadeVersions = map[string]int{
 	"Spaces":                       3,
 	"SSHClient":                    2,
 	"StatusHistory":                2,
-	"Storage":                      4,
-	"StorageProvisioner":           3,
+	"Storage":                      6,
+	"StorageProvisioner":           4,
 	"StringsWatcher":               1,
 	"Subnets":                      2,
 	"Undertaker":                   1,
 	"UnitAssigner":                 1,
-	"Uniter":                       6,
+	"Uniter":                       7,
 	"Upgrader":                     1,
 	"UserManager":                  1,
 	"VolumeAttachmentsWatcher":     2,
@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 )
 
@@ -75,3 +76,62 @@ func (s *Application) IsExposed() (bool, error) {
 	}
 	return result.Result, nil
 }
+
+// ExposeInfo returns whether this application is exposed and, if so,
+// the source CIDRs from which its explicitly open ports may be
+// accessed. If the API server does not support restricting exposed
+// applications, exposed applications may be accessed from anywhere.
+func (s *Application) ExposeInfo() (bool, []string, error) {
+	if s.st.BestAPIVersion() < 5 {
+		exposed, err := s.IsExposed()
+		if err != nil || !exposed {
+			return false, nil, err
+		}
+		return true, []string{"0.0.0.0/0"}, nil
+	}
+	var results params.ExposeInfoResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetExposeInfo", args, &results)
+	if err != nil {
+		return false, nil, err
+	}
+	if len(results.Results) != 1 {
+		return false, nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return false, nil, result.Error
+	}
+	return result.Exposed, result.SourceCIDRs, nil
+}
+
+// EgressRules returns the destinations and ports to which the
+// application's machines may send outgoing traffic. If the API server
+// does not support egress rules, there are none.
+func (s *Application) EgressRules() ([]network.EgressRule, error) {
+	if s.st.BestAPIVersion() < 7 {
+		return nil, nil
+	}
+	var results params.EgressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: s.tag.String()}},
+	}
+	err := s.st.facade.FacadeCall("GetEgressRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	var rules []network.EgressRule
+	for _, rule := range result.Rules {
+		rules = append(rules, rule.NetworkEgressRule())
+	}
+	return rules, nil
+}
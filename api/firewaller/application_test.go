@@ -10,6 +10,8 @@ import (
 
 	"github.com/juju/juju/api/firewaller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
 
@@ -91,3 +93,40 @@ func (s *applicationSuite) TestIsExposed(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(isExposed, jc.IsFalse)
 }
+
+func (s *applicationSuite) TestExposeInfo(c *gc.C) {
+	err := s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, cidrs, err := s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsTrue)
+	c.Assert(cidrs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	err = s.application.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	exposed, cidrs, err = s.apiApplication.ExposeInfo()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(exposed, jc.IsFalse)
+	c.Assert(cidrs, gc.HasLen, 0)
+}
+
+func (s *applicationSuite) TestEgressRules(c *gc.C) {
+	rules, err := s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = s.application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	rules, err = s.apiApplication.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+}
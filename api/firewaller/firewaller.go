@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/api/common/cloudspec"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/watcher"
 	"gopkg.in/macaroon.v1"
 )
@@ -120,6 +121,63 @@ func (c *Client) WatchOpenedPorts() (watcher.StringsWatcher, error) {
 	return w, nil
 }
 
+// WatchSubnets returns a StringsWatcher that notifies of changes
+// to the model's subnets.
+func (c *Client) WatchSubnets() (watcher.StringsWatcher, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("WatchSubnets")
+	}
+	var result params.StringsWatchResult
+	if err := c.facade.FacadeCall("WatchSubnets", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewStringsWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// WatchModelFirewallRules returns a NotifyWatcher that notifies of
+// changes to the model's firewall rules.
+func (c *Client) WatchModelFirewallRules() (watcher.NotifyWatcher, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("WatchModelFirewallRules")
+	}
+	var result params.NotifyWatchResult
+	if err := c.facade.FacadeCall("WatchModelFirewallRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	w := apiwatcher.NewNotifyWatcher(c.facade.RawAPICaller(), result)
+	return w, nil
+}
+
+// ModelIngressRules returns the ingress rules required by the model's
+// firewall rules for the whole model.
+func (c *Client) ModelIngressRules() ([]network.IngressRule, error) {
+	if c.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("ModelIngressRules")
+	}
+	var result params.IngressRulesResult
+	if err := c.facade.FacadeCall("ModelIngressRules", nil, &result); err != nil {
+		return nil, err
+	}
+	if err := result.Error; err != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.IngressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = network.IngressRule{
+			PortRange:   rule.PortRange.NetworkPortRange(),
+			SourceCIDRs: rule.SourceCIDRs,
+		}
+	}
+	return rules, nil
+}
+
 // Relation provides access to methods of a state.Relation through the
 // facade.
 func (c *Client) Relation(tag names.RelationTag) (*Relation, error) {
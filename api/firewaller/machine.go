@@ -6,8 +6,10 @@ package firewaller
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/api/common"
 	apiwatcher "github.com/juju/juju/api/watcher"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/instance"
@@ -27,6 +29,14 @@ func (m *Machine) Tag() names.MachineTag {
 	return m.tag
 }
 
+// Watch returns a watcher for observing changes to the machine.
+func (m *Machine) Watch() (watcher.NotifyWatcher, error) {
+	if m.st.BestAPIVersion() < 6 {
+		return nil, errors.NotSupportedf("watching machines")
+	}
+	return common.Watch(m.st.facade, "Watch", m.tag)
+}
+
 // WatchUnits starts a StringsWatcher to watch all units assigned to
 // the machine.
 func (m *Machine) WatchUnits() (watcher.StringsWatcher, error) {
@@ -143,3 +153,35 @@ func (m *Machine) OpenedPorts(subnetTag names.SubnetTag) (map[network.PortRange]
 	}
 	return endResult, nil
 }
+
+// FirewallRules returns the ingress rules required on the machine by
+// the model's firewall rules. If the API server does not support model
+// firewall rules, no rules are returned.
+func (m *Machine) FirewallRules() ([]network.IngressRule, error) {
+	if m.st.BestAPIVersion() < 6 {
+		return nil, nil
+	}
+	var results params.IngressRulesResults
+	args := params.Entities{
+		Entities: []params.Entity{{Tag: m.tag.String()}},
+	}
+	err := m.st.facade.FacadeCall("ModelFirewallRules", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != 1 {
+		return nil, fmt.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	rules := make([]network.IngressRule, len(result.Rules))
+	for i, rule := range result.Rules {
+		rules[i] = network.IngressRule{
+			PortRange:   rule.PortRange.NetworkPortRange(),
+			SourceCIDRs: rule.SourceCIDRs,
+		}
+	}
+	return rules, nil
+}
@@ -94,6 +94,26 @@ func (s *machineSuite) TestWatchUnits(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *machineSuite) TestWatch(c *gc.C) {
+	newMachine, err := s.State.AddMachine("quantal", state.JobHostUnits)
+	c.Assert(err, jc.ErrorIsNil)
+	apiNewMachine, err := s.firewaller.Machine(newMachine.Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := apiNewMachine.Watch()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	// Provision the machine and check it's detected.
+	err = newMachine.SetProvisioned("i-new", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
 func (s *machineSuite) TestActiveSubnets(c *gc.C) {
 	// No ports opened at first, no active subnets.
 	subnets, err := s.apiMachine.ActiveSubnets()
@@ -134,3 +154,41 @@ func (s *machineSuite) TestOpenedPorts(c *gc.C) {
 		network.PortRange{FromPort: 1234, ToPort: 1234, Protocol: "tcp"}: unitTag,
 	})
 }
+
+func (s *machineSuite) TestFirewallRules(c *gc.C) {
+	// No firewall rules at first.
+	rules, err := s.apiMachine.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	fwRules := state.NewFirewallRules(s.State)
+	err = fwRules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwRules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := controllerConfig.APIPort()
+
+	// The first machine is a controller, so the API port rule applies.
+	rules, err = s.apiMachine.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "192.168.1.0/24"),
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+
+	apiMachine1, err := s.firewaller.Machine(s.machines[1].Tag().(names.MachineTag))
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = apiMachine1.FirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+}
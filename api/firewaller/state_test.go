@@ -9,6 +9,7 @@ import (
 
 	apitesting "github.com/juju/juju/api/testing"
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/watcher/watchertest"
 )
@@ -100,3 +101,56 @@ func (s *stateSuite) TestWatchOpenedPorts(c *gc.C) {
 	wc.AssertChange("1:")
 	wc.AssertNoChange()
 }
+
+func (s *stateSuite) TestWatchModelFirewallRules(c *gc.C) {
+	w, err := s.firewaller.WatchModelFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewNotifyWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertOneChange()
+
+	err = state.NewFirewallRules(s.State).Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *stateSuite) TestModelIngressRules(c *gc.C) {
+	rules, err := s.firewaller.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = state.NewFirewallRules(s.State).Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = s.firewaller.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+}
+
+func (s *stateSuite) TestWatchSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.0.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w, err := s.firewaller.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	wc := watchertest.NewStringsWatcherC(c, w, s.BackingState.StartSync)
+	defer wc.AssertStops()
+
+	// Initial event.
+	wc.AssertChange("10.0.0.0/24")
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "10.0.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("10.0.1.0/24")
+	wc.AssertNoChange()
+}
@@ -0,0 +1,51 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewallrules provides access to the firewall rules api
+// facade, which is used to control access to well-known services,
+// such as SSH, on a model's machines.
+package firewallrules
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
+)
+
+// Client allows access to the firewall rules API end point.
+type Client struct {
+	base.ClientFacade
+	facade base.FacadeCaller
+}
+
+// NewClient creates a new client for accessing the firewall rules API.
+func NewClient(st base.APICallCloser) *Client {
+	frontend, backend := base.NewClientFacade(st, "FirewallRules")
+	return &Client{ClientFacade: frontend, facade: backend}
+}
+
+// SetFirewallRule sets the source CIDRs from which the specified
+// well-known service may be reached.
+func (c *Client) SetFirewallRule(service string, whitelistCIDRs []string) error {
+	args := params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{
+			KnownService:   service,
+			WhitelistCIDRS: whitelistCIDRs,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("SetFirewallRules", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	return results.OneError()
+}
+
+// ListFirewallRules returns all the firewall rules in the model.
+func (c *Client) ListFirewallRules() ([]params.FirewallRule, error) {
+	var result params.ListFirewallRulesResults
+	if err := c.facade.FacadeCall("ListFirewallRules", nil, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Rules, nil
+}
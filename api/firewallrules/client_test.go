@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	basetesting "github.com/juju/juju/api/base/testing"
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type firewallRulesMockSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&firewallRulesMockSuite{})
+
+func (s *firewallRulesMockSuite) TestSetFirewallRule(c *gc.C) {
+	var called bool
+	client := firewallrules.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			called = true
+			c.Check(objType, gc.Equals, "FirewallRules")
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "SetFirewallRules")
+			c.Check(a, jc.DeepEquals, params.FirewallRuleArgs{
+				Args: []params.FirewallRule{{
+					KnownService:   "ssh",
+					WhitelistCIDRS: []string{"10.0.0.0/8"},
+				}},
+			})
+			*(response.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "boom"}}},
+			}
+			return nil
+		},
+	))
+	err := client.SetFirewallRule("ssh", []string{"10.0.0.0/8"})
+	c.Assert(err, gc.ErrorMatches, "boom")
+	c.Assert(called, jc.IsTrue)
+}
+
+func (s *firewallRulesMockSuite) TestListFirewallRules(c *gc.C) {
+	client := firewallrules.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			c.Check(request, gc.Equals, "ListFirewallRules")
+			*(response.(*params.ListFirewallRulesResults)) = params.ListFirewallRulesResults{
+				Rules: []params.FirewallRule{{
+					KnownService:   "ssh",
+					WhitelistCIDRS: []string{"10.0.0.0/8"},
+				}},
+			}
+			return nil
+		},
+	))
+	rules, err := client.ListFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []params.FirewallRule{{
+		KnownService:   "ssh",
+		WhitelistCIDRS: []string{"10.0.0.0/8"},
+	}})
+}
+
+func (s *firewallRulesMockSuite) TestListFirewallRulesError(c *gc.C) {
+	client := firewallrules.NewClient(basetesting.APICallerFunc(
+		func(objType string, version int, id, request string, a, response interface{}) error {
+			return errors.New("boom")
+		},
+	))
+	_, err := client.ListFirewallRules()
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
@@ -0,0 +1,139 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logstream
+
+import (
+	"sync"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/version"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/api/common/stream"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+)
+
+// AuditStream streams audit entries of the /auditstream API endpoint
+// over a websocket connection.
+type AuditStream struct {
+	mu             sync.Mutex
+	stream         jsonReadCloser
+	controllerUUID string
+}
+
+// OpenAuditStream opens a websocket to the API's /auditstream endpoint
+// and returns a stream of audit records from that connection.
+func OpenAuditStream(conn base.StreamConnector, cfg params.LogStreamConfig, controllerUUID string) (*AuditStream, error) {
+	wsStream, err := stream.Open(conn, "/auditstream", &cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	as := &AuditStream{
+		stream:         wsStream,
+		controllerUUID: controllerUUID,
+	}
+	return as, nil
+}
+
+// Next returns the next batch of audit records from the server. The
+// records are converted from the wire format into logfwd.Record, with
+// the Audit field set. As with LogStream.Next, an error indicates that
+// the stream should be re-opened.
+func (as *AuditStream) Next() ([]logfwd.Record, error) {
+	apiRecords, err := as.next()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	records, err := auditRecordsFromAPI(apiRecords, as.controllerUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return records, nil
+}
+
+func (as *AuditStream) next() (params.AuditStreamRecords, error) {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	var result params.AuditStreamRecords
+	if as.stream == nil {
+		return result, errors.Errorf("cannot read from closed stream")
+	}
+
+	err := as.stream.ReadJSON(&result)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	return result, nil
+}
+
+// Close closes the stream.
+func (as *AuditStream) Close() error {
+	as.mu.Lock()
+	defer as.mu.Unlock()
+
+	if as.stream == nil {
+		return nil
+	}
+	if err := as.stream.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	as.stream = nil
+	return nil
+}
+
+// See the counterpart in apiserver/auditstream.go.
+func auditRecordsFromAPI(apiRecords params.AuditStreamRecords, controllerUUID string) ([]logfwd.Record, error) {
+	result := make([]logfwd.Record, len(apiRecords.Records))
+	for i, apiRec := range apiRecords.Records {
+		rec, err := auditRecordFromAPI(apiRec, controllerUUID)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result[i] = rec
+	}
+	return result, nil
+}
+
+func auditRecordFromAPI(apiRec params.AuditLogEntry, controllerUUID string) (logfwd.Record, error) {
+	rec := logfwd.Record{
+		// Audit entries have no sequential ID, so the creation time
+		// is used to identify the last record sent.
+		ID:        apiRec.Timestamp.UnixNano(),
+		Timestamp: apiRec.Timestamp,
+		Level:     loggo.INFO,
+		Message:   apiRec.Operation,
+		Audit: &logfwd.AuditDetails{
+			RemoteAddress: apiRec.RemoteAddress,
+			Operation:     apiRec.Operation,
+			Data:          apiRec.Data,
+		},
+	}
+
+	modelTag, err := names.ParseModelTag(apiRec.ModelTag)
+	if err != nil {
+		return rec, errors.Annotate(err, "invalid model")
+	}
+	tag, err := names.ParseTag(apiRec.OriginName)
+	if err != nil {
+		return rec, errors.Annotate(err, "invalid origin")
+	}
+	ver, err := version.Parse(apiRec.JujuServerVersion)
+	if err != nil {
+		return rec, errors.Annotatef(err, "invalid version %q", apiRec.JujuServerVersion)
+	}
+	origin, err := logfwd.OriginForJuju(tag, controllerUUID, modelTag.Id(), ver)
+	if err != nil {
+		return rec, errors.Annotate(err, "could not extract origin")
+	}
+	rec.Origin = origin
+
+	if err := rec.Validate(); err != nil {
+		return rec, errors.Trace(err)
+	}
+	return rec, nil
+}
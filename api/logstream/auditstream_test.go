@@ -0,0 +1,160 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package logstream_test
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/api/logstream"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/logfwd"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/version"
+)
+
+type AuditStreamSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&AuditStreamSuite{})
+
+func (s *AuditStreamSuite) TestOpen(c *gc.C) {
+	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	conn.ReturnConnectStream = mockStream{stub: stub}
+	cfg := params.LogStreamConfig{
+		Sink: "spam",
+	}
+
+	_, err := logstream.OpenAuditStream(conn, cfg, cUUID)
+	c.Assert(err, gc.IsNil)
+
+	stub.CheckCallNames(c, "ConnectStream")
+	stub.CheckCall(c, 0, "ConnectStream", `/auditstream`, url.Values{
+		"sink": []string{"spam"},
+	})
+}
+
+func (s *AuditStreamSuite) TestOpenError(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	stub.SetErrors(errors.New("foo"))
+
+	_, err := logstream.OpenAuditStream(conn, params.LogStreamConfig{}, "")
+
+	c.Check(err, gc.ErrorMatches, "cannot connect to /auditstream: foo")
+	stub.CheckCallNames(c, "ConnectStream")
+}
+
+func (s *AuditStreamSuite) TestNextOneRecord(c *gc.C) {
+	ts := time.Now()
+	apiRecords := params.AuditStreamRecords{
+		Records: []params.AuditLogEntry{{
+			JujuServerVersion: version.Current.String(),
+			ModelTag:          "model-deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Timestamp:         ts,
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "user",
+			OriginName:        "user-bob",
+			Operation:         "Application:v4 - Deploy",
+			Data:              map[string]interface{}{"application": "mysql"},
+		}},
+	}
+	cUUID := "feebdaed-2f18-4fd2-967d-db9663db7bea"
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	jsonReader := mockStream{stub: stub}
+	auditCh := make(chan params.AuditStreamRecords, 1)
+	auditCh <- apiRecords
+	jsonReader.ReturnReadAuditJSON = auditCh
+	conn.ReturnConnectStream = jsonReader
+	stream, err := logstream.OpenAuditStream(conn, params.LogStreamConfig{}, cUUID)
+	c.Assert(err, gc.IsNil)
+	stub.ResetCalls()
+
+	var records []logfwd.Record
+	done := make(chan struct{})
+	go func() {
+		records, err = stream.Next()
+		c.Assert(err, jc.ErrorIsNil)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for record")
+	}
+	c.Assert(records, gc.HasLen, 1)
+	c.Check(records[0], jc.DeepEquals, logfwd.Record{
+		Origin: logfwd.Origin{
+			ControllerUUID: cUUID,
+			ModelUUID:      "deadbeef-2f18-4fd2-967d-db9663db7bea",
+			Type:           logfwd.OriginTypeUser,
+			Name:           "bob",
+			Software: logfwd.Software{
+				PrivateEnterpriseNumber: 28978,
+				Name:                    "juju",
+				Version:                 version.Current,
+			},
+		},
+		ID:        ts.UnixNano(),
+		Timestamp: ts,
+		Level:     loggo.INFO,
+		Message:   "Application:v4 - Deploy",
+		Audit: &logfwd.AuditDetails{
+			RemoteAddress: "10.0.0.1",
+			Operation:     "Application:v4 - Deploy",
+			Data:          map[string]interface{}{"application": "mysql"},
+		},
+	})
+	stub.CheckCallNames(c, "ReadJSON")
+}
+
+func (s *AuditStreamSuite) TestNextBadOrigin(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	jsonReader := mockStream{stub: stub}
+	auditCh := make(chan params.AuditStreamRecords, 1)
+	auditCh <- params.AuditStreamRecords{
+		Records: []params.AuditLogEntry{{
+			JujuServerVersion: version.Current.String(),
+			ModelTag:          "model-deadbeef-2f18-4fd2-967d-db9663db7bea",
+			OriginName:        "bob",
+			Operation:         "Application:v4 - Deploy",
+		}},
+	}
+	jsonReader.ReturnReadAuditJSON = auditCh
+	conn.ReturnConnectStream = jsonReader
+	stream, err := logstream.OpenAuditStream(conn, params.LogStreamConfig{}, "")
+	c.Assert(err, gc.IsNil)
+
+	_, err = stream.Next()
+	c.Assert(err, gc.ErrorMatches, `invalid origin: "bob" is not a valid tag`)
+}
+
+func (s *AuditStreamSuite) TestClose(c *gc.C) {
+	stub := &testing.Stub{}
+	conn := &mockConnector{stub: stub}
+	conn.ReturnConnectStream = mockStream{stub: stub}
+	stream, err := logstream.OpenAuditStream(conn, params.LogStreamConfig{}, "")
+	c.Assert(err, gc.IsNil)
+	stub.ResetCalls()
+
+	err = stream.Close()
+	c.Assert(err, jc.ErrorIsNil)
+	err = stream.Close() // idempotent
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = stream.Next()
+	c.Check(err, gc.ErrorMatches, `cannot read from closed stream`)
+	stub.CheckCallNames(c, "Close")
+}
@@ -208,7 +208,8 @@ type mockStream struct {
 	base.Stream
 	stub *testing.Stub
 
-	ReturnReadJSON chan params.LogStreamRecords
+	ReturnReadJSON      chan params.LogStreamRecords
+	ReturnReadAuditJSON chan params.AuditStreamRecords
 }
 
 func (s mockStream) ReadJSON(v interface{}) error {
@@ -221,6 +222,9 @@ func (s mockStream) ReadJSON(v interface{}) error {
 	case *params.LogStreamRecords:
 		*vt = <-s.ReturnReadJSON
 		return nil
+	case *params.AuditStreamRecords:
+		*vt = <-s.ReturnReadAuditJSON
+		return nil
 	default:
 		return errors.Errorf("unexpected output type: %T", v)
 	}
@@ -44,14 +44,37 @@ type Client struct {
 }
 
 func (c *Client) Prechecks(model coremigration.ModelInfo) error {
-	args := params.MigrationModelInfo{
+	args := modelInfoParams(model)
+	return c.caller.FacadeCall("Prechecks", args, nil)
+}
+
+// PrecheckReport runs all of the target controller's migration
+// prechecks for the model, including checking that the serialized
+// model could be imported, and returns every problem found. The
+// model is not imported.
+func (c *Client) PrecheckReport(model coremigration.ModelInfo, bytes []byte) ([]string, error) {
+	if c.caller.BestAPIVersion() < 2 {
+		return nil, errors.NotSupportedf("migration precheck report")
+	}
+	args := params.MigrationPrecheckArgs{
+		Model: modelInfoParams(model),
+		Bytes: bytes,
+	}
+	var result params.MigrationPrecheckReport
+	if err := c.caller.FacadeCall("PrecheckReport", args, &result); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return result.Failures, nil
+}
+
+func modelInfoParams(model coremigration.ModelInfo) params.MigrationModelInfo {
+	return params.MigrationModelInfo{
 		UUID:                   model.UUID,
 		Name:                   model.Name,
 		OwnerTag:               model.Owner.String(),
 		AgentVersion:           model.AgentVersion,
 		ControllerAgentVersion: model.ControllerAgentVersion,
 	}
-	return c.caller.FacadeCall("Prechecks", args, nil)
 }
 
 // Import takes a serialized model and imports it into the target
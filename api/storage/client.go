@@ -244,3 +244,137 @@ func (c *Client) Detach(storageIds []string) ([]params.ErrorResult, error) {
 	}
 	return results.Results, nil
 }
+
+// SnapshotStorage creates snapshots of the volumes backing the specified
+// storage entities.
+func (c *Client) SnapshotStorage(storageIds []string) ([]params.StorageSnapshotResult, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("snapshotting storage by this controller")
+	}
+	entities, err := storageEntities(storageIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := params.StorageSnapshotResults{}
+	if err := c.facade.FacadeCall("SnapshotStorage", params.Entities{entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// ListStorageSnapshots lists the snapshots of the volumes backing the
+// specified storage entities.
+func (c *Client) ListStorageSnapshots(storageIds []string) ([]params.StorageSnapshotsResult, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("listing storage snapshots by this controller")
+	}
+	entities, err := storageEntities(storageIds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := params.StorageSnapshotsResults{}
+	if err := c.facade.FacadeCall("ListStorageSnapshots", params.Entities{entities}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(storageIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(storageIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+// RemoveStorageSnapshots removes the specified snapshots of a storage
+// entity.
+func (c *Client) RemoveStorageSnapshots(storageId string, snapshotIds []string) ([]params.ErrorResult, error) {
+	if c.BestAPIVersion() < 5 {
+		return nil, errors.NotSupportedf("removing storage snapshots by this controller")
+	}
+	return c.storageSnapshotsCall("RemoveStorageSnapshots", storageId, snapshotIds)
+}
+
+// RestoreStorage replaces the volume backing the specified storage
+// entity with a new volume created from the specified snapshot.
+func (c *Client) RestoreStorage(storageId, snapshotId string) error {
+	if c.BestAPIVersion() < 5 {
+		return errors.NotSupportedf("restoring storage by this controller")
+	}
+	results, err := c.storageSnapshotsCall("RestoreStorage", storageId, []string{snapshotId})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if results[0].Error != nil {
+		return results[0].Error
+	}
+	return nil
+}
+
+// ResizeStorage requests that the volume backing the specified storage
+// entity be grown to the specified size, in MiB.
+func (c *Client) ResizeStorage(storageId string, size uint64) error {
+	if c.BestAPIVersion() < 6 {
+		return errors.NotSupportedf("resizing storage by this controller")
+	}
+	if !names.IsValidStorage(storageId) {
+		return errors.NotValidf("storage ID %q", storageId)
+	}
+	args := params.ResizeStorageArgs{
+		Args: []params.ResizeStorageArg{{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			Size:       size,
+		}},
+	}
+	var results params.ErrorResults
+	if err := c.facade.FacadeCall("ResizeStorage", args, &results); err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(results.Results))
+	}
+	if results.Results[0].Error != nil {
+		return results.Results[0].Error
+	}
+	return nil
+}
+
+func (c *Client) storageSnapshotsCall(method, storageId string, snapshotIds []string) ([]params.ErrorResult, error) {
+	if !names.IsValidStorage(storageId) {
+		return nil, errors.NotValidf("storage ID %q", storageId)
+	}
+	args := make([]params.StorageSnapshotId, len(snapshotIds))
+	for i, id := range snapshotIds {
+		args[i] = params.StorageSnapshotId{
+			StorageTag: names.NewStorageTag(storageId).String(),
+			SnapshotId: id,
+		}
+	}
+	results := params.ErrorResults{}
+	if err := c.facade.FacadeCall(method, params.StorageSnapshotIds{args}, &results); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results.Results) != len(snapshotIds) {
+		return nil, errors.Errorf(
+			"expected %d result(s), got %d",
+			len(snapshotIds), len(results.Results),
+		)
+	}
+	return results.Results, nil
+}
+
+func storageEntities(storageIds []string) ([]params.Entity, error) {
+	entities := make([]params.Entity, len(storageIds))
+	for i, id := range storageIds {
+		if !names.IsValidStorage(id) {
+			return nil, errors.NotValidf("storage ID %q", id)
+		}
+		entities[i].Tag = names.NewStorageTag(id).String()
+	}
+	return entities, nil
+}
@@ -771,3 +771,195 @@ func (s *storageMockSuite) TestAttachArityMismatch(c *gc.C) {
 	_, err := client.Attach("foo/0", []string{"bar/1", "baz/2"})
 	c.Check(err, gc.ErrorMatches, `expected 2 result\(s\), got 3`)
 }
+
+func (s *storageMockSuite) TestSnapshotStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(objType, gc.Equals, "Storage")
+				c.Check(id, gc.Equals, "")
+				c.Check(request, gc.Equals, "SnapshotStorage")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+					{Tag: "storage-bar-1"},
+				}})
+				c.Assert(result, gc.FitsTypeOf, &params.StorageSnapshotResults{})
+				results := result.(*params.StorageSnapshotResults)
+				results.Results = []params.StorageSnapshotResult{
+					{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.SnapshotStorage([]string{"foo/0", "bar/1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StorageSnapshotResult{
+		{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+		{Error: &params.Error{Message: "baz"}},
+	})
+}
+
+func (s *storageMockSuite) TestSnapshotStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 4,
+	}
+	client := storage.NewClient(apiCaller)
+	_, err := client.SnapshotStorage([]string{"foo/0"})
+	c.Check(err, gc.ErrorMatches, "snapshotting storage by this controller not supported")
+}
+
+func (s *storageMockSuite) TestListStorageSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "ListStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.Entities{[]params.Entity{
+					{Tag: "storage-foo-0"},
+				}})
+				results := result.(*params.StorageSnapshotsResults)
+				results.Results = []params.StorageSnapshotsResult{{
+					Result: []params.StorageSnapshot{{SnapshotId: "snap-0"}},
+				}}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.ListStorageSnapshots([]string{"foo/0"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{SnapshotId: "snap-0"}},
+	}})
+}
+
+func (s *storageMockSuite) TestRemoveStorageSnapshots(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "RemoveStorageSnapshots")
+				c.Check(a, jc.DeepEquals, params.StorageSnapshotIds{[]params.StorageSnapshotId{
+					{StorageTag: "storage-foo-0", SnapshotId: "snap-0"},
+					{StorageTag: "storage-foo-0", SnapshotId: "snap-1"},
+				}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{},
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	results, err := client.RemoveStorageSnapshots("foo/0", []string{"snap-0", "snap-1"})
+	c.Check(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.IsNil)
+	c.Assert(results[1].Error, jc.DeepEquals, &params.Error{Message: "baz"})
+}
+
+func (s *storageMockSuite) TestRestoreStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "RestoreStorage")
+				c.Check(a, jc.DeepEquals, params.StorageSnapshotIds{[]params.StorageSnapshotId{
+					{StorageTag: "storage-foo-0", SnapshotId: "snap-0"},
+				}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RestoreStorage("foo/0", "snap-0")
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestRestoreStorageInvalidId(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.RestoreStorage("foo", "snap-0")
+	c.Check(err, gc.ErrorMatches, `storage ID "foo" not valid`)
+}
+
+func (s *storageMockSuite) TestResizeStorage(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(objType string,
+				version int,
+				id, request string,
+				a, result interface{},
+			) error {
+				c.Check(request, gc.Equals, "ResizeStorage")
+				c.Check(a, jc.DeepEquals, params.ResizeStorageArgs{[]params.ResizeStorageArg{
+					{StorageTag: "storage-foo-0", Size: 2048},
+				}})
+				results := result.(*params.ErrorResults)
+				results.Results = []params.ErrorResult{
+					{Error: &params.Error{Message: "baz"}},
+				}
+				return nil
+			},
+		),
+		BestVersion: 6,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "baz")
+}
+
+func (s *storageMockSuite) TestResizeStorageNotSupported(c *gc.C) {
+	apiCaller := basetesting.BestVersionCaller{
+		APICallerFunc: basetesting.APICallerFunc(
+			func(string, int, string, string, interface{}, interface{}) error {
+				c.Fatalf("unexpected API call")
+				return nil
+			},
+		),
+		BestVersion: 5,
+	}
+	client := storage.NewClient(apiCaller)
+	err := client.ResizeStorage("foo/0", 2048)
+	c.Check(err, gc.ErrorMatches, "resizing storage by this controller not supported")
+}
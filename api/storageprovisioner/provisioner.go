@@ -87,6 +87,17 @@ func (st *State) WatchFilesystems() (watcher.StringsWatcher, error) {
 	return st.watchStorageEntities("WatchFilesystems")
 }
 
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resizes
+// may be processed. An error satisfying errors.IsNotSupported is
+// returned if the controller does not support resizing volumes.
+func (st *State) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	return st.watchStorageEntities("WatchVolumeResizes")
+}
+
 func (st *State) watchStorageEntities(method string) (watcher.StringsWatcher, error) {
 	var results params.StringsWatchResults
 	args := params.Entities{
@@ -243,6 +254,29 @@ func (st *State) VolumeParams(tags []names.VolumeTag) ([]params.VolumeParamsResu
 	return results.Results, nil
 }
 
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags.
+func (st *State) VolumeResizeParams(tags []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.VolumeResizeParamsResults
+	err := st.facade.FacadeCall("VolumeResizeParams", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // FilesystemParams returns the parameters for creating the filesystems
 // with the specified tags.
 func (st *State) FilesystemParams(tags []names.FilesystemTag) ([]params.FilesystemParamsResult, error) {
@@ -307,6 +341,56 @@ func (st *State) SetVolumeInfo(volumes []params.Volume) ([]params.ErrorResult, e
 	return results.Results, nil
 }
 
+// SetVolumeSizes records the sizes of resized volumes.
+func (st *State) SetVolumeSizes(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+	return st.setSizes("SetVolumeSizes", sizes)
+}
+
+// CancelVolumeResizes clears the pending resizes of the volumes with
+// the specified tags, which cannot be carried out.
+func (st *State) CancelVolumeResizes(tags []names.VolumeTag) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing volumes")
+	}
+	args := params.Entities{
+		Entities: make([]params.Entity, len(tags)),
+	}
+	for i, tag := range tags {
+		args.Entities[i].Tag = tag.String()
+	}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall("CancelVolumeResizes", args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(tags) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(tags), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
+// SetFilesystemSizes records the sizes of filesystems that have been
+// grown to fill their resized backing volumes.
+func (st *State) SetFilesystemSizes(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+	return st.setSizes("SetFilesystemSizes", sizes)
+}
+
+func (st *State) setSizes(method string, sizes []params.EntitySize) ([]params.ErrorResult, error) {
+	if st.facade.BestAPIVersion() < 4 {
+		return nil, errors.NotSupportedf("resizing storage")
+	}
+	args := params.EntitySizes{Sizes: sizes}
+	var results params.ErrorResults
+	err := st.facade.FacadeCall(method, args, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results.Results) != len(sizes) {
+		panic(errors.Errorf("expected %d result(s), got %d", len(sizes), len(results.Results)))
+	}
+	return results.Results, nil
+}
+
 // SetFilesystemInfo records the details of newly provisioned filesystems.
 func (st *State) SetFilesystemInfo(filesystems []params.Filesystem) ([]params.ErrorResult, error) {
 	args := params.Filesystems{Filesystems: filesystems}
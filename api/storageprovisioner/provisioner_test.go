@@ -914,3 +914,154 @@ func (s *provisionerSuite) TestLifeServerError(c *gc.C) {
 	c.Assert(results, gc.HasLen, 1)
 	c.Check(results[0].Error, gc.ErrorMatches, "MSG")
 }
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "WatchVolumeResizes")
+			c.Assert(result, gc.FitsTypeOf, &params.StringsWatchResults{})
+			*(result.(*params.StringsWatchResults)) = params.StringsWatchResults{
+				Results: []params.StringsWatchResult{{
+					Error: &params.Error{Message: "FAIL"},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Check(err, gc.ErrorMatches, "FAIL")
+	c.Check(callCount, gc.Equals, 1)
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizesNotSupported(c *gc.C) {
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: nullAPICaller,
+		BestVersion:   3,
+	}
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = st.WatchVolumeResizes()
+	c.Check(err, gc.ErrorMatches, "resizing volumes not supported")
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "VolumeResizeParams")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.VolumeResizeParamsResults{})
+			*(result.(*params.VolumeResizeParamsResults)) = params.VolumeResizeParamsResults{
+				Results: []params.VolumeResizeParamsResult{{
+					Result: params.VolumeResizeParams{
+						VolumeTag: "volume-100",
+						VolumeId:  "vol-100",
+						Size:      2048,
+						Provider:  "loop",
+					},
+				}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.VolumeResizeParams([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, jc.DeepEquals, []params.VolumeResizeParamsResult{{
+		Result: params.VolumeResizeParams{
+			VolumeTag: "volume-100",
+			VolumeId:  "vol-100",
+			Size:      2048,
+			Provider:  "loop",
+		},
+	}})
+}
+
+func (s *provisionerSuite) TestCancelVolumeResizes(c *gc.C) {
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, "CancelVolumeResizes")
+			c.Check(arg, gc.DeepEquals, params.Entities{Entities: []params.Entity{{"volume-100"}}})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: &params.Error{Message: "FAIL"}}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	results, err := st.CancelVolumeResizes([]names.VolumeTag{names.NewVolumeTag("100")})
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Assert(results, gc.HasLen, 1)
+	c.Check(results[0].Error, gc.ErrorMatches, "FAIL")
+}
+
+func (s *provisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	s.testSetSizes(c, "SetVolumeSizes", func(st *storageprovisioner.State, sizes []params.EntitySize) ([]params.ErrorResult, error) {
+		return st.SetVolumeSizes(sizes)
+	})
+}
+
+func (s *provisionerSuite) TestSetFilesystemSizes(c *gc.C) {
+	s.testSetSizes(c, "SetFilesystemSizes", func(st *storageprovisioner.State, sizes []params.EntitySize) ([]params.ErrorResult, error) {
+		return st.SetFilesystemSizes(sizes)
+	})
+}
+
+func (s *provisionerSuite) testSetSizes(
+	c *gc.C, method string,
+	setSizes func(*storageprovisioner.State, []params.EntitySize) ([]params.ErrorResult, error),
+) {
+	sizes := []params.EntitySize{{Tag: "volume-100", Size: 2048}}
+	var callCount int
+	apiCaller := testing.BestVersionCaller{
+		APICallerFunc: testing.APICallerFunc(func(objType string, version int, id, request string, arg, result interface{}) error {
+			c.Check(objType, gc.Equals, "StorageProvisioner")
+			c.Check(version, gc.Equals, 4)
+			c.Check(id, gc.Equals, "")
+			c.Check(request, gc.Equals, method)
+			c.Check(arg, gc.DeepEquals, params.EntitySizes{Sizes: sizes})
+			c.Assert(result, gc.FitsTypeOf, &params.ErrorResults{})
+			*(result.(*params.ErrorResults)) = params.ErrorResults{
+				Results: []params.ErrorResult{{Error: nil}},
+			}
+			callCount++
+			return nil
+		}),
+		BestVersion: 4,
+	}
+
+	st, err := storageprovisioner.NewState(apiCaller, names.NewMachineTag("123"))
+	c.Assert(err, jc.ErrorIsNil)
+	errorResults, err := setSizes(st, sizes)
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(callCount, gc.Equals, 1)
+	c.Check(errorResults, gc.HasLen, 1)
+	c.Check(errorResults[0].Error, gc.IsNil)
+}
@@ -122,3 +122,20 @@ func (s *actionSuite) TestActionFail(c *gc.C) {
 	c.Assert(res, gc.DeepEquals, map[string]interface{}{})
 	c.Assert(completed[0].Name(), gc.Equals, "fakeaction")
 }
+
+func (s *actionSuite) TestLogActionMessage(c *gc.C) {
+	action, err := s.uniterSuite.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.uniter.ActionBegin(action.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.uniter.LogActionMessage(action.ActionTag(), "halfway there")
+	c.Assert(err, jc.ErrorIsNil)
+
+	running, err := s.uniterSuite.wordpressUnit.RunningActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(running, gc.HasLen, 1)
+	messages := running[0].Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "halfway there")
+}
@@ -276,6 +276,31 @@ func (st *State) ActionFinish(tag names.ActionTag, status string, results map[st
 	return nil
 }
 
+// LogActionMessage logs a progress message for the specified action.
+func (st *State) LogActionMessage(tag names.ActionTag, message string) error {
+	if st.BestAPIVersion() < 7 {
+		return errors.NotSupportedf("LogActionMessage")
+	}
+	var outcome params.ErrorResults
+	args := params.ActionMessageParams{
+		Messages: []params.ActionMessageArg{{
+			ActionTag: tag.String(),
+			Message:   message,
+		}},
+	}
+	err := st.facade.FacadeCall("LogActionsMessages", args, &outcome)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(outcome.Results) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(outcome.Results))
+	}
+	if result := outcome.Results[0]; result.Error != nil {
+		return result.Error
+	}
+	return nil
+}
+
 // RelationById returns the existing relation with the given id.
 func (st *State) RelationById(id int) (*Relation, error) {
 	var results params.RelationResults
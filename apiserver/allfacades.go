@@ -39,6 +39,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/annotations" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/application" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/applicationoffers"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
 	"github.com/juju/juju/apiserver/facades/client/backups" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/block"   // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/bundle"
@@ -46,6 +47,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/client"           // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/cloud"            // ModelUser Read
 	"github.com/juju/juju/apiserver/facades/client/controller"       // ModelUser Admin (although some methods check for read only)
+	"github.com/juju/juju/apiserver/facades/client/firewallrules"    // ModelUser Admin (although ListFirewallRules checks for read only)
 	"github.com/juju/juju/apiserver/facades/client/highavailability" // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemanager"     // ModelUser Write
 	"github.com/juju/juju/apiserver/facades/client/imagemetadatamanager"
@@ -61,6 +63,8 @@ import (
 	"github.com/juju/juju/apiserver/facades/client/storage"
 	"github.com/juju/juju/apiserver/facades/client/subnets"
 	"github.com/juju/juju/apiserver/facades/client/usermanager"
+	"github.com/juju/juju/apiserver/facades/controller/actionpruner"
+	"github.com/juju/juju/apiserver/facades/controller/actionscheduler"
 	"github.com/juju/juju/apiserver/facades/controller/agenttools"
 	"github.com/juju/juju/apiserver/facades/controller/applicationscaler"
 	"github.com/juju/juju/apiserver/facades/controller/charmrevisionupdater"
@@ -114,6 +118,9 @@ func AllFacades() *facade.Registry {
 	}
 
 	reg("Action", 2, action.NewActionAPI)
+	reg("Action", 3, action.NewActionAPI) // adds scheduled actions
+	reg("ActionPruner", 1, actionpruner.NewAPI)
+	reg("ActionScheduler", 1, actionscheduler.NewAPI)
 	reg("Agent", 2, agent.NewAgentAPIV2)
 	reg("AgentTools", 1, agenttools.NewFacade)
 	reg("Annotations", 2, annotations.NewAPI)
@@ -123,20 +130,32 @@ func AllFacades() *facade.Registry {
 	reg("Application", 3, application.NewFacade)
 	reg("Application", 4, application.NewFacade)
 	reg("Application", 5, application.NewFacade) // adds AttachStorage
+	reg("Application", 6, application.NewFacade) // adds rolling charm upgrades
+	reg("Application", 7, application.NewFacade) // adds expose settings
+	reg("Application", 8, application.NewFacade) // adds egress rules
 
 	reg("ApplicationScaler", 1, applicationscaler.NewAPI)
+	reg("AuditLog", 1, auditlog.NewFacade)
 	reg("Backups", 1, backups.NewFacade)
+	reg("Backups", 2, backups.NewFacade) // adds encryption and upload to Create, decryption to Restore
 	reg("Block", 2, block.NewAPI)
-	reg("Bundle", 1, bundle.NewFacade)
+	reg("Bundle", 1, bundle.NewFacadeV1)
+	reg("Bundle", 2, bundle.NewFacadeV2) // adds ExportBundle
 	reg("CharmRevisionUpdater", 2, charmrevisionupdater.NewCharmRevisionUpdaterAPI)
 	reg("Charms", 2, charms.NewFacade)
 	reg("Cleaner", 2, cleaner.NewCleanerAPI)
 	reg("Client", 1, client.NewFacade)
 	reg("Cloud", 1, cloud.NewFacade)
 	reg("Controller", 3, controller.NewControllerAPI)
+	reg("Controller", 4, controller.NewControllerAPI) // adds ScheduledBackupStatus
+	reg("Controller", 5, controller.NewControllerAPI) // adds PrecheckMigration
 	reg("Deployer", 1, deployer.NewDeployerAPI)
 	reg("DiskManager", 2, diskmanager.NewDiskManagerAPI)
+	reg("FirewallRules", 1, firewallrules.NewFacade)
 	reg("Firewaller", 3, firewaller.NewStateFirewallerAPIV3)
+	reg("Firewaller", 5, firewaller.NewStateFirewallerAPIV5) // adds GetExposeInfo
+	reg("Firewaller", 6, firewaller.NewStateFirewallerAPIV6) // adds model firewall rules
+	reg("Firewaller", 7, firewaller.NewStateFirewallerAPIV7) // adds egress rules
 	reg("HighAvailability", 2, highavailability.NewHighAvailabilityAPI)
 	reg("HostKeyReporter", 1, hostkeyreporter.NewFacade)
 	reg("ImageManager", 2, imagemanager.NewImageManagerAPI)
@@ -170,6 +189,7 @@ func AllFacades() *facade.Registry {
 	reg("MigrationMaster", 1, migrationmaster.NewFacade)
 	reg("MigrationMinion", 1, migrationminion.NewFacade)
 	reg("MigrationTarget", 1, migrationtarget.NewFacade)
+	reg("MigrationTarget", 2, migrationtarget.NewFacade) // adds PrecheckReport
 
 	reg("ModelConfig", 1, modelconfig.NewFacade)
 	reg("ModelManager", 2, modelmanager.NewFacadeV2)
@@ -209,15 +229,19 @@ func AllFacades() *facade.Registry {
 
 	reg("Storage", 3, storage.NewFacadeV3)
 	reg("Storage", 4, storage.NewFacadeV4) // changes Destroy() method signature.
+	reg("Storage", 5, storage.NewFacadeV5) // adds storage snapshot methods.
+	reg("Storage", 6, storage.NewFacadeV6) // adds ResizeStorage.
 
 	reg("StorageProvisioner", 3, storageprovisioner.NewFacade)
+	reg("StorageProvisioner", 4, storageprovisioner.NewFacadeV4) // adds volume and filesystem resizing.
 	reg("Subnets", 2, subnets.NewAPI)
 	reg("Undertaker", 1, undertaker.NewUndertakerAPI)
 	reg("UnitAssigner", 1, unitassigner.New)
 
 	reg("Uniter", 4, uniter.NewUniterAPIV4)
 	reg("Uniter", 5, uniter.NewUniterAPIV5)
-	reg("Uniter", 6, uniter.NewUniterAPI)
+	reg("Uniter", 6, uniter.NewUniterAPIV6)
+	reg("Uniter", 7, uniter.NewUniterAPI) // adds LogActionsMessages
 
 	reg("Upgrader", 1, upgrader.NewUpgraderFacade)
 	reg("UserManager", 1, usermanager.NewUserManagerAPI)
@@ -611,6 +611,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 
 	mainAPIHandler := srv.trackRequests(http.HandlerFunc(srv.apiHandler))
 	logStreamHandler := srv.trackRequests(newLogStreamEndpointHandler(httpCtxt))
+	auditStreamHandler := srv.trackRequests(newAuditStreamEndpointHandler(httpCtxt))
 	debugLogHandler := srv.trackRequests(newDebugLogDBHandler(httpCtxt))
 	pubsubHandler := srv.trackRequests(newPubSubHandler(httpCtxt, srv.centralHub))
 
@@ -620,6 +621,7 @@ func (srv *Server) endpoints() []apihttp.Endpoint {
 	// /model/:modeluuid namespace.
 	add("/model/:modeluuid/pubsub", pubsubHandler)
 	add("/model/:modeluuid/logstream", logStreamHandler)
+	add("/model/:modeluuid/auditstream", auditStreamHandler)
 	add("/model/:modeluuid/log", debugLogHandler)
 
 	logSinkHandler := logsink.NewHTTPHandler(
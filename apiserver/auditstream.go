@@ -0,0 +1,181 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/schema"
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/apiserver/websocket"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+type auditStreamSource interface {
+	getStart(sink string) (time.Time, error)
+	newTailer(state.AuditTailerParams) (state.AuditTailer, error)
+}
+
+// auditStreamEndpointHandler takes requests to stream a model's audit
+// entries from the DB.
+type auditStreamEndpointHandler struct {
+	stopCh    <-chan struct{}
+	newSource func(*http.Request) (auditStreamSource, state.StatePoolReleaser, error)
+}
+
+func newAuditStreamEndpointHandler(ctxt httpContext) *auditStreamEndpointHandler {
+	newSource := func(req *http.Request) (auditStreamSource, state.StatePoolReleaser, error) {
+		st, releaser, _, err := ctxt.stateForRequestAuthenticatedAgent(req)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		return &auditStreamState{logStreamState{st}}, releaser, nil
+	}
+	return &auditStreamEndpointHandler{
+		stopCh:    ctxt.stop(),
+		newSource: newSource,
+	}
+}
+
+// ServeHTTP will serve up connections as a websocket for the
+// auditstream API.
+//
+// Args for the HTTP request are as follows:
+//
+//	sink -> string - the name of the the audit forwarding target
+//	maxlookbackduration -> string - the maximum age of entries to stream
+func (h *auditStreamEndpointHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	logger.Infof("audit stream request handler starting")
+	handler := func(conn *websocket.Conn) {
+		defer conn.Close()
+		reqHandler, err := h.newAuditStreamRequestHandler(conn, req, clock.WallClock)
+		if err != nil {
+			sendStreamError(conn, req, err)
+			return
+		}
+		defer reqHandler.close()
+
+		// If we get to here, no more errors to report, so we report a nil
+		// error.  This way the first line of the connection is always a json
+		// formatted simple error.
+		sendStreamError(conn, req, nil)
+		reqHandler.serveWebsocket(h.stopCh)
+	}
+	websocket.Serve(w, req, handler)
+}
+
+func (h *auditStreamEndpointHandler) newAuditStreamRequestHandler(conn messageWriter, req *http.Request, clock clock.Clock) (rh *auditStreamRequestHandler, err error) {
+	source, releaser, err := h.newSource(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			releaser()
+		}
+	}()
+
+	var cfg params.LogStreamConfig
+	query := req.URL.Query()
+	query.Del(":modeluuid")
+	if err := schema.NewDecoder().Decode(&cfg, query); err != nil {
+		return nil, errors.Annotate(err, "decoding schema")
+	}
+
+	start, err := source.getStart(cfg.Sink)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting audit start position")
+	}
+	if cfg.MaxLookbackDuration != "" {
+		d, err := time.ParseDuration(cfg.MaxLookbackDuration)
+		if err != nil {
+			return nil, errors.Annotatef(err, "invalid lookback duration")
+		}
+		now := clock.Now()
+		if now.Sub(start) > d {
+			start = now.Add(-1 * d)
+		}
+	}
+	tailer, err := source.newTailer(state.AuditTailerParams{StartTime: start})
+	if err != nil {
+		return nil, errors.Annotate(err, "tailing audit entries")
+	}
+
+	return &auditStreamRequestHandler{
+		conn:     conn,
+		tailer:   tailer,
+		releaser: releaser,
+	}, nil
+}
+
+// auditStreamState is an implementation of auditStreamSource. The
+// position of the audit stream for each sink is tracked in the same
+// way as the log stream's.
+type auditStreamState struct {
+	logStreamState
+}
+
+func (st auditStreamState) newTailer(args state.AuditTailerParams) (state.AuditTailer, error) {
+	tailer, err := state.NewAuditTailer(st, args)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return tailer, nil
+}
+
+type auditStreamRequestHandler struct {
+	conn     messageWriter
+	tailer   state.AuditTailer
+	releaser state.StatePoolReleaser
+}
+
+func (h *auditStreamRequestHandler) serveWebsocket(stop <-chan struct{}) {
+	logger.Infof("audit stream request handler starting")
+
+	for {
+		select {
+		case <-stop:
+			return
+		case entry, ok := <-h.tailer.Entries():
+			if !ok {
+				logger.Errorf("audit tailer stopped: %v", h.tailer.Err())
+				return
+			}
+			records := params.AuditStreamRecords{
+				Records: []params.AuditLogEntry{apiFromAuditEntry(entry)},
+			}
+			if err := h.conn.WriteJSON(records); err != nil {
+				if isBrokenPipe(err) {
+					logger.Tracef("auditstream handler stopped (client disconnected)")
+				} else {
+					logger.Errorf("auditstream handler error: %v", err)
+				}
+			}
+		}
+	}
+}
+
+func (h *auditStreamRequestHandler) close() {
+	h.tailer.Stop()
+	h.releaser()
+}
+
+func apiFromAuditEntry(entry audit.AuditEntry) params.AuditLogEntry {
+	return params.AuditLogEntry{
+		JujuServerVersion: entry.JujuServerVersion.String(),
+		ModelTag:          names.NewModelTag(entry.ModelUUID).String(),
+		Timestamp:         entry.Timestamp,
+		RemoteAddress:     entry.RemoteAddress,
+		OriginType:        entry.OriginType,
+		OriginName:        entry.OriginName,
+		Operation:         entry.Operation,
+		Data:              entry.Data,
+	}
+}
@@ -0,0 +1,142 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package apiserver
+
+import (
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/go-querystring/query"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/clock"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type AuditStreamIntSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&AuditStreamIntSuite{})
+
+func (s *AuditStreamIntSuite) TestParamConversion(c *gc.C) {
+	req := s.newReq(c, params.LogStreamConfig{
+		Sink: "spam",
+	})
+
+	stub := &testing.Stub{}
+	source := &stubAuditSource{stub: stub, ReturnGetStart: 10}
+	handler := auditStreamEndpointHandler{
+		newSource: source.newSource,
+	}
+
+	_, err := handler.newAuditStreamRequestHandler(nil, req, clock.WallClock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCallNames(c, "newSource", "getStart", "newTailer")
+	stub.CheckCall(c, 1, "getStart", "spam")
+	stub.CheckCall(c, 2, "newTailer", state.AuditTailerParams{
+		StartTime: time.Unix(10, 0),
+	})
+}
+
+func (s *AuditStreamIntSuite) TestParamStartTruncate(c *gc.C) {
+	req := s.newReq(c, params.LogStreamConfig{
+		Sink:                "spam",
+		MaxLookbackDuration: "2h",
+	})
+
+	stub := &testing.Stub{}
+	source := &stubAuditSource{stub: stub}
+	handler := auditStreamEndpointHandler{
+		newSource: source.newSource,
+	}
+
+	clock := &mockClock{now: time.Date(2016, 10, 1, 12, 0, 0, 0, time.UTC)}
+	_, err := handler.newAuditStreamRequestHandler(nil, req, clock)
+	c.Assert(err, jc.ErrorIsNil)
+
+	stub.CheckCall(c, 2, "newTailer", state.AuditTailerParams{
+		StartTime: time.Date(2016, 10, 1, 10, 0, 0, 0, time.UTC),
+	})
+}
+
+func (s *AuditStreamIntSuite) TestAPIFromAuditEntry(c *gc.C) {
+	timestamp := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	record := apiFromAuditEntry(audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         timestamp,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "user",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	})
+	c.Assert(record, jc.DeepEquals, params.AuditLogEntry{
+		JujuServerVersion: "2.3.0",
+		ModelTag:          coretesting.ModelTag.String(),
+		Timestamp:         timestamp,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "user",
+		OriginName:        "user-bob",
+		Operation:         "Application:v4 - Deploy",
+	})
+}
+
+func (s *AuditStreamIntSuite) newReq(c *gc.C, cfg params.LogStreamConfig) *http.Request {
+	attrs, err := query.Values(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	URL, err := url.Parse("https://a.b.c/auditstream")
+	c.Assert(err, jc.ErrorIsNil)
+	URL.RawQuery = attrs.Encode()
+	req, err := http.NewRequest("GET", URL.String(), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	return req
+}
+
+type stubAuditSource struct {
+	stub *testing.Stub
+
+	ReturnGetStart  int64
+	ReturnNewTailer state.AuditTailer
+}
+
+func (s *stubAuditSource) newSource(req *http.Request) (auditStreamSource, state.StatePoolReleaser, error) {
+	s.stub.AddCall("newSource", req)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
+	closer := func() bool {
+		s.stub.AddCall("close")
+		return false
+	}
+	return s, closer, nil
+}
+
+func (s *stubAuditSource) getStart(sink string) (time.Time, error) {
+	s.stub.AddCall("getStart", sink)
+	if err := s.stub.NextErr(); err != nil {
+		return time.Time{}, errors.Trace(err)
+	}
+
+	return time.Unix(s.ReturnGetStart, 0), nil
+}
+
+func (s *stubAuditSource) newTailer(args state.AuditTailerParams) (state.AuditTailer, error) {
+	s.stub.AddCall("newTailer", args)
+	if err := s.stub.NextErr(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return s.ReturnNewTailer, nil
+}
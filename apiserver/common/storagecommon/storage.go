@@ -47,6 +47,10 @@ type StorageInterface interface {
 	// attachment corresponding to the identfified machine and filesystem.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
+	// WatchFilesystem watches for changes to the filesystem with the
+	// specified tag, such as changes to its size.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchVolumeAttachment watches for changes to the volume attachment
 	// corresponding to the identfified machine and volume.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
@@ -144,6 +148,7 @@ func volumeStorageAttachmentInfo(
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindBlock,
 		devicePath,
+		blockDevice.Size,
 	}, nil
 }
 
@@ -171,9 +176,18 @@ func filesystemStorageAttachmentInfo(
 	if err != nil {
 		return nil, errors.Annotate(err, "getting filesystem attachment info")
 	}
+	// The filesystem's size is informational, so we do
+	// not fail if the filesystem info is not available.
+	var size uint64
+	if filesystemInfo, err := filesystem.Info(); err == nil {
+		size = filesystemInfo.Size
+	} else if !errors.IsNotProvisioned(err) {
+		return nil, errors.Annotate(err, "getting filesystem info")
+	}
 	return &storage.StorageAttachmentInfo{
 		storage.StorageKindFilesystem,
 		filesystemAttachmentInfo.MountPoint,
+		size,
 	}, nil
 }
 
@@ -216,8 +230,12 @@ func WatchStorageAttachment(
 		if err != nil {
 			return nil, errors.Annotate(err, "getting storage filesystem")
 		}
+		// We need to watch both the filesystem attachment, and
+		// the filesystem itself, so that we observe the filesystem
+		// being grown after its backing volume is resized.
 		watchers = []state.NotifyWatcher{
 			st.WatchFilesystemAttachment(machineTag, filesystem.FilesystemTag()),
+			st.WatchFilesystem(filesystem.FilesystemTag()),
 		}
 	default:
 		return nil, errors.Errorf("invalid storage kind %v", storageInstance.Kind())
@@ -270,9 +288,9 @@ func MaybeAssignedStorageInstance(
 	return nil, errors.Trace(err)
 }
 
-// storageTags returns the tags that should be set on a volume or filesystem,
+// StorageTags returns the tags that should be set on a volume or filesystem,
 // if the provider supports them.
-func storageTags(
+func StorageTags(
 	storageInstance state.StorageInstance,
 	modelUUID, controllerUUID string,
 	tagger tags.ResourceTagger,
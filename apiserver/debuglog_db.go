@@ -7,6 +7,7 @@ import (
 	"net/http"
 
 	"github.com/juju/errors"
+	"github.com/juju/version"
 
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/state"
@@ -56,14 +57,16 @@ func handleDebugLogDBRequest(
 
 func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 	params := state.LogTailerParams{
-		MinLevel:      reqParams.filterLevel,
-		NoTail:        reqParams.noTail,
-		StartTime:     reqParams.startTime,
-		InitialLines:  int(reqParams.backlog),
-		IncludeEntity: reqParams.includeEntity,
-		ExcludeEntity: reqParams.excludeEntity,
-		IncludeModule: reqParams.includeModule,
-		ExcludeModule: reqParams.excludeModule,
+		MinLevel:       reqParams.filterLevel,
+		NoTail:         reqParams.noTail,
+		StartTime:      reqParams.startTime,
+		EndTime:        reqParams.endTime,
+		InitialLines:   int(reqParams.backlog),
+		IncludeEntity:  reqParams.includeEntity,
+		ExcludeEntity:  reqParams.excludeEntity,
+		IncludeModule:  reqParams.includeModule,
+		ExcludeModule:  reqParams.excludeModule,
+		MessagePattern: reqParams.messagePattern,
 	}
 	if reqParams.fromTheStart {
 		params.InitialLines = 0
@@ -72,7 +75,14 @@ func makeLogTailerParams(reqParams debugLogParams) state.LogTailerParams {
 }
 
 func formatLogRecord(r *state.LogRecord) *params.LogMessage {
+	var ver string
+	if r.Version != version.Zero {
+		ver = r.Version.String()
+	}
 	return &params.LogMessage{
+		ID:        r.ID,
+		ModelUUID: r.ModelUUID,
+		Version:   ver,
 		Entity:    r.Entity.String(),
 		Timestamp: r.Time,
 		Severity:  r.Level.String(),
@@ -5,6 +5,7 @@ package apiserver
 
 import (
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/juju/loggo"
@@ -31,16 +32,19 @@ func (s *debugLogDBIntSuite) SetUpTest(c *gc.C) {
 
 func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	t1 := time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC)
+	t2 := time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC)
 	reqParams := debugLogParams{
-		fromTheStart:  false,
-		noTail:        true,
-		backlog:       11,
-		startTime:     t1,
-		filterLevel:   loggo.INFO,
-		includeEntity: []string{"foo"},
-		includeModule: []string{"bar"},
-		excludeEntity: []string{"baz"},
-		excludeModule: []string{"qux"},
+		fromTheStart:   false,
+		noTail:         true,
+		backlog:        11,
+		startTime:      t1,
+		endTime:        t2,
+		filterLevel:    loggo.INFO,
+		includeEntity:  []string{"foo"},
+		includeModule:  []string{"bar"},
+		excludeEntity:  []string{"baz"},
+		excludeModule:  []string{"qux"},
+		messagePattern: "hook .* failed",
 	}
 
 	called := false
@@ -50,6 +54,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		// Start time will be used once the client is extended to send
 		// time range arguments.
 		c.Assert(params.StartTime, gc.Equals, t1)
+		c.Assert(params.EndTime, gc.Equals, t2)
 		c.Assert(params.NoTail, jc.IsTrue)
 		c.Assert(params.MinLevel, gc.Equals, loggo.INFO)
 		c.Assert(params.InitialLines, gc.Equals, 11)
@@ -57,6 +62,7 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 		c.Assert(params.IncludeModule, jc.DeepEquals, []string{"bar"})
 		c.Assert(params.ExcludeEntity, jc.DeepEquals, []string{"baz"})
 		c.Assert(params.ExcludeModule, jc.DeepEquals, []string{"qux"})
+		c.Assert(params.MessagePattern, gc.Equals, "hook .* failed")
 
 		return newFakeLogTailer(), nil
 	})
@@ -68,6 +74,26 @@ func (s *debugLogDBIntSuite) TestParamConversion(c *gc.C) {
 	c.Assert(called, jc.IsTrue)
 }
 
+func (s *debugLogDBIntSuite) TestReadParamsTimeWindowAndPattern(c *gc.C) {
+	params, err := readDebugLogParams(url.Values{
+		"startTime":      {"2016-11-30T10:51:00Z"},
+		"endTime":        {"2016-11-30T11:51:00Z"},
+		"messagePattern": {"hook .* failed"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(params.startTime, gc.Equals, time.Date(2016, 11, 30, 10, 51, 0, 0, time.UTC))
+	c.Assert(params.endTime, gc.Equals, time.Date(2016, 11, 30, 11, 51, 0, 0, time.UTC))
+	c.Assert(params.messagePattern, gc.Equals, "hook .* failed")
+}
+
+func (s *debugLogDBIntSuite) TestReadParamsInvalid(c *gc.C) {
+	_, err := readDebugLogParams(url.Values{"endTime": {"yesterday"}})
+	c.Assert(err, gc.ErrorMatches, `end time "yesterday" is not a valid time in RFC3339 format`)
+
+	_, err = readDebugLogParams(url.Values{"messagePattern": {"hook ("}})
+	c.Assert(err, gc.ErrorMatches, `message pattern "hook \(" is not a valid regular expression`)
+}
+
 func (s *debugLogDBIntSuite) TestParamConversionReplay(c *gc.C) {
 	reqParams := debugLogParams{
 		fromTheStart: true,
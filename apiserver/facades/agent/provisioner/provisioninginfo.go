@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/common/storagecommon"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cloudconfig/instancecfg"
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/simplestreams"
@@ -66,6 +67,11 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		return nil, errors.Trace(err)
 	}
 
+	rootDisk, err := p.machineRootDiskParams(cons, env)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine root disk source")
+	}
+
 	var jobs []multiwatcher.MachineJob
 	for _, job := range m.Jobs() {
 		jobs = append(jobs, job.ToParams())
@@ -103,6 +109,7 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 		Jobs:              jobs,
 		Volumes:           volumes,
 		VolumeAttachments: volumeAttachments,
+		RootDisk:          rootDisk,
 		Tags:              tags,
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
@@ -111,6 +118,39 @@ func (p *ProvisionerAPI) getProvisioningInfo(m *state.Machine, env environs.Envi
 	}, nil
 }
 
+// machineRootDiskParams returns the storage provider type and pool
+// attributes for the machine's root disk, if its root-disk-source
+// constraint names a storage pool or storage provider. Otherwise,
+// nil is returned and the environ interprets the constraint itself.
+func (p *ProvisionerAPI) machineRootDiskParams(
+	cons constraints.Value,
+	env environs.Environ,
+) (*params.VolumeParams, error) {
+	if !cons.HasRootDiskSource() {
+		return nil, nil
+	}
+	source := *cons.RootDiskSource
+	providerType, cfg, err := storagecommon.StoragePoolConfig(
+		source, p.storagePoolManager, p.storageProviderRegistry,
+	)
+	if errors.IsNotFound(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := env.StorageProvider(providerType); errors.IsNotFound(err) {
+		return nil, errors.NotSupportedf(
+			"root disk source %q with storage provider %q", source, providerType,
+		)
+	} else if err != nil {
+		return nil, errors.Annotate(err, "getting storage provider")
+	}
+	return &params.VolumeParams{
+		Provider:   string(providerType),
+		Attributes: cfg.Attrs(),
+	}, nil
+}
+
 // machineVolumeParams retrieves VolumeParams for the volumes that should be
 // provisioned with, and attached to, the machine. The client should ignore
 // parameters that it does not know how to handle.
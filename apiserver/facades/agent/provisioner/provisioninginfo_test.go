@@ -119,6 +119,50 @@ func (s *withoutControllerSuite) TestProvisioningInfoWithStorage(c *gc.C) {
 	c.Assert(result, jc.DeepEquals, expected)
 }
 
+func (s *withoutControllerSuite) TestProvisioningInfoWithRootDiskSource(c *gc.C) {
+	pm := poolmanager.New(state.NewStateSettings(s.State), storage.ChainedProviderRegistry{
+		dummy.StorageProviders(),
+		provider.CommonStorageProviders(),
+	})
+	_, err := pm.Create("static-pool", "static", map[string]interface{}{"foo": "bar"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	var entities []params.Entity
+	for _, source := range []string{"static-pool", "static", "volume", "loop"} {
+		m, err := s.State.AddOneMachine(state.MachineTemplate{
+			Series:      "quantal",
+			Jobs:        []state.MachineJob{state.JobHostUnits},
+			Constraints: constraints.MustParse("root-disk-source=" + source),
+		})
+		c.Assert(err, jc.ErrorIsNil)
+		entities = append(entities, params.Entity{Tag: m.Tag().String()})
+	}
+	result, err := s.provisioner.ProvisioningInfo(params.Entities{Entities: entities})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 4)
+
+	// A storage pool is resolved to its provider and attributes.
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].Result.RootDisk, jc.DeepEquals, &params.VolumeParams{
+		Provider:   "static",
+		Attributes: map[string]interface{}{"foo": "bar"},
+	})
+
+	// A storage provider is resolved without attributes.
+	c.Assert(result.Results[1].Error, gc.IsNil)
+	c.Assert(result.Results[1].Result.RootDisk, jc.DeepEquals, &params.VolumeParams{
+		Provider: "static",
+	})
+
+	// Anything else is left to the environ to interpret.
+	c.Assert(result.Results[2].Error, gc.IsNil)
+	c.Assert(result.Results[2].Result.RootDisk, gc.IsNil)
+
+	// Storage providers not managed by the environ are rejected.
+	c.Assert(result.Results[3].Error, gc.ErrorMatches,
+		`cannot determine root disk source: root disk source "loop" with storage provider "loop" not supported`)
+}
+
 func (s *withoutControllerSuite) TestProvisioningInfoWithSingleNegativeAndPositiveSpaceInConstraints(c *gc.C) {
 	s.addSpacesAndSubnets(c)
 
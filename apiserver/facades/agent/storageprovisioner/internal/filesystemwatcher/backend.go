@@ -14,6 +14,7 @@ import (
 type Backend interface {
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
 	VolumeAttachment(names.MachineTag, names.VolumeTag) (state.VolumeAttachment, error)
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 	WatchMachineFilesystems(names.MachineTag) state.StringsWatcher
 	WatchMachineFilesystemAttachments(names.MachineTag) state.StringsWatcher
 	WatchModelFilesystems() state.StringsWatcher
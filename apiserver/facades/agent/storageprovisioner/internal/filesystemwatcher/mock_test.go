@@ -17,6 +17,7 @@ type mockBackend struct {
 	modelFilesystemsW             *watchertest.StringsWatcher
 	modelFilesystemAttachmentsW   *watchertest.StringsWatcher
 	modelVolumeAttachmentsW       *watchertest.StringsWatcher
+	filesystemWs                  map[string]*watchertest.NotifyWatcher
 
 	filesystems               map[string]*mockFilesystem
 	volumeAttachments         map[string]*mockVolumeAttachment
@@ -52,6 +53,10 @@ func (b *mockBackend) VolumeAttachment(m names.MachineTag, v names.VolumeTag) (s
 	return nil, errors.NotFoundf("attachment for volume %s to machine %s", v.Id(), m.Id())
 }
 
+func (b *mockBackend) WatchFilesystem(tag names.FilesystemTag) state.NotifyWatcher {
+	return b.filesystemWs[tag.Id()]
+}
+
 func (b *mockBackend) WatchMachineFilesystems(tag names.MachineTag) state.StringsWatcher {
 	return b.machineFilesystemsW
 }
@@ -76,9 +81,27 @@ func newStringsWatcher() *watchertest.StringsWatcher {
 	return watchertest.NewStringsWatcher(make(chan []string, 1))
 }
 
+func newNotifyWatcher() *watchertest.NotifyWatcher {
+	return watchertest.NewNotifyWatcher(make(chan struct{}, 1))
+}
+
 type mockFilesystem struct {
 	state.Filesystem
-	volume names.VolumeTag
+	tag         names.FilesystemTag
+	volume      names.VolumeTag
+	shared      bool
+	provisioned bool
+}
+
+func (f *mockFilesystem) FilesystemTag() names.FilesystemTag {
+	return f.tag
+}
+
+func (f *mockFilesystem) Info() (state.FilesystemInfo, error) {
+	if !f.provisioned {
+		return state.FilesystemInfo{}, errors.NotProvisionedf("filesystem %s", f.tag.Id())
+	}
+	return state.FilesystemInfo{FilesystemId: "fs-" + f.tag.Id()}, nil
 }
 
 func (f *mockFilesystem) Volume() (names.VolumeTag, error) {
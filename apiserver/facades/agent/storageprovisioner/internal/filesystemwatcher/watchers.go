@@ -19,8 +19,30 @@ import (
 // model-scoped filesystems that have no backing volume. The machine-level worker
 // watches both machine-scoped filesytems, and model-scoped filesystems whose
 // backing volumes are attached to the machine.
+//
+// Attachments to shared filesystems are made by the machine-level worker,
+// even though the filesystems themselves are model-scoped, as each machine
+// must mount the filesystem itself.
 type Watchers struct {
 	Backend Backend
+
+	// IsShared reports whether or not the specified filesystem is a
+	// shared filesystem. If IsShared is nil, then no filesystems are
+	// considered to be shared.
+	IsShared func(state.Filesystem) (bool, error)
+}
+
+// isShared reports whether or not the specified filesystem is a shared
+// filesystem.
+func (fw Watchers) isShared(f state.Filesystem) (bool, error) {
+	if fw.IsShared == nil {
+		return false, nil
+	}
+	shared, err := fw.IsShared(f)
+	if err != nil {
+		return false, errors.Annotate(err, "checking if filesystem is shared")
+	}
+	return shared, nil
 }
 
 // WatchModelManagedFilesystems returns a strings watcher that reports
@@ -197,8 +219,8 @@ func (w *machineFilesystemsWatcher) modelVolumeAttachmentChanged(volumeTag names
 
 // WatchModelManagedFilesystemAttachments returns a strings watcher that
 // reports lifecycle changes to attachments of model-scoped filesystem that
-// have no backing volume. Volume-backed filesystems are always managed by
-// the machine to which they are attached.
+// have no backing volume, and are not shared. Volume-backed and shared
+// filesystems are always managed by the machine to which they are attached.
 func (fw Watchers) WatchModelManagedFilesystemAttachments() state.StringsWatcher {
 	return newFilteredStringsWatcher(fw.Backend.WatchModelFilesystemAttachments(), func(id string) (bool, error) {
 		_, filesystemTag, err := state.ParseFilesystemAttachmentId(id)
@@ -211,26 +233,36 @@ func (fw Watchers) WatchModelManagedFilesystemAttachments() state.StringsWatcher
 		} else if err != nil {
 			return false, errors.Trace(err)
 		}
-		_, err = f.Volume()
-		return err == state.ErrNoBackingVolume, nil
+		if _, err := f.Volume(); err != state.ErrNoBackingVolume {
+			return false, nil
+		}
+		shared, err := fw.isShared(f)
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		return !shared, nil
 	})
 }
 
 // WatchMachineManagedFilesystemAttachments returns a strings watcher that
 // reports lifecycle change sfor attachments to both machine-scoped filesystems,
-// and model-scoped, volume-backed filesystems that are attached to the
-// specified machine.
+// and model-scoped, volume-backed or shared filesystems that are attached to
+// the specified machine.
 func (fw Watchers) WatchMachineManagedFilesystemAttachments(m names.MachineTag) state.StringsWatcher {
 	w := &machineFilesystemAttachmentsWatcher{
-		stringsWatcherBase: stringsWatcherBase{out: make(chan []string)},
-		backend:            fw.Backend,
-		machine:            m,
-		changes:            make(set.Strings),
-		machineFilesystemAttachments:     fw.Backend.WatchMachineFilesystemAttachments(m),
-		modelFilesystemAttachments:       fw.Backend.WatchModelFilesystemAttachments(),
-		modelVolumeAttachments:           fw.Backend.WatchModelVolumeAttachments(),
-		modelVolumesAttached:             make(set.Tags),
-		modelVolumeFilesystemAttachments: make(map[names.VolumeTag]string),
+		stringsWatcherBase:                 stringsWatcherBase{out: make(chan []string)},
+		backend:                            fw.Backend,
+		isShared:                           fw.isShared,
+		machine:                            m,
+		changes:                            make(set.Strings),
+		machineFilesystemAttachments:       fw.Backend.WatchMachineFilesystemAttachments(m),
+		modelFilesystemAttachments:         fw.Backend.WatchModelFilesystemAttachments(),
+		modelVolumeAttachments:             fw.Backend.WatchModelVolumeAttachments(),
+		modelVolumesAttached:               make(set.Tags),
+		modelVolumeFilesystemAttachments:   make(map[names.VolumeTag]string),
+		pendingSharedFilesystemAttachments: make(map[names.FilesystemTag]string),
+		sharedFilesystemWatchers:           make(map[names.FilesystemTag]state.NotifyWatcher),
+		sharedFilesystemChanges:            make(chan names.FilesystemTag),
 	}
 	go func() {
 		defer w.tomb.Done()
@@ -244,26 +276,37 @@ func (fw Watchers) WatchMachineManagedFilesystemAttachments(m names.MachineTag)
 
 // machineFilesystemAttachmentsWatcher is a strings watcher that reports
 // lifechcle changes for attachments to both machine-scoped filesystems,
-// and model-scoped, volume-backed filesystems that are attached to the
-// specified machine.
+// and model-scoped, volume-backed or shared filesystems that are attached
+// to the specified machine.
 //
 // NOTE(axw) we use the existence of the *volume* attachment rather than
 // filesystem attachment because the filesystem attachment can be destroyed
 // before the filesystem, but the volume attachment cannot.
+//
+// Shared filesystems are provisioned by the model-level worker, and the
+// machine cannot attach to them until they have been. The watcher watches
+// each shared filesystem that is attached to the machine but not yet
+// provisioned, and reports the attachment again once it has been.
 type machineFilesystemAttachmentsWatcher struct {
 	stringsWatcherBase
 	changes                          set.Strings
 	backend                          Backend
+	isShared                         func(state.Filesystem) (bool, error)
 	machine                          names.MachineTag
 	machineFilesystemAttachments     state.StringsWatcher
 	modelFilesystemAttachments       state.StringsWatcher
 	modelVolumeAttachments           state.StringsWatcher
 	modelVolumesAttached             set.Tags
 	modelVolumeFilesystemAttachments map[names.VolumeTag]string
+
+	pendingSharedFilesystemAttachments map[names.FilesystemTag]string
+	sharedFilesystemWatchers           map[names.FilesystemTag]state.NotifyWatcher
+	sharedFilesystemChanges            chan names.FilesystemTag
 }
 
 func (w *machineFilesystemAttachmentsWatcher) loop() error {
 	defer close(w.out)
+	defer w.stopSharedFilesystemWatchers()
 	var out chan<- []string
 	var machineFilesystemAttachmentsReceived bool
 	var modelFilesystemAttachmentsReceived bool
@@ -315,6 +358,10 @@ func (w *machineFilesystemAttachmentsWatcher) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case filesystemTag := <-w.sharedFilesystemChanges:
+			if err := w.sharedFilesystemChanged(filesystemTag); err != nil {
+				return errors.Trace(err)
+			}
 		case out <- w.changes.SortedValues():
 			w.changes = make(set.Strings)
 			out = nil
@@ -346,8 +393,9 @@ func (w *machineFilesystemAttachmentsWatcher) modelFilesystemAttachmentChanged(
 	}
 	volumeTag, err := filesystem.Volume()
 	if err == state.ErrNoBackingVolume {
-		// Filesystem has no backing volume: nothing more to do.
-		return nil
+		// Filesystem has no backing volume, so it is managed
+		// by the machine only if it is a shared filesystem.
+		return w.sharedFilesystemAttachmentChanged(filesystemAttachmentId, filesystem)
 	} else if err != nil {
 		return errors.Annotate(err, "getting filesystem volume")
 	}
@@ -384,6 +432,102 @@ func (w *machineFilesystemAttachmentsWatcher) modelVolumeAttachmentChanged(volum
 	return nil
 }
 
+func (w *machineFilesystemAttachmentsWatcher) sharedFilesystemAttachmentChanged(
+	filesystemAttachmentId string,
+	filesystem state.Filesystem,
+) error {
+	shared, err := w.isShared(filesystem)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !shared {
+		// Filesystem is managed by the model: nothing more to do.
+		return nil
+	}
+	w.changes.Add(filesystemAttachmentId)
+	if _, err := filesystem.Info(); errors.IsNotProvisioned(err) {
+		// The filesystem must be provisioned before the machine
+		// can attach to it, so watch for it to be provisioned.
+		w.watchSharedFilesystem(filesystem.FilesystemTag(), filesystemAttachmentId)
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem info")
+	}
+	return nil
+}
+
+func (w *machineFilesystemAttachmentsWatcher) watchSharedFilesystem(
+	filesystemTag names.FilesystemTag,
+	filesystemAttachmentId string,
+) {
+	w.pendingSharedFilesystemAttachments[filesystemTag] = filesystemAttachmentId
+	if _, ok := w.sharedFilesystemWatchers[filesystemTag]; ok {
+		return
+	}
+	fw := w.backend.WatchFilesystem(filesystemTag)
+	w.sharedFilesystemWatchers[filesystemTag] = fw
+	go func() {
+		for {
+			select {
+			case <-w.tomb.Dying():
+				return
+			case _, ok := <-fw.Changes():
+				if !ok {
+					// The watcher is stopped when the filesystem
+					// is provisioned; any other reason is an error.
+					if err := fw.Err(); err != nil {
+						w.tomb.Kill(err)
+					}
+					return
+				}
+				select {
+				case <-w.tomb.Dying():
+					return
+				case w.sharedFilesystemChanges <- filesystemTag:
+				}
+			}
+		}
+	}()
+}
+
+func (w *machineFilesystemAttachmentsWatcher) sharedFilesystemChanged(filesystemTag names.FilesystemTag) error {
+	filesystemAttachmentId, ok := w.pendingSharedFilesystemAttachments[filesystemTag]
+	if !ok {
+		return nil
+	}
+	filesystem, err := w.backend.Filesystem(filesystemTag)
+	if errors.IsNotFound(err) {
+		// Filesystem removed: nothing more to do.
+		return w.stopWatchingSharedFilesystem(filesystemTag)
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem")
+	}
+	if _, err := filesystem.Info(); errors.IsNotProvisioned(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "getting filesystem info")
+	}
+	// The filesystem has been provisioned, so the
+	// machine can now attach to it.
+	w.changes.Add(filesystemAttachmentId)
+	return w.stopWatchingSharedFilesystem(filesystemTag)
+}
+
+func (w *machineFilesystemAttachmentsWatcher) stopWatchingSharedFilesystem(filesystemTag names.FilesystemTag) error {
+	delete(w.pendingSharedFilesystemAttachments, filesystemTag)
+	fw, ok := w.sharedFilesystemWatchers[filesystemTag]
+	if !ok {
+		return nil
+	}
+	delete(w.sharedFilesystemWatchers, filesystemTag)
+	return errors.Trace(fw.Stop())
+}
+
+func (w *machineFilesystemAttachmentsWatcher) stopSharedFilesystemWatchers() {
+	for _, fw := range w.sharedFilesystemWatchers {
+		watcher.Stop(fw, &w.tomb)
+	}
+}
+
 type filteredStringsWatcher struct {
 	stringsWatcherBase
 	w      state.StringsWatcher
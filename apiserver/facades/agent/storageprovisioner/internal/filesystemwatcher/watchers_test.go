@@ -13,6 +13,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner/internal/filesystemwatcher"
 	"github.com/juju/juju/state"
 	statetesting "github.com/juju/juju/state/testing"
+	"github.com/juju/juju/state/watcher/watchertest"
 )
 
 var _ = gc.Suite(&WatchersSuite{})
@@ -38,6 +39,13 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 			"1": {volume: names.NewVolumeTag("1")},
 			// filesystem 2 is backed by volume 2.
 			"2": {volume: names.NewVolumeTag("2")},
+			// filesystem 3 is shared, and not yet provisioned.
+			"3": {tag: names.NewFilesystemTag("3"), shared: true},
+			// filesystem 4 is shared, and provisioned.
+			"4": {tag: names.NewFilesystemTag("4"), shared: true, provisioned: true},
+		},
+		filesystemWs: map[string]*watchertest.NotifyWatcher{
+			"3": newNotifyWatcher(),
 		},
 		volumeAttachments: map[string]*mockVolumeAttachment{
 			"1": {life: state.Alive},
@@ -51,8 +59,12 @@ func (s *WatchersSuite) SetUpTest(c *gc.C) {
 		s.backend.modelFilesystemsW.Stop()
 		s.backend.modelFilesystemAttachmentsW.Stop()
 		s.backend.modelVolumeAttachmentsW.Stop()
+		s.backend.filesystemWs["3"].Stop()
 	})
 	s.watchers.Backend = s.backend
+	s.watchers.IsShared = func(f state.Filesystem) (bool, error) {
+		return f.(*mockFilesystem).shared, nil
+	}
 }
 
 func (s *WatchersSuite) TestWatchModelManagedFilesystems(c *gc.C) {
@@ -83,6 +95,17 @@ func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachmentsShared(c *gc.C) {
+	w := s.watchers.WatchModelManagedFilesystemAttachments()
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:3", "1:4"}
+
+	// Filesystems 3 and 4 are shared, so should not be reported.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0:0")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchModelManagedFilesystemAttachmentsWatcherErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchModelManagedFilesystemAttachments()
 	s.backend.modelFilesystemAttachmentsW.T.Kill(errors.New("rah"))
@@ -174,6 +197,27 @@ func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachments(c *gc.C) {
 	wc.AssertNoChange()
 }
 
+func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachmentsShared(c *gc.C) {
+	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
+	defer statetesting.AssertKillAndWait(c, w)
+	s.backend.modelFilesystemAttachmentsW.C <- []string{"0:0", "0:3", "0:4", "1:3"}
+	s.backend.machineFilesystemAttachmentsW.C <- []string{}
+	s.backend.modelVolumeAttachmentsW.C <- []string{}
+
+	// Filesystems 3 and 4 are shared, so attachments to
+	// them are managed by the machine.
+	wc := statetesting.NewStringsWatcherC(c, nopSyncStarter{}, w)
+	wc.AssertChangeInSingleEvent("0:3", "0:4")
+	wc.AssertNoChange()
+
+	// Once filesystem 3 is provisioned, its attachment is reported
+	// again, so that the machine can attach to it.
+	s.backend.filesystems["3"].provisioned = true
+	s.backend.filesystemWs["3"].C <- struct{}{}
+	wc.AssertChangeInSingleEvent("0:3")
+	wc.AssertNoChange()
+}
+
 func (s *WatchersSuite) TestWatchMachineManagedFilesystemAttachmentsErrorsPropagate(c *gc.C) {
 	w := s.watchers.WatchMachineManagedFilesystemAttachments(names.NewMachineTag("0"))
 	s.backend.modelFilesystemAttachmentsW.T.Kill(errors.New("rah"))
@@ -0,0 +1,180 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/poolmanager"
+)
+
+// StorageProvisionerAPIv4 provides the StorageProvisioner API v4 facade,
+// which adds support for resizing volumes and filesystems.
+type StorageProvisionerAPIv4 struct {
+	*StorageProvisionerAPI
+}
+
+// NewStorageProvisionerAPIv4 creates a new server-side StorageProvisioner
+// API v4 facade.
+func NewStorageProvisionerAPIv4(
+	st Backend,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+	registry storage.ProviderRegistry,
+	poolManager poolmanager.PoolManager,
+) (*StorageProvisionerAPIv4, error) {
+	api, err := NewStorageProvisionerAPI(st, resources, authorizer, registry, poolManager)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &StorageProvisionerAPIv4{api}, nil
+}
+
+// WatchVolumeResizes watches for changes to volumes scoped to the
+// entity with the tag passed to NewState, so that pending resizes
+// may be processed.
+func (s *StorageProvisionerAPIv4) WatchVolumeResizes(args params.Entities) (params.StringsWatchResults, error) {
+	return s.watchStorageEntities(args, s.st.WatchModelVolumeResizes, s.st.WatchMachineVolumeResizes)
+}
+
+// VolumeResizeParams returns the parameters for resizing the volumes
+// with the specified tags. An error satisfying params.IsCodeNotFound
+// is returned for volumes that have no resize pending.
+func (s *StorageProvisionerAPIv4) VolumeResizeParams(args params.Entities) (params.VolumeResizeParamsResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.VolumeResizeParamsResults{}, err
+	}
+	results := params.VolumeResizeParamsResults{
+		Results: make([]params.VolumeResizeParamsResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) (params.VolumeResizeParams, error) {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		}
+		volume, err := s.st.Volume(tag)
+		if errors.IsNotFound(err) {
+			return params.VolumeResizeParams{}, common.ErrPerm
+		} else if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		size, ok := volume.PendingSize()
+		if !ok {
+			return params.VolumeResizeParams{}, errors.NotFoundf("pending resize of volume %q", tag.Id())
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return params.VolumeResizeParams{}, err
+		}
+		providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, s.poolManager, s.registry)
+		if err != nil {
+			return params.VolumeResizeParams{}, errors.Trace(err)
+		}
+		return params.VolumeResizeParams{
+			VolumeTag:  tag.String(),
+			VolumeId:   info.VolumeId,
+			Size:       size,
+			Provider:   string(providerType),
+			Attributes: cfg.Attrs(),
+		}, nil
+	}
+	for i, arg := range args.Entities {
+		var result params.VolumeResizeParamsResult
+		resizeParams, err := one(arg)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Result = resizeParams
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+// SetVolumeSizes records the sizes of resized volumes.
+func (s *StorageProvisionerAPIv4) SetVolumeSizes(args params.EntitySizes) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sizes)),
+	}
+	one := func(arg params.EntitySize) error {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetVolumeSize(tag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Sizes {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// CancelVolumeResizes clears the pending resizes of the specified
+// volumes, which the storage provisioner is unable to carry out.
+func (s *StorageProvisionerAPIv4) CancelVolumeResizes(args params.Entities) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Entities)),
+	}
+	one := func(arg params.Entity) error {
+		tag, err := names.ParseVolumeTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.CancelVolumeResize(tag)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Entities {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
+
+// SetFilesystemSizes records the sizes of filesystems that have been
+// grown to fill their resized backing volumes.
+func (s *StorageProvisionerAPIv4) SetFilesystemSizes(args params.EntitySizes) (params.ErrorResults, error) {
+	canAccess, err := s.getStorageEntityAuthFunc()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Sizes)),
+	}
+	one := func(arg params.EntitySize) error {
+		tag, err := names.ParseFilesystemTag(arg.Tag)
+		if err != nil || !canAccess(tag) {
+			return common.ErrPerm
+		}
+		err = s.st.SetFilesystemSize(tag, arg.Size)
+		if errors.IsNotFound(err) {
+			return common.ErrPerm
+		}
+		return errors.Trace(err)
+	}
+	for i, arg := range args.Sizes {
+		results.Results[i].Error = common.ServerError(one(arg))
+	}
+	return results, nil
+}
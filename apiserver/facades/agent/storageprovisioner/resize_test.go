@@ -0,0 +1,128 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storageprovisioner_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/agent/storageprovisioner"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+func (s *provisionerSuite) apiv4() *storageprovisioner.StorageProvisionerAPIv4 {
+	return &storageprovisioner.StorageProvisionerAPIv4{s.api}
+}
+
+func (s *provisionerSuite) TestWatchVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.apiv4().WatchVolumeResizes(params.Entities{Entities: []params.Entity{
+		{s.State.ModelTag().String()},
+		{"machine-42"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, gc.HasLen, 2)
+	c.Assert(result.Results[0].Error, gc.IsNil)
+	c.Assert(result.Results[0].StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Results[1].Error, jc.DeepEquals, apiservertesting.ErrUnauthorized)
+
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	w := s.resources.Get("1")
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewStringsWatcherC(c, s.State, w.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("2")
+}
+
+func (s *provisionerSuite) TestVolumeResizeParams(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4().VolumeResizeParams(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-0-0"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.VolumeResizeParamsResults{
+		Results: []params.VolumeResizeParamsResult{
+			{Result: params.VolumeResizeParams{
+				VolumeTag: "volume-2",
+				VolumeId:  "def",
+				Size:      8192,
+				Provider:  "modelscoped",
+			}},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `pending resize of volume "0/0" not found`,
+			}},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+}
+
+func (s *provisionerSuite) TestSetVolumeSizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4().SetVolumeSizes(params.EntitySizes{
+		Sizes: []params.EntitySize{
+			{Tag: "volume-2", Size: 8192},
+			{Tag: "volume-42", Size: 1},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, pending := volume.PendingSize()
+	c.Assert(pending, jc.IsFalse)
+	info, err := volume.Info()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(info.Size, gc.Equals, uint64(8192))
+}
+
+func (s *provisionerSuite) TestCancelVolumeResizes(c *gc.C) {
+	s.setupVolumes(c)
+	err := s.State.ResizeVolume(names.NewVolumeTag("2"), 8192)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := s.apiv4().CancelVolumeResizes(params.Entities{
+		Entities: []params.Entity{
+			{"volume-2"},
+			{"volume-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Message: "permission denied", Code: "unauthorized access"}},
+		},
+	})
+
+	volume, err := s.State.Volume(names.NewVolumeTag("2"))
+	c.Assert(err, jc.ErrorIsNil)
+	_, pending := volume.PendingSize()
+	c.Assert(pending, jc.IsFalse)
+}
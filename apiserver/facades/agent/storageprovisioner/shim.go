@@ -32,6 +32,15 @@ func NewFacade(st *state.State, resources facade.Resources, authorizer facade.Au
 	return NewStorageProvisionerAPI(stateShim{st}, resources, authorizer, registry, pm)
 }
 
+// NewFacadeV4 provides the signature required for facade registration.
+func NewFacadeV4(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*StorageProvisionerAPIv4, error) {
+	v3, err := NewFacade(st, resources, authorizer)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &StorageProvisionerAPIv4{v3}, nil
+}
+
 type Backend interface {
 	state.EntityFinder
 	state.ModelAccessor
@@ -52,10 +61,13 @@ type Backend interface {
 	WatchMachineVolumes(names.MachineTag) state.StringsWatcher
 	WatchMachineVolumeAttachments(names.MachineTag) state.StringsWatcher
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
+	WatchModelVolumeResizes() state.StringsWatcher
+	WatchMachineVolumeResizes(names.MachineTag) state.StringsWatcher
 
 	StorageInstance(names.StorageTag) (state.StorageInstance, error)
 
 	Filesystem(names.FilesystemTag) (state.Filesystem, error)
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
 	FilesystemAttachment(names.MachineTag, names.FilesystemTag) (state.FilesystemAttachment, error)
 
 	Volume(names.VolumeTag) (state.Volume, error)
@@ -71,6 +83,9 @@ type Backend interface {
 	SetFilesystemAttachmentInfo(names.MachineTag, names.FilesystemTag, state.FilesystemAttachmentInfo) error
 	SetVolumeInfo(names.VolumeTag, state.VolumeInfo) error
 	SetVolumeAttachmentInfo(names.MachineTag, names.VolumeTag, state.VolumeAttachmentInfo) error
+	SetVolumeSize(names.VolumeTag, uint64) error
+	CancelVolumeResize(names.VolumeTag) error
+	SetFilesystemSize(names.FilesystemTag, uint64) error
 }
 
 type stateShim struct {
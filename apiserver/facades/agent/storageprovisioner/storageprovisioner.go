@@ -280,7 +280,7 @@ func (s *StorageProvisionerAPI) WatchVolumes(args params.Entities) (params.Strin
 // WatchFilesystems watches for changes to filesystems scoped
 // to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchFilesystems(args params.Entities) (params.StringsWatchResults, error) {
-	w := filesystemwatcher.Watchers{s.st}
+	w := s.filesystemWatchers()
 	return s.watchStorageEntities(args, w.WatchModelManagedFilesystems, w.WatchMachineManagedFilesystems)
 }
 
@@ -340,7 +340,7 @@ func (s *StorageProvisionerAPI) WatchVolumeAttachments(args params.Entities) (pa
 // WatchFilesystemAttachments watches for changes to filesystem attachments
 // scoped to the entity with the tag passed to NewState.
 func (s *StorageProvisionerAPI) WatchFilesystemAttachments(args params.Entities) (params.MachineStorageIdsWatchResults, error) {
-	w := filesystemwatcher.Watchers{s.st}
+	w := s.filesystemWatchers()
 	return s.watchAttachments(
 		args,
 		w.WatchModelManagedFilesystemAttachments,
@@ -349,6 +349,40 @@ func (s *StorageProvisionerAPI) WatchFilesystemAttachments(args params.Entities)
 	)
 }
 
+func (s *StorageProvisionerAPI) filesystemWatchers() filesystemwatcher.Watchers {
+	return filesystemwatcher.Watchers{
+		Backend:  s.st,
+		IsShared: s.isSharedFilesystem,
+	}
+}
+
+// isSharedFilesystem reports whether or not the specified filesystem
+// was created by a provider whose filesystems may be attached to
+// multiple machines. Attachments to shared filesystems are made by
+// the machines themselves.
+func (s *StorageProvisionerAPI) isSharedFilesystem(f state.Filesystem) (bool, error) {
+	var pool string
+	if filesystemParams, ok := f.Params(); ok {
+		pool = filesystemParams.Pool
+	} else {
+		filesystemInfo, err := f.Info()
+		if err != nil {
+			return false, errors.Trace(err)
+		}
+		pool = filesystemInfo.Pool
+	}
+	providerType, _, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	provider, err := s.registry.StorageProvider(providerType)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	shared, ok := provider.(storage.SharedFilesystemProvider)
+	return ok && shared.SharedFilesystems(), nil
+}
+
 func (s *StorageProvisionerAPI) watchAttachments(
 	args params.Entities,
 	watchEnvironAttachments func() state.StringsWatcher,
@@ -823,7 +857,7 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 			filesystemId = filesystemInfo.FilesystemId
 			pool = filesystemInfo.Pool
 		}
-		providerType, _, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
+		providerType, cfg, err := storagecommon.StoragePoolConfig(pool, s.poolManager, s.registry)
 		if err != nil {
 			return params.FilesystemAttachmentParams{}, errors.Trace(err)
 		}
@@ -853,6 +887,7 @@ func (s *StorageProvisionerAPI) FilesystemAttachmentParams(
 			// parts of the codebase.
 			location,
 			readOnly,
+			cfg.Attrs(),
 		}, nil
 	}
 	for i, arg := range args.Ids {
@@ -51,11 +51,16 @@ type UniterAPI struct {
 	StorageAPI
 }
 
+// UniterAPIV6 doesn't have the LogActionsMessages method.
+type UniterAPIV6 struct {
+	UniterAPI
+}
+
 // UniterAPIV5 returns a RelationResultsV5 instead of RelationResults
 // from Relation and RelationById - elements don't have an
 // OtherApplication field.
 type UniterAPIV5 struct {
-	UniterAPI
+	UniterAPIV6
 }
 
 // UniterAPIV4 has old WatchApplicationRelations and NetworkConfig
@@ -152,14 +157,25 @@ func NewUniterAPI(st *state.State, resources facade.Resources, authorizer facade
 	}, nil
 }
 
+// NewUniterAPIV6 creates an instance of the V6 uniter API.
+func NewUniterAPIV6(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV6, error) {
+	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &UniterAPIV6{
+		UniterAPI: *uniterAPI,
+	}, nil
+}
+
 // NewUniterAPIV5 creates an instance of the V5 uniter API.
 func NewUniterAPIV5(st *state.State, resources facade.Resources, authorizer facade.Authorizer) (*UniterAPIV5, error) {
-	uniterAPI, err := NewUniterAPI(st, resources, authorizer)
+	uniterAPI, err := NewUniterAPIV6(st, resources, authorizer)
 	if err != nil {
 		return nil, err
 	}
 	return &UniterAPIV5{
-		UniterAPI: *uniterAPI,
+		UniterAPIV6: *uniterAPI,
 	}, nil
 }
 
@@ -607,6 +623,9 @@ func (u *UniterAPI) charmModifiedVersion(tagStr string, canAccess func(names.Tag
 	default:
 		return -1, errors.BadRequestf("type %T does not have a CharmModifiedVersion", entity)
 	}
+	if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+		return application.CharmModifiedVersionForUnit(unitTag.Id()), nil
+	}
 	return application.CharmModifiedVersion(), nil
 }
 
@@ -631,10 +650,7 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 			var unitOrApplication state.Entity
 			unitOrApplication, err = u.st.FindEntity(tag)
 			if err == nil {
-				charmURLer := unitOrApplication.(interface {
-					CharmURL() (*charm.URL, bool)
-				})
-				curl, ok := charmURLer.CharmURL()
+				curl, ok := u.charmURL(unitOrApplication)
 				if curl != nil {
 					result.Results[i].Result = curl.String()
 					result.Results[i].Ok = ok
@@ -646,6 +662,22 @@ func (u *UniterAPI) CharmURL(args params.Entities) (params.StringBoolResults, er
 	return result, nil
 }
 
+// charmURL returns the charm URL of the given unit or application. For
+// an application, a unit agent is told the charm URL that applies to
+// its own unit, which may differ from the application's during a
+// rolling charm upgrade.
+func (u *UniterAPI) charmURL(unitOrApplication state.Entity) (*charm.URL, bool) {
+	if application, ok := unitOrApplication.(*state.Application); ok {
+		if unitTag, ok := u.auth.GetAuthTag().(names.UnitTag); ok {
+			return application.CharmURLForUnit(unitTag.Id())
+		}
+	}
+	charmURLer := unitOrApplication.(interface {
+		CharmURL() (*charm.URL, bool)
+	})
+	return charmURLer.CharmURL()
+}
+
 // SetCharmURL sets the charm URL for each given unit. An error will
 // be returned if a unit is dead, or the charm URL is not know.
 func (u *UniterAPI) SetCharmURL(args params.EntitiesCharmURL) (params.ErrorResults, error) {
@@ -960,6 +992,31 @@ func (u *UniterAPI) FinishActions(args params.ActionExecutionResults) (params.Er
 	return common.FinishActions(args, actionFn), nil
 }
 
+// LogActionsMessages records the progress messages logged by running
+// Actions.
+func (u *UniterAPI) LogActionsMessages(args params.ActionMessageParams) (params.ErrorResults, error) {
+	canAccess, err := u.accessUnit()
+	if err != nil {
+		return params.ErrorResults{}, err
+	}
+	actionFn := common.AuthAndActionFromTagFn(canAccess, u.st.ActionByTag)
+
+	oneActionMessage := func(actionTag string, message string) error {
+		action, err := actionFn(actionTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		return action.Log(message)
+	}
+
+	result := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Messages))}
+	for i, actionMessage := range args.Messages {
+		result.Results[i].Error = common.ServerError(
+			oneActionMessage(actionMessage.ActionTag, actionMessage.Message))
+	}
+	return result, nil
+}
+
 // RelationById returns information about all given relations,
 // specified by their ids, including their key and the local
 // endpoint.
@@ -1927,6 +1984,9 @@ func (u *UniterAPIV4) watchOneApplicationRelations(tag names.ApplicationTag) (pa
 // SLALevel isn't on the V4 API.
 func (u *UniterAPIV4) SLALevel(_, _ struct{}) {}
 
+// LogActionsMessages isn't on the V6 API.
+func (u *UniterAPIV6) LogActionsMessages(_, _ struct{}) {}
+
 // NetworkInfo isn't on the V4 API.
 func (u *UniterAPIV4) NetworkInfo(_, _ struct{}) {}
 
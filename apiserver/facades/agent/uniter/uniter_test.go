@@ -785,6 +785,36 @@ func (s *uniterSuite) TestCharmURL(c *gc.C) {
 	})
 }
 
+func (s *uniterSuite) TestCharmURLRollingUpgrade(c *gc.C) {
+	err := s.wordpressUnit.SetCharmURL(s.wpCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	oldVersion := s.wordpress.CharmModifiedVersion()
+	newCharm := s.Factory.MakeCharm(c, &jujufactory.CharmParams{
+		Name: "wordpress",
+		URL:  "cs:quantal/wordpress-4",
+	})
+	err = s.wordpress.SetCharm(state.SetCharmConfig{Charm: newCharm, Rolling: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The unit is held on the old charm until it is released.
+	args := params.Entities{Entities: []params.Entity{{Tag: "application-wordpress"}}}
+	result, err := s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.StringBoolResult{{Result: s.wpCharm.String()}})
+	versions, err := s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results, jc.DeepEquals, []params.IntResult{{Result: oldVersion}})
+
+	err = s.wordpress.ReleaseCharmUpgrade(s.wordpressUnit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = s.uniter.CharmURL(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.StringBoolResult{{Result: newCharm.String()}})
+	versions, err = s.uniter.CharmModifiedVersion(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(versions.Results, jc.DeepEquals, []params.IntResult{{Result: oldVersion + 1}})
+}
+
 func (s *uniterSuite) TestSetCharmURL(c *gc.C) {
 	_, ok := s.wordpressUnit.CharmURL()
 	c.Assert(ok, jc.IsFalse)
@@ -1656,6 +1686,37 @@ func (s *uniterSuite) TestBeginActions(c *gc.C) {
 	c.Assert(started.After(enqueued) || started.Equal(enqueued), jc.IsTrue, gc.Commentf("started should be after or equal to enqueued time"))
 }
 
+func (s *uniterSuite) TestLogActionsMessages(c *gc.C) {
+	good, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = good.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	notRunning, err := s.wordpressUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	bad, err := s.mysqlUnit.AddAction("fakeaction", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	args := params.ActionMessageParams{Messages: []params.ActionMessageArg{
+		{ActionTag: good.ActionTag().String(), Message: "hello"},
+		{ActionTag: notRunning.ActionTag().String(), Message: "hello"},
+		{ActionTag: bad.ActionTag().String(), Message: "hello"},
+		{ActionTag: "action-foo", Message: "hello"},
+	}}
+	res, err := s.uniter.LogActionsMessages(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 4)
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	c.Assert(res.Results[1].Error, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+	c.Assert(res.Results[2].Error, gc.DeepEquals, apiservertesting.ErrUnauthorized)
+	c.Assert(res.Results[3].Error, gc.ErrorMatches, `"action-foo" is not a valid action tag`)
+
+	action, err := s.State.ActionByTag(good.ActionTag())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := action.Messages()
+	c.Assert(messages, gc.HasLen, 1)
+	c.Assert(messages[0].Message(), gc.Equals, "hello")
+}
+
 func (s *uniterSuite) TestRelation(c *gc.C) {
 	rel := s.addRelation(c, "wordpress", "mysql")
 	wpEp, err := rel.Endpoint("wordpress")
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// ScheduleActions arranges for actions to be enqueued at a future time,
+// and optionally repeated at a fixed interval. Only units may be
+// targeted by scheduled actions.
+func (a *ActionAPI) ScheduleActions(args params.ScheduleActions) (params.ActionScheduleResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+	if err := a.check.ChangeAllowed(); err != nil {
+		return params.ActionScheduleResults{}, errors.Trace(err)
+	}
+
+	response := params.ActionScheduleResults{Results: make([]params.ActionScheduleResult, len(args.Schedules))}
+	for i, arg := range args.Schedules {
+		currentResult := &response.Results[i]
+		unitTag, err := names.ParseUnitTag(arg.Receiver)
+		if err != nil {
+			currentResult.Error = common.ServerError(common.ErrBadId)
+			continue
+		}
+		unit, err := a.state.Unit(unitTag.Id())
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		schedule, err := unit.ScheduleAction(arg.Name, arg.Parameters, arg.At, arg.Every)
+		if err != nil {
+			currentResult.Error = common.ServerError(err)
+			continue
+		}
+		result := makeActionSchedule(schedule)
+		currentResult.Schedule = &result
+	}
+	return response, nil
+}
+
+// ListActionSchedules returns all of the action schedules in the model,
+// ordered by the time at which they will next run.
+func (a *ActionAPI) ListActionSchedules() (params.ActionSchedules, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	schedules, err := a.state.ActionSchedules()
+	if err != nil {
+		return params.ActionSchedules{}, errors.Trace(err)
+	}
+	result := params.ActionSchedules{Schedules: make([]params.ActionSchedule, len(schedules))}
+	for i, schedule := range schedules {
+		result.Schedules[i] = makeActionSchedule(schedule)
+	}
+	return result, nil
+}
+
+// RemoveActionSchedules removes the action schedules with the given
+// ids. Actions that have already been enqueued are not affected.
+func (a *ActionAPI) RemoveActionSchedules(args params.ActionScheduleIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := a.check.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	result := params.ErrorResults{Results: make([]params.ErrorResult, len(args.Ids))}
+	for i, id := range args.Ids {
+		if err := a.state.RemoveActionSchedule(id); err != nil {
+			result.Results[i].Error = common.ServerError(err)
+		}
+	}
+	return result, nil
+}
+
+func makeActionSchedule(schedule *state.ActionSchedule) params.ActionSchedule {
+	result := params.ActionSchedule{
+		Id:         schedule.Id(),
+		Receiver:   names.NewUnitTag(schedule.Receiver()).String(),
+		Name:       schedule.Name(),
+		Parameters: schedule.Parameters(),
+		NextRun:    schedule.NextRun(),
+		Every:      schedule.Interval(),
+	}
+	if id := schedule.LastActionId(); id != "" {
+		result.LastActionTag = names.NewActionTag(id).String()
+	}
+	return result
+}
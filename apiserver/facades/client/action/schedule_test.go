@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package action_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+)
+
+func (s *actionSuite) TestBlockScheduleActions(c *gc.C) {
+	s.BlockAllChanges(c, "ScheduleActions")
+	_, err := s.action.ScheduleActions(params.ScheduleActions{})
+	s.AssertBlocked(c, err, "ScheduleActions")
+}
+
+func (s *actionSuite) TestBlockRemoveActionSchedules(c *gc.C) {
+	s.BlockRemoveObject(c, "RemoveActionSchedules")
+	_, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{})
+	s.AssertBlocked(c, err, "RemoveActionSchedules")
+}
+
+func (s *actionSuite) TestScheduleActionsIntervalTooShort(c *gc.C) {
+	res, err := s.action.ScheduleActions(params.ScheduleActions{
+		Schedules: []params.ScheduleAction{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			Every:    30 * time.Second,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 1)
+	c.Assert(res.Results[0].Error, gc.ErrorMatches, "interval 30s shorter than 1m0s not valid")
+	c.Assert(res.Results[0].Schedule, gc.IsNil)
+}
+
+func (s *actionSuite) TestScheduleActions(c *gc.C) {
+	at := time.Date(2030, time.January, 1, 2, 0, 0, 0, time.UTC)
+	res, err := s.action.ScheduleActions(params.ScheduleActions{
+		Schedules: []params.ScheduleAction{{
+			Receiver: s.wordpressUnit.Tag().String(),
+			Name:     "fakeaction",
+			At:       at,
+			Every:    24 * time.Hour,
+		}, {
+			// Application tag instead of unit tag.
+			Receiver: s.wordpress.Tag().String(),
+			Name:     "fakeaction",
+			At:       at,
+		}, {
+			Receiver: s.mysqlUnit.Tag().String(),
+			Name:     "nosuchaction",
+			At:       at,
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res.Results, gc.HasLen, 3)
+
+	c.Assert(res.Results[0].Error, gc.IsNil)
+	schedule := res.Results[0].Schedule
+	c.Assert(schedule, gc.NotNil)
+	c.Assert(schedule.Receiver, gc.Equals, s.wordpressUnit.Tag().String())
+	c.Assert(schedule.Name, gc.Equals, "fakeaction")
+	c.Assert(schedule.NextRun.Equal(at), jc.IsTrue)
+	c.Assert(schedule.Every, gc.Equals, 24*time.Hour)
+
+	c.Assert(res.Results[1].Error, gc.DeepEquals, &params.Error{Message: "id not found", Code: "not found"})
+	c.Assert(res.Results[2].Error, gc.ErrorMatches, `action "nosuchaction" not defined on unit "mysql/0"`)
+
+	list, err := s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 1)
+	c.Assert(list.Schedules[0].Id, gc.Equals, schedule.Id)
+
+	removed, err := s.action.RemoveActionSchedules(params.ActionScheduleIds{
+		Ids: []string{schedule.Id, "nonexistent"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(removed.Results, gc.HasLen, 2)
+	c.Assert(removed.Results[0].Error, gc.IsNil)
+	c.Assert(removed.Results[1].Error, gc.ErrorMatches, `action schedule "nonexistent" not found`)
+
+	list, err = s.action.ListActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(list.Schedules, gc.HasLen, 0)
+}
@@ -309,8 +309,9 @@ func (api *API) Update(args params.ApplicationUpdate) error {
 			"",  // charm settings (YAML)
 			args.ForceSeries,
 			args.ForceCharmURL,
-			nil, // resource IDs
-			nil, // storage constraints
+			nil,   // resource IDs
+			nil,   // storage constraints
+			false, // rolling
 		); err != nil {
 			return errors.Trace(err)
 		}
@@ -365,9 +366,167 @@ func (api *API) SetCharm(args params.ApplicationSetCharm) error {
 		args.ForceUnits,
 		args.ResourceIDs,
 		args.StorageConstraints,
+		args.Rolling,
 	)
 }
 
+// ReleaseCharmUpgrade allows units held on their previous charm by a
+// rolling charm upgrade to upgrade to their application's charm.
+func (api *API) ReleaseCharmUpgrade(args params.ApplicationCharmUpgradeReleases) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Releases)),
+	}
+	for i, release := range args.Releases {
+		err := api.releaseCharmUpgrade(release)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) releaseCharmUpgrade(release params.ApplicationCharmUpgradeRelease) error {
+	appTag, err := names.ParseApplicationTag(release.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	unitNames := make([]string, len(release.UnitTags))
+	for i, tagString := range release.UnitTags {
+		unitTag, err := names.ParseUnitTag(tagString)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if appName, _ := names.UnitApplication(unitTag.Id()); appName != appTag.Id() {
+			return errors.NotValidf("unit %q of application %q", unitTag.Id(), appTag.Id())
+		}
+		unitNames[i] = unitTag.Id()
+	}
+	application, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return application.ReleaseCharmUpgrade(unitNames...)
+}
+
+// CharmUpgradeHold returns, for each application, the charm on which
+// units are held by a rolling charm upgrade and the units still held.
+func (api *API) CharmUpgradeHold(args params.Entities) (params.CharmUpgradeHoldResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.CharmUpgradeHoldResults{}, errors.Trace(err)
+	}
+	results := params.CharmUpgradeHoldResults{
+		Results: make([]params.CharmUpgradeHoldResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		result, err := api.charmUpgradeHold(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i] = result
+	}
+	return results, nil
+}
+
+func (api *API) charmUpgradeHold(tagString string) (params.CharmUpgradeHoldResult, error) {
+	appTag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return params.CharmUpgradeHoldResult{}, errors.Trace(err)
+	}
+	application, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return params.CharmUpgradeHoldResult{}, errors.Trace(err)
+	}
+	curl, unitNames := application.CharmUpgradeHold()
+	if curl == nil {
+		return params.CharmUpgradeHoldResult{}, nil
+	}
+	unitTags := make([]string, len(unitNames))
+	for i, unitName := range unitNames {
+		unitTags[i] = names.NewUnitTag(unitName).String()
+	}
+	return params.CharmUpgradeHoldResult{
+		CharmURL: curl.String(),
+		UnitTags: unitTags,
+	}, nil
+}
+
+// SetEgressRules replaces the egress rules of each application, which
+// determine the destinations and ports to which the application's
+// machines may send outgoing traffic in models where outgoing traffic
+// is denied by default.
+func (api *API) SetEgressRules(args params.SetApplicationsEgressRules) (params.ErrorResults, error) {
+	if err := api.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.setEgressRules(arg)
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+func (api *API) setEgressRules(arg params.ApplicationEgressRules) error {
+	appTag, err := names.ParseApplicationTag(arg.ApplicationTag)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	application, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rules := make([]network.EgressRule, len(arg.Rules))
+	for i, rule := range arg.Rules {
+		rules[i] = rule.NetworkEgressRule()
+	}
+	return application.SetEgressRules(rules)
+}
+
+// GetEgressRules returns the egress rules of each application.
+func (api *API) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	if err := api.checkCanRead(); err != nil {
+		return params.EgressRulesResults{}, errors.Trace(err)
+	}
+	results := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	for i, entity := range args.Entities {
+		rules, err := api.egressRules(entity.Tag)
+		if err != nil {
+			results.Results[i].Error = common.ServerError(err)
+			continue
+		}
+		results.Results[i].Rules = rules
+	}
+	return results, nil
+}
+
+func (api *API) egressRules(tagString string) ([]params.EgressRule, error) {
+	appTag, err := names.ParseApplicationTag(tagString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	application, err := api.backend.Application(appTag.Id())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []params.EgressRule
+	for _, rule := range application.EgressRules() {
+		rules = append(rules, params.FromNetworkEgressRule(rule))
+	}
+	return rules, nil
+}
+
 // applicationSetCharm sets the charm for the given for the application.
 func (api *API) applicationSetCharm(
 	appName string,
@@ -380,6 +539,7 @@ func (api *API) applicationSetCharm(
 	forceUnits bool,
 	resourceIDs map[string]string,
 	storageConstraints map[string]params.StorageConstraints,
+	rolling bool,
 ) error {
 	curl, err := charm.ParseURL(url)
 	if err != nil {
@@ -420,6 +580,7 @@ func (api *API) applicationSetCharm(
 		ForceUnits:         forceUnits,
 		ResourceIDs:        resourceIDs,
 		StorageConstraints: stateStorageConstraints,
+		Rolling:            rolling,
 	}
 	return application.SetCharm(cfg)
 }
@@ -565,7 +726,9 @@ func (api *API) CharmRelations(p params.ApplicationCharmRelations) (params.Appli
 }
 
 // Expose changes the juju-managed firewall to expose any ports that
-// were also explicitly marked by units as open.
+// were also explicitly marked by units as open. If expose settings
+// are specified, the ports may only be accessed from the given
+// spaces and CIDRs.
 func (api *API) Expose(args params.ApplicationExpose) error {
 	if err := api.checkCanWrite(); err != nil {
 		return err
@@ -577,7 +740,17 @@ func (api *API) Expose(args params.ApplicationExpose) error {
 	if err != nil {
 		return err
 	}
-	return app.SetExposed()
+	if len(args.ExposedEndpoints) == 0 {
+		return app.SetExposed()
+	}
+	exposedEndpoints := make(map[string]state.ExposedEndpoint)
+	for endpoint, settings := range args.ExposedEndpoints {
+		exposedEndpoints[endpoint] = state.ExposedEndpoint{
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+	}
+	return app.MergeExposeSettings(exposedEndpoints)
 }
 
 // Unexpose changes the juju-managed firewall to unexpose any ports that
@@ -30,6 +30,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	jujutesting "github.com/juju/juju/juju/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	statestorage "github.com/juju/juju/state/storage"
 	"github.com/juju/juju/status"
@@ -1837,7 +1838,7 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	c.Assert(apps[1].IsExposed(), jc.IsTrue)
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err = s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err = s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1849,6 +1850,83 @@ func (s *applicationSuite) TestApplicationExpose(c *gc.C) {
 	}
 }
 
+func (s *applicationSuite) TestApplicationExposeSettings(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	err := s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.IsExposed(), jc.IsTrue)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+	})
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"": {ExposeToSpaces: []string{"nowhere"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "dummy-application": space "nowhere" not found`)
+
+	err = s.applicationAPI.Expose(params.ApplicationExpose{
+		ApplicationName: "dummy-application",
+		ExposedEndpoints: map[string]params.ExposedEndpoint{
+			"juju-info": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+		},
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "dummy-application": expose settings for endpoint "juju-info" not supported`)
+}
+
+func (s *applicationSuite) TestSetAndGetEgressRules(c *gc.C) {
+	app := s.AddTestingApplication(c, "dummy-application", s.AddTestingCharm(c, "dummy"))
+	results, err := s.applicationAPI.SetEgressRules(params.SetApplicationsEgressRules{
+		Args: []params.ApplicationEgressRules{{
+			ApplicationTag: "application-dummy-application",
+			Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}},
+		}, {
+			ApplicationTag: "application-dummy-application",
+			Rules: []params.EgressRule{{
+				PortRange: params.PortRange{FromPort: 1, ToPort: 1, Protocol: "icmp"},
+			}},
+		}, {
+			ApplicationTag: "application-missing",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `cannot set egress rules for application "dummy-application": invalid protocol "icmp", expected "tcp" or "udp"`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `application "missing" not found`)
+
+	err = app.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.EgressRules(), jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+
+	rulesResults, err := s.applicationAPI.GetEgressRules(params.Entities{
+		Entities: []params.Entity{{Tag: "application-dummy-application"}, {Tag: "machine-0"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rulesResults.Results, gc.HasLen, 2)
+	c.Assert(rulesResults.Results[0], jc.DeepEquals, params.EgressRulesResult{
+		Rules: []params.EgressRule{{
+			PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+			DestinationCIDRs: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(rulesResults.Results[1].Error, gc.ErrorMatches, `"machine-0" is not a valid application tag`)
+}
+
 func (s *applicationSuite) setupApplicationExpose(c *gc.C) {
 	charm := s.AddTestingCharm(c, "dummy")
 	applicationNames := []string{"dummy-application", "exposed-application"}
@@ -1889,7 +1967,7 @@ var applicationExposeTests = []struct {
 func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		if t.err != "" {
 			c.Assert(err, gc.ErrorMatches, t.err)
 		} else {
@@ -1904,7 +1982,7 @@ func (s *applicationSuite) assertApplicationExpose(c *gc.C) {
 func (s *applicationSuite) assertApplicationExposeBlocked(c *gc.C, msg string) {
 	for i, t := range applicationExposeTests {
 		c.Logf("test %d. %s", i, t.about)
-		err := s.applicationAPI.Expose(params.ApplicationExpose{t.application})
+		err := s.applicationAPI.Expose(params.ApplicationExpose{ApplicationName: t.application})
 		s.AssertBlocked(c, err, msg)
 	}
 }
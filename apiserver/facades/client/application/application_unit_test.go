@@ -199,6 +199,79 @@ postgresql:
 	})
 }
 
+func (s *ApplicationSuite) TestSetCharmRolling(c *gc.C) {
+	err := s.api.SetCharm(params.ApplicationSetCharm{
+		ApplicationName: "postgresql",
+		CharmURL:        "cs:postgresql",
+		Rolling:         true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "SetCharm")
+	app.CheckCall(c, 0, "SetCharm", state.SetCharmConfig{
+		Charm:   &state.Charm{},
+		Rolling: true,
+	})
+}
+
+func (s *ApplicationSuite) TestReleaseCharmUpgrade(c *gc.C) {
+	results, err := s.api.ReleaseCharmUpgrade(params.ApplicationCharmUpgradeReleases{
+		Releases: []params.ApplicationCharmUpgradeRelease{{
+			ApplicationTag: "application-postgresql",
+			UnitTags:       []string{"unit-postgresql-0", "unit-postgresql-1"},
+		}, {
+			ApplicationTag: "application-postgresql",
+			UnitTags:       []string{"unit-mysql-0"},
+		}, {
+			ApplicationTag: "unit-postgresql-0",
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 3)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `unit "mysql/0" of application "postgresql" not valid`)
+	c.Assert(results.Results[2].Error, gc.ErrorMatches, `"unit-postgresql-0" is not a valid application tag`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckCallNames(c, "ReleaseCharmUpgrade")
+	app.CheckCall(c, 0, "ReleaseCharmUpgrade", []string{"postgresql/0", "postgresql/1"})
+}
+
+func (s *ApplicationSuite) TestBlockChangesReleaseCharmUpgrade(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.ReleaseCharmUpgrade(params.ApplicationCharmUpgradeReleases{
+		Releases: []params.ApplicationCharmUpgradeRelease{{
+			ApplicationTag: "application-postgresql",
+			UnitTags:       []string{"unit-postgresql-0"},
+		}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.CheckNoCalls(c)
+}
+
+func (s *ApplicationSuite) TestCharmUpgradeHold(c *gc.C) {
+	app := s.backend.applications["postgresql"].(*mockApplication)
+	app.curl = charm.MustParseURL("cs:postgresql-1")
+	app.heldUnits = []string{"postgresql/1"}
+	results, err := s.api.CharmUpgradeHold(params.Entities{
+		Entities: []params.Entity{
+			{Tag: "application-postgresql"},
+			{Tag: "application-foo"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, jc.DeepEquals, []params.CharmUpgradeHoldResult{{
+		CharmURL: "cs:postgresql-1",
+		UnitTags: []string{"unit-postgresql-1"},
+	}, {
+		Error: &params.Error{
+			Code:    params.CodeNotFound,
+			Message: `application "foo" not found`,
+		},
+	}})
+}
+
 func (s *ApplicationSuite) TestDestroyRelation(c *gc.C) {
 	err := s.api.DestroyRelation(params.DestroyRelation{Endpoints: []string{"a", "b"}})
 	c.Assert(err, jc.ErrorIsNil)
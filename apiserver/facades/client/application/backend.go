@@ -55,16 +55,21 @@ type Application interface {
 	AllUnits() ([]Unit, error)
 	Charm() (Charm, bool, error)
 	CharmURL() (*charm.URL, bool)
+	CharmUpgradeHold() (*charm.URL, []string)
 	Channel() csparams.Channel
 	ClearExposed() error
 	ConfigSettings() (charm.Settings, error)
 	Constraints() (constraints.Value, error)
 	Destroy() error
+	EgressRules() []network.EgressRule
 	Endpoints() ([]state.Endpoint, error)
 	IsPrincipal() bool
+	MergeExposeSettings(map[string]state.ExposedEndpoint) error
+	ReleaseCharmUpgrade(...string) error
 	Series() string
 	SetCharm(state.SetCharmConfig) error
 	SetConstraints(constraints.Value) error
+	SetEgressRules([]network.EgressRule) error
 	SetExposed() error
 	SetMetricCredentials([]byte) error
 	SetMinUnits(int) error
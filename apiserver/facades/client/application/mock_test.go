@@ -87,6 +87,7 @@ type mockApplication struct {
 	endpoints []state.Endpoint
 	bindings  map[string]string
 	units     []mockUnit
+	heldUnits []string
 }
 
 func (m *mockApplication) Name() string {
@@ -126,6 +127,20 @@ func (a *mockApplication) SetCharm(cfg state.SetCharmConfig) error {
 	return a.NextErr()
 }
 
+func (a *mockApplication) CharmUpgradeHold() (*charm.URL, []string) {
+	a.MethodCall(a, "CharmUpgradeHold")
+	a.PopNoErr()
+	if len(a.heldUnits) == 0 {
+		return nil, nil
+	}
+	return a.curl, a.heldUnits
+}
+
+func (a *mockApplication) ReleaseCharmUpgrade(unitNames ...string) error {
+	a.MethodCall(a, "ReleaseCharmUpgrade", unitNames)
+	return a.NextErr()
+}
+
 func (a *mockApplication) Destroy() error {
 	a.MethodCall(a, "Destroy")
 	return a.NextErr()
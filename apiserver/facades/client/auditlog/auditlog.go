@@ -0,0 +1,114 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package auditlog defines an API end point for querying the audit
+// entries recorded by the controller.
+package auditlog
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/permission"
+)
+
+// AuditLog defines the methods on the AuditLog API end point.
+type AuditLog interface {
+	Query(params.AuditLogFilter) (params.AuditLogResult, error)
+}
+
+// API implements the AuditLog interface.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+var _ AuditLog = (*API)(nil)
+
+// NewFacade creates a new API server endpoint for querying the
+// audit log.
+func NewFacade(ctx facade.Context) (*API, error) {
+	return NewAPI(stateShim{ctx.State()}, ctx.Auth())
+}
+
+// NewAPI returns a new AuditLog API using the given backend. Only
+// controller superusers may use it.
+func NewAPI(backend Backend, authorizer facade.Authorizer) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	isAdmin, err := authorizer.HasPermission(permission.SuperuserAccess, backend.ControllerTag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !isAdmin {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+	}, nil
+}
+
+// Query returns the audit entries matching the filter, oldest first.
+func (api *API) Query(args params.AuditLogFilter) (params.AuditLogResult, error) {
+	filter, err := auditFilter(args)
+	if err != nil {
+		return params.AuditLogResult{}, common.ServerError(err)
+	}
+	entries, err := api.backend.AuditEntries(filter)
+	if err != nil {
+		return params.AuditLogResult{}, common.ServerError(err)
+	}
+	result := params.AuditLogResult{
+		Entries: make([]params.AuditLogEntry, len(entries)),
+	}
+	for i, entry := range entries {
+		result.Entries[i] = params.AuditLogEntry{
+			JujuServerVersion: entry.JujuServerVersion.String(),
+			ModelTag:          names.NewModelTag(entry.ModelUUID).String(),
+			Timestamp:         entry.Timestamp,
+			RemoteAddress:     entry.RemoteAddress,
+			OriginType:        entry.OriginType,
+			OriginName:        entry.OriginName,
+			Operation:         entry.Operation,
+			Data:              entry.Data,
+		}
+	}
+	return result, nil
+}
+
+func auditFilter(args params.AuditLogFilter) (audit.Filter, error) {
+	filter := audit.Filter{
+		Operation: args.Operation,
+		Limit:     args.Limit,
+	}
+	if args.UserTag != "" {
+		tag, err := names.ParseUserTag(args.UserTag)
+		if err != nil {
+			return audit.Filter{}, errors.Trace(err)
+		}
+		filter.OriginName = tag.String()
+	}
+	if args.ModelTag != "" {
+		tag, err := names.ParseModelTag(args.ModelTag)
+		if err != nil {
+			return audit.Filter{}, errors.Trace(err)
+		}
+		filter.ModelUUID = tag.Id()
+	}
+	if args.After != nil {
+		filter.After = *args.After
+	}
+	if args.Before != nil {
+		filter.Before = *args.Before
+	}
+	if err := filter.Validate(); err != nil {
+		return audit.Filter{}, errors.Trace(err)
+	}
+	return filter, nil
+}
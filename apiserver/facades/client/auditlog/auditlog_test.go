@@ -0,0 +1,129 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditLogSuite struct {
+	jtesting.IsolationSuite
+
+	backend *mockBackend
+	auth    apiservertesting.FakeAuthorizer
+	api     *auditlog.API
+}
+
+var _ = gc.Suite(&auditLogSuite{})
+
+func (s *auditLogSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.auth = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("superuser-bob"),
+	}
+	api, err := auditlog.NewAPI(s.backend, s.auth)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresSuperuser(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin-bob"),
+	}
+	_, err := auditlog.NewAPI(s.backend, auth)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestNewAPIRequiresClient(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	_, err := auditlog.NewAPI(s.backend, auth)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *auditLogSuite) TestQuery(c *gc.C) {
+	timestamp := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.backend.entries = []audit.AuditEntry{{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         coretesting.ModelTag.Id(),
+		Timestamp:         timestamp,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "user",
+		OriginName:        "user-bob",
+		Operation:         "Client:v1 - FullStatus",
+		Data:              map[string]interface{}{"request-id": 1},
+	}}
+
+	after := timestamp.Add(-time.Hour)
+	before := timestamp.Add(time.Hour)
+	result, err := s.api.Query(params.AuditLogFilter{
+		UserTag:   "user-bob",
+		ModelTag:  coretesting.ModelTag.String(),
+		Operation: "status",
+		After:     &after,
+		Before:    &before,
+		Limit:     10,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.AuditLogResult{
+		Entries: []params.AuditLogEntry{{
+			JujuServerVersion: "2.3.0",
+			ModelTag:          coretesting.ModelTag.String(),
+			Timestamp:         timestamp,
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "user",
+			OriginName:        "user-bob",
+			Operation:         "Client:v1 - FullStatus",
+			Data:              map[string]interface{}{"request-id": 1},
+		}},
+	})
+	s.backend.CheckCallNames(c, "AuditEntries")
+	s.backend.CheckCall(c, 0, "AuditEntries", audit.Filter{
+		OriginName: "user-bob",
+		ModelUUID:  coretesting.ModelTag.Id(),
+		Operation:  "status",
+		After:      after,
+		Before:     before,
+		Limit:      10,
+	})
+}
+
+func (s *auditLogSuite) TestQueryInvalidUserTag(c *gc.C) {
+	_, err := s.api.Query(params.AuditLogFilter{UserTag: "machine-0"})
+	c.Assert(err, gc.ErrorMatches, `"machine-0" is not a valid user tag`)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryInvalidTimeRange(c *gc.C) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	_, err := s.api.Query(params.AuditLogFilter{
+		After:  &now,
+		Before: &earlier,
+	})
+	c.Assert(err, gc.ErrorMatches, "After must be earlier than Before")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *auditLogSuite) TestQueryError(c *gc.C) {
+	s.backend.SetErrors(errors.New("boom"))
+	_, err := s.api.Query(params.AuditLogFilter{})
+	c.Assert(err, gc.ErrorMatches, "boom")
+}
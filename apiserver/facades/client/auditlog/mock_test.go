@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog_test
+
+import (
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	entries []audit.AuditEntry
+}
+
+func (m *mockBackend) ControllerTag() names.ControllerTag {
+	return coretesting.ControllerTag
+}
+
+func (m *mockBackend) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	m.MethodCall(m, "AuditEntries", filter)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.entries, nil
+}
@@ -0,0 +1,22 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package auditlog
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// AuditLog facade.
+type Backend interface {
+	ControllerTag() names.ControllerTag
+	AuditEntries(audit.Filter) ([]audit.AuditEntry, error)
+}
+
+type stateShim struct {
+	*state.State
+}
@@ -129,6 +129,7 @@ func ResultFromMetadata(meta *backups.Metadata) params.BackupsMetadataResult {
 		result.Finished = *meta.Finished
 	}
 	result.Notes = meta.Notes
+	result.Encrypted = meta.Encrypted
 
 	result.Model = meta.Origin.Model
 	result.Machine = meta.Origin.Machine
@@ -163,6 +164,7 @@ func MetadataFromResult(result params.BackupsMetadataResult) *backups.Metadata {
 	meta.Origin.Version = result.Version
 	meta.Origin.Series = result.Series
 	meta.Notes = result.Notes
+	meta.Encrypted = result.Encrypted
 	meta.SetFileInfo(result.Size, result.Checksum, result.ChecksumFormat)
 	return meta
 }
@@ -12,7 +12,10 @@ import (
 	"github.com/juju/juju/state/backups"
 )
 
-var waitUntilReady = replicaset.WaitUntilReady
+var (
+	waitUntilReady    = replicaset.WaitUntilReady
+	openRemoteStorage = backups.OpenRemoteStorage
+)
 
 // Create is the API method that requests juju to create a new backup
 // of its state.  It returns the metadata for that backup.
@@ -53,7 +56,17 @@ func (a *API) Create(args params.BackupsCreateArgs) (p params.BackupsMetadataRes
 	}
 	meta.Notes = args.Notes
 
-	err = backupsMethods.Create(meta, a.paths, dbInfo)
+	opts := backups.CreateOptions{
+		EncryptionKey: args.EncryptionKey,
+	}
+	if args.UploadTo != "" {
+		opts.Remote, err = openRemoteStorage(args.UploadTo, args.UploadCredentials)
+		if err != nil {
+			return p, errors.Trace(err)
+		}
+	}
+
+	err = backupsMethods.Create(meta, a.paths, dbInfo, opts)
 	if err != nil {
 		return p, errors.Trace(err)
 	}
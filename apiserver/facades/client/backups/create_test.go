@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facades/client/backups"
 	"github.com/juju/juju/apiserver/params"
+	statebackups "github.com/juju/juju/state/backups"
 )
 
 func (s *backupsSuite) TestCreateOkay(c *gc.C) {
@@ -53,3 +54,50 @@ func (s *backupsSuite) TestCreateError(c *gc.C) {
 	c.Logf("%v", err)
 	c.Check(err, gc.ErrorMatches, "failed!")
 }
+
+type fakeRemoteStorage struct {
+	statebackups.RemoteStorage
+}
+
+func (s *backupsSuite) TestCreateEncryptAndUpload(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	remote := &fakeRemoteStorage{}
+	var location string
+	var creds map[string]string
+	s.PatchValue(backups.OpenRemoteStorage,
+		func(loc string, credentials map[string]string) (statebackups.RemoteStorage, error) {
+			location, creds = loc, credentials
+			return remote, nil
+		},
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		EncryptionKey:     "sekrit",
+		UploadTo:          "s3://bucket/backups",
+		UploadCredentials: map[string]string{"access-key": "AKID", "secret-key": "shh"},
+	}
+	_, err := s.api.Create(args)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(location, gc.Equals, "s3://bucket/backups")
+	c.Check(creds, jc.DeepEquals, map[string]string{"access-key": "AKID", "secret-key": "shh"})
+	c.Check(fake.CreateOptionsArg, gc.Equals, statebackups.CreateOptions{
+		EncryptionKey: "sekrit",
+		Remote:        remote,
+	})
+}
+
+func (s *backupsSuite) TestCreateUploadBadLocation(c *gc.C) {
+	s.PatchValue(backups.WaitUntilReady,
+		func(*mgo.Session, int) error { return nil },
+	)
+	fake := s.setBackups(c, s.meta, "")
+	args := params.BackupsCreateArgs{
+		UploadTo: "ftp://example.com/backups",
+	}
+	_, err := s.api.Create(args)
+	c.Check(err, gc.ErrorMatches, `backup storage location "ftp://example.com/backups" not supported`)
+	c.Check(fake.Calls, gc.HasLen, 0)
+}
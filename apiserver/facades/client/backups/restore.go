@@ -69,6 +69,7 @@ func (a *API) Restore(p params.RestoreArgs) error {
 		NewInstId:      instanceId,
 		NewInstTag:     machine.Tag(),
 		NewInstSeries:  machine.Series(),
+		EncryptionKey:  p.EncryptionKey,
 	}
 
 	session := a.backend.MongoSession().Copy()
@@ -5,31 +5,58 @@
 package bundle
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/juju/bundlechanges"
+	"github.com/juju/description"
 	"github.com/juju/errors"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/yaml.v2"
 
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/instance"
+	"github.com/juju/juju/permission"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/storage"
 )
 
-// NewFacade provides the required signature for facade registration.
-func NewFacade(_ *state.State, _ facade.Resources, auth facade.Authorizer) (Bundle, error) {
-	return NewBundle(auth)
+// NewFacadeV1 provides the signature required for facade registration
+// of version 1 of the Bundle facade.
+func NewFacadeV1(st *state.State, resources facade.Resources, auth facade.Authorizer) (*APIv1, error) {
+	api, err := NewFacadeV2(st, resources, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv1{api}, nil
+}
+
+// NewFacadeV2 provides the signature required for facade registration
+// of version 2 of the Bundle facade.
+func NewFacadeV2(st *state.State, _ facade.Resources, auth facade.Authorizer) (*APIv2, error) {
+	api, err := NewBundleAPI(stateShim{st}, auth)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &APIv2{api}, nil
 }
 
-// NewBundle creates and returns a new Bundle API facade.
-func NewBundle(auth facade.Authorizer) (Bundle, error) {
+// NewBundleAPI creates and returns a new Bundle API facade.
+func NewBundleAPI(st Backend, auth facade.Authorizer) (*BundleAPI, error) {
 	if !auth.AuthClient() {
 		return nil, common.ErrPerm
 	}
-	return &bundleAPI{}, nil
+	return &BundleAPI{
+		backend:    st,
+		authorizer: auth,
+	}, nil
 }
 
 // Bundle defines the API endpoint used to retrieve bundle changes.
@@ -37,16 +64,55 @@ type Bundle interface {
 	// GetChanges returns the list of changes required to deploy the given
 	// bundle data.
 	GetChanges(params.BundleChangesParams) (params.BundleChangesResults, error)
+
+	// ExportBundle returns the current model as bundle YAML.
+	ExportBundle() (params.StringResult, error)
+}
+
+// BundleAPI implements the Bundle interface and is the concrete
+// implementation of the API end point.
+type BundleAPI struct {
+	backend    Backend
+	authorizer facade.Authorizer
+}
+
+// APIv2 implements version 2 of the Bundle API, which adds ExportBundle.
+type APIv2 struct {
+	*BundleAPI
 }
 
-// bundleAPI implements the Bundle interface and is the concrete implementation
-// of the API end point.
-type bundleAPI struct{}
+// APIv1 implements version 1 of the Bundle API.
+type APIv1 struct {
+	*APIv2
+}
+
+var (
+	_ Bundle = (*BundleAPI)(nil)
+	_ Bundle = (*APIv2)(nil)
+)
+
+// ExportBundle isn't on the V1 API.
+//
+// Mask the ExportBundle method from the v1 API. The API reflection code
+// in rpc/rpcreflect/type.go:newMethod skips 2-argument methods, so this
+// removes the method as far as the RPC machinery is concerned.
+func (*APIv1) ExportBundle(_, _ struct{}) {}
+
+func (b *BundleAPI) checkCanRead() error {
+	canRead, err := b.authorizer.HasPermission(permission.ReadAccess, b.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !canRead {
+		return common.ErrPerm
+	}
+	return nil
+}
 
 // GetChanges returns the list of changes required to deploy the given bundle
 // data. The changes are sorted by requirements, so that they can be applied in
 // order.
-func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
+func (b *BundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleChangesResults, error) {
 	var results params.BundleChangesResults
 	data, err := charm.ReadBundleData(strings.NewReader(args.BundleDataYAML))
 	if err != nil {
@@ -83,3 +149,223 @@ func (b *bundleAPI) GetChanges(args params.BundleChangesParams) (params.BundleCh
 	}
 	return results, nil
 }
+
+// ExportBundle exports the current model as a bundle that can be
+// passed to "juju deploy". Applications, their charms, config,
+// constraints, endpoint bindings, storage directives and exposure are
+// included, along with relations and the machines hosting the units.
+// Bundles cannot express expose settings that restrict access, so
+// models with such applications cannot be exported.
+func (b *BundleAPI) ExportBundle() (params.StringResult, error) {
+	fail := func(err error) (params.StringResult, error) {
+		return params.StringResult{Error: common.ServerError(err)}, nil
+	}
+	if err := b.checkCanRead(); err != nil {
+		return params.StringResult{}, err
+	}
+
+	model, err := b.backend.ExportPartial(state.ExportConfig{
+		SkipActions:                true,
+		SkipCloudImageMetadata:     true,
+		SkipCredentials:            true,
+		SkipIPAddresses:            true,
+		SkipSSHHostKeys:            true,
+		SkipStatusHistory:          true,
+		SkipLinkLayerDevices:       true,
+		SkipFirewallRules:          true,
+		SkipEgressRules:            true,
+		SkipUnsupportedConstraints: true,
+		SkipCharmUpgradeHolds:      true,
+	})
+	if err != nil {
+		return fail(errors.Trace(err))
+	}
+
+	data, err := bundleDataFromModel(model)
+	if err != nil {
+		return fail(errors.Trace(err))
+	}
+	bytes, err := yaml.Marshal(data)
+	if err != nil {
+		return fail(errors.Trace(err))
+	}
+	return params.StringResult{Result: string(bytes)}, nil
+}
+
+// bundleDataFromModel builds bundle data describing the applications,
+// machines and relations in the given model.
+func bundleDataFromModel(model description.Model) (*charm.BundleData, error) {
+	if len(model.Applications()) == 0 {
+		return nil, errors.NewNotFound(nil, "nothing to export as there are no applications")
+	}
+
+	data := &charm.BundleData{
+		Applications: make(map[string]*charm.ApplicationSpec),
+	}
+	if value, ok := model.Config()["default-series"]; ok {
+		data.Series = fmt.Sprint(value)
+	}
+
+	// The machines that host units are the only ones of interest. Units
+	// deployed into containers are placed with a container directive
+	// referring to the top level machine.
+	machineIds := set.NewStrings()
+	for _, application := range model.Applications() {
+		spec := &charm.ApplicationSpec{
+			Charm:            application.CharmURL(),
+			Expose:           application.Exposed(),
+			Options:          application.Settings(),
+			Annotations:      application.Annotations(),
+			Constraints:      constraintsString(application.Constraints()),
+			EndpointBindings: endpointBindings(application.EndpointBindings()),
+			Storage:          storageDirectives(application.StorageConstraints()),
+		}
+		if application.Series() != data.Series {
+			spec.Series = application.Series()
+		}
+		if !application.Subordinate() {
+			units := application.Units()
+			sort.Sort(unitsByName(units))
+			spec.NumUnits = len(units)
+			for _, unit := range units {
+				machineId := unit.Machine().Id()
+				to, topLevel := placement(machineId)
+				machineIds.Add(topLevel)
+				spec.To = append(spec.To, to)
+			}
+		}
+		data.Applications[application.Name()] = spec
+	}
+
+	for _, machine := range model.Machines() {
+		if !machineIds.Contains(machine.Id()) {
+			continue
+		}
+		if data.Machines == nil {
+			data.Machines = make(map[string]*charm.MachineSpec)
+		}
+		spec := &charm.MachineSpec{
+			Annotations: machine.Annotations(),
+			Constraints: constraintsString(machine.Constraints()),
+		}
+		if machine.Series() != data.Series {
+			spec.Series = machine.Series()
+		}
+		data.Machines[machine.Id()] = spec
+	}
+
+	for _, relation := range model.Relations() {
+		var endpoints []string
+		for _, ep := range relation.Endpoints() {
+			// Peer relations are established automatically on deploy.
+			if ep.Role() == string(charm.RolePeer) {
+				continue
+			}
+			endpoints = append(endpoints, ep.ApplicationName()+":"+ep.Name())
+		}
+		if len(endpoints) != 0 {
+			data.Relations = append(data.Relations, endpoints)
+		}
+	}
+	return data, nil
+}
+
+// placement returns the bundle placement directive for a unit assigned
+// to the given machine, along with the id of the top level machine
+// that must be declared in the bundle.
+func placement(machineId string) (string, string) {
+	if !names.IsContainerMachine(machineId) {
+		return machineId, machineId
+	}
+	parts := strings.Split(machineId, "/")
+	parent := strings.Join(parts[:len(parts)-2], "/")
+	if names.IsContainerMachine(parent) {
+		// Nested containers cannot be expressed in a bundle, so
+		// place the unit in a new container on the top level machine.
+		parent = strings.Split(parent, "/")[0]
+	}
+	containerType := parts[len(parts)-2]
+	return containerType + ":" + parent, parent
+}
+
+// endpointBindings returns the bindings with unbound endpoints,
+// which use the model default space, removed.
+func endpointBindings(bindings map[string]string) map[string]string {
+	var result map[string]string
+	for endpoint, space := range bindings {
+		if space == "" {
+			continue
+		}
+		if result == nil {
+			result = make(map[string]string)
+		}
+		result[endpoint] = space
+	}
+	return result
+}
+
+// storageDirectives converts storage constraints to the
+// "pool,count,size" form accepted in bundles.
+func storageDirectives(cons map[string]description.StorageConstraint) map[string]string {
+	if len(cons) == 0 {
+		return nil
+	}
+	result := make(map[string]string)
+	for name, c := range cons {
+		result[name] = fmt.Sprintf("%s,%d,%dM", c.Pool(), c.Count(), c.Size())
+	}
+	return result
+}
+
+// constraintsString returns the string representation of the
+// given exported constraints.
+func constraintsString(cons description.Constraints) string {
+	if cons == nil {
+		return ""
+	}
+	var result constraints.Value
+	if arch := cons.Architecture(); arch != "" {
+		result.Arch = &arch
+	}
+	if container := instance.ContainerType(cons.Container()); container != "" {
+		result.Container = &container
+	}
+	if cores := cons.CpuCores(); cores != 0 {
+		result.CpuCores = &cores
+	}
+	if power := cons.CpuPower(); power != 0 {
+		result.CpuPower = &power
+	}
+	if inst := cons.InstanceType(); inst != "" {
+		result.InstanceType = &inst
+	}
+	if mem := cons.Memory(); mem != 0 {
+		result.Mem = &mem
+	}
+	if disk := cons.RootDisk(); disk != 0 {
+		result.RootDisk = &disk
+	}
+	if spaces := cons.Spaces(); len(spaces) > 0 {
+		result.Spaces = &spaces
+	}
+	if tags := cons.Tags(); len(tags) > 0 {
+		result.Tags = &tags
+	}
+	if virt := cons.VirtType(); virt != "" {
+		result.VirtType = &virt
+	}
+	return result.String()
+}
+
+type unitsByName []description.Unit
+
+func (u unitsByName) Len() int      { return len(u) }
+func (u unitsByName) Swap(i, j int) { u[i], u[j] = u[j], u[i] }
+func (u unitsByName) Less(i, j int) bool {
+	return unitNumber(u[i].Name()) < unitNumber(u[j].Name())
+}
+
+func unitNumber(unitName string) int {
+	number, _ := strconv.Atoi(unitName[strings.LastIndex(unitName, "/")+1:])
+	return number
+}
@@ -4,18 +4,28 @@
 package bundle_test
 
 import (
+	"strings"
+
+	"github.com/juju/description"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/facades/client/bundle"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
 	coretesting "github.com/juju/juju/testing"
 )
 
 type bundleSuite struct {
 	coretesting.BaseSuite
+	auth   *apiservertesting.FakeAuthorizer
+	st     *mockState
 	facade bundle.Bundle
 }
 
@@ -23,10 +33,16 @@ var _ = gc.Suite(&bundleSuite{})
 
 func (s *bundleSuite) SetUpTest(c *gc.C) {
 	s.BaseSuite.SetUpTest(c)
-	auth := apiservertesting.FakeAuthorizer{
-		Tag: names.NewUserTag("who"),
+	s.auth = &apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("read"),
+	}
+	s.st = &mockState{
+		model: description.NewModel(description.ModelArgs{
+			Owner:  names.NewUserTag("magic"),
+			Config: map[string]interface{}{"default-series": "xenial"},
+		}),
 	}
-	facade, err := bundle.NewBundle(auth)
+	facade, err := bundle.NewBundleAPI(s.st, s.auth)
 	c.Assert(err, jc.ErrorIsNil)
 	s.facade = facade
 }
@@ -198,3 +214,179 @@ func (s *bundleSuite) TestGetChangesBundleEndpointBindingsSuccess(c *gc.C) {
 		}
 	}
 }
+
+func (s *bundleSuite) TestExportBundleNoApplications(c *gc.C) {
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "nothing to export as there are no applications")
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotFound)
+	s.st.CheckCallNames(c, "ExportPartial")
+}
+
+func (s *bundleSuite) TestExportBundleExportError(c *gc.C) {
+	s.st.SetErrors(errors.New("boom"))
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, "boom")
+}
+
+func (s *bundleSuite) TestExportBundleRestrictedExposeSettings(c *gc.C) {
+	s.st.SetErrors(errors.NotSupportedf(`exporting restricted expose settings of application "mysql"`))
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.ErrorMatches, `exporting restricted expose settings of application "mysql" not supported`)
+	c.Assert(result.Error, jc.Satisfies, params.IsCodeNotSupported)
+}
+
+func (s *bundleSuite) TestExportBundlePermissionDenied(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("someone")
+	s.auth.AdminTag = names.NewUserTag("admin")
+	s.auth.HasWriteTag = names.NewUserTag("write")
+	_, err := s.facade.ExportBundle()
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+	s.st.CheckNoCalls(c)
+}
+
+func (s *bundleSuite) TestExportBundle(c *gc.C) {
+	wordpress := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("wordpress"),
+		Series:   "xenial",
+		CharmURL: "cs:xenial/wordpress-5",
+		Exposed:  true,
+		Settings: map[string]interface{}{"blog-title": "Juju"},
+		EndpointBindings: map[string]string{
+			"url":     "public",
+			"db":      "",
+			"logging": "",
+		},
+		StorageConstraints: map[string]description.StorageConstraintArgs{
+			"uploads": {Pool: "ebs", Size: 10240, Count: 1},
+		},
+	})
+	wordpress.SetConstraints(description.ConstraintsArgs{Memory: 2048})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/1"),
+		Machine: names.NewMachineTag("1/lxd/0"),
+	})
+	wordpress.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("wordpress/0"),
+		Machine: names.NewMachineTag("0"),
+	})
+	mysql := s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:      names.NewApplicationTag("mysql"),
+		Series:   "trusty",
+		CharmURL: "cs:trusty/mysql-42",
+	})
+	mysql.AddUnit(description.UnitArgs{
+		Tag:     names.NewUnitTag("mysql/0"),
+		Machine: names.NewMachineTag("2"),
+	})
+	s.st.model.AddApplication(description.ApplicationArgs{
+		Tag:         names.NewApplicationTag("logging"),
+		Series:      "xenial",
+		CharmURL:    "cs:xenial/logging-1",
+		Subordinate: true,
+	})
+	s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("0"),
+		Series: "xenial",
+	})
+	m1 := s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("1"),
+		Series: "xenial",
+	})
+	m1.SetConstraints(description.ConstraintsArgs{CpuCores: 4})
+	s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("2"),
+		Series: "trusty",
+	})
+	s.st.model.AddMachine(description.MachineArgs{
+		Id:     names.NewMachineTag("3"),
+		Series: "xenial",
+	})
+	rel := s.st.model.AddRelation(description.RelationArgs{
+		Id:  1,
+		Key: "wordpress:db mysql:server",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "wordpress",
+		Name:            "db",
+		Role:            "requirer",
+	})
+	rel.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "server",
+		Role:            "provider",
+	})
+	peer := s.st.model.AddRelation(description.RelationArgs{
+		Id:  2,
+		Key: "mysql:cluster",
+	})
+	peer.AddEndpoint(description.EndpointArgs{
+		ApplicationName: "mysql",
+		Name:            "cluster",
+		Role:            "peer",
+	})
+
+	result, err := s.facade.ExportBundle()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Error, gc.IsNil)
+	data, err := charm.ReadBundleData(strings.NewReader(result.Result))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(data, jc.DeepEquals, &charm.BundleData{
+		Series: "xenial",
+		Applications: map[string]*charm.ApplicationSpec{
+			"logging": {
+				Charm: "cs:xenial/logging-1",
+			},
+			"mysql": {
+				Charm:    "cs:trusty/mysql-42",
+				Series:   "trusty",
+				NumUnits: 1,
+				To:       []string{"2"},
+			},
+			"wordpress": {
+				Charm:       "cs:xenial/wordpress-5",
+				NumUnits:    2,
+				To:          []string{"0", "lxd:1"},
+				Expose:      true,
+				Options:     map[string]interface{}{"blog-title": "Juju"},
+				Constraints: "mem=2048M",
+				Storage:     map[string]string{"uploads": "ebs,1,10240M"},
+				EndpointBindings: map[string]string{
+					"url": "public",
+				},
+			},
+		},
+		Machines: map[string]*charm.MachineSpec{
+			"0": {},
+			"1": {Constraints: "cores=4"},
+			"2": {Series: "trusty"},
+		},
+		Relations: [][]string{
+			{"wordpress:db", "mysql:server"},
+		},
+	})
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	c.Assert(data.Verify(verifyConstraints, verifyStorage), jc.ErrorIsNil)
+	s.st.CheckCall(c, 0, "ExportPartial", state.ExportConfig{
+		SkipActions:                true,
+		SkipCloudImageMetadata:     true,
+		SkipCredentials:            true,
+		SkipIPAddresses:            true,
+		SkipSSHHostKeys:            true,
+		SkipStatusHistory:          true,
+		SkipLinkLayerDevices:       true,
+		SkipFirewallRules:          true,
+		SkipEgressRules:            true,
+		SkipUnsupportedConstraints: true,
+		SkipCharmUpgradeHolds:      true,
+	})
+}
@@ -0,0 +1,29 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle_test
+
+import (
+	"github.com/juju/description"
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+type mockState struct {
+	jtesting.Stub
+	model description.Model
+}
+
+func (m *mockState) ModelTag() names.ModelTag {
+	return names.NewModelTag("some-uuid")
+}
+
+func (m *mockState) ExportPartial(cfg state.ExportConfig) (description.Model, error) {
+	m.MethodCall(m, "ExportPartial", cfg)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.model, nil
+}
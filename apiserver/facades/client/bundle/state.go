@@ -0,0 +1,22 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package bundle
+
+import (
+	"github.com/juju/description"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// Bundle facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	ExportPartial(cfg state.ExportConfig) (description.Model, error)
+}
+
+type stateShim struct {
+	*state.State
+}
@@ -44,7 +44,9 @@ type Controller interface {
 	WatchAllModels() (params.AllWatcherId, error)
 	ModelStatus(params.Entities) (params.ModelStatusResults, error)
 	InitiateMigration(params.InitiateMigrationArgs) (params.InitiateMigrationResults, error)
+	PrecheckMigration(params.InitiateMigrationArgs) (params.PrecheckMigrationResults, error)
 	ModifyControllerAccess(params.ModifyControllerAccessRequest) (params.ErrorResults, error)
+	ScheduledBackupStatus() (params.ScheduledBackupStatus, error)
 }
 
 // ControllerAPI implements the environment manager interface and is
@@ -293,6 +295,25 @@ func (s *ControllerAPI) RemoveBlocks(args params.RemoveBlocksArgs) error {
 	return errors.Trace(s.state.RemoveAllBlocksForController())
 }
 
+// ScheduledBackupStatus returns the outcome of the most recent
+// scheduled backups of the controller.
+func (s *ControllerAPI) ScheduledBackupStatus() (params.ScheduledBackupStatus, error) {
+	if err := s.checkHasAdmin(); err != nil {
+		return params.ScheduledBackupStatus{}, errors.Trace(err)
+	}
+	status, err := s.state.ScheduledBackupStatus()
+	if err != nil {
+		return params.ScheduledBackupStatus{}, errors.Trace(err)
+	}
+	return params.ScheduledBackupStatus{
+		LastAttempt:  status.LastAttempt,
+		LastSuccess:  status.LastSuccess,
+		LastBackupID: status.LastBackupID,
+		LastFailure:  status.LastFailure,
+		LastError:    status.LastError,
+	}, nil
+}
+
 // WatchAllModels starts watching events for all models in the
 // controller. The returned AllWatcherId should be used with Next on the
 // AllModelWatcher endpoint to receive deltas.
@@ -392,36 +413,92 @@ func (c *ControllerAPI) InitiateMigration(reqArgs params.InitiateMigrationArgs)
 }
 
 func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string, error) {
-	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	hostedState, targetInfo, err := c.migrationSpecInfo(spec)
 	if err != nil {
-		return "", errors.Annotate(err, "model tag")
+		return "", errors.Trace(err)
 	}
+	defer hostedState.Close()
 
-	// Ensure the model exists.
-	if _, err := c.state.GetModel(modelTag); err != nil {
-		return "", errors.Annotate(err, "unable to read model")
+	// Check if the migration is likely to succeed.
+	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
+		return "", errors.Trace(err)
 	}
 
-	hostedState, err := c.state.ForModel(modelTag)
+	// Trigger the migration.
+	mig, err := hostedState.CreateMigration(state.MigrationSpec{
+		InitiatedBy: c.apiUser,
+		TargetInfo:  targetInfo,
+	})
 	if err != nil {
 		return "", errors.Trace(err)
 	}
+	return mig.Id(), nil
+}
+
+// PrecheckMigration runs all of the prechecks for migrating one or
+// more models to other controllers, without starting the migrations,
+// and reports every problem found.
+func (c *ControllerAPI) PrecheckMigration(reqArgs params.InitiateMigrationArgs) (
+	params.PrecheckMigrationResults, error,
+) {
+	out := params.PrecheckMigrationResults{
+		Results: make([]params.PrecheckMigrationResult, len(reqArgs.Specs)),
+	}
+	if err := c.checkHasAdmin(); err != nil {
+		return out, errors.Trace(err)
+	}
+
+	for i, spec := range reqArgs.Specs {
+		result := &out.Results[i]
+		result.ModelTag = spec.ModelTag
+		failures, err := c.precheckOneMigration(spec)
+		if err != nil {
+			result.Error = common.ServerError(err)
+		} else {
+			result.Failures = failures
+		}
+	}
+	return out, nil
+}
+
+func (c *ControllerAPI) precheckOneMigration(spec params.MigrationSpec) ([]string, error) {
+	hostedState, targetInfo, err := c.migrationSpecInfo(spec)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	defer hostedState.Close()
+	return runMigrationPrecheckReport(hostedState, &targetInfo)
+}
+
+// migrationSpecInfo returns the state for the model to be migrated,
+// and the target controller details, for the given migration spec.
+// It is the caller's responsibility to close the returned state.
+func (c *ControllerAPI) migrationSpecInfo(spec params.MigrationSpec) (*state.State, coremigration.TargetInfo, error) {
+	var empty coremigration.TargetInfo
+	modelTag, err := names.ParseModelTag(spec.ModelTag)
+	if err != nil {
+		return nil, empty, errors.Annotate(err, "model tag")
+	}
+
+	// Ensure the model exists.
+	if _, err := c.state.GetModel(modelTag); err != nil {
+		return nil, empty, errors.Annotate(err, "unable to read model")
+	}
 
 	// Construct target info.
 	specTarget := spec.TargetInfo
 	controllerTag, err := names.ParseControllerTag(specTarget.ControllerTag)
 	if err != nil {
-		return "", errors.Annotate(err, "controller tag")
+		return nil, empty, errors.Annotate(err, "controller tag")
 	}
 	authTag, err := names.ParseUserTag(specTarget.AuthTag)
 	if err != nil {
-		return "", errors.Annotate(err, "auth tag")
+		return nil, empty, errors.Annotate(err, "auth tag")
 	}
 	var macs []macaroon.Slice
 	if specTarget.Macaroons != "" {
 		if err := json.Unmarshal([]byte(specTarget.Macaroons), &macs); err != nil {
-			return "", errors.Annotate(err, "invalid macaroons")
+			return nil, empty, errors.Annotate(err, "invalid macaroons")
 		}
 	}
 	targetInfo := coremigration.TargetInfo{
@@ -433,20 +510,11 @@ func (c *ControllerAPI) initiateOneMigration(spec params.MigrationSpec) (string,
 		Macaroons:     macs,
 	}
 
-	// Check if the migration is likely to succeed.
-	if err := runMigrationPrechecks(hostedState, &targetInfo); err != nil {
-		return "", errors.Trace(err)
-	}
-
-	// Trigger the migration.
-	mig, err := hostedState.CreateMigration(state.MigrationSpec{
-		InitiatedBy: c.apiUser,
-		TargetInfo:  targetInfo,
-	})
+	hostedState, err := c.state.ForModel(modelTag)
 	if err != nil {
-		return "", errors.Trace(err)
+		return nil, empty, errors.Trace(err)
 	}
-	return mig.Id(), nil
+	return hostedState, targetInfo, nil
 }
 
 // ModifyControllerAccess changes the model access granted to users.
@@ -526,6 +594,56 @@ var runMigrationPrechecks = func(st *state.State, targetInfo *coremigration.Targ
 	return errors.Annotate(err, "target prechecks failed")
 }
 
+// runMigrationPrecheckReport runs all of the source and target
+// prechecks on the migration, including checking that the model can
+// be exported and then imported into the target controller, and
+// returns every problem found.
+var runMigrationPrecheckReport = func(st *state.State, targetInfo *coremigration.TargetInfo) ([]string, error) {
+	var failures []string
+
+	// Check model and source controller.
+	backend, err := migration.PrecheckShim(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "creating backend")
+	}
+	for _, err := range migration.SourcePrecheckAll(backend) {
+		failures = append(failures, "source: "+err.Error())
+	}
+	bytes, err := migration.ExportModel(st)
+	if err != nil {
+		failures = append(failures, "source: exporting model: "+err.Error())
+	}
+
+	// Check target controller. Failing to connect to it is one of
+	// the problems reported, so that the source problems found are
+	// not lost.
+	conn, err := api.Open(targetToAPIInfo(targetInfo), migration.ControllerDialOpts())
+	if err != nil {
+		failures = append(failures, "target: connecting: "+err.Error())
+		return failures, nil
+	}
+	defer conn.Close()
+	modelInfo, err := makeModelInfo(st)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	client := migrationtarget.NewClient(conn)
+	targetFailures, err := client.PrecheckReport(modelInfo, bytes)
+	if errors.IsNotSupported(err) {
+		// The target controller cannot report every problem,
+		// so report the first problem found instead.
+		if err := client.Prechecks(modelInfo); err != nil {
+			targetFailures = []string{err.Error()}
+		}
+	} else if err != nil {
+		return nil, errors.Annotate(err, "target prechecks failed")
+	}
+	for _, failure := range targetFailures {
+		failures = append(failures, "target: "+failure)
+	}
+	return failures, nil
+}
+
 func makeModelInfo(st *state.State) (coremigration.ModelInfo, error) {
 	var empty coremigration.ModelInfo
 
@@ -327,6 +327,24 @@ func (s *controllerSuite) TestRemoveBlocksNotAll(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "not supported")
 }
 
+func (s *controllerSuite) TestScheduledBackupStatus(c *gc.C) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	err := s.State.SetScheduledBackupStatus(state.ScheduledBackupStatus{
+		LastAttempt: now,
+		LastFailure: now,
+		LastError:   "boom",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	status, err := s.controller.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, params.ScheduledBackupStatus{
+		LastAttempt: now,
+		LastFailure: now,
+		LastError:   "boom",
+	})
+}
+
 func (s *controllerSuite) TestWatchAllModels(c *gc.C) {
 	watcherId, err := s.controller.WatchAllModels()
 	c.Assert(err, jc.ErrorIsNil)
@@ -550,6 +568,99 @@ func (s *controllerSuite) TestInitiateMigrationPrecheckFail(c *gc.C) {
 	c.Check(active, jc.IsFalse)
 }
 
+func (s *controllerSuite) TestPrecheckMigration(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckReport(s, []string{
+		"source: machine 0 not running",
+		"target: model with same UUID already exists",
+	}, nil)
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}, {
+			ModelTag: randomModelTag(), // Doesn't exist.
+		}},
+	}
+	out, err := s.controller.PrecheckMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 2)
+
+	c.Check(out.Results[0].ModelTag, gc.Equals, st.ModelTag().String())
+	c.Check(out.Results[0].Error, gc.IsNil)
+	c.Check(out.Results[0].Failures, jc.DeepEquals, []string{
+		"source: machine 0 not running",
+		"target: model with same UUID already exists",
+	})
+
+	c.Check(out.Results[1].ModelTag, gc.Equals, args.Specs[1].ModelTag)
+	c.Check(out.Results[1].Error, gc.ErrorMatches, "unable to read model: .+")
+
+	// No migration should have been started.
+	active, err := st.IsMigrationActive()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(active, jc.IsFalse)
+}
+
+func (s *controllerSuite) TestPrecheckMigrationError(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	controller.SetPrecheckReport(s, nil, errors.New("creating backend: boom"))
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"1.1.1.1:1111"},
+				CACert:        "cert1",
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	}
+	out, err := s.controller.PrecheckMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Failures, gc.HasLen, 0)
+	c.Check(out.Results[0].Error, gc.ErrorMatches, "creating backend: boom")
+}
+
+func (s *controllerSuite) TestPrecheckMigrationTargetUnreachable(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+
+	args := params.InitiateMigrationArgs{
+		Specs: []params.MigrationSpec{{
+			ModelTag: st.ModelTag().String(),
+			TargetInfo: params.MigrationTargetInfo{
+				ControllerTag: randomControllerTag(),
+				Addrs:         []string{"127.0.0.1:1"},
+				CACert:        testing.CACert,
+				AuthTag:       names.NewUserTag("admin1").String(),
+				Password:      "secret1",
+			},
+		}},
+	}
+	out, err := s.controller.PrecheckMigration(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out.Results, gc.HasLen, 1)
+	c.Check(out.Results[0].Error, gc.IsNil)
+	failures := out.Results[0].Failures
+	c.Assert(failures, gc.Not(gc.HasLen), 0)
+	c.Check(failures[len(failures)-1], gc.Matches, "target: connecting: .+")
+}
+
 func randomControllerTag() string {
 	uuid := utils.MustNewUUID().String()
 	return names.NewControllerTag(uuid).String()
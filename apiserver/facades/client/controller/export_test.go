@@ -17,3 +17,9 @@ func SetPrecheckResult(p patcher, err error) {
 		return err
 	})
 }
+
+func SetPrecheckReport(p patcher, failures []string, err error) {
+	p.PatchValue(&runMigrationPrecheckReport, func(*state.State, *migration.TargetInfo) ([]string, error) {
+		return failures, err
+	})
+}
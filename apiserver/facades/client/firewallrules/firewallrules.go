@@ -0,0 +1,105 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package firewallrules defines an API end point for managing the
+// firewall rules that control access to well-known services, such
+// as SSH, on a model's machines.
+package firewallrules
+
+import (
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/permission"
+	"github.com/juju/juju/state"
+)
+
+// FirewallRules defines the methods on the FirewallRules API end point.
+type FirewallRules interface {
+	SetFirewallRules(params.FirewallRuleArgs) (params.ErrorResults, error)
+	ListFirewallRules() (params.ListFirewallRulesResults, error)
+}
+
+// API implements the FirewallRules interface.
+type API struct {
+	backend    Backend
+	authorizer facade.Authorizer
+	check      BlockChecker
+}
+
+var _ FirewallRules = (*API)(nil)
+
+// NewFacade creates a new API server endpoint for managing
+// firewall rules.
+func NewFacade(ctx facade.Context) (*API, error) {
+	st := ctx.State()
+	return NewAPI(stateShim{st}, ctx.Auth(), common.NewBlockChecker(st))
+}
+
+// NewAPI returns a new FirewallRules API using the given backend.
+func NewAPI(backend Backend, authorizer facade.Authorizer, blockChecker BlockChecker) (*API, error) {
+	if !authorizer.AuthClient() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		backend:    backend,
+		authorizer: authorizer,
+		check:      blockChecker,
+	}, nil
+}
+
+func (api *API) checkPermission(perm permission.Access) error {
+	allowed, err := api.authorizer.HasPermission(perm, api.backend.ModelTag())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !allowed {
+		return common.ErrPerm
+	}
+	return nil
+}
+
+// SetFirewallRules sets the source CIDRs from which each of the
+// given well-known services may be reached.
+func (api *API) SetFirewallRules(args params.FirewallRuleArgs) (params.ErrorResults, error) {
+	if err := api.checkPermission(permission.AdminAccess); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	if err := api.check.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	results := params.ErrorResults{
+		Results: make([]params.ErrorResult, len(args.Args)),
+	}
+	for i, arg := range args.Args {
+		err := api.backend.SaveFirewallRule(state.FirewallRule{
+			WellKnownService: state.WellKnownServiceType(arg.KnownService),
+			WhitelistCIDRs:   arg.WhitelistCIDRS,
+		})
+		results.Results[i].Error = common.ServerError(err)
+	}
+	return results, nil
+}
+
+// ListFirewallRules returns all the firewall rules in the model.
+func (api *API) ListFirewallRules() (params.ListFirewallRulesResults, error) {
+	if err := api.checkPermission(permission.ReadAccess); err != nil {
+		return params.ListFirewallRulesResults{}, errors.Trace(err)
+	}
+	rules, err := api.backend.ListFirewallRules()
+	if err != nil {
+		return params.ListFirewallRulesResults{}, errors.Trace(err)
+	}
+	result := params.ListFirewallRulesResults{
+		Rules: make([]params.FirewallRule, len(rules)),
+	}
+	for i, rule := range rules {
+		result.Rules[i] = params.FirewallRule{
+			KnownService:   string(rule.WellKnownService),
+			WhitelistCIDRS: rule.WhitelistCIDRs,
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facades/client/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/state"
+)
+
+type firewallRulesSuite struct {
+	jtesting.IsolationSuite
+
+	backend      *mockBackend
+	blockChecker *mockBlockChecker
+	auth         apiservertesting.FakeAuthorizer
+	api          *firewallrules.API
+}
+
+var _ = gc.Suite(&firewallRulesSuite{})
+
+func (s *firewallRulesSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.backend = &mockBackend{}
+	s.blockChecker = &mockBlockChecker{}
+	s.auth = apiservertesting.FakeAuthorizer{
+		Tag: names.NewUserTag("admin"),
+	}
+	api, err := firewallrules.NewAPI(s.backend, s.auth, s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api = api
+}
+
+func (s *firewallRulesSuite) TestNewAPIRequiresClient(c *gc.C) {
+	auth := apiservertesting.FakeAuthorizer{
+		Tag: names.NewMachineTag("0"),
+	}
+	_, err := firewallrules.NewAPI(s.backend, auth, s.blockChecker)
+	c.Assert(err, gc.Equals, common.ErrPerm)
+}
+
+func (s *firewallRulesSuite) TestSetFirewallRules(c *gc.C) {
+	s.backend.SetErrors(nil, errors.NotValidf("well known service type %q", "telnet"))
+	results, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{
+			KnownService:   "ssh",
+			WhitelistCIDRS: []string{"10.0.0.0/8"},
+		}, {
+			KnownService:   "telnet",
+			WhitelistCIDRS: []string{"10.0.0.0/8"},
+		}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 2)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	c.Assert(results.Results[1].Error, gc.ErrorMatches, `well known service type "telnet" not valid`)
+	s.blockChecker.CheckCallNames(c, "ChangeAllowed")
+	s.backend.CheckCalls(c, []jtesting.StubCall{
+		{"SaveFirewallRule", []interface{}{state.FirewallRule{
+			WellKnownService: state.SSHRule,
+			WhitelistCIDRs:   []string{"10.0.0.0/8"},
+		}}},
+		{"SaveFirewallRule", []interface{}{state.FirewallRule{
+			WellKnownService: "telnet",
+			WhitelistCIDRs:   []string{"10.0.0.0/8"},
+		}}},
+	})
+}
+
+func (s *firewallRulesSuite) TestSetFirewallRulesRequiresAdmin(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("write")
+	api, err := firewallrules.NewAPI(s.backend, s.auth, s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = api.SetFirewallRules(params.FirewallRuleArgs{})
+	c.Assert(err, gc.Equals, common.ErrPerm)
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *firewallRulesSuite) TestSetFirewallRulesBlocked(c *gc.C) {
+	s.blockChecker.SetErrors(errors.New("blocked"))
+	_, err := s.api.SetFirewallRules(params.FirewallRuleArgs{
+		Args: []params.FirewallRule{{KnownService: "ssh"}},
+	})
+	c.Assert(err, gc.ErrorMatches, "blocked")
+	s.backend.CheckNoCalls(c)
+}
+
+func (s *firewallRulesSuite) TestListFirewallRules(c *gc.C) {
+	s.auth.Tag = names.NewUserTag("read")
+	api, err := firewallrules.NewAPI(s.backend, s.auth, s.blockChecker)
+	c.Assert(err, jc.ErrorIsNil)
+	s.backend.rules = []*state.FirewallRule{{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	}, {
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}}
+	result, err := api.ListFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ListFirewallRulesResults{
+		Rules: []params.FirewallRule{{
+			KnownService:   "juju-controller",
+			WhitelistCIDRS: []string{"192.168.1.0/24"},
+		}, {
+			KnownService:   "ssh",
+			WhitelistCIDRS: []string{"10.0.0.0/8"},
+		}},
+	})
+}
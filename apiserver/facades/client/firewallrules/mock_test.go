@@ -0,0 +1,43 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules_test
+
+import (
+	jtesting "github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type mockBackend struct {
+	jtesting.Stub
+	rules []*state.FirewallRule
+}
+
+func (m *mockBackend) ModelTag() names.ModelTag {
+	return coretesting.ModelTag
+}
+
+func (m *mockBackend) SaveFirewallRule(rule state.FirewallRule) error {
+	m.MethodCall(m, "SaveFirewallRule", rule)
+	return m.NextErr()
+}
+
+func (m *mockBackend) ListFirewallRules() ([]*state.FirewallRule, error) {
+	m.MethodCall(m, "ListFirewallRules")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rules, nil
+}
+
+type mockBlockChecker struct {
+	jtesting.Stub
+}
+
+func (m *mockBlockChecker) ChangeAllowed() error {
+	m.MethodCall(m, "ChangeAllowed")
+	return m.NextErr()
+}
@@ -0,0 +1,37 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewallrules
+
+import (
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/state"
+)
+
+// Backend defines the state functionality required by the
+// FirewallRules facade.
+type Backend interface {
+	ModelTag() names.ModelTag
+	SaveFirewallRule(state.FirewallRule) error
+	ListFirewallRules() ([]*state.FirewallRule, error)
+}
+
+// BlockChecker defines the block-checking functionality required by
+// the FirewallRules facade. This is implemented by
+// apiserver/common.BlockChecker.
+type BlockChecker interface {
+	ChangeAllowed() error
+}
+
+type stateShim struct {
+	*state.State
+}
+
+func (s stateShim) SaveFirewallRule(rule state.FirewallRule) error {
+	return state.NewFirewallRules(s.State).Save(rule)
+}
+
+func (s stateShim) ListFirewallRules() ([]*state.FirewallRule, error) {
+	return state.NewFirewallRules(s.State).AllRules()
+}
@@ -410,6 +410,11 @@ func (m *ModelManagerAPI) dumpModel(args params.Entity, simplified bool) ([]byte
 		exportConfig.SkipSSHHostKeys = true
 		exportConfig.SkipStatusHistory = true
 		exportConfig.SkipLinkLayerDevices = true
+		exportConfig.SkipExposeSettings = true
+		exportConfig.SkipFirewallRules = true
+		exportConfig.SkipEgressRules = true
+		exportConfig.SkipUnsupportedConstraints = true
+		exportConfig.SkipCharmUpgradeHolds = true
 	}
 
 	model, err := st.ExportPartial(exportConfig)
@@ -8,9 +8,11 @@ import (
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
 	jujustorage "github.com/juju/juju/storage"
+	coretesting "github.com/juju/juju/testing"
 )
 
 type mockPoolManager struct {
@@ -47,6 +49,7 @@ type mockState struct {
 	storageInstanceFilesystemAttachment func(m names.MachineTag, f names.FilesystemTag) (state.FilesystemAttachment, error)
 	watchStorageAttachment              func(names.StorageTag, names.UnitTag) state.NotifyWatcher
 	watchFilesystemAttachment           func(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
+	watchFilesystem                     func(names.FilesystemTag) state.NotifyWatcher
 	watchVolumeAttachment               func(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 	watchBlockDevices                   func(names.MachineTag) state.NotifyWatcher
 	modelName                           string
@@ -65,6 +68,8 @@ type mockState struct {
 	destroyStorageInstance              func(names.StorageTag, bool) error
 	attachStorage                       func(names.StorageTag, names.UnitTag) error
 	detachStorage                       func(names.StorageTag, names.UnitTag) error
+	restoreVolumeInfo                   func(names.VolumeTag, state.VolumeInfo) error
+	resizeVolume                        func(names.VolumeTag, uint64) error
 }
 
 func (st *mockState) StorageInstance(s names.StorageTag) (state.StorageInstance, error) {
@@ -107,6 +112,10 @@ func (st *mockState) WatchFilesystemAttachment(mtag names.MachineTag, f names.Fi
 	return st.watchFilesystemAttachment(mtag, f)
 }
 
+func (st *mockState) WatchFilesystem(f names.FilesystemTag) state.NotifyWatcher {
+	return st.watchFilesystem(f)
+}
+
 func (st *mockState) WatchVolumeAttachment(mtag names.MachineTag, v names.VolumeTag) state.NotifyWatcher {
 	return st.watchVolumeAttachment(mtag, v)
 }
@@ -123,6 +132,26 @@ func (st *mockState) ModelTag() names.ModelTag {
 	return st.modelTag
 }
 
+func (st *mockState) ModelUUID() string {
+	return st.modelTag.Id()
+}
+
+func (st *mockState) ControllerUUID() string {
+	return coretesting.ControllerTag.Id()
+}
+
+func (st *mockState) ModelConfig() (*config.Config, error) {
+	return config.New(config.UseDefaults, coretesting.FakeConfig())
+}
+
+func (st *mockState) RestoreVolumeInfo(tag names.VolumeTag, info state.VolumeInfo) error {
+	return st.restoreVolumeInfo(tag, info)
+}
+
+func (st *mockState) ResizeVolume(tag names.VolumeTag, size uint64) error {
+	return st.resizeVolume(tag, size)
+}
+
 func (st *mockState) AllVolumes() ([]state.Volume, error) {
 	return st.allVolumes()
 }
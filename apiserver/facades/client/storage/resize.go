@@ -0,0 +1,90 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
+)
+
+// ResizeStorage requests that the volumes backing the specified storage
+// instances be grown to the specified sizes. The storage provisioner
+// responsible for each volume will resize it, after which the charm is
+// notified so that it may make use of the additional space.
+func (a *APIv6) ResizeStorage(args params.ResizeStorageArgs) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	resizeOne := func(arg params.ResizeStorageArg) error {
+		storageTag, err := names.ParseStorageTag(arg.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := a.storage.StorageInstance(storageTag); err != nil {
+			return errors.Trace(err)
+		}
+		volume, err := a.storage.StorageInstanceVolume(storageTag)
+		if errors.IsNotFound(err) {
+			return errors.NotSupportedf(
+				"resizing %s, which is not backed by a volume,",
+				names.ReadableString(storageTag),
+			)
+		} else if err != nil {
+			return errors.Trace(err)
+		}
+		info, err := volume.Info()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := a.checkResizable(info.Pool); err != nil {
+			return errors.Trace(err)
+		}
+		return a.storage.ResizeVolume(volume.VolumeTag(), arg.Size)
+	}
+
+	results := make([]params.ErrorResult, len(args.Args))
+	for i, arg := range args.Args {
+		results[i].Error = common.ServerError(resizeOne(arg))
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// checkResizable returns an error satisfying errors.IsNotSupported if
+// volumes in the specified pool cannot be resized. Machine-scoped
+// volume sources can only be created on the machine, so only
+// model-scoped volume sources are checked here.
+func (a *APIv6) checkResizable(poolName string) error {
+	providerType, cfg, err := storagecommon.StoragePoolConfig(poolName, a.poolManager, a.registry)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !provider.Dynamic() {
+		return errors.NotSupportedf("resizing %q storage", providerType)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if _, ok := source.(storage.VolumeResizer); !ok {
+		return errors.NotSupportedf("resizing %q storage", providerType)
+	}
+	return nil
+}
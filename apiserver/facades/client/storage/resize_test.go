@@ -0,0 +1,91 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type resizeSuite struct {
+	baseStorageSuite
+
+	apiv6   *storage.APIv6
+	resized map[names.VolumeTag]uint64
+}
+
+var _ = gc.Suite(&resizeSuite{})
+
+func (s *resizeSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.registry.Providers["modelscoped"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return &dummystorage.VolumeSource{}, nil
+		},
+	}
+	s.registry.Providers["static"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+	}
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "modelscoped",
+		Size:     1024,
+	}
+	s.resized = make(map[names.VolumeTag]uint64)
+	s.state.resizeVolume = func(tag names.VolumeTag, size uint64) error {
+		s.resized[tag] = size
+		return nil
+	}
+
+	var err error
+	s.apiv6, err = storage.NewAPIv6(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *resizeSuite) TestResizeStorage(c *gc.C) {
+	results, err := s.apiv6.ResizeStorage(params.ResizeStorageArgs{
+		Args: []params.ResizeStorageArg{
+			{StorageTag: s.storageTag.String(), Size: 2048},
+			{StorageTag: "storage-foo-0", Size: 2048},
+			{StorageTag: "volume-0", Size: 2048},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{Code: params.CodeNotFound, Message: "storage foo/0 not found"}},
+			{Error: &params.Error{Message: `"volume-0" is not a valid storage tag`}},
+		},
+	})
+	c.Assert(s.resized, jc.DeepEquals, map[names.VolumeTag]uint64{s.volumeTag: 2048})
+}
+
+func (s *resizeSuite) TestResizeStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestResizeStorageBlocked")
+	_, err := s.apiv6.ResizeStorage(params.ResizeStorageArgs{
+		Args: []params.ResizeStorageArg{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	s.assertBlocked(c, err, "TestResizeStorageBlocked")
+}
+
+func (s *resizeSuite) TestResizeStorageNotSupported(c *gc.C) {
+	s.volume.info.Pool = "static"
+	results, err := s.apiv6.ResizeStorage(params.ResizeStorageArgs{
+		Args: []params.ResizeStorageArg{{StorageTag: s.storageTag.String(), Size: 2048}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, `resizing "static" storage not supported`)
+	c.Assert(s.resized, gc.HasLen, 0)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/juju/juju/apiserver/facade"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/storage/poolmanager"
@@ -19,6 +20,36 @@ import (
 // to change any part of it so that it were no longer *obviously* and
 // *trivially* correct, you would be Doing It Wrong.
 
+// NewFacadeV6 provides the signature required for facade registration.
+func NewFacadeV6(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	return NewAPIv6(getState(st), registry, pm, resources, authorizer)
+}
+
+// NewFacadeV5 provides the signature required for facade registration.
+func NewFacadeV5(
+	st *state.State,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	env, err := stateenvirons.GetNewEnvironFunc(environs.New)(st)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting environ")
+	}
+	registry := stateenvirons.NewStorageProviderRegistry(env)
+	pm := poolmanager.New(state.NewStateSettings(st), registry)
+	return NewAPIv5(getState(st), registry, pm, resources, authorizer)
+}
+
 // NewFacadeV4 provides the signature required for facade registration.
 func NewFacadeV4(
 	st *state.State,
@@ -80,6 +111,9 @@ type storageAccess interface {
 	// WatchFilesystemAttachment is required for storage functionality.
 	WatchFilesystemAttachment(names.MachineTag, names.FilesystemTag) state.NotifyWatcher
 
+	// WatchFilesystem is required for storage functionality.
+	WatchFilesystem(names.FilesystemTag) state.NotifyWatcher
+
 	// WatchVolumeAttachment is required for storage functionality.
 	WatchVolumeAttachment(names.MachineTag, names.VolumeTag) state.NotifyWatcher
 
@@ -95,6 +129,15 @@ type storageAccess interface {
 	// ModelTag is required for model permission checking.
 	ModelTag() names.ModelTag
 
+	// ModelUUID is required for snapshot functionality.
+	ModelUUID() string
+
+	// ControllerUUID is required for snapshot functionality.
+	ControllerUUID() string
+
+	// ModelConfig is required for snapshot functionality.
+	ModelConfig() (*config.Config, error)
+
 	// AllVolumes is required for volume functionality.
 	AllVolumes() ([]state.Volume, error)
 
@@ -107,6 +150,12 @@ type storageAccess interface {
 	// Volume is required for volume functionality.
 	Volume(tag names.VolumeTag) (state.Volume, error)
 
+	// RestoreVolumeInfo is required for snapshot functionality.
+	RestoreVolumeInfo(tag names.VolumeTag, info state.VolumeInfo) error
+
+	// ResizeVolume is required for resize functionality.
+	ResizeVolume(tag names.VolumeTag, size uint64) error
+
 	// AllFilesystems is required for filesystem functionality.
 	AllFilesystems() ([]state.Filesystem, error)
 
@@ -0,0 +1,353 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/errors"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/common/storagecommon"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/storage"
+)
+
+// SnapshotStorage creates point-in-time snapshots of the volumes backing
+// the specified storage instances. Only storage backed by model-scoped
+// volumes, whose provider supports snapshots, may be snapshotted.
+func (a *APIv5) SnapshotStorage(args params.Entities) (params.StorageSnapshotResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.StorageSnapshotResults{}, errors.Trace(err)
+	}
+
+	snapshotOne := func(arg params.Entity) (*params.StorageSnapshot, error) {
+		sv, err := a.storageVolume(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results, err := sv.snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+			Volume:       sv.volume.VolumeTag(),
+			VolumeId:     sv.info.VolumeId,
+			ResourceTags: sv.resourceTags,
+		}})
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(results) != 1 {
+			return nil, errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if results[0].Error != nil {
+			return nil, errors.Trace(results[0].Error)
+		}
+		snapshot := sv.snapshotFromStorage(*results[0].Snapshot)
+		return &snapshot, nil
+	}
+
+	results := make([]params.StorageSnapshotResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshot, err := snapshotOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshot
+	}
+	return params.StorageSnapshotResults{results}, nil
+}
+
+// ListStorageSnapshots returns the snapshots of the volumes backing the
+// specified storage instances.
+func (a *APIv5) ListStorageSnapshots(args params.Entities) (params.StorageSnapshotsResults, error) {
+	if err := a.checkCanRead(); err != nil {
+		return params.StorageSnapshotsResults{}, errors.Trace(err)
+	}
+
+	listOne := func(arg params.Entity) ([]params.StorageSnapshot, error) {
+		sv, err := a.storageVolume(arg.Tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		snapshots, err := sv.snapshots()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		result := make([]params.StorageSnapshot, len(snapshots))
+		for i, snapshot := range snapshots {
+			result[i] = sv.snapshotFromStorage(snapshot)
+		}
+		return result, nil
+	}
+
+	results := make([]params.StorageSnapshotsResult, len(args.Entities))
+	for i, arg := range args.Entities {
+		snapshots, err := listOne(arg)
+		if err != nil {
+			results[i].Error = common.ServerError(err)
+			continue
+		}
+		results[i].Result = snapshots
+	}
+	return params.StorageSnapshotsResults{results}, nil
+}
+
+// RemoveStorageSnapshots deletes the specified storage snapshots.
+func (a *APIv5) RemoveStorageSnapshots(args params.StorageSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.RemoveAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	removeOne := func(arg params.StorageSnapshotId) error {
+		sv, err := a.storageVolume(arg.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if err := sv.checkSnapshot(arg.SnapshotId); err != nil {
+			return errors.Trace(err)
+		}
+		results, err := sv.snapshotter.DeleteVolumeSnapshots([]string{arg.SnapshotId})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(results) != 1 {
+			return errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		return errors.Trace(results[0])
+	}
+
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, arg := range args.Ids {
+		results[i].Error = common.ServerError(removeOne(arg))
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// RestoreStorage replaces the volumes backing the specified storage
+// instances with new volumes created from the specified snapshots.
+// The storage must not be attached to any machine. The original
+// volumes are destroyed only once the replacements have been
+// recorded in state.
+func (a *APIv5) RestoreStorage(args params.StorageSnapshotIds) (params.ErrorResults, error) {
+	if err := a.checkCanWrite(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+	blockChecker := common.NewBlockChecker(a.storage)
+	if err := blockChecker.ChangeAllowed(); err != nil {
+		return params.ErrorResults{}, errors.Trace(err)
+	}
+
+	restoreOne := func(arg params.StorageSnapshotId) error {
+		sv, err := a.storageVolume(arg.StorageTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		volumeTag := sv.volume.VolumeTag()
+		attachments, err := a.storage.VolumeAttachments(volumeTag)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(attachments) > 0 {
+			return errors.Errorf(
+				"%s is attached to %s; detach it before restoring",
+				names.ReadableString(sv.storageTag),
+				names.ReadableString(attachments[0].Machine()),
+			)
+		}
+		if err := sv.checkSnapshot(arg.SnapshotId); err != nil {
+			return errors.Trace(err)
+		}
+		results, err := sv.snapshotter.RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+			Volume:       volumeTag,
+			VolumeId:     sv.info.VolumeId,
+			SnapshotId:   arg.SnapshotId,
+			Size:         sv.info.Size,
+			Attributes:   sv.config.Attrs(),
+			ResourceTags: sv.resourceTags,
+		}})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if len(results) != 1 {
+			return errors.Errorf("expected 1 result, got %d", len(results))
+		}
+		if results[0].Error != nil {
+			return errors.Trace(results[0].Error)
+		}
+		info := results[0].VolumeInfo
+		if err := a.storage.RestoreVolumeInfo(volumeTag, state.VolumeInfo{
+			HardwareId: info.HardwareId,
+			WWN:        info.WWN,
+			Size:       info.Size,
+			VolumeId:   info.VolumeId,
+			Persistent: info.Persistent,
+		}); err != nil {
+			// State still refers to the original volume, so it is
+			// the replacement that must go.
+			if err := sv.destroyVolume(info.VolumeId); err != nil {
+				logger.Errorf("error cleaning up volume %v: %v", info.VolumeId, err)
+			}
+			return errors.Trace(err)
+		}
+		if err := sv.destroyVolume(sv.info.VolumeId); err != nil {
+			return errors.Annotatef(
+				err, "%s restored, but destroying the original volume %v",
+				names.ReadableString(sv.storageTag), sv.info.VolumeId,
+			)
+		}
+		return nil
+	}
+
+	results := make([]params.ErrorResult, len(args.Ids))
+	for i, arg := range args.Ids {
+		results[i].Error = common.ServerError(restoreOne(arg))
+	}
+	return params.ErrorResults{results}, nil
+}
+
+// storageVolume holds the details required to manage snapshots of
+// the volume backing a storage instance.
+type storageVolume struct {
+	storageTag   names.StorageTag
+	volume       state.Volume
+	info         state.VolumeInfo
+	config       *storage.Config
+	resourceTags map[string]string
+	source       storage.VolumeSource
+	snapshotter  storage.VolumeSnapshotter
+}
+
+// storageVolume returns the volume backing the storage instance with
+// the given tag, along with a VolumeSnapshotter for managing its
+// snapshots.
+func (a *APIv5) storageVolume(tag string) (*storageVolume, error) {
+	storageTag, err := names.ParseStorageTag(tag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	storageInstance, err := a.storage.StorageInstance(storageTag)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volume, err := a.storage.StorageInstanceVolume(storageTag)
+	if errors.IsNotFound(err) {
+		return nil, errors.NotSupportedf(
+			"snapshotting %s, which is not backed by a volume,",
+			names.ReadableString(storageTag),
+		)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := volume.Info()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	providerType, cfg, err := storagecommon.StoragePoolConfig(info.Pool, a.poolManager, a.registry)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	provider, err := a.registry.StorageProvider(providerType)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if provider.Scope() != storage.ScopeEnviron {
+		return nil, errors.NotSupportedf("snapshotting machine-scoped storage")
+	}
+	source, err := provider.VolumeSource(cfg)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	snapshotter, ok := source.(storage.VolumeSnapshotter)
+	if !ok {
+		return nil, errors.NotSupportedf("snapshotting %q storage", providerType)
+	}
+
+	modelConfig, err := a.storage.ModelConfig()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags, err := storagecommon.StorageTags(
+		storageInstance,
+		a.storage.ModelUUID(),
+		a.storage.ControllerUUID(),
+		modelConfig,
+	)
+	if err != nil {
+		return nil, errors.Annotate(err, "computing storage tags")
+	}
+	return &storageVolume{
+		storageTag:   storageTag,
+		volume:       volume,
+		info:         info,
+		config:       cfg,
+		resourceTags: resourceTags,
+		source:       source,
+		snapshotter:  snapshotter,
+	}, nil
+}
+
+// snapshots returns the snapshots of the storage volume, including
+// those taken before the volume was last restored.
+func (sv *storageVolume) snapshots() ([]storage.VolumeSnapshot, error) {
+	results, err := sv.snapshotter.ListVolumeSnapshots([]names.VolumeTag{sv.volume.VolumeTag()})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(results) != 1 {
+		return nil, errors.Errorf("expected 1 result, got %d", len(results))
+	}
+	if results[0].Error != nil {
+		return nil, errors.Trace(results[0].Error)
+	}
+	return results[0].Snapshots, nil
+}
+
+// destroyVolume destroys the volume with the given provider ID.
+func (sv *storageVolume) destroyVolume(volumeId string) error {
+	errs, err := sv.source.DestroyVolumes([]string{volumeId})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(errs) != 1 {
+		return errors.Errorf("expected 1 result, got %d", len(errs))
+	}
+	return errors.Trace(errs[0])
+}
+
+// checkSnapshot returns an error satisfying errors.IsNotFound if the
+// snapshot with the given ID was not taken of the storage volume.
+func (sv *storageVolume) checkSnapshot(snapshotId string) error {
+	snapshots, err := sv.snapshots()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, snapshot := range snapshots {
+		if snapshot.SnapshotId == snapshotId {
+			return nil
+		}
+	}
+	return errors.NotFoundf(
+		"snapshot %q of %s",
+		snapshotId, names.ReadableString(sv.storageTag),
+	)
+}
+
+func (sv *storageVolume) snapshotFromStorage(snapshot storage.VolumeSnapshot) params.StorageSnapshot {
+	return params.StorageSnapshot{
+		StorageTag: sv.storageTag.String(),
+		VolumeTag:  sv.volume.VolumeTag().String(),
+		SnapshotId: snapshot.SnapshotId,
+		Size:       snapshot.Size,
+		Status:     snapshot.Status,
+		Created:    snapshot.Created,
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/facades/client/storage"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/state"
+	jujustorage "github.com/juju/juju/storage"
+	dummystorage "github.com/juju/juju/storage/provider/dummy"
+)
+
+type snapshotSuite struct {
+	baseStorageSuite
+
+	apiv5        *storage.APIv5
+	volumeSource *dummystorage.VolumeSource
+}
+
+var _ = gc.Suite(&snapshotSuite{})
+
+func (s *snapshotSuite) SetUpTest(c *gc.C) {
+	s.baseStorageSuite.SetUpTest(c)
+	s.volumeSource = &dummystorage.VolumeSource{}
+	s.registry.Providers["modelscoped"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeEnviron,
+		IsDynamic:    true,
+		VolumeSourceFunc: func(*jujustorage.Config) (jujustorage.VolumeSource, error) {
+			return s.volumeSource, nil
+		},
+	}
+	s.registry.Providers["machinescoped"] = &dummystorage.StorageProvider{
+		StorageScope: jujustorage.ScopeMachine,
+		IsDynamic:    true,
+	}
+	s.volume.info = &state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "modelscoped",
+		Size:     1024,
+	}
+
+	var err error
+	s.apiv5, err = storage.NewAPIv5(s.state, s.registry, s.poolManager, s.resources, s.authorizer)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *snapshotSuite) snapshot(c *gc.C) params.StorageSnapshot {
+	results, err := s.apiv5.SnapshotStorage(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.IsNil)
+	return *results.Results[0].Result
+}
+
+func (s *snapshotSuite) TestSnapshotStorage(c *gc.C) {
+	results, err := s.apiv5.SnapshotStorage(params.Entities{
+		Entities: []params.Entity{
+			{Tag: s.storageTag.String()},
+			{Tag: "storage-foo-0"},
+			{Tag: "volume-0"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotResults{
+		Results: []params.StorageSnapshotResult{{
+			Result: &params.StorageSnapshot{
+				StorageTag: s.storageTag.String(),
+				VolumeTag:  s.volumeTag.String(),
+				SnapshotId: "snap-0",
+				Status:     "completed",
+			},
+		}, {
+			Error: &params.Error{Code: params.CodeNotFound, Message: "storage foo/0 not found"},
+		}, {
+			Error: &params.Error{Message: `"volume-0" is not a valid storage tag`},
+		}},
+	})
+
+	s.volumeSource.CheckCallNames(c, "CreateVolumeSnapshots")
+	args := s.volumeSource.Calls()[0].Args[0].([]jujustorage.VolumeSnapshotParams)
+	c.Assert(args, gc.HasLen, 1)
+	c.Assert(args[0].Volume, gc.Equals, s.volumeTag)
+	c.Assert(args[0].VolumeId, gc.Equals, "vol-0")
+	c.Assert(args[0].ResourceTags[tags.JujuStorageInstance], gc.Equals, "data/0")
+	c.Assert(args[0].ResourceTags[tags.JujuStorageOwner], gc.Equals, "mysql/0")
+}
+
+func (s *snapshotSuite) TestSnapshotStorageBlocked(c *gc.C) {
+	s.blockAllChanges(c, "TestSnapshotStorageBlocked")
+	_, err := s.apiv5.SnapshotStorage(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	s.assertBlocked(c, err, "TestSnapshotStorageBlocked")
+}
+
+func (s *snapshotSuite) TestSnapshotStorageMachineScoped(c *gc.C) {
+	s.volume.info.Pool = "machinescoped"
+	results, err := s.apiv5.SnapshotStorage(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "snapshotting machine-scoped storage not supported")
+}
+
+func (s *snapshotSuite) TestSnapshotStorageUnprovisioned(c *gc.C) {
+	s.volume.info = nil
+	results, err := s.apiv5.SnapshotStorage(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "volume-22 not provisioned")
+}
+
+func (s *snapshotSuite) TestListStorageSnapshots(c *gc.C) {
+	snapshot := s.snapshot(c)
+	results, err := s.apiv5.ListStorageSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotsResults{
+		Results: []params.StorageSnapshotsResult{{
+			Result: []params.StorageSnapshot{snapshot},
+		}},
+	})
+}
+
+func (s *snapshotSuite) TestRemoveStorageSnapshots(c *gc.C) {
+	snapshot := s.snapshot(c)
+	results, err := s.apiv5.RemoveStorageSnapshots(params.StorageSnapshotIds{
+		Ids: []params.StorageSnapshotId{
+			{StorageTag: s.storageTag.String(), SnapshotId: snapshot.SnapshotId},
+			{StorageTag: s.storageTag.String(), SnapshotId: "snap-42"},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{
+			{},
+			{Error: &params.Error{
+				Code:    params.CodeNotFound,
+				Message: `snapshot "snap-42" of storage data/0 not found`,
+			}},
+		},
+	})
+	s.volumeSource.CheckCallNames(c,
+		"CreateVolumeSnapshots",
+		"ListVolumeSnapshots", "DeleteVolumeSnapshots",
+		"ListVolumeSnapshots",
+	)
+	s.volumeSource.CheckCall(c, 2, "DeleteVolumeSnapshots", []string{snapshot.SnapshotId})
+}
+
+func (s *snapshotSuite) TestRemoveStorageSnapshotsBlocked(c *gc.C) {
+	s.blockRemoveObject(c, "TestRemoveStorageSnapshotsBlocked")
+	_, err := s.apiv5.RemoveStorageSnapshots(params.StorageSnapshotIds{
+		Ids: []params.StorageSnapshotId{{StorageTag: s.storageTag.String(), SnapshotId: "snap-0"}},
+	})
+	s.assertBlocked(c, err, "TestRemoveStorageSnapshotsBlocked")
+}
+
+func (s *snapshotSuite) TestRestoreStorage(c *gc.C) {
+	snapshot := s.snapshot(c)
+	s.state.volumeAttachments = func(names.VolumeTag) ([]state.VolumeAttachment, error) {
+		return nil, nil
+	}
+	var restored *state.VolumeInfo
+	s.state.restoreVolumeInfo = func(tag names.VolumeTag, info state.VolumeInfo) error {
+		c.Assert(tag, gc.Equals, s.volumeTag)
+		restored = &info
+		return nil
+	}
+	s.volumeSource.DestroyVolumesFunc = func(volIds []string) ([]error, error) {
+		// The original volume must only be destroyed once state
+		// refers to its replacement.
+		c.Assert(restored, gc.NotNil)
+		return make([]error, len(volIds)), nil
+	}
+
+	results, err := s.apiv5.RestoreStorage(params.StorageSnapshotIds{
+		Ids: []params.StorageSnapshotId{
+			{StorageTag: s.storageTag.String(), SnapshotId: snapshot.SnapshotId},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.ErrorResults{
+		Results: []params.ErrorResult{{}},
+	})
+	c.Assert(restored, jc.DeepEquals, &state.VolumeInfo{
+		VolumeId:   "vol-0-snap-0",
+		Size:       1024,
+		Persistent: true,
+	})
+	s.volumeSource.CheckCallNames(c,
+		"CreateVolumeSnapshots", "ListVolumeSnapshots",
+		"RestoreVolumeSnapshots", "DestroyVolumes",
+	)
+	s.volumeSource.CheckCall(c, 3, "DestroyVolumes", []string{"vol-0"})
+}
+
+func (s *snapshotSuite) TestRestoreStorageStateFails(c *gc.C) {
+	snapshot := s.snapshot(c)
+	s.state.volumeAttachments = func(names.VolumeTag) ([]state.VolumeAttachment, error) {
+		return nil, nil
+	}
+	s.state.restoreVolumeInfo = func(tag names.VolumeTag, info state.VolumeInfo) error {
+		return errors.New("boom")
+	}
+	s.volumeSource.DestroyVolumesFunc = func(volIds []string) ([]error, error) {
+		return make([]error, len(volIds)), nil
+	}
+
+	results, err := s.apiv5.RestoreStorage(params.StorageSnapshotIds{
+		Ids: []params.StorageSnapshotId{
+			{StorageTag: s.storageTag.String(), SnapshotId: snapshot.SnapshotId},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches, "boom")
+
+	// The replacement is destroyed, and the original left alone.
+	s.volumeSource.CheckCallNames(c,
+		"CreateVolumeSnapshots", "ListVolumeSnapshots",
+		"RestoreVolumeSnapshots", "DestroyVolumes",
+	)
+	s.volumeSource.CheckCall(c, 3, "DestroyVolumes", []string{"vol-0-snap-0"})
+}
+
+func (s *snapshotSuite) TestListStorageSnapshotsAfterRestore(c *gc.C) {
+	snapshot := s.snapshot(c)
+
+	// Restoring replaces the provider volume; the snapshots taken
+	// before then must still be found.
+	s.volume.info.VolumeId = "vol-0-snap-0"
+	results, err := s.apiv5.ListStorageSnapshots(params.Entities{
+		Entities: []params.Entity{{Tag: s.storageTag.String()}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, params.StorageSnapshotsResults{
+		Results: []params.StorageSnapshotsResult{{
+			Result: []params.StorageSnapshot{snapshot},
+		}},
+	})
+	s.volumeSource.CheckCall(c, 1, "ListVolumeSnapshots", []names.VolumeTag{s.volumeTag})
+}
+
+func (s *snapshotSuite) TestRestoreStorageAttached(c *gc.C) {
+	snapshot := s.snapshot(c)
+	results, err := s.apiv5.RestoreStorage(params.StorageSnapshotIds{
+		Ids: []params.StorageSnapshotId{
+			{StorageTag: s.storageTag.String(), SnapshotId: snapshot.SnapshotId},
+		},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results.Results, gc.HasLen, 1)
+	c.Assert(results.Results[0].Error, gc.ErrorMatches,
+		"storage data/0 is attached to machine 66; detach it before restoring",
+	)
+	s.volumeSource.CheckCallNames(c, "CreateVolumeSnapshots")
+}
@@ -7,6 +7,7 @@ package storage
 
 import (
 	"github.com/juju/errors"
+	"github.com/juju/loggo"
 	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
@@ -21,6 +22,8 @@ import (
 	"github.com/juju/juju/storage/poolmanager"
 )
 
+var logger = loggo.GetLogger("juju.apiserver.storage")
+
 // APIv3 implements the storage v3 API.
 type APIv3 struct {
 	storage     storageAccess
@@ -34,6 +37,46 @@ type APIv4 struct {
 	*APIv3
 }
 
+// APIv5 implements the storage v5 API.
+type APIv5 struct {
+	*APIv4
+}
+
+// APIv6 implements the storage v6 API.
+type APIv6 struct {
+	*APIv5
+}
+
+// NewAPIv6 returns a new storage v6 API facade.
+func NewAPIv6(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv6, error) {
+	apiv5, err := NewAPIv5(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv6{apiv5}, nil
+}
+
+// NewAPIv5 returns a new storage v5 API facade.
+func NewAPIv5(
+	st storageAccess,
+	registry storage.ProviderRegistry,
+	pm poolmanager.PoolManager,
+	resources facade.Resources,
+	authorizer facade.Authorizer,
+) (*APIv5, error) {
+	apiv4, err := NewAPIv4(st, registry, pm, resources, authorizer)
+	if err != nil {
+		return nil, err
+	}
+	return &APIv5{apiv4}, nil
+}
+
 // NewAPIv4 returns a new storage v4 API facade.
 func NewAPIv4(
 	st storageAccess,
@@ -0,0 +1,40 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionpruner
+
+import (
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/state"
+)
+
+// API is the concrete implementation of the ActionPruner endpoint.
+type API struct {
+	*common.ModelWatcher
+	st         *state.State
+	authorizer facade.Authorizer
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, r facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{
+		ModelWatcher: common.NewModelWatcher(st, r, auth),
+		st:           st,
+		authorizer:   auth,
+	}, nil
+}
+
+// Prune endpoint removes completed actions, and their results, until
+// only the ones completed after now - p.MaxHistoryTime remain and
+// the actions collection is smaller than p.MaxHistoryMB.
+func (api *API) Prune(p params.ActionPruneArgs) error {
+	if !api.authorizer.AuthController() {
+		return common.ErrPerm
+	}
+	return state.PruneActions(api.st, p.MaxHistoryTime, p.MaxHistoryMB)
+}
@@ -0,0 +1,41 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+
+	"github.com/juju/juju/apiserver/common"
+	"github.com/juju/juju/apiserver/facade"
+	"github.com/juju/juju/state"
+)
+
+var logger = loggo.GetLogger("juju.apiserver.actionscheduler")
+
+// API is the concrete implementation of the ActionScheduler endpoint.
+type API struct {
+	st *state.State
+}
+
+// NewAPI returns an API Instance.
+func NewAPI(st *state.State, _ facade.Resources, auth facade.Authorizer) (*API, error) {
+	if !auth.AuthController() {
+		return nil, common.ErrPerm
+	}
+	return &API{st: st}, nil
+}
+
+// RunDueActions enqueues the actions for every action schedule in the
+// model whose next run time has passed.
+func (api *API) RunDueActions() error {
+	count, err := api.st.RunDueActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if count > 0 {
+		logger.Debugf("enqueued %d scheduled actions", count)
+	}
+	return nil
+}
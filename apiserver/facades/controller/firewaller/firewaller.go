@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/names.v2"
 
@@ -48,6 +49,21 @@ type FirewallerAPIV4 struct {
 	*common.ControllerConfigAPI
 }
 
+// FirewallerAPIV5 provides access to the Firewaller v5 API facade.
+type FirewallerAPIV5 struct {
+	*FirewallerAPIV4
+}
+
+// FirewallerAPIV6 provides access to the Firewaller v6 API facade.
+type FirewallerAPIV6 struct {
+	*FirewallerAPIV5
+}
+
+// FirewallerAPIV7 provides access to the Firewaller v7 API facade.
+type FirewallerAPIV7 struct {
+	*FirewallerAPIV6
+}
+
 // NewStateFirewallerAPIv3 creates a new server-side FirewallerAPIV3 facade.
 func NewStateFirewallerAPIV3(context facade.Context) (*FirewallerAPIV3, error) {
 	st := context.State()
@@ -68,6 +84,33 @@ func NewStateFirewallerAPIV4(context facade.Context) (*FirewallerAPIV4, error) {
 	}, nil
 }
 
+// NewStateFirewallerAPIV5 creates a new server-side FirewallerAPIV5 facade.
+func NewStateFirewallerAPIV5(context facade.Context) (*FirewallerAPIV5, error) {
+	facadev4, err := NewStateFirewallerAPIV4(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV5{facadev4}, nil
+}
+
+// NewStateFirewallerAPIV6 creates a new server-side FirewallerAPIV6 facade.
+func NewStateFirewallerAPIV6(context facade.Context) (*FirewallerAPIV6, error) {
+	facadev5, err := NewStateFirewallerAPIV5(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV6{facadev5}, nil
+}
+
+// NewStateFirewallerAPIV7 creates a new server-side FirewallerAPIV7 facade.
+func NewStateFirewallerAPIV7(context facade.Context) (*FirewallerAPIV7, error) {
+	facadev6, err := NewStateFirewallerAPIV6(context)
+	if err != nil {
+		return nil, err
+	}
+	return &FirewallerAPIV7{facadev6}, nil
+}
+
 // NewFirewallerAPI creates a new server-side FirewallerAPIV3 facade.
 func NewFirewallerAPI(
 	st State,
@@ -312,6 +355,220 @@ func (f *FirewallerAPIV3) GetExposed(args params.Entities) (params.BoolResults,
 	return result, nil
 }
 
+// GetExposeInfo returns, for each given application, whether it is
+// exposed and, if so, the source CIDRs from which its open ports may
+// be accessed. Spaces the application is exposed to are resolved to
+// the CIDRs of their subnets.
+func (f *FirewallerAPIV5) GetExposeInfo(args params.Entities) (params.ExposeInfoResults, error) {
+	result := params.ExposeInfoResults{
+		Results: make([]params.ExposeInfoResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.ExposeInfoResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			result.Results[i], err = f.exposeInfo(application)
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+func (f *FirewallerAPIV5) exposeInfo(application *state.Application) (params.ExposeInfoResult, error) {
+	exposedEndpoints := application.ExposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return params.ExposeInfoResult{}, nil
+	}
+	cidrs := set.NewStrings()
+	for _, settings := range exposedEndpoints {
+		cidrs = cidrs.Union(set.NewStrings(settings.ExposeToCIDRs...))
+		for _, spaceName := range settings.ExposeToSpaces {
+			space, err := f.st.Space(spaceName)
+			if err != nil {
+				return params.ExposeInfoResult{}, errors.Trace(err)
+			}
+			subnets, err := space.Subnets()
+			if err != nil {
+				return params.ExposeInfoResult{}, errors.Trace(err)
+			}
+			for _, subnet := range subnets {
+				cidrs.Add(subnet.CIDR())
+			}
+		}
+	}
+	return params.ExposeInfoResult{
+		Exposed:     true,
+		SourceCIDRs: cidrs.SortedValues(),
+	}, nil
+}
+
+// WatchSubnets returns a StringsWatcher that notifies of changes to
+// the model's subnets, and so to the source CIDRs of applications
+// exposed to spaces.
+func (f *FirewallerAPIV5) WatchSubnets() (params.StringsWatchResult, error) {
+	watch := f.st.WatchSubnets(nil)
+	// Consume the initial event and forward it to the result.
+	if changes, ok := <-watch.Changes(); ok {
+		return params.StringsWatchResult{
+			StringsWatcherId: f.resources.Register(watch),
+			Changes:          changes,
+		}, nil
+	}
+	return params.StringsWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// Watch starts a NotifyWatcher for each given application or machine.
+// Machines are watched so that model firewall rules may be applied
+// to them once they are provisioned.
+func (f *FirewallerAPIV6) Watch(args params.Entities) (params.NotifyWatchResults, error) {
+	entityWatcher := common.NewAgentEntityWatcher(
+		f.st,
+		f.resources,
+		common.AuthAny(f.accessApplication, f.accessMachine),
+	)
+	return entityWatcher.Watch(args)
+}
+
+// WatchModelFirewallRules returns a NotifyWatcher that notifies of
+// changes to the model's firewall rules.
+func (f *FirewallerAPIV6) WatchModelFirewallRules() (params.NotifyWatchResult, error) {
+	watch := f.st.WatchFirewallRules()
+	// Consume the initial event.
+	if _, ok := <-watch.Changes(); ok {
+		return params.NotifyWatchResult{
+			NotifyWatcherId: f.resources.Register(watch),
+		}, nil
+	}
+	return params.NotifyWatchResult{}, watcher.EnsureErr(watch)
+}
+
+// ModelFirewallRules returns, for each given machine, the ingress
+// rules required by the model's firewall rules. The ssh rule applies
+// to every machine, and the juju-controller rule applies to the API
+// port on controller machines.
+func (f *FirewallerAPIV6) ModelFirewallRules(args params.Entities) (params.IngressRulesResults, error) {
+	result := params.IngressRulesResults{
+		Results: make([]params.IngressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessMachine()
+	if err != nil {
+		return params.IngressRulesResults{}, err
+	}
+	rules, err := f.st.FirewallRules()
+	if err != nil {
+		return params.IngressRulesResults{}, errors.Trace(err)
+	}
+	controllerConfig, err := f.st.ControllerConfig()
+	if err != nil {
+		return params.IngressRulesResults{}, errors.Trace(err)
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseMachineTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		machine, err := f.getMachine(canAccess, tag)
+		if err == nil {
+			result.Results[i].Rules = machineFirewallRules(machine, rules, controllerConfig.APIPort())
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
+// ModelIngressRules returns the ingress rules required by the model's
+// firewall rules for the whole model. The firewaller uses these to
+// restrict the access to SSH and the API port which providers allow
+// from anywhere by default.
+func (f *FirewallerAPIV6) ModelIngressRules() (params.IngressRulesResult, error) {
+	rules, err := f.st.FirewallRules()
+	if err != nil {
+		return params.IngressRulesResult{}, errors.Trace(err)
+	}
+	controllerConfig, err := f.st.ControllerConfig()
+	if err != nil {
+		return params.IngressRulesResult{}, errors.Trace(err)
+	}
+	var result params.IngressRulesResult
+	for _, rule := range rules {
+		if ingressRule, ok := firewallRuleIngressRule(rule, controllerConfig.APIPort()); ok {
+			result.Rules = append(result.Rules, ingressRule)
+		}
+	}
+	return result, nil
+}
+
+// machineFirewallRules returns the ingress rules for the well-known
+// services provided on the specified machine.
+func machineFirewallRules(machine *state.Machine, rules []*state.FirewallRule, apiPort int) []params.IngressRule {
+	var result []params.IngressRule
+	for _, rule := range rules {
+		if rule.WellKnownService == state.JujuControllerRule && !machine.IsManager() {
+			continue
+		}
+		if ingressRule, ok := firewallRuleIngressRule(rule, apiPort); ok {
+			result = append(result, ingressRule)
+		}
+	}
+	return result
+}
+
+// firewallRuleIngressRule returns the ingress rule for the port of
+// the firewall rule's well-known service, and whether the service
+// is known.
+func firewallRuleIngressRule(rule *state.FirewallRule, apiPort int) (params.IngressRule, bool) {
+	var port int
+	switch rule.WellKnownService {
+	case state.SSHRule:
+		port = 22
+	case state.JujuControllerRule:
+		port = apiPort
+	default:
+		return params.IngressRule{}, false
+	}
+	return params.IngressRule{
+		PortRange:   params.PortRange{FromPort: port, ToPort: port, Protocol: "tcp"},
+		SourceCIDRs: rule.WhitelistCIDRs,
+	}, true
+}
+
+// GetEgressRules returns, for each given application, the destinations
+// and ports to which the application's machines may send outgoing
+// traffic.
+func (f *FirewallerAPIV7) GetEgressRules(args params.Entities) (params.EgressRulesResults, error) {
+	result := params.EgressRulesResults{
+		Results: make([]params.EgressRulesResult, len(args.Entities)),
+	}
+	canAccess, err := f.accessApplication()
+	if err != nil {
+		return params.EgressRulesResults{}, err
+	}
+	for i, entity := range args.Entities {
+		tag, err := names.ParseApplicationTag(entity.Tag)
+		if err != nil {
+			result.Results[i].Error = common.ServerError(common.ErrPerm)
+			continue
+		}
+		application, err := f.getApplication(canAccess, tag)
+		if err == nil {
+			for _, rule := range application.EgressRules() {
+				result.Results[i].Rules = append(result.Results[i].Rules, params.FromNetworkEgressRule(rule))
+			}
+		}
+		result.Results[i].Error = common.ServerError(err)
+	}
+	return result, nil
+}
+
 // GetAssignedMachine returns the assigned machine tag (if any) for
 // each given unit.
 func (f *FirewallerAPIV3) GetAssignedMachine(args params.Entities) (params.StringResults, error) {
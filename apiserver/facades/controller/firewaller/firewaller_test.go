@@ -17,6 +17,7 @@ import (
 	"github.com/juju/juju/apiserver/facades/controller/firewaller"
 	"github.com/juju/juju/apiserver/params"
 	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/stateenvirons"
 	statetesting "github.com/juju/juju/state/testing"
@@ -83,6 +84,259 @@ func (s *firewallerSuite) TestGetExposed(c *gc.C) {
 	s.testGetExposed(c, s.firewaller)
 }
 
+func (s *firewallerSuite) TestGetExposeInfo(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("office", "", []string{"192.168.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"office"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	facade := &firewaller.FirewallerAPIV5{
+		FirewallerAPIV4: &firewaller.FirewallerAPIV4{FirewallerAPIV3: s.firewaller},
+	}
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: "application-mysql"},
+	}})
+	result, err := facade.GetExposeInfo(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.ExposeInfoResults{
+		Results: []params.ExposeInfoResult{
+			{Exposed: true, SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+			{Exposed: false},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Applications exposed without settings may be accessed from anywhere.
+	err = s.application.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	result, err = facade.GetExposeInfo(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Results, jc.DeepEquals, []params.ExposeInfoResult{
+		{Exposed: true, SourceCIDRs: []string{"0.0.0.0/0"}},
+	})
+}
+
+func (s *firewallerSuite) TestWatchSubnets(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	facade := &firewaller.FirewallerAPIV5{
+		FirewallerAPIV4: &firewaller.FirewallerAPIV4{FirewallerAPIV3: s.firewaller},
+	}
+	result, err := facade.WatchSubnets()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.StringsWatcherId, gc.Equals, "1")
+	c.Assert(result.Changes, jc.SameContents, []string{"10.20.30.0/24", "192.168.1.0/24"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewStringsWatcherC(c, s.State, resource.(state.StringsWatcher))
+	wc.AssertNoChange()
+
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.2.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChange("192.168.2.0/24")
+	wc.AssertNoChange()
+}
+
+func (s *firewallerSuite) newFirewallerAPIV6() *firewaller.FirewallerAPIV6 {
+	return &firewaller.FirewallerAPIV6{
+		FirewallerAPIV5: &firewaller.FirewallerAPIV5{
+			FirewallerAPIV4: &firewaller.FirewallerAPIV4{FirewallerAPIV3: s.firewaller},
+		},
+	}
+}
+
+func (s *firewallerSuite) TestGetEgressRules(c *gc.C) {
+	err := s.application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	facade := &firewaller.FirewallerAPIV7{FirewallerAPIV6: s.newFirewallerAPIV6()}
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.application.Tag().String()},
+		{Tag: "application-mysql"},
+	}})
+	result, err := facade.GetEgressRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.EgressRulesResults{
+		Results: []params.EgressRulesResult{
+			{Rules: []params.EgressRule{{
+				PortRange:        params.PortRange{FromPort: 443, ToPort: 443, Protocol: "tcp"},
+				DestinationCIDRs: []string{"10.0.0.0/8"},
+			}}},
+			{},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestWatchV6(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: s.application.Tag().String()},
+		{Tag: s.units[0].Tag().String()},
+	}})
+	result, err := s.newFirewallerAPIV6().Watch(args)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResults{
+		Results: []params.NotifyWatchResult{
+			{NotifyWatcherId: "1"},
+			{NotifyWatcherId: "2"},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError(`application "bar"`)},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+
+	// Verify the resources were registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 2)
+	watcher := s.resources.Get("1")
+	defer statetesting.AssertStop(c, watcher)
+	defer statetesting.AssertStop(c, s.resources.Get("2"))
+
+	// Check that the Watch has consumed the initial event ("returned" in
+	// the Watch call)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, watcher.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	// Provisioning the machine is reported.
+	err = s.machines[0].SetProvisioned("i-am", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *firewallerSuite) TestWatchModelFirewallRules(c *gc.C) {
+	c.Assert(s.resources.Count(), gc.Equals, 0)
+
+	result, err := s.newFirewallerAPIV6().WatchModelFirewallRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.NotifyWatchResult{NotifyWatcherId: "1"})
+
+	// Verify the resource was registered and stop when done.
+	c.Assert(s.resources.Count(), gc.Equals, 1)
+	resource := s.resources.Get("1")
+	defer statetesting.AssertStop(c, resource)
+
+	// Check that the Watch has consumed the initial event.
+	wc := statetesting.NewNotifyWatcherC(c, s.State, resource.(state.NotifyWatcher))
+	wc.AssertNoChange()
+
+	err = state.NewFirewallRules(s.State).Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+}
+
+func (s *firewallerSuite) TestModelFirewallRules(c *gc.C) {
+	controllerMachine, err := s.State.AddMachine("quantal", state.JobManageModel)
+	c.Assert(err, jc.ErrorIsNil)
+	rules := state.NewFirewallRules(s.State)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"172.16.0.0/12"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := controllerConfig.APIPort()
+
+	args := addFakeEntities(params.Entities{Entities: []params.Entity{
+		{Tag: s.machines[0].Tag().String()},
+		{Tag: controllerMachine.Tag().String()},
+		{Tag: s.application.Tag().String()},
+	}})
+	result, err := s.newFirewallerAPIV6().ModelFirewallRules(args)
+	c.Assert(err, jc.ErrorIsNil)
+	sshRule := params.IngressRule{
+		PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}
+	c.Assert(result, jc.DeepEquals, params.IngressRulesResults{
+		Results: []params.IngressRulesResult{
+			{Rules: []params.IngressRule{sshRule}},
+			{Rules: []params.IngressRule{{
+				PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+				SourceCIDRs: []string{"172.16.0.0/12"},
+			}, sshRule}},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.NotFoundError("machine 42")},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+			{Error: apiservertesting.ErrUnauthorized},
+		},
+	})
+}
+
+func (s *firewallerSuite) TestModelIngressRules(c *gc.C) {
+	result, err := s.newFirewallerAPIV6().ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result, jc.DeepEquals, params.IngressRulesResult{})
+
+	rules := state.NewFirewallRules(s.State)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"172.16.0.0/12"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	controllerConfig, err := s.State.ControllerConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := controllerConfig.APIPort()
+
+	result, err = s.newFirewallerAPIV6().ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Rules, jc.SameContents, []params.IngressRule{{
+		PortRange:   params.PortRange{FromPort: 22, ToPort: 22, Protocol: "tcp"},
+		SourceCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"},
+	}, {
+		PortRange:   params.PortRange{FromPort: apiPort, ToPort: apiPort, Protocol: "tcp"},
+		SourceCIDRs: []string{"172.16.0.0/12"},
+	}})
+}
+
 func (s *firewallerSuite) TestGetAssignedMachine(c *gc.C) {
 	s.testGetAssignedMachine(c, s.firewaller)
 }
@@ -152,6 +152,24 @@ func (st *mockState) FindEntity(tag names.Tag) (state.Entity, error) {
 	return nil, errors.NotImplementedf("FindEntity")
 }
 
+func (st *mockState) Space(name string) (*state.Space, error) {
+	st.MethodCall(st, "Space", name)
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("Space")
+}
+
+func (st *mockState) WatchFirewallRules() state.NotifyWatcher {
+	st.MethodCall(st, "WatchFirewallRules")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil
+}
+
+func (st *mockState) FirewallRules() ([]*state.FirewallRule, error) {
+	st.MethodCall(st, "FirewallRules")
+	// TODO - implement when remaining firewaller tests become unit tests
+	return nil, errors.NotImplementedf("FirewallRules")
+}
+
 func (st *mockState) GetModel(tag names.ModelTag) (*state.Model, error) {
 	st.MethodCall(st, "GetModel", tag)
 	// TODO - implement when remaining firewaller tests become unit tests
@@ -8,6 +8,7 @@ import (
 	"gopkg.in/juju/names.v2"
 	"gopkg.in/macaroon.v1"
 
+	"github.com/juju/juju/controller"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/state"
 )
@@ -37,6 +38,14 @@ type State interface {
 	WatchOpenedPorts() state.StringsWatcher
 
 	FindEntity(tag names.Tag) (state.Entity, error)
+
+	Space(name string) (*state.Space, error)
+
+	ControllerConfig() (controller.Config, error)
+
+	WatchFirewallRules() state.NotifyWatcher
+
+	FirewallRules() ([]*state.FirewallRule, error)
 }
 
 // TODO(wallyworld) - for tests, remove when remaining firewaller tests become unit tests.
@@ -58,6 +67,10 @@ func (st stateShim) GetMacaroon(model names.ModelTag, entity names.Tag) (*macaro
 	return r.GetMacaroon(model, entity)
 }
 
+func (st stateShim) FirewallRules() ([]*state.FirewallRule, error) {
+	return state.NewFirewallRules(st.State).AllRules()
+}
+
 func (st stateShim) KeyRelation(key string) (Relation, error) {
 	rel, err := st.State.KeyRelation(key)
 	if err != nil {
@@ -99,6 +99,41 @@ func (api *API) Prechecks(model params.MigrationModelInfo) error {
 	)
 }
 
+// PrecheckReport runs all of the prechecks performed by Prechecks,
+// and additionally checks that the serialized model could be imported,
+// reporting every problem found rather than stopping at the first.
+// The model is not imported.
+func (api *API) PrecheckReport(args params.MigrationPrecheckArgs) (params.MigrationPrecheckReport, error) {
+	var result params.MigrationPrecheckReport
+	ownerTag, err := names.ParseUserTag(args.Model.OwnerTag)
+	if err != nil {
+		return result, errors.Trace(err)
+	}
+	backend, err := migration.PrecheckShim(api.state)
+	if err != nil {
+		return result, errors.Annotate(err, "creating backend")
+	}
+	errs := migration.TargetPrecheckAll(
+		backend,
+		coremigration.ModelInfo{
+			UUID:                   args.Model.UUID,
+			Name:                   args.Model.Name,
+			Owner:                  ownerTag,
+			AgentVersion:           args.Model.AgentVersion,
+			ControllerAgentVersion: args.Model.ControllerAgentVersion,
+		},
+	)
+	if len(args.Bytes) > 0 {
+		// The model may not be available if the source
+		// controller failed to export it.
+		errs = append(errs, migration.ImportPrecheck(api.state, args.Bytes)...)
+	}
+	for _, err := range errs {
+		result.Failures = append(result.Failures, err.Error())
+	}
+	return result, nil
+}
+
 // Import takes a serialized Juju model, deserializes it, and
 // recreates it in the receiving controller.
 func (api *API) Import(serialized params.SerializedModel) error {
@@ -121,6 +121,52 @@ func (s *Suite) TestPrechecksFail(c *gc.C) {
 	c.Assert(err, gc.NotNil)
 }
 
+func (s *Suite) TestPrecheckReport(c *gc.C) {
+	api := s.mustNewAPI(c)
+	uuid, bytes := s.makeExportedModel(c)
+	result, err := api.PrecheckReport(params.MigrationPrecheckArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   uuid,
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           s.controllerVersion(c),
+			ControllerAgentVersion: s.controllerVersion(c),
+		},
+		Bytes: bytes,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.HasLen, 0)
+
+	// The model must not have been imported.
+	_, err = s.State.GetModel(names.NewModelTag(uuid))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *Suite) TestPrecheckReportFailures(c *gc.C) {
+	controllerVersion := s.controllerVersion(c)
+
+	// Set the model version ahead of the controller.
+	modelVersion := controllerVersion
+	modelVersion.Minor++
+
+	api := s.mustNewAPI(c)
+	result, err := api.PrecheckReport(params.MigrationPrecheckArgs{
+		Model: params.MigrationModelInfo{
+			UUID:                   s.State.ModelUUID(),
+			Name:                   "some-model",
+			OwnerTag:               names.NewUserTag("someone").String(),
+			AgentVersion:           modelVersion,
+			ControllerAgentVersion: controllerVersion,
+		},
+		Bytes: []byte("not a model"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Failures, gc.HasLen, 3)
+	c.Assert(result.Failures[0], gc.Matches, "model has higher version than target controller .*")
+	c.Assert(result.Failures[1], gc.Matches, `model with same UUID already exists .*`)
+	c.Assert(result.Failures[2], gc.Matches, "deserializing model: .*")
+}
+
 func (s *Suite) TestImport(c *gc.C) {
 	api := s.mustNewAPI(c)
 	tag := s.importModel(c, api)
@@ -136,6 +136,12 @@ func (h *logStreamEndpointHandler) newTailer(source logStreamSource, cfg params.
 
 // sendError sends a JSON-encoded error response.
 func (h *logStreamEndpointHandler) sendError(ws *websocket.Conn, req *http.Request, err error) {
+	sendStreamError(ws, req, err)
+}
+
+// sendStreamError sends a JSON-encoded error response as the first
+// message of a streaming websocket connection.
+func sendStreamError(ws *websocket.Conn, req *http.Request, err error) {
 	// There is no need to log the error for normal operators as there is nothing
 	// they can action. This is for developers.
 	if err != nil && featureflag.Enabled(feature.DeveloperMode) {
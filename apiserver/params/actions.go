@@ -53,10 +53,17 @@ type ActionResult struct {
 	Completed time.Time              `json:"completed,omitempty"`
 	Status    string                 `json:"status,omitempty"`
 	Message   string                 `json:"message,omitempty"`
+	Log       []ActionMessage        `json:"log,omitempty"`
 	Output    map[string]interface{} `json:"output,omitempty"`
 	Error     *Error                 `json:"error,omitempty"`
 }
 
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message"`
+}
+
 // ActionsByReceivers wrap a slice of Actions for API calls.
 type ActionsByReceivers struct {
 	Actions []ActionsByReceiver `json:"actions,omitempty"`
@@ -116,6 +123,19 @@ type ActionExecutionResult struct {
 	Message   string                 `json:"message,omitempty"`
 }
 
+// ActionMessageParams holds the progress messages to be logged
+// against running actions.
+type ActionMessageParams struct {
+	Messages []ActionMessageArg `json:"messages"`
+}
+
+// ActionMessageArg holds a progress message to be logged against the
+// action with the given tag.
+type ActionMessageArg struct {
+	ActionTag string `json:"action-tag"`
+	Message   string `json:"message"`
+}
+
 // ApplicationsCharmActionsResults holds a slice of ApplicationCharmActionsResult for
 // a bulk result of charm Actions for Applications.
 type ApplicationsCharmActionsResults struct {
@@ -138,3 +158,57 @@ type ActionSpec struct {
 	Description string                 `json:"description"`
 	Params      map[string]interface{} `json:"params"`
 }
+
+// ActionPruneArgs holds arguments for the action pruning process.
+type ActionPruneArgs struct {
+	MaxHistoryTime time.Duration `json:"max-history-time"`
+	MaxHistoryMB   int           `json:"max-history-mb"`
+}
+
+// ActionSchedule describes an action that will be enqueued at a future
+// time, and optionally repeated at a fixed interval.
+type ActionSchedule struct {
+	Id            string                 `json:"id"`
+	Receiver      string                 `json:"receiver"`
+	Name          string                 `json:"name"`
+	Parameters    map[string]interface{} `json:"parameters,omitempty"`
+	NextRun       time.Time              `json:"next-run"`
+	Every         time.Duration          `json:"every,omitempty"`
+	LastActionTag string                 `json:"last-action-tag,omitempty"`
+}
+
+// ScheduleActions holds the arguments for scheduling actions.
+type ScheduleActions struct {
+	Schedules []ScheduleAction `json:"schedules"`
+}
+
+// ScheduleAction holds the arguments for scheduling an action. If At is
+// zero, the action first runs one interval from now.
+type ScheduleAction struct {
+	Receiver   string                 `json:"receiver"`
+	Name       string                 `json:"name"`
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+	At         time.Time              `json:"at,omitempty"`
+	Every      time.Duration          `json:"every,omitempty"`
+}
+
+// ActionScheduleResults holds the results of scheduling actions.
+type ActionScheduleResults struct {
+	Results []ActionScheduleResult `json:"results"`
+}
+
+// ActionScheduleResult holds a scheduled action, or an error.
+type ActionScheduleResult struct {
+	Schedule *ActionSchedule `json:"schedule,omitempty"`
+	Error    *Error          `json:"error,omitempty"`
+}
+
+// ActionSchedules holds a list of action schedules.
+type ActionSchedules struct {
+	Schedules []ActionSchedule `json:"schedules"`
+}
+
+// ActionScheduleIds holds the ids of action schedules.
+type ActionScheduleIds struct {
+	Ids []string `json:"ids"`
+}
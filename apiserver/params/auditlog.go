@@ -0,0 +1,60 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package params
+
+import (
+	"time"
+)
+
+// AuditLogFilter holds the arguments for a call to the Query method
+// of the AuditLog facade. Empty fields match every entry.
+type AuditLogFilter struct {
+	// UserTag, if set, restricts entries to those triggered by this
+	// user.
+	UserTag string `json:"user-tag,omitempty"`
+
+	// ModelTag, if set, restricts entries to those recorded on this
+	// model.
+	ModelTag string `json:"model-tag,omitempty"`
+
+	// Operation, if set, restricts entries to those whose operation
+	// contains this text, ignoring case.
+	Operation string `json:"operation,omitempty"`
+
+	// After, if set, restricts entries to those recorded after this
+	// time.
+	After *time.Time `json:"after,omitempty"`
+
+	// Before, if set, restricts entries to those recorded before this
+	// time.
+	Before *time.Time `json:"before,omitempty"`
+
+	// Limit, if positive, restricts the result to the most recent
+	// Limit entries.
+	Limit int `json:"limit,omitempty"`
+}
+
+// AuditLogEntry holds a single recorded audit entry.
+type AuditLogEntry struct {
+	JujuServerVersion string                 `json:"juju-server-version"`
+	ModelTag          string                 `json:"model-tag"`
+	Timestamp         time.Time              `json:"timestamp"`
+	RemoteAddress     string                 `json:"remote-address"`
+	OriginType        string                 `json:"origin-type"`
+	OriginName        string                 `json:"origin-name"`
+	Operation         string                 `json:"operation"`
+	Data              map[string]interface{} `json:"data,omitempty"`
+}
+
+// AuditLogResult holds the result of a call to the Query method of
+// the AuditLog facade. Entries are ordered oldest first.
+type AuditLogResult struct {
+	Entries []AuditLogEntry `json:"entries"`
+}
+
+// AuditStreamRecords holds a batch of audit entries streamed from
+// the server.
+type AuditStreamRecords struct {
+	Records []AuditLogEntry `json:"records"`
+}
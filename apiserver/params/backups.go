@@ -12,6 +12,17 @@ import (
 // BackupsCreateArgs holds the args for the API Create method.
 type BackupsCreateArgs struct {
 	Notes string `json:"notes"`
+
+	// EncryptionKey, if set, is used to encrypt the backup archive.
+	EncryptionKey string `json:"encryption-key,omitempty"`
+
+	// UploadTo, if set, is the location of the remote storage to
+	// which a copy of the backup archive is uploaded.
+	UploadTo string `json:"upload-to,omitempty"`
+
+	// UploadCredentials holds the credentials used to access the
+	// remote storage identified by UploadTo.
+	UploadCredentials map[string]string `json:"upload-credentials,omitempty"`
 }
 
 // BackupsInfoArgs holds the args for the API Info method.
@@ -68,6 +79,8 @@ type BackupsMetadataResult struct {
 	Version  version.Number `json:"version"`
 	Series   string         `json:"series"`
 
+	Encrypted bool `json:"encrypted,omitempty"`
+
 	CACert       string `json:"ca-cert"`
 	CAPrivateKey string `json:"ca-private-key"`
 }
@@ -76,4 +89,7 @@ type BackupsMetadataResult struct {
 type RestoreArgs struct {
 	// BackupId holds the id of the backup in server if any
 	BackupId string `json:"backup-id"`
+
+	// EncryptionKey, if set, is used to decrypt the backup archive.
+	EncryptionKey string `json:"encryption-key,omitempty"`
 }
@@ -3,6 +3,8 @@
 
 package params
 
+import "time"
+
 // DestroyControllerArgs holds the arguments for destroying a controller.
 type DestroyControllerArgs struct {
 	// DestroyModels specifies whether or not the hosted models
@@ -87,3 +89,14 @@ const (
 	GrantControllerAccess  ControllerAction = "grant"
 	RevokeControllerAccess ControllerAction = "revoke"
 )
+
+// ScheduledBackupStatus holds the outcome of the most recent scheduled
+// backups of a controller. Times are zero if there has not yet been a
+// matching attempt.
+type ScheduledBackupStatus struct {
+	LastAttempt  time.Time `json:"last-attempt"`
+	LastSuccess  time.Time `json:"last-success"`
+	LastBackupID string    `json:"last-backup-id,omitempty"`
+	LastFailure  time.Time `json:"last-failure"`
+	LastError    string    `json:"last-error,omitempty"`
+}
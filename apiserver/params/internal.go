@@ -658,6 +658,7 @@ type ProvisioningInfo struct {
 	Jobs              []multiwatcher.MachineJob `json:"jobs"`
 	Volumes           []VolumeParams            `json:"volumes,omitempty"`
 	VolumeAttachments []VolumeAttachmentParams  `json:"volume-attachments,omitempty"`
+	RootDisk          *VolumeParams             `json:"root-disk,omitempty"`
 	Tags              map[string]string         `json:"tags,omitempty"`
 	SubnetsToZones    map[string][]string       `json:"subnets-to-zones,omitempty"`
 	ImageMetadata     []CloudImageMetadata      `json:"image-metadata,omitempty"`
@@ -762,6 +763,9 @@ type GUIVersionRequest struct {
 
 // LogMessage is a structured logging entry.
 type LogMessage struct {
+	ID        int64     `json:"id,omitempty"`
+	ModelUUID string    `json:"model,omitempty"`
+	Version   string    `json:"ver,omitempty"`
 	Entity    string    `json:"tag"`
 	Timestamp time.Time `json:"ts"`
 	Severity  string    `json:"sev"`
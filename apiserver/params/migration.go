@@ -52,6 +52,21 @@ type InitiateMigrationResult struct {
 	MigrationId string `json:"migration-id"`
 }
 
+// PrecheckMigrationResults is used to return the results of running
+// the migration prechecks for one or more models.
+type PrecheckMigrationResults struct {
+	Results []PrecheckMigrationResult `json:"results"`
+}
+
+// PrecheckMigrationResult holds every problem found by the prechecks
+// for a single model migration. If Failures is empty and Error is
+// nil, the migration is expected to succeed.
+type PrecheckMigrationResult struct {
+	ModelTag string   `json:"model-tag"`
+	Failures []string `json:"failures,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
+}
+
 // SetMigrationPhaseArgs provides a migration phase to the
 // migrationmaster.SetPhase API method.
 type SetMigrationPhaseArgs struct {
@@ -134,6 +149,20 @@ type MigrationModelInfo struct {
 	ControllerAgentVersion version.Number `json:"controller-agent-version"`
 }
 
+// MigrationPrecheckArgs holds the arguments for running all of the
+// target controller's migration prechecks for a model, including
+// validation of the serialized model.
+type MigrationPrecheckArgs struct {
+	Model MigrationModelInfo `json:"model"`
+	Bytes []byte             `json:"bytes"`
+}
+
+// MigrationPrecheckReport holds every problem found by the target
+// controller's migration prechecks.
+type MigrationPrecheckReport struct {
+	Failures []string `json:"failures,omitempty"`
+}
+
 // MigrationStatus reports the current status of a model migration.
 type MigrationStatus struct {
 	MigrationId string `json:"migration-id"`
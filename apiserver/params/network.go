@@ -530,6 +530,110 @@ type MachinePortsResults struct {
 	Results []MachinePortsResult `json:"results"`
 }
 
+// ExposeInfoResult holds whether an application is exposed and, if
+// so, the source CIDRs from which its open ports may be accessed.
+type ExposeInfoResult struct {
+	Exposed     bool     `json:"exposed"`
+	SourceCIDRs []string `json:"source-cidrs,omitempty"`
+	Error       *Error   `json:"error,omitempty"`
+}
+
+// ExposeInfoResults holds the results of the
+// FirewallerAPIV5.GetExposeInfo() API call.
+type ExposeInfoResults struct {
+	Results []ExposeInfoResult `json:"results"`
+}
+
+// FirewallRule holds the source CIDRs from which a well-known
+// service, such as SSH, may be reached.
+type FirewallRule struct {
+	KnownService   string   `json:"known-service"`
+	WhitelistCIDRS []string `json:"whitelist-cidrs,omitempty"`
+}
+
+// FirewallRuleArgs holds the parameters for a
+// FirewallRules.SetFirewallRules() API call.
+type FirewallRuleArgs struct {
+	Args []FirewallRule `json:"args"`
+}
+
+// ListFirewallRulesResults holds the results of a
+// FirewallRules.ListFirewallRules() API call.
+type ListFirewallRulesResults struct {
+	Rules []FirewallRule `json:"rules"`
+}
+
+// IngressRule holds a port range, and the source CIDRs from which
+// it may be reached.
+type IngressRule struct {
+	PortRange   PortRange `json:"port-range"`
+	SourceCIDRs []string  `json:"source-cidrs"`
+}
+
+// IngressRulesResult holds the ingress rules for an entity, or
+// an error.
+type IngressRulesResult struct {
+	Rules []IngressRule `json:"rules,omitempty"`
+	Error *Error        `json:"error,omitempty"`
+}
+
+// IngressRulesResults holds the results of the
+// FirewallerAPIV6.ModelFirewallRules() API call.
+type IngressRulesResults struct {
+	Results []IngressRulesResult `json:"results"`
+}
+
+// EgressRule holds a port range, and the destination CIDRs to which
+// outgoing traffic on it is allowed.
+type EgressRule struct {
+	PortRange        PortRange `json:"port-range"`
+	DestinationCIDRs []string  `json:"destination-cidrs,omitempty"`
+}
+
+// FromNetworkEgressRule is a convenience helper to create a parameter
+// out of the network type, here for EgressRule.
+func FromNetworkEgressRule(rule network.EgressRule) EgressRule {
+	return EgressRule{
+		PortRange:        FromNetworkPortRange(rule.PortRange),
+		DestinationCIDRs: rule.DestinationCIDRs,
+	}
+}
+
+// NetworkEgressRule is a convenience helper to return the parameter
+// as network type, here for EgressRule.
+func (rule EgressRule) NetworkEgressRule() network.EgressRule {
+	return network.EgressRule{
+		PortRange:        rule.PortRange.NetworkPortRange(),
+		DestinationCIDRs: rule.DestinationCIDRs,
+	}
+}
+
+// EgressRulesResult holds the egress rules for an entity, or
+// an error.
+type EgressRulesResult struct {
+	Rules []EgressRule `json:"rules,omitempty"`
+	Error *Error       `json:"error,omitempty"`
+}
+
+// EgressRulesResults holds the results of the GetEgressRules
+// API calls.
+type EgressRulesResults struct {
+	Results []EgressRulesResult `json:"results"`
+}
+
+// ApplicationEgressRules holds the egress rules to set for
+// an application.
+type ApplicationEgressRules struct {
+	ApplicationTag string       `json:"application-tag"`
+	Rules          []EgressRule `json:"rules"`
+}
+
+// SetApplicationsEgressRules holds the parameters for making the
+// application SetEgressRules call.
+type SetApplicationsEgressRules struct {
+	Args []ApplicationEgressRules `json:"args"`
+}
+
 // APIHostPortsResult holds the result of an APIHostPorts
 // call. Each element in the top level slice holds
 // the addresses for one API server.
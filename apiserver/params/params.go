@@ -277,11 +277,59 @@ type ApplicationSetCharm struct {
 	// update during the upgrade. This field is only understood by Application
 	// facade version 2 and greater.
 	StorageConstraints map[string]StorageConstraints `json:"storage-constraints,omitempty"`
+
+	// Rolling holds the application's existing units on their current
+	// charm until they are released with ReleaseCharmUpgrade. This
+	// field is only understood by Application facade version 6 and
+	// greater.
+	Rolling bool `json:"rolling,omitempty"`
+}
+
+// ApplicationCharmUpgradeReleases holds the parameters for making the
+// application ReleaseCharmUpgrade call.
+type ApplicationCharmUpgradeReleases struct {
+	Releases []ApplicationCharmUpgradeRelease `json:"releases"`
+}
+
+// ApplicationCharmUpgradeRelease identifies units, held on their
+// previous charm by a rolling charm upgrade, that should be allowed
+// to upgrade.
+type ApplicationCharmUpgradeRelease struct {
+	ApplicationTag string   `json:"application-tag"`
+	UnitTags       []string `json:"unit-tags"`
+}
+
+// CharmUpgradeHoldResults holds the results of the application
+// CharmUpgradeHold call.
+type CharmUpgradeHoldResults struct {
+	Results []CharmUpgradeHoldResult `json:"results"`
+}
+
+// CharmUpgradeHoldResult describes the units of an application held on
+// their previous charm by a rolling charm upgrade. CharmURL is empty if
+// there is no rolling charm upgrade in progress.
+type CharmUpgradeHoldResult struct {
+	CharmURL string   `json:"charm-url,omitempty"`
+	UnitTags []string `json:"unit-tags,omitempty"`
+	Error    *Error   `json:"error,omitempty"`
 }
 
 // ApplicationExpose holds the parameters for making the application Expose call.
+// ExposedEndpoints, keyed by endpoint name, restricts the sources from which
+// the application's open ports may be accessed; the empty endpoint name
+// applies to all endpoints, and is currently the only one supported.
+// ExposedEndpoints is only supported by Application API version 7 and
+// later.
 type ApplicationExpose struct {
-	ApplicationName string `json:"application"`
+	ApplicationName  string                     `json:"application"`
+	ExposedEndpoints map[string]ExposedEndpoint `json:"exposed-endpoints,omitempty"`
+}
+
+// ExposedEndpoint holds the spaces and CIDRs from which the open
+// ports of an exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	ExposeToSpaces []string `json:"expose-to-spaces,omitempty"`
+	ExposeToCIDRs  []string `json:"expose-to-cidrs,omitempty"`
 }
 
 // ApplicationSet holds the parameters for an application Set
@@ -3,7 +3,11 @@
 
 package params
 
-import "github.com/juju/juju/storage"
+import (
+	"time"
+
+	"github.com/juju/juju/storage"
+)
 
 // MachineBlockDevices holds a machine tag and the block devices present
 // on that machine.
@@ -94,6 +98,10 @@ type StorageAttachment struct {
 	Kind     StorageKind `json:"kind"`
 	Location string      `json:"location"`
 	Life     Life        `json:"life"`
+
+	// Size is the size of the attached storage in MiB,
+	// if it is known.
+	Size uint64 `json:"size,omitempty"`
 }
 
 // StorageAttachmentId identifies a storage attachment by the tags of the
@@ -276,6 +284,38 @@ type VolumeParamsResults struct {
 	Results []VolumeParamsResult `json:"results,omitempty"`
 }
 
+// VolumeResizeParams holds the parameters for growing a volume.
+type VolumeResizeParams struct {
+	VolumeTag  string                 `json:"volume-tag"`
+	VolumeId   string                 `json:"volume-id"`
+	Size       uint64                 `json:"size"`
+	Provider   string                 `json:"provider"`
+	Attributes map[string]interface{} `json:"attributes,omitempty"`
+}
+
+// VolumeResizeParamsResult holds resize parameters for a volume.
+type VolumeResizeParamsResult struct {
+	Result VolumeResizeParams `json:"result"`
+	Error  *Error             `json:"error,omitempty"`
+}
+
+// VolumeResizeParamsResults holds resize parameters for multiple volumes.
+type VolumeResizeParamsResults struct {
+	Results []VolumeResizeParamsResult `json:"results,omitempty"`
+}
+
+// EntitySize records the size, in MiB, of an entity such
+// as a volume or filesystem.
+type EntitySize struct {
+	Tag  string `json:"tag"`
+	Size uint64 `json:"size"`
+}
+
+// EntitySizes holds the sizes of multiple entities.
+type EntitySizes struct {
+	Sizes []EntitySize `json:"sizes"`
+}
+
 // VolumeAttachmentParamsResults holds provisioning parameters for a volume
 // attachment.
 type VolumeAttachmentParamsResult struct {
@@ -345,13 +385,14 @@ type FilesystemParams struct {
 // FilesystemAttachmentParams holds the parameters for creating a filesystem
 // attachment.
 type FilesystemAttachmentParams struct {
-	FilesystemTag string `json:"filesystem-tag"`
-	MachineTag    string `json:"machine-tag"`
-	FilesystemId  string `json:"filesystem-id,omitempty"`
-	InstanceId    string `json:"instance-id,omitempty"`
-	Provider      string `json:"provider"`
-	MountPoint    string `json:"mount-point,omitempty"`
-	ReadOnly      bool   `json:"read-only,omitempty"`
+	FilesystemTag string                 `json:"filesystem-tag"`
+	MachineTag    string                 `json:"machine-tag"`
+	FilesystemId  string                 `json:"filesystem-id,omitempty"`
+	InstanceId    string                 `json:"instance-id,omitempty"`
+	Provider      string                 `json:"provider"`
+	MountPoint    string                 `json:"mount-point,omitempty"`
+	ReadOnly      bool                   `json:"read-only,omitempty"`
+	Attributes    map[string]interface{} `json:"attributes,omitempty"`
 }
 
 // FilesystemAttachmentResult holds the details of a single filesystem attachment,
@@ -747,3 +788,73 @@ type DestroyStorageInstance struct {
 	// is false, then the storage must already be detached.
 	DestroyAttached bool `json:"destroy-attached,bool"`
 }
+
+// StorageSnapshot describes a point-in-time snapshot of the volume
+// backing a storage instance.
+type StorageSnapshot struct {
+	// StorageTag is the tag of the storage instance.
+	StorageTag string `json:"storage-tag"`
+
+	// VolumeTag is the tag of the volume that was snapshotted.
+	VolumeTag string `json:"volume-tag"`
+
+	// SnapshotId is the provider-supplied ID of the snapshot.
+	SnapshotId string `json:"snapshot-id"`
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64 `json:"size"`
+
+	// Status is the provider-specific status of the snapshot.
+	Status string `json:"status,omitempty"`
+
+	// Created is the time at which the snapshot was started.
+	Created time.Time `json:"created"`
+}
+
+// StorageSnapshotResult holds a storage snapshot or an error.
+type StorageSnapshotResult struct {
+	Result *StorageSnapshot `json:"result,omitempty"`
+	Error  *Error           `json:"error,omitempty"`
+}
+
+// StorageSnapshotResults holds a collection of storage snapshot results.
+type StorageSnapshotResults struct {
+	Results []StorageSnapshotResult `json:"results"`
+}
+
+// StorageSnapshotsResult holds the snapshots of a storage instance,
+// or an error.
+type StorageSnapshotsResult struct {
+	Result []StorageSnapshot `json:"result,omitempty"`
+	Error  *Error            `json:"error,omitempty"`
+}
+
+// StorageSnapshotsResults holds a collection of storage snapshots results.
+type StorageSnapshotsResults struct {
+	Results []StorageSnapshotsResult `json:"results"`
+}
+
+// StorageSnapshotId identifies a snapshot of a storage instance.
+type StorageSnapshotId struct {
+	StorageTag string `json:"storage-tag"`
+	SnapshotId string `json:"snapshot-id"`
+}
+
+// StorageSnapshotIds holds a collection of storage snapshot IDs.
+type StorageSnapshotIds struct {
+	Ids []StorageSnapshotId `json:"ids"`
+}
+
+// ResizeStorageArg holds the arguments for growing a storage instance.
+type ResizeStorageArg struct {
+	StorageTag string `json:"storage-tag"`
+
+	// Size is the requested size of the storage, in MiB.
+	Size uint64 `json:"size"`
+}
+
+// ResizeStorageArgs holds the arguments for growing multiple
+// storage instances.
+type ResizeStorageArgs struct {
+	Args []ResizeStorageArg `json:"args"`
+}
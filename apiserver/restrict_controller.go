@@ -16,6 +16,7 @@ import (
 var controllerFacadeNames = set.NewStrings(
 	"AllModelWatcher",
 	"ApplicationOffers",
+	"AuditLog",
 	"Cloud",
 	"Controller",
 	"MigrationTarget",
@@ -78,3 +78,45 @@ func (e AuditEntry) Validate() error {
 
 	return nil
 }
+
+// Filter holds the criteria used to select recorded audit entries.
+// Criteria which are left empty match every entry.
+type Filter struct {
+	// OriginName, if set, restricts entries to those triggered by
+	// the named origin, e.g. "user-admin".
+	OriginName string
+
+	// ModelUUID, if set, restricts entries to those recorded on the
+	// model with this UUID.
+	ModelUUID string
+
+	// Operation, if set, restricts entries to those whose operation
+	// contains this text. The match is case insensitive.
+	Operation string
+
+	// After, if set, restricts entries to those recorded after this
+	// time.
+	After time.Time
+
+	// Before, if set, restricts entries to those recorded before this
+	// time.
+	Before time.Time
+
+	// Limit, if positive, is the maximum number of entries to return.
+	// The most recent entries are returned.
+	Limit int
+}
+
+// Validate ensures that the filter is well formed.
+func (f Filter) Validate() error {
+	if f.ModelUUID != "" && !utils.IsValidUUIDString(f.ModelUUID) {
+		return errors.NotValidf("ModelUUID %q", f.ModelUUID)
+	}
+	if !f.After.IsZero() && !f.Before.IsZero() && !f.After.Before(f.Before) {
+		return errors.NewNotValid(nil, "After must be earlier than Before")
+	}
+	if f.Limit < 0 {
+		return errors.NotValidf("negative Limit")
+	}
+	return nil
+}
@@ -114,3 +114,35 @@ func validEntry() audit.AuditEntry {
 		Operation:         ".",
 	}
 }
+
+func (s *auditSuite) TestFilterValidate(c *gc.C) {
+	now := time.Now().UTC()
+	c.Check(audit.Filter{}.Validate(), jc.ErrorIsNil)
+	c.Check(audit.Filter{
+		OriginName: "user-admin",
+		ModelUUID:  utils.MustNewUUID().String(),
+		Operation:  "Destroy",
+		After:      now.Add(-time.Hour),
+		Before:     now,
+		Limit:      10,
+	}.Validate(), jc.ErrorIsNil)
+}
+
+func (s *auditSuite) TestFilterValidate_InvalidModelUUIDErrors(c *gc.C) {
+	err := audit.Filter{ModelUUID: "."}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `ModelUUID "." not valid`)
+}
+
+func (s *auditSuite) TestFilterValidate_TimeRangeErrors(c *gc.C) {
+	now := time.Now().UTC()
+	err := audit.Filter{After: now, Before: now.Add(-time.Hour)}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "After must be earlier than Before")
+}
+
+func (s *auditSuite) TestFilterValidate_NegativeLimitErrors(c *gc.C) {
+	err := audit.Filter{Limit: -1}.Validate()
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "negative Limit not valid")
+}
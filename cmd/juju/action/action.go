@@ -56,6 +56,18 @@ type APIClient interface {
 	// FindActionsByNames takes a list of names and finds a corresponding list of
 	// Actions for every name.
 	FindActionsByNames(params.FindActionsByNames) (params.ActionsByNames, error)
+
+	// ScheduleActions arranges for actions to be enqueued at a future
+	// time, and optionally repeated at a fixed interval.
+	ScheduleActions(params.ScheduleActions) (params.ActionScheduleResults, error)
+
+	// ListActionSchedules returns the action schedules in the model,
+	// ordered by the time at which they will next run.
+	ListActionSchedules() ([]params.ActionSchedule, error)
+
+	// RemoveActionSchedules removes the action schedules with the
+	// given ids.
+	RemoveActionSchedules([]string) (params.ErrorResults, error)
 }
 
 // ActionCommandBase is the base type for action sub-commands.
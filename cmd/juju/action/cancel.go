@@ -23,16 +23,22 @@ type cancelCommand struct {
 	ActionCommandBase
 	out          cmd.Output
 	requestedIds []string
+	schedules    bool
 }
 
 // Set up the output.
 func (c *cancelCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+	f.BoolVar(&c.schedules, "schedule", false, "Remove action schedules with the given IDs, rather than cancelling actions")
 }
 
 const cancelDoc = `
-Cancel actions matching given IDs or partial ID prefixes.`
+Cancel actions matching given IDs or partial ID prefixes.
+
+With --schedule, remove the action schedules with the given IDs, as shown
+by 'juju show-action-status', so that they queue no further actions.
+Actions that have already been queued by a schedule are not affected.`
 
 func (c *cancelCommand) Info() *cmd.Info {
 	return &cmd.Info{
@@ -58,6 +64,9 @@ func (c *cancelCommand) Run(ctx *cmd.Context) error {
 	if len(c.requestedIds) == 0 {
 		return errors.Errorf("no actions specified")
 	}
+	if c.schedules {
+		return c.removeSchedules(ctx, api)
+	}
 
 	var actionTags []names.ActionTag
 	for _, requestedId := range c.requestedIds {
@@ -118,3 +127,27 @@ func (c *cancelCommand) Run(ctx *cmd.Context) error {
 
 	return err
 }
+
+// removeSchedules removes the action schedules with the requested ids.
+func (c *cancelCommand) removeSchedules(ctx *cmd.Context, api APIClient) error {
+	results, err := api.RemoveActionSchedules(c.requestedIds)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(results.Results) != len(c.requestedIds) {
+		return errors.Errorf("expected %d results, got %d", len(c.requestedIds), len(results.Results))
+	}
+	failed := false
+	for i, result := range results.Results {
+		if result.Error != nil {
+			ctx.Infof("cannot remove action schedule %s: %v", c.requestedIds[i], result.Error)
+			failed = true
+			continue
+		}
+		ctx.Infof("removed action schedule %s", c.requestedIds[i])
+	}
+	if failed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
@@ -105,3 +105,14 @@ type cancelTestCase struct {
 	results        []params.ActionResult
 	actionsByNames params.ActionsByNames
 }
+
+func (s *CancelSuite) TestRemoveSchedules(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, params.ActionsByNames{}, "")
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "-m", "admin", "--schedule", "id-1", "id-2")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(fakeClient.removedSchedules, jc.DeepEquals, []string{"id-1", "id-2"})
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "removed action schedule id-1\nremoved action schedule id-2\n")
+}
@@ -15,6 +15,8 @@ import (
 var (
 	NewActionAPIClient = &newAPIClient
 	AddValueToMap      = addValueToMap
+
+	UnseenActionMessages = unseenActionMessages
 )
 
 type ShowOutputCommand struct {
@@ -127,6 +127,9 @@ type fakeAPIClient struct {
 	actionTagMatches   params.FindTagsResults
 	actionsByNames     params.ActionsByNames
 	charmActions       map[string]params.ActionSpec
+	scheduledActions   params.ScheduleActions
+	actionSchedules    []params.ActionSchedule
+	removedSchedules   []string
 	apiErr             error
 }
 
@@ -209,3 +212,22 @@ func (c *fakeAPIClient) FindActionTagsByPrefix(arg params.FindTags) (params.Find
 func (c *fakeAPIClient) FindActionsByNames(args params.FindActionsByNames) (params.ActionsByNames, error) {
 	return c.actionsByNames, c.apiErr
 }
+
+func (c *fakeAPIClient) ScheduleActions(args params.ScheduleActions) (params.ActionScheduleResults, error) {
+	c.scheduledActions = args
+	results := params.ActionScheduleResults{}
+	for _, schedule := range c.actionSchedules {
+		schedule := schedule
+		results.Results = append(results.Results, params.ActionScheduleResult{Schedule: &schedule})
+	}
+	return results, c.apiErr
+}
+
+func (c *fakeAPIClient) ListActionSchedules() ([]params.ActionSchedule, error) {
+	return c.actionSchedules, c.apiErr
+}
+
+func (c *fakeAPIClient) RemoveActionSchedules(ids []string) (params.ErrorResults, error) {
+	c.removedSchedules = ids
+	return params.ErrorResults{Results: make([]params.ErrorResult, len(ids))}, c.apiErr
+}
@@ -37,6 +37,10 @@ type runCommand struct {
 	wait         waitFlag
 	out          cmd.Output
 	args         [][]string
+
+	atString string
+	at       time.Time
+	every    time.Duration
 }
 
 const runDoc = `
@@ -116,6 +120,21 @@ $ juju run-action sleeper/0 pause time=1000
 $ juju run-action sleeper/0 pause --string-args time=1000
 ...
 The value for the "time" param will be the string literal "1000".
+
+$ juju run-action mysql/3 backup --at 2017-10-01T02:00:00Z --every 24h
+Action scheduled with id: <ID>
+next run: 2017-10-01 02:00:00
+
+Actions may be scheduled to run at a later time with --at, which takes a
+time in RFC3339 format, and repeated with --every, which takes a duration
+such as 30m or 24h. If --every is given without --at, the action first runs
+one interval from now. Cron-style schedules are not supported: to run an
+action at a fixed time every day, as in the example above, give that time
+with --at and use --every 24h. Intervals are exact, so such a schedule
+follows UTC rather than local daylight saving time. Scheduled actions are
+enqueued by the controller, within a minute of the requested time;
+upcoming runs are listed by 'juju show-action-status' and may be removed with
+'juju cancel-action --schedule <ID>'.
 `
 
 // ActionNameRule describes the format an action name must match to be valid.
@@ -128,6 +147,8 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(&c.paramsYAML, "params", "Path to yaml-formatted params file")
 	f.BoolVar(&c.parseStrings, "string-args", false, "Use raw string values of CLI args")
 	f.Var(&c.wait, "wait", "Wait for results, with optional timeout")
+	f.StringVar(&c.atString, "at", "", "Queue the action at the given time (RFC3339), rather than now")
+	f.DurationVar(&c.every, "every", 0, "Queue the action repeatedly at the given interval")
 }
 
 func (c *runCommand) Info() *cmd.Info {
@@ -141,6 +162,9 @@ func (c *runCommand) Info() *cmd.Info {
 
 // Init gets the unit tag, and checks for other correct args.
 func (c *runCommand) Init(args []string) error {
+	if err := c.initSchedule(); err != nil {
+		return errors.Trace(err)
+	}
 	switch len(args) {
 	case 0:
 		return errors.New("no unit specified")
@@ -182,6 +206,33 @@ func (c *runCommand) Init(args []string) error {
 	}
 }
 
+// initSchedule validates the --at and --every flags.
+func (c *runCommand) initSchedule() error {
+	if c.atString != "" {
+		at, err := time.Parse(time.RFC3339, c.atString)
+		if err != nil {
+			return errors.Errorf("invalid --at time %q, expected RFC3339 format", c.atString)
+		}
+		c.at = at
+	}
+	if c.every < 0 {
+		return errors.New("--every must be positive")
+	}
+	if c.every > 0 && c.every < time.Minute {
+		return errors.New("--every must be at least 1m")
+	}
+	if c.scheduled() && (c.wait.forever || c.wait.d > 0) {
+		return errors.New("--wait cannot be used with --at or --every")
+	}
+	return nil
+}
+
+// scheduled reports whether the action is to be queued later,
+// rather than now.
+func (c *runCommand) scheduled() bool {
+	return !c.at.IsZero() || c.every > 0
+}
+
 func (c *runCommand) Run(ctx *cmd.Context) error {
 	api, err := c.NewActionAPIClient()
 	if err != nil {
@@ -242,6 +293,10 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("params must be a map, got %T", typedConformantParams)
 	}
 
+	if c.scheduled() {
+		return c.schedule(ctx, api, actionParams)
+	}
+
 	actionParam := params.Actions{
 		Actions: []params.Action{{
 			Receiver:   c.unitTag.String(),
@@ -298,3 +353,34 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	output["action-id"] = tag.Id() // Action ID is required in case we timed out.
 	return c.out.Write(ctx, output)
 }
+
+// schedule arranges for the action to be queued later.
+func (c *runCommand) schedule(ctx *cmd.Context, api APIClient, actionParams map[string]interface{}) error {
+	results, err := api.ScheduleActions(params.ScheduleActions{
+		Schedules: []params.ScheduleAction{{
+			Receiver:   c.unitTag.String(),
+			Name:       c.actionName,
+			Parameters: actionParams,
+			At:         c.at,
+			Every:      c.every,
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if len(results.Results) != 1 {
+		return errors.New("illegal number of results returned")
+	}
+	result := results.Results[0]
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.Schedule == nil {
+		return errors.New("action failed to schedule")
+	}
+	output := map[string]string{
+		"Action scheduled with id": result.Schedule.Id,
+		"next run":                 result.Schedule.NextRun.UTC().Format("2006-01-02 15:04:05"),
+	}
+	return c.out.Write(ctx, output)
+}
@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"errors"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -399,3 +400,66 @@ func (s *RunSuite) TestRun(c *gc.C) {
 		}
 	}
 }
+
+func (s *RunSuite) TestInitSchedule(c *gc.C) {
+	for i, t := range []struct {
+		args        []string
+		expectError string
+	}{{
+		args:        []string{validUnitId, "backup", "--at", "tomorrow"},
+		expectError: `invalid --at time "tomorrow", expected RFC3339 format`,
+	}, {
+		args:        []string{validUnitId, "backup", "--every", "30s"},
+		expectError: "--every must be at least 1m",
+	}, {
+		args:        []string{validUnitId, "backup", "--every", "-1h"},
+		expectError: "--every must be positive",
+	}, {
+		args:        []string{validUnitId, "backup", "--every", "1h", "--wait"},
+		expectError: "--wait cannot be used with --at or --every",
+	}, {
+		args: []string{validUnitId, "backup", "--at", "2017-10-01T02:00:00Z", "--every", "24h"},
+	}} {
+		c.Logf("test %d: %v", i, t.args)
+		wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+		args := append([]string{"-m", "admin"}, t.args...)
+		err := cmdtesting.InitCommand(wrappedCommand, args)
+		if t.expectError == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, t.expectError)
+		}
+	}
+}
+
+func (s *RunSuite) TestRunScheduled(c *gc.C) {
+	at := time.Date(2017, time.October, 1, 2, 0, 0, 0, time.UTC)
+	fakeClient := &fakeAPIClient{
+		actionSchedules: []params.ActionSchedule{{Id: "some-id", NextRun: at}},
+	}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	wrappedCommand, _ := action.NewRunCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, wrappedCommand,
+		"-m", "admin", validUnitId, "backup", "out=name",
+		"--at", "2017-10-01T02:00:00Z", "--every", "24h",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	resultMap := make(map[string]string)
+	err = yaml.Unmarshal(ctx.Stdout.(*bytes.Buffer).Bytes(), &resultMap)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(resultMap, jc.DeepEquals, map[string]string{
+		"Action scheduled with id": "some-id",
+		"next run":                 "2017-10-01 02:00:00",
+	})
+
+	c.Assert(fakeClient.scheduledActions.Schedules, gc.HasLen, 1)
+	scheduled := fakeClient.scheduledActions.Schedules[0]
+	c.Check(scheduled.Receiver, gc.Equals, names.NewUnitTag(validUnitId).String())
+	c.Check(scheduled.Name, gc.Equals, "backup")
+	c.Check(scheduled.Parameters, jc.DeepEquals, map[string]interface{}{"out": "name"})
+	c.Check(scheduled.At.Equal(at), jc.IsTrue)
+	c.Check(scheduled.Every, gc.Equals, 24*time.Hour)
+	c.Check(fakeClient.EnqueuedActions().Actions, gc.HasLen, 0)
+}
@@ -4,6 +4,7 @@
 package action
 
 import (
+	"fmt"
 	"regexp"
 	"time"
 
@@ -27,6 +28,7 @@ type showOutputCommand struct {
 	requestedId string
 	fullSchema  bool
 	wait        string
+	watch       bool
 }
 
 const showOutputDoc = `
@@ -38,6 +40,10 @@ to wait indefinitely.  If units are left off, seconds are assumed.
 The default behavior without --wait is to immediately check and return; if
 the results are "pending" then only the available information will be
 displayed.  This is also the behavior when any negative time is given.
+
+Use --watch to print the progress messages logged by the action with
+action-log as they arrive, until the action completes or fails.  When
+combined with --wait, watching stops once the wait time has elapsed.
 `
 
 // Set up the output.
@@ -45,6 +51,7 @@ func (c *showOutputCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.ActionCommandBase.SetFlags(f)
 	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
 	f.StringVar(&c.wait, "wait", "-1s", "Wait for results")
+	f.BoolVar(&c.watch, "watch", false, "Stream progress messages until the action finishes")
 }
 
 func (c *showOutputCommand) Info() *cmd.Info {
@@ -93,6 +100,10 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	wait := time.NewTimer(0 * time.Second)
 
 	switch {
+	case c.watch && waitDur.Nanoseconds() < 0:
+		// Watching without a wait time streams until the action
+		// finishes.  Discard the tick.
+		_ = <-wait.C
 	case waitDur.Nanoseconds() < 0:
 		// Negative duration signals immediate return.  All is well.
 	case waitDur.Nanoseconds() == 0:
@@ -104,6 +115,16 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 		wait = time.NewTimer(waitDur)
 	}
 
+	if c.watch {
+		result, err := watchActionResult(ctx, api, c.requestedId, wait)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// The progress messages have already been written.
+		result.Log = nil
+		return c.out.Write(ctx, FormatActionResult(result))
+	}
+
 	result, err := GetActionResult(api, c.requestedId, wait)
 	if err != nil {
 		return errors.Trace(err)
@@ -112,6 +133,41 @@ func (c *showOutputCommand) Run(ctx *cmd.Context) error {
 	return c.out.Write(ctx, FormatActionResult(result))
 }
 
+// watchActionResult repeatedly fetches an action, writing any new
+// progress messages to the context's stdout, until the action is in a
+// completed state or "wait" expires.
+func watchActionResult(ctx *cmd.Context, api APIClient, requestedId string, wait *time.Timer) (params.ActionResult, error) {
+	tick := time.NewTimer(2 * time.Second)
+	var last *params.ActionMessage
+	return timerLoop(api, requestedId, wait, tick, func(result params.ActionResult) {
+		unseen := unseenActionMessages(result.Log, last)
+		for _, m := range unseen {
+			fmt.Fprintln(ctx.Stdout, formatActionMessage(m))
+		}
+		if len(unseen) > 0 {
+			last = &unseen[len(unseen)-1]
+		}
+	})
+}
+
+// unseenActionMessages returns the messages in log that follow last,
+// the most recent message already written. The log cannot be treated
+// as append-only, because state retains only the newest messages and
+// drops older ones from the front; so the position of last is found
+// by its timestamp and content. If last is nil, or has been dropped
+// from the log, every message is returned.
+func unseenActionMessages(log []params.ActionMessage, last *params.ActionMessage) []params.ActionMessage {
+	if last == nil {
+		return log
+	}
+	for i := len(log) - 1; i >= 0; i-- {
+		if log[i].Timestamp.Equal(last.Timestamp) && log[i].Message == last.Message {
+			return log[i+1:]
+		}
+	}
+	return log
+}
+
 // GetActionResult tries to repeatedly fetch an action until it is
 // in a completed state and then it returns it.
 // It waits for a maximum of "wait" before returning with the latest action status.
@@ -121,13 +177,14 @@ func GetActionResult(api APIClient, requestedId string, wait *time.Timer) (param
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	tick := time.NewTimer(2 * time.Second)
 
-	return timerLoop(api, requestedId, wait, tick)
+	return timerLoop(api, requestedId, wait, tick, nil)
 }
 
 // timerLoop loops indefinitely to query the given API, until "wait" times
 // out, using the "tick" timer to delay the API queries.  It writes the
-// result to the given output.
-func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (params.ActionResult, error) {
+// result to the given output.  If onResult is not nil, it is called
+// with each result fetched.
+func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer, onResult func(params.ActionResult)) (params.ActionResult, error) {
 	var (
 		result params.ActionResult
 		err    error
@@ -140,6 +197,9 @@ func timerLoop(api APIClient, requestedId string, wait, tick *time.Timer) (param
 		if err != nil {
 			return result, err
 		}
+		if onResult != nil {
+			onResult(result)
+		}
 
 		// Whether or not we're waiting for a result, if a completed
 		// result arrives, we're done.
@@ -204,6 +264,13 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 	if len(result.Output) != 0 {
 		response["results"] = result.Output
 	}
+	if len(result.Log) != 0 {
+		logs := make([]string, len(result.Log))
+		for i, m := range result.Log {
+			logs[i] = formatActionMessage(m)
+		}
+		response["log"] = logs
+	}
 
 	if result.Enqueued.IsZero() && result.Started.IsZero() && result.Completed.IsZero() {
 		return response
@@ -223,3 +290,9 @@ func FormatActionResult(result params.ActionResult) map[string]interface{} {
 
 	return response
 }
+
+// formatActionMessage returns a progress message prefixed with the
+// time at which it was logged.
+func formatActionMessage(m params.ActionMessage) string {
+	return fmt.Sprintf("%s %s", m.Timestamp.String(), m.Message)
+}
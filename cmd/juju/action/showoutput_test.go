@@ -167,6 +167,32 @@ timing:
   completed: 2015-02-14 08:15:30 +0000 UTC
   enqueued: 2015-02-14 08:13:00 +0000 UTC
   started: 2015-02-14 08:15:00 +0000 UTC
+`[1:],
+	}, {
+		should:            "pretty-print action output with progress messages",
+		withClientQueryID: validActionId,
+		withAPITimeout:    10 * time.Second,
+		withTags:          tagsForIdPrefix(validActionId, validActionTagString),
+		withAPIResponse: []params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "backing up",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing",
+			}},
+			Enqueued:  time.Date(2015, time.February, 14, 8, 13, 0, 0, time.UTC),
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		expectedOutput: `
+log:
+- 2015-02-14 08:15:10 +0000 UTC backing up
+- 2015-02-14 08:15:20 +0000 UTC compressing
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+  enqueued: 2015-02-14 08:13:00 +0000 UTC
 `[1:],
 	}, {
 		should:            "pretty-print action output with no completed time",
@@ -290,6 +316,72 @@ timing:
 	}
 }
 
+func (s *ShowOutputSuite) TestWatch(c *gc.C) {
+	client := makeFakeClient(
+		0, 10*time.Second,
+		tagsForIdPrefix(validActionId, validActionTagString),
+		[]params.ActionResult{{
+			Status: "completed",
+			Log: []params.ActionMessage{{
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 10, 0, time.UTC),
+				Message:   "backing up",
+			}, {
+				Timestamp: time.Date(2015, time.February, 14, 8, 15, 20, 0, time.UTC),
+				Message:   "compressing",
+			}},
+			Output:    map[string]interface{}{"outfile": "out.tar.bz2"},
+			Completed: time.Date(2015, time.February, 14, 8, 15, 30, 0, time.UTC),
+		}},
+		params.ActionsByNames{},
+		"",
+	)
+	unpatch := s.BaseActionSuite.patchAPIClient(client)
+	defer unpatch()
+
+	cmd, _ := action.NewShowOutputCommandForTest(s.store)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "-m", "admin", validActionId, "--watch")
+	c.Assert(err, gc.IsNil)
+	// Progress messages are streamed before the final result, and are
+	// not repeated in it.
+	c.Check(ctx.Stdout.(*bytes.Buffer).String(), gc.Equals, `
+2015-02-14 08:15:10 +0000 UTC backing up
+2015-02-14 08:15:20 +0000 UTC compressing
+results:
+  outfile: out.tar.bz2
+status: completed
+timing:
+  completed: 2015-02-14 08:15:30 +0000 UTC
+`[1:])
+}
+
+func (s *ShowOutputSuite) TestUnseenActionMessagesCapped(c *gc.C) {
+	message := func(sec int, text string) params.ActionMessage {
+		return params.ActionMessage{
+			Timestamp: time.Date(2015, time.February, 14, 8, 15, sec, 0, time.UTC),
+			Message:   text,
+		}
+	}
+	first := []params.ActionMessage{message(1, "one"), message(2, "two"), message(3, "three")}
+	c.Check(action.UnseenActionMessages(first, nil), gc.DeepEquals, first)
+	last := first[2]
+
+	// Nothing new has been logged.
+	c.Check(action.UnseenActionMessages(first, &last), gc.HasLen, 0)
+
+	// The log has grown past its cap, so the oldest messages have been
+	// dropped and the log is no longer than before.
+	capped := []params.ActionMessage{message(3, "three"), message(4, "four"), message(5, "five")}
+	c.Check(action.UnseenActionMessages(capped, &last), gc.DeepEquals, capped[1:])
+
+	// Every message seen so far has been dropped.
+	rolled := []params.ActionMessage{message(6, "six"), message(7, "seven"), message(8, "eight")}
+	c.Check(action.UnseenActionMessages(rolled, &last), gc.DeepEquals, rolled)
+
+	// A repeated message with a different timestamp is still new.
+	repeated := []params.ActionMessage{message(3, "three"), message(9, "three")}
+	c.Check(action.UnseenActionMessages(repeated, &last), gc.DeepEquals, repeated[1:])
+}
+
 func testRunHelper(c *gc.C, s *ShowOutputSuite, client *fakeAPIClient, expectedErr, expectedOutput, wait, query, modelFlag string) {
 	unpatch := s.BaseActionSuite.patchAPIClient(client)
 	defer unpatch()
@@ -31,6 +31,8 @@ type statusCommand struct {
 const statusDoc = `
 Show the status of Actions matching given ID, partial ID prefix, or all Actions if no ID is supplied.
 If --name <name> is provided the search will be done by name rather than by ID.
+When no ID or name is supplied, actions scheduled with 'juju run-action --at'
+or '--every' are also listed, along with the time of their next run.
 `
 
 // Set up the output.
@@ -82,6 +84,18 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return err
 	}
 
+	var scheduled []interface{}
+	if c.requestedId == "" {
+		schedules, err := api.ListActionSchedules()
+		if err != nil && !errors.IsNotSupported(err) {
+			return errors.Trace(err)
+		}
+		scheduled = schedulesToList(schedules)
+	}
+	if len(actionTags) < 1 && len(scheduled) > 0 {
+		return c.out.Write(ctx, map[string]interface{}{"scheduled": scheduled})
+	}
+
 	if len(actionTags) < 1 {
 		if len(c.requestedId) == 0 {
 			return errors.Errorf("no actions found")
@@ -104,7 +118,38 @@ func (c *statusCommand) Run(ctx *cmd.Context) error {
 		return errors.Errorf("identifier %q matched action(s) %v, but found no results", c.requestedId, actionTags)
 	}
 
-	return c.out.Write(ctx, resultsToMap(actions.Results))
+	output := resultsToMap(actions.Results)
+	if len(scheduled) > 0 {
+		output["scheduled"] = scheduled
+	}
+	return c.out.Write(ctx, output)
+}
+
+// schedulesToList is a helper function that takes in a
+// []params.ActionSchedule and returns a list ready to be served to
+// the formatter for printing.
+func schedulesToList(schedules []params.ActionSchedule) []interface{} {
+	var items []interface{}
+	for _, schedule := range schedules {
+		item := map[string]interface{}{
+			"id":          schedule.Id,
+			"action":      schedule.Name,
+			"next run at": schedule.NextRun.UTC().Format("2006-01-02 15:04:05"),
+		}
+		if rtag, err := names.ParseUnitTag(schedule.Receiver); err != nil {
+			item["unit"] = schedule.Receiver
+		} else {
+			item["unit"] = rtag.Id()
+		}
+		if schedule.Every > 0 {
+			item["every"] = schedule.Every.String()
+		}
+		if atag, err := names.ParseActionTag(schedule.LastActionTag); err == nil {
+			item["last action"] = atag.Id()
+		}
+		items = append(items, item)
+	}
+	return items
 }
 
 // resultsToMap is a helper function that takes in a []params.ActionResult
@@ -159,3 +159,22 @@ type statusTestCase struct {
 	results        []params.ActionResult
 	actionsByNames params.ActionsByNames
 }
+
+func (s *StatusSuite) TestRunShowsSchedules(c *gc.C) {
+	fakeClient := makeFakeClient(0, 5*time.Second, params.FindTagsResults{}, nil, params.ActionsByNames{}, "")
+	fakeClient.actionSchedules = []params.ActionSchedule{{
+		Id:            "some-id",
+		Receiver:      "unit-mysql-0",
+		Name:          "backup",
+		NextRun:       time.Date(2017, time.October, 1, 2, 0, 0, 0, time.UTC),
+		Every:         24 * time.Hour,
+		LastActionTag: validActionTagString,
+	}}
+	restore := s.patchAPIClient(fakeClient)
+	defer restore()
+
+	ctx, err := cmdtesting.RunCommand(c, s.subcommand, "-m", "admin", "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stdout(ctx), gc.Equals, `{"scheduled":[{"action":"backup","every":"24h0m0s",`+
+		`"id":"some-id","last action":"`+validActionId+`","next run at":"2017-10-01 02:00:00","unit":"mysql/0"}]}`+"\n")
+}
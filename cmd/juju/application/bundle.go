@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/juju/bundlechanges"
+	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"gopkg.in/juju/charm.v6-unstable"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -60,29 +61,8 @@ func deployBundle(
 	log deploymentLogger,
 	bundleStorage map[string]map[string]storage.Constraints,
 ) (map[*charm.URL]*macaroon.Macaroon, error) {
-	verifyConstraints := func(s string) error {
-		_, err := constraints.Parse(s)
-		return err
-	}
-	verifyStorage := func(s string) error {
-		_, err := storage.ParseConstraints(s)
-		return err
-	}
-	var verifyError error
-	if bundleFilePath == "" {
-		verifyError = data.Verify(verifyConstraints, verifyStorage)
-	} else {
-		verifyError = data.VerifyLocal(bundleFilePath, verifyConstraints, verifyStorage)
-	}
-	if verifyError != nil {
-		if verr, ok := verifyError.(*charm.VerificationError); ok {
-			errs := make([]string, len(verr.Errors))
-			for i, err := range verr.Errors {
-				errs[i] = err.Error()
-			}
-			return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
-		}
-		return nil, errors.Annotate(verifyError, "cannot deploy bundle")
+	if err := verifyBundle(data, bundleFilePath); err != nil {
+		return nil, errors.Trace(err)
 	}
 
 	// Retrieve bundle changes.
@@ -167,6 +147,78 @@ func deployBundle(
 	return csMacs, nil
 }
 
+// verifyBundle checks that the given bundle data is valid. If bundleDir
+// is not empty, local charm paths in the bundle are resolved relative
+// to it.
+func verifyBundle(data *charm.BundleData, bundleDir string) error {
+	verifyConstraints := func(s string) error {
+		_, err := constraints.Parse(s)
+		return err
+	}
+	verifyStorage := func(s string) error {
+		_, err := storage.ParseConstraints(s)
+		return err
+	}
+	var verifyError error
+	if bundleDir == "" {
+		verifyError = data.Verify(verifyConstraints, verifyStorage)
+	} else {
+		verifyError = data.VerifyLocal(bundleDir, verifyConstraints, verifyStorage)
+	}
+	if verr, ok := verifyError.(*charm.VerificationError); ok {
+		errs := make([]string, len(verr.Errors))
+		for i, err := range verr.Errors {
+			errs[i] = err.Error()
+		}
+		return errors.New("the provided bundle has the following errors:\n" + strings.Join(errs, "\n"))
+	}
+	return errors.Annotate(verifyError, "cannot verify bundle")
+}
+
+// localBundle holds a bundle read from a bundle YAML file, a bundle
+// archive or an exploded bundle directory.
+type localBundle struct {
+	data *charm.BundleData
+	// file holds the path of the bundle YAML file, if the bundle
+	// was read from one.
+	file string
+	// dir holds the path of the exploded bundle directory, if the
+	// bundle was read from one.
+	dir string
+}
+
+// bundleDir returns the directory that local charm paths in the
+// bundle are relative to, or "" if there is none.
+func (b *localBundle) bundleDir(ctx *cmd.Context) string {
+	if b.file != "" {
+		return filepath.Dir(ctx.AbsPath(b.file))
+	}
+	return b.dir
+}
+
+// readLocalBundle reads the bundle at the given path, which may be a
+// bundle YAML file, a bundle archive or an exploded bundle directory.
+// If the path cannot be read as any of these, the error from reading
+// it as a bundle archive or directory is returned unchanged, so that
+// callers can check for charmrepo.IsInvalidPathError and
+// *charmrepo.NotFoundError.
+func readLocalBundle(path string) (*localBundle, error) {
+	data, err := charmrepo.ReadBundleFile(path)
+	if err == nil {
+		return &localBundle{data: data, file: path}, nil
+	}
+	// We may have been given a local bundle archive or exploded directory.
+	bundle, url, err := charmrepo.NewBundleAtPath(path)
+	if err != nil {
+		return nil, err
+	}
+	result := &localBundle{data: bundle.Data()}
+	if info, err := os.Stat(url.String()); err == nil && info.IsDir() {
+		result.dir = url.String()
+	}
+	return result, nil
+}
+
 // bundleHandler provides helpers and the state required to deploy a bundle.
 type bundleHandler struct {
 	// bundleDir is the path where the bundle file is located for local bundles.
@@ -57,10 +57,20 @@ This command requires that the application to have at least one unit. To apply
 constraints to
 the first unit set them at the model level or pass them as an argument
 when deploying.
+The zones constraint restricts the availability zones in which the
+application's machines are provisioned, including those added with
+'juju add-unit', on providers that support availability zones.
+The root-disk-source constraint selects the storage pool or volume type
+of the root disk (e.g. an EBS volume type on AWS, pd-ssd on GCE, or
+"volume" to boot from a Cinder volume on OpenStack), and
+allocate-public-ip controls whether machines are given public IP
+addresses.
 
 Examples:
     juju set-constraints mysql mem=8G cores=4
     juju set-constraints -m mymodel apache2 mem=8G arch=amd64
+    juju set-constraints mysql zones=us-east-1a,us-east-1b
+    juju set-constraints mysql root-disk-source=gp2 allocate-public-ip=false
 
 See also: 
     get-constraints
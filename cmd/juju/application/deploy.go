@@ -6,7 +6,6 @@ package application
 import (
 	"archive/zip"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/juju/cmd"
@@ -764,47 +763,29 @@ func (c *DeployCommand) maybePredeployedLocalCharm() (deployFn, error) {
 }
 
 func (c *DeployCommand) maybeReadLocalBundle() (deployFn, error) {
-	bundleFile := c.CharmOrBundle
-	var (
-		bundleFilePath                string
-		resolveRelativeBundleFilePath bool
-	)
-
-	bundleData, err := charmrepo.ReadBundleFile(bundleFile)
+	bundle, err := readLocalBundle(c.CharmOrBundle)
+	if charmrepo.IsInvalidPathError(err) {
+		return nil, errors.Errorf(""+
+			"The charm or bundle %q is ambiguous.\n"+
+			"To deploy a local charm or bundle, run `juju deploy ./%[1]s`.\n"+
+			"To deploy a charm or bundle from the store, run `juju deploy cs:%[1]s`.",
+			c.CharmOrBundle,
+		)
+	}
 	if err != nil {
-		// We may have been given a local bundle archive or exploded directory.
-		bundle, url, pathErr := charmrepo.NewBundleAtPath(bundleFile)
-		if charmrepo.IsInvalidPathError(pathErr) {
-			return nil, errors.Errorf(""+
-				"The charm or bundle %q is ambiguous.\n"+
-				"To deploy a local charm or bundle, run `juju deploy ./%[1]s`.\n"+
-				"To deploy a charm or bundle from the store, run `juju deploy cs:%[1]s`.",
-				c.CharmOrBundle,
-			)
-		}
-		if pathErr != nil {
-			// If the bundle files existed but we couldn't read them,
-			// then return that error rather than trying to interpret
-			// as a charm.
-			if info, statErr := os.Stat(c.CharmOrBundle); statErr == nil {
-				if info.IsDir() {
-					if _, ok := pathErr.(*charmrepo.NotFoundError); !ok {
-						return nil, pathErr
-					}
+		// If the bundle files existed but we couldn't read them,
+		// then return that error rather than trying to interpret
+		// as a charm.
+		if info, statErr := os.Stat(c.CharmOrBundle); statErr == nil {
+			if info.IsDir() {
+				if _, ok := err.(*charmrepo.NotFoundError); !ok {
+					return nil, err
 				}
 			}
-
-			logger.Debugf("cannot interpret as local bundle: %v", err)
-			return nil, nil
 		}
 
-		bundleData = bundle.Data()
-		bundleFile = url.String()
-		if info, err := os.Stat(bundleFile); err == nil && info.IsDir() {
-			bundleFilePath = bundleFile
-		}
-	} else {
-		resolveRelativeBundleFilePath = true
+		logger.Debugf("cannot interpret as local bundle: %v", err)
+		return nil, nil
 	}
 
 	if err := c.validateBundleFlags(); err != nil {
@@ -812,16 +793,10 @@ func (c *DeployCommand) maybeReadLocalBundle() (deployFn, error) {
 	}
 
 	return func(ctx *cmd.Context, apiRoot DeployAPI) error {
-		// For local bundles, we extract the local path of the bundle
-		// directory.
-		if resolveRelativeBundleFilePath {
-			bundleFilePath = filepath.Dir(ctx.AbsPath(bundleFile))
-		}
-
 		return errors.Trace(c.deployBundle(
 			ctx,
-			bundleFilePath,
-			bundleData,
+			bundle.bundleDir(ctx),
+			bundle.data,
 			c.Channel,
 			apiRoot,
 			c.BundleStorage,
@@ -0,0 +1,575 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application
+
+import (
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/charm.v6-unstable"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/api/bundle"
+	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/constraints"
+)
+
+const diffBundleDoc = `
+Compares the applications, charms, config options, constraints, unit
+counts and relations in a bundle with the current model, and shows
+the differences. Nothing in the model is changed.
+
+Applications that appear in only one of the bundle and the model are
+reported as missing from the other. For applications in both, only
+the attributes that differ are shown, with the bundle and model values
+side by side. Relations are listed as bundle or model additions.
+Bundle relations that omit endpoint names are matched using the
+metadata of the charms deployed in the model.
+
+Only config options set in either the bundle or the model are
+compared. A bundle charm without a revision matches any revision
+of that charm in the model.
+
+Examples:
+
+    juju diff-bundle mediawiki.yaml
+    juju diff-bundle ./bundles/openstack/ --format json
+
+See also:
+    deploy
+    export-bundle
+`
+
+// NewDiffBundleCommand returns a command to compare a bundle with the
+// current model.
+func NewDiffBundleCommand() cmd.Command {
+	cmd := &diffBundleCommand{}
+	cmd.newAPIFunc = func() (DiffBundleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return &diffBundleAPI{
+			Client:       bundle.NewClient(root),
+			charmsClient: apicharms.NewClient(root),
+		}, nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// DiffBundleAPI defines the API methods that the diff-bundle command uses.
+type DiffBundleAPI interface {
+	Close() error
+	GetChanges(bundleDataYAML string) (params.BundleChangesResults, error)
+	ExportBundle() (string, error)
+	CharmInfo(charmURL string) (*apicharms.CharmInfo, error)
+}
+
+// diffBundleAPI implements DiffBundleAPI using the Bundle and Charms
+// facades.
+type diffBundleAPI struct {
+	*bundle.Client
+	charmsClient *apicharms.Client
+}
+
+// CharmInfo is part of the DiffBundleAPI interface.
+func (a *diffBundleAPI) CharmInfo(charmURL string) (*apicharms.CharmInfo, error) {
+	return a.charmsClient.CharmInfo(charmURL)
+}
+
+// diffBundleCommand compares a bundle with the current model.
+type diffBundleCommand struct {
+	modelcmd.ModelCommandBase
+	out        cmd.Output
+	bundle     string
+	newAPIFunc func() (DiffBundleAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *diffBundleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "diff-bundle",
+		Args:    "<bundle file or directory>",
+		Purpose: "Compares a bundle with the current model.",
+		Doc:     diffBundleDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *diffBundleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "yaml", output.DefaultFormatters)
+}
+
+// Init implements cmd.Command.
+func (c *diffBundleCommand) Init(args []string) error {
+	switch len(args) {
+	case 0:
+		return errors.New("no bundle specified")
+	case 1:
+		c.bundle = args[0]
+		return nil
+	default:
+		return cmd.CheckEmpty(args[1:])
+	}
+}
+
+// Run implements cmd.Command.
+func (c *diffBundleCommand) Run(ctx *cmd.Context) error {
+	bundle, err := readLocalBundle(c.bundle)
+	if err != nil {
+		return errors.Annotatef(err, "cannot read bundle %q", c.bundle)
+	}
+	if err := verifyBundle(bundle.data, bundle.bundleDir(ctx)); err != nil {
+		return errors.Trace(err)
+	}
+
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	bundleRelations, err := getBundleRelations(client, bundle.data)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	modelData := &charm.BundleData{}
+	modelYAML, err := client.ExportBundle()
+	switch {
+	case params.IsCodeNotFound(err):
+		// There are no applications in the model.
+	case err != nil:
+		return errors.Annotate(err, "cannot export model")
+	default:
+		modelData, err = charm.ReadBundleData(strings.NewReader(modelYAML))
+		if err != nil {
+			return errors.Annotate(err, "cannot read model bundle")
+		}
+	}
+
+	resolver := &relationResolver{
+		client:    client,
+		modelData: modelData,
+		metas:     make(map[string]*charm.Meta),
+	}
+	for i, relation := range bundleRelations {
+		if bundleRelations[i], err = resolver.resolve(relation); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return c.out.Write(ctx, diffBundle(bundle.data, bundleRelations, modelData))
+}
+
+// getBundleRelations returns the relations that deploying the bundle
+// would add, as computed by the bundle facade's GetChanges, with the
+// application placeholders replaced by application names.
+func getBundleRelations(client DiffBundleAPI, data *charm.BundleData) ([][]string, error) {
+	// The bundle facade only accepts charm URLs, so local charm paths
+	// are replaced with local charm URLs. The charms themselves are
+	// not needed to compute the relations.
+	remoteData := *data
+	remoteData.Applications = make(map[string]*charm.ApplicationSpec)
+	for name, app := range data.Applications {
+		if _, err := charm.ParseURL(app.Charm); err != nil {
+			remoteApp := *app
+			remoteApp.Charm = "local:" + filepath.Base(app.Charm)
+			app = &remoteApp
+		}
+		remoteData.Applications[name] = app
+	}
+	bundleYAML, err := yaml.Marshal(&remoteData)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results, err := client.GetChanges(string(bundleYAML))
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot get bundle changes")
+	}
+	if len(results.Errors) > 0 {
+		return nil, errors.New("the provided bundle has the following errors:\n" + strings.Join(results.Errors, "\n"))
+	}
+
+	// Changes are sorted by requirements, so applications are always
+	// deployed before they are related.
+	applications := make(map[string]string)
+	var relations [][]string
+	for _, change := range results.Changes {
+		switch change.Method {
+		case "deploy":
+			// The application name is the third argument.
+			if len(change.Args) < 3 {
+				return nil, errors.Errorf("invalid arguments for change %q", change.Id)
+			}
+			name, ok := change.Args[2].(string)
+			if !ok {
+				return nil, errors.Errorf("invalid application name for change %q", change.Id)
+			}
+			applications[change.Id] = name
+		case "addRelation":
+			endpoints := make([]string, len(change.Args))
+			for i, arg := range change.Args {
+				placeholder, _ := arg.(string)
+				id, endpoint := splitEndpoint(strings.TrimPrefix(placeholder, "$"))
+				name, ok := applications[id]
+				if !ok {
+					return nil, errors.Errorf("unknown application placeholder %q for change %q", placeholder, change.Id)
+				}
+				if endpoint != "" {
+					name += ":" + endpoint
+				}
+				endpoints[i] = name
+			}
+			relations = append(relations, endpoints)
+		}
+	}
+	return relations, nil
+}
+
+// splitEndpoint splits a relation endpoint in the "application:name"
+// form into its application and endpoint name. The endpoint name is
+// empty if the endpoint does not include one.
+func splitEndpoint(endpoint string) (string, string) {
+	if i := strings.Index(endpoint, ":"); i >= 0 {
+		return endpoint[:i], endpoint[i+1:]
+	}
+	return endpoint, ""
+}
+
+// relationResolver fills in the endpoint names that a bundle relation
+// omits, so that it can be compared with the "application:name"
+// endpoints of the model's relations. Endpoints are inferred from the
+// metadata of the charms deployed in the model, in the same way that
+// relations are inferred when they are added.
+type relationResolver struct {
+	client    DiffBundleAPI
+	modelData *charm.BundleData
+	// metas caches the charm metadata of model applications.
+	metas map[string]*charm.Meta
+}
+
+// resolve returns the relation with its endpoint names filled in.
+// Relations involving applications that are not in the model, or
+// whose endpoints cannot be inferred unambiguously, are returned
+// unchanged.
+func (r *relationResolver) resolve(relation []string) ([]string, error) {
+	if len(relation) != 2 {
+		return relation, nil
+	}
+	var applications, names [2]string
+	for i, endpoint := range relation {
+		applications[i], names[i] = splitEndpoint(endpoint)
+	}
+	if names[0] != "" && names[1] != "" {
+		return relation, nil
+	}
+	var candidates [2][]charm.Relation
+	for i, application := range applications {
+		meta, err := r.meta(application)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if meta == nil {
+			return relation, nil
+		}
+		candidates[i] = charmRelations(meta, names[i])
+	}
+
+	var matches, explicitMatches [][2]charm.Relation
+	for _, rel0 := range candidates[0] {
+		for _, rel1 := range candidates[1] {
+			if rel0.Interface != rel1.Interface || !counterpartRoles(rel0.Role, rel1.Role) {
+				continue
+			}
+			match := [2]charm.Relation{rel0, rel1}
+			matches = append(matches, match)
+			if !rel0.IsImplicit() && !rel1.IsImplicit() {
+				explicitMatches = append(explicitMatches, match)
+			}
+		}
+	}
+	// As when adding relations, implicit relations are only used if
+	// they are the only match.
+	if len(matches) > 1 {
+		matches = explicitMatches
+	}
+	if len(matches) != 1 {
+		return relation, nil
+	}
+	return []string{
+		applications[0] + ":" + matches[0][0].Name,
+		applications[1] + ":" + matches[0][1].Name,
+	}, nil
+}
+
+// meta returns the metadata of the charm of the given model
+// application, or nil if the application is not in the model.
+func (r *relationResolver) meta(application string) (*charm.Meta, error) {
+	if meta, ok := r.metas[application]; ok {
+		return meta, nil
+	}
+	app, ok := r.modelData.Applications[application]
+	if !ok {
+		return nil, nil
+	}
+	info, err := r.client.CharmInfo(app.Charm)
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get charm %q", app.Charm)
+	}
+	r.metas[application] = info.Meta
+	return info.Meta, nil
+}
+
+// charmRelations returns the provided and required relations of the
+// charm with the given name, or all of them if name is empty. The
+// implicit juju-info relation is included.
+func charmRelations(meta *charm.Meta, name string) []charm.Relation {
+	var result []charm.Relation
+	add := func(relations map[string]charm.Relation, role charm.RelationRole) {
+		for relName, rel := range relations {
+			if name != "" && relName != name {
+				continue
+			}
+			rel.Name = relName
+			rel.Role = role
+			result = append(result, rel)
+		}
+	}
+	add(meta.Provides, charm.RoleProvider)
+	add(meta.Requires, charm.RoleRequirer)
+	if _, ok := meta.Provides["juju-info"]; !ok && (name == "" || name == "juju-info") {
+		result = append(result, charm.Relation{
+			Name:      "juju-info",
+			Role:      charm.RoleProvider,
+			Interface: "juju-info",
+			Scope:     charm.ScopeGlobal,
+		})
+	}
+	return result
+}
+
+// counterpartRoles reports whether relations with the given roles
+// can be related to each other.
+func counterpartRoles(a, b charm.RelationRole) bool {
+	return a == charm.RoleProvider && b == charm.RoleRequirer ||
+		a == charm.RoleRequirer && b == charm.RoleProvider
+}
+
+// bundleDiff describes the differences between a bundle and a model.
+type bundleDiff struct {
+	Applications map[string]*applicationDiff `yaml:"applications,omitempty" json:"applications,omitempty"`
+	Relations    *relationsDiff              `yaml:"relations,omitempty" json:"relations,omitempty"`
+}
+
+// applicationDiff describes the differences for a single application.
+// Missing is set to "bundle" or "model" when the application only
+// exists in the other one.
+type applicationDiff struct {
+	Missing     string                 `yaml:"missing,omitempty" json:"missing,omitempty"`
+	Charm       *stringDiff            `yaml:"charm,omitempty" json:"charm,omitempty"`
+	Series      *stringDiff            `yaml:"series,omitempty" json:"series,omitempty"`
+	NumUnits    *intDiff               `yaml:"num_units,omitempty" json:"num_units,omitempty"`
+	Expose      *boolDiff              `yaml:"expose,omitempty" json:"expose,omitempty"`
+	Constraints *stringDiff            `yaml:"constraints,omitempty" json:"constraints,omitempty"`
+	Options     map[string]*optionDiff `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
+// relationsDiff holds the relations that only exist in one of the
+// bundle and the model.
+type relationsDiff struct {
+	BundleAdditions [][]string `yaml:"bundle-additions,omitempty" json:"bundle-additions,omitempty"`
+	ModelAdditions  [][]string `yaml:"model-additions,omitempty" json:"model-additions,omitempty"`
+}
+
+type stringDiff struct {
+	Bundle string `yaml:"bundle" json:"bundle"`
+	Model  string `yaml:"model" json:"model"`
+}
+
+type intDiff struct {
+	Bundle int `yaml:"bundle" json:"bundle"`
+	Model  int `yaml:"model" json:"model"`
+}
+
+type boolDiff struct {
+	Bundle bool `yaml:"bundle" json:"bundle"`
+	Model  bool `yaml:"model" json:"model"`
+}
+
+type optionDiff struct {
+	Bundle interface{} `yaml:"bundle" json:"bundle"`
+	Model  interface{} `yaml:"model" json:"model"`
+}
+
+// diffBundle compares the given bundle data, along with the relations
+// that deploying it would add, with bundle data describing the model.
+func diffBundle(bundleData *charm.BundleData, bundleRelations [][]string, modelData *charm.BundleData) *bundleDiff {
+	result := &bundleDiff{
+		Applications: make(map[string]*applicationDiff),
+	}
+	for name, bundleApp := range bundleData.Applications {
+		modelApp, ok := modelData.Applications[name]
+		if !ok {
+			result.Applications[name] = &applicationDiff{Missing: "model"}
+			continue
+		}
+		diff := diffApplication(
+			bundleApp, effectiveSeries(bundleApp.Series, bundleData.Series),
+			modelApp, effectiveSeries(modelApp.Series, modelData.Series),
+		)
+		if diff != nil {
+			result.Applications[name] = diff
+		}
+	}
+	for name := range modelData.Applications {
+		if _, ok := bundleData.Applications[name]; !ok {
+			result.Applications[name] = &applicationDiff{Missing: "bundle"}
+		}
+	}
+	if len(result.Applications) == 0 {
+		result.Applications = nil
+	}
+
+	bundleSet := relationSet(bundleRelations)
+	modelSet := relationSet(modelData.Relations)
+	var relations relationsDiff
+	for key, relation := range bundleSet {
+		if _, ok := modelSet[key]; !ok {
+			relations.BundleAdditions = append(relations.BundleAdditions, relation)
+		}
+	}
+	for key, relation := range modelSet {
+		if _, ok := bundleSet[key]; !ok {
+			relations.ModelAdditions = append(relations.ModelAdditions, relation)
+		}
+	}
+	if len(relations.BundleAdditions) > 0 || len(relations.ModelAdditions) > 0 {
+		sort.Sort(relationsByEndpoints(relations.BundleAdditions))
+		sort.Sort(relationsByEndpoints(relations.ModelAdditions))
+		result.Relations = &relations
+	}
+	return result
+}
+
+// diffApplication returns the differences between an application in
+// the bundle and in the model, or nil if there are none.
+func diffApplication(bundleApp *charm.ApplicationSpec, bundleSeries string, modelApp *charm.ApplicationSpec, modelSeries string) *applicationDiff {
+	var diff applicationDiff
+	changed := false
+	if charmDiffers(bundleApp.Charm, modelApp.Charm) {
+		diff.Charm = &stringDiff{Bundle: bundleApp.Charm, Model: modelApp.Charm}
+		changed = true
+	}
+	if bundleSeries != "" && bundleSeries != modelSeries {
+		diff.Series = &stringDiff{Bundle: bundleSeries, Model: modelSeries}
+		changed = true
+	}
+	if bundleApp.NumUnits != modelApp.NumUnits {
+		diff.NumUnits = &intDiff{Bundle: bundleApp.NumUnits, Model: modelApp.NumUnits}
+		changed = true
+	}
+	if bundleApp.Expose != modelApp.Expose {
+		diff.Expose = &boolDiff{Bundle: bundleApp.Expose, Model: modelApp.Expose}
+		changed = true
+	}
+	if !sameConstraints(bundleApp.Constraints, modelApp.Constraints) {
+		diff.Constraints = &stringDiff{Bundle: bundleApp.Constraints, Model: modelApp.Constraints}
+		changed = true
+	}
+	for key, bundleValue := range bundleApp.Options {
+		modelValue := modelApp.Options[key]
+		if !reflect.DeepEqual(bundleValue, modelValue) {
+			diff.addOption(key, bundleValue, modelValue)
+		}
+	}
+	for key, modelValue := range modelApp.Options {
+		if _, ok := bundleApp.Options[key]; !ok {
+			diff.addOption(key, nil, modelValue)
+		}
+	}
+	if !changed && len(diff.Options) == 0 {
+		return nil
+	}
+	return &diff
+}
+
+func (d *applicationDiff) addOption(key string, bundleValue, modelValue interface{}) {
+	if d.Options == nil {
+		d.Options = make(map[string]*optionDiff)
+	}
+	d.Options[key] = &optionDiff{Bundle: bundleValue, Model: modelValue}
+}
+
+// effectiveSeries returns the series an application is deployed with,
+// given its own series and the default series of its bundle.
+func effectiveSeries(series, defaultSeries string) string {
+	if series != "" {
+		return series
+	}
+	return defaultSeries
+}
+
+// charmDiffers reports whether the bundle charm refers to a different
+// charm than the one deployed in the model. A bundle charm without a
+// series or revision matches any series or revision.
+func charmDiffers(bundleCharm, modelCharm string) bool {
+	bundleURL, err := charm.ParseURL(bundleCharm)
+	if err != nil {
+		return bundleCharm != modelCharm
+	}
+	modelURL, err := charm.ParseURL(modelCharm)
+	if err != nil {
+		return true
+	}
+	if bundleURL.Schema != modelURL.Schema || bundleURL.Name != modelURL.Name {
+		return true
+	}
+	if bundleURL.User != modelURL.User {
+		return true
+	}
+	if bundleURL.Series != "" && bundleURL.Series != modelURL.Series {
+		return true
+	}
+	return bundleURL.Revision >= 0 && bundleURL.Revision != modelURL.Revision
+}
+
+// sameConstraints reports whether the given constraints strings
+// describe the same constraints.
+func sameConstraints(a, b string) bool {
+	consA, errA := constraints.Parse(a)
+	consB, errB := constraints.Parse(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return consA.String() == consB.String()
+}
+
+// relationSet returns the given relations keyed by a normalised
+// representation, so that endpoint order does not matter.
+func relationSet(relations [][]string) map[string][]string {
+	result := make(map[string][]string)
+	for _, relation := range relations {
+		endpoints := append([]string(nil), relation...)
+		sort.Strings(endpoints)
+		result[strings.Join(endpoints, " ")] = endpoints
+	}
+	return result
+}
+
+type relationsByEndpoints [][]string
+
+func (r relationsByEndpoints) Len() int      { return len(r) }
+func (r relationsByEndpoints) Swap(i, j int) { r[i], r[j] = r[j], r[i] }
+func (r relationsByEndpoints) Less(i, j int) bool {
+	return strings.Join(r[i], " ") < strings.Join(r[j], " ")
+}
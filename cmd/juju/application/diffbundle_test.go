@@ -0,0 +1,443 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package application_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/charm.v6-unstable"
+
+	apicharms "github.com/juju/juju/api/charms"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/application"
+	"github.com/juju/juju/jujuclient"
+)
+
+type DiffBundleSuite struct {
+	testing.IsolationSuite
+	mockAPI *mockDiffBundleAPI
+	store   *jujuclient.MemStore
+	dir     string
+}
+
+var _ = gc.Suite(&DiffBundleSuite{})
+
+func (s *DiffBundleSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.mockAPI = &mockDiffBundleAPI{
+		Stub:   &testing.Stub{},
+		charms: make(map[string]*charm.Meta),
+	}
+	s.dir = c.MkDir()
+
+	controllerName := "test-master"
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = controllerName
+	s.store.Controllers[controllerName] = jujuclient.ControllerDetails{}
+	s.store.Models[controllerName] = &jujuclient.ControllerModels{
+		CurrentModel: "bob/test",
+		Models: map[string]jujuclient.ModelDetails{
+			"bob/test": {"test-uuid"},
+		},
+	}
+	s.store.Accounts[controllerName] = jujuclient.AccountDetails{
+		User: "bob",
+	}
+}
+
+func (s *DiffBundleSuite) writeBundle(c *gc.C, content string) string {
+	path := filepath.Join(s.dir, "bundle.yaml")
+	err := ioutil.WriteFile(path, []byte(content), 0644)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *DiffBundleSuite) runDiffBundle(c *gc.C, args ...string) (string, error) {
+	ctx, err := cmdtesting.RunCommand(c, application.NewDiffBundleCommandForTest(s.mockAPI, s.store), args...)
+	if err != nil {
+		return "", err
+	}
+	return cmdtesting.Stdout(ctx), nil
+}
+
+func (s *DiffBundleSuite) TestNoArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c)
+	c.Assert(err, gc.ErrorMatches, "no bundle specified")
+}
+
+func (s *DiffBundleSuite) TestTooManyArguments(c *gc.C) {
+	_, err := s.runDiffBundle(c, "bundle.yaml", "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
+
+func (s *DiffBundleSuite) TestInvalidBundle(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  wordpress:
+    charm: cs:wordpress
+    constraints: bad=wolf
+`)
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, `the provided bundle has the following errors:
+invalid constraints "bad=wolf" in application "wordpress": unknown constraint "bad"`)
+	s.mockAPI.CheckNoCalls(c)
+}
+
+func (s *DiffBundleSuite) TestNoDifferences(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+`)
+	s.mockAPI.bundle = `
+series: xenial
+applications:
+  mysql:
+    charm: cs:xenial/mysql-42
+    num_units: 1
+    to:
+    - "0"
+machines:
+  "0": {}
+`
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+	s.mockAPI.CheckCallNames(c, "GetChanges", "ExportBundle", "Close")
+}
+
+func (s *DiffBundleSuite) TestEmptyModel(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+    num_units: 1
+`)
+	s.mockAPI.SetErrors(nil, &params.Error{
+		Code:    params.CodeNotFound,
+		Message: "nothing to export as there are no applications",
+	})
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  mysql:
+    missing: model
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestExportError(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+`)
+	s.mockAPI.SetErrors(nil, errors.New("boom"))
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, "cannot export model: boom")
+	s.mockAPI.CheckCallNames(c, "GetChanges", "ExportBundle", "Close")
+}
+
+func (s *DiffBundleSuite) TestBundleChangesErrors(c *gc.C) {
+	path := s.writeBundle(c, `
+applications:
+  mysql:
+    charm: cs:mysql
+`)
+	s.mockAPI.errors = []string{"cannot use bundle"}
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, `the provided bundle has the following errors:
+cannot use bundle`)
+	s.mockAPI.CheckCallNames(c, "GetChanges", "Close")
+}
+
+func (s *DiffBundleSuite) TestRelationEndpointsResolved(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:wordpress
+  mysql:
+    charm: cs:mysql
+  nrpe:
+    charm: cs:nrpe
+relations:
+- [wordpress, mysql]
+- [nrpe, mysql]
+`)
+	s.mockAPI.changes = []*params.BundleChange{
+		deployChange("deploy-1", "wordpress"),
+		deployChange("deploy-2", "mysql"),
+		deployChange("deploy-3", "nrpe"),
+		relationChange("addRelation-4", "$deploy-1", "$deploy-2"),
+		relationChange("addRelation-5", "$deploy-3", "$deploy-2"),
+	}
+	s.mockAPI.bundle = `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+  mysql:
+    charm: cs:xenial/mysql-42
+  nrpe:
+    charm: cs:xenial/nrpe-2
+relations:
+- - wordpress:db
+  - mysql:server
+- - nrpe:general-info
+  - mysql:juju-info
+`
+	s.mockAPI.charms["cs:xenial/wordpress-5"] = &charm.Meta{
+		Requires: map[string]charm.Relation{
+			"db": {Interface: "mysql"},
+		},
+		Provides: map[string]charm.Relation{
+			"website": {Interface: "http"},
+		},
+	}
+	s.mockAPI.charms["cs:xenial/mysql-42"] = &charm.Meta{
+		Provides: map[string]charm.Relation{
+			"server": {Interface: "mysql"},
+		},
+	}
+	s.mockAPI.charms["cs:xenial/nrpe-2"] = &charm.Meta{
+		Requires: map[string]charm.Relation{
+			"general-info": {Interface: "juju-info", Scope: charm.ScopeContainer},
+		},
+	}
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, "{}\n")
+	s.mockAPI.CheckCallNames(c, "GetChanges", "ExportBundle", "CharmInfo", "CharmInfo", "CharmInfo", "Close")
+}
+
+func (s *DiffBundleSuite) TestRelationEndpointsAmbiguous(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:wordpress
+  mysql:
+    charm: cs:mysql
+relations:
+- [wordpress, mysql]
+`)
+	s.mockAPI.changes = []*params.BundleChange{
+		deployChange("deploy-1", "wordpress"),
+		deployChange("deploy-2", "mysql"),
+		relationChange("addRelation-3", "$deploy-1", "$deploy-2"),
+	}
+	s.mockAPI.bundle = `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+  mysql:
+    charm: cs:xenial/mysql-42
+relations:
+- - wordpress:db
+  - mysql:server
+`
+	s.mockAPI.charms["cs:xenial/wordpress-5"] = &charm.Meta{
+		Requires: map[string]charm.Relation{
+			"db":        {Interface: "mysql"},
+			"db-backup": {Interface: "mysql"},
+		},
+	}
+	s.mockAPI.charms["cs:xenial/mysql-42"] = &charm.Meta{
+		Provides: map[string]charm.Relation{
+			"server": {Interface: "mysql"},
+		},
+	}
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+relations:
+  bundle-additions:
+  - - mysql
+    - wordpress
+  model-additions:
+  - - mysql:server
+    - wordpress:db
+`[1:])
+}
+
+func (s *DiffBundleSuite) TestCharmInfoError(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:wordpress
+  mysql:
+    charm: cs:mysql
+relations:
+- [wordpress, mysql]
+`)
+	s.mockAPI.changes = []*params.BundleChange{
+		deployChange("deploy-1", "wordpress"),
+		deployChange("deploy-2", "mysql"),
+		relationChange("addRelation-3", "$deploy-1", "$deploy-2"),
+	}
+	s.mockAPI.bundle = `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+  mysql:
+    charm: cs:xenial/mysql-42
+`
+	s.mockAPI.SetErrors(nil, nil, errors.New("boom"))
+	_, err := s.runDiffBundle(c, path)
+	c.Assert(err, gc.ErrorMatches, `cannot get charm "cs:xenial/wordpress-5": boom`)
+}
+
+func (s *DiffBundleSuite) TestDifferences(c *gc.C) {
+	path := s.writeBundle(c, `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:wordpress
+    num_units: 2
+    expose: true
+    options:
+      blog-title: Juju
+    constraints: mem=4G
+  mysql:
+    charm: cs:mysql-42
+    num_units: 1
+  haproxy:
+    charm: cs:haproxy
+relations:
+- [wordpress:db, mysql:server]
+- [haproxy:reverseproxy, wordpress:website]
+`)
+	s.mockAPI.changes = []*params.BundleChange{
+		deployChange("deploy-1", "wordpress"),
+		deployChange("deploy-2", "mysql"),
+		deployChange("deploy-3", "haproxy"),
+		relationChange("addRelation-4", "$deploy-1:db", "$deploy-2:server"),
+		relationChange("addRelation-5", "$deploy-3:reverseproxy", "$deploy-1:website"),
+	}
+	s.mockAPI.bundle = `
+series: xenial
+applications:
+  wordpress:
+    charm: cs:xenial/wordpress-5
+    num_units: 1
+    options:
+      blog-title: Juju
+      debug: true
+    constraints: mem=4096M
+  mysql:
+    charm: cs:xenial/mysql-40
+    num_units: 1
+  memcached:
+    charm: cs:xenial/memcached-3
+    num_units: 1
+relations:
+- - mysql:server
+  - wordpress:db
+- - wordpress:cache
+  - memcached:cache
+`
+	out, err := s.runDiffBundle(c, path)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(out, gc.Equals, `
+applications:
+  haproxy:
+    missing: model
+  memcached:
+    missing: bundle
+  mysql:
+    charm:
+      bundle: cs:mysql-42
+      model: cs:xenial/mysql-40
+  wordpress:
+    num_units:
+      bundle: 2
+      model: 1
+    expose:
+      bundle: true
+      model: false
+    options:
+      debug:
+        bundle: null
+        model: true
+relations:
+  bundle-additions:
+  - - haproxy:reverseproxy
+    - wordpress:website
+  model-additions:
+  - - memcached:cache
+    - wordpress:cache
+`[1:])
+}
+
+func deployChange(id, application string) *params.BundleChange {
+	return &params.BundleChange{
+		Id:     id,
+		Method: "deploy",
+		Args:   []interface{}{"$addCharm-0", "xenial", application, map[string]interface{}{}, "", map[string]string{}, map[string]string{}, map[string]int{}},
+	}
+}
+
+func relationChange(id, endpoint1, endpoint2 string) *params.BundleChange {
+	return &params.BundleChange{
+		Id:     id,
+		Method: "addRelation",
+		Args:   []interface{}{endpoint1, endpoint2},
+	}
+}
+
+type mockDiffBundleAPI struct {
+	*testing.Stub
+	changes []*params.BundleChange
+	errors  []string
+	bundle  string
+	charms  map[string]*charm.Meta
+}
+
+func (m *mockDiffBundleAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return m.NextErr()
+}
+
+func (m *mockDiffBundleAPI) ExportBundle() (string, error) {
+	m.MethodCall(m, "ExportBundle")
+	if err := m.NextErr(); err != nil {
+		return "", err
+	}
+	return m.bundle, nil
+}
+
+func (m *mockDiffBundleAPI) GetChanges(bundleDataYAML string) (params.BundleChangesResults, error) {
+	m.MethodCall(m, "GetChanges", bundleDataYAML)
+	if err := m.NextErr(); err != nil {
+		return params.BundleChangesResults{}, err
+	}
+	return params.BundleChangesResults{
+		Changes: m.changes,
+		Errors:  m.errors,
+	}, nil
+}
+
+func (m *mockDiffBundleAPI) CharmInfo(charmURL string) (*apicharms.CharmInfo, error) {
+	m.MethodCall(m, "CharmInfo", charmURL)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	meta, ok := m.charms[charmURL]
+	if !ok {
+		return nil, errors.NotFoundf("charm %q", charmURL)
+	}
+	return &apicharms.CharmInfo{URL: charmURL, Meta: meta}, nil
+}
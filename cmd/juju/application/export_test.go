@@ -5,6 +5,7 @@ package application
 
 import (
 	"github.com/juju/cmd"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charmrepo.v2-unstable/csclient"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 
@@ -24,6 +25,8 @@ func NewUpgradeCharmCommandForTest(
 	newCharmUpgradeClient func(api.Connection) CharmUpgradeClient,
 	newModelConfigGetter func(api.Connection) ModelConfigGetter,
 	newResourceLister func(api.Connection) (ResourceLister, error),
+	newStatusGetter func(api.Connection) StatusGetter,
+	clock clock.Clock,
 ) cmd.Command {
 	cmd := &upgradeCharmCommand{
 		DeployResources:       deployResources,
@@ -33,6 +36,8 @@ func NewUpgradeCharmCommandForTest(
 		NewCharmUpgradeClient: newCharmUpgradeClient,
 		NewModelConfigGetter:  newModelConfigGetter,
 		NewResourceLister:     newResourceLister,
+		NewStatusGetter:       newStatusGetter,
+		Clock:                 clock,
 	}
 	cmd.SetClientStore(store)
 	cmd.SetAPIOpen(apiOpen)
@@ -71,6 +76,15 @@ func NewConsumeCommandForTest(
 	return modelcmd.Wrap(c)
 }
 
+// NewDiffBundleCommandForTest returns a DiffBundleCommand with the api provided as specified.
+func NewDiffBundleCommandForTest(api DiffBundleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &diffBundleCommand{newAPIFunc: func() (DiffBundleAPI, error) {
+		return api, nil
+	}}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
 type Patcher interface {
 	PatchValue(dest, value interface{})
 }
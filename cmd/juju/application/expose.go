@@ -4,10 +4,15 @@
 package application
 
 import (
+	"net"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/block"
 	"github.com/juju/juju/cmd/modelcmd"
 )
@@ -19,8 +24,18 @@ var usageExposeDetails = `
 Adjusts the firewall rules and any relevant security mechanisms of the
 cloud to allow public access to the application.
 
+Access may instead be restricted to a set of source CIDRs with
+--to-cidrs, and to the subnets of a set of spaces with --to-spaces.
+The restrictions apply to all of the ports opened by the
+application's units.
+
+Running expose without any of these options allows access from
+anywhere, discarding any previous restrictions.
+
 Examples:
     juju expose wordpress
+    juju expose mysql --to-cidrs 10.0.0.0/8,192.168.1.0/24
+    juju expose mysql --to-spaces office
 
 See also: 
     unexpose`[1:]
@@ -34,6 +49,8 @@ func NewExposeCommand() modelcmd.ModelCommand {
 type exposeCommand struct {
 	modelcmd.ModelCommandBase
 	ApplicationName string
+	ExposeToSpaces  []string
+	ExposeToCIDRs   []string
 }
 
 func (c *exposeCommand) Info() *cmd.Info {
@@ -45,17 +62,34 @@ func (c *exposeCommand) Info() *cmd.Info {
 	}
 }
 
+func (c *exposeCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.ExposeToSpaces), "to-spaces", "Comma separated list of spaces from which to allow access")
+	f.Var(cmd.NewAppendStringsValue(&c.ExposeToCIDRs), "to-cidrs", "Comma separated list of source CIDRs from which to allow access")
+}
+
 func (c *exposeCommand) Init(args []string) error {
 	if len(args) == 0 {
 		return errors.New("no application name specified")
 	}
 	c.ApplicationName = args[0]
+	for _, space := range c.ExposeToSpaces {
+		if !names.IsValidSpace(space) {
+			return errors.NotValidf("space name %q", space)
+		}
+	}
+	for _, cidr := range c.ExposeToCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
 	return cmd.CheckEmpty(args[1:])
 }
 
 type serviceExposeAPI interface {
 	Close() error
 	Expose(serviceName string) error
+	ExposeTo(serviceName string, exposedEndpoints map[string]params.ExposedEndpoint) error
 	Unexpose(serviceName string) error
 }
 
@@ -75,5 +109,23 @@ func (c *exposeCommand) Run(_ *cmd.Context) error {
 		return err
 	}
 	defer client.Close()
-	return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	exposedEndpoints := c.exposedEndpoints()
+	if len(exposedEndpoints) == 0 {
+		return block.ProcessBlockedError(client.Expose(c.ApplicationName), block.BlockChange)
+	}
+	return block.ProcessBlockedError(client.ExposeTo(c.ApplicationName, exposedEndpoints), block.BlockChange)
+}
+
+// exposedEndpoints returns the expose settings specified on the
+// command line, which apply to all endpoints, or nil if none were.
+func (c *exposeCommand) exposedEndpoints() map[string]params.ExposedEndpoint {
+	if len(c.ExposeToSpaces) == 0 && len(c.ExposeToCIDRs) == 0 {
+		return nil
+	}
+	return map[string]params.ExposedEndpoint{
+		"": {
+			ExposeToSpaces: c.ExposeToSpaces,
+			ExposeToCIDRs:  c.ExposeToCIDRs,
+		},
+	}
 }
@@ -12,6 +12,7 @@ import (
 
 	jujutesting "github.com/juju/juju/juju/testing"
 	"github.com/juju/juju/rpc"
+	"github.com/juju/juju/state"
 	"github.com/juju/juju/testcharms"
 	"github.com/juju/juju/testing"
 )
@@ -61,6 +62,31 @@ func (s *ExposeSuite) TestExpose(c *gc.C) {
 	})
 }
 
+func (s *ExposeSuite) TestExposeToCIDRs(c *gc.C) {
+	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
+	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0/8,192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertExposed(c, "some-application-name")
+	app, err := s.State.Application("some-application-name")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(app.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+	})
+
+	err = runExpose(c, "some-application-name", "--to-spaces", "nowhere")
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "some-application-name": space "nowhere" not found`)
+}
+
+func (s *ExposeSuite) TestExposeInvalidSources(c *gc.C) {
+	err := runExpose(c, "some-application-name", "--to-cidrs", "10.0.0.0")
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+	err = runExpose(c, "some-application-name", "--to-spaces", "Office")
+	c.Assert(err, gc.ErrorMatches, `space name "Office" not valid`)
+}
+
 func (s *ExposeSuite) TestBlockExpose(c *gc.C) {
 	ch := testcharms.Repo.CharmArchivePath(s.CharmsPath, "multi-series")
 	_, err := runDeploy(c, ch, "some-application-name", "--series", "trusty")
@@ -6,10 +6,14 @@ package application
 import (
 	"fmt"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"github.com/juju/utils"
+	"github.com/juju/utils/clock"
 	"gopkg.in/juju/charm.v6-unstable"
 	charmresource "gopkg.in/juju/charm.v6-unstable/resource"
 	"gopkg.in/juju/charmrepo.v2-unstable"
@@ -31,9 +35,24 @@ import (
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/resource/resourceadapters"
+	"github.com/juju/juju/status"
 	"github.com/juju/juju/storage"
 )
 
+const (
+	// onErrorPause leaves a failed rolling upgrade paused, with the
+	// remaining units held on the previous charm.
+	onErrorPause = "pause"
+
+	// onErrorRollback returns the application to the previous charm
+	// when a rolling upgrade fails.
+	onErrorRollback = "rollback"
+
+	// rollingPollInterval is how often the status of upgrading units
+	// is checked during a rolling upgrade.
+	rollingPollInterval = 5 * time.Second
+)
+
 // NewUpgradeCharmCommand returns a command which upgrades application's charm.
 func NewUpgradeCharmCommand() cmd.Command {
 	cmd := &upgradeCharmCommand{
@@ -49,6 +68,10 @@ func NewUpgradeCharmCommand() cmd.Command {
 		NewModelConfigGetter: func(conn api.Connection) ModelConfigGetter {
 			return modelconfig.NewClient(conn)
 		},
+		NewStatusGetter: func(conn api.Connection) StatusGetter {
+			return conn.Client()
+		},
+		Clock: clock.WallClock,
 		NewResourceLister: func(conn api.Connection) (ResourceLister, error) {
 			resclient, err := resourceadapters.NewAPIClient(conn)
 			if err != nil {
@@ -66,6 +89,14 @@ type CharmUpgradeClient interface {
 	GetCharmURL(string) (*charm.URL, error)
 	Get(string) (*params.ApplicationGetResults, error)
 	SetCharm(application.SetCharmConfig) error
+	ReleaseCharmUpgrade(string, ...string) error
+	CharmUpgradeHold(string) (*charm.URL, []string, error)
+}
+
+// StatusGetter defines a subset of the client facade, as required
+// by the upgrade-charm command to monitor a rolling upgrade.
+type StatusGetter interface {
+	Status(patterns []string) (*params.FullStatus, error)
 }
 
 // CharmClient defines a subset of the charms facade, as required
@@ -99,6 +130,8 @@ type upgradeCharmCommand struct {
 	NewCharmUpgradeClient func(api.Connection) CharmUpgradeClient
 	NewModelConfigGetter  func(api.Connection) ModelConfigGetter
 	NewResourceLister     func(api.Connection) (ResourceLister, error)
+	NewStatusGetter       func(api.Connection) StatusGetter
+	Clock                 clock.Clock
 
 	ApplicationName string
 	ForceUnits      bool
@@ -121,6 +154,22 @@ type upgradeCharmCommand struct {
 	// Storage is a map of storage constraints, keyed on the storage name
 	// defined in charm storage metadata, to add or update during upgrade.
 	Storage map[string]storage.Constraints
+
+	// Rolling upgrades the application's units a batch at a time,
+	// waiting for each batch to become healthy before continuing.
+	Rolling bool
+
+	// BatchSize is the number of units upgraded at once during a
+	// rolling upgrade.
+	BatchSize int
+
+	// WaitTimeout is how long to wait for each batch of units to
+	// become healthy during a rolling upgrade.
+	WaitTimeout time.Duration
+
+	// OnError determines what happens when a unit fails during a
+	// rolling upgrade: either "pause" or "rollback".
+	OnError string
 }
 
 const upgradeCharmDoc = `
@@ -194,6 +243,25 @@ would specify revision number 5 of the wordpress charm.
 Use of the --force-units flag is not generally recommended; units upgraded while in an
 error state will not have upgrade-charm hooks executed, and may cause unexpected
 behavior.
+
+The --rolling flag upgrades the application's units in batches rather than all
+at once. Units wait on the current charm until their batch is reached; the
+next batch is not started until every unit in the current batch is running the
+new charm, has an "active" workload status and an idle agent. The size of each
+batch is set with --batch-size, and --wait-timeout limits how long to wait for
+a batch to become healthy.
+
+  juju upgrade-charm foo --rolling --batch-size 2
+
+If a unit goes into an error state, or a batch does not become healthy in time,
+the upgrade is either paused or rolled back, as chosen with --on-error. When
+paused, the remaining units stay on the previous charm; resolve the problem and
+run "juju upgrade-charm <application> --rolling" again to resume the upgrade,
+or run upgrade-charm with --switch to the previous charm to abandon it. A
+rollback returns every unit to the previous charm, including units in an error
+state.
+
+--rolling and --force-units are mutually exclusive.
 `
 
 func (c *upgradeCharmCommand) Info() *cmd.Info {
@@ -216,6 +284,10 @@ func (c *upgradeCharmCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(stringMap{&c.Resources}, "resource", "Resource to be uploaded to the controller")
 	f.Var(storageFlag{&c.Storage, nil}, "storage", "Charm storage constraints")
 	f.Var(&c.Config, "config", "Path to yaml-formatted application config")
+	f.BoolVar(&c.Rolling, "rolling", false, "Upgrade units in batches, waiting for each batch to become healthy")
+	f.IntVar(&c.BatchSize, "batch-size", 1, "Number of units to upgrade at once with --rolling")
+	f.DurationVar(&c.WaitTimeout, "wait-timeout", 10*time.Minute, "How long to wait for each batch of units to become healthy with --rolling")
+	f.StringVar(&c.OnError, "on-error", onErrorPause, `What to do if a unit fails with --rolling: "pause" or "rollback"`)
 }
 
 func (c *upgradeCharmCommand) Init(args []string) error {
@@ -239,6 +311,18 @@ func (c *upgradeCharmCommand) Init(args []string) error {
 	if c.SwitchURL != "" && c.CharmPath != "" {
 		return errors.Errorf("--switch and --path are mutually exclusive")
 	}
+	if c.Rolling && c.ForceUnits {
+		return errors.Errorf("--rolling and --force-units are mutually exclusive")
+	}
+	if c.BatchSize < 1 {
+		return errors.Errorf("--batch-size must be a positive number, got %d", c.BatchSize)
+	}
+	if c.WaitTimeout <= 0 {
+		return errors.Errorf("--wait-timeout must be positive, got %v", c.WaitTimeout)
+	}
+	if c.OnError != onErrorPause && c.OnError != onErrorRollback {
+		return errors.Errorf("--on-error must be %q or %q, got %q", onErrorPause, onErrorRollback, c.OnError)
+	}
 	return nil
 }
 
@@ -267,7 +351,34 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	if c.Rolling && apiRoot.BestFacadeVersion("Application") < 6 {
+		suffix := "this server"
+		if version, ok := apiRoot.ServerVersion(); ok {
+			suffix = fmt.Sprintf("server version %s", version)
+		}
+		return errors.New("rolling upgrades are not supported by " + suffix)
+	}
+
 	charmUpgradeClient := c.NewCharmUpgradeClient(apiRoot)
+	if c.Rolling {
+		// A rolling upgrade that was paused is resumed, rather than
+		// starting another.
+		heldURL, heldUnits, err := charmUpgradeClient.CharmUpgradeHold(c.ApplicationName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if heldURL != nil {
+			if c.SwitchURL != "" || c.CharmPath != "" || c.Revision != -1 {
+				return errors.Errorf(
+					"rolling upgrade of %q already in progress; run without --switch, --path or --revision to resume it",
+					c.ApplicationName,
+				)
+			}
+			ctx.Infof("Resuming rolling upgrade of %q; %d unit(s) remain on %q.", c.ApplicationName, len(heldUnits), heldURL)
+			return c.rollingUpgrade(ctx, charmUpgradeClient, c.NewStatusGetter(apiRoot), heldURL, c.Channel, heldUnits)
+		}
+	}
+
 	oldURL, err := charmUpgradeClient.GetCharmURL(c.ApplicationName)
 	if err != nil {
 		return errors.Trace(err)
@@ -342,8 +453,135 @@ func (c *upgradeCharmCommand) Run(ctx *cmd.Context) error {
 		ForceUnits:         c.ForceUnits,
 		ResourceIDs:        ids,
 		StorageConstraints: c.Storage,
+		Rolling:            c.Rolling,
+	}
+	if err := charmUpgradeClient.SetCharm(cfg); err != nil {
+		return block.ProcessBlockedError(err, block.BlockChange)
+	}
+	if !c.Rolling {
+		return nil
+	}
+	heldURL, heldUnits, err := charmUpgradeClient.CharmUpgradeHold(c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if heldURL == nil {
+		// There were no units running the previous charm.
+		return nil
+	}
+	return c.rollingUpgrade(ctx, charmUpgradeClient, c.NewStatusGetter(apiRoot), heldURL, chID.Channel, heldUnits)
+}
+
+// rollingUpgrade releases the held units of the application a batch at
+// a time, waiting for each batch to upgrade and become healthy before
+// releasing the next. If a batch fails, the upgrade is paused or rolled
+// back to prevURL according to c.OnError.
+func (c *upgradeCharmCommand) rollingUpgrade(
+	ctx *cmd.Context,
+	client CharmUpgradeClient,
+	statusGetter StatusGetter,
+	prevURL *charm.URL,
+	prevChannel csclientparams.Channel,
+	heldUnits []string,
+) error {
+	newURL, err := client.GetCharmURL(c.ApplicationName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	remaining := append([]string(nil), heldUnits...)
+	utils.SortStringsNaturally(remaining)
+	for len(remaining) > 0 {
+		n := c.BatchSize
+		if n > len(remaining) {
+			n = len(remaining)
+		}
+		batch := remaining[:n]
+		remaining = remaining[n:]
+
+		ctx.Infof("Upgrading %s to %q.", strings.Join(batch, ", "), newURL)
+		if err := client.ReleaseCharmUpgrade(c.ApplicationName, batch...); err != nil {
+			return block.ProcessBlockedError(err, block.BlockChange)
+		}
+		problem, err := c.waitForUnits(statusGetter, newURL, batch)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if problem == "" {
+			continue
+		}
+		if c.OnError == onErrorRollback {
+			ctx.Infof("%s; rolling back to %q.", problem, prevURL)
+			err := client.SetCharm(application.SetCharmConfig{
+				ApplicationName: c.ApplicationName,
+				CharmID: charmstore.CharmID{
+					URL:     prevURL,
+					Channel: prevChannel,
+				},
+				// Failed units cannot otherwise be returned to
+				// the previous charm.
+				ForceUnits: true,
+			})
+			if err != nil {
+				return errors.Annotatef(block.ProcessBlockedError(err, block.BlockChange), "rolling back to %q", prevURL)
+			}
+			return errors.Errorf("rolling upgrade of %q failed and was rolled back to %q", c.ApplicationName, prevURL)
+		}
+		return errors.Errorf(
+			"rolling upgrade of %q paused: %s; %d unit(s) remain on %q",
+			c.ApplicationName, problem, len(remaining), prevURL,
+		)
+	}
+	ctx.Infof("Rolling upgrade of %q to %q complete.", c.ApplicationName, newURL)
+	return nil
+}
+
+// waitForUnits waits until each of the named units is running the
+// charm with the given URL, with an active workload and an idle agent.
+// If a unit fails, or the units do not become healthy before the wait
+// timeout expires, a description of the problem is returned.
+func (c *upgradeCharmCommand) waitForUnits(statusGetter StatusGetter, curl *charm.URL, unitNames []string) (string, error) {
+	deadline := c.Clock.Now().Add(c.WaitTimeout)
+	for {
+		fullStatus, err := statusGetter.Status([]string{c.ApplicationName})
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		appStatus, ok := fullStatus.Applications[c.ApplicationName]
+		if !ok {
+			return "", errors.NotFoundf("application %q", c.ApplicationName)
+		}
+		var pending []string
+		for _, name := range unitNames {
+			unitStatus, ok := appStatus.Units[name]
+			if !ok {
+				// The unit has been removed, so there is
+				// nothing to wait for.
+				continue
+			}
+			if unitStatus.WorkloadStatus.Status == string(status.Error) {
+				return fmt.Sprintf("unit %s failed: %s", name, unitStatus.WorkloadStatus.Info), nil
+			}
+			unitCharm := unitStatus.Charm
+			if unitCharm == "" {
+				unitCharm = appStatus.Charm
+			}
+			if unitCharm != curl.String() ||
+				unitStatus.WorkloadStatus.Status != string(status.Active) ||
+				unitStatus.AgentStatus.Status != string(status.Idle) {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return "", nil
+		}
+		if !c.Clock.Now().Before(deadline) {
+			return fmt.Sprintf(
+				"timed out after %v waiting for %s to become healthy",
+				c.WaitTimeout, strings.Join(pending, ", "),
+			), nil
+		}
+		<-c.Clock.After(rollingPollInterval)
 	}
-	return block.ProcessBlockedError(charmUpgradeClient.SetCharm(cfg), block.BlockChange)
 }
 
 // upgradeResources pushes metadata up to the server for each resource defined
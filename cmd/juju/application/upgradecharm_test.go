@@ -11,6 +11,7 @@ import (
 	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -61,6 +62,8 @@ type UpgradeCharmSuite struct {
 	charmUpgradeClient mockCharmUpgradeClient
 	modelConfigGetter  mockModelConfigGetter
 	resourceLister     mockResourceLister
+	statusGetter       mockStatusGetter
+	clock              *testing.Clock
 	cmd                cmd.Command
 }
 
@@ -119,6 +122,8 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 	s.charmUpgradeClient = mockCharmUpgradeClient{charmURL: currentCharmURL}
 	s.modelConfigGetter = mockModelConfigGetter{}
 	s.resourceLister = mockResourceLister{}
+	s.statusGetter = mockStatusGetter{}
+	s.clock = testing.NewClock(time.Time{})
 
 	store := jujuclient.NewMemStore()
 	store.CurrentControllerName = "foo"
@@ -162,6 +167,11 @@ func (s *UpgradeCharmSuite) SetUpTest(c *gc.C) {
 			s.AddCall("NewResourceLister", conn)
 			return &s.resourceLister, s.NextErr()
 		},
+		func(conn api.Connection) StatusGetter {
+			s.AddCall("NewStatusGetter", conn)
+			return &s.statusGetter
+		},
+		s.clock,
 	)
 }
 
@@ -231,6 +241,152 @@ func (s *UpgradeCharmSuite) TestConfigSettingsMinFacadeVersion(c *gc.C) {
 		"updating config at upgrade-charm time is not supported by server version 1.2.3")
 }
 
+func (s *UpgradeCharmSuite) setupRolling(unitStatus ...string) {
+	s.apiConnection.bestFacadeVersion = 6
+	s.charmUpgradeClient.rollingUnits = []string{"foo/10", "foo/2", "foo/1"}
+	units := make(map[string]params.UnitStatus)
+	for _, name := range s.charmUpgradeClient.rollingUnits {
+		units[name] = params.UnitStatus{
+			WorkloadStatus: params.DetailedStatus{Status: "active"},
+			AgentStatus:    params.DetailedStatus{Status: "idle"},
+		}
+	}
+	for i := 0; i < len(unitStatus); i += 2 {
+		unit := units[unitStatus[i]]
+		unit.WorkloadStatus = params.DetailedStatus{Status: "error", Info: unitStatus[i+1]}
+		units[unitStatus[i]] = unit
+	}
+	s.statusGetter.status = &params.FullStatus{
+		Applications: map[string]params.ApplicationStatus{
+			"foo": {
+				Charm: s.resolvedCharmURL.String(),
+				Units: units,
+			},
+		},
+	}
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgrade(c *gc.C) {
+	s.setupRolling()
+	ctx, err := s.runUpgradeCharm(c, "foo", "--rolling", "--batch-size", "2")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c,
+		"CharmUpgradeHold", "GetCharmURL", "Get", "SetCharm", "CharmUpgradeHold",
+		"GetCharmURL", "ReleaseCharmUpgrade", "ReleaseCharmUpgrade",
+	)
+	s.charmUpgradeClient.CheckCall(c, 3, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     s.resolvedCharmURL,
+			Channel: csclientparams.StableChannel,
+		},
+		Rolling: true,
+	})
+	s.charmUpgradeClient.CheckCall(c, 6, "ReleaseCharmUpgrade", "foo", []string{"foo/1", "foo/2"})
+	s.charmUpgradeClient.CheckCall(c, 7, "ReleaseCharmUpgrade", "foo", []string{"foo/10"})
+	s.statusGetter.CheckCallNames(c, "Status", "Status")
+	c.Assert(cmdtesting.Stderr(ctx), jc.Contains, `Rolling upgrade of "foo" to "cs:quantal/foo-2" complete.`)
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradePause(c *gc.C) {
+	s.setupRolling("foo/1", `hook failed: "upgrade-charm"`)
+	_, err := s.runUpgradeCharm(c, "foo", "--rolling")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "foo" paused: unit foo/1 failed: hook failed: "upgrade-charm"; 2 unit\(s\) remain on "cs:quantal/foo-1"`)
+	s.charmUpgradeClient.CheckCallNames(c,
+		"CharmUpgradeHold", "GetCharmURL", "Get", "SetCharm", "CharmUpgradeHold",
+		"GetCharmURL", "ReleaseCharmUpgrade",
+	)
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeRollback(c *gc.C) {
+	s.setupRolling("foo/1", `hook failed: "upgrade-charm"`)
+	_, err := s.runUpgradeCharm(c, "foo", "--rolling", "--on-error", "rollback")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "foo" failed and was rolled back to "cs:quantal/foo-1"`)
+	s.charmUpgradeClient.CheckCallNames(c,
+		"CharmUpgradeHold", "GetCharmURL", "Get", "SetCharm", "CharmUpgradeHold",
+		"GetCharmURL", "ReleaseCharmUpgrade", "SetCharm",
+	)
+	s.charmUpgradeClient.CheckCall(c, 7, "SetCharm", application.SetCharmConfig{
+		ApplicationName: "foo",
+		CharmID: jujucharmstore.CharmID{
+			URL:     charm.MustParseURL("cs:quantal/foo-1"),
+			Channel: csclientparams.StableChannel,
+		},
+		ForceUnits: true,
+	})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeTimeout(c *gc.C) {
+	s.setupRolling()
+	unit := s.statusGetter.status.Applications["foo"].Units["foo/1"]
+	unit.AgentStatus.Status = "executing"
+	s.statusGetter.status.Applications["foo"].Units["foo/1"] = unit
+
+	errc := make(chan error)
+	go func() {
+		_, err := s.runUpgradeCharm(c, "foo", "--rolling", "--wait-timeout", "8s")
+		errc <- err
+	}()
+	for i := 0; i < 2; i++ {
+		err := s.clock.WaitAdvance(5*time.Second, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	select {
+	case err := <-errc:
+		c.Assert(err, gc.ErrorMatches, `rolling upgrade of "foo" paused: timed out after 8s waiting for foo/1 to become healthy; 2 unit\(s\) remain on "cs:quantal/foo-1"`)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for upgrade-charm")
+	}
+	s.statusGetter.CheckCallNames(c, "Status", "Status", "Status")
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeResume(c *gc.C) {
+	s.setupRolling()
+	s.charmUpgradeClient.charmURL = s.resolvedCharmURL
+	s.charmUpgradeClient.heldURL = charm.MustParseURL("cs:quantal/foo-1")
+	s.charmUpgradeClient.heldUnits = []string{"foo/2"}
+	_, err := s.runUpgradeCharm(c, "foo", "--rolling")
+	c.Assert(err, jc.ErrorIsNil)
+	s.charmUpgradeClient.CheckCallNames(c, "CharmUpgradeHold", "GetCharmURL", "ReleaseCharmUpgrade")
+	s.charmUpgradeClient.CheckCall(c, 2, "ReleaseCharmUpgrade", "foo", []string{"foo/2"})
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeResumeWithRevision(c *gc.C) {
+	s.setupRolling()
+	s.charmUpgradeClient.heldURL = charm.MustParseURL("cs:quantal/foo-1")
+	s.charmUpgradeClient.heldUnits = []string{"foo/2"}
+	_, err := s.runUpgradeCharm(c, "foo", "--rolling", "--revision", "3")
+	c.Assert(err, gc.ErrorMatches, `rolling upgrade of "foo" already in progress; run without --switch, --path or --revision to resume it`)
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeMinFacadeVersion(c *gc.C) {
+	s.apiConnection.bestFacadeVersion = 5
+	_, err := s.runUpgradeCharm(c, "foo", "--rolling")
+	c.Assert(err, gc.ErrorMatches, "rolling upgrades are not supported by server version 1.2.3")
+}
+
+func (s *UpgradeCharmSuite) TestRollingUpgradeInvalidArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"--rolling", "--force-units"},
+		err:  "--rolling and --force-units are mutually exclusive",
+	}, {
+		args: []string{"--rolling", "--batch-size", "0"},
+		err:  "--batch-size must be a positive number, got 0",
+	}, {
+		args: []string{"--rolling", "--wait-timeout", "0s"},
+		err:  "--wait-timeout must be positive, got 0s",
+	}, {
+		args: []string{"--rolling", "--on-error", "ignore"},
+		err:  `--on-error must be "pause" or "rollback", got "ignore"`,
+	}} {
+		_, err := s.runUpgradeCharm(c, append([]string{"foo"}, test.args...)...)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
 type UpgradeCharmErrorsStateSuite struct {
 	jujutesting.RepoSuite
 	handler charmstore.HTTPCloseHandler
@@ -744,6 +900,12 @@ type mockCharmUpgradeClient struct {
 	CharmUpgradeClient
 	testing.Stub
 	charmURL *charm.URL
+
+	// rollingUnits are held on the current charm by
+	// a rolling call to SetCharm.
+	rollingUnits []string
+	heldURL      *charm.URL
+	heldUnits    []string
 }
 
 func (m *mockCharmUpgradeClient) GetCharmURL(applicationName string) (*charm.URL, error) {
@@ -753,9 +915,36 @@ func (m *mockCharmUpgradeClient) GetCharmURL(applicationName string) (*charm.URL
 
 func (m *mockCharmUpgradeClient) SetCharm(cfg application.SetCharmConfig) error {
 	m.MethodCall(m, "SetCharm", cfg)
+	if err := m.NextErr(); err != nil {
+		return err
+	}
+	if cfg.Rolling {
+		m.heldURL, m.heldUnits = m.charmURL, m.rollingUnits
+	}
+	m.charmURL = cfg.CharmID.URL
+	return nil
+}
+
+func (m *mockCharmUpgradeClient) ReleaseCharmUpgrade(applicationName string, unitNames ...string) error {
+	m.MethodCall(m, "ReleaseCharmUpgrade", applicationName, unitNames)
 	return m.NextErr()
 }
 
+func (m *mockCharmUpgradeClient) CharmUpgradeHold(applicationName string) (*charm.URL, []string, error) {
+	m.MethodCall(m, "CharmUpgradeHold", applicationName)
+	return m.heldURL, m.heldUnits, m.NextErr()
+}
+
+type mockStatusGetter struct {
+	testing.Stub
+	status *params.FullStatus
+}
+
+func (m *mockStatusGetter) Status(patterns []string) (*params.FullStatus, error) {
+	m.MethodCall(m, "Status", patterns)
+	return m.status, m.NextErr()
+}
+
 func (m *mockCharmUpgradeClient) Get(applicationName string) (*params.ApplicationGetResults, error) {
 	m.MethodCall(m, "Get", applicationName)
 	return &params.ApplicationGetResults{}, m.NextErr()
@@ -24,7 +24,7 @@ import (
 type APIClient interface {
 	io.Closer
 	// Create sends an RPC request to create a new backup.
-	Create(notes string) (*params.BackupsMetadataResult, error)
+	Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error)
 	// Info gets the backup's metadata.
 	Info(id string) (*params.BackupsMetadataResult, error)
 	// List gets all stored metadata.
@@ -80,6 +80,9 @@ func (c *CommandBase) dumpMetadata(ctx *cmd.Context, result *params.BackupsMetad
 	fmt.Fprintf(ctx.Stdout, "started:         %v\n", result.Started)
 	fmt.Fprintf(ctx.Stdout, "finished:        %v\n", result.Finished)
 	fmt.Fprintf(ctx.Stdout, "notes:           %q\n", result.Notes)
+	if result.Encrypted {
+		fmt.Fprintf(ctx.Stdout, "encrypted:       true\n")
+	}
 
 	fmt.Fprintf(ctx.Stdout, "model ID:        %q\n", result.Model)
 	fmt.Fprintf(ctx.Stdout, "machine ID:      %q\n", result.Machine)
@@ -105,6 +108,17 @@ func getArchive(filename string) (rc ArchiveReader, metaResult *params.BackupsMe
 		return nil, nil, errors.Trace(err)
 	}
 
+	encrypted, err := statebackups.IsEncryptedArchive(archive)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	if encrypted {
+		return nil, nil, errors.Errorf("backup archive %q is encrypted and must be decrypted first", filename)
+	}
+	if _, err := archive.Seek(0, os.SEEK_SET); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+
 	// Extract the metadata.
 	ad, err := statebackups.NewArchiveDataReader(archive)
 	if err != nil {
@@ -4,6 +4,7 @@
 package backups
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"os"
@@ -12,7 +13,9 @@ import (
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
+	"golang.org/x/crypto/ssh/terminal"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/state/backups"
 )
@@ -44,6 +47,19 @@ Therefore, you should use the --download or --filename options, or use:
 to get a local copy of the backup archive.
 This local copy can then be used to restore an model even if that
 model was already destroyed or is otherwise unavailable.
+
+The --encrypt option prompts for a key with which the backup archive is
+encrypted before it is stored.  The same key is needed to decrypt the
+archive; it is not stored anywhere by juju.
+
+The --upload-to option uploads a copy of the backup archive to remote
+storage once it has been created.  S3-compatible storage is supported,
+with locations of the form:
+
+    s3://<bucket>[/<prefix>][?endpoint=<url>&region=<region>]
+
+The credentials used for the upload are taken from the
+AWS_ACCESS_KEY_ID and AWS_SECRET_ACCESS_KEY environment variables.
 `
 
 // NewCreateCommand returns a command used to create backups.
@@ -60,6 +76,12 @@ type createCommand struct {
 	Filename string
 	// Notes is the custom message to associated with the new backup.
 	Notes string
+	// Encrypt means the backup archive should be encrypted with a
+	// key read from the user.
+	Encrypt bool
+	// UploadTo is the location of remote storage to which the backup
+	// archive should be uploaded.
+	UploadTo string
 }
 
 // Info implements Command.Info.
@@ -77,6 +99,8 @@ func (c *createCommand) SetFlags(f *gnuflag.FlagSet) {
 	c.CommandBase.SetFlags(f)
 	f.BoolVar(&c.NoDownload, "no-download", false, "Do not download the archive")
 	f.StringVar(&c.Filename, "filename", notset, "Download to this file")
+	f.BoolVar(&c.Encrypt, "encrypt", false, "Encrypt the archive with a key read from the terminal")
+	f.StringVar(&c.UploadTo, "upload-to", "", "Upload a copy of the archive to this remote storage location")
 }
 
 // Init implements Command.Init.
@@ -104,16 +128,34 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 			return err
 		}
 	}
+	args := params.BackupsCreateArgs{
+		Notes:    c.Notes,
+		UploadTo: c.UploadTo,
+	}
+	if c.Encrypt {
+		key, err := readAndConfirmKey(ctx)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		args.EncryptionKey = key
+	}
+	if c.UploadTo != "" {
+		args.UploadCredentials = uploadCredentials()
+	}
+
 	client, err := c.NewAPIClient()
 	if err != nil {
 		return errors.Trace(err)
 	}
 	defer client.Close()
 
-	result, err := client.Create(c.Notes)
+	result, err := client.Create(args)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if c.UploadTo != "" && c.Log != nil && !c.Log.Quiet {
+		fmt.Fprintf(ctx.Stderr, "uploaded to %s\n", c.UploadTo)
+	}
 
 	if c.Log != nil && !c.Log.Quiet {
 		if c.NoDownload {
@@ -125,7 +167,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	fmt.Fprintln(ctx.Stdout, result.ID)
 
 	// Handle download.
-	filename := c.decideFilename(ctx, c.Filename, result.Started)
+	filename := c.decideFilename(ctx, c.Filename, result.Started, result.Encrypted)
 	if filename != "" {
 		if err := c.download(ctx, result.ID, filename); err != nil {
 			return errors.Trace(err)
@@ -135,7 +177,7 @@ func (c *createCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
-func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time) string {
+func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timestamp time.Time, encrypted bool) string {
 	if filename != notset {
 		return filename
 	}
@@ -144,7 +186,74 @@ func (c *createCommand) decideFilename(ctx *cmd.Context, filename string, timest
 	}
 
 	// Downloading but no filename given, so generate one.
-	return timestamp.Format(backups.FilenameTemplate)
+	filename = timestamp.Format(backups.FilenameTemplate)
+	if encrypted {
+		filename += backups.EncryptedFilenameSuffix
+	}
+	return filename
+}
+
+// uploadCredentials returns the credentials for uploading the backup
+// archive to remote storage, taken from the environment.
+func uploadCredentials() map[string]string {
+	creds := make(map[string]string)
+	if accessKey := os.Getenv("AWS_ACCESS_KEY_ID"); accessKey != "" {
+		creds[backups.S3AccessKey] = accessKey
+	}
+	if secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY"); secretKey != "" {
+		creds[backups.S3SecretKey] = secretKey
+	}
+	return creds
+}
+
+func readAndConfirmKey(ctx *cmd.Context) (string, error) {
+	// As with passwords, don't add the carriage returns before
+	// readKey, but add them directly after so that any errors are
+	// output on their own lines.
+	fmt.Fprint(ctx.Stderr, "encryption key: ")
+	key, err := readKey(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if key == "" {
+		return "", errors.Errorf("you must enter an encryption key")
+	}
+
+	fmt.Fprint(ctx.Stderr, "type encryption key again: ")
+	verify, err := readKey(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if key != verify {
+		return "", errors.New("encryption keys do not match")
+	}
+	return key, nil
+}
+
+func readKey(stdin io.Reader) (string, error) {
+	if f, ok := stdin.(*os.File); ok && terminal.IsTerminal(int(f.Fd())) {
+		key, err := terminal.ReadPassword(int(f.Fd()))
+		if err != nil {
+			return "", errors.Trace(err)
+		}
+		return string(key), nil
+	}
+	// Read one byte at a time to avoid reading beyond the delimiter.
+	line, err := bufio.NewReader(byteAtATimeReader{stdin}).ReadString('\n')
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return line[:len(line)-1], nil
+}
+
+type byteAtATimeReader struct {
+	io.Reader
+}
+
+func (r byteAtATimeReader) Read(out []byte) (int, error) {
+	return r.Reader.Read(out[:1])
 }
 
 func (c *createCommand) download(ctx *cmd.Context, id string, filename string) error {
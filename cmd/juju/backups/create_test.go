@@ -13,6 +13,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/backups"
 )
 
@@ -139,3 +140,67 @@ func (s *createSuite) TestError(c *gc.C) {
 
 	c.Check(errors.Cause(err), gc.ErrorMatches, "failed!")
 }
+
+func (s *createSuite) runWithStdin(c *gc.C, stdin string, args ...string) (*cmd.Context, error) {
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = strings.NewReader(stdin)
+	if err := cmdtesting.InitCommand(s.wrappedCommand, args); err != nil {
+		return ctx, err
+	}
+	return ctx, s.wrappedCommand.Run(ctx)
+}
+
+func (s *createSuite) TestEncrypt(c *gc.C) {
+	client := s.setSuccess()
+	ctx, err := s.runWithStdin(c, "sekrit\nsekrit\n", "--no-download", "--encrypt")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs.EncryptionKey, gc.Equals, "sekrit")
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "encryption key: \ntype encryption key again: \n")
+}
+
+func (s *createSuite) TestEncryptKeysDoNotMatch(c *gc.C) {
+	client := s.setSuccess()
+	_, err := s.runWithStdin(c, "sekrit\nsecret\n", "--no-download", "--encrypt")
+	c.Assert(err, gc.ErrorMatches, "encryption keys do not match")
+	c.Check(client.calls, gc.HasLen, 0)
+}
+
+func (s *createSuite) TestEncryptEmptyKey(c *gc.C) {
+	s.setSuccess()
+	_, err := s.runWithStdin(c, "\n", "--no-download", "--encrypt")
+	c.Assert(err, gc.ErrorMatches, "you must enter an encryption key")
+}
+
+func (s *createSuite) TestEncryptedDefaultFilename(c *gc.C) {
+	s.metaresult.Encrypted = true
+	client := s.setDownload()
+	ctx, err := s.runWithStdin(c, "sekrit\nsekrit\n", "--quiet", "--encrypt")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, s.metaresult.ID, "", "Create", "Download")
+	out := cmdtesting.Stdout(ctx)
+	c.Check(out, jc.Contains, "downloading to juju-backup-")
+	c.Check(out, jc.HasSuffix, ".tar.gz.enc\n")
+	s.filename = strings.TrimSpace(strings.SplitN(out, "downloading to ", 2)[1])
+	s.checkArchive(c)
+}
+
+func (s *createSuite) TestUploadTo(c *gc.C) {
+	s.PatchEnvironment("AWS_ACCESS_KEY_ID", "AKID")
+	s.PatchEnvironment("AWS_SECRET_ACCESS_KEY", "shh")
+	client := s.setSuccess()
+	ctx, err := cmdtesting.RunCommand(c, s.wrappedCommand, "--no-download", "--upload-to", "s3://bucket/backups")
+	c.Assert(err, jc.ErrorIsNil)
+
+	client.Check(c, "", "", "Create")
+	c.Check(client.createArgs, jc.DeepEquals, params.BackupsCreateArgs{
+		UploadTo: "s3://bucket/backups",
+		UploadCredentials: map[string]string{
+			"access-key": "AKID",
+			"secret-key": "shh",
+		},
+	})
+	c.Check(cmdtesting.Stderr(ctx), jc.Contains, "uploaded to s3://bucket/backups\n")
+}
@@ -123,6 +123,8 @@ type fakeAPIClient struct {
 	args  []string
 	idArg string
 	notes string
+
+	createArgs params.BackupsCreateArgs
 }
 
 func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
@@ -131,10 +133,11 @@ func (f *fakeAPIClient) Check(c *gc.C, id, notes string, calls ...string) {
 	c.Check(f.notes, gc.Equals, notes)
 }
 
-func (c *fakeAPIClient) Create(notes string) (*params.BackupsMetadataResult, error) {
+func (c *fakeAPIClient) Create(args params.BackupsCreateArgs) (*params.BackupsMetadataResult, error) {
 	c.calls = append(c.calls, "Create")
 	c.args = append(c.args, "notes")
-	c.notes = notes
+	c.notes = args.Notes
+	c.createArgs = args
 	if c.err != nil {
 		return nil, c.err
 	}
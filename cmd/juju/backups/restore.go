@@ -7,6 +7,8 @@ import (
 	"crypto/rand"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"path/filepath"
 	"strings"
 	"time"
@@ -30,6 +32,7 @@ import (
 	"github.com/juju/juju/juju"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/network"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/version"
 )
 
@@ -56,6 +59,8 @@ type restoreCommand struct {
 	backupId       string
 	bootstrap      bool
 	buildAgent     bool
+	decrypt        bool
+	keyFile        string
 
 	newAPIClientFunc         func() (RestoreAPI, error)
 	newEnvironFunc           func(environs.OpenParams) (environs.Environ, error)
@@ -72,6 +77,9 @@ type RestoreAPI interface {
 	// Restore is taken from backups.Client.
 	Restore(backupId string, newClient backups.ClientConnection) error
 
+	// RestoreEncrypted is taken from backups.Client.
+	RestoreEncrypted(backupId, encryptionKey string, newClient backups.ClientConnection) error
+
 	// RestoreReader is taken from backups.Client.
 	RestoreReader(r io.ReadSeeker, meta *params.BackupsMetadataResult, newClient backups.ClientConnection) error
 }
@@ -91,6 +99,11 @@ If the provided state cannot be restored, this command will fail with
 an appropriate message.  For instance, if the existing bootstrap
 instance is already running then the command will fail with a message
 to that effect.
+
+Encrypted backups are restored with the key they were created with.
+The --decrypt option prompts for the key, and the --key-file option
+reads it from a file.  An encrypted backup archive given with --file
+is decrypted locally before it is uploaded.
 `
 
 var BootstrapFunc = bootstrap.Bootstrap
@@ -113,6 +126,8 @@ func (c *restoreCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.StringVar(&c.filename, "file", "", "Provide a file to be used as the backup.")
 	f.StringVar(&c.backupId, "id", "", "Provide the name of the backup to be restored")
 	f.BoolVar(&c.buildAgent, "build-agent", false, "Build binary agent if bootstraping a new machine")
+	f.BoolVar(&c.decrypt, "decrypt", false, "Decrypt the backup with a key read from the terminal")
+	f.StringVar(&c.keyFile, "key-file", "", "Decrypt the backup with the key in this file")
 }
 
 // Init is where the preconditions for this commands can be checked.
@@ -126,6 +141,9 @@ func (c *restoreCommand) Init(args []string) error {
 	if c.backupId != "" && c.bootstrap {
 		return errors.Errorf("it is not possible to rebootstrap and restore from an id.")
 	}
+	if c.decrypt && c.keyFile != "" {
+		return errors.Errorf("you must specify either --decrypt or --key-file but not both.")
+	}
 
 	var err error
 	if c.filename != "" {
@@ -397,6 +415,17 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	var encryptionKey string
+	switch {
+	case c.decrypt:
+		encryptionKey, err = readDecryptionKey(ctx)
+	case c.keyFile != "":
+		encryptionKey, err = readKeyFile(ctx.AbsPath(c.keyFile))
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+
 	var archive ArchiveReader
 	var meta *params.BackupsMetadataResult
 	target := c.backupId
@@ -405,8 +434,17 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 		// we'll need the info later regardless if
 		// we need it now to rebootstrap.
 		target = c.filename
+		filename := c.filename
+		if encryptionKey != "" {
+			decrypted, err := decryptArchiveFile(c.filename, encryptionKey)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			defer os.Remove(decrypted)
+			filename = decrypted
+		}
 		var err error
-		archive, meta, err = c.getArchiveFunc(c.filename)
+		archive, meta, err = c.getArchiveFunc(filename)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -427,9 +465,12 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 
 	// We have a backup client, now use the relevant method
 	// to restore the backup.
-	if c.filename != "" {
+	switch {
+	case c.filename != "":
 		err = client.RestoreReader(archive, meta, c.newClient)
-	} else {
+	case encryptionKey != "":
+		err = client.RestoreEncrypted(c.backupId, encryptionKey, c.newClient)
+	default:
 		err = client.Restore(c.backupId, c.newClient)
 	}
 	if err != nil {
@@ -439,6 +480,57 @@ func (c *restoreCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func readDecryptionKey(ctx *cmd.Context) (string, error) {
+	fmt.Fprint(ctx.Stderr, "encryption key: ")
+	key, err := readKey(ctx.Stdin)
+	fmt.Fprint(ctx.Stderr, "\n")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	if key == "" {
+		return "", errors.Errorf("you must enter an encryption key")
+	}
+	return key, nil
+}
+
+func readKeyFile(path string) (string, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", errors.Annotate(err, "cannot read key file")
+	}
+	key := strings.TrimRight(string(data), "\r\n")
+	if key == "" {
+		return "", errors.Errorf("key file %q is empty", path)
+	}
+	return key, nil
+}
+
+// decryptArchiveFile writes a decrypted copy of the encrypted backup
+// archive at filename to a temporary file, and returns the name of
+// that file. The caller is responsible for removing it.
+func decryptArchiveFile(filename, key string) (_ string, err error) {
+	archive, err := os.Open(filename)
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer archive.Close()
+
+	decrypted, err := ioutil.TempFile("", "juju-restore-")
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	defer decrypted.Close()
+	defer func() {
+		if err != nil {
+			os.Remove(decrypted.Name())
+		}
+	}()
+	if err := statebackups.DecryptArchive(decrypted, archive, key); err != nil {
+		return "", errors.Annotatef(err, "cannot decrypt backup archive %q", filename)
+	}
+	return decrypted.Name(), nil
+}
+
 func newInt(x int) *int {
 	return &x
 }
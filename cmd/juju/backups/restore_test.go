@@ -4,7 +4,11 @@
 package backups_test
 
 import (
+	"bytes"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"sort"
 
 	"github.com/juju/cmd/cmdtesting"
@@ -24,6 +28,7 @@ import (
 	"github.com/juju/juju/network"
 	_ "github.com/juju/juju/provider/dummy"
 	_ "github.com/juju/juju/provider/lxd"
+	statebackups "github.com/juju/juju/state/backups"
 	"github.com/juju/juju/testing"
 	"github.com/juju/juju/version"
 )
@@ -103,18 +108,97 @@ func (s *restoreSuite) TestRestoreArgs(c *gc.C) {
 
 	_, err = cmdtesting.RunCommand(c, s.command, "restore", "--id", "anid", "-b")
 	c.Assert(err, gc.ErrorMatches, "it is not possible to rebootstrap and restore from an id.")
+
+	_, err = cmdtesting.RunCommand(c, s.command, "restore", "--id", "anid", "--decrypt", "--key-file", "key")
+	c.Assert(err, gc.ErrorMatches, "you must specify either --decrypt or --key-file but not both.")
+}
+
+func (s *restoreSuite) writeKeyFile(c *gc.C, key string) string {
+	path := filepath.Join(c.MkDir(), "key")
+	err := ioutil.WriteFile(path, []byte(key+"\n"), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+	return path
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFile(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := statebackups.EncryptArchive(&encrypted, bytes.NewBufferString("<compressed tarball>"), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz.enc")
+	err = ioutil.WriteFile(filename, encrypted.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var archiveName, archiveData string
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(
+		s.store, api,
+		func(name string) (backups.ArchiveReader, *params.BackupsMetadataResult, error) {
+			data, err := ioutil.ReadFile(name)
+			c.Assert(err, jc.ErrorIsNil)
+			archiveName, archiveData = name, string(data)
+			return &mockArchiveReader{}, &params.BackupsMetadataResult{}, nil
+		},
+		nil, nil,
+	)
+	_, err = cmdtesting.RunCommand(c, s.command, "restore", "--file", filename, "--key-file", s.writeKeyFile(c, "sekrit"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(archiveData, gc.Equals, "<compressed tarball>")
+	c.Check(api.calls, jc.DeepEquals, []string{"RestoreReader"})
+	// The decrypted copy is removed once the restore is done.
+	_, err = os.Stat(archiveName)
+	c.Check(os.IsNotExist(err), jc.IsTrue)
+}
+
+func (s *restoreSuite) TestRestoreEncryptedFileWrongKey(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := statebackups.EncryptArchive(&encrypted, bytes.NewBufferString("<compressed tarball>"), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	filename := filepath.Join(c.MkDir(), "backup.tar.gz.enc")
+	err = ioutil.WriteFile(filename, encrypted.Bytes(), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+
+	api := &mockRestoreAPI{}
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil, nil)
+	_, err = cmdtesting.RunCommand(c, s.command, "restore", "--file", filename, "--key-file", s.writeKeyFile(c, "wrong"))
+	c.Assert(err, gc.ErrorMatches, `cannot decrypt backup archive ".*": backup archive failed authentication \(wrong key\?\)`)
+	c.Check(api.calls, gc.HasLen, 0)
+}
+
+func (s *restoreSuite) TestRestoreEncryptedId(c *gc.C) {
+	api := &mockRestoreAPI{}
+	ctx := cmdtesting.Context(c)
+	ctx.Stdin = bytes.NewBufferString("sekrit\n")
+	s.command = backups.NewRestoreCommandForTest(s.store, api, nil, nil, nil)
+	err := cmdtesting.InitCommand(s.command, []string{"--id", "anid", "--decrypt"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.command.Run(ctx)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(api.calls, jc.DeepEquals, []string{"RestoreEncrypted"})
+	c.Check(api.backupId, gc.Equals, "anid")
+	c.Check(api.encryptionKey, gc.Equals, "sekrit")
 }
 
 // TODO(wallyworld) - add more api related unit tests
 type mockRestoreAPI struct {
 	backups.RestoreAPI
+	calls         []string
+	backupId      string
+	encryptionKey string
 }
 
 func (*mockRestoreAPI) Close() error {
 	return nil
 }
 
-func (*mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+func (m *mockRestoreAPI) RestoreReader(io.ReadSeeker, *params.BackupsMetadataResult, apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "RestoreReader")
+	return nil
+}
+
+func (m *mockRestoreAPI) RestoreEncrypted(backupId, encryptionKey string, _ apibackups.ClientConnection) error {
+	m.calls = append(m.calls, "RestoreEncrypted")
+	m.backupId, m.encryptionKey = backupId, encryptionKey
 	return nil
 }
 
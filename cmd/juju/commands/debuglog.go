@@ -4,9 +4,11 @@
 package commands
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"strings"
 	"time"
 
@@ -21,6 +23,7 @@ import (
 
 	"github.com/juju/juju/api/common"
 	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/logfwd"
 )
 
 // defaultLineCount is the default number of lines to
@@ -94,6 +97,25 @@ new WARNING and ERROR messages as they are logged:
 
     juju debug-log --replay --level WARNING
 
+The '--grep' option filters on the log message, using a regular
+expression. The filtering is done by the controller, so it is much faster
+than piping the output through grep.
+
+The '--since' and '--until' options select a time window. Each takes
+either a time in RFC3339 format, such as 2017-06-01T12:00:00Z, or a
+duration, such as 90m, meaning that long ago. Using '--since' implies
+'--replay', and using '--until' implies '--no-tail'.
+
+Show all failed hooks in the last two hours, and then exit:
+
+    juju debug-log --since 2h --no-tail --grep 'hook "[^"]+" failed'
+
+With '--format json', each log message is written as a JSON object on a
+line of its own, including all the details recorded for the message. This
+is intended for use by other tools:
+
+    juju debug-log --replay --no-tail --format json
+
 See also: 
     status
     ssh`
@@ -129,6 +151,10 @@ type debugLogCommand struct {
 	notail bool
 	color  bool
 
+	since        string
+	until        string
+	outputFormat string
+
 	format string
 	tz     *time.Location
 }
@@ -149,6 +175,9 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.UintVar(&c.params.Backlog, "lines", defaultLineCount, "")
 	f.UintVar(&c.params.Limit, "limit", 0, "Exit once this many of the most recent (possibly filtered) lines are shown")
 	f.BoolVar(&c.params.Replay, "replay", false, "Show the entire (possibly filtered) log and continue to append")
+	f.StringVar(&c.params.MessagePattern, "grep", "", "Only show log messages matching this regular expression")
+	f.StringVar(&c.since, "since", "", "Only show log messages recorded after this time or duration ago")
+	f.StringVar(&c.until, "until", "", "Only show log messages recorded before this time or duration ago")
 
 	f.BoolVar(&c.notail, "no-tail", false, "Stop after returning existing log messages")
 	f.BoolVar(&c.tail, "tail", false, "Wait for new logs")
@@ -158,6 +187,7 @@ func (c *debugLogCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.BoolVar(&c.location, "location", false, "Show filename and line numbers")
 	f.BoolVar(&c.date, "date", false, "Show dates as well as times")
 	f.BoolVar(&c.ms, "ms", false, "Show times to millisecond precision")
+	f.StringVar(&c.outputFormat, "format", "text", "Specify output format (text|json)")
 }
 
 func (c *debugLogCommand) Init(args []string) error {
@@ -172,6 +202,38 @@ func (c *debugLogCommand) Init(args []string) error {
 	if c.tail && c.notail {
 		return errors.NotValidf("setting --tail and --no-tail")
 	}
+	if c.params.MessagePattern != "" {
+		if _, err := regexp.Compile(c.params.MessagePattern); err != nil {
+			return errors.Annotate(err, "invalid --grep pattern")
+		}
+	}
+	now := time.Now()
+	if c.since != "" {
+		since, err := parseLogTime(c.since, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --since value")
+		}
+		c.params.StartTime = since
+		c.params.Replay = true
+	}
+	if c.until != "" {
+		until, err := parseLogTime(c.until, now)
+		if err != nil {
+			return errors.Annotate(err, "invalid --until value")
+		}
+		if c.tail {
+			return errors.NotValidf("setting --tail and --until")
+		}
+		c.params.EndTime = until
+	}
+	if !c.params.StartTime.IsZero() && !c.params.EndTime.IsZero() && c.params.EndTime.Before(c.params.StartTime) {
+		return errors.NotValidf("--until before --since")
+	}
+	switch c.outputFormat {
+	case "text", "json":
+	default:
+		return errors.NotValidf("format %q", c.outputFormat)
+	}
 	if c.utc {
 		c.tz = time.UTC
 	}
@@ -188,6 +250,19 @@ func (c *debugLogCommand) Init(args []string) error {
 	return cmd.CheckEmpty(args)
 }
 
+// parseLogTime parses a --since or --until value, which may either be
+// an RFC3339 time or a duration before now.
+func parseLogTime(value string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		return time.Time{}, errors.Errorf("%q is neither an RFC3339 time nor a positive duration", value)
+	}
+	return now.Add(-d), nil
+}
+
 func (c *debugLogCommand) processEntities(entities []string) []string {
 	if entities == nil {
 		return nil
@@ -241,7 +316,7 @@ func isTerminal(out io.Writer) bool {
 func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if c.tail {
 		c.params.NoTail = false
-	} else if c.notail {
+	} else if c.notail || !c.params.EndTime.IsZero() {
 		c.params.NoTail = true
 	} else {
 		// Set the default tail option to true if the caller is
@@ -258,6 +333,15 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if c.outputFormat == "json" {
+		encoder := json.NewEncoder(ctx.Stdout)
+		for msg := range messages {
+			if err := encoder.Encode(jsonLogRecord(msg)); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		return nil
+	}
 	writer := ansiterm.NewWriter(ctx.Stdout)
 	if c.color {
 		writer.SetColorCapable(true)
@@ -273,6 +357,57 @@ func (c *debugLogCommand) Run(ctx *cmd.Context) (err error) {
 	return nil
 }
 
+// logRecordJSON is the --format json representation of a log message.
+// It follows the shape of logfwd.Record, so that tools can consume
+// debug-log output and forwarded logs in the same way.
+type logRecordJSON struct {
+	ID        int64           `json:"id,omitempty"`
+	Origin    logOriginJSON   `json:"origin"`
+	Timestamp time.Time       `json:"timestamp"`
+	Level     string          `json:"level"`
+	Location  logLocationJSON `json:"location"`
+	Message   string          `json:"message"`
+}
+
+type logOriginJSON struct {
+	ModelUUID string `json:"model-uuid,omitempty"`
+	Name      string `json:"name"`
+	Version   string `json:"version,omitempty"`
+}
+
+type logLocationJSON struct {
+	Module   string `json:"module,omitempty"`
+	Filename string `json:"filename,omitempty"`
+	Line     int    `json:"line,omitempty"`
+}
+
+func jsonLogRecord(msg common.LogMessage) logRecordJSON {
+	loc, err := logfwd.ParseLocation(msg.Module, msg.Location)
+	if err != nil {
+		// Pass through locations we don't understand as they are.
+		loc = logfwd.SourceLocation{Module: msg.Module, Filename: msg.Location}
+	}
+	if loc.Line < 0 {
+		loc.Line = 0
+	}
+	return logRecordJSON{
+		ID: msg.ID,
+		Origin: logOriginJSON{
+			ModelUUID: msg.ModelUUID,
+			Name:      msg.Entity,
+			Version:   msg.Version,
+		},
+		Timestamp: msg.Timestamp.UTC(),
+		Level:     msg.Severity,
+		Location: logLocationJSON{
+			Module:   loc.Module,
+			Filename: loc.Filename,
+			Line:     loc.Line,
+		},
+		Message: msg.Message,
+	}
+}
+
 var SeverityColor = map[string]*ansiterm.Context{
 	"TRACE":   ansiterm.Foreground(ansiterm.Default),
 	"DEBUG":   ansiterm.Foreground(ansiterm.Green),
@@ -103,6 +103,35 @@ func (s *DebugLogSuite) TestArgParsing(c *gc.C) {
 				Backlog: 10,
 				Limit:   100,
 			},
+		}, {
+			args: []string{"--grep", `hook "[^"]+" failed`},
+			expected: common.DebugLogParams{
+				Backlog:        10,
+				MessagePattern: `hook "[^"]+" failed`,
+			},
+		}, {
+			args:     []string{"--grep", "hook ("},
+			errMatch: `invalid --grep pattern: error parsing regexp: .*`,
+		}, {
+			args: []string{"--since", "2016-11-30T11:48:00Z", "--until", "2016-11-30T12:48:00Z"},
+			expected: common.DebugLogParams{
+				Backlog:   10,
+				Replay:    true,
+				StartTime: time.Date(2016, 11, 30, 11, 48, 0, 0, time.UTC),
+				EndTime:   time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC),
+			},
+		}, {
+			args:     []string{"--since", "yesterday"},
+			errMatch: `invalid --since value: "yesterday" is neither an RFC3339 time nor a positive duration`,
+		}, {
+			args:     []string{"--since", "2016-11-30T12:48:00Z", "--until", "2016-11-30T11:48:00Z"},
+			errMatch: `--until before --since not valid`,
+		}, {
+			args:     []string{"--tail", "--until", "1h"},
+			errMatch: `setting --tail and --until not valid`,
+		}, {
+			args:     []string{"--format", "yaml"},
+			errMatch: `format "yaml" not valid`,
 		},
 	} {
 		c.Logf("test %v", i)
@@ -140,6 +169,63 @@ func (s *DebugLogSuite) TestParamsPassed(c *gc.C) {
 	})
 }
 
+func (s *DebugLogSuite) TestSinceDuration(c *gc.C) {
+	command := &debugLogCommand{}
+	before := time.Now()
+	err := cmdtesting.InitCommand(modelcmd.Wrap(command), []string{"--since", "90m"})
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Now()
+	c.Assert(command.params.Replay, jc.IsTrue)
+	start := command.params.StartTime
+	c.Assert(start.Before(before.Add(-90*time.Minute)), jc.IsFalse)
+	c.Assert(start.After(after.Add(-90*time.Minute)), jc.IsFalse)
+}
+
+func (s *DebugLogSuite) TestUntilImpliesNoTail(c *gc.C) {
+	fake := &fakeDebugLogAPI{}
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return fake, nil
+	})
+	_, err := cmdtesting.RunCommand(c, newDebugLogCommand(), "--until", "2016-11-30T12:48:00Z")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.params.NoTail, jc.IsTrue)
+	c.Assert(fake.params.EndTime, gc.Equals, time.Date(2016, 11, 30, 12, 48, 0, 0, time.UTC))
+}
+
+func (s *DebugLogSuite) TestJSONOutput(c *gc.C) {
+	s.PatchValue(&getDebugLogAPI, func(_ *debugLogCommand) (DebugLogAPI, error) {
+		return &fakeDebugLogAPI{log: []common.LogMessage{
+			{
+				ID:        1475993723345000000,
+				ModelUUID: "deadbeef-0bad-400d-8000-4b1d0d06f00d",
+				Version:   "2.3.0",
+				Entity:    "unit-mysql-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 23, 345000000, time.UTC),
+				Severity:  "ERROR",
+				Module:    "juju.worker.uniter",
+				Location:  "uniter.go:123",
+				Message:   `hook "install" failed`,
+			}, {
+				Entity:    "machine-0",
+				Timestamp: time.Date(2016, 10, 9, 8, 15, 24, 0, time.UTC),
+				Severity:  "INFO",
+				Module:    "juju.worker",
+				Location:  "runner.go",
+				Message:   "started",
+			},
+		}}, nil
+	})
+	ctx, err := cmdtesting.RunCommand(c, newDebugLogCommand(), "--format", "json")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, ""+
+		`{"id":1475993723345000000,"origin":{"model-uuid":"deadbeef-0bad-400d-8000-4b1d0d06f00d","name":"unit-mysql-0","version":"2.3.0"},`+
+		`"timestamp":"2016-10-09T08:15:23.345Z","level":"ERROR","location":{"module":"juju.worker.uniter","filename":"uniter.go","line":123},`+
+		`"message":"hook \"install\" failed"}`+"\n"+
+		`{"origin":{"name":"machine-0"},"timestamp":"2016-10-09T08:15:24Z","level":"INFO",`+
+		`"location":{"module":"juju.worker","filename":"runner.go"},"message":"started"}`+"\n",
+	)
+}
+
 func (s *DebugLogSuite) TestLogOutput(c *gc.C) {
 	// test timezone is 6 hours east of UTC
 	tz := time.FixedZone("test", 6*60*60)
@@ -31,6 +31,7 @@ Show help on a Juju charm tool.
 var expectedCommands = []string{
 	"action-fail",
 	"action-get",
+	"action-log",
 	"action-set",
 	"add-metric",
 	"application-version-set",
@@ -0,0 +1,218 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"fmt"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+const setJumpHostsDoc = `
+Sets the chain of SSH jump hosts (bastions) through which machines in
+a model are reached by "juju ssh", "juju scp" and "juju debug-hooks".
+The hosts are traversed in the order given, each being of the form
+[user@]host[:port]. Connecting through more than one jump host requires
+OpenSSH 7.3 or later.
+
+By default the chain is set for the current model, or the model
+specified with -m. With --all-models, the chain is set for all models
+on the controller that do not have their own chain configured.
+
+Jump hosts are stored in the local client configuration only, and
+are not shared with other users of the controller. The machines'
+private addresses are used when connecting through jump hosts.
+
+Examples:
+    juju set-jump-hosts bastion.example.com
+    juju set-jump-hosts -m prod:web ubuntu@bastion.example.com admin@10.0.0.1:2222
+    juju set-jump-hosts -m prod:controller --all-models bastion.example.com
+    juju set-jump-hosts --reset
+
+See also:
+    show-jump-hosts
+    ssh
+    scp
+    debug-hooks
+`
+
+func newSetJumpHostsCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&setJumpHostsCommand{})
+}
+
+// setJumpHostsCommand sets the chain of SSH jump hosts
+// for a model or controller.
+type setJumpHostsCommand struct {
+	modelcmd.ModelCommandBase
+	allModels bool
+	reset     bool
+	hosts     []string
+}
+
+// Info implements cmd.Command.
+func (c *setJumpHostsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-jump-hosts",
+		Args:    "[<[user@]host[:port]> ...]",
+		Purpose: "Sets the SSH jump hosts used to reach machines in a model.",
+		Doc:     setJumpHostsDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setJumpHostsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.allModels, "all-models", false, "Set the jump hosts for all models on the controller")
+	f.BoolVar(&c.reset, "reset", false, "Remove the jump hosts")
+}
+
+// Init implements cmd.Command.
+func (c *setJumpHostsCommand) Init(args []string) error {
+	if c.reset {
+		if len(args) > 0 {
+			return errors.New("cannot specify jump hosts with --reset")
+		}
+		return nil
+	}
+	if len(args) == 0 {
+		return errors.New("no jump hosts specified")
+	}
+	if err := jujuclient.ValidateJumpHosts(args); err != nil {
+		return errors.Trace(err)
+	}
+	c.hosts = args
+	return nil
+}
+
+// Run implements cmd.Command.
+func (c *setJumpHostsCommand) Run(ctx *cmd.Context) error {
+	controllerName, modelName, err := jumpHostsScope(&c.ModelCommandBase, c.allModels)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(c.ClientStore().UpdateJumpHosts(controllerName, modelName, c.hosts))
+}
+
+const showJumpHostsDoc = `
+Shows the chain of SSH jump hosts (bastions) through which machines in
+a model are reached by "juju ssh", "juju scp" and "juju debug-hooks",
+one host per line in the order in which they are traversed.
+
+If the model has no jump hosts of its own, the jump hosts set for all
+models on the controller are shown. With --all-models, only the jump
+hosts set for all models on the controller are shown.
+
+Examples:
+    juju show-jump-hosts
+    juju show-jump-hosts -m prod:web
+    juju show-jump-hosts --all-models
+
+See also:
+    set-jump-hosts
+`
+
+func newShowJumpHostsCommand() modelcmd.ModelCommand {
+	return modelcmd.Wrap(&showJumpHostsCommand{})
+}
+
+// showJumpHostsCommand shows the chain of SSH jump hosts
+// for a model or controller.
+type showJumpHostsCommand struct {
+	modelcmd.ModelCommandBase
+	allModels bool
+}
+
+// Info implements cmd.Command.
+func (c *showJumpHostsCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "show-jump-hosts",
+		Purpose: "Shows the SSH jump hosts used to reach machines in a model.",
+		Doc:     showJumpHostsDoc,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *showJumpHostsCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.allModels, "all-models", false, "Show the jump hosts for all models on the controller")
+}
+
+// Init implements cmd.Command.
+func (c *showJumpHostsCommand) Init(args []string) error {
+	return cmd.CheckEmpty(args)
+}
+
+// Run implements cmd.Command.
+func (c *showJumpHostsCommand) Run(ctx *cmd.Context) error {
+	var hosts []string
+	if c.allModels {
+		controllerName, _, err := jumpHostsScope(&c.ModelCommandBase, true)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		hosts, err = c.ClientStore().JumpHosts(controllerName, "")
+		if err != nil && !errors.IsNotFound(err) {
+			return errors.Trace(err)
+		}
+	} else {
+		var err error
+		hosts, err = modelJumpHosts(&c.ModelCommandBase)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if len(hosts) == 0 {
+		ctx.Infof("No jump hosts configured.")
+		return nil
+	}
+	for _, host := range hosts {
+		fmt.Fprintln(ctx.Stdout, host)
+	}
+	return nil
+}
+
+// jumpHostsScope returns the names of the controller and model
+// for which jump hosts are stored. The model name is empty if
+// allModels is true.
+func jumpHostsScope(c *modelcmd.ModelCommandBase, allModels bool) (string, string, error) {
+	controllerName, err := c.ControllerName()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	if allModels {
+		return controllerName, "", nil
+	}
+	modelName, err := c.ModelName()
+	if err != nil {
+		return "", "", errors.Trace(err)
+	}
+	return controllerName, modelName, nil
+}
+
+// modelJumpHosts returns the chain of SSH jump hosts configured for
+// the command's model or, if there is none, for all models on the
+// model's controller. If there are no jump hosts configured, an empty
+// chain is returned.
+func modelJumpHosts(c *modelcmd.ModelCommandBase) ([]string, error) {
+	controllerName, modelName, err := jumpHostsScope(c, false)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	store := c.ClientStore()
+	for _, name := range []string{modelName, ""} {
+		hosts, err := store.JumpHosts(controllerName, name)
+		if errors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return hosts, nil
+	}
+	return nil, nil
+}
@@ -0,0 +1,109 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package commands
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type JumpHostsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&JumpHostsSuite{})
+
+func (s *JumpHostsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "ctrl"
+	s.store.Controllers["ctrl"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["ctrl"] = jujuclient.AccountDetails{User: "admin"}
+	s.store.Models["ctrl"] = &jujuclient.ControllerModels{
+		Models: map[string]jujuclient.ModelDetails{
+			"admin/default": {ModelUUID: "default-uuid"},
+			"admin/other":   {ModelUUID: "other-uuid"},
+		},
+		CurrentModel: "admin/default",
+	}
+}
+
+func (s *JumpHostsSuite) run(c *gc.C, command modelcmd.ModelCommand, args ...string) (*cmd.Context, error) {
+	command.SetClientStore(s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *JumpHostsSuite) TestSetJumpHostsInit(c *gc.C) {
+	_, err := s.run(c, newSetJumpHostsCommand())
+	c.Assert(err, gc.ErrorMatches, "no jump hosts specified")
+	_, err = s.run(c, newSetJumpHostsCommand(), "--reset", "bastion")
+	c.Assert(err, gc.ErrorMatches, "cannot specify jump hosts with --reset")
+	_, err = s.run(c, newSetJumpHostsCommand(), "bastion", "-oProxyCommand=foo")
+	c.Assert(err, gc.ErrorMatches, `jump host "-oProxyCommand=foo" not valid`)
+}
+
+func (s *JumpHostsSuite) TestSetJumpHostsModel(c *gc.C) {
+	_, err := s.run(c, newSetJumpHostsCommand(), "bastion", "ubuntu@jump:2222")
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.run(c, newSetJumpHostsCommand(), "-m", "other", "other-bastion")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.ControllerJumpHosts, jc.DeepEquals, map[string]jujuclient.ControllerJumpHosts{
+		"ctrl": {
+			Models: map[string][]string{
+				"admin/default": {"bastion", "ubuntu@jump:2222"},
+				"admin/other":   {"other-bastion"},
+			},
+		},
+	})
+
+	_, err = s.run(c, newSetJumpHostsCommand(), "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.ControllerJumpHosts, jc.DeepEquals, map[string]jujuclient.ControllerJumpHosts{
+		"ctrl": {
+			Models: map[string][]string{
+				"admin/other": {"other-bastion"},
+			},
+		},
+	})
+}
+
+func (s *JumpHostsSuite) TestSetJumpHostsAllModels(c *gc.C) {
+	_, err := s.run(c, newSetJumpHostsCommand(), "--all-models", "bastion")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.store.ControllerJumpHosts, jc.DeepEquals, map[string]jujuclient.ControllerJumpHosts{
+		"ctrl": {JumpHosts: []string{"bastion"}},
+	})
+}
+
+func (s *JumpHostsSuite) TestShowJumpHosts(c *gc.C) {
+	ctx, err := s.run(c, newShowJumpHostsCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No jump hosts configured.\n")
+
+	err = s.store.UpdateJumpHosts("ctrl", "", []string{"bastion"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateJumpHosts("ctrl", "admin/other", []string{"jump1", "jump2"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The default model has no jump hosts of its own.
+	ctx, err = s.run(c, newShowJumpHostsCommand())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "bastion\n")
+
+	ctx, err = s.run(c, newShowJumpHostsCommand(), "-m", "other")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "jump1\njump2\n")
+
+	ctx, err = s.run(c, newShowJumpHostsCommand(), "-m", "other", "--all-models")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "bastion\n")
+}
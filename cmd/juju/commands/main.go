@@ -32,6 +32,7 @@ import (
 	"github.com/juju/juju/cmd/juju/cloud"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/cmd/juju/crossmodel"
+	"github.com/juju/juju/cmd/juju/firewall"
 	"github.com/juju/juju/cmd/juju/gui"
 	"github.com/juju/juju/cmd/juju/machine"
 	"github.com/juju/juju/cmd/juju/metricsdebug"
@@ -295,6 +296,8 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(newResolvedCommand())
 	r.Register(newDebugLogCommand())
 	r.Register(newDebugHooksCommand(nil))
+	r.Register(newSetJumpHostsCommand())
+	r.Register(newShowJumpHostsCommand())
 
 	// Configuration commands.
 	r.Register(model.NewModelGetConstraintsCommand())
@@ -352,6 +355,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(model.NewGrantCommand())
 	r.Register(model.NewRevokeCommand())
 	r.Register(model.NewShowCommand())
+	r.Register(model.NewExportBundleCommand())
 
 	r.Register(newMigrateCommand())
 	if featureflag.Enabled(feature.DeveloperMode) {
@@ -373,6 +377,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(application.NewAddUnitCommand())
 	r.Register(application.NewConfigCommand())
 	r.Register(application.NewDeployCommand())
+	r.Register(application.NewDiffBundleCommand())
 	r.Register(application.NewExposeCommand())
 	r.Register(application.NewUnexposeCommand())
 	r.Register(application.NewServiceGetConstraintsCommand())
@@ -392,6 +397,9 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(storage.NewRemoveStorageCommandWithAPI())
 	r.Register(storage.NewDetachStorageCommandWithAPI())
 	r.Register(storage.NewAttachStorageCommandWithAPI())
+	r.Register(storage.NewSnapshotStorageCommandWithAPI())
+	r.Register(storage.NewRestoreStorageCommandWithAPI())
+	r.Register(storage.NewResizeStorageCommandWithAPI())
 
 	// Manage spaces
 	r.Register(space.NewAddCommand())
@@ -411,6 +419,12 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 		r.Register(subnet.NewRemoveCommand())
 	}
 
+	// Manage firewall rules
+	r.Register(firewall.NewSetFirewallRuleCommand())
+	r.Register(firewall.NewListFirewallRulesCommand())
+	r.Register(firewall.NewSetEgressRulesCommand())
+	r.Register(firewall.NewEgressRulesCommand())
+
 	// Manage controllers
 	r.Register(controller.NewAddModelCommand())
 	r.Register(controller.NewDestroyCommand())
@@ -422,6 +436,7 @@ func registerCommands(r commandRegistry, ctx *cmd.Context) {
 	r.Register(controller.NewEnableDestroyControllerCommand())
 	r.Register(controller.NewShowControllerCommand())
 	r.Register(controller.NewGetConfigCommand())
+	r.Register(controller.NewAuditLogCommand())
 
 	// Debug Metrics
 	r.Register(metricsdebug.New())
@@ -403,6 +403,7 @@ var commandNames = []string{
 	"agreements",
 	"attach",
 	"attach-storage",
+	"audit-log",
 	"autoload-credentials",
 	"backups",
 	"bootstrap",
@@ -426,15 +427,19 @@ var commandNames = []string{
 	"destroy-controller",
 	"destroy-model",
 	"detach-storage",
+	"diff-bundle",
 	"disable-command",
 	"disable-user",
 	"disabled-commands",
 	"download-backup",
+	"egress-rules",
 	"enable-command",
 	"enable-destroy-controller",
 	"enable-ha",
 	"enable-user",
+	"export-bundle",
 	"expose",
+	"firewall-rules",
 	"get-constraints",
 	"get-model-constraints",
 	"grant",
@@ -451,6 +456,8 @@ var commandNames = []string{
 	"list-controllers",
 	"list-credentials",
 	"list-disabled-commands",
+	"list-egress-rules",
+	"list-firewall-rules",
 	"list-machines",
 	"list-models",
 	"list-payloads",
@@ -489,9 +496,11 @@ var commandNames = []string{
 	"remove-storage",
 	"remove-unit",
 	"remove-user",
+	"resize-storage",
 	"resolved",
 	"resources",
 	"restore-backup",
+	"restore-storage",
 	"retry-provisioning",
 	"revoke",
 	"run",
@@ -500,6 +509,9 @@ var commandNames = []string{
 	"set-constraints",
 	"set-default-credential",
 	"set-default-region",
+	"set-egress-rules",
+	"set-firewall-rule",
+	"set-jump-hosts",
 	"set-meter-status",
 	"set-model-constraints",
 	"set-plan",
@@ -509,6 +521,7 @@ var commandNames = []string{
 	"show-backup",
 	"show-cloud",
 	"show-controller",
+	"show-jump-hosts",
 	"show-machine",
 	"show-model",
 	"show-status",
@@ -517,6 +530,7 @@ var commandNames = []string{
 	"show-user",
 	"show-wallet",
 	"sla",
+	"snapshot-storage",
 	"spaces",
 	"ssh",
 	"ssh-keys",
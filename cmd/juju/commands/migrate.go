@@ -4,8 +4,11 @@
 package commands
 
 import (
+	"fmt"
+
 	"github.com/juju/cmd"
 	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
 	"gopkg.in/macaroon.v1"
 
@@ -27,10 +30,12 @@ type migrateCommand struct {
 	newAPIRoot       func(jujuclient.ClientStore, string, string) (api.Connection, error)
 	api              migrateAPI
 	targetController string
+	dryRun           bool
 }
 
 type migrateAPI interface {
 	InitiateMigration(spec controller.MigrationSpec) (string, error)
+	PrecheckMigration(spec controller.MigrationSpec) ([]string, error)
 }
 
 const migrateDoc = `
@@ -56,6 +61,13 @@ This command only starts a model migration - it does not wait for its
 completion. The progress of a migration can be tracked using the
 "status" command and by consulting the logs.
 
+The --dry-run option runs all of the checks that would be made before
+starting the migration, on both the source and target controllers,
+without starting the migration. This includes checking that the model
+can be exported from the source controller and imported into the target
+controller. Every problem that would prevent the migration is reported,
+rather than just the first.
+
 See also:
     login
     controllers
@@ -72,6 +84,12 @@ func (c *migrateCommand) Info() *cmd.Info {
 	}
 }
 
+// SetFlags implements cmd.Command.
+func (c *migrateCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.BoolVar(&c.dryRun, "dry-run", false, "Check whether the model can be migrated, without migrating it")
+}
+
 // Init implements cmd.Command.
 func (c *migrateCommand) Init(args []string) error {
 	if len(args) < 1 {
@@ -140,6 +158,9 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	if err != nil {
 		return err
 	}
+	if c.dryRun {
+		return c.precheckMigration(ctx, api, *spec)
+	}
 	id, err := api.InitiateMigration(*spec)
 	if err != nil {
 		return err
@@ -148,6 +169,24 @@ func (c *migrateCommand) Run(ctx *cmd.Context) error {
 	return nil
 }
 
+func (c *migrateCommand) precheckMigration(ctx *cmd.Context, api migrateAPI, spec controller.MigrationSpec) error {
+	failures, err := api.PrecheckMigration(spec)
+	if errors.IsNotSupported(err) {
+		return errors.New("--dry-run is not supported by this controller")
+	} else if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		ctx.Infof("All migration prechecks passed")
+		return nil
+	}
+	fmt.Fprintf(ctx.Stderr, "Migration prechecks failed:\n")
+	for _, failure := range failures {
+		fmt.Fprintf(ctx.Stderr, "  - %s\n", failure)
+	}
+	return cmd.ErrSilent
+}
+
 func (c *migrateCommand) getAPI() (migrateAPI, error) {
 	if c.api != nil {
 		return c.api, nil
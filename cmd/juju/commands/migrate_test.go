@@ -10,6 +10,7 @@ import (
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/macaroon-bakery.v1/httpbakery"
@@ -210,6 +211,45 @@ func (s *MigrateSuite) TestControllerDoesntExist(c *gc.C) {
 	c.Check(s.api.specSeen, gc.IsNil) // API shouldn't have been called
 }
 
+func (s *MigrateSuite) TestDryRun(c *gc.C) {
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "All migration prechecks passed\n")
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+	c.Check(s.api.specSeen, jc.DeepEquals, &controller.MigrationSpec{
+		ModelUUID:            modelUUID,
+		TargetControllerUUID: targetControllerUUID,
+		TargetAddrs:          []string{"1.2.3.4:5"},
+		TargetCACert:         "cert",
+		TargetUser:           "targetuser",
+		TargetPassword:       "secret",
+	})
+}
+
+func (s *MigrateSuite) TestDryRunFailures(c *gc.C) {
+	s.api.precheckFailures = []string{
+		"source: machine 0 not running",
+		"target: model with same UUID already exists",
+	}
+	ctx, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, `
+Migration prechecks failed:
+  - source: machine 0 not running
+  - target: model with same UUID already exists
+`[1:])
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+}
+
+func (s *MigrateSuite) TestDryRunNotSupported(c *gc.C) {
+	s.api.precheckErr = errors.NotSupportedf("migration precheck report")
+	_, err := s.makeAndRun(c, "model", "target", "--dry-run")
+	c.Assert(err, gc.ErrorMatches, "--dry-run is not supported by this controller")
+	c.Check(s.api.migrationStarted, jc.IsFalse)
+}
+
 func (s *MigrateSuite) makeAndRun(c *gc.C, args ...string) (*cmd.Context, error) {
 	return cmdtesting.RunCommand(c, s.makeCommand(), args...)
 }
@@ -227,14 +267,23 @@ func (s *MigrateSuite) makeCommand() modelcmd.ModelCommand {
 }
 
 type fakeMigrateAPI struct {
-	specSeen *controller.MigrationSpec
+	specSeen         *controller.MigrationSpec
+	migrationStarted bool
+	precheckFailures []string
+	precheckErr      error
 }
 
 func (a *fakeMigrateAPI) InitiateMigration(spec controller.MigrationSpec) (string, error) {
 	a.specSeen = &spec
+	a.migrationStarted = true
 	return "uuid:0", nil
 }
 
+func (a *fakeMigrateAPI) PrecheckMigration(spec controller.MigrationSpec) ([]string, error) {
+	a.specSeen = &spec
+	return a.precheckFailures, a.precheckErr
+}
+
 type fakeModelAPI struct {
 	models []base.UserModel
 }
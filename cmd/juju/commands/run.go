@@ -14,6 +14,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/gnuflag"
 	"github.com/juju/utils"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/names.v2"
 
 	actionapi "github.com/juju/juju/api/action"
@@ -44,6 +45,11 @@ type runCommand struct {
 	units     []string
 	commands  string
 	timeAfter func(time.Duration) <-chan time.Time
+
+	maxParallel   int
+	batchSize     int
+	stopOnFailure bool
+	outputPath    *gnuflag.Flag
 }
 
 const runDoc = `
@@ -76,11 +82,29 @@ targets.
 Since juju run creates actions, you can query for the status of commands
 started with juju run by calling "juju show-action-status --name juju-run".
 
+By default the commands are started on every target at once. Use
+--max-parallel to limit the number of targets running the commands at any
+one time, starting the next target as each one finishes, or --batch-size to
+run the commands on a number of targets at a time, waiting for the whole
+batch to finish before starting the next. With either of these,
+--stop-on-failure stops starting new targets once the commands have failed
+on any target; targets that are already running are allowed to finish.
+When --timeout is exceeded, targets that have not been started are skipped.
+
+With --max-parallel or --batch-size, results are written as each target
+finishes. JSON output, and output written to a file, is still written once
+all targets have finished.
+
 If you need to pass flags to the command being run, you must precede the
 command and its arguments with "--", to tell "juju run" to stop processing
 those arguments. For example:
 
     juju run --all -- hostname -f
+
+To restart a service on ten units of an application at a time, stopping if
+it fails to restart anywhere:
+
+    juju run --application mysql --batch-size 10 --stop-on-failure -- sudo service mysql restart
 `
 
 func (c *runCommand) Info() *cmd.Info {
@@ -105,6 +129,10 @@ func (c *runCommand) SetFlags(f *gnuflag.FlagSet) {
 	f.Var(cmd.NewStringsValue(nil, &c.machines), "machine", "One or more machine ids")
 	f.Var(cmd.NewStringsValue(nil, &c.services), "application", "One or more application names")
 	f.Var(cmd.NewStringsValue(nil, &c.units), "unit", "One or more unit ids")
+	f.IntVar(&c.maxParallel, "max-parallel", 0, "Maximum number of targets to run the commands on at once")
+	f.IntVar(&c.batchSize, "batch-size", 0, "Number of targets to run the commands on in each batch")
+	f.BoolVar(&c.stopOnFailure, "stop-on-failure", false, "Stop starting new targets once the commands fail on any target")
+	c.outputPath = f.Lookup("output")
 }
 
 func (c *runCommand) Init(args []string) error {
@@ -138,6 +166,19 @@ func (c *runCommand) Init(args []string) error {
 		}
 	}
 
+	if c.maxParallel < 0 {
+		return errors.Errorf("--max-parallel must be a positive number, got %d", c.maxParallel)
+	}
+	if c.batchSize < 0 {
+		return errors.Errorf("--batch-size must be a positive number, got %d", c.batchSize)
+	}
+	if c.maxParallel > 0 && c.batchSize > 0 {
+		return errors.Errorf("You cannot specify both --max-parallel and --batch-size")
+	}
+	if c.stopOnFailure && c.maxParallel == 0 && c.batchSize == 0 {
+		return errors.Errorf("--stop-on-failure requires --max-parallel or --batch-size")
+	}
+
 	var nameErrors []string
 	for _, machineId := range c.machines {
 		if !names.IsValidMachine(machineId) {
@@ -217,65 +258,74 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 	}
 	defer client.Close()
 
-	var runResults []params.ActionResult
-	if c.all {
-		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
-	} else {
-		params := params.RunParams{
-			Commands:     c.commands,
-			Timeout:      c.timeout,
-			Machines:     c.machines,
-			Applications: c.services,
-			Units:        c.units,
-		}
-		runResults, err = client.Run(params)
+	// When the number of targets running at once is limited, the
+	// targets are expanded up front so they can be started a few at
+	// a time. Otherwise everything is started in one go.
+	limit := c.maxParallel
+	if c.batchSize > 0 {
+		limit = c.batchSize
 	}
-
-	if err != nil {
-		return block.ProcessBlockedError(err, block.BlockChange)
-	}
-
-	actionsToQuery := []actionQuery{}
-	for _, result := range runResults {
-		if result.Error != nil {
-			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
-			continue
-		}
-		actionTag, err := names.ParseActionTag(result.Action.Tag)
+	var pending []names.Tag
+	if limit > 0 {
+		pending, err = c.expandTargets()
 		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
-			continue
+			return errors.Trace(err)
 		}
+	}
 
-		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
-		if err != nil {
-			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
-			continue
+	stream := c.streamResults()
+	var (
+		actionsToQuery []actionQuery
+		values         []interface{}
+		timeout        <-chan time.Time
+		started        bool
+		enqueued       int
+		stopped        bool
+		failed         names.Tag
+		timedOut       bool
+	)
+	for !timedOut {
+		if !stopped {
+			var start bool
+			var targets []names.Tag
+			switch {
+			case limit == 0:
+				start = !started
+			case c.batchSize > 0:
+				// Wait for the whole batch to finish.
+				if len(actionsToQuery) == 0 && len(pending) > 0 {
+					start = true
+					targets, pending = splitTargets(pending, limit)
+				}
+			case len(actionsToQuery) < limit && len(pending) > 0:
+				start = true
+				targets, pending = splitTargets(pending, limit-len(actionsToQuery))
+			}
+			if start {
+				started = true
+				queries, queueFailed, err := c.enqueue(ctx, client, targets)
+				if err != nil {
+					return err
+				}
+				if queueFailed && c.stopOnFailure {
+					stopped = true
+				}
+				if len(queries) > 0 {
+					// The timeout runs from when the most
+					// recent actions were enqueued.
+					enqueued += len(queries)
+					actionsToQuery = append(actionsToQuery, queries...)
+					timeout = c.timeAfter(c.timeout)
+				}
+			}
 		}
-		var receiverType string
-		switch receiverTag.(type) {
-		case names.UnitTag:
-			receiverType = "UnitId"
-		case names.MachineTag:
-			receiverType = "MachineId"
-		default:
-			receiverType = "ReceiverId"
+		if len(actionsToQuery) == 0 {
+			if stopped || len(pending) == 0 {
+				break
+			}
+			continue
 		}
-		actionsToQuery = append(actionsToQuery, actionQuery{
-			actionTag: actionTag,
-			receiver: actionReceiver{
-				receiverType: receiverType,
-				tag:          receiverTag,
-			}})
-	}
 
-	if len(actionsToQuery) == 0 {
-		return errors.New("no actions were successfully enqueued, aborting")
-	}
-
-	timeout := c.timeAfter(c.timeout)
-	values := []interface{}{}
-	for len(actionsToQuery) > 0 {
 		actionResults, err := client.Actions(entities(actionsToQuery))
 		if err != nil {
 			return errors.Trace(err)
@@ -291,12 +341,31 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				}
 			}
 
-			values = append(values, ConvertActionResults(result, actionsToQuery[i]))
+			value := ConvertActionResults(result, actionsToQuery[i])
+			if c.stopOnFailure && !stopped && runFailed(result, value) {
+				stopped = true
+				failed = actionsToQuery[i].receiver.tag
+			}
+			// A lone result in the default format is written
+			// as if the commands had been run locally, so it
+			// is held back until the end.
+			single := c.out.Name() == "default" && enqueued == 1 && len(pending) == 0
+			if stream && !single {
+				if err := cmd.FormatYaml(ctx.Stdout, []interface{}{value}); err != nil {
+					return errors.Trace(err)
+				}
+				continue
+			}
+			values = append(values, value)
 		}
+		finished := len(actionsToQuery) - len(newActionsToQuery)
 		actionsToQuery = newActionsToQuery
 
+		if finished > 0 && c.maxParallel > 0 && len(pending) > 0 && !stopped {
+			// Start the next targets straight away.
+			continue
+		}
 		if len(actionsToQuery) > 0 {
-			var timedOut bool
 			select {
 			case <-timeout:
 				timedOut = true
@@ -306,12 +375,13 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 				// this should be easier once we implement
 				// action grouping
 			}
-			if timedOut {
-				break
-			}
 		}
 	}
 
+	if enqueued == 0 {
+		return errors.New("no actions were successfully enqueued, aborting")
+	}
+
 	// If we are just dealing with one result, AND we are using the default
 	// format, then pretend we were running it locally.
 	if len(actionsToQuery) == 0 && len(values) == 1 && c.out.Name() == "default" {
@@ -341,24 +411,236 @@ func (c *runCommand) Run(ctx *cmd.Context) error {
 		}
 	}
 
+	var notRun string
+	if len(pending) > 0 {
+		notRun = fmt.Sprintf("; commands were not run on: %s", readableTags(pending))
+	}
 	if n := len(actionsToQuery); n > 0 {
 		// There are action results remaining, so return an error.
 		suffix := ""
 		if n > 1 {
 			suffix = "s"
 		}
-		receivers := make([]string, n)
+		receivers := make([]names.Tag, n)
 		for i, actionToQuery := range actionsToQuery {
-			receivers[i] = names.ReadableString(actionToQuery.receiver.tag)
+			receivers[i] = actionToQuery.receiver.tag
 		}
 		return errors.Errorf(
-			"timed out waiting for result%s from: %s",
-			suffix, strings.Join(receivers, ", "),
+			"timed out waiting for result%s from: %s%s",
+			suffix, readableTags(receivers), notRun,
 		)
 	}
+	if notRun != "" {
+		var cause string
+		if failed != nil {
+			cause = " on " + names.ReadableString(failed)
+		}
+		return errors.Errorf("stopped after failure%s%s", cause, notRun)
+	}
 	return nil
 }
 
+// enqueue starts the commands on the given targets, or on all targets
+// specified on the command line if targets is nil. It returns queries
+// for the actions that were enqueued, and whether any of the targets
+// could not be enqueued.
+func (c *runCommand) enqueue(ctx *cmd.Context, client RunClient, targets []names.Tag) ([]actionQuery, bool, error) {
+	var runResults []params.ActionResult
+	var err error
+	if targets == nil && c.all {
+		runResults, err = client.RunOnAllMachines(c.commands, c.timeout)
+	} else {
+		params := params.RunParams{
+			Commands:     c.commands,
+			Timeout:      c.timeout,
+			Machines:     c.machines,
+			Applications: c.services,
+			Units:        c.units,
+		}
+		if targets != nil {
+			params.Machines, params.Applications, params.Units = nil, nil, nil
+			for _, tag := range targets {
+				switch tag := tag.(type) {
+				case names.MachineTag:
+					params.Machines = append(params.Machines, tag.Id())
+				case names.UnitTag:
+					params.Units = append(params.Units, tag.Id())
+				}
+			}
+		}
+		runResults, err = client.Run(params)
+	}
+
+	if err != nil {
+		return nil, false, block.ProcessBlockedError(err, block.BlockChange)
+	}
+
+	var queueFailed bool
+	actionsToQuery := []actionQuery{}
+	for _, result := range runResults {
+		if result.Error != nil {
+			fmt.Fprintf(ctx.GetStderr(), "couldn't queue one action: %v", result.Error)
+			queueFailed = true
+			continue
+		}
+		actionTag, err := names.ParseActionTag(result.Action.Tag)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action tag %v for receiver %v", result.Action.Tag, result.Action.Receiver)
+			queueFailed = true
+			continue
+		}
+
+		receiverTag, err := names.ActionReceiverFromTag(result.Action.Receiver)
+		if err != nil {
+			fmt.Fprintf(ctx.GetStderr(), "got invalid action receiver tag %v for action %v", result.Action.Receiver, result.Action.Tag)
+			queueFailed = true
+			continue
+		}
+		var receiverType string
+		switch receiverTag.(type) {
+		case names.UnitTag:
+			receiverType = "UnitId"
+		case names.MachineTag:
+			receiverType = "MachineId"
+		default:
+			receiverType = "ReceiverId"
+		}
+		actionsToQuery = append(actionsToQuery, actionQuery{
+			actionTag: actionTag,
+			receiver: actionReceiver{
+				receiverType: receiverType,
+				tag:          receiverTag,
+			}})
+	}
+	return actionsToQuery, queueFailed, nil
+}
+
+// expandTargets returns the individual machines and units on which the
+// commands are to be run. Applications, and --all, are expanded using
+// the model status.
+func (c *runCommand) expandTargets() ([]names.Tag, error) {
+	var targets []names.Tag
+	seen := set.NewStrings()
+	add := func(tag names.Tag) {
+		if !seen.Contains(tag.String()) {
+			seen.Add(tag.String())
+			targets = append(targets, tag)
+		}
+	}
+	for _, id := range c.machines {
+		add(names.NewMachineTag(id))
+	}
+	for _, id := range c.units {
+		add(names.NewUnitTag(id))
+	}
+	if !c.all && len(c.services) == 0 {
+		return targets, nil
+	}
+
+	client, err := getRunStatusAPIClient(c)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer client.Close()
+	status, err := client.Status(nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if c.all {
+		for _, id := range utils.SortStringsNaturally(machineIds(status.Machines)) {
+			add(names.NewMachineTag(id))
+		}
+	}
+	for _, application := range c.services {
+		if _, ok := status.Applications[application]; !ok {
+			return nil, errors.NotFoundf("application %q", application)
+		}
+		var units []string
+		for _, app := range status.Applications {
+			for _, name := range unitNames(app.Units) {
+				if unitApplication, _ := names.UnitApplication(name); unitApplication == application {
+					units = append(units, name)
+				}
+			}
+		}
+		for _, name := range utils.SortStringsNaturally(units) {
+			add(names.NewUnitTag(name))
+		}
+	}
+	return targets, nil
+}
+
+// machineIds returns the ids of the machines, and of any containers
+// they host.
+func machineIds(machines map[string]params.MachineStatus) []string {
+	var ids []string
+	for id, machine := range machines {
+		ids = append(ids, id)
+		ids = append(ids, machineIds(machine.Containers)...)
+	}
+	return ids
+}
+
+// unitNames returns the names of the units, and of any subordinate
+// units.
+func unitNames(units map[string]params.UnitStatus) []string {
+	var result []string
+	for name, unit := range units {
+		result = append(result, name)
+		result = append(result, unitNames(unit.Subordinates)...)
+	}
+	return result
+}
+
+// splitTargets returns the first n targets, and those that remain.
+func splitTargets(targets []names.Tag, n int) ([]names.Tag, []names.Tag) {
+	if n > len(targets) {
+		n = len(targets)
+	}
+	return targets[:n], targets[n:]
+}
+
+func readableTags(tags []names.Tag) string {
+	readable := make([]string, len(tags))
+	for i, tag := range tags {
+		readable[i] = names.ReadableString(tag)
+	}
+	return strings.Join(readable, ", ")
+}
+
+// runFailed reports whether the commands failed on the action's receiver.
+func runFailed(result params.ActionResult, values map[string]interface{}) bool {
+	if _, ok := values["Error"]; ok {
+		return true
+	}
+	if _, ok := values["ReturnCode"]; ok {
+		return true
+	}
+	return result.Status == params.ActionFailed
+}
+
+// streamResults reports whether results should be written as each
+// target finishes. Results are only streamed when the number of
+// targets running at once is limited, so the output is otherwise
+// unchanged. A sequence of YAML documents, each holding a single item
+// list, reads the same as one list holding every item; the same is not
+// true of JSON, so JSON output is written at the end. Output files are
+// rewritten on every write, so are also written at the end.
+func (c *runCommand) streamResults() bool {
+	if c.maxParallel == 0 && c.batchSize == 0 {
+		return false
+	}
+	if c.outputPath != nil && c.outputPath.Value.String() != "" {
+		return false
+	}
+	switch c.out.Name() {
+	case "default", "yaml":
+		return true
+	}
+	return false
+}
+
 type actionReceiver struct {
 	receiverType string
 	tag          names.Tag
@@ -376,6 +658,13 @@ type RunClient interface {
 	Run(params.RunParams) ([]params.ActionResult, error)
 }
 
+// RunStatusClient exposes the model status, which is used to expand
+// applications and --all into individual targets.
+type RunStatusClient interface {
+	Status(patterns []string) (*params.FullStatus, error)
+	Close() error
+}
+
 // In order to be able to easily mock out the API side for testing,
 // the API client is retrieved using a function.
 var getRunAPIClient = func(c *runCommand) (RunClient, error) {
@@ -386,6 +675,14 @@ var getRunAPIClient = func(c *runCommand) (RunClient, error) {
 	return actionapi.NewClient(root), errors.Trace(err)
 }
 
+var getRunStatusAPIClient = func(c *runCommand) (RunStatusClient, error) {
+	client, err := c.NewAPIClient()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return client, nil
+}
+
 // getActionResult abstracts over the action CLI function that we use here to fetch results
 var getActionResult = func(c RunClient, actionId string, wait *time.Timer) (params.ActionResult, error) {
 	return action.GetActionResult(c, actionId, wait)
@@ -166,6 +166,55 @@ func (*RunSuite) TestTimeoutArgParsing(c *gc.C) {
 	}
 }
 
+func (*RunSuite) TestParallelismArgParsing(c *gc.C) {
+	for i, test := range []struct {
+		message       string
+		args          []string
+		errMatch      string
+		maxParallel   int
+		batchSize     int
+		stopOnFailure bool
+	}{{
+		message: "defaults",
+		args:    []string{"--all", "sudo reboot"},
+	}, {
+		message:     "max parallel",
+		args:        []string{"--max-parallel=10", "--all", "sudo reboot"},
+		maxParallel: 10,
+	}, {
+		message:       "batch size stopping on failure",
+		args:          []string{"--batch-size=5", "--stop-on-failure", "--all", "sudo reboot"},
+		batchSize:     5,
+		stopOnFailure: true,
+	}, {
+		message:  "negative max parallel",
+		args:     []string{"--max-parallel=-1", "--all", "sudo reboot"},
+		errMatch: "--max-parallel must be a positive number, got -1",
+	}, {
+		message:  "negative batch size",
+		args:     []string{"--batch-size=-1", "--all", "sudo reboot"},
+		errMatch: "--batch-size must be a positive number, got -1",
+	}, {
+		message:  "max parallel and batch size",
+		args:     []string{"--max-parallel=2", "--batch-size=2", "--all", "sudo reboot"},
+		errMatch: "You cannot specify both --max-parallel and --batch-size",
+	}, {
+		message:  "stop on failure without limit",
+		args:     []string{"--stop-on-failure", "--all", "sudo reboot"},
+		errMatch: "--stop-on-failure requires --max-parallel or --batch-size",
+	}} {
+		c.Log(fmt.Sprintf("%v: %s", i, test.message))
+		cmd := &runCommand{}
+		runCmd := modelcmd.Wrap(cmd)
+		cmdtesting.TestInit(c, runCmd, test.args, test.errMatch)
+		if test.errMatch == "" {
+			c.Check(cmd.maxParallel, gc.Equals, test.maxParallel)
+			c.Check(cmd.batchSize, gc.Equals, test.batchSize)
+			c.Check(cmd.stopOnFailure, gc.Equals, test.stopOnFailure)
+		}
+	}
+}
+
 func (s *RunSuite) TestConvertRunResults(c *gc.C) {
 	for i, test := range []struct {
 		message  string
@@ -491,6 +540,138 @@ func (s *RunSuite) TestSingleResponse(c *gc.C) {
 	}
 }
 
+func (s *RunSuite) setupUnitResponses(mock *mockRunAPI, responses map[string]mockResponse) {
+	mock.actionResponses = make(map[string]params.ActionResult)
+	for unit, response := range responses {
+		response.unitTag = names.NewUnitTag(unit).String()
+		mock.setResponse(unit, response)
+		mock.actionResponses[mock.receiverIdMap[unit]] = mock.runResponses[unit]
+	}
+}
+
+func (s *RunSuite) TestMultipleTargetsDefaultFormat(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupUnitResponses(mock, map[string]mockResponse{
+		"unit/0": {stdout: "zero"},
+		"unit/1": {stderr: "failed", code: "1"},
+	})
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(gitjujutesting.NewClock(time.Time{})),
+		"--unit=unit/0,unit/1", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Without --max-parallel or --batch-size, all the results are
+	// written together once every target has finished.
+	c.Assert(mock.runCalls, gc.HasLen, 1)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"- Stdout: zero\n"+
+		"  UnitId: unit/0\n"+
+		"- ReturnCode: 1\n"+
+		"  Stderr: failed\n"+
+		"  Stdout: \"\"\n"+
+		"  UnitId: unit/1\n",
+	)
+	c.Check(cmdtesting.Stderr(context), gc.Equals, "")
+}
+
+func (s *RunSuite) TestMaxParallel(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupUnitResponses(mock, map[string]mockResponse{
+		"unit/0": {stdout: "zero"},
+		"unit/1": {stdout: "one"},
+		"unit/2": {stdout: "two"},
+	})
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(gitjujutesting.NewClock(time.Time{})),
+		"--max-parallel=2", "--unit=unit/0,unit/1,unit/2", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"unit/0", "unit/1"})
+	c.Check(mock.runCalls[1].Units, jc.DeepEquals, []string{"unit/2"})
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"- Stdout: zero\n"+
+		"  UnitId: unit/0\n"+
+		"- Stdout: one\n"+
+		"  UnitId: unit/1\n"+
+		"- Stdout: two\n"+
+		"  UnitId: unit/2\n",
+	)
+}
+
+func (s *RunSuite) TestBatchSizeExpandsApplications(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupUnitResponses(mock, map[string]mockResponse{
+		"unit/0":   {stdout: "zero"},
+		"unit/1":   {stdout: "one"},
+		"unit/10":  {stdout: "ten"},
+		"logger/0": {stdout: "logger"},
+	})
+	s.PatchValue(&getRunStatusAPIClient, func(_ *runCommand) (RunStatusClient, error) {
+		return &mockRunStatusAPI{status: &params.FullStatus{
+			Applications: map[string]params.ApplicationStatus{
+				"unit": {Units: map[string]params.UnitStatus{
+					"unit/10": {},
+					"unit/0": {Subordinates: map[string]params.UnitStatus{
+						"logger/0": {},
+					}},
+					"unit/1": {},
+				}},
+				"logger": {},
+			},
+		}}, nil
+	})
+
+	_, err := cmdtesting.RunCommand(c, newTestRunCommand(gitjujutesting.NewClock(time.Time{})),
+		"--batch-size=2", "--application=unit,logger", "hostname",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(mock.runCalls, gc.HasLen, 2)
+	c.Check(mock.runCalls[0].Units, jc.DeepEquals, []string{"unit/0", "unit/1"})
+	c.Check(mock.runCalls[0].Applications, gc.HasLen, 0)
+	c.Check(mock.runCalls[1].Units, jc.DeepEquals, []string{"unit/10", "logger/0"})
+}
+
+func (s *RunSuite) TestStopOnFailure(c *gc.C) {
+	mock := s.setupMockAPI()
+	s.setupUnitResponses(mock, map[string]mockResponse{
+		"unit/0": {stdout: "zero"},
+		"unit/1": {stderr: "failed", code: "1"},
+		"unit/2": {stdout: "two"},
+		"unit/3": {stdout: "three"},
+	})
+
+	context, err := cmdtesting.RunCommand(c, newTestRunCommand(gitjujutesting.NewClock(time.Time{})),
+		"--batch-size=2", "--stop-on-failure", "--unit=unit/0,unit/1,unit/2,unit/3", "hostname",
+	)
+	c.Assert(err, gc.ErrorMatches, "stopped after failure on unit unit/1; commands were not run on: unit unit/2, unit unit/3")
+
+	c.Assert(mock.runCalls, gc.HasLen, 1)
+	c.Check(cmdtesting.Stdout(context), gc.Equals, ""+
+		"- Stdout: zero\n"+
+		"  UnitId: unit/0\n"+
+		"- ReturnCode: 1\n"+
+		"  Stderr: failed\n"+
+		"  Stdout: \"\"\n"+
+		"  UnitId: unit/1\n",
+	)
+}
+
+type mockRunStatusAPI struct {
+	status *params.FullStatus
+}
+
+func (m *mockRunStatusAPI) Status(patterns []string) (*params.FullStatus, error) {
+	return m.status, nil
+}
+
+func (*mockRunStatusAPI) Close() error {
+	return nil
+}
+
 func (s *RunSuite) setupMockAPI() *mockRunAPI {
 	mock := &mockRunAPI{}
 	s.PatchValue(&getRunAPIClient, func(_ *runCommand) (RunClient, error) {
@@ -510,6 +691,7 @@ type mockRunAPI struct {
 	actionResponses map[string]params.ActionResult
 	receiverIdMap   map[string]string
 	block           bool
+	runCalls        []params.RunParams
 }
 
 type mockResponse struct {
@@ -621,6 +803,7 @@ func (m *mockRunAPI) Run(runParams params.RunParams) ([]params.ActionResult, err
 	if m.block {
 		return result, common.OperationBlockedError("the operation has been blocked")
 	}
+	m.runCalls = append(m.runCalls, runParams)
 	// Just add in ids that match in order.
 	for _, id := range runParams.Machines {
 		response, found := m.runResponses[id]
@@ -41,6 +41,7 @@ type SSHCommon struct {
 	knownHostsPath  string
 	hostChecker     jujussh.ReachableChecker
 	forceAPIv1      bool
+	jumpHosts       []string
 }
 
 const jujuSSHClientForceAPIv1 = "JUJU_SSHCLIENT_API_V1"
@@ -133,7 +134,8 @@ func (c *SSHCommon) setHostChecker(checker jujussh.ReachableChecker) {
 // if SSH proxying is required. It must be called at the top of the
 // command's Run method.
 //
-// The apiClient, apiAddr and proxy fields are initialized after this call.
+// The apiClient, apiAddr, proxy and jumpHosts fields are initialized
+// after this call.
 func (c *SSHCommon) initRun() error {
 	if err := c.ensureAPIClient(); err != nil {
 		return errors.Trace(err)
@@ -145,6 +147,15 @@ func (c *SSHCommon) initRun() error {
 		c.proxy = proxy
 	}
 
+	jumpHosts, err := modelJumpHosts(&c.ModelCommandBase)
+	if err != nil {
+		return errors.Annotate(err, "getting jump hosts")
+	}
+	if len(jumpHosts) > 0 {
+		logger.Debugf("using jump hosts %v", jumpHosts)
+	}
+	c.jumpHosts = jumpHosts
+
 	// Used mostly for testing, but useful for debugging and/or
 	// backwards-compatibility with some scripts.
 	c.forceAPIv1 = os.Getenv(jujuSSHClientForceAPIv1) != ""
@@ -197,9 +208,14 @@ func (c *SSHCommon) getSSHOptions(enablePty bool, targets ...*resolvedTarget) (*
 	}
 
 	if c.proxy {
+		// When proxying through the API server, the connection
+		// to the API server is made by another "juju ssh", which
+		// will itself traverse any jump hosts.
 		if err := c.setProxyCommand(&options); err != nil {
 			return nil, err
 		}
+	} else if len(c.jumpHosts) > 0 {
+		c.setJumpHostsProxyCommand(&options)
 	}
 
 	return &options, nil
@@ -296,6 +312,28 @@ func (c *SSHCommon) setProxyCommand(options *ssh.Options) error {
 	return nil
 }
 
+// setJumpHostsProxyCommand sets the proxy command option such that
+// the connection to the target is made through the chain of jump
+// hosts. All but the last jump host are traversed using OpenSSH's
+// ProxyJump (-J) support, and the last forwards the connection.
+func (c *SSHCommon) setJumpHostsProxyCommand(options *ssh.Options) {
+	last := len(c.jumpHosts) - 1
+	args := []string{"ssh"}
+	if last > 0 {
+		args = append(args, "-J", strings.Join(c.jumpHosts[:last], ","))
+	}
+	user, hostPort := splitUserTarget(c.jumpHosts[last])
+	if host, port, err := net.SplitHostPort(hostPort); err == nil {
+		args = append(args, "-p", port)
+		hostPort = host
+	}
+	if user != "" {
+		hostPort = user + "@" + hostPort
+	}
+	args = append(args, "-W", "%h:%p", hostPort)
+	options.SetProxyCommand(args...)
+}
+
 func (c *SSHCommon) ensureAPIClient() error {
 	if c.apiClient != nil {
 		return nil
@@ -325,7 +363,7 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 	if c.apiClient.BestAPIVersion() < 2 || c.forceAPIv1 {
 		logger.Debugf("using legacy SSHClient API v1: no support for AllAddresses()")
 		getAddress = c.legacyAddressGetter
-	} else if c.proxy {
+	} else if c.proxy || len(c.jumpHosts) > 0 {
 		// Ideally a reachability scan would be done from the
 		// controller's perspective but that isn't possible yet, so
 		// fall back to the legacy mode (i.e. use the instance's
@@ -337,7 +375,10 @@ func (c *SSHCommon) resolveTarget(target string) (*resolvedTarget, error) {
 		// reachability scan juju ssh could inadvertently end up using
 		// the public address when it really should be using the
 		// internal/private address.
-		logger.Debugf("proxy-ssh enabled so not doing reachability scan")
+		//
+		// The same applies when connecting through jump hosts, which
+		// are expected to be on the same network as the instances.
+		logger.Debugf("proxy-ssh or jump hosts enabled so not doing reachability scan")
 		getAddress = c.legacyAddressGetter
 	}
 
@@ -387,10 +428,11 @@ func (c *SSHCommon) resolveWithRetry(target resolvedTarget, getAddress addressGe
 }
 
 // legacyAddressGetter returns the preferred public or private address of the
-// given entity (private when c.proxy is true), using the apiClient. Only used
-// when the SSHClient API facade v2 is not available or when proxy-ssh is set.
+// given entity (private when c.proxy is true or jump hosts are configured),
+// using the apiClient. Only used when the SSHClient API facade v2 is not
+// available or when proxy-ssh is set or jump hosts are configured.
 func (c *SSHCommon) legacyAddressGetter(entity string) (string, error) {
-	if c.proxy {
+	if c.proxy || len(c.jumpHosts) > 0 {
 		return c.apiClient.PrivateAddress(entity)
 	}
 
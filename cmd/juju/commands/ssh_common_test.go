@@ -33,6 +33,10 @@ type argsSpec struct {
 	// expected.
 	withProxy bool
 
+	// jumpHostsProxy specifies the ProxyCommand option expected
+	// when connecting through jump hosts, if any.
+	jumpHostsProxy string
+
 	// enablePty specifies if the forced PTY allocation switches are
 	// expected.
 	enablePty bool
@@ -78,6 +82,8 @@ func (s *argsSpec) check(c *gc.C, output string) {
 			"--proxy=false " +
 			"--no-host-key-checks " +
 			"--pty=false ubuntu@localhost -q \"nc %h %p\"")
+	} else if s.jumpHostsProxy != "" {
+		expect("-o ProxyCommand " + regexp.QuoteMeta(s.jumpHostsProxy))
 	}
 	expect("-o PasswordAuthentication no -o ServerAliveInterval 30")
 	if s.enablePty {
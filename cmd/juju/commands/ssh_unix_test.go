@@ -221,6 +221,50 @@ func (s *SSHSuite) TestSSHCommandModelConfigProxySSH(c *gc.C) {
 
 }
 
+func (s *SSHSuite) TestSSHCommandJumpHosts(c *gc.C) {
+	s.setupModel(c)
+	controllerName, err := s.ControllerStore.CurrentController()
+	c.Assert(err, jc.ErrorIsNil)
+	modelName, err := s.ControllerStore.CurrentModel(controllerName)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Jump hosts configured for all models on the controller.
+	err = s.ControllerStore.UpdateJumpHosts(controllerName, "", []string{"bastion"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The host checker shouldn't get used with jump hosts.
+	ctx, err := cmdtesting.RunCommand(c, newSSHCommand(nil), "0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs := argsSpec{
+		hostKeyChecking: "yes",
+		knownHosts:      "0",
+		enablePty:       true,
+		jumpHostsProxy:  "ssh -W %h:%p bastion",
+		args:            "ubuntu@0.private",
+	}
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+
+	// Jump hosts configured for the model take precedence.
+	err = s.ControllerStore.UpdateJumpHosts(controllerName, modelName, []string{
+		"jump1", "ubuntu@jump2:2200", "admin@10.0.0.1:2222",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	ctx, err = cmdtesting.RunCommand(c, newSSHCommand(nil), "0")
+	c.Check(err, jc.ErrorIsNil)
+	c.Check(cmdtesting.Stderr(ctx), gc.Equals, "")
+	expectedArgs.jumpHostsProxy = "ssh -J jump1,ubuntu@jump2:2200 -p 2222 -W %h:%p admin@10.0.0.1"
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+
+	// When proxying through the API server, the jump hosts
+	// are traversed by the proxy command.
+	ctx, err = cmdtesting.RunCommand(c, newSSHCommand(nil), "--proxy", "0")
+	c.Check(err, jc.ErrorIsNil)
+	expectedArgs.jumpHostsProxy = ""
+	expectedArgs.withProxy = true
+	expectedArgs.check(c, cmdtesting.Stdout(ctx))
+}
+
 func (s *SSHSuite) TestSSHWillWorkInUpgrade(c *gc.C) {
 	// Check the API client interface used by "juju ssh" against what
 	// the API server will allow during upgrades. Ensure that the API
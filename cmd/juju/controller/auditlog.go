@@ -0,0 +1,246 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/auditlog"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewAuditLogCommand returns a command to query the controller's
+// audit log.
+func NewAuditLogCommand() cmd.Command {
+	return modelcmd.WrapController(&auditLogCommand{})
+}
+
+// auditLogCommand displays the audit entries recorded by a
+// controller.
+type auditLogCommand struct {
+	modelcmd.ControllerCommandBase
+	out cmd.Output
+	api AuditLogAPI
+
+	user      string
+	model     string
+	operation string
+	from      string
+	to        string
+	limit     int
+
+	after  time.Time
+	before time.Time
+}
+
+const auditLogHelpDoc = `
+Shows the audit entries recorded by the controller for API requests
+made by users. Only controller superusers may view the audit log.
+
+Entries may be filtered by the user that made the request, the model
+the request was made on, the operation performed, and the time at which
+the request was made. Operations are matched case-insensitively against
+any part of the operation name, which has the form
+"<facade>:v<version> - <method>".
+
+Times given to --from and --to may be either RFC3339 timestamps
+(e.g. 2017-06-01T12:00:00Z) or dates (e.g. 2017-06-01), which are
+interpreted as midnight UTC.
+
+Only the most recent entries, up to the limit given by --limit, are
+shown. Use --limit 0 to show all matching entries.
+
+Examples:
+
+    juju audit-log
+    juju audit-log --user bob --model mymodel
+    juju audit-log --operation deploy --from 2017-06-01 --to 2017-06-02
+    juju audit-log --limit 0 --format json
+
+See also:
+    controller-config
+`
+
+// AuditLogAPI defines the API methods used by the audit-log command.
+type AuditLogAPI interface {
+	Close() error
+	Query(params.AuditLogFilter) ([]params.AuditLogEntry, error)
+}
+
+// Info implements Command.Info.
+func (c *auditLogCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "audit-log",
+		Purpose: "Displays the audit log of a controller.",
+		Doc:     strings.TrimSpace(auditLogHelpDoc),
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *auditLogCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ControllerCommandBase.SetFlags(f)
+	f.StringVar(&c.user, "user", "", "Only show entries for requests made by this user")
+	f.StringVar(&c.model, "model", "", "Only show entries for requests made on this model")
+	f.StringVar(&c.operation, "operation", "", "Only show entries whose operation contains this text")
+	f.StringVar(&c.from, "from", "", "Only show entries recorded after this time")
+	f.StringVar(&c.to, "to", "", "Only show entries recorded before this time")
+	f.IntVar(&c.limit, "limit", 100, "The maximum number of entries to show, 0 for no limit")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatAuditLogTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *auditLogCommand) Init(args []string) error {
+	if c.user != "" && !names.IsValidUser(c.user) {
+		return errors.NotValidf("user name %q", c.user)
+	}
+	if c.limit < 0 {
+		return errors.New("--limit must not be negative")
+	}
+	var err error
+	if c.from != "" {
+		if c.after, err = parseAuditLogTime(c.from); err != nil {
+			return errors.Annotate(err, "invalid --from")
+		}
+	}
+	if c.to != "" {
+		if c.before, err = parseAuditLogTime(c.to); err != nil {
+			return errors.Annotate(err, "invalid --to")
+		}
+	}
+	if !c.after.IsZero() && !c.before.IsZero() && !c.after.Before(c.before) {
+		return errors.New("--from must be earlier than --to")
+	}
+	return cmd.CheckEmpty(args)
+}
+
+func parseAuditLogTime(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, errors.Errorf("expected RFC3339 timestamp or YYYY-MM-DD date, got %q", value)
+	}
+	return t, nil
+}
+
+func (c *auditLogCommand) getAPI() (AuditLogAPI, error) {
+	if c.api != nil {
+		return c.api, nil
+	}
+	root, err := c.NewAPIRoot()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return auditlog.NewClient(root), nil
+}
+
+// Run implements Command.Run.
+func (c *auditLogCommand) Run(ctx *cmd.Context) error {
+	filter := params.AuditLogFilter{
+		Operation: c.operation,
+		Limit:     c.limit,
+	}
+	if c.user != "" {
+		filter.UserTag = names.NewUserTag(c.user).String()
+	}
+	if c.model != "" {
+		uuids, err := c.ModelUUIDs([]string{c.model})
+		if err != nil {
+			return errors.Trace(err)
+		}
+		filter.ModelTag = names.NewModelTag(uuids[0]).String()
+	}
+	if !c.after.IsZero() {
+		filter.After = &c.after
+	}
+	if !c.before.IsZero() {
+		filter.Before = &c.before
+	}
+
+	client, err := c.getAPI()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	entries, err := client.Query(filter)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(entries) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No audit entries to display.")
+		return nil
+	}
+	return c.out.Write(ctx, formatAuditEntries(entries))
+}
+
+// auditEntry is the serialisation format of a single audit entry.
+type auditEntry struct {
+	Time          time.Time              `yaml:"time" json:"time"`
+	User          string                 `yaml:"user" json:"user"`
+	Model         string                 `yaml:"model" json:"model"`
+	Operation     string                 `yaml:"operation" json:"operation"`
+	RemoteAddress string                 `yaml:"remote-address" json:"remote-address"`
+	ServerVersion string                 `yaml:"server-version" json:"server-version"`
+	Data          map[string]interface{} `yaml:"data,omitempty" json:"data,omitempty"`
+}
+
+func formatAuditEntries(entries []params.AuditLogEntry) []auditEntry {
+	out := make([]auditEntry, len(entries))
+	for i, entry := range entries {
+		user := entry.OriginName
+		if tag, err := names.ParseUserTag(entry.OriginName); err == nil {
+			user = tag.Id()
+		}
+		model := entry.ModelTag
+		if tag, err := names.ParseModelTag(entry.ModelTag); err == nil {
+			model = tag.Id()
+		}
+		out[i] = auditEntry{
+			Time:          entry.Timestamp.UTC(),
+			User:          user,
+			Model:         model,
+			Operation:     entry.Operation,
+			RemoteAddress: entry.RemoteAddress,
+			ServerVersion: entry.JujuServerVersion,
+			Data:          entry.Data,
+		}
+	}
+	return out
+}
+
+func formatAuditLogTabular(writer io.Writer, value interface{}) error {
+	entries, ok := value.([]auditEntry)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", entries, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Time", "User", "Model", "Operation", "Address")
+	for _, entry := range entries {
+		w.Println(
+			common.FormatTime(&entry.Time, true),
+			entry.User,
+			entry.Model,
+			entry.Operation,
+			entry.RemoteAddress,
+		)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,154 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package controller_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/controller"
+)
+
+type AuditLogSuite struct {
+	baseControllerSuite
+	api *fakeAuditLogAPI
+}
+
+var _ = gc.Suite(&AuditLogSuite{})
+
+func (s *AuditLogSuite) SetUpTest(c *gc.C) {
+	s.baseControllerSuite.SetUpTest(c)
+	s.createTestClientStore(c)
+	s.api = &fakeAuditLogAPI{
+		entries: []params.AuditLogEntry{{
+			JujuServerVersion: "2.3.0",
+			ModelTag:          "model-def",
+			Timestamp:         time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC),
+			RemoteAddress:     "10.0.0.1",
+			OriginType:        "user",
+			OriginName:        "user-bob",
+			Operation:         "Application:v4 - Deploy",
+		}},
+	}
+}
+
+func (s *AuditLogSuite) run(c *gc.C, args ...string) (*cmd.Context, error) {
+	command := controller.NewAuditLogCommandForTest(s.api, s.store)
+	return cmdtesting.RunCommand(c, command, args...)
+}
+
+func (s *AuditLogSuite) TestInitErrors(c *gc.C) {
+	for i, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{"extra"},
+		err:  `unrecognized args: \["extra"\]`,
+	}, {
+		args: []string{"--user", "not valid"},
+		err:  `user name "not valid" not valid`,
+	}, {
+		args: []string{"--limit", "-1"},
+		err:  "--limit must not be negative",
+	}, {
+		args: []string{"--from", "yesterday"},
+		err:  `invalid --from: expected RFC3339 timestamp or YYYY-MM-DD date, got "yesterday"`,
+	}, {
+		args: []string{"--from", "2017-06-02", "--to", "2017-06-01"},
+		err:  "--from must be earlier than --to",
+	}} {
+		c.Logf("test %d: %v", i, test.args)
+		err := cmdtesting.InitCommand(controller.NewAuditLogCommandForTest(s.api, s.store), test.args)
+		c.Check(err, gc.ErrorMatches, test.err)
+	}
+}
+
+func (s *AuditLogSuite) TestDefaultFilter(c *gc.C) {
+	_, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	s.api.CheckCallNames(c, "Query", "Close")
+	s.api.CheckCall(c, 0, "Query", params.AuditLogFilter{Limit: 100})
+}
+
+func (s *AuditLogSuite) TestFilter(c *gc.C) {
+	_, err := s.run(c,
+		"--user", "bob",
+		"--model", "my-model",
+		"--operation", "deploy",
+		"--from", "2017-06-01",
+		"--to", "2017-06-01T18:00:00Z",
+		"--limit", "0",
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	after := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2017, 6, 1, 18, 0, 0, 0, time.UTC)
+	s.api.CheckCall(c, 0, "Query", params.AuditLogFilter{
+		UserTag:   "user-bob",
+		ModelTag:  "model-def",
+		Operation: "deploy",
+		After:     &after,
+		Before:    &before,
+	})
+}
+
+func (s *AuditLogSuite) TestTabular(c *gc.C) {
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Time                  User  Model  Operation                Address
+2017-06-01 12:00:00Z  bob   def    Application:v4 - Deploy  10.0.0.1
+`[1:])
+}
+
+func (s *AuditLogSuite) TestYAML(c *gc.C) {
+	ctx, err := s.run(c, "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- time: 2017-06-01T12:00:00Z
+  user: bob
+  model: def
+  operation: Application:v4 - Deploy
+  remote-address: 10.0.0.1
+  server-version: 2.3.0
+`[1:])
+}
+
+func (s *AuditLogSuite) TestNoEntries(c *gc.C) {
+	s.api.entries = nil
+	ctx, err := s.run(c)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No audit entries to display.\n")
+}
+
+func (s *AuditLogSuite) TestQueryError(c *gc.C) {
+	s.api.SetErrors(errors.New("permission denied"))
+	_, err := s.run(c)
+	c.Assert(err, gc.ErrorMatches, "permission denied")
+}
+
+type fakeAuditLogAPI struct {
+	jtesting.Stub
+	entries []params.AuditLogEntry
+}
+
+func (f *fakeAuditLogAPI) Close() error {
+	f.MethodCall(f, "Close")
+	return nil
+}
+
+func (f *fakeAuditLogAPI) Query(filter params.AuditLogFilter) ([]params.AuditLogEntry, error) {
+	f.MethodCall(f, "Query", filter)
+	if err := f.NextErr(); err != nil {
+		return nil, err
+	}
+	return f.entries, nil
+}
@@ -159,6 +159,14 @@ func NewGetConfigCommandForTest(api controllerAPI, store jujuclient.ClientStore)
 	return modelcmd.WrapController(c)
 }
 
+// NewAuditLogCommandForTest returns an audit-log command with the API
+// provided as specified.
+func NewAuditLogCommandForTest(api AuditLogAPI, store jujuclient.ClientStore) cmd.Command {
+	c := &auditLogCommand{api: api}
+	c.SetClientStore(store)
+	return modelcmd.WrapController(c)
+}
+
 type CtrData ctrData
 type ModelData modelData
 
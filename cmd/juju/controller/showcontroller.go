@@ -14,6 +14,7 @@ import (
 	"github.com/juju/juju/api/base"
 	"github.com/juju/juju/api/controller"
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
 	"github.com/juju/juju/cmd/modelcmd"
 	"github.com/juju/juju/environs/bootstrap"
 	"github.com/juju/juju/jujuclient"
@@ -28,6 +29,10 @@ var usageShowControllerDetails = `
 Shows extended information about a controller(s) as well as related models
 and user login details.
 
+If the controller has been bootstrapped with a backup-schedule, the time and
+outcome of the most recent successful and failed scheduled backups are also
+shown.
+
 Examples:
     juju show-controller
     juju show-controller aws google
@@ -86,6 +91,7 @@ type ControllerAccessAPI interface {
 	ModelConfig() (map[string]interface{}, error)
 	ModelStatus(models ...names.ModelTag) ([]base.ModelStatus, error)
 	AllModels() ([]base.UserModel, error)
+	ScheduledBackupStatus() (params.ScheduledBackupStatus, error)
 	Close() error
 }
 
@@ -150,6 +156,7 @@ func (c *showControllerCommand) Run(ctx *cmd.Context) error {
 			continue
 		}
 		c.convertControllerForShow(&details, controllerName, one, access, allModels, modelStatus)
+		c.convertBackupsForShow(client, &details)
 		controllers[controllerName] = details
 	}
 	return c.out.Write(ctx, controllers)
@@ -204,6 +211,10 @@ type ShowControllerDetails struct {
 	// Account is the account details for the user logged into this controller.
 	Account *AccountDetails `yaml:"account,omitempty" json:"account,omitempty"`
 
+	// Backups holds the outcome of the most recent scheduled backups of
+	// this controller.
+	Backups *BackupDetails `yaml:"scheduled-backups,omitempty" json:"scheduled-backups,omitempty"`
+
 	// Errors is a collection of errors related to accessing this controller details.
 	Errors []string `yaml:"errors,omitempty" json:"errors,omitempty"`
 }
@@ -268,6 +279,22 @@ type AccountDetails struct {
 	Password string `yaml:"password,omitempty" json:"password,omitempty"`
 }
 
+// BackupDetails holds the outcome of the most recent scheduled backups
+// of a controller.
+type BackupDetails struct {
+	// LastSuccess is when the last successful scheduled backup was started.
+	LastSuccess string `yaml:"last-success,omitempty" json:"last-success,omitempty"`
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string `yaml:"last-backup-id,omitempty" json:"last-backup-id,omitempty"`
+
+	// LastFailure is when the last failed scheduled backup was started.
+	LastFailure string `yaml:"last-failure,omitempty" json:"last-failure,omitempty"`
+
+	// LastError is the error from the last failed scheduled backup.
+	LastError string `yaml:"last-error,omitempty" json:"last-error,omitempty"`
+}
+
 func (c *showControllerCommand) convertControllerForShow(
 	controller *ShowControllerDetails,
 	controllerName string,
@@ -310,6 +337,32 @@ func (c *showControllerCommand) convertControllerForShow(
 	}
 }
 
+func (c *showControllerCommand) convertBackupsForShow(client ControllerAccessAPI, controller *ShowControllerDetails) {
+	status, err := client.ScheduledBackupStatus()
+	if errors.IsNotSupported(err) || params.IsCodeUnauthorized(err) {
+		// Older controllers don't schedule backups, and only
+		// controller admins may see their status.
+		return
+	} else if err != nil {
+		controller.Errors = append(controller.Errors, err.Error())
+		return
+	}
+	if status.LastAttempt.IsZero() {
+		return
+	}
+	details := &BackupDetails{
+		LastBackupID: status.LastBackupID,
+		LastError:    status.LastError,
+	}
+	if !status.LastSuccess.IsZero() {
+		details.LastSuccess = common.FormatTime(&status.LastSuccess, true)
+	}
+	if !status.LastFailure.IsZero() {
+		details.LastFailure = common.FormatTime(&status.LastFailure, true)
+	}
+	controller.Backups = details
+}
+
 func (c *showControllerCommand) convertAccountsForShow(controllerName string, controller *ShowControllerDetails, access string) {
 	storeDetails, err := c.store.AccountDetails(controllerName)
 	if err != nil && !errors.IsNotFound(err) {
@@ -5,6 +5,7 @@ package controller_test
 
 import (
 	"regexp"
+	"time"
 
 	"github.com/juju/cmd"
 	"github.com/juju/cmd/cmdtesting"
@@ -14,6 +15,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/cmd/juju/controller"
 	"github.com/juju/juju/jujuclient"
 	"github.com/juju/juju/jujuclient/jujuclienttesting"
@@ -126,6 +128,66 @@ mallards:
 	s.assertShowController(c, "mallards", "--show-password")
 }
 
+func (s *ShowControllerSuite) TestShowControllerWithScheduledBackups(c *gc.C) {
+	s.controllersYaml = `controllers:
+  mallards:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+`
+	s.fakeController.store = s.createTestClientStore(c)
+	lastSuccess := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	lastFailure := time.Date(2017, 6, 2, 12, 0, 0, 0, time.UTC)
+	s.fakeController.backupStatus = params.ScheduledBackupStatus{
+		LastAttempt:  lastFailure,
+		LastSuccess:  lastSuccess,
+		LastBackupID: "20170601-120000.backup-id",
+		LastFailure:  lastFailure,
+		LastError:    "disk full",
+	}
+
+	s.expectedOutput = `
+mallards:
+  details:
+    uuid: this-is-another-uuid
+    api-endpoints: [this-is-another-of-many-api-endpoints, this-is-one-more-of-many-api-endpoints]
+    ca-cert: this-is-another-ca-cert
+    cloud: mallards
+    agent-version: 999.99.99
+  models:
+    controller:
+      uuid: abc
+      machine-count: 2
+      core-count: 4
+    my-model:
+      uuid: def
+      machine-count: 2
+      core-count: 4
+  current-model: my-model
+  account:
+    user: admin
+    access: superuser
+  scheduled-backups:
+    last-success: 2017-06-01 12:00:00Z
+    last-backup-id: 20170601-120000.backup-id
+    last-failure: 2017-06-02 12:00:00Z
+    last-error: disk full
+`[1:]
+
+	s.assertShowController(c, "mallards")
+}
+
+func (s *ShowControllerSuite) TestShowControllerScheduledBackupsNotSupported(c *gc.C) {
+	s.fakeController.store = s.createTestClientStore(c)
+	s.fakeController.backupErr = errors.NotSupportedf("scheduled backup status")
+	context, err := s.runShowController(c, "--format", "json", "aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(context), gc.Not(jc.Contains), "scheduled-backups")
+	c.Assert(cmdtesting.Stdout(context), gc.Not(jc.Contains), "errors")
+}
+
 func (s *ShowControllerSuite) TestShowControllerWithBootstrapConfig(c *gc.C) {
 	s.controllersYaml = `controllers:
   mallards:
@@ -344,6 +406,8 @@ type fakeController struct {
 	store          jujuclient.ClientStore
 	modelNames     map[string]string
 	machines       map[string][]base.Machine
+	backupStatus   params.ScheduledBackupStatus
+	backupErr      error
 }
 
 func (*fakeController) GetControllerAccess(user string) (permission.Access, error) {
@@ -383,6 +447,10 @@ func (c *fakeController) AllModels() (result []base.UserModel, _ error) {
 	return result, nil
 }
 
+func (c *fakeController) ScheduledBackupStatus() (params.ScheduledBackupStatus, error) {
+	return c.backupStatus, c.backupErr
+}
+
 func (*fakeController) Close() error {
 	return nil
 }
@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+	"github.com/juju/juju/network"
+)
+
+var egressRulesHelpSummary = `
+Prints the egress rules of an application.`[1:]
+
+var egressRulesHelpDetails = `
+Lists the destinations and ports to which the machines hosting an
+application's units may send outgoing traffic, in models created with
+"egress-default-deny" set to true.
+
+Examples:
+    juju egress-rules wordpress
+    juju list-egress-rules wordpress --format yaml
+
+See also:
+    set-egress-rules`
+
+// NewEgressRulesCommand returns a command to list the egress rules
+// of an application.
+func NewEgressRulesCommand() cmd.Command {
+	cmd := &egressRulesCommand{}
+	cmd.newAPIFunc = func() (EgressRulesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type egressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	out         cmd.Output
+	application string
+
+	newAPIFunc func() (EgressRulesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *egressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "egress-rules",
+		Args:    "<application>",
+		Purpose: egressRulesHelpSummary,
+		Doc:     egressRulesHelpDetails,
+		Aliases: []string{"list-egress-rules"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *egressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatEgressRulesTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *egressRulesCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application, args = args[0], args[1:]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// EgressRulesAPI defines the API methods that the egress rules
+// command uses.
+type EgressRulesAPI interface {
+	Close() error
+	EgressRules(application string) ([]network.EgressRule, error)
+}
+
+// Run implements cmd.Command.
+func (c *egressRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	rules, err := client.EgressRules(c.application)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No egress rules are set for %s.", c.application)
+		return nil
+	}
+	return c.out.Write(ctx, formatEgressRules(rules))
+}
+
+// egressRule defines the serialization behaviour of an egress rule.
+type egressRule struct {
+	Ports            string   `yaml:"ports" json:"ports"`
+	DestinationCIDRs []string `yaml:"destination-subnets,omitempty" json:"destination-subnets,omitempty"`
+}
+
+func formatEgressRules(all []network.EgressRule) []egressRule {
+	out := make([]egressRule, len(all))
+	for i, rule := range all {
+		out[i] = egressRule{
+			Ports:            rule.PortRange.String(),
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+	}
+	return out
+}
+
+func formatEgressRulesTabular(writer io.Writer, value interface{}) error {
+	rules, ok := value.([]egressRule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", rules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Ports", "Destination subnets")
+	for _, rule := range rules {
+		w.Println(rule.Ports, strings.Join(rule.DestinationCIDRs, ","))
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type EgressRulesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mockAPI *mockEgressRulesAPI
+	store   *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&EgressRulesSuite{})
+
+func (s *EgressRulesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mockAPI = &mockEgressRulesAPI{
+		rules: []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+			network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8", "192.168.1.0/24"),
+		},
+	}
+	s.store = newMemStore(c)
+}
+
+func (s *EgressRulesSuite) TestInit(c *gc.C) {
+	cmd := firewall.NewEgressRulesCommandForTest(s.mockAPI, s.store)
+	err := cmdtesting.InitCommand(cmd, []string{})
+	c.Assert(err, gc.ErrorMatches, "no application specified")
+
+	cmd = firewall.NewEgressRulesCommandForTest(s.mockAPI, s.store)
+	err = cmdtesting.InitCommand(cmd, []string{"wordpress", "mysql"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["mysql"\]`)
+}
+
+func (s *EgressRulesSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Ports          Destination subnets
+443/tcp        0.0.0.0/0
+5432-5433/tcp  10.0.0.0/8,192.168.1.0/24
+`[1:])
+	s.mockAPI.CheckCalls(c, []jtesting.StubCall{
+		{"EgressRules", []interface{}{"wordpress"}},
+		{"Close", nil},
+	})
+}
+
+func (s *EgressRulesSuite) TestListYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress", "--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- ports: 443/tcp
+  destination-subnets:
+  - 0.0.0.0/0
+- ports: 5432-5433/tcp
+  destination-subnets:
+  - 10.0.0.0/8
+  - 192.168.1.0/24
+`[1:])
+}
+
+func (s *EgressRulesSuite) TestListEmpty(c *gc.C) {
+	s.mockAPI.rules = nil
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No egress rules are set for wordpress.\n")
+}
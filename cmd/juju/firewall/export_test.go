@@ -0,0 +1,59 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"github.com/juju/cmd"
+
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/jujuclient"
+)
+
+// NewSetFirewallRuleCommandForTest returns a set-firewall-rule command
+// with the API provided as specified.
+func NewSetFirewallRuleCommandForTest(api SetFirewallRuleAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setFirewallRuleCommand{
+		newAPIFunc: func() (SetFirewallRuleAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewListFirewallRulesCommandForTest returns a firewall-rules command
+// with the API provided as specified.
+func NewListFirewallRulesCommandForTest(api ListFirewallRulesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &listFirewallRulesCommand{
+		newAPIFunc: func() (ListFirewallRulesAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSetEgressRulesCommandForTest returns a set-egress-rules command
+// with the API provided as specified.
+func NewSetEgressRulesCommandForTest(api SetEgressRulesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &setEgressRulesCommand{
+		newAPIFunc: func() (SetEgressRulesAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
+
+// NewEgressRulesCommandForTest returns an egress-rules command
+// with the API provided as specified.
+func NewEgressRulesCommandForTest(api EgressRulesAPI, store jujuclient.ClientStore) cmd.Command {
+	cmd := &egressRulesCommand{
+		newAPIFunc: func() (EgressRulesAPI, error) {
+			return api, nil
+		},
+	}
+	cmd.SetClientStore(store)
+	return modelcmd.Wrap(cmd)
+}
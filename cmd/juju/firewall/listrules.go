@@ -0,0 +1,134 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"io"
+	"strings"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+var listRulesHelpSummary = `
+Prints the firewall rules.`[1:]
+
+var listRulesHelpDetails = `
+Lists the firewall rules which control ingress to well known services
+within a Juju model.
+
+Examples:
+    juju list-firewall-rules
+    juju firewall-rules --format yaml
+
+See also:
+    set-firewall-rule`
+
+// NewListFirewallRulesCommand returns a command to list firewall rules.
+func NewListFirewallRulesCommand() cmd.Command {
+	cmd := &listFirewallRulesCommand{}
+	cmd.newAPIFunc = func() (ListFirewallRulesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type listFirewallRulesCommand struct {
+	modelcmd.ModelCommandBase
+	out cmd.Output
+
+	newAPIFunc func() (ListFirewallRulesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *listFirewallRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "firewall-rules",
+		Purpose: listRulesHelpSummary,
+		Doc:     listRulesHelpDetails,
+		Aliases: []string{"list-firewall-rules"},
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *listFirewallRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatListTabular,
+	})
+}
+
+// Init implements cmd.Command.
+func (c *listFirewallRulesCommand) Init(args []string) (err error) {
+	return cmd.CheckEmpty(args)
+}
+
+// ListFirewallRulesAPI defines the API methods that the list firewall
+// rules command uses.
+type ListFirewallRulesAPI interface {
+	Close() error
+	ListFirewallRules() ([]params.FirewallRule, error)
+}
+
+// Run implements cmd.Command.
+func (c *listFirewallRulesCommand) Run(ctx *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	rules, err := client.ListFirewallRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rules) == 0 && c.out.Name() == "tabular" {
+		ctx.Infof("No firewall rules are currently set.")
+		return nil
+	}
+	return c.out.Write(ctx, formatRules(rules))
+}
+
+// firewallRule defines the serialization behaviour of a firewall rule.
+type firewallRule struct {
+	KnownService   string   `yaml:"known-service" json:"known-service"`
+	WhitelistCIDRs []string `yaml:"whitelist-subnets,omitempty" json:"whitelist-subnets,omitempty"`
+}
+
+func formatRules(all []params.FirewallRule) []firewallRule {
+	out := make([]firewallRule, len(all))
+	for i, rule := range all {
+		out[i] = firewallRule{
+			KnownService:   rule.KnownService,
+			WhitelistCIDRs: rule.WhitelistCIDRS,
+		}
+	}
+	return out
+}
+
+func formatListTabular(writer io.Writer, value interface{}) error {
+	rules, ok := value.([]firewallRule)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", rules, value)
+	}
+	tw := output.TabWriter(writer)
+	w := output.Wrapper{tw}
+	w.Println("Service", "Whitelist subnets")
+	for _, rule := range rules {
+		w.Println(rule.KnownService, strings.Join(rule.WhitelistCIDRs, ","))
+	}
+	return tw.Flush()
+}
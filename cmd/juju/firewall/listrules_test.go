@@ -0,0 +1,77 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ListRulesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mockAPI *mockFirewallRulesAPI
+	store   *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ListRulesSuite{})
+
+func (s *ListRulesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mockAPI = &mockFirewallRulesAPI{
+		rules: []params.FirewallRule{{
+			KnownService:   "juju-controller",
+			WhitelistCIDRS: []string{"10.0.0.0/8"},
+		}, {
+			KnownService:   "ssh",
+			WhitelistCIDRS: []string{"192.168.1.0/24", "10.0.0.0/8"},
+		}},
+	}
+	s.store = newMemStore(c)
+}
+
+func (s *ListRulesSuite) TestInit(c *gc.C) {
+	cmd := firewall.NewListFirewallRulesCommandForTest(s.mockAPI, s.store)
+	err := cmdtesting.InitCommand(cmd, []string{"ssh"})
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["ssh"\]`)
+}
+
+func (s *ListRulesSuite) TestListTabular(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewListFirewallRulesCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Service          Whitelist subnets
+juju-controller  10.0.0.0/8
+ssh              192.168.1.0/24,10.0.0.0/8
+`[1:])
+	s.mockAPI.CheckCallNames(c, "ListFirewallRules", "Close")
+}
+
+func (s *ListRulesSuite) TestListYAML(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewListFirewallRulesCommandForTest(s.mockAPI, s.store),
+		"--format", "yaml")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+- known-service: juju-controller
+  whitelist-subnets:
+  - 10.0.0.0/8
+- known-service: ssh
+  whitelist-subnets:
+  - 192.168.1.0/24
+  - 10.0.0.0/8
+`[1:])
+}
+
+func (s *ListRulesSuite) TestListEmpty(c *gc.C) {
+	s.mockAPI.rules = nil
+	ctx, err := cmdtesting.RunCommand(c, firewall.NewListFirewallRulesCommandForTest(s.mockAPI, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No firewall rules are currently set.\n")
+}
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"net"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/api/application"
+	"github.com/juju/juju/cmd/juju/block"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/network"
+)
+
+var setEgressRulesHelpSummary = `
+Sets the egress rules of an application.`[1:]
+
+var setEgressRulesHelpDetails = `
+Egress rules control the destinations and ports to which the machines
+hosting an application's units may send outgoing traffic, in models
+created with "egress-default-deny" set to true. In such models,
+outgoing traffic is otherwise only allowed to the controller.
+
+Each port range given with --ports, of the form <port>[-<port>][/<protocol>],
+is allowed to the subnets given with --to-cidrs, or to anywhere if no
+subnets are given. The protocol defaults to tcp. Setting rules replaces
+all of the application's existing egress rules; --reset removes them.
+
+Egress rules are supported by the openstack and dummy providers, when
+the openstack cloud supports Neutron security groups.
+
+Examples:
+    juju set-egress-rules wordpress --ports 443
+    juju set-egress-rules wordpress --ports 3306,5432-5433/tcp --to-cidrs 10.0.0.0/8
+    juju set-egress-rules wordpress --reset
+
+See also:
+    egress-rules`
+
+// NewSetEgressRulesCommand returns a command to set the egress rules
+// of an application.
+func NewSetEgressRulesCommand() cmd.Command {
+	cmd := &setEgressRulesCommand{}
+	cmd.newAPIFunc = func() (SetEgressRulesAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return application.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setEgressRulesCommand struct {
+	modelcmd.ModelCommandBase
+	application string
+	ports       []string
+	toCIDRs     []string
+	reset       bool
+	rules       []network.EgressRule
+
+	newAPIFunc func() (SetEgressRulesAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *setEgressRulesCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-egress-rules",
+		Args:    "<application> --ports <port-range>[,<port-range>...] [--to-cidrs <cidr>[,<cidr>...]]",
+		Purpose: setEgressRulesHelpSummary,
+		Doc:     setEgressRulesHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setEgressRulesCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.ports), "ports", "list of port ranges to allow")
+	f.Var(cmd.NewAppendStringsValue(&c.toCIDRs), "to-cidrs", "list of destination subnets to allow")
+	f.BoolVar(&c.reset, "reset", false, "Remove all of the application's egress rules")
+}
+
+// Init implements cmd.Command.
+func (c *setEgressRulesCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no application specified")
+	}
+	c.application, args = args[0], args[1:]
+	if !names.IsValidApplication(c.application) {
+		return errors.NotValidf("application name %q", c.application)
+	}
+	if c.reset {
+		if len(c.ports) > 0 || len(c.toCIDRs) > 0 {
+			return errors.New("cannot specify --ports or --to-cidrs with --reset")
+		}
+		return cmd.CheckEmpty(args)
+	}
+	if len(c.ports) == 0 {
+		return errors.New("no port ranges specified in --ports")
+	}
+	for _, cidr := range c.toCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	for _, ports := range c.ports {
+		portRange, err := network.ParsePortRange(ports)
+		if err != nil {
+			return errors.Annotatef(err, "invalid port range %q", ports)
+		}
+		c.rules = append(c.rules, network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: c.toCIDRs,
+		})
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// SetEgressRulesAPI defines the API methods that the set egress
+// rules command uses.
+type SetEgressRulesAPI interface {
+	Close() error
+	SetEgressRules(application string, rules []network.EgressRule) error
+}
+
+// Run implements cmd.Command.
+func (c *setEgressRulesCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	err = client.SetEgressRules(c.application, c.rules)
+	return block.ProcessBlockedError(err, block.BlockChange)
+}
@@ -0,0 +1,124 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/network"
+	"github.com/juju/juju/testing"
+)
+
+type SetEgressRulesSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mockAPI *mockEgressRulesAPI
+	store   *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&SetEgressRulesSuite{})
+
+func (s *SetEgressRulesSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mockAPI = &mockEgressRulesAPI{}
+	s.store = newMemStore(c)
+}
+
+func (s *SetEgressRulesSuite) TestInitArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no application specified",
+	}, {
+		args: []string{"Wordpress", "--ports", "443"},
+		err:  `application name "Wordpress" not valid`,
+	}, {
+		args: []string{"wordpress"},
+		err:  "no port ranges specified in --ports",
+	}, {
+		args: []string{"wordpress", "--ports", "443", "--to-cidrs", "10.0.0.0/8,foo"},
+		err:  `CIDR "foo" not valid`,
+	}, {
+		args: []string{"wordpress", "--ports", "443/icmp"},
+		err:  `invalid port range "443/icmp": invalid protocol "icmp", expected "tcp" or "udp"`,
+	}, {
+		args: []string{"wordpress", "--reset", "--ports", "443"},
+		err:  "cannot specify --ports or --to-cidrs with --reset",
+	}, {
+		args: []string{"wordpress", "mysql", "--ports", "443"},
+		err:  `unrecognized args: \["mysql"\]`,
+	}, {
+		args: []string{"wordpress", "--ports", "443,5432-5433/tcp", "--to-cidrs", "10.0.0.0/8"},
+	}, {
+		args: []string{"wordpress", "--reset"},
+	}} {
+		cmd := firewall.NewSetEgressRulesCommandForTest(s.mockAPI, s.store)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SetEgressRulesSuite) TestSetEgressRules(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, firewall.NewSetEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress", "--ports", "443,5432-5433/udp", "--to-cidrs", "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jtesting.StubCall{
+		{"SetEgressRules", []interface{}{"wordpress", []network.EgressRule{
+			network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+			network.MustNewEgressRule("udp", 5432, 5433, "10.0.0.0/8"),
+		}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressRulesSuite) TestSetEgressRulesReset(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, firewall.NewSetEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress", "--reset")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jtesting.StubCall{
+		{"SetEgressRules", []interface{}{"wordpress", []network.EgressRule(nil)}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetEgressRulesSuite) TestSetEgressRulesError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("fail"))
+	_, err := cmdtesting.RunCommand(c, firewall.NewSetEgressRulesCommandForTest(s.mockAPI, s.store),
+		"wordpress", "--ports", "443")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+type mockEgressRulesAPI struct {
+	jtesting.Stub
+	rules []network.EgressRule
+}
+
+func (m *mockEgressRulesAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockEgressRulesAPI) SetEgressRules(application string, rules []network.EgressRule) error {
+	m.MethodCall(m, "SetEgressRules", application, rules)
+	return m.NextErr()
+}
+
+func (m *mockEgressRulesAPI) EgressRules(application string) ([]network.EgressRule, error) {
+	m.MethodCall(m, "EgressRules", application)
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rules, nil
+}
@@ -0,0 +1,119 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall
+
+import (
+	"net"
+
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+
+	"github.com/juju/juju/api/firewallrules"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+var setRuleHelpSummary = `
+Sets a firewall rule.`[1:]
+
+var setRuleHelpDetails = `
+Firewall rules control ingress to a well known service within a Juju model.
+A rule consists of the service name and a whitelist of allowed ingress
+subnets.
+The currently supported services are:
+ - ssh
+ - juju-controller
+
+The ssh rule applies to port 22 on every machine in the model. The
+juju-controller rule applies to the API port on the controller machines,
+and may only be set on the controller model. Setting a rule replaces any
+existing whitelist for the service.
+
+On providers which allow access to these ports from anywhere by default,
+such as Amazon EC2 and OpenStack, setting a rule restricts that access to
+the whitelist. Other providers may still allow access from anywhere.
+
+Examples:
+    juju set-firewall-rule ssh --whitelist 192.168.1.0/16
+    juju set-firewall-rule juju-controller --whitelist 10.0.0.0/8,192.168.1.0/24
+
+See also:
+    list-firewall-rules`
+
+// NewSetFirewallRuleCommand returns a command to set firewall rules.
+func NewSetFirewallRuleCommand() cmd.Command {
+	cmd := &setFirewallRuleCommand{}
+	cmd.newAPIFunc = func() (SetFirewallRuleAPI, error) {
+		root, err := cmd.NewAPIRoot()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		return firewallrules.NewClient(root), nil
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+type setFirewallRuleCommand struct {
+	modelcmd.ModelCommandBase
+	service   string
+	whitelist []string
+
+	newAPIFunc func() (SetFirewallRuleAPI, error)
+}
+
+// Info implements cmd.Command.
+func (c *setFirewallRuleCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "set-firewall-rule",
+		Args:    "<service-name> --whitelist <cidr>[,<cidr>...]",
+		Purpose: setRuleHelpSummary,
+		Doc:     setRuleHelpDetails,
+	}
+}
+
+// SetFlags implements cmd.Command.
+func (c *setFirewallRuleCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.ModelCommandBase.SetFlags(f)
+	f.Var(cmd.NewAppendStringsValue(&c.whitelist), "whitelist", "list of subnets to whitelist")
+}
+
+// Init implements cmd.Command.
+func (c *setFirewallRuleCommand) Init(args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("no well known service specified")
+	}
+	c.service, args = args[0], args[1:]
+	switch c.service {
+	case "ssh", "juju-controller":
+	default:
+		return errors.NotValidf("well known service type %q", c.service)
+	}
+	if len(c.whitelist) == 0 {
+		return errors.New("no subnets specified in --whitelist")
+	}
+	for _, cidr := range c.whitelist {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	return cmd.CheckEmpty(args)
+}
+
+// SetFirewallRuleAPI defines the API methods that the set firewall
+// rule command uses.
+type SetFirewallRuleAPI interface {
+	Close() error
+	SetFirewallRule(service string, whitelistCIDRs []string) error
+}
+
+// Run implements cmd.Command.
+func (c *setFirewallRuleCommand) Run(_ *cmd.Context) error {
+	client, err := c.newAPIFunc()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer client.Close()
+
+	return errors.Trace(client.SetFirewallRule(c.service, c.whitelist))
+}
@@ -0,0 +1,120 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package firewall_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	jtesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/firewall"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type SetRuleSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	mockAPI *mockFirewallRulesAPI
+	store   *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&SetRuleSuite{})
+
+func (s *SetRuleSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.mockAPI = &mockFirewallRulesAPI{}
+	s.store = newMemStore(c)
+}
+
+func (s *SetRuleSuite) TestInitArgs(c *gc.C) {
+	for _, test := range []struct {
+		args []string
+		err  string
+	}{{
+		args: []string{},
+		err:  "no well known service specified",
+	}, {
+		args: []string{"telnet", "--whitelist", "10.0.0.0/8"},
+		err:  `well known service type "telnet" not valid`,
+	}, {
+		args: []string{"ssh"},
+		err:  "no subnets specified in --whitelist",
+	}, {
+		args: []string{"ssh", "--whitelist", "10.0.0.0/8,foo"},
+		err:  `CIDR "foo" not valid`,
+	}, {
+		args: []string{"ssh", "juju-controller", "--whitelist", "10.0.0.0/8"},
+		err:  `unrecognized args: \["juju-controller"\]`,
+	}, {
+		args: []string{"ssh", "--whitelist", "10.0.0.0/8,192.168.1.0/24"},
+	}, {
+		args: []string{"juju-controller", "--whitelist", "10.0.0.0/8"},
+	}} {
+		cmd := firewall.NewSetFirewallRuleCommandForTest(s.mockAPI, s.store)
+		err := cmdtesting.InitCommand(cmd, test.args)
+		if test.err == "" {
+			c.Check(err, jc.ErrorIsNil)
+		} else {
+			c.Check(err, gc.ErrorMatches, test.err)
+		}
+	}
+}
+
+func (s *SetRuleSuite) TestSetRule(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, firewall.NewSetFirewallRuleCommandForTest(s.mockAPI, s.store),
+		"ssh", "--whitelist", "10.0.0.0/8,192.168.1.0/24")
+	c.Assert(err, jc.ErrorIsNil)
+	s.mockAPI.CheckCalls(c, []jtesting.StubCall{
+		{"SetFirewallRule", []interface{}{"ssh", []string{"10.0.0.0/8", "192.168.1.0/24"}}},
+		{"Close", nil},
+	})
+}
+
+func (s *SetRuleSuite) TestSetRuleError(c *gc.C) {
+	s.mockAPI.SetErrors(errors.New("fail"))
+	_, err := cmdtesting.RunCommand(c, firewall.NewSetFirewallRuleCommandForTest(s.mockAPI, s.store),
+		"ssh", "--whitelist", "10.0.0.0/8")
+	c.Assert(err, gc.ErrorMatches, "fail")
+}
+
+func newMemStore(c *gc.C) *jujuclient.MemStore {
+	store := jujuclient.NewMemStore()
+	store.CurrentControllerName = "testing"
+	store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	store.Models["testing"].CurrentModel = "admin/mymodel"
+	return store
+}
+
+type mockFirewallRulesAPI struct {
+	jtesting.Stub
+	rules []params.FirewallRule
+}
+
+func (m *mockFirewallRulesAPI) Close() error {
+	m.MethodCall(m, "Close")
+	return nil
+}
+
+func (m *mockFirewallRulesAPI) SetFirewallRule(service string, whitelistCIDRs []string) error {
+	m.MethodCall(m, "SetFirewallRule", service, whitelistCIDRs)
+	return m.NextErr()
+}
+
+func (m *mockFirewallRulesAPI) ListFirewallRules() ([]params.FirewallRule, error) {
+	m.MethodCall(m, "ListFirewallRules")
+	if err := m.NextErr(); err != nil {
+		return nil, err
+	}
+	return m.rules, nil
+}
@@ -0,0 +1,95 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package model_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/errors"
+	gitjujutesting "github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/cmd/juju/model"
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type ExportBundleCommandSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	fake  *fakeExportBundleClient
+	store *jujuclient.MemStore
+}
+
+var _ = gc.Suite(&ExportBundleCommandSuite{})
+
+type fakeExportBundleClient struct {
+	gitjujutesting.Stub
+	result string
+}
+
+func (f *fakeExportBundleClient) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeExportBundleClient) ExportBundle() (string, error) {
+	f.MethodCall(f, "ExportBundle")
+	if err := f.NextErr(); err != nil {
+		return "", err
+	}
+	return f.result, nil
+}
+
+func (s *ExportBundleCommandSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.fake = &fakeExportBundleClient{
+		result: "applications:\n  mysql:\n    charm: cs:mysql\n    num_units: 1\n",
+	}
+	s.store = jujuclient.NewMemStore()
+	s.store.CurrentControllerName = "testing"
+	s.store.Controllers["testing"] = jujuclient.ControllerDetails{}
+	s.store.Accounts["testing"] = jujuclient.AccountDetails{
+		User: "admin",
+	}
+	err := s.store.UpdateModel("testing", "admin/mymodel", jujuclient.ModelDetails{
+		testing.ModelTag.Id(),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.store.Models["testing"].CurrentModel = "admin/mymodel"
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleStdout(c *gc.C) {
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store))
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, s.fake.result)
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleFilename(c *gc.C) {
+	dir := c.MkDir()
+	filename := filepath.Join(dir, "mymodel.yaml")
+	ctx, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "--filename", filename)
+	c.Assert(err, jc.ErrorIsNil)
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "Bundle successfully exported to "+filename+"\n")
+
+	data, err := ioutil.ReadFile(filename)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, s.fake.result)
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleError(c *gc.C) {
+	s.fake.SetErrors(errors.New("nothing to export as there are no applications"))
+	_, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store))
+	c.Assert(err, gc.ErrorMatches, "nothing to export as there are no applications")
+	s.fake.CheckCallNames(c, "ExportBundle", "Close")
+}
+
+func (s *ExportBundleCommandSuite) TestExportBundleTooManyArgs(c *gc.C) {
+	_, err := cmdtesting.RunCommand(c, model.NewExportBundleCommandForTest(s.fake, s.store), "extra")
+	c.Assert(err, gc.ErrorMatches, `unrecognized args: \["extra"\]`)
+}
@@ -0,0 +1,125 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewResizeStorageCommandWithAPI returns a command
+// used to grow storage.
+func NewResizeStorageCommandWithAPI() cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = func() (StorageResizerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewResizeStorageCommand returns a command
+// used to grow storage.
+func NewResizeStorageCommand(new NewStorageResizerCloserFunc) cmd.Command {
+	cmd := &resizeStorageCommand{}
+	cmd.newStorageResizerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	resizeStorageCommandDoc = `
+Grows the volume backing a storage instance. Specify a storage ID, as
+output by "juju storage", and the new size of the storage. The size
+must be larger than the current size, and may be suffixed with one of
+M, G, T, P or E; a size without a suffix is taken to be in MiB.
+
+The volume is resized by the storage provider, after which any
+filesystem created by Juju on the volume is grown to fill it, and the
+charm is notified with the storage-resized hook so that it can make use
+of the additional space. Only storage backed by volumes of a provider
+that supports resizing may be resized: EBS volumes, GCE persistent
+disks, Cinder volumes and loop devices. Cinder volumes can only be
+resized while they are detached.
+
+Examples:
+    juju resize-storage data/0 200G
+
+See also:
+    storage
+    add-storage
+`
+	resizeStorageCommandArgs = `<storage> <size>`
+)
+
+// resizeStorageCommand grows storage.
+type resizeStorageCommand struct {
+	StorageCommandBase
+	newStorageResizerCloser NewStorageResizerCloserFunc
+	storageId               string
+	size                    uint64
+}
+
+// Info implements Command.Info.
+func (c *resizeStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "resize-storage",
+		Purpose: "Grows the volume backing a storage instance.",
+		Doc:     resizeStorageCommandDoc,
+		Args:    resizeStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *resizeStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("resize-storage requires a storage ID and a size")
+	}
+	size, err := utils.ParseSize(args[1])
+	if err != nil {
+		return errors.Annotatef(err, "invalid size %q", args[1])
+	}
+	if size == 0 {
+		return errors.Errorf("invalid size %q: must be greater than zero", args[1])
+	}
+	c.storageId, c.size = args[0], size
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *resizeStorageCommand) Run(ctx *cmd.Context) error {
+	resizer, err := c.newStorageResizerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resizer.Close()
+
+	if err := resizer.ResizeStorage(c.storageId, c.size); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "resize storage")
+		}
+		return err
+	}
+	ctx.Infof("resizing %s to %dMiB", c.storageId, c.size)
+	return nil
+}
+
+// NewStorageResizerCloserFunc is the type of a function that returns a
+// StorageResizerCloser.
+type NewStorageResizerCloserFunc func() (StorageResizerCloser, error)
+
+// StorageResizerCloser extends StorageResizer with a Closer method.
+type StorageResizerCloser interface {
+	StorageResizer
+	Close() error
+}
+
+// StorageResizer defines an interface for growing the storage
+// instance with the specified ID to the specified size in MiB.
+type StorageResizer interface {
+	ResizeStorage(storageId string, size uint64) error
+}
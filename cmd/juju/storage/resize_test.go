@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type ResizeStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&ResizeStorageSuite{})
+
+func (s *ResizeStorageSuite) TestResize(c *gc.C) {
+	var fake fakeStorageResizer
+	cmd := storage.NewResizeStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "200G")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "ResizeStorage", "Close")
+	fake.CheckCall(c, 1, "ResizeStorage", "foo/0", uint64(200*1024))
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "resizing foo/0 to 204800MiB\n")
+}
+
+func (s *ResizeStorageSuite) TestResizeError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Message: "new size 1024MiB must be larger than current size 2048MiB"})
+	cmd := storage.NewResizeStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "foo/0", "1G")
+	c.Assert(err, gc.ErrorMatches, "new size 1024MiB must be larger than current size 2048MiB")
+	fake.CheckCallNames(c, "NewStorageResizerCloser", "ResizeStorage", "Close")
+}
+
+func (s *ResizeStorageSuite) TestResizeUnauthorizedError(c *gc.C) {
+	var fake fakeStorageResizer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewResizeStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "200G")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to resize storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *ResizeStorageSuite) TestResizeInitErrors(c *gc.C) {
+	s.testResizeInitError(c, []string{}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"foo/0"}, "resize-storage requires a storage ID and a size")
+	s.testResizeInitError(c, []string{"foo/0", "big"}, `invalid size "big": .*`)
+	s.testResizeInitError(c, []string{"foo/0", "0"}, `invalid size "0": must be greater than zero`)
+	s.testResizeInitError(c, []string{"foo/0", "200G", "bar"}, `unrecognized args: \["bar"\]`)
+}
+
+func (s *ResizeStorageSuite) testResizeInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewResizeStorageCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageResizer struct {
+	testing.Stub
+}
+
+func (f *fakeStorageResizer) new() (storage.StorageResizerCloser, error) {
+	f.MethodCall(f, "NewStorageResizerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageResizer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageResizer) ResizeStorage(storageId string, size uint64) error {
+	f.MethodCall(f, "ResizeStorage", storageId, size)
+	return f.NextErr()
+}
@@ -0,0 +1,115 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+)
+
+// NewRestoreStorageCommandWithAPI returns a command
+// used to restore storage from a snapshot.
+func NewRestoreStorageCommandWithAPI() cmd.Command {
+	cmd := &restoreStorageCommand{}
+	cmd.newStorageRestorerCloser = func() (StorageRestorerCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewRestoreStorageCommand returns a command
+// used to restore storage from a snapshot.
+func NewRestoreStorageCommand(new NewStorageRestorerCloserFunc) cmd.Command {
+	cmd := &restoreStorageCommand{}
+	cmd.newStorageRestorerCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	restoreStorageCommandDoc = `
+Restores storage from a snapshot. Specify a storage ID, as output by
+"juju storage", and the ID of a snapshot of that storage, as output by
+"juju snapshot-storage --list".
+
+The volume backing the storage is replaced by a new volume created from
+the snapshot, and the original volume is destroyed. The storage must be
+detached from all units before it can be restored.
+
+Examples:
+    juju detach-storage pgdata/0
+    juju restore-storage pgdata/0 snap-0123456789abcdef0
+    juju attach-storage postgresql/0 pgdata/0
+
+See also:
+    snapshot-storage
+    detach-storage
+    attach-storage
+`
+	restoreStorageCommandArgs = `<storage> <snapshot>`
+)
+
+// restoreStorageCommand restores storage from a snapshot.
+type restoreStorageCommand struct {
+	StorageCommandBase
+	newStorageRestorerCloser NewStorageRestorerCloserFunc
+	storageId                string
+	snapshotId               string
+}
+
+// Info implements Command.Info.
+func (c *restoreStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "restore-storage",
+		Purpose: "Restores storage from a snapshot.",
+		Doc:     restoreStorageCommandDoc,
+		Args:    restoreStorageCommandArgs,
+	}
+}
+
+// Init implements Command.Init.
+func (c *restoreStorageCommand) Init(args []string) error {
+	if len(args) < 2 {
+		return errors.New("restore-storage requires a storage ID and a snapshot ID")
+	}
+	c.storageId, c.snapshotId = args[0], args[1]
+	return cmd.CheckEmpty(args[2:])
+}
+
+// Run implements Command.Run.
+func (c *restoreStorageCommand) Run(ctx *cmd.Context) error {
+	restorer, err := c.newStorageRestorerCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer restorer.Close()
+
+	if err := restorer.RestoreStorage(c.storageId, c.snapshotId); err != nil {
+		if params.IsCodeUnauthorized(err) {
+			common.PermissionsMessage(ctx.Stderr, "restore storage")
+		}
+		return err
+	}
+	ctx.Infof("restored %s from snapshot %s", c.storageId, c.snapshotId)
+	return nil
+}
+
+// NewStorageRestorerCloserFunc is the type of a function that returns a
+// StorageRestorerCloser.
+type NewStorageRestorerCloserFunc func() (StorageRestorerCloser, error)
+
+// StorageRestorerCloser extends StorageRestorer with a Closer method.
+type StorageRestorerCloser interface {
+	StorageRestorer
+	Close() error
+}
+
+// StorageRestorer defines an interface for restoring the storage
+// instance with the specified ID from a snapshot.
+type StorageRestorer interface {
+	RestoreStorage(storageId, snapshotId string) error
+}
@@ -0,0 +1,83 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type RestoreStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&RestoreStorageSuite{})
+
+func (s *RestoreStorageSuite) TestRestore(c *gc.C) {
+	var fake fakeStorageRestorer
+	cmd := storage.NewRestoreStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "snap-0")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageRestorerCloser", "RestoreStorage", "Close")
+	fake.CheckCall(c, 1, "RestoreStorage", "foo/0", "snap-0")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "restored foo/0 from snapshot snap-0\n")
+}
+
+func (s *RestoreStorageSuite) TestRestoreError(c *gc.C) {
+	var fake fakeStorageRestorer
+	fake.SetErrors(nil, &params.Error{Message: "storage foo/0 is attached to machine 0; detach it before restoring"})
+	cmd := storage.NewRestoreStorageCommand(fake.new)
+	_, err := cmdtesting.RunCommand(c, cmd, "foo/0", "snap-0")
+	c.Assert(err, gc.ErrorMatches, "storage foo/0 is attached to machine 0; detach it before restoring")
+	fake.CheckCallNames(c, "NewStorageRestorerCloser", "RestoreStorage", "Close")
+}
+
+func (s *RestoreStorageSuite) TestRestoreUnauthorizedError(c *gc.C) {
+	var fake fakeStorageRestorer
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewRestoreStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "snap-0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to restore storage.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *RestoreStorageSuite) TestRestoreInitErrors(c *gc.C) {
+	s.testRestoreInitError(c, []string{}, "restore-storage requires a storage ID and a snapshot ID")
+	s.testRestoreInitError(c, []string{"foo/0"}, "restore-storage requires a storage ID and a snapshot ID")
+	s.testRestoreInitError(c, []string{"foo/0", "snap-0", "bar"}, `unrecognized args: \["bar"\]`)
+}
+
+func (s *RestoreStorageSuite) testRestoreInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewRestoreStorageCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageRestorer struct {
+	testing.Stub
+}
+
+func (f *fakeStorageRestorer) new() (storage.StorageRestorerCloser, error) {
+	f.MethodCall(f, "NewStorageRestorerCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageRestorer) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageRestorer) RestoreStorage(storageId, snapshotId string) error {
+	f.MethodCall(f, "RestoreStorage", storageId, snapshotId)
+	return f.NextErr()
+}
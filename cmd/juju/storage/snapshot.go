@@ -0,0 +1,292 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize"
+	"github.com/juju/cmd"
+	"github.com/juju/errors"
+	"github.com/juju/gnuflag"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/common"
+	"github.com/juju/juju/cmd/modelcmd"
+	"github.com/juju/juju/cmd/output"
+)
+
+// NewSnapshotStorageCommandWithAPI returns a command
+// used to manage snapshots of storage.
+func NewSnapshotStorageCommandWithAPI() cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = func() (StorageSnapshotterCloser, error) {
+		return cmd.NewStorageAPI()
+	}
+	return modelcmd.Wrap(cmd)
+}
+
+// NewSnapshotStorageCommand returns a command
+// used to manage snapshots of storage.
+func NewSnapshotStorageCommand(new NewStorageSnapshotterCloserFunc) cmd.Command {
+	cmd := &snapshotStorageCommand{}
+	cmd.newStorageSnapshotterCloser = new
+	return modelcmd.Wrap(cmd)
+}
+
+const (
+	snapshotStorageCommandDoc = `
+Creates point-in-time snapshots of storage. Specify one or more
+storage IDs, as output by "juju storage". Only storage backed by
+model-scoped volumes, such as EBS, Cinder or GCE persistent disks,
+may be snapshotted.
+
+Use --list to show the existing snapshots of the specified storage,
+or --remove to remove snapshots of a single storage instance.
+
+Snapshots may be restored with "juju restore-storage".
+
+Examples:
+    juju snapshot-storage pgdata/0
+    juju snapshot-storage --list pgdata/0 pgdata/1
+    juju snapshot-storage --remove pgdata/0 snap-0123456789abcdef0
+
+See also:
+    restore-storage
+    storage
+`
+	snapshotStorageCommandArgs = `<storage> [<storage> ...] | --remove <storage> <snapshot> [<snapshot> ...]`
+)
+
+// snapshotStorageCommand creates, lists and removes storage snapshots.
+type snapshotStorageCommand struct {
+	StorageCommandBase
+	newStorageSnapshotterCloser NewStorageSnapshotterCloserFunc
+	storageIds                  []string
+	snapshotIds                 []string
+	list                        bool
+	remove                      bool
+	out                         cmd.Output
+}
+
+// Info implements Command.Info.
+func (c *snapshotStorageCommand) Info() *cmd.Info {
+	return &cmd.Info{
+		Name:    "snapshot-storage",
+		Purpose: "Creates, lists or removes snapshots of storage.",
+		Doc:     snapshotStorageCommandDoc,
+		Args:    snapshotStorageCommandArgs,
+	}
+}
+
+// SetFlags implements Command.SetFlags.
+func (c *snapshotStorageCommand) SetFlags(f *gnuflag.FlagSet) {
+	c.StorageCommandBase.SetFlags(f)
+	f.BoolVar(&c.list, "list", false, "List the snapshots of the specified storage")
+	f.BoolVar(&c.remove, "remove", false, "Remove the specified snapshots of the storage")
+	c.out.AddFlags(f, "tabular", map[string]cmd.Formatter{
+		"yaml":    cmd.FormatYaml,
+		"json":    cmd.FormatJson,
+		"tabular": formatSnapshotListTabular,
+	})
+}
+
+// Init implements Command.Init.
+func (c *snapshotStorageCommand) Init(args []string) error {
+	if c.list && c.remove {
+		return errors.New("cannot specify both --list and --remove")
+	}
+	if c.remove {
+		if len(args) < 2 {
+			return errors.New("snapshot-storage --remove requires a storage ID and at least one snapshot ID")
+		}
+		c.storageIds = args[:1]
+		c.snapshotIds = args[1:]
+		return nil
+	}
+	if len(args) < 1 {
+		return errors.New("snapshot-storage requires at least one storage ID")
+	}
+	c.storageIds = args
+	return nil
+}
+
+// Run implements Command.Run.
+func (c *snapshotStorageCommand) Run(ctx *cmd.Context) error {
+	snapshotter, err := c.newStorageSnapshotterCloser()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer snapshotter.Close()
+
+	switch {
+	case c.list:
+		err = c.listSnapshots(ctx, snapshotter)
+	case c.remove:
+		err = c.removeSnapshots(ctx, snapshotter)
+	default:
+		err = c.createSnapshots(ctx, snapshotter)
+	}
+	if params.IsCodeUnauthorized(err) {
+		common.PermissionsMessage(ctx.Stderr, "manage storage snapshots")
+	}
+	return err
+}
+
+func (c *snapshotStorageCommand) createSnapshots(ctx *cmd.Context, snapshotter StorageSnapshotter) error {
+	results, err := snapshotter.SnapshotStorage(c.storageIds)
+	if err != nil {
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to snapshot %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("created snapshot %s of %s", result.Result.SnapshotId, c.storageIds[i])
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *snapshotStorageCommand) listSnapshots(ctx *cmd.Context, snapshotter StorageSnapshotter) error {
+	results, err := snapshotter.ListStorageSnapshots(c.storageIds)
+	if err != nil {
+		return err
+	}
+	anyFailed := false
+	details := make(map[string]map[string]SnapshotInfo)
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to list snapshots of %s: %s", c.storageIds[i], result.Error)
+			anyFailed = true
+			continue
+		}
+		if len(result.Result) == 0 {
+			continue
+		}
+		snapshots := make(map[string]SnapshotInfo)
+		for _, snapshot := range result.Result {
+			snapshots[snapshot.SnapshotId] = formatSnapshotInfo(snapshot)
+		}
+		details[c.storageIds[i]] = snapshots
+	}
+	if len(details) == 0 {
+		if !anyFailed {
+			ctx.Infof("No snapshots to display.")
+		}
+	} else if err := c.out.Write(ctx, details); err != nil {
+		return err
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+func (c *snapshotStorageCommand) removeSnapshots(ctx *cmd.Context, snapshotter StorageSnapshotter) error {
+	storageId := c.storageIds[0]
+	results, err := snapshotter.RemoveStorageSnapshots(storageId, c.snapshotIds)
+	if err != nil {
+		return err
+	}
+	anyFailed := false
+	for i, result := range results {
+		if result.Error != nil {
+			ctx.Infof("failed to remove snapshot %s of %s: %s", c.snapshotIds[i], storageId, result.Error)
+			anyFailed = true
+			continue
+		}
+		ctx.Infof("removed snapshot %s of %s", c.snapshotIds[i], storageId)
+	}
+	if anyFailed {
+		return cmd.ErrSilent
+	}
+	return nil
+}
+
+// SnapshotInfo defines the serialization behaviour of storage snapshot
+// information.
+type SnapshotInfo struct {
+	Volume  string `yaml:"volume" json:"volume"`
+	Size    uint64 `yaml:"size" json:"size"`
+	Status  string `yaml:"status,omitempty" json:"status,omitempty"`
+	Created string `yaml:"created,omitempty" json:"created,omitempty"`
+}
+
+func formatSnapshotInfo(snapshot params.StorageSnapshot) SnapshotInfo {
+	info := SnapshotInfo{
+		Size:   snapshot.Size,
+		Status: snapshot.Status,
+	}
+	if volumeTag, err := names.ParseVolumeTag(snapshot.VolumeTag); err == nil {
+		info.Volume = volumeTag.Id()
+	}
+	if !snapshot.Created.IsZero() {
+		info.Created = common.FormatTime(&snapshot.Created, true)
+	}
+	return info
+}
+
+// formatSnapshotListTabular writes a tabular summary of storage snapshots.
+func formatSnapshotListTabular(writer io.Writer, value interface{}) error {
+	snapshots, ok := value.(map[string]map[string]SnapshotInfo)
+	if !ok {
+		return errors.Errorf("expected value of type %T, got %T", snapshots, value)
+	}
+	tw := output.TabWriter(writer)
+	print := func(values ...string) {
+		fmt.Fprintln(tw, strings.Join(values, "\t"))
+	}
+	print("Storage", "Snapshot", "Volume", "Size", "Status", "Created")
+
+	storageIds := make([]string, 0, len(snapshots))
+	for storageId := range snapshots {
+		storageIds = append(storageIds, storageId)
+	}
+	sort.Strings(storageIds)
+	for _, storageId := range storageIds {
+		snapshotIds := make([]string, 0, len(snapshots[storageId]))
+		for snapshotId := range snapshots[storageId] {
+			snapshotIds = append(snapshotIds, snapshotId)
+		}
+		sort.Strings(snapshotIds)
+		for _, snapshotId := range snapshotIds {
+			info := snapshots[storageId][snapshotId]
+			var size string
+			if info.Size > 0 {
+				size = humanize.IBytes(info.Size * humanize.MiByte)
+			}
+			print(storageId, snapshotId, info.Volume, size, info.Status, info.Created)
+		}
+	}
+	tw.Flush()
+	return nil
+}
+
+// NewStorageSnapshotterCloserFunc is the type of a function that returns a
+// StorageSnapshotterCloser.
+type NewStorageSnapshotterCloserFunc func() (StorageSnapshotterCloser, error)
+
+// StorageSnapshotterCloser extends StorageSnapshotter with a Closer method.
+type StorageSnapshotterCloser interface {
+	StorageSnapshotter
+	Close() error
+}
+
+// StorageSnapshotter defines an interface for creating, listing and
+// removing snapshots of storage instances with the specified IDs.
+type StorageSnapshotter interface {
+	SnapshotStorage(storageIds []string) ([]params.StorageSnapshotResult, error)
+	ListStorageSnapshots(storageIds []string) ([]params.StorageSnapshotsResult, error)
+	RemoveStorageSnapshots(storageId string, snapshotIds []string) ([]params.ErrorResult, error)
+}
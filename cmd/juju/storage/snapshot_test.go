@@ -0,0 +1,184 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package storage_test
+
+import (
+	"time"
+
+	"github.com/juju/cmd"
+	"github.com/juju/cmd/cmdtesting"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/cmd/juju/storage"
+)
+
+type SnapshotStorageSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&SnapshotStorageSuite{})
+
+func (s *SnapshotStorageSuite) TestSnapshot(c *gc.C) {
+	fake := fakeStorageSnapshotter{snapshotResults: []params.StorageSnapshotResult{
+		{Result: &params.StorageSnapshot{SnapshotId: "snap-0"}},
+		{Result: &params.StorageSnapshot{SnapshotId: "snap-1"}},
+	}}
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "SnapshotStorage", "Close")
+	fake.CheckCall(c, 1, "SnapshotStorage", []string{"foo/0", "bar/1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+created snapshot snap-0 of foo/0
+created snapshot snap-1 of bar/1
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotError(c *gc.C) {
+	fake := fakeStorageSnapshotter{snapshotResults: []params.StorageSnapshotResult{
+		{Error: &params.Error{Message: "foo"}},
+	}}
+	snapshotCmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, snapshotCmd, "baz/0")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "failed to snapshot baz/0: foo\n")
+}
+
+func (s *SnapshotStorageSuite) TestSnapshotUnauthorizedError(c *gc.C) {
+	var fake fakeStorageSnapshotter
+	fake.SetErrors(nil, &params.Error{Code: params.CodeUnauthorized, Message: "nope"})
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "foo/0")
+	c.Assert(err, gc.ErrorMatches, "nope")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+You do not have permission to manage storage snapshots.
+You may ask an administrator to grant you access with "juju grant".
+
+`)
+}
+
+func (s *SnapshotStorageSuite) TestList(c *gc.C) {
+	created := time.Date(2017, time.November, 1, 2, 3, 4, 0, time.UTC)
+	fake := fakeStorageSnapshotter{listResults: []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			StorageTag: "storage-foo-0",
+			VolumeTag:  "volume-0",
+			SnapshotId: "snap-1",
+			Size:       2048,
+			Status:     "pending",
+		}, {
+			StorageTag: "storage-foo-0",
+			VolumeTag:  "volume-0",
+			SnapshotId: "snap-0",
+			Size:       1024,
+			Status:     "completed",
+			Created:    created,
+		}},
+	}, {}}}
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--list", "foo/0", "bar/1")
+	c.Assert(err, jc.ErrorIsNil)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "ListStorageSnapshots", "Close")
+	fake.CheckCall(c, 1, "ListStorageSnapshots", []string{"foo/0", "bar/1"})
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+Storage  Snapshot  Volume  Size    Status     Created
+foo/0    snap-0    0       1.0GiB  completed  2017-11-01 02:03:04Z
+foo/0    snap-1    0       2.0GiB  pending    
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListYAML(c *gc.C) {
+	fake := fakeStorageSnapshotter{listResults: []params.StorageSnapshotsResult{{
+		Result: []params.StorageSnapshot{{
+			StorageTag: "storage-foo-0",
+			VolumeTag:  "volume-0",
+			SnapshotId: "snap-0",
+			Size:       1024,
+			Status:     "completed",
+		}},
+	}}}
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--list", "--format", "yaml", "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, `
+foo/0:
+  snap-0:
+    volume: "0"
+    size: 1024
+    status: completed
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestListNone(c *gc.C) {
+	fake := fakeStorageSnapshotter{listResults: []params.StorageSnapshotsResult{{}}}
+	cmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, cmd, "--list", "foo/0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cmdtesting.Stdout(ctx), gc.Equals, "")
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, "No snapshots to display.\n")
+}
+
+func (s *SnapshotStorageSuite) TestRemove(c *gc.C) {
+	fake := fakeStorageSnapshotter{removeResults: []params.ErrorResult{
+		{},
+		{Error: &params.Error{Message: "bar"}},
+	}}
+	removeCmd := storage.NewSnapshotStorageCommand(fake.new)
+	ctx, err := cmdtesting.RunCommand(c, removeCmd, "--remove", "foo/0", "snap-0", "snap-1")
+	c.Assert(err, gc.Equals, cmd.ErrSilent)
+	fake.CheckCallNames(c, "NewStorageSnapshotterCloser", "RemoveStorageSnapshots", "Close")
+	fake.CheckCall(c, 1, "RemoveStorageSnapshots", "foo/0", []string{"snap-0", "snap-1"})
+	c.Assert(cmdtesting.Stderr(ctx), gc.Equals, `
+removed snapshot snap-0 of foo/0
+failed to remove snapshot snap-1 of foo/0: bar
+`[1:])
+}
+
+func (s *SnapshotStorageSuite) TestInitErrors(c *gc.C) {
+	s.testInitError(c, []string{}, "snapshot-storage requires at least one storage ID")
+	s.testInitError(c, []string{"--list"}, "snapshot-storage requires at least one storage ID")
+	s.testInitError(c, []string{"--remove", "foo/0"}, "snapshot-storage --remove requires a storage ID and at least one snapshot ID")
+	s.testInitError(c, []string{"--list", "--remove", "foo/0", "snap-0"}, "cannot specify both --list and --remove")
+}
+
+func (s *SnapshotStorageSuite) testInitError(c *gc.C, args []string, expect string) {
+	cmd := storage.NewSnapshotStorageCommand(nil)
+	_, err := cmdtesting.RunCommand(c, cmd, args...)
+	c.Assert(err, gc.ErrorMatches, expect)
+}
+
+type fakeStorageSnapshotter struct {
+	testing.Stub
+	snapshotResults []params.StorageSnapshotResult
+	listResults     []params.StorageSnapshotsResult
+	removeResults   []params.ErrorResult
+}
+
+func (f *fakeStorageSnapshotter) new() (storage.StorageSnapshotterCloser, error) {
+	f.MethodCall(f, "NewStorageSnapshotterCloser")
+	return f, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) Close() error {
+	f.MethodCall(f, "Close")
+	return f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) SnapshotStorage(ids []string) ([]params.StorageSnapshotResult, error) {
+	f.MethodCall(f, "SnapshotStorage", ids)
+	return f.snapshotResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) ListStorageSnapshots(ids []string) ([]params.StorageSnapshotsResult, error) {
+	f.MethodCall(f, "ListStorageSnapshots", ids)
+	return f.listResults, f.NextErr()
+}
+
+func (f *fakeStorageSnapshotter) RemoveStorageSnapshots(id string, snapshotIds []string) ([]params.ErrorResult, error) {
+	f.MethodCall(f, "RemoveStorageSnapshots", id, snapshotIds)
+	return f.removeResults, f.NextErr()
+}
@@ -51,6 +51,8 @@ var (
 		"application-scaler",
 		"state-cleaner",
 		"status-history-pruner",
+		"action-pruner",
+		"action-scheduler",
 		"storage-provisioner",
 		"unit-assigner",
 		"remote-relations",
@@ -70,6 +70,7 @@ import (
 	"github.com/juju/juju/service"
 	"github.com/juju/juju/service/common"
 	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
 	"github.com/juju/juju/state/multiwatcher"
 	"github.com/juju/juju/state/stateenvirons"
 	"github.com/juju/juju/state/statemetrics"
@@ -79,6 +80,7 @@ import (
 	"github.com/juju/juju/watcher"
 	jworker "github.com/juju/juju/worker"
 	"github.com/juju/juju/worker/apicaller"
+	"github.com/juju/juju/worker/backupscheduler"
 	"github.com/juju/juju/worker/catacomb"
 	"github.com/juju/juju/worker/certupdater"
 	"github.com/juju/juju/worker/conv2state"
@@ -1211,6 +1213,18 @@ func (a *MachineAgent) startStateWorkers(
 			a.startWorkerAfterUpgrade(singularRunner, "txnpruner", func() (worker.Worker, error) {
 				return txnpruner.New(st, time.Hour, clock.WallClock), nil
 			})
+
+			a.startWorkerAfterUpgrade(singularRunner, "backupscheduler", func() (worker.Worker, error) {
+				paths := backups.Paths{
+					DataDir: agentConfig.DataDir(),
+					LogsDir: agentConfig.LogDir(),
+				}
+				return backupscheduler.New(backupscheduler.Config{
+					Backend: st,
+					Backups: backupscheduler.NewStateBackups(st, paths, a.machineId),
+					Clock:   clock.WallClock,
+				})
+			})
 		default:
 			return nil, errors.Errorf("unknown job type %q", job)
 		}
@@ -1245,6 +1259,8 @@ func (a *MachineAgent) startModelWorkers(controllerUUID, modelUUID string) (work
 		CharmRevisionUpdateInterval: 24 * time.Hour,
 		InstPollerAggregationDelay:  3 * time.Second,
 		StatusHistoryPrunerInterval: 5 * time.Minute,
+		ActionPrunerInterval:        5 * time.Minute,
+		ActionSchedulerInterval:     time.Minute,
 		NewEnvironFunc:              newEnvirons,
 		NewMigrationMaster:          migrationmaster.NewWorker,
 	})
@@ -18,6 +18,8 @@ import (
 	"github.com/juju/juju/core/life"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/worker/actionpruner"
+	"github.com/juju/juju/worker/actionscheduler"
 	"github.com/juju/juju/worker/agent"
 	"github.com/juju/juju/worker/apicaller"
 	"github.com/juju/juju/worker/apiconfigwatcher"
@@ -87,6 +89,14 @@ type ManifoldsConfig struct {
 	// behaviour.
 	StatusHistoryPrunerInterval time.Duration
 
+	// ActionPrunerInterval controls how often completed actions
+	// are pruned.
+	ActionPrunerInterval time.Duration
+
+	// ActionSchedulerInterval controls how often the controller checks
+	// for scheduled actions that are due to be enqueued.
+	ActionSchedulerInterval time.Duration
+
 	// NewEnvironFunc is a function opens a provider "environment"
 	// (typically environs.New).
 	NewEnvironFunc environs.NewEnvironFunc
@@ -300,6 +310,21 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			NewFacade:     statushistorypruner.NewFacade,
 			PruneInterval: config.StatusHistoryPrunerInterval,
 		})),
+		actionPrunerName: ifNotMigrating(actionpruner.Manifold(actionpruner.ManifoldConfig{
+			APICallerName: apiCallerName,
+			EnvironName:   environTrackerName,
+			ClockName:     clockName,
+			NewWorker:     actionpruner.New,
+			NewFacade:     actionpruner.NewFacade,
+			PruneInterval: config.ActionPrunerInterval,
+		})),
+		actionSchedulerName: ifNotMigrating(actionscheduler.Manifold(actionscheduler.ManifoldConfig{
+			APICallerName: apiCallerName,
+			ClockName:     clockName,
+			Interval:      config.ActionSchedulerInterval,
+			NewWorker:     actionscheduler.New,
+			NewFacade:     actionscheduler.NewFacade,
+		})),
 		machineUndertakerName: ifNotMigrating(machineundertaker.Manifold(machineundertaker.ManifoldConfig{
 			APICallerName: apiCallerName,
 			EnvironName:   environTrackerName,
@@ -311,6 +336,10 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 				Name:   "juju-log-forward",
 				OpenFn: sinks.OpenSyslog,
 			}},
+			AuditSinks: []logforwarder.LogSinkSpec{{
+				Name:   "juju-audit-forward",
+				OpenFn: sinks.OpenSyslog,
+			}},
 		})),
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
@@ -424,6 +453,8 @@ const (
 	metricWorkerName         = "metric-worker"
 	stateCleanerName         = "state-cleaner"
 	statusHistoryPrunerName  = "status-history-pruner"
+	actionPrunerName         = "action-pruner"
+	actionSchedulerName      = "action-scheduler"
 	machineUndertakerName    = "machine-undertaker"
 	remoteRelationsName      = "remote-relations"
 	logForwarderName         = "log-forwarder"
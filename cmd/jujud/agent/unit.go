@@ -29,6 +29,7 @@ import (
 	"github.com/juju/juju/worker/dependency"
 	"github.com/juju/juju/worker/introspection"
 	"github.com/juju/juju/worker/logsender"
+	uniterworker "github.com/juju/juju/worker/uniter"
 )
 
 var (
@@ -58,6 +59,7 @@ type UnitAgent struct {
 	initialUpgradeCheckComplete chan struct{}
 
 	prometheusRegistry *prometheus.Registry
+	uniterMetrics      *uniterworker.Metrics
 }
 
 // NewUnitAgent creates a new UnitAgent value properly initialized.
@@ -66,6 +68,10 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	uniterMetrics := uniterworker.NewMetrics()
+	if err := prometheusRegistry.Register(uniterMetrics); err != nil {
+		return nil, errors.Annotate(err, "registering uniter collector")
+	}
 	return &UnitAgent{
 		AgentConf:        NewAgentConf(""),
 		configChangedVal: voyeur.NewValue(true),
@@ -73,6 +79,7 @@ func NewUnitAgent(ctx *cmd.Context, bufferedLogger *logsender.BufferedLogWriter)
 		initialUpgradeCheckComplete: make(chan struct{}),
 		bufferedLogger:              bufferedLogger,
 		prometheusRegistry:          prometheusRegistry,
+		uniterMetrics:               uniterMetrics,
 	}, nil
 }
 
@@ -168,6 +175,7 @@ func (a *UnitAgent) APIWorkers() (worker.Worker, error) {
 		AgentConfigChanged:   a.configChangedVal,
 		ValidateMigration:    a.validateMigration,
 		PrometheusRegisterer: a.prometheusRegistry,
+		UniterMetrics:        a.uniterMetrics,
 	})
 
 	config := dependency.EngineConfig{
@@ -64,6 +64,10 @@ type ManifoldsConfig struct {
 	// PrometheusRegisterer is a prometheus.Registerer that may be used
 	// by workers to register Prometheus metric collectors.
 	PrometheusRegisterer prometheus.Registerer
+
+	// UniterMetrics records the hooks and actions run by the uniter.
+	// It should already be registered with PrometheusRegisterer.
+	UniterMetrics *uniter.Metrics
 }
 
 // Manifolds returns a set of co-configured manifolds covering the various
@@ -229,6 +233,7 @@ func Manifolds(config ManifoldsConfig) dependency.Manifolds {
 			CharmDirName:          charmDirName,
 			HookRetryStrategyName: hookRetryStrategyName,
 			TranslateResolverErr:  uniter.TranslateFortressErrors,
+			Metrics:               config.UniterMetrics,
 		})),
 
 		// TODO (mattyw) should be added to machine agent.
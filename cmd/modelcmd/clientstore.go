@@ -76,3 +76,27 @@ func (s QualifyingClientStore) RemoveModel(controllerName, modelName string) err
 	}
 	return s.ClientStore.RemoveModel(controllerName, modelName)
 }
+
+// Implements jujuclient.JumpHostsUpdater.
+func (s QualifyingClientStore) UpdateJumpHosts(controllerName, modelName string, hosts []string) error {
+	if modelName != "" {
+		qualifiedModelName, err := s.QualifiedModelName(controllerName, modelName)
+		if err != nil {
+			return errors.Annotatef(err, "updating jump hosts for model %q", modelName)
+		}
+		modelName = qualifiedModelName
+	}
+	return s.ClientStore.UpdateJumpHosts(controllerName, modelName, hosts)
+}
+
+// Implements jujuclient.JumpHostsGetter.
+func (s QualifyingClientStore) JumpHosts(controllerName, modelName string) ([]string, error) {
+	if modelName != "" {
+		qualifiedModelName, err := s.QualifiedModelName(controllerName, modelName)
+		if err != nil {
+			return nil, errors.Annotatef(err, "getting jump hosts for model %q", modelName)
+		}
+		modelName = qualifiedModelName
+	}
+	return s.ClientStore.JumpHosts(controllerName, modelName)
+}
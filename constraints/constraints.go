@@ -32,6 +32,10 @@ const (
 	InstanceType = "instance-type"
 	Spaces       = "spaces"
 	VirtType     = "virt-type"
+	Zones        = "zones"
+
+	RootDiskSource   = "root-disk-source"
+	AllocatePublicIP = "allocate-public-ip"
 )
 
 // Value describes a user's requirements of the hardware on which units
@@ -85,6 +89,23 @@ type Value struct {
 	// VirtType, if not nil or empty, indicates that a machine must run the named
 	// virtual type. Only valid for clouds with multi-hypervisor support.
 	VirtType *string `json:"virt-type,omitempty" yaml:"virt-type,omitempty"`
+
+	// Zones, if not nil, holds a list of availability zones limiting where
+	// the machine can be located. An empty list is treated the same as a
+	// nil (unspecified) list, except an empty list will override any
+	// default zones, where a nil list will not.
+	Zones *[]string `json:"zones,omitempty" yaml:"zones,omitempty"`
+
+	// RootDiskSource, if not nil or empty, indicates where the machine's
+	// root disk should be provisioned: the name of a storage pool, or a
+	// provider-specific source such as a volume type. Only valid for
+	// clouds whose root disks are configurable at instance startup time.
+	RootDiskSource *string `json:"root-disk-source,omitempty" yaml:"root-disk-source,omitempty"`
+
+	// AllocatePublicIP, if not nil, indicates whether the machine must
+	// be allocated a public IP address. If nil, the provider's default
+	// behaviour applies.
+	AllocatePublicIP *bool `json:"allocate-public-ip,omitempty" yaml:"allocate-public-ip,omitempty"`
 }
 
 var rawAliases = map[string]string{
@@ -180,6 +201,24 @@ func (v *Value) HasVirtType() bool {
 	return v.VirtType != nil && *v.VirtType != ""
 }
 
+// HasZones returns true if the constraints.Value limits the availability
+// zones in which a machine may be located.
+func (v *Value) HasZones() bool {
+	return v.Zones != nil && len(*v.Zones) > 0
+}
+
+// HasRootDiskSource returns true if the constraints.Value specifies
+// a source for the root disk.
+func (v *Value) HasRootDiskSource() bool {
+	return v.RootDiskSource != nil && *v.RootDiskSource != ""
+}
+
+// HasAllocatePublicIP returns true if the constraints.Value specifies
+// whether a public IP address is to be allocated.
+func (v *Value) HasAllocatePublicIP() bool {
+	return v.AllocatePublicIP != nil
+}
+
 // String expresses a constraints.Value in the language in which it was specified.
 func (v Value) String() string {
 	var strs []string
@@ -223,6 +262,16 @@ func (v Value) String() string {
 	if v.VirtType != nil {
 		strs = append(strs, "virt-type="+string(*v.VirtType))
 	}
+	if v.Zones != nil {
+		s := strings.Join(*v.Zones, ",")
+		strs = append(strs, "zones="+s)
+	}
+	if v.RootDiskSource != nil {
+		strs = append(strs, "root-disk-source="+*v.RootDiskSource)
+	}
+	if v.AllocatePublicIP != nil {
+		strs = append(strs, "allocate-public-ip="+strconv.FormatBool(*v.AllocatePublicIP))
+	}
 	return strings.Join(strs, " ")
 }
 
@@ -264,6 +313,17 @@ func (v Value) GoString() string {
 	if v.VirtType != nil {
 		values = append(values, fmt.Sprintf("VirtType: %q", *v.VirtType))
 	}
+	if v.Zones != nil && *v.Zones != nil {
+		values = append(values, fmt.Sprintf("Zones: %q", *v.Zones))
+	} else if v.Zones != nil {
+		values = append(values, "Zones: (*[]string)(nil)")
+	}
+	if v.RootDiskSource != nil {
+		values = append(values, fmt.Sprintf("RootDiskSource: %q", *v.RootDiskSource))
+	}
+	if v.AllocatePublicIP != nil {
+		values = append(values, fmt.Sprintf("AllocatePublicIP: %v", *v.AllocatePublicIP))
+	}
 	return fmt.Sprintf("{%s}", strings.Join(values, ", "))
 }
 
@@ -420,6 +480,12 @@ func (v *Value) setRaw(name, str string) error {
 		err = v.setSpaces(str)
 	case VirtType:
 		err = v.setVirtType(str)
+	case Zones:
+		err = v.setZones(str)
+	case RootDiskSource:
+		err = v.setRootDiskSource(str)
+	case AllocatePublicIP:
+		err = v.setAllocatePublicIP(str)
 	default:
 		return errors.Errorf("unknown constraint %q", name)
 	}
@@ -483,6 +549,12 @@ func (v *Value) UnmarshalYAML(unmarshal func(interface{}) error) error {
 			}
 		case VirtType:
 			v.VirtType = &vstr
+		case Zones:
+			v.Zones, err = parseYamlStrings("zones", val)
+		case RootDiskSource:
+			v.RootDiskSource = &vstr
+		case AllocatePublicIP:
+			v.AllocatePublicIP, err = parseBool(vstr)
 		default:
 			return errors.Errorf("unknown constraint value: %v", k)
 		}
@@ -607,6 +679,38 @@ func (v *Value) setVirtType(str string) error {
 	return nil
 }
 
+func (v *Value) setZones(str string) error {
+	if v.Zones != nil {
+		return errors.Errorf("already set")
+	}
+	v.Zones = parseCommaDelimited(str)
+	return nil
+}
+
+func (v *Value) setRootDiskSource(str string) error {
+	if v.RootDiskSource != nil {
+		return errors.Errorf("already set")
+	}
+	v.RootDiskSource = &str
+	return nil
+}
+
+func (v *Value) setAllocatePublicIP(str string) (err error) {
+	if v.AllocatePublicIP != nil {
+		return errors.Errorf("already set")
+	}
+	v.AllocatePublicIP, err = parseBool(str)
+	return
+}
+
+func parseBool(str string) (*bool, error) {
+	value, err := strconv.ParseBool(str)
+	if err != nil {
+		return nil, errors.Errorf("must be true or false")
+	}
+	return &value, nil
+}
+
 func parseUint64(str string) (*uint64, error) {
 	var value uint64
 	if str != "" {
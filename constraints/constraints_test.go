@@ -313,6 +313,56 @@ var parseConstraintsTests = []struct {
 		err:     `bad "virt-type" constraint: already set`,
 	},
 
+	// zones
+	{
+		summary: "single zone",
+		args:    []string{"zones=az1"},
+	}, {
+		summary: "multiple zones",
+		args:    []string{"zones=az1,az2"},
+	}, {
+		summary: "no zones",
+		args:    []string{"zones="},
+	}, {
+		summary: "double set zones",
+		args:    []string{"zones=az1", "zones=az2"},
+		err:     `bad "zones" constraint: already set`,
+	},
+
+	// "root-disk-source" in detail.
+	{
+		summary: "set root-disk-source",
+		args:    []string{"root-disk-source=ebs-ssd"},
+	}, {
+		summary: "set root-disk-source empty",
+		args:    []string{"root-disk-source="},
+	}, {
+		summary: "double set root-disk-source",
+		args:    []string{"root-disk-source=volume", "root-disk-source=local"},
+		err:     `bad "root-disk-source" constraint: already set`,
+	},
+
+	// "allocate-public-ip" in detail.
+	{
+		summary: "set allocate-public-ip true",
+		args:    []string{"allocate-public-ip=true"},
+	}, {
+		summary: "set allocate-public-ip false",
+		args:    []string{"allocate-public-ip=false"},
+	}, {
+		summary: "set allocate-public-ip empty",
+		args:    []string{"allocate-public-ip="},
+		err:     `bad "allocate-public-ip" constraint: must be true or false`,
+	}, {
+		summary: "set allocate-public-ip invalid",
+		args:    []string{"allocate-public-ip=maybe"},
+		err:     `bad "allocate-public-ip" constraint: must be true or false`,
+	}, {
+		summary: "double set allocate-public-ip",
+		args:    []string{"allocate-public-ip=true", "allocate-public-ip=false"},
+		err:     `bad "allocate-public-ip" constraint: already set`,
+	},
+
 	// Everything at once.
 	{
 		summary: "kitchen sink together",
@@ -469,6 +519,10 @@ func uint64p(i uint64) *uint64 {
 	return &i
 }
 
+func boolp(b bool) *bool {
+	return &b
+}
+
 func strp(s string) *string {
 	return &s
 }
@@ -510,16 +564,26 @@ var constraintsRoundtripTests = []roundTrip{
 	{"Spaces3", constraints.Value{Spaces: &[]string{"space1", "^space2"}}},
 	{"InstanceType1", constraints.Value{InstanceType: strp("")}},
 	{"InstanceType2", constraints.Value{InstanceType: strp("foo")}},
+	{"Zones1", constraints.Value{Zones: nil}},
+	{"Zones2", constraints.Value{Zones: &[]string{}}},
+	{"Zones3", constraints.Value{Zones: &[]string{"az1", "az2"}}},
+	{"RootDiskSource1", constraints.Value{RootDiskSource: strp("")}},
+	{"RootDiskSource2", constraints.Value{RootDiskSource: strp("volume")}},
+	{"AllocatePublicIP1", constraints.Value{AllocatePublicIP: boolp(true)}},
+	{"AllocatePublicIP2", constraints.Value{AllocatePublicIP: boolp(false)}},
 	{"All", constraints.Value{
-		Arch:         strp("i386"),
-		Container:    ctypep("lxd"),
-		CpuCores:     uint64p(4096),
-		CpuPower:     uint64p(9001),
-		Mem:          uint64p(18000000000),
-		RootDisk:     uint64p(24000000000),
-		Tags:         &[]string{"foo", "bar"},
-		Spaces:       &[]string{"space1", "^space2"},
-		InstanceType: strp("foo"),
+		Arch:             strp("i386"),
+		Container:        ctypep("lxd"),
+		CpuCores:         uint64p(4096),
+		CpuPower:         uint64p(9001),
+		Mem:              uint64p(18000000000),
+		RootDisk:         uint64p(24000000000),
+		Tags:             &[]string{"foo", "bar"},
+		Spaces:           &[]string{"space1", "^space2"},
+		InstanceType:     strp("foo"),
+		Zones:            &[]string{"az1", "az2"},
+		RootDiskSource:   strp("volume"),
+		AllocatePublicIP: boolp(false),
 	}},
 }
 
@@ -597,6 +661,33 @@ func (s *ConstraintsSuite) TestHasInstanceType(c *gc.C) {
 	c.Check(cons.HasInstanceType(), jc.IsTrue)
 }
 
+func (s *ConstraintsSuite) TestHasZones(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=")
+	c.Check(cons.HasZones(), jc.IsFalse)
+	cons = constraints.MustParse("zones=az1,az2")
+	c.Check(cons.HasZones(), jc.IsTrue)
+	c.Check(*cons.Zones, jc.DeepEquals, []string{"az1", "az2"})
+}
+
+func (s *ConstraintsSuite) TestHasRootDiskSource(c *gc.C) {
+	cons := constraints.MustParse("root-disk=8G")
+	c.Check(cons.HasRootDiskSource(), jc.IsFalse)
+	cons = constraints.MustParse("root-disk-source=")
+	c.Check(cons.HasRootDiskSource(), jc.IsFalse)
+	cons = constraints.MustParse("root-disk-source=volume")
+	c.Check(cons.HasRootDiskSource(), jc.IsTrue)
+}
+
+func (s *ConstraintsSuite) TestHasAllocatePublicIP(c *gc.C) {
+	cons := constraints.MustParse("arch=amd64")
+	c.Check(cons.HasAllocatePublicIP(), jc.IsFalse)
+	cons = constraints.MustParse("allocate-public-ip=false")
+	c.Check(cons.HasAllocatePublicIP(), jc.IsTrue)
+	c.Check(*cons.AllocatePublicIP, jc.IsFalse)
+}
+
 const initialWithoutCons = "root-disk=8G mem=4G arch=amd64 cpu-power=1000 cores=4 spaces=space1,^space2 tags=foo container=lxd instance-type=bar"
 
 var withoutTests = []struct {
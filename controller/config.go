@@ -15,6 +15,7 @@ import (
 	"gopkg.in/macaroon-bakery.v1/bakery"
 
 	"github.com/juju/juju/cert"
+	"github.com/juju/juju/logfwd/syslog"
 )
 
 const (
@@ -82,6 +83,43 @@ const (
 	// MaxTxnLogSize is the maximum size the of capped txn log collection, eg "10M"
 	MaxTxnLogSize = "max-txn-log-size"
 
+	// MaxAuditLogSize is the maximum size of the capped audit log
+	// collection, eg "300M"
+	MaxAuditLogSize = "max-audit-log-size"
+
+	// BackupSchedule is the interval between scheduled backups of the
+	// controller, eg "24h". Scheduled backups are disabled if unset or
+	// zero.
+	BackupSchedule = "backup-schedule"
+
+	// BackupRetentionCount is the number of scheduled backups to keep.
+	// Zero means there is no limit.
+	BackupRetentionCount = "backup-retention-count"
+
+	// BackupRetentionAge is the maximum age of scheduled backups before
+	// they are pruned, eg "168h". Zero means there is no limit.
+	BackupRetentionAge = "backup-retention-age"
+
+	// AuditForwardEnabled determines whether audit entries are
+	// forwarded to a syslog server.
+	AuditForwardEnabled = "auditforward-enabled"
+
+	// AuditFwdSyslogHost sets the hostname:port of the syslog server
+	// to which audit entries are forwarded.
+	AuditFwdSyslogHost = "audit-syslog-host"
+
+	// AuditFwdSyslogCACert sets the certificate of the CA that signed
+	// the audit syslog server certificate.
+	AuditFwdSyslogCACert = "audit-syslog-ca-cert"
+
+	// AuditFwdSyslogClientCert sets the client certificate for audit
+	// forwarding.
+	AuditFwdSyslogClientCert = "audit-syslog-client-cert"
+
+	// AuditFwdSyslogClientKey sets the client key for audit
+	// forwarding.
+	AuditFwdSyslogClientKey = "audit-syslog-client-key"
+
 	// Attribute Defaults
 
 	// DefaultAuditingEnabled contains the default value for the
@@ -110,6 +148,10 @@ const (
 
 	// DefaultMaxTxnLogCollectionMB is the maximum size the txn log collection.
 	DefaultMaxTxnLogCollectionMB = 10 // 10 MB
+
+	// DefaultMaxAuditLogCollectionMB is the maximum size of the audit
+	// log collection.
+	DefaultMaxAuditLogCollectionMB = 300 // 300 MB
 )
 
 // ControllerOnlyConfigAttributes are attributes which are only relevant
@@ -129,6 +171,15 @@ var ControllerOnlyConfigAttributes = []string{
 	MaxLogsSize,
 	MaxLogsAge,
 	MaxTxnLogSize,
+	MaxAuditLogSize,
+	BackupSchedule,
+	BackupRetentionCount,
+	BackupRetentionAge,
+	AuditForwardEnabled,
+	AuditFwdSyslogHost,
+	AuditFwdSyslogCACert,
+	AuditFwdSyslogClientCert,
+	AuditFwdSyslogClientKey,
 }
 
 // ControllerOnlyAttribute returns true if the specified attribute name
@@ -313,6 +364,61 @@ func (c Config) MaxTxnLogSizeMB() int {
 	return int(val)
 }
 
+// MaxAuditLogSizeMB is the maximum size in MiB of the audit log
+// collection.
+func (c Config) MaxAuditLogSizeMB() int {
+	if v, ok := c[MaxAuditLogSize].(string); ok {
+		// Value has already been validated.
+		val, _ := utils.ParseSize(v)
+		return int(val)
+	}
+	return DefaultMaxAuditLogCollectionMB
+}
+
+// BackupSchedule is the interval between scheduled backups of the
+// controller. A zero value means scheduled backups are disabled.
+func (c Config) BackupSchedule() time.Duration {
+	return c.optionalDuration(BackupSchedule)
+}
+
+// BackupRetentionCount is the number of scheduled backups to keep.
+// A zero value means there is no limit.
+func (c Config) BackupRetentionCount() int {
+	value, _ := c[BackupRetentionCount].(int)
+	return value
+}
+
+// BackupRetentionAge is the maximum age of scheduled backups before
+// they are pruned. A zero value means there is no limit.
+func (c Config) BackupRetentionAge() time.Duration {
+	return c.optionalDuration(BackupRetentionAge)
+}
+
+// AuditFwdSyslog returns the syslog forwarding config for audit
+// entries, and whether audit forwarding has been configured.
+func (c Config) AuditFwdSyslog() (*syslog.RawConfig, bool) {
+	enabled, ok := c[AuditForwardEnabled].(bool)
+	if !ok {
+		return nil, false
+	}
+	return &syslog.RawConfig{
+		Enabled:    enabled,
+		Host:       c.asString(AuditFwdSyslogHost),
+		CACert:     c.asString(AuditFwdSyslogCACert),
+		ClientCert: c.asString(AuditFwdSyslogClientCert),
+		ClientKey:  c.asString(AuditFwdSyslogClientKey),
+	}, true
+}
+
+func (c Config) optionalDuration(key string) time.Duration {
+	if v, ok := c[key].(string); ok {
+		// Value has already been validated.
+		val, _ := time.ParseDuration(v)
+		return val
+	}
+	return 0
+}
+
 // Validate ensures that config is a valid configuration.
 func Validate(c Config) error {
 	if v, ok := c[IdentityPublicKey].(string); ok {
@@ -372,6 +478,34 @@ func Validate(c Config) error {
 		}
 	}
 
+	if v, ok := c[MaxAuditLogSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max audit log size in configuration")
+		}
+	}
+
+	for _, key := range []string{BackupSchedule, BackupRetentionAge} {
+		if v, ok := c[key].(string); ok {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				return errors.Annotatef(err, "invalid %s in configuration", key)
+			}
+			if d < 0 {
+				return errors.Errorf("invalid %s in configuration: negative duration %q", key, v)
+			}
+		}
+	}
+
+	if v, ok := c[BackupRetentionCount].(int); ok && v < 0 {
+		return errors.Errorf("invalid %s in configuration: expected a non-negative number, got %d", BackupRetentionCount, v)
+	}
+
+	if afCfg, ok := c.AuditFwdSyslog(); ok {
+		if err := afCfg.Validate(); err != nil {
+			return errors.Annotate(err, "invalid audit syslog forwarding config")
+		}
+	}
+
 	return nil
 }
 
@@ -382,31 +516,49 @@ func GenerateControllerCertAndKey(caCert, caKey string, hostAddresses []string)
 }
 
 var configChecker = schema.FieldMap(schema.Fields{
-	AuditingEnabled:         schema.Bool(),
-	APIPort:                 schema.ForceInt(),
-	StatePort:               schema.ForceInt(),
-	IdentityURL:             schema.String(),
-	IdentityPublicKey:       schema.String(),
-	SetNUMAControlPolicyKey: schema.Bool(),
-	AutocertURLKey:          schema.String(),
-	AutocertDNSNameKey:      schema.String(),
-	AllowModelAccessKey:     schema.Bool(),
-	MongoMemoryProfile:      schema.String(),
-	MaxLogsAge:              schema.String(),
-	MaxLogsSize:             schema.String(),
-	MaxTxnLogSize:           schema.String(),
+	AuditingEnabled:          schema.Bool(),
+	APIPort:                  schema.ForceInt(),
+	StatePort:                schema.ForceInt(),
+	IdentityURL:              schema.String(),
+	IdentityPublicKey:        schema.String(),
+	SetNUMAControlPolicyKey:  schema.Bool(),
+	AutocertURLKey:           schema.String(),
+	AutocertDNSNameKey:       schema.String(),
+	AllowModelAccessKey:      schema.Bool(),
+	MongoMemoryProfile:       schema.String(),
+	MaxLogsAge:               schema.String(),
+	MaxLogsSize:              schema.String(),
+	MaxTxnLogSize:            schema.String(),
+	MaxAuditLogSize:          schema.String(),
+	BackupSchedule:           schema.String(),
+	BackupRetentionCount:     schema.ForceInt(),
+	BackupRetentionAge:       schema.String(),
+	AuditForwardEnabled:      schema.Bool(),
+	AuditFwdSyslogHost:       schema.String(),
+	AuditFwdSyslogCACert:     schema.String(),
+	AuditFwdSyslogClientCert: schema.String(),
+	AuditFwdSyslogClientKey:  schema.String(),
 }, schema.Defaults{
-	APIPort:                 DefaultAPIPort,
-	AuditingEnabled:         DefaultAuditingEnabled,
-	StatePort:               DefaultStatePort,
-	IdentityURL:             schema.Omit,
-	IdentityPublicKey:       schema.Omit,
-	SetNUMAControlPolicyKey: DefaultNUMAControlPolicy,
-	AutocertURLKey:          schema.Omit,
-	AutocertDNSNameKey:      schema.Omit,
-	AllowModelAccessKey:     schema.Omit,
-	MongoMemoryProfile:      schema.Omit,
-	MaxLogsAge:              fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
-	MaxLogsSize:             fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
-	MaxTxnLogSize:           fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	APIPort:                  DefaultAPIPort,
+	AuditingEnabled:          DefaultAuditingEnabled,
+	StatePort:                DefaultStatePort,
+	IdentityURL:              schema.Omit,
+	IdentityPublicKey:        schema.Omit,
+	SetNUMAControlPolicyKey:  DefaultNUMAControlPolicy,
+	AutocertURLKey:           schema.Omit,
+	AutocertDNSNameKey:       schema.Omit,
+	AllowModelAccessKey:      schema.Omit,
+	MongoMemoryProfile:       schema.Omit,
+	MaxLogsAge:               fmt.Sprintf("%vh", DefaultMaxLogsAgeDays*24),
+	MaxLogsSize:              fmt.Sprintf("%vM", DefaultMaxLogCollectionMB),
+	MaxTxnLogSize:            fmt.Sprintf("%vM", DefaultMaxTxnLogCollectionMB),
+	MaxAuditLogSize:          schema.Omit,
+	BackupSchedule:           schema.Omit,
+	BackupRetentionCount:     schema.Omit,
+	BackupRetentionAge:       schema.Omit,
+	AuditForwardEnabled:      schema.Omit,
+	AuditFwdSyslogHost:       schema.Omit,
+	AuditFwdSyslogCACert:     schema.Omit,
+	AuditFwdSyslogClientCert: schema.Omit,
+	AuditFwdSyslogClientKey:  schema.Omit,
 })
@@ -15,6 +15,7 @@ import (
 
 	"github.com/juju/juju/cert"
 	"github.com/juju/juju/controller"
+	"github.com/juju/juju/logfwd/syslog"
 	"github.com/juju/juju/testing"
 )
 
@@ -121,6 +122,52 @@ var validateTests = []struct {
 		controller.CACertKey:         testing.CACert,
 	},
 	expectError: `invalid identity public key: wrong length for base64 key, got 3 want 32`,
+}, {
+	about: "invalid max audit log size",
+	config: controller.Config{
+		controller.MaxAuditLogSize: "abc",
+		controller.CACertKey:       testing.CACert,
+	},
+	expectError: `invalid max audit log size in configuration: expected a non-negative number, got "abc"`,
+}, {
+	about: "invalid backup schedule",
+	config: controller.Config{
+		controller.BackupSchedule: "daily",
+		controller.CACertKey:      testing.CACert,
+	},
+	expectError: `invalid backup-schedule in configuration: time: invalid duration daily`,
+}, {
+	about: "negative backup retention age",
+	config: controller.Config{
+		controller.BackupRetentionAge: "-1h",
+		controller.CACertKey:          testing.CACert,
+	},
+	expectError: `invalid backup-retention-age in configuration: negative duration "-1h"`,
+}, {
+	about: "negative backup retention count",
+	config: controller.Config{
+		controller.BackupRetentionCount: -1,
+		controller.CACertKey:            testing.CACert,
+	},
+	expectError: `invalid backup-retention-count in configuration: expected a non-negative number, got -1`,
+}, {
+	about: "audit forwarding without host",
+	config: controller.Config{
+		controller.AuditForwardEnabled: true,
+		controller.CACertKey:           testing.CACert,
+	},
+	expectError: `invalid audit syslog forwarding config: Host "" not valid`,
+}, {
+	about: "invalid audit syslog ca cert",
+	config: controller.Config{
+		controller.AuditForwardEnabled:      true,
+		controller.AuditFwdSyslogHost:       "localhost:1234",
+		controller.AuditFwdSyslogCACert:     "abc",
+		controller.AuditFwdSyslogClientCert: testing.ServerCert,
+		controller.AuditFwdSyslogClientKey:  testing.ServerKey,
+		controller.CACertKey:                testing.CACert,
+	},
+	expectError: `invalid audit syslog forwarding config: validating TLS config: parsing CA certificate: no certificates found`,
 }}
 
 func (s *ConfigSuite) TestValidate(c *gc.C) {
@@ -173,3 +220,76 @@ func (s *ConfigSuite) TestTxnLogConfigValue(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(cfg.MaxTxnLogSizeMB(), gc.Equals, 8192)
 }
+
+func (s *ConfigSuite) TestAuditLogConfigDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxAuditLogSizeMB(), gc.Equals, 300)
+}
+
+func (s *ConfigSuite) TestAuditLogConfigValue(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"max-audit-log-size": "1G",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.MaxAuditLogSizeMB(), gc.Equals, 1024)
+}
+
+func (s *ConfigSuite) TestBackupConfigDefaults(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, time.Duration(0))
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 0)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, time.Duration(0))
+}
+
+func (s *ConfigSuite) TestBackupConfigValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"backup-schedule":        "24h",
+			"backup-retention-count": 7,
+			"backup-retention-age":   "168h",
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(cfg.BackupSchedule(), gc.Equals, 24*time.Hour)
+	c.Assert(cfg.BackupRetentionCount(), gc.Equals, 7)
+	c.Assert(cfg.BackupRetentionAge(), gc.Equals, 168*time.Hour)
+}
+
+func (s *ConfigSuite) TestAuditFwdSyslogDefault(c *gc.C) {
+	cfg, err := controller.NewConfig(testing.ControllerTag.Id(), testing.CACert, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := cfg.AuditFwdSyslog()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *ConfigSuite) TestAuditFwdSyslogValues(c *gc.C) {
+	cfg, err := controller.NewConfig(
+		testing.ControllerTag.Id(),
+		testing.CACert,
+		map[string]interface{}{
+			"auditforward-enabled":     true,
+			"audit-syslog-host":        "10.0.0.2:12345",
+			"audit-syslog-ca-cert":     testing.CACert,
+			"audit-syslog-client-cert": testing.ServerCert,
+			"audit-syslog-client-key":  testing.ServerKey,
+		},
+	)
+	c.Assert(err, jc.ErrorIsNil)
+	afCfg, ok := cfg.AuditFwdSyslog()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(afCfg, jc.DeepEquals, &syslog.RawConfig{
+		Enabled:    true,
+		Host:       "10.0.0.2:12345",
+		CACert:     testing.CACert,
+		ClientCert: testing.ServerCert,
+		ClientKey:  testing.ServerKey,
+	})
+}
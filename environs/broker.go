@@ -64,6 +64,14 @@ type StartInstanceParams struct {
 	// to specific availability zones.
 	VolumeAttachments []storage.VolumeAttachmentParams
 
+	// RootDisk, if non-nil, holds the storage provider type and pool
+	// attributes identified by the root-disk-source constraint, when
+	// that constraint names a storage pool or storage provider. Only
+	// the Provider and Attributes fields are set. If the constraint
+	// is set but RootDisk is nil, the provider may interpret the
+	// constraint's value directly.
+	RootDisk *storage.VolumeParams
+
 	// NetworkInfo is an optional list of network interface details,
 	// necessary to configure on the instance.
 	NetworkInfo []network.InterfaceInfo
@@ -150,6 +150,14 @@ const (
 	// collection can grow to before it is pruned, eg "5M"
 	MaxStatusHistorySize = "max-status-history-size"
 
+	// MaxActionResultsAge is the maximum age of completed actions and
+	// their results to keep when pruning, eg "336h"
+	MaxActionResultsAge = "max-action-results-age"
+
+	// MaxActionResultsSize is the maximum size the actions collection
+	// can grow to before completed actions are pruned, eg "5G"
+	MaxActionResultsSize = "max-action-results-size"
+
 	// UpdateStatusHookInterval is how often to run the update-status hook.
 	UpdateStatusHookInterval = "update-status-hook-interval"
 
@@ -157,6 +165,11 @@ const (
 	// originates if the model is deployed such that NAT or similar is in use.
 	EgressCidrs = "egress-cidrs"
 
+	// EgressDefaultDenyKey is the key for whether outbound traffic from
+	// the model's machines is denied unless allowed by an application's
+	// egress rules.
+	EgressDefaultDenyKey = "egress-default-deny"
+
 	//
 	// Deprecated Settings Attributes
 	//
@@ -312,6 +325,12 @@ const (
 	// DefaultStatusHistorySize is the default value for MaxStatusHistorySize.
 	DefaultStatusHistorySize = "5G"
 
+	// DefaultActionResultsAge is the default value for MaxActionResultsAge.
+	DefaultActionResultsAge = "336h" // 2 weeks
+
+	// DefaultActionResultsSize is the default value for MaxActionResultsSize.
+	DefaultActionResultsSize = "5G"
+
 	// DefaultUpdateStatusHookInterval is the default value for UpdateStatusHookInterval
 	DefaultUpdateStatusHookInterval = "5m"
 )
@@ -355,6 +374,7 @@ var defaultConfigValues = map[string]interface{}{
 	TransmitVendorMetricsKey:   true,
 	UpdateStatusHookInterval:   DefaultUpdateStatusHookInterval,
 	EgressCidrs:                "",
+	EgressDefaultDenyKey:       false,
 
 	// Image and agent streams and URLs.
 	"image-stream":       "released",
@@ -379,6 +399,10 @@ var defaultConfigValues = map[string]interface{}{
 	// Status history settings
 	MaxStatusHistoryAge:  DefaultStatusHistoryAge,
 	MaxStatusHistorySize: DefaultStatusHistorySize,
+
+	// Action pruning settings
+	MaxActionResultsAge:  DefaultActionResultsAge,
+	MaxActionResultsSize: DefaultActionResultsSize,
 }
 
 // ConfigDefaults returns the config default values
@@ -509,6 +533,18 @@ func Validate(cfg, old *Config) error {
 		}
 	}
 
+	if v, ok := cfg.defined[MaxActionResultsAge].(string); ok {
+		if _, err := time.ParseDuration(v); err != nil {
+			return errors.Annotate(err, "invalid max action results age in model configuration")
+		}
+	}
+
+	if v, ok := cfg.defined[MaxActionResultsSize].(string); ok {
+		if _, err := utils.ParseSize(v); err != nil {
+			return errors.Annotate(err, "invalid max action results size in model configuration")
+		}
+	}
+
 	if v, ok := cfg.defined[UpdateStatusHookInterval].(string); ok {
 		if f, err := time.ParseDuration(v); err != nil {
 			return errors.Annotate(err, "invalid update status hook interval in model configuration")
@@ -968,6 +1004,22 @@ func (c *Config) MaxStatusHistorySizeMB() uint {
 	return uint(val)
 }
 
+// MaxActionResultsAge is the maximum age of completed actions before
+// they are pruned.
+func (c *Config) MaxActionResultsAge() time.Duration {
+	// Value has already been validated.
+	val, _ := time.ParseDuration(c.mustString(MaxActionResultsAge))
+	return val
+}
+
+// MaxActionResultsSizeMB is the maximum size in MiB which the actions
+// collection can grow to before completed actions are pruned.
+func (c *Config) MaxActionResultsSizeMB() uint {
+	// Value has already been validated.
+	val, _ := utils.ParseSize(c.mustString(MaxActionResultsSize))
+	return uint(val)
+}
+
 // UpdateStatusHookInterval is how often to run the charm
 // update-status hook.
 func (c *Config) UpdateStatusHookInterval() time.Duration {
@@ -984,6 +1036,14 @@ func (c *Config) UpdateStatusHookInterval() time.Duration {
 	return val
 }
 
+// EgressDefaultDeny returns whether outbound traffic from the model's
+// machines is denied unless allowed by an application's egress rules.
+// By default this is false.
+func (c *Config) EgressDefaultDeny() bool {
+	val, _ := c.defined[EgressDefaultDenyKey].(bool)
+	return val
+}
+
 // EgressCidrs are the source addresses from which traffic from this model
 // originates if the model is deployed such that NAT or similar is in use.
 func (c *Config) EgressCidrs() []string {
@@ -1107,8 +1167,11 @@ var alwaysOptional = schema.Defaults{
 	NetBondReconfigureDelayKey:   schema.Omit,
 	MaxStatusHistoryAge:          schema.Omit,
 	MaxStatusHistorySize:         schema.Omit,
+	MaxActionResultsAge:          schema.Omit,
+	MaxActionResultsSize:         schema.Omit,
 	UpdateStatusHookInterval:     schema.Omit,
 	EgressCidrs:                  schema.Omit,
+	EgressDefaultDenyKey:         schema.Omit,
 }
 
 func allowEmpty(attr string) bool {
@@ -1142,6 +1205,7 @@ var immutableAttributes = []string{
 	TypeKey,
 	UUIDKey,
 	"firewall-mode",
+	EgressDefaultDenyKey,
 }
 
 var (
@@ -1490,6 +1554,16 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	MaxActionResultsAge: {
+		Description: "The maximum age for completed actions and their results before they are pruned, in human-readable time format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
+	MaxActionResultsSize: {
+		Description: "The maximum size for the actions collection before completed actions are pruned, in human-readable memory format",
+		Type:        environschema.Tstring,
+		Group:       environschema.EnvironGroup,
+	},
 	UpdateStatusHookInterval: {
 		Description: "How often to run the charm update-status hook, in human-readable time format (default 5m, range 1-60m)",
 		Type:        environschema.Tstring,
@@ -1500,4 +1574,10 @@ data of the store. (default false)`,
 		Type:        environschema.Tstring,
 		Group:       environschema.EnvironGroup,
 	},
+	EgressDefaultDenyKey: {
+		Description: "Whether outbound traffic from machines is denied, except to the controller and as allowed by application egress rules",
+		Type:        environschema.Tbool,
+		Immutable:   true,
+		Group:       environschema.EnvironGroup,
+	},
 }
@@ -1112,6 +1112,21 @@ func (s *ConfigSuite) TestStatusHistoryConfigValues(c *gc.C) {
 	c.Assert(cfg.MaxStatusHistorySizeMB(), gc.Equals, uint(8192))
 }
 
+func (s *ConfigSuite) TestActionResultsConfigDefaults(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, 336*time.Hour)
+	c.Assert(cfg.MaxActionResultsSizeMB(), gc.Equals, uint(5120))
+}
+
+func (s *ConfigSuite) TestActionResultsConfigValues(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{
+		"max-action-results-size": "2G",
+		"max-action-results-age":  "48h",
+	})
+	c.Assert(cfg.MaxActionResultsAge(), gc.Equals, 48*time.Hour)
+	c.Assert(cfg.MaxActionResultsSizeMB(), gc.Equals, uint(2048))
+}
+
 func (s *ConfigSuite) TestUpdateStatusHookIntervalConfigDefault(c *gc.C) {
 	cfg := newTestConfig(c, testing.Attrs{})
 	c.Assert(cfg.UpdateStatusHookInterval(), gc.Equals, 5*time.Minute)
@@ -1131,6 +1146,26 @@ func (s *ConfigSuite) TestEgressCidrs(c *gc.C) {
 	c.Assert(cfg.EgressCidrs(), gc.DeepEquals, []string{"10.0.0.1/32", "192.168.1.1/16"})
 }
 
+func (s *ConfigSuite) TestEgressDefaultDeny(c *gc.C) {
+	cfg := newTestConfig(c, testing.Attrs{})
+	c.Assert(cfg.EgressDefaultDeny(), jc.IsFalse)
+
+	cfg = newTestConfig(c, testing.Attrs{
+		"egress-default-deny": true,
+	})
+	c.Assert(cfg.EgressDefaultDeny(), jc.IsTrue)
+}
+
+func (s *ConfigSuite) TestEgressDefaultDenyImmutable(c *gc.C) {
+	oldCfg := newTestConfig(c, testing.Attrs{})
+	newCfg, err := oldCfg.Apply(map[string]interface{}{
+		"egress-default-deny": true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = config.Validate(newCfg, oldCfg)
+	c.Assert(err, gc.ErrorMatches, "cannot change egress-default-deny from false to true")
+}
+
 func (s *ConfigSuite) TestSchemaNoExtra(c *gc.C) {
 	schema, err := config.Schema(nil)
 	c.Assert(err, gc.IsNil)
@@ -410,6 +410,50 @@ type Firewaller interface {
 	IngressRules() ([]network.IngressRule, error)
 }
 
+// EgressFirewaller exposes methods for managing the ports to which
+// outgoing traffic is allowed, in models where outgoing traffic is
+// denied by default. It may be implemented by Environs whose providers
+// support egress rules.
+type EgressFirewaller interface {
+	// OpenEgressPorts allows outgoing traffic on the given port
+	// ranges for the whole environment. Must only be used if the
+	// environment was setup with the FwGlobal firewall mode.
+	OpenEgressPorts(rules []network.EgressRule) error
+
+	// CloseEgressPorts denies outgoing traffic on the given port
+	// ranges for the whole environment. Must only be used if the
+	// environment was setup with the FwGlobal firewall mode.
+	CloseEgressPorts(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole
+	// environment. Must only be used if the environment was setup
+	// with the FwGlobal firewall mode. As with IngressRules, there
+	// is only one rule for a given port range.
+	EgressRules() ([]network.EgressRule, error)
+}
+
+// ModelFirewaller exposes methods for managing the model-wide ingress
+// rules, such as those for SSH and the API port, which providers open
+// from anywhere when setting up a model's security groups. It may be
+// implemented by Environs whose providers open such rules, so that the
+// model's firewall rules can restrict them.
+type ModelFirewaller interface {
+	// OpenModelPorts opens the given port ranges for the whole model,
+	// regardless of the firewall mode.
+	OpenModelPorts(rules []network.IngressRule) error
+
+	// CloseModelPorts closes the given port ranges for the whole
+	// model, regardless of the firewall mode.
+	CloseModelPorts(rules []network.IngressRule) error
+
+	// ModelIngressRules returns the model-wide ingress rules. Rules
+	// allowing traffic between the model's machines are not included.
+	// As with IngressRules, there is only one rule for a given port
+	// range. If the model's security group has not yet been created,
+	// an error satisfying errors.IsNotFound is returned.
+	ModelIngressRules() ([]network.IngressRule, error)
+}
+
 // InstanceTagger is an interface that can be used for tagging instances.
 type InstanceTagger interface {
 	// TagInstance tags the given instance with the specified tags.
@@ -242,12 +242,16 @@ block:
     it: works
 loop:
   provider: loop
+lvm:
+  provider: lvm
 machinescoped:
   provider: machinescoped
 modelscoped:
   provider: modelscoped
 modelscoped-block:
   provider: modelscoped-block
+nfs:
+  provider: nfs
 rootfs:
   provider: rootfs
 static:
@@ -265,9 +269,11 @@ func (s *cmdStorageSuite) TestListPoolsTabular(c *gc.C) {
 Name               Provider           Attrs
 block              loop               it=works
 loop               loop               
+lvm                lvm                
 machinescoped      machinescoped      
 modelscoped        modelscoped        
 modelscoped-block  modelscoped-block  
+nfs                nfs                
 rootfs             rootfs             
 static             static             
 tmpfs              tmpfs              
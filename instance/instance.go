@@ -57,6 +57,27 @@ type Instance interface {
 	IngressRules(machineId string) ([]network.IngressRule, error)
 }
 
+// EgressFirewaller is implemented by instances whose providers support
+// egress rules, which allow outgoing traffic in models where it is
+// denied by default.
+type EgressFirewaller interface {
+	// OpenEgressPorts allows outgoing traffic on the given port
+	// ranges from the instance, which should have been started
+	// with the given machine id.
+	OpenEgressPorts(machineId string, rules []network.EgressRule) error
+
+	// CloseEgressPorts denies outgoing traffic on the given port
+	// ranges from the instance, which should have been started
+	// with the given machine id.
+	CloseEgressPorts(machineId string, rules []network.EgressRule) error
+
+	// EgressRules returns the set of egress rules for the instance,
+	// which should have been applied to the given machine id. The
+	// rules are returned as sorted by network.SortEgressRules(), with
+	// only one rule for a given port range.
+	EgressRules(machineId string) ([]network.EgressRule, error)
+}
+
 // HardwareCharacteristics represents the characteristics of the instance (if known).
 // Attributes that are nil are unknown or not supported.
 type HardwareCharacteristics struct {
@@ -311,6 +311,20 @@ func (s *store) RemoveController(name string) error {
 		}
 	}
 
+	// Remove jump hosts for the controller.
+	controllerJumpHosts, err := ReadJumpHostsFile(JujuJumpHostsPath())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for _, name := range names {
+		if _, ok := controllerJumpHosts[name]; ok {
+			delete(controllerJumpHosts, name)
+			if err := WriteJumpHostsFile(controllerJumpHosts); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+
 	// Remove the controller cookie jars.
 	for _, name := range names {
 		err := os.Remove(JujuCookiePath(name))
@@ -736,6 +750,55 @@ func (s *store) BootstrapConfigForController(controllerName string) (*BootstrapC
 	return &cfg, nil
 }
 
+// UpdateJumpHosts implements JumpHostsUpdater.
+func (s *store) UpdateJumpHosts(controllerName, modelName string, hosts []string) error {
+	if err := ValidateControllerName(controllerName); err != nil {
+		return errors.Trace(err)
+	}
+	if modelName != "" {
+		if err := ValidateModelName(modelName); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := ValidateJumpHosts(hosts); err != nil {
+		return errors.Trace(err)
+	}
+
+	releaser, err := s.acquireLock()
+	if err != nil {
+		return errors.Annotatef(err, "cannot update jump hosts for controller %s", controllerName)
+	}
+	defer releaser.Release()
+
+	all, err := ReadJumpHostsFile(JujuJumpHostsPath())
+	if err != nil {
+		return errors.Annotate(err, "cannot get jump hosts")
+	}
+
+	if all == nil {
+		all = make(map[string]ControllerJumpHosts)
+	}
+	updateJumpHosts(all, controllerName, modelName, hosts)
+	return WriteJumpHostsFile(all)
+}
+
+// JumpHosts implements JumpHostsGetter.
+func (s *store) JumpHosts(controllerName, modelName string) ([]string, error) {
+	if err := ValidateControllerName(controllerName); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if modelName != "" {
+		if err := ValidateModelName(modelName); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	all, err := ReadJumpHostsFile(JujuJumpHostsPath())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return jumpHostsFor(all, controllerName, modelName)
+}
+
 // CookieJar returns the cookie jar associated with the given controller.
 func (s *store) CookieJar(controllerName string) (CookieJar, error) {
 	if err := ValidateControllerName(controllerName); err != nil {
@@ -291,6 +291,29 @@ type BootstrapConfigGetter interface {
 	BootstrapConfigForController(string) (*BootstrapConfig, error)
 }
 
+// JumpHostsUpdater stores SSH jump host chains.
+type JumpHostsUpdater interface {
+	// UpdateJumpHosts sets the chain of SSH jump hosts, in the
+	// order in which they are traversed, used to reach machines
+	// in the named model. If modelName is empty, the chain is set
+	// for all models on the controller that do not have their own
+	// chain configured.
+	//
+	// If hosts is empty, the chain will be removed.
+	UpdateJumpHosts(controllerName, modelName string, hosts []string) error
+}
+
+// JumpHostsGetter gets SSH jump host chains.
+type JumpHostsGetter interface {
+	// JumpHosts returns the chain of SSH jump hosts configured for
+	// the named model or, if modelName is empty, for the controller.
+	// The controller's chain is not returned for a model that does
+	// not have its own chain configured. If there is no chain
+	// configured, an error satisfying errors.IsNotFound will be
+	// returned.
+	JumpHosts(controllerName, modelName string) ([]string, error)
+}
+
 // CookieJar is the interface implemented by cookie jars.
 type CookieJar interface {
 	http.CookieJar
@@ -344,8 +367,15 @@ type BootstrapConfigStore interface {
 	BootstrapConfigGetter
 }
 
+// JumpHostsStore is an amalgamation of JumpHostsUpdater and
+// JumpHostsGetter.
+type JumpHostsStore interface {
+	JumpHostsUpdater
+	JumpHostsGetter
+}
+
 // ClientStore is an amalgamation of AccountStore, BootstrapConfigStore,
-// ControllerStore, CredentialStore, and ModelStore.
+// ControllerStore, CredentialStore, ModelStore, and JumpHostsStore.
 type ClientStore interface {
 	AccountStore
 	BootstrapConfigStore
@@ -353,4 +383,5 @@ type ClientStore interface {
 	CredentialStore
 	ModelStore
 	CookieStore
+	JumpHostsStore
 }
@@ -40,6 +40,9 @@ type StubStore struct {
 	UpdateBootstrapConfigFunc        func(controllerName string, cfg jujuclient.BootstrapConfig) error
 
 	CookieJarFunc func(controllerName string) (jujuclient.CookieJar, error)
+
+	JumpHostsFunc       func(controllerName, modelName string) ([]string, error)
+	UpdateJumpHostsFunc func(controllerName, modelName string, hosts []string) error
 }
 
 func NewStubStore() *StubStore {
@@ -116,6 +119,12 @@ func NewStubStore() *StubStore {
 	result.CookieJarFunc = func(controllerName string) (jujuclient.CookieJar, error) {
 		return nil, result.Stub.NextErr()
 	}
+	result.JumpHostsFunc = func(controllerName, modelName string) ([]string, error) {
+		return nil, result.Stub.NextErr()
+	}
+	result.UpdateJumpHostsFunc = func(controllerName, modelName string, hosts []string) error {
+		return result.Stub.NextErr()
+	}
 	return result
 }
 
@@ -143,6 +152,8 @@ func WrapClientStore(underlying jujuclient.ClientStore) *StubStore {
 	stub.BootstrapConfigForControllerFunc = underlying.BootstrapConfigForController
 	stub.UpdateBootstrapConfigFunc = underlying.UpdateBootstrapConfig
 	stub.CookieJarFunc = underlying.CookieJar
+	stub.JumpHostsFunc = underlying.JumpHosts
+	stub.UpdateJumpHostsFunc = underlying.UpdateJumpHosts
 	return stub
 }
 
@@ -276,3 +287,15 @@ func (c *StubStore) CookieJar(controllerName string) (jujuclient.CookieJar, erro
 	c.MethodCall(c, "CookieJar", controllerName)
 	return c.CookieJarFunc(controllerName)
 }
+
+// JumpHosts implements JumpHostsGetter.
+func (c *StubStore) JumpHosts(controllerName, modelName string) ([]string, error) {
+	c.MethodCall(c, "JumpHosts", controllerName, modelName)
+	return c.JumpHostsFunc(controllerName, modelName)
+}
+
+// UpdateJumpHosts implements JumpHostsUpdater.
+func (c *StubStore) UpdateJumpHosts(controllerName, modelName string, hosts []string) error {
+	c.MethodCall(c, "UpdateJumpHosts", controllerName, modelName, hosts)
+	return c.UpdateJumpHostsFunc(controllerName, modelName, hosts)
+}
@@ -0,0 +1,123 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils"
+	"gopkg.in/yaml.v2"
+
+	"github.com/juju/juju/juju/osenv"
+)
+
+// JujuJumpHostsPath is the location where SSH jump host
+// chains are expected to be found.
+func JujuJumpHostsPath() string {
+	return osenv.JujuXDGDataHomePath("jump-hosts.yaml")
+}
+
+// ControllerJumpHosts holds the SSH jump host chains configured
+// for a controller, and for models on the controller.
+type ControllerJumpHosts struct {
+	// JumpHosts is the chain of jump hosts used to reach machines
+	// in any model on the controller that does not have its own
+	// chain configured.
+	JumpHosts []string `yaml:"jump-hosts,omitempty,flow"`
+
+	// Models holds the chains of jump hosts configured for
+	// individual models, keyed by qualified model name.
+	Models map[string][]string `yaml:"models,omitempty"`
+}
+
+// ReadJumpHostsFile loads all SSH jump host chains defined in a
+// given file. If the file is not found, it is not an error.
+func ReadJumpHostsFile(file string) (map[string]ControllerJumpHosts, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	jumpHosts, err := ParseJumpHosts(data)
+	if err != nil {
+		return nil, err
+	}
+	return jumpHosts, nil
+}
+
+// WriteJumpHostsFile marshals to YAML details of the given SSH jump
+// host chains and writes it to the jump hosts file.
+func WriteJumpHostsFile(jumpHosts map[string]ControllerJumpHosts) error {
+	data, err := yaml.Marshal(jumpHostsCollection{jumpHosts})
+	if err != nil {
+		return errors.Annotate(err, "cannot marshal jump hosts")
+	}
+	return utils.AtomicWriteFile(JujuJumpHostsPath(), data, os.FileMode(0600))
+}
+
+// ParseJumpHosts parses the given YAML bytes into SSH jump
+// host chains.
+func ParseJumpHosts(data []byte) (map[string]ControllerJumpHosts, error) {
+	var result jumpHostsCollection
+	err := yaml.Unmarshal(data, &result)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot unmarshal jump hosts")
+	}
+	return result.ControllerJumpHosts, nil
+}
+
+type jumpHostsCollection struct {
+	ControllerJumpHosts map[string]ControllerJumpHosts `yaml:"controllers"`
+}
+
+// updateJumpHosts sets or, if hosts is empty, removes the chain of
+// jump hosts for the specified controller and, if modelName is not
+// empty, model, in the given collection.
+func updateJumpHosts(
+	all map[string]ControllerJumpHosts,
+	controllerName, modelName string,
+	hosts []string,
+) {
+	controllerJumpHosts := all[controllerName]
+	if modelName == "" {
+		controllerJumpHosts.JumpHosts = hosts
+	} else if len(hosts) > 0 {
+		if controllerJumpHosts.Models == nil {
+			controllerJumpHosts.Models = make(map[string][]string)
+		}
+		controllerJumpHosts.Models[modelName] = hosts
+	} else {
+		delete(controllerJumpHosts.Models, modelName)
+	}
+	if len(controllerJumpHosts.JumpHosts) == 0 && len(controllerJumpHosts.Models) == 0 {
+		delete(all, controllerName)
+		return
+	}
+	all[controllerName] = controllerJumpHosts
+}
+
+// jumpHostsFor returns the chain of jump hosts for the specified
+// controller and, if modelName is not empty, model, in the given
+// collection.
+func jumpHostsFor(
+	all map[string]ControllerJumpHosts,
+	controllerName, modelName string,
+) ([]string, error) {
+	controllerJumpHosts := all[controllerName]
+	hosts := controllerJumpHosts.JumpHosts
+	if modelName != "" {
+		hosts = controllerJumpHosts.Models[modelName]
+	}
+	if len(hosts) == 0 {
+		if modelName != "" {
+			return nil, errors.NotFoundf("jump hosts for model %s:%s", controllerName, modelName)
+		}
+		return nil, errors.NotFoundf("jump hosts for controller %s", controllerName)
+	}
+	return hosts, nil
+}
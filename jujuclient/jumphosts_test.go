@@ -0,0 +1,145 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package jujuclient_test
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/jujuclient"
+	"github.com/juju/juju/testing"
+)
+
+type JumpHostsSuite struct {
+	testing.FakeJujuXDGDataHomeSuite
+	store jujuclient.ClientStore
+}
+
+var _ = gc.Suite(&JumpHostsSuite{})
+
+const testJumpHostsYAML = `
+controllers:
+  aws-test:
+    jump-hosts: [bastion.example.com, admin@10.0.0.1:2222]
+    models:
+      admin/admin: [bastion.example.com]
+  mallards:
+    models:
+      admin/my-model: [jump.mallards.example.com]
+`
+
+func (s *JumpHostsSuite) SetUpTest(c *gc.C) {
+	s.FakeJujuXDGDataHomeSuite.SetUpTest(c)
+	s.store = jujuclient.NewFileClientStore()
+	err := ioutil.WriteFile(jujuclient.JujuJumpHostsPath(), []byte(testJumpHostsYAML), 0600)
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *JumpHostsSuite) TestJumpHostsNoFile(c *gc.C) {
+	err := os.Remove(jujuclient.JujuJumpHostsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.store.JumpHosts("aws-test", "")
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, "jump hosts for controller aws-test not found")
+}
+
+func (s *JumpHostsSuite) TestJumpHostsController(c *gc.C) {
+	hosts, err := s.store.JumpHosts("aws-test", "")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hosts, jc.DeepEquals, []string{"bastion.example.com", "admin@10.0.0.1:2222"})
+
+	_, err = s.store.JumpHosts("mallards", "")
+	c.Assert(err, gc.ErrorMatches, "jump hosts for controller mallards not found")
+}
+
+func (s *JumpHostsSuite) TestJumpHostsModel(c *gc.C) {
+	hosts, err := s.store.JumpHosts("mallards", "admin/my-model")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(hosts, jc.DeepEquals, []string{"jump.mallards.example.com"})
+
+	// The controller's chain is not returned for models.
+	_, err = s.store.JumpHosts("aws-test", "admin/other")
+	c.Assert(err, gc.ErrorMatches, "jump hosts for model aws-test:admin/other not found")
+}
+
+func (s *JumpHostsSuite) TestUpdateJumpHosts(c *gc.C) {
+	err := s.store.UpdateJumpHosts("new-controller", "", []string{"bastion"})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateJumpHosts("new-controller", "admin/foo", []string{"a", "b:2222"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := jujuclient.ReadJumpHostsFile(jujuclient.JujuJumpHostsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all["new-controller"], jc.DeepEquals, jujuclient.ControllerJumpHosts{
+		JumpHosts: []string{"bastion"},
+		Models: map[string][]string{
+			"admin/foo": {"a", "b:2222"},
+		},
+	})
+	c.Assert(all, gc.HasLen, 3)
+}
+
+func (s *JumpHostsSuite) TestUpdateJumpHostsRemoves(c *gc.C) {
+	err := s.store.UpdateJumpHosts("aws-test", "admin/admin", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.store.UpdateJumpHosts("mallards", "admin/my-model", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := jujuclient.ReadJumpHostsFile(jujuclient.JujuJumpHostsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, jc.DeepEquals, map[string]jujuclient.ControllerJumpHosts{
+		"aws-test": {
+			JumpHosts: []string{"bastion.example.com", "admin@10.0.0.1:2222"},
+		},
+	})
+}
+
+func (s *JumpHostsSuite) TestUpdateJumpHostsInvalid(c *gc.C) {
+	err := s.store.UpdateJumpHosts("aws-test", "", []string{"bastion", "-oProxyCommand=foo"})
+	c.Assert(err, gc.ErrorMatches, `jump host "-oProxyCommand=foo" not valid`)
+	err = s.store.UpdateJumpHosts("aws-test", "my-model", []string{"bastion"})
+	c.Assert(err, gc.ErrorMatches, `validating model name "my-model": .*`)
+}
+
+func (s *JumpHostsSuite) TestRemoveControllerRemovesJumpHosts(c *gc.C) {
+	writeTestControllersFile(c)
+	err := s.store.RemoveController("aws-test")
+	c.Assert(err, jc.ErrorIsNil)
+
+	all, err := jujuclient.ReadJumpHostsFile(jujuclient.JujuJumpHostsPath())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+	c.Assert(all["mallards"].Models, gc.HasLen, 1)
+}
+
+func (s *JumpHostsSuite) TestValidateJumpHosts(c *gc.C) {
+	for _, host := range []string{
+		"bastion",
+		"bastion.example.com",
+		"ubuntu@bastion.example.com",
+		"ubuntu@bastion.example.com:2222",
+		"10.0.0.1:22",
+		"[2001:db8::1]:22",
+		"2001:db8::1",
+	} {
+		c.Check(jujuclient.ValidateJumpHosts([]string{host}), jc.ErrorIsNil, gc.Commentf("%q", host))
+	}
+	for _, host := range []string{
+		"",
+		"bastion example",
+		"a,b",
+		"-oProxyCommand=foo",
+		"@bastion",
+		"ubuntu@",
+		"bastion:ssh",
+		"bastion:0",
+		"bastion:65536",
+	} {
+		c.Check(jujuclient.ValidateJumpHosts([]string{host}), gc.ErrorMatches, ".* not valid", gc.Commentf("%q", host))
+	}
+}
@@ -24,16 +24,18 @@ type MemStore struct {
 	Credentials           map[string]cloud.CloudCredential
 	BootstrapConfig       map[string]BootstrapConfig
 	CookieJars            map[string]*cookiejar.Jar
+	ControllerJumpHosts   map[string]ControllerJumpHosts
 }
 
 func NewMemStore() *MemStore {
 	return &MemStore{
-		Controllers:     make(map[string]ControllerDetails),
-		Models:          make(map[string]*ControllerModels),
-		Accounts:        make(map[string]AccountDetails),
-		Credentials:     make(map[string]cloud.CloudCredential),
-		BootstrapConfig: make(map[string]BootstrapConfig),
-		CookieJars:      make(map[string]*cookiejar.Jar),
+		Controllers:         make(map[string]ControllerDetails),
+		Models:              make(map[string]*ControllerModels),
+		Accounts:            make(map[string]AccountDetails),
+		Credentials:         make(map[string]cloud.CloudCredential),
+		BootstrapConfig:     make(map[string]BootstrapConfig),
+		CookieJars:          make(map[string]*cookiejar.Jar),
+		ControllerJumpHosts: make(map[string]ControllerJumpHosts),
 	}
 }
 
@@ -171,6 +173,7 @@ func (c *MemStore) RemoveController(name string) error {
 		delete(c.BootstrapConfig, name)
 		delete(c.Controllers, name)
 		delete(c.CookieJars, name)
+		delete(c.ControllerJumpHosts, name)
 	}
 	return nil
 }
@@ -415,6 +418,37 @@ func (c *MemStore) BootstrapConfigForController(controllerName string) (*Bootstr
 	return nil, errors.NotFoundf("bootstrap config for controller %s", controllerName)
 }
 
+// UpdateJumpHosts implements JumpHostsUpdater.
+func (c *MemStore) UpdateJumpHosts(controllerName, modelName string, hosts []string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := ValidateControllerName(controllerName); err != nil {
+		return err
+	}
+	if modelName != "" {
+		if err := ValidateModelName(modelName); err != nil {
+			return err
+		}
+	}
+	if err := ValidateJumpHosts(hosts); err != nil {
+		return err
+	}
+	if c.ControllerJumpHosts == nil {
+		c.ControllerJumpHosts = make(map[string]ControllerJumpHosts)
+	}
+	updateJumpHosts(c.ControllerJumpHosts, controllerName, modelName, hosts)
+	return nil
+}
+
+// JumpHosts implements JumpHostsGetter.
+func (c *MemStore) JumpHosts(controllerName, modelName string) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return jumpHostsFor(c.ControllerJumpHosts, controllerName, modelName)
+}
+
 func (c *MemStore) CookieJar(controllerName string) (CookieJar, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -4,6 +4,10 @@
 package jujuclient
 
 import (
+	"net"
+	"strconv"
+	"strings"
+
 	"github.com/juju/errors"
 	"gopkg.in/juju/names.v2"
 )
@@ -69,6 +73,46 @@ func ValidateBootstrapConfig(cfg BootstrapConfig) error {
 	return nil
 }
 
+// ValidateJumpHosts validates the given chain of SSH jump hosts,
+// each of which must be of the form [user@]host[:port].
+func ValidateJumpHosts(hosts []string) error {
+	for _, host := range hosts {
+		if err := validateJumpHost(host); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+func validateJumpHost(jumpHost string) error {
+	if jumpHost == "" || strings.ContainsAny(jumpHost, " \t\n,") || strings.HasPrefix(jumpHost, "-") {
+		return errors.NotValidf("jump host %q", jumpHost)
+	}
+	hostPort := jumpHost
+	if i := strings.Index(jumpHost, "@"); i != -1 {
+		if i == 0 {
+			return errors.NotValidf("jump host %q", jumpHost)
+		}
+		hostPort = jumpHost[i+1:]
+	}
+	host := hostPort
+	if strings.HasPrefix(hostPort, "[") || strings.Count(hostPort, ":") == 1 {
+		var port string
+		var err error
+		host, port, err = net.SplitHostPort(hostPort)
+		if err != nil {
+			return errors.NotValidf("jump host %q", jumpHost)
+		}
+		if n, err := strconv.Atoi(port); err != nil || n <= 0 || n > 65535 {
+			return errors.NotValidf("jump host %q port", jumpHost)
+		}
+	}
+	if host == "" {
+		return errors.NotValidf("jump host %q", jumpHost)
+	}
+	return nil
+}
+
 func validateUser(name string) error {
 	if !names.IsValidUser(name) {
 		return errors.NotValidf("user name %q", name)
@@ -33,6 +33,34 @@ type Record struct {
 
 	// Message is the record's body. It may be empty.
 	Message string
+
+	// Audit holds the details of the audited API request, for records
+	// taken from the audit stream rather than the log stream. It is
+	// nil for log records.
+	Audit *AuditDetails
+}
+
+// AuditDetails holds the audit-specific information for a record.
+// The user that made the request is identified by the record's
+// Origin.
+type AuditDetails struct {
+	// RemoteAddress is the address from which the request was made.
+	RemoteAddress string
+
+	// Operation is the API operation that was requested.
+	Operation string
+
+	// Data holds any additional information recorded for the
+	// request.
+	Data map[string]interface{}
+}
+
+// Validate ensures that the audit details are correct.
+func (a AuditDetails) Validate() error {
+	if a.Operation == "" {
+		return errors.NewNotValid(nil, "empty Operation")
+	}
+	return nil
 }
 
 // Validate ensures that the record is correct.
@@ -53,6 +81,12 @@ func (rec Record) Validate() error {
 
 	// rec.Message may be anything, so we don't check it.
 
+	if rec.Audit != nil {
+		if err := rec.Audit.Validate(); err != nil {
+			return errors.Annotate(err, "invalid Audit")
+		}
+	}
+
 	return nil
 }
 
@@ -57,6 +57,28 @@ func (s *RecordSuite) TestValidateEmptyTimestamp(c *gc.C) {
 	c.Check(err, gc.ErrorMatches, `empty Timestamp`)
 }
 
+func (s *RecordSuite) TestValidateAudit(c *gc.C) {
+	rec := validRecord
+	rec.Audit = &logfwd.AuditDetails{
+		RemoteAddress: "10.0.0.1",
+		Operation:     "Client:v1 - FullStatus",
+	}
+
+	err := rec.Validate()
+
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *RecordSuite) TestValidateBadAudit(c *gc.C) {
+	rec := validRecord
+	rec.Audit = &logfwd.AuditDetails{}
+
+	err := rec.Validate()
+
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, `invalid Audit: empty Operation`)
+}
+
 func (s *RecordSuite) TestValidateBadLocation(c *gc.C) {
 	rec := validRecord
 	rec.Location.Filename = ""
@@ -5,6 +5,7 @@ package syslog
 
 import (
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
 	"io"
 	"time"
@@ -113,6 +114,9 @@ func (client Client) Send(records []logfwd.Record) error {
 }
 
 func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
+	if rec.Audit != nil {
+		return messageFromAuditRecord(rec)
+	}
 	msg := rfc5424.Message{
 		Header: rfc5424.Header{
 			Priority: rfc5424.Priority{
@@ -177,3 +181,82 @@ func messageFromRecord(rec logfwd.Record) (rfc5424.Message, error) {
 	}
 	return msg, nil
 }
+
+// messageFromAuditRecord converts an audit record into a syslog
+// message. Audit messages use their own application name and carry
+// the details of the request as structured data, so that they may be
+// told apart from, and processed separately to, log messages.
+func messageFromAuditRecord(rec logfwd.Record) (rfc5424.Message, error) {
+	appName := "juju-audit-" + rec.Origin.ModelUUID
+	if len(appName) > 48 {
+		appName = appName[:48]
+	}
+	auditData := []rfc5424.StructuredDataParam{{
+		Name:  "origin-type",
+		Value: rfc5424.StructuredDataParamValue(rec.Origin.Type.String()),
+	}, {
+		Name:  "origin-name",
+		Value: rfc5424.StructuredDataParamValue(rec.Origin.Name),
+	}, {
+		Name:  "operation",
+		Value: rfc5424.StructuredDataParamValue(rec.Audit.Operation),
+	}, {
+		Name:  "remote-address",
+		Value: rfc5424.StructuredDataParamValue(rec.Audit.RemoteAddress),
+	}}
+	if len(rec.Audit.Data) > 0 {
+		data, err := json.Marshal(rec.Audit.Data)
+		if err != nil {
+			return rfc5424.Message{}, errors.Annotate(err, "marshalling audit data")
+		}
+		auditData = append(auditData, rfc5424.StructuredDataParam{
+			Name:  "data",
+			Value: rfc5424.StructuredDataParamValue(data),
+		})
+	}
+
+	msg := rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{rec.Timestamp},
+			Hostname: rfc5424.Hostname{
+				FQDN: rec.Origin.Hostname,
+			},
+			AppName: rfc5424.AppName(appName),
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Origin{
+				EnterpriseID: sdelements.OriginEnterpriseID{
+					Number: sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
+				},
+				SoftwareName:    rec.Origin.Software.Name,
+				SoftwareVersion: rec.Origin.Software.Version,
+			},
+			&sdelements.Private{
+				Name: "model",
+				PEN:  sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "controller-uuid",
+					Value: rfc5424.StructuredDataParamValue(rec.Origin.ControllerUUID),
+				}, {
+					Name:  "model-uuid",
+					Value: rfc5424.StructuredDataParamValue(rec.Origin.ModelUUID),
+				}},
+			},
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  sdelements.PrivateEnterpriseNumber(rec.Origin.Software.PrivateEnterpriseNumber),
+				Data: auditData,
+			},
+		},
+		Msg: rec.Audit.Operation,
+	}
+
+	if err := msg.Validate(); err != nil {
+		return msg, errors.Trace(err)
+	}
+	return msg, nil
+}
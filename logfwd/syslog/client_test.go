@@ -186,6 +186,83 @@ func (s *ClientSuite) TestSendLogLevels(c *gc.C) {
 	}
 }
 
+func (s *ClientSuite) TestSendAudit(c *gc.C) {
+	tag := names.NewUserTag("bob")
+	cID := "9f484882-2f18-4fd2-967d-db9663db7bea"
+	mID := "deadbeef-2f18-4fd2-967d-db9663db7bea"
+	ver := version.MustParse("1.2.3")
+	ts := time.Unix(12345, 0)
+	origin, err := logfwd.OriginForJuju(tag, cID, mID, ver)
+	c.Assert(err, jc.ErrorIsNil)
+	rec := logfwd.Record{
+		Origin:    origin,
+		Timestamp: ts,
+		Level:     loggo.INFO,
+		Audit: &logfwd.AuditDetails{
+			RemoteAddress: "10.0.0.1",
+			Operation:     "Application:v4 - Deploy",
+			Data:          map[string]interface{}{"request-id": 7},
+		},
+	}
+	client := syslog.Client{Sender: s.sender}
+
+	err = client.Send([]logfwd.Record{rec})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.stub.CheckCallNames(c, "Send")
+	s.stub.CheckCall(c, 0, "Send", rfc5424.Message{
+		Header: rfc5424.Header{
+			Priority: rfc5424.Priority{
+				Severity: rfc5424.SeverityInformational,
+				Facility: rfc5424.FacilityUser,
+			},
+			Timestamp: rfc5424.Timestamp{ts},
+			AppName:   "juju-audit-deadbeef-2f18-4fd2-967d-db9663db7bea",
+		},
+		StructuredData: rfc5424.StructuredData{
+			&sdelements.Origin{
+				EnterpriseID: sdelements.OriginEnterpriseID{
+					Number: 28978,
+				},
+				SoftwareName:    "juju",
+				SoftwareVersion: ver,
+			},
+			&sdelements.Private{
+				Name: "model",
+				PEN:  28978,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "controller-uuid",
+					Value: "9f484882-2f18-4fd2-967d-db9663db7bea",
+				}, {
+					Name:  "model-uuid",
+					Value: "deadbeef-2f18-4fd2-967d-db9663db7bea",
+				}},
+			},
+			&sdelements.Private{
+				Name: "audit",
+				PEN:  28978,
+				Data: []rfc5424.StructuredDataParam{{
+					Name:  "origin-type",
+					Value: "user",
+				}, {
+					Name:  "origin-name",
+					Value: "bob",
+				}, {
+					Name:  "operation",
+					Value: "Application:v4 - Deploy",
+				}, {
+					Name:  "remote-address",
+					Value: "10.0.0.1",
+				}, {
+					Name:  "data",
+					Value: `{"request-id":7}`,
+				}},
+			},
+		},
+		Msg: "Application:v4 - Deploy",
+	})
+}
+
 type stubSenderOpener struct {
 	stub *testing.Stub
 
@@ -5,7 +5,9 @@ package migration
 
 import (
 	"fmt"
+	"reflect"
 
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	"github.com/juju/version"
 	"gopkg.in/juju/charm.v6-unstable"
@@ -14,6 +16,7 @@ import (
 	"github.com/juju/juju/apiserver/common"
 	"github.com/juju/juju/cloud"
 	coremigration "github.com/juju/juju/core/migration"
+	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/resource"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/status"
@@ -90,68 +93,78 @@ type PrecheckUnit interface {
 
 // SourcePrecheck checks the state of the source controller to make
 // sure that the preconditions for model migration are met. The
-// backend provided must be for the model to be migrated.
+// backend provided must be for the model to be migrated. Only the
+// first problem found is reported; see SourcePrecheckAll.
 func SourcePrecheck(backend PrecheckBackend) error {
-	if err := checkModel(backend); err != nil {
-		return errors.Trace(err)
-	}
+	return firstError(SourcePrecheckAll(backend))
+}
 
-	if err := checkMachines(backend); err != nil {
-		return errors.Trace(err)
-	}
-
-	if err := checkApplications(backend); err != nil {
-		return errors.Trace(err)
-	}
+// SourcePrecheckAll runs all of the checks performed by SourcePrecheck,
+// and returns every problem found rather than stopping at the first.
+func SourcePrecheckAll(backend PrecheckBackend) []error {
+	var errs precheckErrors
+	checkModel(backend, &errs)
+	checkMachines(backend, &errs)
+	checkApplications(backend, &errs)
 
 	if cleanupNeeded, err := backend.NeedsCleanup(); err != nil {
-		return errors.Annotate(err, "checking cleanups")
+		errs.add(errors.Annotate(err, "checking cleanups"))
 	} else if cleanupNeeded {
-		return errors.New("cleanup needed")
+		errs.add(errors.New("cleanup needed"))
 	}
 
 	// Check the source controller.
 	controllerBackend, err := backend.ControllerBackend()
 	if err != nil {
-		return errors.Trace(err)
+		errs.add(errors.Trace(err))
+		return errs
 	}
 	defer controllerBackend.Close()
-	if err := checkController(controllerBackend); err != nil {
-		return errors.Annotate(err, "controller")
+	var controllerErrs precheckErrors
+	checkController(controllerBackend, &controllerErrs)
+	for _, err := range controllerErrs {
+		errs.add(errors.Annotate(err, "controller"))
 	}
-	return nil
+	return errs
 }
 
-func checkModel(backend PrecheckBackend) error {
+func checkModel(backend PrecheckBackend, errs *precheckErrors) {
 	model, err := backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
+		errs.add(errors.Annotate(err, "retrieving model"))
+		return
 	}
 	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		errs.add(errors.Errorf("model is %s", model.Life()))
 	}
 	if model.MigrationMode() == state.MigrationModeImporting {
-		return errors.New("model is being imported as part of another migration")
+		errs.add(errors.New("model is being imported as part of another migration"))
 	}
 	if credTag, found := model.CloudCredential(); found {
 		creds, err := backend.CloudCredential(credTag)
 		if err != nil {
-			return errors.Trace(err)
-		}
-		if creds.Revoked {
-			return errors.New("model has revoked credentials")
+			errs.add(errors.Trace(err))
+		} else if creds.Revoked {
+			errs.add(errors.New("model has revoked credentials"))
 		}
 	}
-	return nil
 }
 
 // TargetPrecheck checks the state of the target controller to make
 // sure that the preconditions for model migration are met. The
-// backend provided must be for the target controller.
+// backend provided must be for the target controller. Only the
+// first problem found is reported; see TargetPrecheckAll.
 func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo) error {
+	return firstError(TargetPrecheckAll(backend, modelInfo))
+}
+
+// TargetPrecheckAll runs all of the checks performed by TargetPrecheck,
+// and returns every problem found rather than stopping at the first.
+func TargetPrecheckAll(backend PrecheckBackend, modelInfo coremigration.ModelInfo) []error {
 	if err := modelInfo.Validate(); err != nil {
-		return errors.Trace(err)
+		return []error{errors.Trace(err)}
 	}
+	var errs precheckErrors
 
 	// This check is necessary because there is a window between the
 	// REAP phase and then end of the DONE phase where a model's
@@ -161,48 +174,133 @@ func TargetPrecheck(backend PrecheckBackend, modelInfo coremigration.ModelInfo)
 	//
 	// See also https://lpad.tv/1611391
 	if migrating, err := backend.IsMigrationActive(modelInfo.UUID); err != nil {
-		return errors.Annotate(err, "checking for active migration")
+		errs.add(errors.Annotate(err, "checking for active migration"))
 	} else if migrating {
-		return errors.New("model is being migrated out of target controller")
+		errs.add(errors.New("model is being migrated out of target controller"))
 	}
 
-	controllerVersion, err := backend.AgentVersion()
-	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
-	}
-
-	if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
-		return errors.Errorf("model has higher version than target controller (%s > %s)",
-			modelInfo.AgentVersion, controllerVersion)
-	}
-
-	if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
-		return errors.Errorf("source controller has higher version than target controller (%s > %s)",
-			modelInfo.ControllerAgentVersion, controllerVersion)
+	if controllerVersion, err := backend.AgentVersion(); err != nil {
+		errs.add(errors.Annotate(err, "retrieving model version"))
+	} else {
+		if controllerVersion.Compare(modelInfo.AgentVersion) < 0 {
+			errs.add(errors.Errorf("model has higher version than target controller (%s > %s)",
+				modelInfo.AgentVersion, controllerVersion))
+		}
+		if !controllerVersionCompatible(modelInfo.ControllerAgentVersion, controllerVersion) {
+			errs.add(errors.Errorf("source controller has higher version than target controller (%s > %s)",
+				modelInfo.ControllerAgentVersion, controllerVersion))
+		}
 	}
 
-	if err := checkController(backend); err != nil {
-		return errors.Trace(err)
-	}
+	checkController(backend, &errs)
 
 	// Check for conflicts with existing models
 	models, err := backend.AllModels()
 	if err != nil {
-		return errors.Annotate(err, "retrieving models")
+		errs.add(errors.Annotate(err, "retrieving models"))
+		return errs
 	}
 	for _, model := range models {
 		// If the model is importing then it's probably left behind
 		// from a previous migration attempt. It will be removed
 		// before the next import.
 		if model.UUID() == modelInfo.UUID && model.MigrationMode() != state.MigrationModeImporting {
-			return errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID)
+			errs.add(errors.Errorf("model with same UUID already exists (%s)", modelInfo.UUID))
 		}
 		if model.Name() == modelInfo.Name && model.Owner() == modelInfo.Owner {
-			return errors.Errorf("model named %q already exists", model.Name())
+			errs.add(errors.Errorf("model named %q already exists", model.Name()))
 		}
 	}
+	return errs
+}
 
-	return nil
+// ImportPrecheckBackend defines the interface to query the target
+// controller's state when checking that a model may be imported.
+type ImportPrecheckBackend interface {
+	Cloud(name string) (cloud.Cloud, error)
+	CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error)
+}
+
+// ImportPrecheck checks that the serialized model could be imported
+// into the target controller, without importing it. The backend
+// provided must be for the target controller. Every problem found
+// is returned.
+func ImportPrecheck(backend ImportPrecheckBackend, bytes []byte) []error {
+	model, err := description.Deserialize(bytes)
+	if err != nil {
+		return []error{errors.Annotate(err, "deserializing model")}
+	}
+	var errs precheckErrors
+	if err := model.Validate(); err != nil {
+		errs.add(errors.Annotate(err, "validating model"))
+	}
+	if len(model.RemoteApplications()) != 0 {
+		// Cross-model relations are currently limited to models
+		// on the same controller.
+		errs.add(errors.New("model has remote applications"))
+	}
+	if _, err := config.New(config.NoDefaults, model.Config()); err != nil {
+		errs.add(errors.Annotate(err, "validating model config"))
+	}
+	checkImportCloud(backend, model, &errs)
+	checkImportCredential(backend, model, &errs)
+	return errs
+}
+
+func checkImportCloud(backend ImportPrecheckBackend, model description.Model, errs *precheckErrors) {
+	modelCloud, err := backend.Cloud(model.Cloud())
+	if errors.IsNotFound(err) {
+		errs.add(errors.Errorf("cloud %q not found on target controller", model.Cloud()))
+		return
+	} else if err != nil {
+		errs.add(errors.Annotate(err, "retrieving cloud"))
+		return
+	}
+	if model.CloudRegion() == "" {
+		return
+	}
+	for _, region := range modelCloud.Regions {
+		if region.Name == model.CloudRegion() {
+			return
+		}
+	}
+	errs.add(errors.Errorf(
+		"region %q not found in cloud %q on target controller",
+		model.CloudRegion(), model.Cloud(),
+	))
+}
+
+func checkImportCredential(backend ImportPrecheckBackend, model description.Model, errs *precheckErrors) {
+	creds := model.CloudCredential()
+	if creds == nil {
+		return
+	}
+	credID := fmt.Sprintf("%s/%s/%s", creds.Cloud(), creds.Owner(), creds.Name())
+	if !names.IsValidCloudCredential(credID) {
+		errs.add(errors.Errorf("model credential ID %q not valid", credID))
+		return
+	}
+	existing, err := backend.CloudCredential(names.NewCloudCredentialTag(credID))
+	if errors.IsNotFound(err) {
+		// The credential will be added to the
+		// target controller by the import.
+		return
+	} else if err != nil {
+		errs.add(errors.Annotate(err, "retrieving credential"))
+		return
+	}
+	if string(existing.AuthType()) != creds.AuthType() {
+		errs.add(errors.Errorf(
+			"credential %q auth type mismatch: %q != %q",
+			credID, existing.AuthType(), creds.AuthType(),
+		))
+	} else if !reflect.DeepEqual(existing.Attributes(), creds.Attributes()) {
+		// Don't include the attributes, as they contain secrets.
+		errs.add(errors.Errorf("credential %q attributes differ from target controller", credID))
+	}
+	if existing.Revoked {
+		errs.add(errors.Errorf("credential %q is revoked on target controller", credID))
+	}
 }
 
 func controllerVersionCompatible(sourceVersion, targetVersion version.Number) bool {
@@ -222,126 +320,116 @@ func versionToMajMin(ver version.Number) version.Number {
 	return ver
 }
 
-func checkController(backend PrecheckBackend) error {
+func checkController(backend PrecheckBackend, errs *precheckErrors) {
 	model, err := backend.Model()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model")
-	}
-	if model.Life() != state.Alive {
-		return errors.Errorf("model is %s", model.Life())
+		errs.add(errors.Annotate(err, "retrieving model"))
+	} else if model.Life() != state.Alive {
+		errs.add(errors.Errorf("model is %s", model.Life()))
 	}
 
 	if upgrading, err := backend.IsUpgrading(); err != nil {
-		return errors.Annotate(err, "checking for upgrades")
+		errs.add(errors.Annotate(err, "checking for upgrades"))
 	} else if upgrading {
-		return errors.New("upgrade in progress")
+		errs.add(errors.New("upgrade in progress"))
 	}
 
-	err = checkMachines(backend)
-	return errors.Trace(err)
+	checkMachines(backend, errs)
 }
 
-func checkMachines(backend PrecheckBackend) error {
+func checkMachines(backend PrecheckBackend, errs *precheckErrors) {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		errs.add(errors.Annotate(err, "retrieving model version"))
+		return
 	}
 
 	machines, err := backend.AllMachines()
 	if err != nil {
-		return errors.Annotate(err, "retrieving machines")
+		errs.add(errors.Annotate(err, "retrieving machines"))
+		return
 	}
 	for _, machine := range machines {
 		if machine.Life() != state.Alive {
-			return errors.Errorf("machine %s is %s", machine.Id(), machine.Life())
+			errs.add(errors.Errorf("machine %s is %s", machine.Id(), machine.Life()))
 		}
 
 		if statusInfo, err := machine.InstanceStatus(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s instance status", machine.Id())
+			errs.add(errors.Annotatef(err, "retrieving machine %s instance status", machine.Id()))
 		} else if statusInfo.Status != status.Running {
-			return newStatusError("machine %s not running", machine.Id(), statusInfo.Status)
+			errs.add(newStatusError("machine %s not running", machine.Id(), statusInfo.Status))
 		}
 
 		if statusInfo, err := common.MachineStatus(machine); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s status", machine.Id())
+			errs.add(errors.Annotatef(err, "retrieving machine %s status", machine.Id()))
 		} else if statusInfo.Status != status.Started {
-			return newStatusError("machine %s agent not functioning at this time",
-				machine.Id(), statusInfo.Status)
+			errs.add(newStatusError("machine %s agent not functioning at this time",
+				machine.Id(), statusInfo.Status))
 		}
 
 		if rebootAction, err := machine.ShouldRebootOrShutdown(); err != nil {
-			return errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id())
+			errs.add(errors.Annotatef(err, "retrieving machine %s reboot status", machine.Id()))
 		} else if rebootAction != state.ShouldDoNothing {
-			return errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction)
+			errs.add(errors.Errorf("machine %s is scheduled to %s", machine.Id(), rebootAction))
 		}
 
-		if err := checkAgentTools(modelVersion, machine, "machine "+machine.Id()); err != nil {
-			return errors.Trace(err)
-		}
+		errs.add(checkAgentTools(modelVersion, machine, "machine "+machine.Id()))
 	}
-	return nil
 }
 
-func checkApplications(backend PrecheckBackend) error {
+func checkApplications(backend PrecheckBackend, errs *precheckErrors) {
 	modelVersion, err := backend.AgentVersion()
 	if err != nil {
-		return errors.Annotate(err, "retrieving model version")
+		errs.add(errors.Annotate(err, "retrieving model version"))
+		return
 	}
 	apps, err := backend.AllApplications()
 	if err != nil {
-		return errors.Annotate(err, "retrieving applications")
+		errs.add(errors.Annotate(err, "retrieving applications"))
+		return
 	}
 	for _, app := range apps {
 		if app.Life() != state.Alive {
-			return errors.Errorf("application %s is %s", app.Name(), app.Life())
-		}
-		err := checkUnits(app, modelVersion)
-		if err != nil {
-			return errors.Trace(err)
+			errs.add(errors.Errorf("application %s is %s", app.Name(), app.Life()))
 		}
+		checkUnits(app, modelVersion, errs)
 
 		resources, err := backend.ListPendingResources(app.Name())
 		if err != nil {
-			return errors.Annotate(err, "checking resources")
+			errs.add(errors.Annotate(err, "checking resources"))
+			continue
 		}
-		if len(resources) > 0 {
-			resName := resources[0].Name
-			return errors.Errorf("resource %q is pending for application %s", resName, app.Name())
+		for _, res := range resources {
+			errs.add(errors.Errorf("resource %q is pending for application %s", res.Name, app.Name()))
 		}
 	}
-	return nil
 }
 
-func checkUnits(app PrecheckApplication, modelVersion version.Number) error {
+func checkUnits(app PrecheckApplication, modelVersion version.Number, errs *precheckErrors) {
 	units, err := app.AllUnits()
 	if err != nil {
-		return errors.Annotatef(err, "retrieving units for %s", app.Name())
+		errs.add(errors.Annotatef(err, "retrieving units for %s", app.Name()))
+		return
 	}
 	if len(units) < app.MinUnits() {
-		return errors.Errorf("application %s is below its minimum units threshold", app.Name())
+		errs.add(errors.Errorf("application %s is below its minimum units threshold", app.Name()))
 	}
 
 	appCharmURL, _ := app.CharmURL()
 
 	for _, unit := range units {
 		if unit.Life() != state.Alive {
-			return errors.Errorf("unit %s is %s", unit.Name(), unit.Life())
-		}
-
-		if err := checkUnitAgentStatus(unit); err != nil {
-			return errors.Trace(err)
+			errs.add(errors.Errorf("unit %s is %s", unit.Name(), unit.Life()))
 		}
 
-		if err := checkAgentTools(modelVersion, unit, "unit "+unit.Name()); err != nil {
-			return errors.Trace(err)
-		}
+		errs.add(checkUnitAgentStatus(unit))
+		errs.add(checkAgentTools(modelVersion, unit, "unit "+unit.Name()))
 
 		unitCharmURL, _ := unit.CharmURL()
 		if appCharmURL.String() != unitCharmURL.String() {
-			return errors.Errorf("unit %s is upgrading", unit.Name())
+			errs.add(errors.Errorf("unit %s is upgrading", unit.Name()))
 		}
 	}
-	return nil
 }
 
 func checkUnitAgentStatus(unit PrecheckUnit) error {
@@ -383,3 +471,21 @@ func newStatusError(format, id string, s status.Status) error {
 	}
 	return errors.New(msg)
 }
+
+// precheckErrors accumulates the problems found by the migration
+// prechecks.
+type precheckErrors []error
+
+// add records err, if it is non-nil.
+func (errs *precheckErrors) add(err error) {
+	if err != nil {
+		*errs = append(*errs, err)
+	}
+}
+
+func firstError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs[0]
+}
@@ -4,6 +4,7 @@
 package migration_test
 
 import (
+	"github.com/juju/description"
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/version"
@@ -108,6 +109,31 @@ func (*SourcePrecheckSuite) TestCleanupsNeeded(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, "cleanup needed")
 }
 
+func (*SourcePrecheckSuite) TestAllSuccess(c *gc.C) {
+	backend := newHappyBackend()
+	backend.controllerBackend = newHappyBackend()
+	errs := migration.SourcePrecheckAll(backend)
+	c.Assert(errs, gc.HasLen, 0)
+}
+
+func (*SourcePrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	backend := newBackendWithDyingMachine()
+	backend.model.life = state.Dying
+	backend.cleanupNeeded = true
+	backend.controllerBackend = &fakeBackend{isUpgrading: true}
+	errs := migration.SourcePrecheckAll(backend)
+	c.Assert(errorStrings(errs), jc.DeepEquals, []string{
+		"model is dying",
+		"machine 0 is dying",
+		"cleanup needed",
+		"controller: upgrade in progress",
+	})
+
+	// SourcePrecheck reports only the first failure.
+	err := migration.SourcePrecheck(backend)
+	c.Assert(err, gc.ErrorMatches, "model is dying")
+}
+
 func (s *SourcePrecheckSuite) TestIsUpgradingError(c *gc.C) {
 	backend := newFakeBackend()
 	backend.controllerBackend.isUpgradingErr = errors.New("boom")
@@ -341,6 +367,29 @@ func (s *TargetPrecheckSuite) TestSuccess(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *TargetPrecheckSuite) TestAllReportsEveryFailure(c *gc.C) {
+	backend := newBackendWithDownMachine()
+	backend.migrationActive = true
+	backend.models = []migration.PrecheckModel{
+		&fakeModel{uuid: modelUUID},
+		&fakeModel{name: modelName, owner: modelOwner},
+	}
+	errs := migration.TargetPrecheckAll(backend, s.modelInfo)
+	c.Assert(errorStrings(errs), jc.DeepEquals, []string{
+		"model is being migrated out of target controller",
+		"machine 0 agent not functioning at this time (down)",
+		"model with same UUID already exists (model-uuid)",
+		`model named "model-name" already exists`,
+	})
+}
+
+func (s *TargetPrecheckSuite) TestAllInvalidModelInfo(c *gc.C) {
+	s.modelInfo.UUID = ""
+	errs := migration.TargetPrecheckAll(newHappyBackend(), s.modelInfo)
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], jc.Satisfies, errors.IsNotValid)
+}
+
 func (s *TargetPrecheckSuite) TestModelVersionAheadOfTarget(c *gc.C) {
 	backend := newFakeBackend()
 
@@ -523,6 +572,116 @@ func (s *TargetPrecheckSuite) TestUUIDAlreadyExistsButImporting(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+type ImportPrecheckSuite struct {
+	testing.BaseSuite
+	backend *fakeImportBackend
+	model   description.Model
+}
+
+var _ = gc.Suite(&ImportPrecheckSuite{})
+
+func (s *ImportPrecheckSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.backend = &fakeImportBackend{
+		clouds: map[string]cloud.Cloud{
+			"dummy": {
+				Type:    "dummy",
+				Regions: []cloud.Region{{Name: "dummy-region"}},
+			},
+		},
+		credentials: make(map[names.CloudCredentialTag]cloud.Credential),
+	}
+	s.model = description.NewModel(description.ModelArgs{
+		Owner:       modelOwner,
+		Config:      testing.FakeConfig(),
+		Cloud:       "dummy",
+		CloudRegion: "dummy-region",
+	})
+	s.model.SetCloudCredential(description.CloudCredentialArgs{
+		Owner:      modelOwner,
+		Cloud:      names.NewCloudTag("dummy"),
+		Name:       "default",
+		AuthType:   "userpass",
+		Attributes: map[string]string{"username": "admin"},
+	})
+}
+
+func (s *ImportPrecheckSuite) importPrecheck(c *gc.C) []error {
+	bytes, err := description.Serialize(s.model)
+	c.Assert(err, jc.ErrorIsNil)
+	return migration.ImportPrecheck(s.backend, bytes)
+}
+
+func (s *ImportPrecheckSuite) TestSuccess(c *gc.C) {
+	c.Assert(s.importPrecheck(c), gc.HasLen, 0)
+}
+
+func (s *ImportPrecheckSuite) TestInvalidModel(c *gc.C) {
+	errs := migration.ImportPrecheck(s.backend, []byte("not a model"))
+	c.Assert(errs, gc.HasLen, 1)
+	c.Assert(errs[0], gc.ErrorMatches, "deserializing model: .*")
+}
+
+func (s *ImportPrecheckSuite) TestCloudNotFound(c *gc.C) {
+	delete(s.backend.clouds, "dummy")
+	c.Assert(errorStrings(s.importPrecheck(c)), jc.DeepEquals, []string{
+		`cloud "dummy" not found on target controller`,
+	})
+}
+
+func (s *ImportPrecheckSuite) TestRegionNotFound(c *gc.C) {
+	s.backend.clouds["dummy"] = cloud.Cloud{Type: "dummy"}
+	c.Assert(errorStrings(s.importPrecheck(c)), jc.DeepEquals, []string{
+		`region "dummy-region" not found in cloud "dummy" on target controller`,
+	})
+}
+
+func (s *ImportPrecheckSuite) TestCredentialMismatch(c *gc.C) {
+	credTag := names.NewCloudCredentialTag("dummy/owner/default")
+	credential := cloud.NewCredential("userpass", map[string]string{"username": "bob"})
+	credential.Revoked = true
+	s.backend.credentials[credTag] = credential
+	c.Assert(errorStrings(s.importPrecheck(c)), jc.DeepEquals, []string{
+		`credential "dummy/owner/default" attributes differ from target controller`,
+		`credential "dummy/owner/default" is revoked on target controller`,
+	})
+}
+
+func (s *ImportPrecheckSuite) TestCredentialMatches(c *gc.C) {
+	credTag := names.NewCloudCredentialTag("dummy/owner/default")
+	s.backend.credentials[credTag] = cloud.NewCredential(
+		"userpass", map[string]string{"username": "admin"},
+	)
+	c.Assert(s.importPrecheck(c), gc.HasLen, 0)
+}
+
+type fakeImportBackend struct {
+	clouds      map[string]cloud.Cloud
+	credentials map[names.CloudCredentialTag]cloud.Credential
+}
+
+func (b *fakeImportBackend) Cloud(name string) (cloud.Cloud, error) {
+	if c, ok := b.clouds[name]; ok {
+		return c, nil
+	}
+	return cloud.Cloud{}, errors.NotFoundf("cloud %q", name)
+}
+
+func (b *fakeImportBackend) CloudCredential(tag names.CloudCredentialTag) (cloud.Credential, error) {
+	if c, ok := b.credentials[tag]; ok {
+		return c, nil
+	}
+	return cloud.Credential{}, errors.NotFoundf("credential %q", tag.Id())
+}
+
+func errorStrings(errs []error) []string {
+	out := make([]string, len(errs))
+	for i, err := range errs {
+		out[i] = err.Error()
+	}
+	return out
+}
+
 type precheckRunner func(migration.PrecheckBackend) error
 
 type precheckBaseSuite struct {
@@ -108,3 +108,93 @@ func (p IngressRuleSlice) Less(i, j int) bool {
 func SortIngressRules(IngressRules []IngressRule) {
 	sort.Sort(IngressRuleSlice(IngressRules))
 }
+
+// EgressRule represents a range of ports and destinations
+// to which outgoing packets are allowed.
+type EgressRule struct {
+	// PortRange is the range of ports for which outgoing
+	// packets are allowed.
+	PortRange
+
+	// DestinationCIDRs is a list of IP address blocks expressed in
+	// CIDR format to which this rule applies.
+	DestinationCIDRs []string
+}
+
+// NewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, there is
+// no restriction on where outgoing traffic is sent.
+func NewEgressRule(protocol string, from, to int, destinationCIDRs ...string) (EgressRule, error) {
+	rule := EgressRule{
+		PortRange: PortRange{
+			Protocol: protocol,
+			FromPort: from,
+			ToPort:   to,
+		},
+	}
+	for _, cidr := range destinationCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return EgressRule{}, errors.Trace(err)
+		}
+	}
+	if len(destinationCIDRs) > 0 {
+		rule.DestinationCIDRs = destinationCIDRs
+	}
+	return rule, nil
+}
+
+// MustNewEgressRule returns an EgressRule for the specified port
+// range. If no explicit destination ranges are specified, there is
+// no restriction on where outgoing traffic is sent.
+// The method will panic if there is an error.
+func MustNewEgressRule(protocol string, from, to int, destinationCIDRs ...string) EgressRule {
+	rule, err := NewEgressRule(protocol, from, to, destinationCIDRs...)
+	if err != nil {
+		panic(err)
+	}
+	return rule
+}
+
+// String is the string representation of EgressRule.
+func (r EgressRule) String() string {
+	destination := ""
+	to := strings.Join(r.DestinationCIDRs, ",")
+	if to != "" && to != "0.0.0.0/0" {
+		destination = " to " + to
+	}
+	if r.FromPort == r.ToPort {
+		return fmt.Sprintf("%d/%s%s", r.FromPort, strings.ToLower(r.Protocol), destination)
+	}
+	return fmt.Sprintf("%d-%d/%s%s", r.FromPort, r.ToPort, strings.ToLower(r.Protocol), destination)
+}
+
+// GoString is used to print values passed as an operand to a %#v format.
+func (r EgressRule) GoString() string {
+	return r.String()
+}
+
+type EgressRuleSlice []EgressRule
+
+func (p EgressRuleSlice) Len() int      { return len(p) }
+func (p EgressRuleSlice) Swap(i, j int) { p[i], p[j] = p[j], p[i] }
+func (p EgressRuleSlice) Less(i, j int) bool {
+	p1 := p[i]
+	p2 := p[j]
+	if p1.Protocol != p2.Protocol {
+		return p1.Protocol < p2.Protocol
+	}
+	if p1.FromPort != p2.FromPort {
+		return p1.FromPort < p2.FromPort
+	}
+	if p1.ToPort != p2.ToPort {
+		return p1.ToPort < p2.ToPort
+	}
+	d1 := strings.Join(p1.DestinationCIDRs, ",")
+	d2 := strings.Join(p2.DestinationCIDRs, ",")
+	return d1 < d2
+}
+
+// SortEgressRules sorts the given rules, first by protocol, then by ports.
+func SortEgressRules(egressRules []EgressRule) {
+	sort.Sort(EgressRuleSlice(egressRules))
+}
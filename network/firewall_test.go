@@ -72,3 +72,41 @@ func (*FirewallSuite) TestNewIngressRuleBadCIDR(c *gc.C) {
 	_, err := network.NewIngressRule("tcp", 80, 100, "0.0.0.0/0", "192.168.0/24")
 	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 192.168.0/24")
 }
+
+func (*FirewallSuite) TestEgressRuleStrings(c *gc.C) {
+	rule := network.MustNewEgressRule("tcp", 443, 443)
+	c.Assert(rule.String(), gc.Equals, "443/tcp")
+	c.Assert(rule.GoString(), gc.Equals, "443/tcp")
+
+	rule = network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0")
+	c.Assert(rule.String(), gc.Equals, "53/udp")
+
+	rule = network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8", "192.168.1.0/24")
+	c.Assert(rule.String(), gc.Equals, "5432-5433/tcp to 10.0.0.0/8,192.168.1.0/24")
+	c.Assert(rule.GoString(), gc.Equals, "5432-5433/tcp to 10.0.0.0/8,192.168.1.0/24")
+}
+
+func (*FirewallSuite) TestSortEgressRules(c *gc.C) {
+	rule1 := network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8")
+	rule2 := network.MustNewEgressRule("tcp", 443, 443, "192.168.1.0/24")
+	rule3 := network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8")
+	rule4 := network.MustNewEgressRule("tcp", 80, 80)
+
+	rules := []network.EgressRule{rule1, rule2, rule3, rule4}
+	network.SortEgressRules(rules)
+	c.Assert(rules, gc.DeepEquals, []network.EgressRule{rule4, rule3, rule2, rule1})
+}
+
+func (*FirewallSuite) TestNewEgressRule(c *gc.C) {
+	rule, err := network.NewEgressRule("tcp", 80, 100, "10.0.0.0/8")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.PortRange, gc.Equals, network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 100})
+	c.Assert(rule.DestinationCIDRs, jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	rule, err = network.NewEgressRule("tcp", 80, 100)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.DestinationCIDRs, gc.IsNil)
+
+	_, err = network.NewEgressRule("tcp", 80, 100, "10.0.0/8")
+	c.Assert(err, gc.ErrorMatches, "invalid CIDR address: 10.0.0/8")
+}
@@ -370,6 +370,9 @@ func (env *azureEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.Tags,
 		constraints.VirtType,
+		constraints.Zones,
+		constraints.RootDiskSource,
+		constraints.AllocatePublicIP,
 	})
 	validator.RegisterVocabulary(
 		constraints.Arch,
@@ -85,8 +85,8 @@ func (s *environSuite) TestUnsupportedConstraints(c *gc.C) {
 	c.Check(validator, gc.NotNil)
 
 	unsupported, err := validator.Validate(constraints.MustParse(
-		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm",
+		"arch=amd64 tags=foo cpu-power=100 virt-type=kvm zones=az1 root-disk-source=volume allocate-public-ip=false",
 	))
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"tags", "virt-type", "zones", "root-disk-source", "allocate-public-ip"})
 }
@@ -12,6 +12,9 @@ var unsupportedConstraints = []string{
 	constraints.InstanceType,
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.Zones,
+	constraints.RootDiskSource,
+	constraints.AllocatePublicIP,
 }
 
 // ConstraintsValidator returns a Validator instance which
@@ -5,7 +5,11 @@ package common
 
 import (
 	"sort"
+	"strings"
 
+	"github.com/juju/errors"
+
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 )
@@ -164,3 +168,44 @@ func DistributeInstances(env ZonedEnviron, candidates, group []instance.Id) ([]i
 	}
 	return eligible, nil
 }
+
+// CheckZoneConstraint returns an error if the specified availability
+// zone is not one of those permitted by the zones constraint, if any.
+func CheckZoneConstraint(zone string, cons constraints.Value) error {
+	if !cons.HasZones() {
+		return nil
+	}
+	for _, allowed := range *cons.Zones {
+		if zone == allowed {
+			return nil
+		}
+	}
+	return errors.Errorf(
+		"availability zone %q not allowed by zones constraint %q",
+		zone, strings.Join(*cons.Zones, ","),
+	)
+}
+
+// FilterZonesByConstraints returns the specified availability zones,
+// in their original order, that are permitted by the zones constraint.
+// If there is no zones constraint, all of the zones are returned. If
+// none of the zones is permitted, an error satisfying errors.IsNotFound
+// is returned.
+func FilterZonesByConstraints(zones []string, cons constraints.Value) ([]string, error) {
+	if !cons.HasZones() {
+		return zones, nil
+	}
+	var result []string
+	for _, zone := range zones {
+		if CheckZoneConstraint(zone, cons) == nil {
+			result = append(result, zone)
+		}
+	}
+	if len(result) == 0 {
+		return nil, errors.NotFoundf(
+			"availability zones matching zones constraint %q",
+			strings.Join(*cons.Zones, ","),
+		)
+	}
+	return result, nil
+}
@@ -6,9 +6,11 @@ package common_test
 import (
 	"fmt"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
@@ -254,3 +256,29 @@ func (s *AvailabilityZoneSuite) TestDistributeInstances(c *gc.C) {
 		c.Assert(eligible, jc.SameContents, test.eligible)
 	}
 }
+
+func (s *AvailabilityZoneSuite) TestCheckZoneConstraint(c *gc.C) {
+	err := common.CheckZoneConstraint("az1", constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cons := constraints.MustParse("zones=az1,az2")
+	err = common.CheckZoneConstraint("az2", cons)
+	c.Assert(err, jc.ErrorIsNil)
+	err = common.CheckZoneConstraint("az3", cons)
+	c.Assert(err, gc.ErrorMatches, `availability zone "az3" not allowed by zones constraint "az1,az2"`)
+}
+
+func (s *AvailabilityZoneSuite) TestFilterZonesByConstraints(c *gc.C) {
+	zones := []string{"az1", "az2", "az3"}
+	filtered, err := common.FilterZonesByConstraints(zones, constraints.Value{})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filtered, jc.DeepEquals, zones)
+
+	filtered, err = common.FilterZonesByConstraints(zones, constraints.MustParse("zones=az3,az1"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(filtered, jc.DeepEquals, []string{"az1", "az3"})
+
+	_, err = common.FilterZonesByConstraints(zones, constraints.MustParse("zones=az4"))
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+	c.Assert(err, gc.ErrorMatches, `availability zones matching zones constraint "az4" not found`)
+}
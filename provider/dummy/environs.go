@@ -40,6 +40,7 @@ import (
 	"github.com/juju/utils/arch"
 	"github.com/juju/utils/clock"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/environschema.v1"
@@ -245,6 +246,8 @@ type environState struct {
 	maxAddr        int // maximum allocated address last byte
 	insts          map[instance.Id]*dummyInstance
 	globalRules    network.IngressRuleSlice
+	globalEgress   egressRules
+	modelRules     map[network.PortRange]set.Strings
 	bootstrapped   bool
 	apiListener    net.Listener
 	apiServer      *apiserver.Server
@@ -421,7 +424,13 @@ func newState(name string, ops chan<- Operation, newStatePolicy state.NewPolicyF
 		ops:            ops,
 		newStatePolicy: newStatePolicy,
 		insts:          make(map[instance.Id]*dummyInstance),
-		creator:        string(buf),
+		globalEgress:   make(egressRules),
+		// Like the real providers, allow SSH from anywhere
+		// unless restricted by the model's firewall rules.
+		modelRules: map[network.PortRange]set.Strings{
+			{Protocol: "tcp", FromPort: 22, ToPort: 22}: set.NewStrings("0.0.0.0/0"),
+		},
+		creator: string(buf),
 	}
 	return s
 }
@@ -1490,6 +1499,118 @@ func (e *environ) IngressRules() (rules []network.IngressRule, err error) {
 	return
 }
 
+// OpenEgressPorts is specified in environs.EgressFirewaller.
+func (e *environ) OpenEgressPorts(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for opening egress ports on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.globalEgress.open(rules)
+	return nil
+}
+
+// CloseEgressPorts is specified in environs.EgressFirewaller.
+func (e *environ) CloseEgressPorts(rules []network.EgressRule) error {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return fmt.Errorf("invalid firewall mode %q for closing egress ports on model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	estate.globalEgress.close(rules)
+	return nil
+}
+
+// EgressRules is specified in environs.EgressFirewaller.
+func (e *environ) EgressRules() ([]network.EgressRule, error) {
+	if mode := e.ecfg().FirewallMode(); mode != config.FwGlobal {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from model", mode)
+	}
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	return estate.globalEgress.rules(), nil
+}
+
+// OpenModelPorts is specified in environs.ModelFirewaller.
+func (e *environ) OpenModelPorts(rules []network.IngressRule) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, rule := range rules {
+		cidrs, ok := estate.modelRules[rule.PortRange]
+		if !ok {
+			cidrs = set.NewStrings()
+			estate.modelRules[rule.PortRange] = cidrs
+		}
+		if len(rule.SourceCIDRs) == 0 {
+			cidrs.Add("0.0.0.0/0")
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Add(cidr)
+		}
+	}
+	return nil
+}
+
+// CloseModelPorts is specified in environs.ModelFirewaller.
+func (e *environ) CloseModelPorts(rules []network.IngressRule) error {
+	estate, err := e.state()
+	if err != nil {
+		return err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for _, rule := range rules {
+		cidrs, ok := estate.modelRules[rule.PortRange]
+		if !ok {
+			continue
+		}
+		if len(rule.SourceCIDRs) == 0 {
+			cidrs.Remove("0.0.0.0/0")
+		}
+		for _, cidr := range rule.SourceCIDRs {
+			cidrs.Remove(cidr)
+		}
+		if cidrs.IsEmpty() {
+			delete(estate.modelRules, rule.PortRange)
+		}
+	}
+	return nil
+}
+
+// ModelIngressRules is specified in environs.ModelFirewaller.
+func (e *environ) ModelIngressRules() (rules []network.IngressRule, err error) {
+	estate, err := e.state()
+	if err != nil {
+		return nil, err
+	}
+	estate.mu.Lock()
+	defer estate.mu.Unlock()
+	for portRange, cidrs := range estate.modelRules {
+		rules = append(rules, network.IngressRule{
+			PortRange:   portRange,
+			SourceCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &dummy
 }
@@ -1497,6 +1618,7 @@ func (*environ) Provider() environs.EnvironProvider {
 type dummyInstance struct {
 	state        *environState
 	rules        network.IngressRuleSlice
+	egress       egressRules
 	id           instance.Id
 	status       string
 	machineId    string
@@ -1674,6 +1796,117 @@ func (inst *dummyInstance) IngressRules(machineId string) (rules []network.Ingre
 	return
 }
 
+// OpenEgressPorts is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) OpenEgressPorts(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress ports on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("OpenEgressPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("OpenEgressPorts"); err != nil {
+		return err
+	}
+	if inst.egress == nil {
+		inst.egress = make(egressRules)
+	}
+	inst.egress.open(rules)
+	return nil
+}
+
+// CloseEgressPorts is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) CloseEgressPorts(machineId string, rules []network.EgressRule) error {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress ports on instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("CloseEgressPorts with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("CloseEgressPorts"); err != nil {
+		return err
+	}
+	inst.egress.close(rules)
+	return nil
+}
+
+// EgressRules is specified in instance.EgressFirewaller.
+func (inst *dummyInstance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	defer delay()
+	if inst.firewallMode != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.firewallMode)
+	}
+	if inst.machineId != machineId {
+		panic(fmt.Errorf("EgressRules with mismatched machine id, expected %q got %q", inst.machineId, machineId))
+	}
+	inst.state.mu.Lock()
+	defer inst.state.mu.Unlock()
+	if err := inst.checkBroken("EgressRules"); err != nil {
+		return nil, err
+	}
+	return inst.egress.rules(), nil
+}
+
+// egressRules holds the destination CIDRs to which outgoing
+// traffic is allowed, keyed by port range.
+type egressRules map[network.PortRange]set.Strings
+
+func (r egressRules) open(rules []network.EgressRule) {
+	for _, rule := range rules {
+		cidrs := rule.DestinationCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{"0.0.0.0/0"}
+		}
+		existing, ok := r[rule.PortRange]
+		if !ok {
+			existing = set.NewStrings()
+			r[rule.PortRange] = existing
+		}
+		for _, cidr := range cidrs {
+			existing.Add(cidr)
+		}
+	}
+}
+
+func (r egressRules) close(rules []network.EgressRule) {
+	for _, rule := range rules {
+		existing, ok := r[rule.PortRange]
+		if !ok {
+			continue
+		}
+		cidrs := rule.DestinationCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range cidrs {
+			existing.Remove(cidr)
+		}
+		if existing.IsEmpty() {
+			delete(r, rule.PortRange)
+		}
+	}
+}
+
+func (r egressRules) rules() []network.EgressRule {
+	var result []network.EgressRule
+	for portRange, cidrs := range r {
+		result = append(result, network.EgressRule{
+			PortRange:        portRange,
+			DestinationCIDRs: cidrs.SortedValues(),
+		})
+	}
+	network.SortEgressRules(result)
+	return result
+}
+
 // providerDelay controls the delay before dummy responds.
 // non empty values in JUJU_DUMMY_DELAY will be parsed as
 // time.Durations into this value.
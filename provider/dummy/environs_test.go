@@ -156,6 +156,83 @@ func (s *suite) TestAvailabilityZone(c *gc.C) {
 	c.Check(hwc.AvailabilityZone, gc.IsNil)
 }
 
+func (s *suite) TestInstanceEgressRules(c *gc.C) {
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	inst, _ := jujutesting.AssertStartInstance(c, e, s.ControllerUUID, "0")
+	egress, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	err := egress.OpenEgressPorts("0", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 80, 80),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = egress.OpenEgressPorts("0", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := egress.EgressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+
+	err = egress.CloseEgressPorts("0", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 80),
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egress.EgressRules("0")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "192.168.0.0/16"),
+	})
+
+	// Egress rules cannot be applied to the whole model in
+	// instance firewall mode.
+	err = e.(environs.EgressFirewaller).OpenEgressPorts(rules)
+	c.Assert(err, gc.ErrorMatches, `invalid firewall mode "instance" for opening egress ports on model`)
+}
+
+func (s *suite) TestGlobalEgressRules(c *gc.C) {
+	s.PatchValue(&s.TestConfig, s.TestConfig.Merge(testing.Attrs{"firewall-mode": "global"}))
+	e := s.bootstrapTestEnviron(c)
+	defer func() {
+		err := e.Destroy()
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+
+	egress, ok := e.(environs.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	err := egress.OpenEgressPorts([]network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err := egress.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+
+	err = egress.CloseEgressPorts([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = egress.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+}
+
 func (s *suite) TestSupportsSpaces(c *gc.C) {
 	e := s.bootstrapTestEnviron(c)
 	defer func() {
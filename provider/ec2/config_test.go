@@ -273,6 +273,10 @@ var configTests = []configTest{
 			"ssl-hostname-verification": false,
 		},
 		err: ".*disabling ssh-hostname-verification is not supported",
+	}, {
+		config: attrs{
+			"egress-default-deny": true,
+		},
 	}, {
 		config: attrs{
 			"future": "hammerstein",
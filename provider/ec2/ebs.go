@@ -5,6 +5,7 @@ package ec2
 
 import (
 	"regexp"
+	"strconv"
 	"sync"
 	"time"
 
@@ -61,6 +62,7 @@ const (
 	deviceInUse        = "InvalidDevice.InUse"
 	attachmentNotFound = "InvalidAttachment.NotFound"
 	volumeNotFound     = "InvalidVolume.NotFound"
+	snapshotNotFound   = "InvalidSnapshot.NotFound"
 	incorrectState     = "IncorrectState"
 )
 
@@ -250,6 +252,8 @@ type ebsVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*ebsVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*ebsVolumeSource)(nil)
+var _ storage.VolumeResizer = (*ebsVolumeSource)(nil)
 
 // parseVolumeOptions uses storage volume parameters to make a struct used to create volumes.
 func parseVolumeOptions(size uint64, attrs map[string]interface{}) (_ ec2.CreateVolume, _ error) {
@@ -443,6 +447,55 @@ func (v *ebsVolumeSource) DescribeVolumes(volIds []string) ([]storage.DescribeVo
 	return results, nil
 }
 
+// ResizeVolumes is specified on the storage.VolumeResizer interface.
+func (v *ebsVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+// modifyVolumeResp is the response to a ModifyVolume request.
+type modifyVolumeResp struct {
+	RequestId          string `xml:"requestId"`
+	VolumeModification struct {
+		VolumeId          string `xml:"volumeId"`
+		ModificationState string `xml:"modificationState"`
+		TargetSize        uint64 `xml:"targetSize"`
+	} `xml:"volumeModification"`
+}
+
+func (v *ebsVolumeSource) resizeOneVolume(p storage.VolumeResizeParams) (uint64, error) {
+	resp, err := v.env.ec2.Volumes([]string{p.VolumeId}, nil)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	if len(resp.Volumes) != 1 {
+		return 0, errors.NotFoundf("volume %q", p.VolumeId)
+	}
+	// EBS volumes cannot be shrunk, so there is nothing to do if
+	// the volume is already large enough.
+	currentSize := gibToMib(uint64(resp.Volumes[0].Size))
+	if currentSize >= p.Size {
+		return currentSize, nil
+	}
+	// ModifyVolume is not supported by the amz.v3 client.
+	var modifyResp modifyVolumeResp
+	if err := query(v.env.ec2, "ModifyVolume", map[string]string{
+		"VolumeId": p.VolumeId,
+		"Size":     strconv.FormatUint(mibToGib(p.Size), 10),
+	}, &modifyResp); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return gibToMib(modifyResp.VolumeModification.TargetSize), nil
+}
+
 // DestroyVolumes is specified on the storage.VolumeSource interface.
 func (v *ebsVolumeSource) DestroyVolumes(volIds []string) ([]error, error) {
 	return destroyVolumes(v.env.ec2, volIds), nil
@@ -779,6 +832,168 @@ func describeVolume(client *ec2.EC2, volumeId string) (*ec2.Volume, error) {
 	return &resp.Volumes[0], nil
 }
 
+// CreateVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting volume %v", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) createVolumeSnapshot(p storage.VolumeSnapshotParams) (*storage.VolumeSnapshot, error) {
+	resp, err := v.env.ec2.CreateSnapshot(p.VolumeId, resourceName(p.Volume, v.envName))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagResources(v.env.ec2, resourceTags, resp.Id); err != nil {
+		return nil, errors.Annotate(err, "tagging snapshot")
+	}
+	snapshot := ec2SnapshotToVolumeSnapshot(resp.Snapshot)
+	return &snapshot, nil
+}
+
+// ListVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+//
+// Snapshots are identified by the Name tag set when they were created,
+// which is derived from the volume tag, so that they are still found
+// once the volume has been replaced by restoring one of them.
+func (v *ebsVolumeSource) ListVolumeSnapshots(volumes []names.VolumeTag) ([]storage.ListVolumeSnapshotsResult, error) {
+	results := make([]storage.ListVolumeSnapshotsResult, len(volumes))
+	if len(volumes) == 0 {
+		return results, nil
+	}
+	snapshotNames := make([]string, len(volumes))
+	for i, volume := range volumes {
+		snapshotNames[i] = resourceName(volume, v.envName)
+	}
+	filter := ec2.NewFilter()
+	filter.Add("tag:"+tagName, snapshotNames...)
+	filter.Add("tag:"+tags.JujuModel, v.modelUUID)
+	resp, err := v.env.ec2.Snapshots(nil, filter)
+	if err != nil {
+		return nil, errors.Annotate(err, "querying snapshots")
+	}
+	byName := make(map[string][]storage.VolumeSnapshot)
+	for _, snapshot := range resp.Snapshots {
+		for _, tag := range snapshot.Tags {
+			if tag.Key != tagName {
+				continue
+			}
+			byName[tag.Value] = append(byName[tag.Value], ec2SnapshotToVolumeSnapshot(snapshot))
+		}
+	}
+	for i, name := range snapshotNames {
+		results[i].Snapshots = byName[name]
+	}
+	return results, nil
+}
+
+// DeleteVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if _, err := v.env.ec2.DeleteSnapshots([]string{snapshotId}); err != nil {
+			if ec2ErrCode(err) == snapshotNotFound {
+				logger.Tracef("Ignoring error destroying snapshot %q: %v", snapshotId, err)
+				continue
+			}
+			results[i] = errors.Annotatef(err, "deleting snapshot %v", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// RestoreVolumeSnapshots is specified on the storage.VolumeSnapshotter interface.
+func (v *ebsVolumeSource) RestoreVolumeSnapshots(params []storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error) {
+	results := make([]storage.RestoreVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		info, err := v.restoreVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "restoring volume %v from snapshot %v", p.VolumeId, p.SnapshotId,
+			)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *ebsVolumeSource) restoreVolumeSnapshot(p storage.RestoreVolumeSnapshotParams) (_ *storage.VolumeInfo, err error) {
+	oldVolume, err := describeVolume(v.env.ec2, p.VolumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if len(oldVolume.Attachments) > 0 {
+		return nil, errors.Errorf("volume %v is attached", p.VolumeId)
+	}
+
+	vol, err := parseVolumeOptions(p.Size, p.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	vol.AvailZone = oldVolume.AvailZone
+	vol.SnapshotId = p.SnapshotId
+	resp, err := v.env.ec2.CreateVolume(vol)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeId := resp.Id
+	defer func() {
+		if err == nil {
+			return
+		}
+		if _, err := v.env.ec2.DeleteVolume(volumeId); err != nil {
+			logger.Errorf("error cleaning up volume %v: %v", volumeId, err)
+		}
+	}()
+
+	resourceTags := make(map[string]string)
+	for k, v := range p.ResourceTags {
+		resourceTags[k] = v
+	}
+	resourceTags[tagName] = resourceName(p.Volume, v.envName)
+	if err := tagResources(v.env.ec2, resourceTags, volumeId); err != nil {
+		return nil, errors.Annotate(err, "tagging volume")
+	}
+	newVolume, err := v.waitVolumeCreated(volumeId)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.VolumeInfo{
+		VolumeId:   volumeId,
+		Size:       gibToMib(uint64(newVolume.Size)),
+		Persistent: true,
+	}, nil
+}
+
+func ec2SnapshotToVolumeSnapshot(snapshot ec2.Snapshot) storage.VolumeSnapshot {
+	result := storage.VolumeSnapshot{
+		SnapshotId: snapshot.Id,
+		VolumeId:   snapshot.VolumeId,
+		Status:     snapshot.Status,
+	}
+	// AWS sizes are GiB, Juju sizes are MiB.
+	if size, err := strconv.ParseUint(snapshot.VolumeSize, 10, 64); err == nil {
+		result.Size = gibToMib(size)
+	}
+	if created, err := time.Parse(time.RFC3339, snapshot.StartTime); err == nil {
+		result.Created = created
+	}
+	return result
+}
+
 type instanceCache map[string]ec2.Instance
 
 func (c instanceCache) update(ec2client *ec2.EC2, ids ...string) error {
@@ -889,12 +1104,15 @@ func minRootDiskSizeMiB(series string) uint64 {
 // getBlockDeviceMappings translates constraints into BlockDeviceMappings.
 //
 // The first entry is always the root disk mapping, followed by instance
-// stores (ephemeral disks).
+// stores (ephemeral disks). If rootDisk is non-nil, it specifies the EBS
+// storage pool from which the root disk's volume type is taken; otherwise
+// the root-disk-source constraint, if any, is taken to be the volume type.
 func getBlockDeviceMappings(
 	cons constraints.Value,
 	series string,
 	controller bool,
-) []ec2.BlockDeviceMapping {
+	rootDisk *storage.VolumeParams,
+) ([]ec2.BlockDeviceMapping, error) {
 	minRootDiskSizeMiB := minRootDiskSizeMiB(series)
 	rootDiskSizeMiB := minRootDiskSizeMiB
 	if controller {
@@ -912,10 +1130,23 @@ func getBlockDeviceMappings(
 		}
 	}
 	// The first block device is for the root disk.
+	rootDiskSizeGiB := mibToGib(rootDiskSizeMiB)
 	blockDeviceMappings := []ec2.BlockDeviceMapping{{
 		DeviceName: rootDiskDeviceName,
-		VolumeSize: int64(mibToGib(rootDiskSizeMiB)),
+		VolumeSize: int64(rootDiskSizeGiB),
 	}}
+	rootDiskConfig, err := rootDiskEbsConfig(cons, rootDisk)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if rootDiskConfig != nil {
+		iops := uint64(rootDiskConfig.iops) * rootDiskSizeGiB
+		if iops > maxProvisionedIops {
+			iops = maxProvisionedIops
+		}
+		blockDeviceMappings[0].VolumeType = rootDiskConfig.volumeType
+		blockDeviceMappings[0].IOPS = int64(iops)
+	}
 
 	// Not all machines have this many instance stores.
 	// Instances will be started with as many of the
@@ -934,7 +1165,39 @@ func getBlockDeviceMappings(
 		DeviceName:  "/dev/sde",
 	}}...)
 
-	return blockDeviceMappings
+	return blockDeviceMappings, nil
+}
+
+// rootDiskEbsConfig returns the EBS configuration for the root disk, as
+// specified by the root disk storage pool or root-disk-source constraint,
+// or nil if neither is specified.
+func rootDiskEbsConfig(cons constraints.Value, rootDisk *storage.VolumeParams) (*ebsConfig, error) {
+	var attrs map[string]interface{}
+	switch {
+	case rootDisk != nil:
+		if rootDisk.Provider != EBS_ProviderType {
+			return nil, errors.NotSupportedf("root disk with storage provider %q", rootDisk.Provider)
+		}
+		attrs = rootDisk.Attributes
+	case cons.HasRootDiskSource():
+		attrs = map[string]interface{}{EBS_VolumeType: *cons.RootDiskSource}
+	default:
+		return nil, nil
+	}
+	ebsConfig, err := newEbsConfig(attrs)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid root disk source")
+	}
+	if ebsConfig.encrypted {
+		return nil, errors.NotSupportedf("encrypted root disk")
+	}
+	if ebsConfig.iops > maxProvisionedIopsSizeRatio {
+		return nil, errors.Errorf(
+			"specified IOPS ratio is %d/GiB, maximum is %d/GiB",
+			ebsConfig.iops, maxProvisionedIopsSizeRatio,
+		)
+	}
+	return ebsConfig, nil
 }
 
 // mibToGib converts mebibytes to gibibytes.
@@ -9,13 +9,16 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/clock"
+	"github.com/juju/utils/set"
 	awsec2 "gopkg.in/amz.v3/ec2"
 	"gopkg.in/amz.v3/ec2/ec2test"
 	gc "gopkg.in/check.v1"
@@ -708,6 +711,354 @@ func (s *ebsSuite) testDetachVolumesDetachedState(c *gc.C, errorCode string) {
 	c.Assert(errs, jc.DeepEquals, []error{nil})
 }
 
+func (s *ebsSuite) TestResizeVolumes(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	info := s.createDetachedVolume(c, vs)
+	var modified url.Values
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		query := resp.Request.URL.Query()
+		if query.Get("Action") != "ModifyVolume" {
+			return nil
+		}
+		// The ec2test server does not support ModifyVolume.
+		modified = query
+		var value modifyVolumeResp
+		value.VolumeModification.VolumeId = query.Get("VolumeId")
+		value.VolumeModification.ModificationState = "modifying"
+		value.VolumeModification.TargetSize = query.Get("Size")
+		resp.StatusCode = http.StatusOK
+		return replaceResponseBody(resp, value)
+	}
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: info.VolumeId,
+		Size:     20*1024 - 1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 20 * 1024}})
+	c.Assert(modified.Get("VolumeId"), gc.Equals, info.VolumeId)
+	c.Assert(modified.Get("Size"), gc.Equals, "20")
+}
+
+func (s *ebsSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	info := s.createDetachedVolume(c, vs)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		if resp.Request.URL.Query().Get("Action") == "ModifyVolume" {
+			c.Fatalf("unexpected ModifyVolume request")
+		}
+		return nil
+	}
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: info.VolumeId,
+		Size:     5 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 10 * 1024}})
+}
+
+func (s *ebsSuite) TestResizeVolumesError(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	info := s.createDetachedVolume(c, vs)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		if resp.Request.URL.Query().Get("Action") != "ModifyVolume" {
+			return nil
+		}
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code:    "IncorrectModificationState",
+			Message: "volume is being modified",
+		}}})
+	}
+
+	results, err := vs.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: info.VolumeId,
+		Size:     20 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`resizing volume "`+info.VolumeId+`": volume is being modified \(IncorrectModificationState\)`)
+}
+
+// modifyVolumeResp is the response to a ModifyVolume request.
+type modifyVolumeResp struct {
+	XMLName            xml.Name `xml:"ModifyVolumeResponse"`
+	VolumeModification struct {
+		VolumeId          string `xml:"volumeId"`
+		ModificationState string `xml:"modificationState"`
+		TargetSize        string `xml:"targetSize"`
+	} `xml:"volumeModification"`
+}
+
+// fakeSnapshots implements the EBS snapshot API calls, which the
+// ec2test server does not support, by replacing the proxied responses.
+type fakeSnapshots struct {
+	snapshots []awsec2.Snapshot
+	deleted   []string
+}
+
+func (f *fakeSnapshots) modifyResponse(resp *http.Response) error {
+	query := resp.Request.URL.Query()
+	var value interface{}
+	switch query.Get("Action") {
+	case "CreateSnapshot":
+		snapshot := awsec2.Snapshot{
+			Id:         fmt.Sprintf("snap-%d", len(f.snapshots)),
+			VolumeId:   query.Get("VolumeId"),
+			VolumeSize: "10",
+			Status:     "pending",
+			StartTime:  "2017-11-01T10:00:00.000Z",
+		}
+		f.snapshots = append(f.snapshots, snapshot)
+		value = awsec2.CreateSnapshotResp{Snapshot: snapshot}
+	case "CreateTags":
+		if !strings.HasPrefix(query.Get("ResourceId.1"), "snap-") {
+			return nil
+		}
+		for i := range f.snapshots {
+			if f.snapshots[i].Id != query.Get("ResourceId.1") {
+				continue
+			}
+			for n := 1; query.Get(fmt.Sprintf("Tag.%d.Key", n)) != ""; n++ {
+				f.snapshots[i].Tags = append(f.snapshots[i].Tags, awsec2.Tag{
+					Key:   query.Get(fmt.Sprintf("Tag.%d.Key", n)),
+					Value: query.Get(fmt.Sprintf("Tag.%d.Value", n)),
+				})
+			}
+		}
+		value = awsec2.SimpleResp{}
+	case "DescribeSnapshots":
+		var matching []awsec2.Snapshot
+		for _, snapshot := range f.snapshots {
+			if snapshotMatchesFilters(snapshot, query) {
+				matching = append(matching, snapshot)
+			}
+		}
+		value = awsec2.SnapshotsResp{Snapshots: matching}
+	case "DeleteSnapshot":
+		f.deleted = append(f.deleted, query.Get("SnapshotId.1"))
+		value = awsec2.SimpleResp{}
+	default:
+		return nil
+	}
+	resp.StatusCode = http.StatusOK
+	return replaceResponseBody(resp, value)
+}
+
+// snapshotMatchesFilters reports whether the snapshot has a tag
+// matching one of the values of every "tag:" filter in the query.
+func snapshotMatchesFilters(snapshot awsec2.Snapshot, query url.Values) bool {
+	for n := 1; query.Get(fmt.Sprintf("Filter.%d.Name", n)) != ""; n++ {
+		key := strings.TrimPrefix(query.Get(fmt.Sprintf("Filter.%d.Name", n)), "tag:")
+		values := set.NewStrings()
+		for m := 1; query.Get(fmt.Sprintf("Filter.%d.Value.%d", n, m)) != ""; m++ {
+			values.Add(query.Get(fmt.Sprintf("Filter.%d.Value.%d", n, m)))
+		}
+		matched := false
+		for _, tag := range snapshot.Tags {
+			if tag.Key == key && values.Contains(tag.Value) {
+				matched = true
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *ebsSuite) createDetachedVolume(c *gc.C, vs storage.VolumeSource) storage.VolumeInfo {
+	// The instance only determines the volume's availability zone;
+	// the volume is not attached to it.
+	instanceId := s.srv.ec2srv.NewInstances(1, "m1.medium", imageId, ec2test.Running, nil)[0]
+	results, err := vs.CreateVolumes([]storage.VolumeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		Size:     10 * 1024,
+		Provider: ec2.EBS_ProviderType,
+		Attachment: &storage.VolumeAttachmentParams{
+			AttachmentParams: storage.AttachmentParams{
+				InstanceId: instance.Id(instanceId),
+			},
+		},
+		ResourceTags: map[string]string{
+			tags.JujuModel: s.modelConfig.UUID(),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	return results[0].Volume.VolumeInfo
+}
+
+func (s *ebsSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	info := s.createDetachedVolume(c, vs)
+	fake := &fakeSnapshots{}
+	s.srv.proxy.ModifyResponse = fake.modifyResponse
+
+	results, err := vs.(storage.VolumeSnapshotter).CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: info.VolumeId,
+		ResourceTags: map[string]string{
+			tags.JujuModel: s.modelConfig.UUID(),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snap-0",
+			VolumeId:   info.VolumeId,
+			Size:       10 * 1024,
+			Status:     "pending",
+			Created:    time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC),
+		},
+	}})
+	c.Assert(fake.snapshots, gc.HasLen, 1)
+	c.Assert(fake.snapshots[0].Tags, jc.SameContents, []awsec2.Tag{
+		{"juju-model-uuid", s.modelConfig.UUID()},
+		{"Name", "juju-testenv-volume-0"},
+	})
+}
+
+func (s *ebsSuite) TestListVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	fake := &fakeSnapshots{snapshots: []awsec2.Snapshot{{
+		Id:       "snap-0",
+		VolumeId: "vol-0",
+		Status:   "completed",
+		Tags: []awsec2.Tag{
+			{"juju-model-uuid", s.modelConfig.UUID()},
+			{"Name", "juju-testenv-volume-0"},
+		},
+	}, {
+		// Taken before volume 0 was restored, so its provider
+		// volume ID differs.
+		Id:       "snap-1",
+		VolumeId: "vol-old",
+		Status:   "completed",
+		Tags: []awsec2.Tag{
+			{"juju-model-uuid", s.modelConfig.UUID()},
+			{"Name", "juju-testenv-volume-0"},
+		},
+	}, {
+		// Belongs to another model.
+		Id:       "snap-2",
+		VolumeId: "vol-0",
+		Status:   "completed",
+		Tags: []awsec2.Tag{
+			{"juju-model-uuid", "something-else"},
+			{"Name", "juju-testenv-volume-0"},
+		},
+	}, {
+		Id:       "snap-3",
+		VolumeId: "vol-1",
+		Status:   "pending",
+		Tags: []awsec2.Tag{
+			{"juju-model-uuid", s.modelConfig.UUID()},
+			{"Name", "juju-testenv-volume-1"},
+		},
+	}}}
+	s.srv.proxy.ModifyResponse = fake.modifyResponse
+
+	results, err := vs.(storage.VolumeSnapshotter).ListVolumeSnapshots([]names.VolumeTag{
+		names.NewVolumeTag("0"),
+		names.NewVolumeTag("1"),
+		names.NewVolumeTag("2"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ListVolumeSnapshotsResult{{
+		Snapshots: []storage.VolumeSnapshot{
+			{SnapshotId: "snap-0", VolumeId: "vol-0", Status: "completed"},
+			{SnapshotId: "snap-1", VolumeId: "vol-old", Status: "completed"},
+		},
+	}, {
+		Snapshots: []storage.VolumeSnapshot{
+			{SnapshotId: "snap-3", VolumeId: "vol-1", Status: "pending"},
+		},
+	}, {}})
+}
+
+func (s *ebsSuite) TestDeleteVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	fake := &fakeSnapshots{}
+	s.srv.proxy.ModifyResponse = fake.modifyResponse
+
+	errs, err := vs.(storage.VolumeSnapshotter).DeleteVolumeSnapshots([]string{"snap-0", "snap-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil, nil})
+	c.Assert(fake.deleted, jc.DeepEquals, []string{"snap-0", "snap-1"})
+}
+
+func (s *ebsSuite) TestDeleteVolumeSnapshotsNotFound(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	s.srv.proxy.ModifyResponse = func(resp *http.Response) error {
+		resp.StatusCode = http.StatusBadRequest
+		return replaceResponseBody(resp, ec2Errors{[]awsec2.Error{{
+			Code: "InvalidSnapshot.NotFound",
+		}}})
+	}
+	errs, err := vs.(storage.VolumeSnapshotter).DeleteVolumeSnapshots([]string{"snap-0"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(errs, jc.DeepEquals, []error{nil})
+}
+
+func (s *ebsSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	info := s.createDetachedVolume(c, vs)
+
+	results, err := vs.(storage.VolumeSnapshotter).RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Volume:     names.NewVolumeTag("0"),
+		VolumeId:   info.VolumeId,
+		SnapshotId: "snap-0",
+		Size:       info.Size,
+		ResourceTags: map[string]string{
+			tags.JujuModel: s.modelConfig.UUID(),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+	c.Assert(results[0].VolumeInfo, jc.DeepEquals, &storage.VolumeInfo{
+		VolumeId:   "vol-1",
+		Size:       10 * 1024,
+		Persistent: true,
+	})
+
+	// The original volume is left for the caller to destroy, once
+	// the replacement has been recorded.
+	ec2Client := ec2.StorageEC2(vs)
+	ec2Vols, err := ec2Client.Volumes(nil, nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ec2Vols.Volumes, gc.HasLen, 2)
+	for _, vol := range ec2Vols.Volumes {
+		c.Assert(vol.Tags, jc.SameContents, []awsec2.Tag{
+			{"juju-model-uuid", s.modelConfig.UUID()},
+			{"Name", "juju-testenv-volume-0"},
+		})
+	}
+}
+
+func (s *ebsSuite) TestRestoreVolumeSnapshotsAttached(c *gc.C) {
+	vs := s.volumeSource(c, nil)
+	params := s.setupAttachVolumesTest(c, vs, ec2test.Running)
+	_, err := vs.AttachVolumes(params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	results, err := vs.(storage.VolumeSnapshotter).RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Volume:     params[0].Volume,
+		VolumeId:   params[0].VolumeId,
+		SnapshotId: "snap-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "restoring volume vol-0 from snapshot snap-0: volume vol-0 is attached")
+}
+
 type blockDeviceMappingSuite struct {
 	testing.BaseSuite
 }
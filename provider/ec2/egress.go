@@ -0,0 +1,305 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/ec2"
+
+	"github.com/juju/juju/environs/config"
+	"github.com/juju/juju/network"
+)
+
+// The amz.v3 client only manages the ingress permissions of security
+// groups, so egress permissions are managed with query.
+
+// defaultEgressProtocol is the protocol of the permission which EC2
+// adds to every new VPC security group, allowing all outbound traffic.
+const defaultEgressProtocol = "-1"
+
+// egressPerm is an egress permission of a security group, allowing
+// traffic to the given IPv4 and IPv6 ranges and security groups.
+type egressPerm struct {
+	Protocol  string   `xml:"ipProtocol"`
+	FromPort  int      `xml:"fromPort"`
+	ToPort    int      `xml:"toPort"`
+	IPv4CIDRs []string `xml:"ipRanges>item>cidrIp"`
+	IPv6CIDRs []string `xml:"ipv6Ranges>item>cidrIpv6"`
+	GroupIds  []string `xml:"groups>item>groupId"`
+}
+
+// groupEgressInfo holds the egress permissions of a security group.
+type groupEgressInfo struct {
+	Id          string       `xml:"groupId"`
+	VPCId       string       `xml:"vpcId"`
+	EgressPerms []egressPerm `xml:"ipPermissionsEgress>item"`
+}
+
+type groupEgressResp struct {
+	RequestId string            `xml:"requestId"`
+	Groups    []groupEgressInfo `xml:"securityGroupInfo>item"`
+}
+
+// groupEgress returns the egress permissions of the given security
+// group. Only groups in a VPC have egress permissions.
+func groupEgress(client *ec2.EC2, g ec2.SecurityGroup) (groupEgressInfo, error) {
+	var resp groupEgressResp
+	if err := query(client, "DescribeSecurityGroups", map[string]string{
+		"GroupId.1": g.Id,
+	}, &resp); err != nil {
+		return groupEgressInfo{}, errors.Trace(err)
+	}
+	if len(resp.Groups) != 1 {
+		return groupEgressInfo{}, errors.NotFoundf("security group %q", g.Id)
+	}
+	info := resp.Groups[0]
+	if info.VPCId == "" {
+		return groupEgressInfo{}, errors.NotSupportedf("egress rules for EC2-Classic security group %q", g.Id)
+	}
+	return info, nil
+}
+
+// authorizeGroupEgress allows outbound traffic matching the given
+// permissions from the instances in the security group.
+func authorizeGroupEgress(client *ec2.EC2, g ec2.SecurityGroup, perms []egressPerm) error {
+	var resp ec2.SimpleResp
+	return query(client, "AuthorizeSecurityGroupEgress", egressPermParams(g, perms), &resp)
+}
+
+// revokeGroupEgress revokes the given egress permissions of the
+// security group.
+func revokeGroupEgress(client *ec2.EC2, g ec2.SecurityGroup, perms []egressPerm) error {
+	var resp ec2.SimpleResp
+	return query(client, "RevokeSecurityGroupEgress", egressPermParams(g, perms), &resp)
+}
+
+func egressPermParams(g ec2.SecurityGroup, perms []egressPerm) map[string]string {
+	params := map[string]string{"GroupId": g.Id}
+	for i, perm := range perms {
+		prefix := "IpPermissions." + strconv.Itoa(i+1)
+		params[prefix+".IpProtocol"] = perm.Protocol
+		if perm.Protocol != defaultEgressProtocol {
+			params[prefix+".FromPort"] = strconv.Itoa(perm.FromPort)
+			params[prefix+".ToPort"] = strconv.Itoa(perm.ToPort)
+		}
+		for j, cidr := range perm.IPv4CIDRs {
+			params[prefix+".IpRanges."+strconv.Itoa(j+1)+".CidrIp"] = cidr
+		}
+		for j, cidr := range perm.IPv6CIDRs {
+			params[prefix+".Ipv6Ranges."+strconv.Itoa(j+1)+".CidrIpv6"] = cidr
+		}
+		for j, groupId := range perm.GroupIds {
+			params[prefix+".Groups."+strconv.Itoa(j+1)+".GroupId"] = groupId
+		}
+	}
+	return params
+}
+
+// newEgressPermSet returns a set of all the permissions in the given
+// slice of egressPerms, with one entry per destination.
+func newEgressPermSet(ps []egressPerm) permSet {
+	m := make(permSet)
+	for _, p := range ps {
+		k := permKey{
+			protocol: p.Protocol,
+			fromPort: p.FromPort,
+			toPort:   p.ToPort,
+		}
+		for _, cidrs := range [][]string{p.IPv4CIDRs, p.IPv6CIDRs} {
+			for _, cidr := range cidrs {
+				k.ipAddr = cidr
+				m[k] = true
+			}
+		}
+		k.ipAddr = ""
+		for _, groupId := range p.GroupIds {
+			k.groupId = groupId
+			m[k] = true
+		}
+	}
+	return m
+}
+
+// egressPerms returns m as a slice of egress permissions.
+func (m permSet) egressPerms() (ps []egressPerm) {
+	for p := range m {
+		perm := egressPerm{
+			Protocol: p.protocol,
+			FromPort: p.fromPort,
+			ToPort:   p.toPort,
+		}
+		switch {
+		case p.ipAddr == "":
+			perm.GroupIds = []string{p.groupId}
+		case strings.Contains(p.ipAddr, ":"):
+			perm.IPv6CIDRs = []string{p.ipAddr}
+		default:
+			perm.IPv4CIDRs = []string{p.ipAddr}
+		}
+		ps = append(ps, perm)
+	}
+	return
+}
+
+func egressRulesToPerms(rules []network.EgressRule) []egressPerm {
+	m := make(permSet)
+	for _, r := range rules {
+		k := permKey{
+			protocol: r.Protocol,
+			fromPort: r.FromPort,
+			toPort:   r.ToPort,
+		}
+		cidrs := r.DestinationCIDRs
+		if len(cidrs) == 0 {
+			cidrs = []string{defaultRouteCIDRBlock}
+		}
+		for _, cidr := range cidrs {
+			k.ipAddr = cidr
+			m[k] = true
+		}
+	}
+	return m.egressPerms()
+}
+
+// ensureGroupEgress revokes the permission allowing all outbound
+// traffic from the security group, which EC2 adds to every new VPC
+// security group, and grants the given permissions. Other egress
+// permissions, such as those for application egress rules, are
+// left alone.
+func (e *environ) ensureGroupEgress(g ec2.SecurityGroup, perms []egressPerm) error {
+	info, err := groupEgress(e.ec2, g)
+	if err != nil {
+		return errors.Annotatef(err, "denying outbound traffic from security group %q", g.Id)
+	}
+	have := newEgressPermSet(info.EgressPerms)
+	revoke := make(permSet)
+	for p := range have {
+		if p.protocol == defaultEgressProtocol {
+			revoke[p] = true
+		}
+	}
+	if len(revoke) > 0 {
+		if err := revokeGroupEgress(e.ec2, g, revoke.egressPerms()); err != nil {
+			return errors.Annotatef(err, "revoking egress of security group %q", g.Id)
+		}
+	}
+	add := make(permSet)
+	for p := range newEgressPermSet(perms) {
+		if !have[p] {
+			add[p] = true
+		}
+	}
+	if len(add) > 0 {
+		if err := authorizeGroupEgress(e.ec2, g, add.egressPerms()); err != nil {
+			return errors.Annotatef(err, "authorizing egress of security group %q", g.Id)
+		}
+	}
+	return nil
+}
+
+func (e *environ) openEgressPortsInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	g, err := e.groupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	perms := egressRulesToPerms(rules)
+	err = authorizeGroupEgress(e.ec2, g, perms)
+	if err != nil && ec2ErrCode(err) == "InvalidPermission.Duplicate" {
+		// As in openPortsInGroup, authorize each permission
+		// individually so that those which are not duplicates
+		// are not ignored.
+		for i := range perms {
+			err := authorizeGroupEgress(e.ec2, g, perms[i:i+1])
+			if err != nil && ec2ErrCode(err) != "InvalidPermission.Duplicate" {
+				return errors.Annotatef(err, "cannot open egress port %v", perms[i])
+			}
+		}
+		return nil
+	}
+	if err != nil {
+		return errors.Annotate(err, "cannot open egress ports")
+	}
+	return nil
+}
+
+func (e *environ) closeEgressPortsInGroup(name string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	g, err := e.groupByName(name)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := revokeGroupEgress(e.ec2, g, egressRulesToPerms(rules)); err != nil {
+		return errors.Annotate(err, "cannot close egress ports")
+	}
+	return nil
+}
+
+func (e *environ) egressRulesInGroup(name string) (rules []network.EgressRule, err error) {
+	g, err := e.groupByName(name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := groupEgress(e.ec2, g)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range info.EgressPerms {
+		// Skip the permission allowing all outbound traffic,
+		// and any granted to security groups.
+		if p.Protocol == defaultEgressProtocol {
+			continue
+		}
+		cidrs := append(append([]string(nil), p.IPv4CIDRs...), p.IPv6CIDRs...)
+		if len(cidrs) == 0 {
+			continue
+		}
+		rule, err := network.NewEgressRule(p.Protocol, p.FromPort, p.ToPort, cidrs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
+// OpenEgressPorts is specified in environs.EgressFirewaller.
+func (e *environ) OpenEgressPorts(rules []network.EgressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening egress ports on model", e.Config().FirewallMode())
+	}
+	if err := e.openEgressPortsInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in global group: %v", rules)
+	return nil
+}
+
+// CloseEgressPorts is specified in environs.EgressFirewaller.
+func (e *environ) CloseEgressPorts(rules []network.EgressRule) error {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing egress ports on model", e.Config().FirewallMode())
+	}
+	if err := e.closeEgressPortsInGroup(e.globalGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in global group: %v", rules)
+	return nil
+}
+
+// EgressRules is specified in environs.EgressFirewaller.
+func (e *environ) EgressRules() ([]network.EgressRule, error) {
+	if e.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from model", e.Config().FirewallMode())
+	}
+	return e.egressRulesInGroup(e.globalGroupName())
+}
@@ -59,6 +59,9 @@ var (
 	aliveInstanceStates = []string{"pending", "running"}
 )
 
+var _ environs.ModelFirewaller = (*environ)(nil)
+var _ environs.EgressFirewaller = (*environ)(nil)
+
 type environ struct {
 	name  string
 	cloud environs.CloudSpec
@@ -308,7 +311,16 @@ func (e *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, _, err := e.instancePlacementZone(args.Placement, volumeAttachmentsZone); err != nil {
+	placementZone, _, err := e.instancePlacementZone(args.Placement, volumeAttachmentsZone)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if err := e.checkAllocatePublicIP(args.Constraints); err != nil {
 		return errors.Trace(err)
 	}
 	if !args.Constraints.HasInstanceType() {
@@ -333,6 +345,40 @@ func (e *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
 	return fmt.Errorf("invalid AWS instance type %q and arch %q specified", *args.Constraints.InstanceType, *args.Constraints.Arch)
 }
 
+// checkAllocatePublicIP returns an error if the allocate-public-ip
+// constraint cannot be honoured. Without vpc-id, instances are only
+// started without public IP addresses in the default VPC, as
+// EC2-Classic instances always have them.
+func (e *environ) checkAllocatePublicIP(cons constraints.Value) error {
+	if !cons.HasAllocatePublicIP() || *cons.AllocatePublicIP {
+		return nil
+	}
+	if isVPCIDSet(e.ecfg().vpcID()) {
+		return nil
+	}
+	hasDefaultVPC, err := e.hasDefaultVPC()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !hasDefaultVPC {
+		return errors.NotSupportedf("allocate-public-ip=false without vpc-id or a default VPC")
+	}
+	return nil
+}
+
+// createNetworkInterface creates a network interface in the given
+// subnet and security groups, returning its id.
+func createNetworkInterface(client *ec2.EC2, subnetId string, groupIds []string) (string, error) {
+	resp, err := client.CreateNetworkInterface(ec2.CreateNetworkInterface{
+		SubnetId:         subnetId,
+		SecurityGroupIds: groupIds,
+	})
+	if err != nil {
+		return "", errors.Trace(err)
+	}
+	return resp.NetworkInterface.Id, nil
+}
+
 // MetadataLookupParams returns parameters which are used to query simplestreams metadata.
 func (e *environ) MetadataLookupParams(region string) (*simplestreams.MetadataLookupParams, error) {
 	var endpoint string
@@ -404,6 +450,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		}
 	}()
 
+	if err := e.checkAllocatePublicIP(args.Constraints); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	callback(status.Allocating, "Determining availability zones", nil)
 
 	// Determine the availability zones of existing volumes that are to be
@@ -419,12 +469,15 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 	}
 	var availabilityZones []string
 	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = []string{placementZone}
 	}
 
 	// If no availability zone is specified or required, then automatically
-	// spread across the known zones for optimal spread across the instance
-	// distribution group.
+	// spread across the known zones, restricted by any zones constraint,
+	// for optimal spread across the instance distribution group.
 	if len(availabilityZones) == 0 {
 		var err error
 		var group []instance.Id
@@ -444,6 +497,10 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		if len(availabilityZones) == 0 {
 			return nil, errors.New("failed to determine availability zones")
 		}
+		availabilityZones, err = common.FilterZonesByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	arches := args.Tools.Arches()
@@ -503,11 +560,15 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		return nil, errors.Annotate(err, "cannot set up groups")
 	}
 
-	blockDeviceMappings := getBlockDeviceMappings(
+	blockDeviceMappings, err := getBlockDeviceMappings(
 		args.Constraints,
 		args.InstanceConfig.Series,
 		args.InstanceConfig.Controller != nil,
+		args.RootDisk,
 	)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot determine root disk")
+	}
 	rootDiskSize := uint64(blockDeviceMappings[0].VolumeSize) * 1024
 
 	// If --constraints spaces=foo was passed, the provisioner will populate
@@ -534,7 +595,24 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		ImageId:             spec.Image.Id,
 	}
 
-	haveVPCID := isVPCIDSet(e.ecfg().vpcID())
+	vpcID := e.ecfg().vpcID()
+	haveVPCID := isVPCIDSet(vpcID)
+
+	// The subnets of the default VPC map public IP addresses on
+	// launch, so without vpc-id allocate-public-ip=false is honoured
+	// by launching with an existing network interface, which EC2
+	// never associates a public IP address with. The security groups
+	// must then be given on the interface.
+	withoutPublicIP := !haveVPCID &&
+		args.Constraints.HasAllocatePublicIP() && !*args.Constraints.AllocatePublicIP
+	var groupIds []string
+	if withoutPublicIP {
+		vpcID = e.defaultVPC.Id
+		for _, group := range groups {
+			groupIds = append(groupIds, group.Id)
+		}
+		commonRunArgs.SecurityGroups = nil
+	}
 
 	for _, zone := range availabilityZones {
 		runArgs := commonRunArgs
@@ -542,7 +620,7 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 
 		var subnetIDsForZone []string
 		var subnetErr error
-		if haveVPCID {
+		if haveVPCID || withoutPublicIP {
 			var allowedSubnetIDs []string
 			if placementSubnetID != "" {
 				allowedSubnetIDs = []string{placementSubnetID}
@@ -551,7 +629,14 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 					allowedSubnetIDs = append(allowedSubnetIDs, string(subnetID))
 				}
 			}
-			subnetIDsForZone, subnetErr = getVPCSubnetIDsForAvailabilityZone(e.ec2, e.ecfg().vpcID(), zone, allowedSubnetIDs)
+			publicIP := args.Constraints.AllocatePublicIP
+			if withoutPublicIP {
+				// The network interface overrides the subnet.
+				publicIP = nil
+			}
+			subnetIDsForZone, subnetErr = getVPCSubnetIDsForAvailabilityZone(
+				e.ec2, vpcID, zone, allowedSubnetIDs, publicIP,
+			)
 		} else if args.Constraints.HaveSpaces() {
 			subnetIDsForZone, subnetErr = findSubnetIDsForAvailabilityZone(zone, args.SubnetsToZones)
 			if subnetErr == nil && placementSubnetID != "" {
@@ -586,9 +671,28 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 			runArgs.SubnetId = subnetIDsForZone[0]
 			logger.Infof("selected subnet %q in zone %q", runArgs.SubnetId, zone)
 		}
+		var ifaceId string
+		if withoutPublicIP {
+			var ifaceErr error
+			ifaceId, ifaceErr = createNetworkInterface(e.ec2, runArgs.SubnetId, groupIds)
+			if ifaceErr != nil {
+				return nil, errors.Annotatef(ifaceErr, "creating network interface in subnet %q", runArgs.SubnetId)
+			}
+			runArgs.NetworkInterfaces = []ec2.RunNetworkInterface{{
+				Id:                  ifaceId,
+				DeviceIndex:         0,
+				DeleteOnTermination: true,
+			}}
+			runArgs.SubnetId = ""
+		}
 
 		callback(status.Allocating, fmt.Sprintf("Trying to start instance in availability zone %q", zone), nil)
 		instResp, err = runInstances(e.ec2, runArgs, callback)
+		if err != nil && ifaceId != "" {
+			if _, deleteErr := e.ec2.DeleteNetworkInterface(ifaceId); deleteErr != nil {
+				logger.Warningf("cannot delete network interface %q: %v", ifaceId, deleteErr)
+			}
+		}
 		if err == nil || !isZoneOrSubnetConstrainedError(err) {
 			break
 		}
@@ -608,8 +712,8 @@ func (e *environ) StartInstance(args environs.StartInstanceParams) (_ *environs.
 		Instance: &instResp.Instances[0],
 	}
 	instAZ := inst.Instance.AvailZone
-	if haveVPCID {
-		instVPC := e.ecfg().vpcID()
+	if haveVPCID || withoutPublicIP {
+		instVPC := vpcID
 		instSubnet := inst.Instance.SubnetId
 		logger.Infof("started instance %q in AZ %q, subnet %q, VPC %q", inst.Id(), instAZ, instSubnet, instVPC)
 	} else {
@@ -1408,6 +1512,49 @@ func (e *environ) IngressRules() ([]network.IngressRule, error) {
 	return e.ingressRulesInGroup(e.globalGroupName())
 }
 
+// OpenModelPorts is specified in environs.ModelFirewaller.
+func (e *environ) OpenModelPorts(rules []network.IngressRule) error {
+	if err := e.openPortsInGroup(e.jujuGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in juju group: %v", rules)
+	return nil
+}
+
+// CloseModelPorts is specified in environs.ModelFirewaller.
+func (e *environ) CloseModelPorts(rules []network.IngressRule) error {
+	if err := e.closePortsInGroup(e.jujuGroupName(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in juju group: %v", rules)
+	return nil
+}
+
+// ModelIngressRules is specified in environs.ModelFirewaller.
+func (e *environ) ModelIngressRules() (rules []network.IngressRule, err error) {
+	name := e.jujuGroupName()
+	group, err := e.groupInfoByName(name)
+	if isNotFoundError(err) {
+		return nil, errors.NotFoundf("security group %q", name)
+	} else if err != nil {
+		return nil, errors.Trace(err)
+	}
+	for _, p := range group.IPPerms {
+		// Permissions granted to the group itself allow
+		// traffic between the model's machines.
+		if len(p.SourceIPs) == 0 {
+			continue
+		}
+		rule, err := network.NewIngressRule(p.Protocol, p.FromPort, p.ToPort, p.SourceIPs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortIngressRules(rules)
+	return rules, nil
+}
+
 func (*environ) Provider() environs.EnvironProvider {
 	return &providerInstance
 }
@@ -1646,19 +1793,11 @@ func (e *environ) jujuGroupName() string {
 // machine, so that its firewall rules can be configured per machine.
 func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]ec2.SecurityGroup, error) {
 
-	// Ensure there's a global group for Juju-related traffic.
+	// Ensure there's a global group for Juju-related traffic. Access
+	// to SSH and the API port is allowed from anywhere unless it has
+	// been restricted by the model's firewall rules.
 	jujuGroup, err := e.ensureGroup(controllerUUID, e.jujuGroupName(),
 		[]ec2.IPPerm{{
-			Protocol:  "tcp",
-			FromPort:  22,
-			ToPort:    22,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
-			Protocol:  "tcp",
-			FromPort:  apiPort,
-			ToPort:    apiPort,
-			SourceIPs: []string{"0.0.0.0/0"},
-		}, {
 			Protocol: "tcp",
 			FromPort: 0,
 			ToPort:   65535,
@@ -1671,6 +1810,17 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 			FromPort: -1,
 			ToPort:   -1,
 		}},
+		[]ec2.IPPerm{{
+			Protocol:  "tcp",
+			FromPort:  22,
+			ToPort:    22,
+			SourceIPs: []string{defaultRouteCIDRBlock},
+		}, {
+			Protocol:  "tcp",
+			FromPort:  apiPort,
+			ToPort:    apiPort,
+			SourceIPs: []string{defaultRouteCIDRBlock},
+		}},
 	)
 	if err != nil {
 		return nil, err
@@ -1679,13 +1829,48 @@ func (e *environ) setUpGroups(controllerUUID, machineId string, apiPort int) ([]
 	var machineGroup ec2.SecurityGroup
 	switch e.Config().FirewallMode() {
 	case config.FwInstance:
-		machineGroup, err = e.ensureGroup(controllerUUID, e.machineGroupName(machineId), nil)
+		machineGroup, err = e.ensureGroup(controllerUUID, e.machineGroupName(machineId), nil, nil)
 	case config.FwGlobal:
-		machineGroup, err = e.ensureGroup(controllerUUID, e.globalGroupName(), nil)
+		machineGroup, err = e.ensureGroup(controllerUUID, e.globalGroupName(), nil, nil)
 	}
 	if err != nil {
 		return nil, err
 	}
+
+	if e.Config().EgressDefaultDeny() {
+		// Outbound traffic is otherwise only allowed by the
+		// application egress rules in the machine groups, so
+		// machines must be allowed to reach the controller and
+		// each other.
+		if err := e.ensureGroupEgress(jujuGroup, []egressPerm{{
+			Protocol:  "tcp",
+			FromPort:  apiPort,
+			ToPort:    apiPort,
+			IPv4CIDRs: []string{defaultRouteCIDRBlock},
+		}, {
+			Protocol: "tcp",
+			FromPort: 0,
+			ToPort:   65535,
+			GroupIds: []string{jujuGroup.Id},
+		}, {
+			Protocol: "udp",
+			FromPort: 0,
+			ToPort:   65535,
+			GroupIds: []string{jujuGroup.Id},
+		}, {
+			Protocol: "icmp",
+			FromPort: -1,
+			ToPort:   -1,
+			GroupIds: []string{jujuGroup.Id},
+		}}); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if machineGroup.Id != "" {
+			if err := e.ensureGroupEgress(machineGroup, nil); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+	}
 	return []ec2.SecurityGroup{jujuGroup, machineGroup}, nil
 }
 
@@ -1716,7 +1901,12 @@ func (e *environ) securityGroupsByNameOrID(groupName string) (*ec2.SecurityGroup
 // If it exists, its permissions are set to perms.
 // Any entries in perms without SourceIPs will be granted for
 // the named group only.
-func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
+//
+// The entries in defaultPerms are granted only for port ranges
+// which have no permissions from IP addresses in the group, and
+// such permissions are never revoked. This leaves the firewaller
+// free to restrict them according to the model's firewall rules.
+func (e *environ) ensureGroup(controllerUUID, name string, perms, defaultPerms []ec2.IPPerm) (g ec2.SecurityGroup, err error) {
 	// Specify explicit VPC ID if needed (not for default VPC or EC2-classic).
 	chosenVPCID := e.ecfg().vpcID()
 	inVPCLogSuffix := fmt.Sprintf(" (in VPC %q)", chosenVPCID)
@@ -1763,12 +1953,30 @@ func (e *environ) ensureGroup(controllerUUID, name string, perms []ec2.IPPerm) (
 		have = newPermSetForGroup(info.IPPerms, g)
 	}
 
+	defaults := newPermSetForGroup(defaultPerms, g)
+	defaultPortRanges := make(permSet)
+	for p := range defaults {
+		defaultPortRanges[p.portRange()] = true
+	}
+	restricted := make(permSet)
+	for p := range have {
+		if p.ipAddr != "" && defaultPortRanges[p.portRange()] {
+			restricted[p.portRange()] = true
+		}
+	}
+
 	want := newPermSetForGroup(perms, g)
+	for p := range defaults {
+		if !restricted[p.portRange()] {
+			want[p] = true
+		}
+	}
 	revoke := make(permSet)
 	for p := range have {
-		if !want[p] {
-			revoke[p] = true
+		if want[p] || (p.ipAddr != "" && restricted[p.portRange()]) {
+			continue
 		}
+		revoke[p] = true
 	}
 	if len(revoke) > 0 {
 		_, err := e.ec2.RevokeSecurityGroup(g, revoke.ipPerms())
@@ -1804,6 +2012,16 @@ type permKey struct {
 	ipAddr   string
 }
 
+// portRange returns the key without its source, identifying
+// only the protocol and port range.
+func (k permKey) portRange() permKey {
+	return permKey{
+		protocol: k.protocol,
+		fromPort: k.fromPort,
+		toPort:   k.toPort,
+	}
+}
+
 type permSet map[permKey]bool
 
 // newPermSetForGroup returns a set of all the permissions in the
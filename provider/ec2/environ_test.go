@@ -15,6 +15,7 @@ import (
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/network"
+	"github.com/juju/juju/storage"
 )
 
 // Ensure EC2 provider supports the expected interfaces,
@@ -96,12 +97,69 @@ func (*Suite) TestRootDiskBlockDeviceMapping(c *gc.C) {
 	for _, t := range rootDiskTests {
 		c.Logf("Test %s", t.name)
 		cons := constraints.Value{RootDisk: t.constraint}
-		mappings := getBlockDeviceMappings(cons, t.series, false)
+		mappings, err := getBlockDeviceMappings(cons, t.series, false, nil)
+		c.Assert(err, jc.ErrorIsNil)
 		expected := append([]amzec2.BlockDeviceMapping{t.device}, commonInstanceStoreDisks...)
 		c.Assert(mappings, gc.DeepEquals, expected)
 	}
 }
 
+func (*Suite) TestRootDiskSourceBlockDeviceMapping(c *gc.C) {
+	source := func(s string) constraints.Value {
+		return constraints.Value{RootDiskSource: &s}
+	}
+	for i, t := range []struct {
+		cons     constraints.Value
+		rootDisk *storage.VolumeParams
+		device   amzec2.BlockDeviceMapping
+		err      string
+	}{{
+		cons:   source("ssd"),
+		device: amzec2.BlockDeviceMapping{VolumeSize: 8, VolumeType: "gp2", DeviceName: "/dev/sda1"},
+	}, {
+		cons:   source("standard"),
+		device: amzec2.BlockDeviceMapping{VolumeSize: 8, VolumeType: "standard", DeviceName: "/dev/sda1"},
+	}, {
+		cons: source("fast-pool"),
+		rootDisk: &storage.VolumeParams{
+			Provider:   EBS_ProviderType,
+			Attributes: map[string]interface{}{"volume-type": "io1", "iops": 10},
+		},
+		device: amzec2.BlockDeviceMapping{VolumeSize: 8, VolumeType: "io1", IOPS: 80, DeviceName: "/dev/sda1"},
+	}, {
+		cons:     source("ebs"),
+		rootDisk: &storage.VolumeParams{Provider: EBS_ProviderType},
+		device:   amzec2.BlockDeviceMapping{VolumeSize: 8, VolumeType: "standard", DeviceName: "/dev/sda1"},
+	}, {
+		cons: source("volume"),
+		err:  `invalid root disk source: validating EBS storage config: volume-type: unexpected value "volume"`,
+	}, {
+		cons: source("io1"),
+		err:  `invalid root disk source: volume type is "io1", IOPS unspecified or zero`,
+	}, {
+		cons:     source("tmpfs"),
+		rootDisk: &storage.VolumeParams{Provider: "tmpfs"},
+		err:      `root disk with storage provider "tmpfs" not supported`,
+	}, {
+		cons: source("encrypted-pool"),
+		rootDisk: &storage.VolumeParams{
+			Provider:   EBS_ProviderType,
+			Attributes: map[string]interface{}{"encrypted": true},
+		},
+		err: `encrypted root disk not supported`,
+	}} {
+		c.Logf("test %d: %v", i, t.cons)
+		mappings, err := getBlockDeviceMappings(t.cons, "trusty", false, t.rootDisk)
+		if t.err != "" {
+			c.Check(err, gc.ErrorMatches, t.err)
+			continue
+		}
+		c.Assert(err, jc.ErrorIsNil)
+		expected := append([]amzec2.BlockDeviceMapping{t.device}, commonInstanceStoreDisks...)
+		c.Check(mappings, gc.DeepEquals, expected)
+	}
+}
+
 func pInt(i uint64) *uint64 {
 	return &i
 }
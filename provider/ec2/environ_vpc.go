@@ -438,12 +438,15 @@ func findDefaultVPCID(apiClient vpcAPIClient) (string, error) {
 
 // getVPCSubnetIDsForAvailabilityZone returns a sorted list of subnet IDs, which
 // are both in the given vpcID and the given zoneName. If allowedSubnetIDs is
-// not empty, the returned list will only contain IDs present there. Returns an
-// error satisfying errors.IsNotFound() when no results match.
+// not empty, the returned list will only contain IDs present there. If
+// publicIP is not nil, the returned list will only contain IDs of subnets
+// which do (or do not) map public IP addresses to instances on launch.
+// Returns an error satisfying errors.IsNotFound() when no results match.
 func getVPCSubnetIDsForAvailabilityZone(
 	apiClient vpcAPIClient,
 	vpcID, zoneName string,
 	allowedSubnetIDs []string,
+	publicIP *bool,
 ) ([]string, error) {
 	allowedSubnets := set.NewStrings(allowedSubnetIDs...)
 	vpc := &ec2.VPC{Id: vpcID}
@@ -468,6 +471,12 @@ func getVPCSubnetIDsForAvailabilityZone(
 			logger.Infof("skipping subnet %q (in VPC %q, AZ %q): not matching spaces constraints", subnet.Id, vpcID, zoneName)
 			continue
 		}
+		// See findFirstPublicSubnet for why DefaultForAZ is checked too.
+		mapsPublicIP := subnet.MapPublicIPOnLaunch || subnet.DefaultForAZ
+		if publicIP != nil && *publicIP != mapsPublicIP {
+			logger.Infof("skipping subnet %q (in VPC %q, AZ %q): not matching allocate-public-ip constraint", subnet.Id, vpcID, zoneName)
+			continue
+		}
 		matchingSubnetIDs.Add(subnet.Id)
 	}
 
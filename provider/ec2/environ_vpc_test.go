@@ -585,7 +585,7 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneWithSubnetsError(c *gc.
 	s.stubAPI.SetErrors(errors.New("too cloudy"))
 
 	anyVPC := makeEC2VPC(anyVPCID, anyState)
-	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, anyZone, nil)
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, anyZone, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `cannot get VPC "vpc-anything" subnets: unexpected AWS .*: too cloudy`)
 	c.Check(subnetIDs, gc.IsNil)
 
@@ -596,7 +596,7 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneNoSubnetsAtAll(c *gc.C)
 	s.stubAPI.SetSubnetsResponse(noResults, anyZone, noPublicIPOnLaunch)
 
 	anyVPC := makeEC2VPC(anyVPCID, anyState)
-	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, anyZone, nil)
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, anyZone, nil, nil)
 	c.Assert(err, gc.ErrorMatches, `VPC "vpc-anything" has no subnets in AZ "any-zone": no subnets found for VPC.*`)
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 	c.Check(subnetIDs, gc.IsNil)
@@ -608,7 +608,7 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneNoSubnetsInAZ(c *gc.C)
 	s.stubAPI.SetSubnetsResponse(3, "other-zone", noPublicIPOnLaunch)
 
 	anyVPC := makeEC2VPC(anyVPCID, anyState)
-	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "given-zone", nil)
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "given-zone", nil, nil)
 	c.Assert(err, gc.ErrorMatches, `VPC "vpc-anything" has no subnets in AZ "given-zone"`)
 	c.Check(err, jc.Satisfies, errors.IsNotFound)
 	c.Check(subnetIDs, gc.IsNil)
@@ -624,7 +624,7 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneWithSubnetsToZones(c *g
 	allowedSubnetIDs := []string{"subnet-1", "subnet-3"}
 
 	anyVPC := makeEC2VPC(anyVPCID, anyState)
-	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", allowedSubnetIDs)
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", allowedSubnetIDs, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	c.Check(subnetIDs, jc.DeepEquals, []string{"subnet-1", "subnet-3"})
 
@@ -635,7 +635,7 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneSuccess(c *gc.C) {
 	s.stubAPI.SetSubnetsResponse(2, "my-zone", noPublicIPOnLaunch)
 
 	anyVPC := makeEC2VPC(anyVPCID, anyState)
-	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", nil)
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", nil, nil)
 	c.Assert(err, jc.ErrorIsNil)
 	// Result slice of IDs is always sorted.
 	c.Check(subnetIDs, jc.DeepEquals, []string{"subnet-0", "subnet-1"})
@@ -643,6 +643,24 @@ func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneSuccess(c *gc.C) {
 	s.stubAPI.CheckSingleSubnetsCall(c, anyVPC)
 }
 
+func (s *vpcSuite) TestGetVPCSubnetIDsForAvailabilityZoneWithPublicIP(c *gc.C) {
+	s.stubAPI.SetSubnetsResponse(3, "my-zone", noPublicIPOnLaunch)
+	s.stubAPI.subnetsResponse.Subnets[1].MapPublicIPOnLaunch = true
+
+	anyVPC := makeEC2VPC(anyVPCID, anyState)
+	publicIP := true
+	subnetIDs, err := getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", nil, &publicIP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnetIDs, jc.DeepEquals, []string{"subnet-1"})
+	s.stubAPI.CheckSingleSubnetsCall(c, anyVPC)
+
+	publicIP = false
+	subnetIDs, err = getVPCSubnetIDsForAvailabilityZone(s.stubAPI, anyVPC.Id, "my-zone", nil, &publicIP)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(subnetIDs, jc.DeepEquals, []string{"subnet-0", "subnet-2"})
+	s.stubAPI.CheckSingleSubnetsCall(c, anyVPC)
+}
+
 var fakeSubnetsToZones = map[network.Id][]string{
 	"subnet-foo": []string{"az1", "az2"},
 	"subnet-bar": []string{"az1"},
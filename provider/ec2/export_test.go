@@ -63,7 +63,10 @@ var (
 	IsVPCNotRecommendedError    = isVPCNotRecommendedError
 )
 
-const VPCIDNone = vpcIDNone
+const (
+	VPCIDNone       = vpcIDNone
+	QueryAPIVersion = queryAPIVersion
+)
 
 // TODO: Apart from overriding different hardcoded hosts, these two test helpers are identical. Let's share.
 
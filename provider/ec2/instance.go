@@ -25,6 +25,7 @@ func (inst *ec2Instance) String() string {
 }
 
 var _ instance.Instance = (*ec2Instance)(nil)
+var _ instance.EgressFirewaller = (*ec2Instance)(nil)
 
 func (inst *ec2Instance) Id() instance.Id {
 	return instance.Id(inst.InstanceId)
@@ -112,3 +113,40 @@ func (inst *ec2Instance) IngressRules(machineId string) ([]network.IngressRule,
 	}
 	return ranges, nil
 }
+
+// OpenEgressPorts is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) OpenEgressPorts(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for opening egress ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.openEgressPortsInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("opened egress ports in security group %s: %v", name, rules)
+	return nil
+}
+
+// CloseEgressPorts is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) CloseEgressPorts(machineId string, rules []network.EgressRule) error {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return fmt.Errorf("invalid firewall mode %q for closing egress ports on instance",
+			inst.e.Config().FirewallMode())
+	}
+	name := inst.e.machineGroupName(machineId)
+	if err := inst.e.closeEgressPortsInGroup(name, rules); err != nil {
+		return err
+	}
+	logger.Infof("closed egress ports in security group %s: %v", name, rules)
+	return nil
+}
+
+// EgressRules is specified in instance.EgressFirewaller.
+func (inst *ec2Instance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	if inst.e.Config().FirewallMode() != config.FwInstance {
+		return nil, fmt.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			inst.e.Config().FirewallMode())
+	}
+	return inst.e.egressRulesInGroup(inst.e.machineGroupName(machineId))
+}
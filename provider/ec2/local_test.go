@@ -4,7 +4,9 @@
 package ec2_test
 
 import (
+	"encoding/xml"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"net/http/httputil"
 	"net/url"
@@ -589,6 +591,272 @@ func (t *localServerSuite) TestInstanceSecurityGroupsWitheInstanceStatusFilter(c
 	c.Assert(groupsFilteredForTerminatedInstances, gc.HasLen, 0)
 }
 
+func (t *localServerSuite) TestModelIngressRules(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	fwEnv, ok := env.(environs.ModelFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	apiPort := coretesting.FakeControllerConfig().APIPort()
+
+	// SSH and the API port are open to anyone by default.
+	rules, err := fwEnv.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0"),
+	})
+
+	// Restrict SSH, as the firewaller does for the model's ssh rule.
+	err = fwEnv.OpenModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwEnv.CloseModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "0.0.0.0/0"),
+	}
+	rules, err = fwEnv.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	// Starting another instance must not reopen SSH to anyone.
+	testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	rules, err = fwEnv.ModelIngressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+}
+
+func (t *localServerSuite) TestModelIngressRulesNoGroup(c *gc.C) {
+	env := t.Prepare(c)
+	_, err := env.(environs.ModelFirewaller).ModelIngressRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (t *localServerSuite) TestEgressRules(c *gc.C) {
+	fake := &fakeEgress{vpcId: t.srv.defaultVPC.Id}
+	t.srv.proxy.ModifyResponse = fake.modifyResponse
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"firewall-mode":       "global",
+		"egress-default-deny": true,
+	})
+	fwEnv, ok := env.(environs.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+	apiPort := coretesting.FakeControllerConfig().APIPort()
+
+	// Outbound traffic is only allowed to the API port, and
+	// between the model's machines.
+	jujuGroupId := t.groupId(c, ec2.JujuGroupName(env))
+	c.Assert(fake.egress(jujuGroupId), jc.DeepEquals, []string{
+		"icmp -1 -1 " + jujuGroupId,
+		"tcp 0 65535 " + jujuGroupId,
+		fmt.Sprintf("tcp %d %d 0.0.0.0/0", apiPort, apiPort),
+		"udp 0 65535 " + jujuGroupId,
+	})
+	globalGroupId := t.groupId(c, ec2.JujuGroupName(env)+"-global")
+	c.Assert(fake.egress(globalGroupId), gc.HasLen, 0)
+	rules, err := fwEnv.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = fwEnv.OpenEgressPorts([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+		network.MustNewEgressRule("tcp", 80, 81, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwEnv.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 81, "10.0.0.0/8", "192.168.0.0/16"),
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+
+	err = fwEnv.CloseEgressPorts([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 80, 81, "10.0.0.0/8", "192.168.0.0/16"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	}
+	rules, err = fwEnv.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+
+	// Starting another instance must keep the egress rules.
+	testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	rules, err = fwEnv.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, expected)
+}
+
+func (t *localServerSuite) TestInstanceEgressRules(c *gc.C) {
+	fake := &fakeEgress{vpcId: t.srv.defaultVPC.Id}
+	t.srv.proxy.ModifyResponse = fake.modifyResponse
+	env := t.prepareAndBootstrapWithConfig(c, coretesting.Attrs{
+		"egress-default-deny": true,
+	})
+	inst, _ := testing.AssertStartInstance(c, env, t.ControllerUUID, "1")
+	fwInst, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	machineGroupId := t.groupId(c, ec2.MachineGroupName(env, "1"))
+	c.Assert(fake.egress(machineGroupId), gc.HasLen, 0)
+
+	err := fwInst.OpenEgressPorts("1", []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.egress(machineGroupId), jc.DeepEquals, []string{
+		"udp 53 53 10.0.0.2/32",
+	})
+	rules, err := fwInst.EgressRules("1")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.2/32"),
+	})
+
+	err = fwInst.CloseEgressPorts("1", rules)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(fake.egress(machineGroupId), gc.HasLen, 0)
+}
+
+func (t *localServerSuite) groupId(c *gc.C, name string) string {
+	resp, err := t.srv.client.SecurityGroups(amzec2.SecurityGroupNames(name), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(resp.Groups, gc.HasLen, 1)
+	return resp.Groups[0].Id
+}
+
+// fakeEgress implements the security group egress API calls, which
+// the ec2test server does not support, by replacing the proxied
+// responses. Every group starts with the permission allowing all
+// outbound traffic, as new VPC security groups do.
+type fakeEgress struct {
+	vpcId string
+	perms map[string]map[fakeEgressPerm]bool
+}
+
+type fakeEgressPerm struct {
+	protocol string
+	fromPort string
+	toPort   string
+	dest     string
+	group    bool
+}
+
+type fakeEgressPermXML struct {
+	Protocol string   `xml:"ipProtocol"`
+	FromPort string   `xml:"fromPort,omitempty"`
+	ToPort   string   `xml:"toPort,omitempty"`
+	IPRanges []string `xml:"ipRanges>item>cidrIp"`
+	Groups   []string `xml:"groups>item>groupId"`
+}
+
+type fakeEgressGroupXML struct {
+	Id          string              `xml:"groupId"`
+	VPCId       string              `xml:"vpcId"`
+	EgressPerms []fakeEgressPermXML `xml:"ipPermissionsEgress>item"`
+}
+
+type fakeEgressGroupsResp struct {
+	XMLName xml.Name             `xml:"DescribeSecurityGroupsResponse"`
+	Groups  []fakeEgressGroupXML `xml:"securityGroupInfo>item"`
+}
+
+func (f *fakeEgress) groupPerms(groupId string) map[fakeEgressPerm]bool {
+	if f.perms == nil {
+		f.perms = make(map[string]map[fakeEgressPerm]bool)
+	}
+	if f.perms[groupId] == nil {
+		f.perms[groupId] = map[fakeEgressPerm]bool{
+			{protocol: "-1", dest: "0.0.0.0/0"}: true,
+		}
+	}
+	return f.perms[groupId]
+}
+
+// egress returns the sorted egress permissions of the group.
+func (f *fakeEgress) egress(groupId string) []string {
+	var result []string
+	for p := range f.groupPerms(groupId) {
+		result = append(result, strings.Join([]string{p.protocol, p.fromPort, p.toPort, p.dest}, " "))
+	}
+	sort.Strings(result)
+	return result
+}
+
+func (f *fakeEgress) modifyResponse(resp *http.Response) error {
+	query := resp.Request.URL.Query()
+	var value interface{}
+	switch query.Get("Action") {
+	case "DescribeSecurityGroups":
+		if query.Get("Version") != ec2.QueryAPIVersion {
+			return nil
+		}
+		group := fakeEgressGroupXML{
+			Id:    query.Get("GroupId.1"),
+			VPCId: f.vpcId,
+		}
+		byPorts := make(map[fakeEgressPerm]*fakeEgressPermXML)
+		var ports []fakeEgressPerm
+		for p := range f.groupPerms(group.Id) {
+			key := fakeEgressPerm{protocol: p.protocol, fromPort: p.fromPort, toPort: p.toPort}
+			perm, ok := byPorts[key]
+			if !ok {
+				perm = &fakeEgressPermXML{Protocol: p.protocol, FromPort: p.fromPort, ToPort: p.toPort}
+				byPorts[key] = perm
+				ports = append(ports, key)
+			}
+			if p.group {
+				perm.Groups = append(perm.Groups, p.dest)
+			} else {
+				perm.IPRanges = append(perm.IPRanges, p.dest)
+			}
+		}
+		for _, key := range ports {
+			perm := byPorts[key]
+			sort.Strings(perm.IPRanges)
+			group.EgressPerms = append(group.EgressPerms, *perm)
+		}
+		value = fakeEgressGroupsResp{Groups: []fakeEgressGroupXML{group}}
+	case "AuthorizeSecurityGroupEgress", "RevokeSecurityGroupEgress":
+		authorize := query.Get("Action") == "AuthorizeSecurityGroupEgress"
+		perms := f.groupPerms(query.Get("GroupId"))
+		for n := 1; query.Get(fmt.Sprintf("IpPermissions.%d.IpProtocol", n)) != ""; n++ {
+			prefix := fmt.Sprintf("IpPermissions.%d.", n)
+			p := fakeEgressPerm{
+				protocol: query.Get(prefix + "IpProtocol"),
+				fromPort: query.Get(prefix + "FromPort"),
+				toPort:   query.Get(prefix + "ToPort"),
+			}
+			var dests []fakeEgressPerm
+			for m := 1; query.Get(fmt.Sprintf("%sIpRanges.%d.CidrIp", prefix, m)) != ""; m++ {
+				p.dest = query.Get(fmt.Sprintf("%sIpRanges.%d.CidrIp", prefix, m))
+				dests = append(dests, p)
+			}
+			p.group = true
+			for m := 1; query.Get(fmt.Sprintf("%sGroups.%d.GroupId", prefix, m)) != ""; m++ {
+				p.dest = query.Get(fmt.Sprintf("%sGroups.%d.GroupId", prefix, m))
+				dests = append(dests, p)
+			}
+			for _, dest := range dests {
+				if authorize {
+					perms[dest] = true
+				} else {
+					delete(perms, dest)
+				}
+			}
+		}
+		value = amzec2.SimpleResp{}
+	default:
+		return nil
+	}
+	resp.StatusCode = http.StatusOK
+	return replaceResponseBody(resp, value)
+}
+
 func (t *localServerSuite) TestDestroyControllerModelDeleteSecurityGroupInsistentlyError(c *gc.C) {
 	env := t.prepareAndBootstrap(c)
 	msg := "destroy security group error"
@@ -1054,6 +1322,116 @@ func (t *localServerSuite) testStartInstanceAvailZoneAllConstrained(c *gc.C, run
 	c.Assert(azArgs, gc.DeepEquals, []string{"az1", "az2"})
 }
 
+func (t *localServerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{
+			{ZoneName: "az1"}, {ZoneName: "az2"}, {ZoneName: "az3"},
+		},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	var azArgs []string
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		azArgs = append(azArgs, ri.AvailZone)
+		return nil, azConstrainedErr
+	})
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("zones=az3,az2"),
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.NotNil)
+	c.Assert(azArgs, gc.DeepEquals, []string{"az2", "az3"})
+}
+
+func (t *localServerSuite) TestStartInstanceRootDiskSource(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	var mappings []amzec2.BlockDeviceMapping
+	t.PatchValue(ec2.RunInstances, func(e *amzec2.EC2, ri *amzec2.RunInstances, c environs.StatusCallbackFunc) (*amzec2.RunInstancesResp, error) {
+		mappings = ri.BlockDeviceMappings
+		return nil, errors.New("boom")
+	})
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("root-disk-source=fast root-disk=10G"),
+		RootDisk: &storage.VolumeParams{
+			Provider:   "ebs",
+			Attributes: map[string]interface{}{"volume-type": "provisioned-iops", "iops": 30},
+		},
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, "cannot run instances: boom")
+	c.Assert(mappings, gc.Not(gc.HasLen), 0)
+	c.Assert(mappings[0], jc.DeepEquals, amzec2.BlockDeviceMapping{
+		DeviceName: "/dev/sda1",
+		VolumeSize: 10,
+		VolumeType: "io1",
+		IOPS:       300,
+	})
+}
+
+func (t *localServerSuite) TestStartInstanceInvalidRootDiskSource(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("root-disk-source=volume"),
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `cannot determine root disk: invalid root disk source: .*`)
+}
+
+func (t *localServerSuite) TestStartInstanceNoPublicIPWithoutVPCID(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("allocate-public-ip=false"),
+		StatusCallback: fakeCallback,
+	}
+	result, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The instance is started in a subnet of the default VPC.
+	ec2Inst := ec2.InstanceEC2(result.Instance)
+	c.Assert(ec2Inst.VPCId, gc.Equals, t.srv.defaultVPC.Id)
+	c.Assert(ec2Inst.SubnetId, gc.Not(gc.Equals), "")
+}
+
+func (t *localServerSuite) TestStartInstanceZonesConstraintNoMatch(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	mock := mockAvailabilityZoneAllocations{
+		result: []common.AvailabilityZoneInstances{{ZoneName: "az1"}},
+	}
+	t.PatchValue(ec2.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("zones=az2"),
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zones matching zones constraint "az2" not found`)
+}
+
+func (t *localServerSuite) TestStartInstanceAvailZoneZonesConstraint(c *gc.C) {
+	env := t.prepareAndBootstrap(c)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "zone=test-available",
+		Constraints:    constraints.MustParse("zones=az1"),
+		StatusCallback: fakeCallback,
+	}
+	_, err := testing.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" not allowed by zones constraint "az1"`)
+}
+
 // addTestingSubnets adds a testing default VPC with 3 subnets in the EC2 test
 // server: 2 of the subnets are in the "test-available" AZ, the remaining - in
 // "test-unavailable". Returns a slice with the IDs of the created subnets and
@@ -1382,6 +1760,39 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceAvailZoneZonesConstraint(c *gc.C) {
+	env := t.Prepare(c)
+	placement := "zone=test-available"
+	err := env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   placement,
+		Constraints: constraints.MustParse("zones=test-available"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   placement,
+		Constraints: constraints.MustParse("zones=az1,az2"),
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" not allowed by zones constraint "az1,az2"`)
+}
+
+func (t *localServerSuite) TestPrecheckInstanceAllocatePublicIP(c *gc.C) {
+	env := t.Prepare(c)
+	err := env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Constraints: constraints.MustParse("allocate-public-ip=true"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Constraints: constraints.MustParse("allocate-public-ip=false"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceVolumeAvailZoneNoPlacement(c *gc.C) {
 	t.testPrecheckInstanceVolumeAvailZone(c, "")
 }
@@ -0,0 +1,66 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package ec2
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+	"gopkg.in/amz.v3/ec2"
+)
+
+// queryAPIVersion is the EC2 API version used for the requests made
+// by query. It must be recent enough for all of the actions used.
+const queryAPIVersion = "2016-11-15"
+
+// query makes an EC2 query API request for an action that the amz.v3
+// client does not support, and decodes the response into resp. The
+// request is signed by the client's own signer, and errors are
+// returned as *ec2.Error, just as the client returns them.
+func query(client *ec2.EC2, action string, params map[string]string, resp interface{}) error {
+	req, err := http.NewRequest("GET", client.Region.EC2Endpoint, nil)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	values := req.URL.Query()
+	values.Set("Action", action)
+	values.Set("Version", queryAPIVersion)
+	for name, value := range params {
+		values.Set(name, value)
+	}
+	now := time.Now().UTC()
+	values.Set("Timestamp", now.Format(time.RFC3339))
+	req.URL.RawQuery = values.Encode()
+	req.Header.Set("x-amz-date", now.Format(aws.ISO8601BasicFormat))
+	if err := client.Sign(req, client.Auth); err != nil {
+		return errors.Annotatef(err, "signing %s request", action)
+	}
+
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer r.Body.Close()
+	if r.StatusCode != http.StatusOK {
+		var xmlErrors struct {
+			RequestId string      `xml:"RequestID"`
+			Errors    []ec2.Error `xml:"Errors>Error"`
+		}
+		xml.NewDecoder(r.Body).Decode(&xmlErrors)
+		var ec2Err ec2.Error
+		if len(xmlErrors.Errors) > 0 {
+			ec2Err = xmlErrors.Errors[0]
+		}
+		ec2Err.RequestId = xmlErrors.RequestId
+		ec2Err.StatusCode = r.StatusCode
+		if ec2Err.Message == "" {
+			ec2Err.Message = r.Status
+		}
+		return &ec2Err
+	}
+	return errors.Trace(xml.NewDecoder(r.Body).Decode(resp))
+}
@@ -147,6 +147,15 @@ func (s *ConfigSuite) TestValidateOldConfig(c *gc.C) {
 	}
 }
 
+func (s *ConfigSuite) TestValidateEgressDefaultDeny(c *gc.C) {
+	test := configTestSpec{
+		insert: testing.Attrs{"egress-default-deny": true},
+	}
+	validatedConfig, err := gce.Provider.Validate(test.newConfig(c), nil)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(validatedConfig.EgressDefaultDeny(), jc.IsTrue)
+}
+
 var changeConfigTests = []configTestSpec{{
 	info:   "no change, no error",
 	expect: gce.ConfigAttrs,
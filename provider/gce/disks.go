@@ -11,6 +11,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"github.com/juju/utils/set"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/provider/gce/google"
 	"github.com/juju/juju/storage"
@@ -70,6 +71,11 @@ type volumeSource struct {
 	modelUUID string
 }
 
+var (
+	_ storage.VolumeSnapshotter = (*volumeSource)(nil)
+	_ storage.VolumeResizer     = (*volumeSource)(nil)
+)
+
 func (g *storageProvider) VolumeSource(cfg *storage.Config) (storage.VolumeSource, error) {
 	environConfig := g.env.Config()
 	source := &volumeSource{
@@ -419,3 +425,181 @@ func (v *volumeSource) detachOneVolume(attachParam storage.VolumeAttachmentParam
 	}
 	return v.gce.DetachDisk(zone, string(instId), volumeName)
 }
+
+func (v *volumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot, err := v.createOneVolumeSnapshot(p.Volume, p.VolumeId)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "cannot snapshot volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Snapshot = snapshot
+	}
+	return results, nil
+}
+
+func (v *volumeSource) createOneVolumeSnapshot(volume names.VolumeTag, volName string) (*storage.VolumeSnapshot, error) {
+	zone, _, err := parseVolumeId(volName)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid volume id")
+	}
+	snapshotUUID, err := utils.NewUUID()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot generate uuid to name the snapshot")
+	}
+	// Snapshots are named for the volume tag, rather than the disk,
+	// so that they can still be found once the disk has been replaced
+	// by restoring one of them.
+	snapshotName := fmt.Sprintf("%s%s", snapshotNamePrefix(volume), snapshotUUID.String())
+	snapshot, err := v.gce.CreateSnapshot(zone, volName, snapshotName, v.modelUUID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := gceToJujuVolumeSnapshot(snapshot)
+	return &result, nil
+}
+
+func (v *volumeSource) ListVolumeSnapshots(volumes []names.VolumeTag) ([]storage.ListVolumeSnapshotsResult, error) {
+	snapshots, err := v.gce.Snapshots()
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	results := make([]storage.ListVolumeSnapshotsResult, len(volumes))
+	for _, snapshot := range snapshots {
+		// We don't want to lay hands on snapshots we did not create.
+		if snapshot.Description != v.modelUUID {
+			continue
+		}
+		for i, volume := range volumes {
+			if isVolumeSnapshot(snapshot.Name, volume) {
+				results[i].Snapshots = append(results[i].Snapshots, gceToJujuVolumeSnapshot(snapshot))
+			}
+		}
+	}
+	return results, nil
+}
+
+// snapshotNamePrefix returns the prefix of the names of snapshots
+// taken of the volume with the given tag.
+func snapshotNamePrefix(volume names.VolumeTag) string {
+	return volume.String() + "-"
+}
+
+// isVolumeSnapshot reports whether the snapshot with the given name
+// was taken of the volume with the given tag. Matching the prefix
+// alone is not enough, as the prefix for volume 0 is also a prefix of
+// the names of snapshots of volume 0/1.
+func isVolumeSnapshot(snapshotName string, volume names.VolumeTag) bool {
+	prefix := snapshotNamePrefix(volume)
+	if !strings.HasPrefix(snapshotName, prefix) {
+		return false
+	}
+	return utils.IsValidUUIDString(snapshotName[len(prefix):])
+}
+
+func (v *volumeSource) DeleteVolumeSnapshots(snapshotNames []string) ([]error, error) {
+	results := make([]error, len(snapshotNames))
+	for i, snapshotName := range snapshotNames {
+		if err := v.gce.RemoveSnapshot(snapshotName); err != nil {
+			results[i] = errors.Annotatef(err, "cannot delete snapshot %q", snapshotName)
+		}
+	}
+	return results, nil
+}
+
+func (v *volumeSource) RestoreVolumeSnapshots(params []storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error) {
+	results := make([]storage.RestoreVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		info, err := v.restoreOneVolumeSnapshot(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "cannot restore volume %q from snapshot %q",
+				p.VolumeId, p.SnapshotId,
+			)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (v *volumeSource) restoreOneVolumeSnapshot(p storage.RestoreVolumeSnapshotParams) (_ *storage.VolumeInfo, err error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "invalid volume id")
+	}
+	volumeName, err := nameVolume(zone)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create a new volume name")
+	}
+	persistentType, ok := p.Attributes["type"].(google.DiskType)
+	if !ok {
+		persistentType = google.DiskPersistentStandard
+	}
+	disk := google.DiskSpec{
+		SizeHintGB:         mibToGib(p.Size),
+		Name:               volumeName,
+		PersistentDiskType: persistentType,
+		Description:        v.modelUUID,
+		SourceSnapshot:     p.SnapshotId,
+	}
+	gceDisks, err := v.gce.CreateDisks(zone, []google.DiskSpec{disk})
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot create disk")
+	}
+	if len(gceDisks) != 1 {
+		return nil, errors.New(fmt.Sprintf("unexpected number of disks created: %d", len(gceDisks)))
+	}
+	gceDisk := gceDisks[0]
+	return &storage.VolumeInfo{
+		VolumeId:   gceDisk.Name,
+		Size:       gceDisk.Size,
+		Persistent: true,
+	}, nil
+}
+
+func (v *volumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		size, err := v.resizeOneVolume(p)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %q", p.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (v *volumeSource) resizeOneVolume(p storage.VolumeResizeParams) (uint64, error) {
+	zone, _, err := parseVolumeId(p.VolumeId)
+	if err != nil {
+		return 0, errors.Annotate(err, "invalid volume id")
+	}
+	disk, err := v.gce.Disk(zone, p.VolumeId)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	// GCE refuses to shrink disks, or to resize them to their
+	// current size, so there is nothing to do if the disk is
+	// already large enough.
+	if disk.Size >= p.Size {
+		return disk.Size, nil
+	}
+	sizeGB := mibToGib(p.Size)
+	if err := v.gce.ResizeDisk(zone, p.VolumeId, sizeGB); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return sizeGB * 1024, nil
+}
+
+func gceToJujuVolumeSnapshot(snapshot *google.Snapshot) storage.VolumeSnapshot {
+	return storage.VolumeSnapshot{
+		SnapshotId: snapshot.Name,
+		VolumeId:   snapshot.SourceDisk,
+		Size:       snapshot.Size,
+		Status:     strings.ToLower(string(snapshot.Status)),
+		Created:    snapshot.Created,
+	}
+}
@@ -303,3 +303,159 @@ func (s *volumeSourceSuite) TestDetachVolumes(c *gc.C) {
 	c.Assert(call[0].InstanceId, gc.Equals, string(s.instId))
 	c.Assert(call[0].VolumeName, gc.Equals, volName)
 }
+
+func (s *volumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	resizer := s.source.(storage.VolumeResizer)
+	res, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     2000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+
+	called, calls := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].ID, gc.Equals, s.BaseDisk.Name)
+	c.Assert(calls[0].Size, gc.Equals, uint64(2))
+}
+
+func (s *volumeSourceSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	s.FakeConn.GoogleDisk = s.BaseDisk
+	resizer := s.source.(storage.VolumeResizer)
+	res, err := resizer.ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+		Size:     1000,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 1024}})
+
+	called, _ := s.FakeConn.WasCalled("ResizeDisk")
+	c.Assert(called, jc.IsFalse)
+}
+
+func (s *volumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	s.FakeConn.Snapshot = &google.Snapshot{
+		Name:        "volume-0-snap",
+		Description: s.BaseDisk.Description,
+		SourceDisk:  s.BaseDisk.Name,
+		Size:        1024,
+		Status:      google.SnapshotCreating,
+	}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	res, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: s.BaseDisk.Name,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "volume-0-snap",
+			VolumeId:   s.BaseDisk.Name,
+			Size:       1024,
+			Status:     "creating",
+		},
+	}})
+
+	called, calls := s.FakeConn.WasCalled("CreateSnapshot")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls, gc.HasLen, 1)
+	c.Assert(calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Assert(calls[0].VolumeName, gc.Equals, s.BaseDisk.Name)
+	c.Assert(calls[0].ID, jc.HasPrefix, "volume-0-")
+	c.Assert(calls[0].Value, gc.Equals, s.BaseDisk.Description)
+}
+
+func (s *volumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	s.FakeConn.Snapshots_ = []*google.Snapshot{{
+		Name:        "volume-0-3a9f2f4c-5c1d-4f4e-8d4b-9a0e4b4d7c11",
+		Description: s.BaseDisk.Description,
+		SourceDisk:  s.BaseDisk.Name,
+		Status:      google.SnapshotReady,
+	}, {
+		// Taken before volume 0 was restored from a snapshot.
+		Name:        "volume-0-6c2d3e1a-0b8e-4b7a-9c3f-1d2e3f4a5b6c",
+		Description: s.BaseDisk.Description,
+		SourceDisk:  "home-zone--replaced",
+		Status:      google.SnapshotReady,
+	}, {
+		Name:        "volume-0-8e7d6c5b-4a39-4281-9f0e-d1c2b3a49586",
+		Description: "some-other-model",
+		SourceDisk:  s.BaseDisk.Name,
+		Status:      google.SnapshotReady,
+	}, {
+		Name:        "volume-10-2b4d6f80-1a3c-4e5f-8a9b-c0d1e2f3a4b5",
+		Description: s.BaseDisk.Description,
+		SourceDisk:  "home-zone--other",
+		Status:      google.SnapshotReady,
+	}, {
+		// A snapshot of the machine-scoped volume 0/1, whose name
+		// starts with the prefix for volume 0.
+		Name:        "volume-0-1-4f3e2d1c-0b9a-4876-a543-210fedcba987",
+		Description: s.BaseDisk.Description,
+		SourceDisk:  "home-zone--machine",
+		Status:      google.SnapshotReady,
+	}}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	res, err := snapshotter.ListVolumeSnapshots([]names.VolumeTag{
+		names.NewVolumeTag("0"),
+		names.NewVolumeTag("1"),
+		names.NewVolumeTag("0/1"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.ListVolumeSnapshotsResult{{
+		Snapshots: []storage.VolumeSnapshot{{
+			SnapshotId: "volume-0-3a9f2f4c-5c1d-4f4e-8d4b-9a0e4b4d7c11",
+			VolumeId:   s.BaseDisk.Name,
+			Status:     "ready",
+		}, {
+			SnapshotId: "volume-0-6c2d3e1a-0b8e-4b7a-9c3f-1d2e3f4a5b6c",
+			VolumeId:   "home-zone--replaced",
+			Status:     "ready",
+		}},
+	}, {}, {
+		Snapshots: []storage.VolumeSnapshot{{
+			SnapshotId: "volume-0-1-4f3e2d1c-0b9a-4876-a543-210fedcba987",
+			VolumeId:   "home-zone--machine",
+			Status:     "ready",
+		}},
+	}})
+}
+
+func (s *volumeSourceSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	restored := &google.Disk{
+		Name: "home-zone--e5f8a6b3-c2d5-4c36-9b0d-2f5e64e0a3a1",
+		Zone: "home-zone",
+		Size: 2048,
+	}
+	s.FakeConn.GoogleDisks = []*google.Disk{restored}
+	snapshotter := s.source.(storage.VolumeSnapshotter)
+	res, err := snapshotter.RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Volume:     names.NewVolumeTag("0"),
+		VolumeId:   s.BaseDisk.Name,
+		SnapshotId: "snap-0",
+		Size:       2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(res, jc.DeepEquals, []storage.RestoreVolumeSnapshotsResult{{
+		VolumeInfo: &storage.VolumeInfo{
+			VolumeId:   restored.Name,
+			Size:       2048,
+			Persistent: true,
+		},
+	}})
+
+	called, calls := s.FakeConn.WasCalled("CreateDisks")
+	c.Assert(called, jc.IsTrue)
+	c.Assert(calls[0].Disks, gc.HasLen, 1)
+	c.Assert(calls[0].Disks[0].SourceSnapshot, gc.Equals, "snap-0")
+	c.Assert(calls[0].Disks[0].SizeHintGB, gc.Equals, uint64(2))
+
+	// The original disk is left for the caller to destroy.
+	called, _ = s.FakeConn.WasCalled("RemoveDisk")
+	c.Assert(called, jc.IsFalse)
+}
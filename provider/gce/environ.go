@@ -35,6 +35,17 @@ type gceConnection interface {
 	OpenPorts(fwname string, rules ...network.IngressRule) error
 	ClosePorts(fwname string, rules ...network.IngressRule) error
 
+	EgressRules(target string) ([]network.EgressRule, error)
+	OpenEgressPorts(target string, rules ...network.EgressRule) error
+	CloseEgressPorts(target string, rules ...network.EgressRule) error
+	// DenyEgress denies all outbound traffic from the instances
+	// tagged with target, other than that allowed by the given rules
+	// and by the egress firewalls of the target.
+	DenyEgress(target string, allowed ...network.EgressRule) error
+	// RemoveEgressFirewalls removes all of the egress firewalls of
+	// the instances tagged with target.
+	RemoveEgressFirewalls(target string) error
+
 	AvailabilityZones(region string) ([]google.AvailabilityZone, error)
 	// Subnetworks returns the subnetworks that machines can be
 	// assigned to in the given region.
@@ -55,6 +66,9 @@ type gceConnection interface {
 	Disk(zone, id string) (*google.Disk, error)
 	// RemoveDisk will destroy the disk identified by <name> in <zone>.
 	RemoveDisk(zone, id string) error
+	// ResizeDisk will grow the disk identified by <name> in <zone>
+	// to <sizeGB> gigabytes.
+	ResizeDisk(zone, name string, sizeGB uint64) error
 	// AttachDisk will attach the volume identified by <volumeName> into the instance
 	// <instanceId> and return an AttachedDisk representing it or error.
 	AttachDisk(zone, volumeName, instanceId string, mode google.DiskMode) (*google.AttachedDisk, error)
@@ -63,6 +77,13 @@ type gceConnection interface {
 	DetachDisk(zone, instanceId, volumeName string) error
 	// InstanceDisks returns a list of the disks attached to the passed instance.
 	InstanceDisks(zone, instanceId string) ([]*google.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot named <name> of the disk
+	// <disk> in <zone>, and return a Snapshot representing it or error.
+	CreateSnapshot(zone, disk, name, description string) (*google.Snapshot, error)
+	// Snapshots will return a list of the snapshots in the project.
+	Snapshots() ([]*google.Snapshot, error)
+	// RemoveSnapshot will destroy the snapshot identified by <name>.
+	RemoveSnapshot(name string) error
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(zone string) ([]google.MachineType, error)
 }
@@ -226,6 +247,12 @@ func (env *environ) Destroy() error {
 		}
 	}
 
+	if env.Config().EgressDefaultDeny() {
+		if err := env.gce.RemoveEgressFirewalls(env.globalFirewallName()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
 	return destroyEnv(env)
 }
 
@@ -97,12 +97,15 @@ func (env *environ) startInstanceAvailabilityZones(args environs.StartInstancePa
 		return nil, errors.Trace(err)
 	}
 	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return []string{placementZone}, nil
 	}
 
 	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones, restricted by any zones constraint, for optimal spread
+	// across the instance distribution group.
 	var group []instance.Id
 	if args.DistributionGroup != nil {
 		group, err = args.DistributionGroup()
@@ -125,6 +128,10 @@ func (env *environ) startInstanceAvailabilityZones(args environs.StartInstancePa
 		return nil, errors.NotFoundf("failed to determine availability zones")
 	}
 
+	zoneNames, err = common.FilterZonesByConstraints(zoneNames, args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return zoneNames, nil
 }
 
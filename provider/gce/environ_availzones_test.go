@@ -8,6 +8,7 @@ import (
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce"
@@ -167,6 +168,39 @@ func (s *environAZSuite) TestStartInstanceAvailabilityZonesDistGroup(c *gc.C) {
 	c.Check(zones, jc.DeepEquals, []string{"home-zone"})
 }
 
+func (s *environAZSuite) TestStartInstanceAvailabilityZonesZonesConstraint(c *gc.C) {
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{
+		{ZoneName: "a-zone"}, {ZoneName: "b-zone"}, {ZoneName: "c-zone"},
+	}
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=c-zone,a-zone")
+
+	zones, err := gce.StartInstanceAvailabilityZones(s.Env, s.StartInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(zones, jc.DeepEquals, []string{"a-zone", "c-zone"})
+}
+
+func (s *environAZSuite) TestStartInstanceAvailabilityZonesZonesConstraintNoneAllowed(c *gc.C) {
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{ZoneName: "a-zone"}}
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=b-zone")
+
+	_, err := gce.StartInstanceAvailabilityZones(s.Env, s.StartInstArgs)
+
+	c.Check(err, jc.Satisfies, errors.IsNotFound)
+}
+
+func (s *environAZSuite) TestStartInstanceAvailabilityZonesPlacementZonesConstraint(c *gc.C) {
+	s.StartInstArgs.Placement = "zone=a-zone"
+	s.StartInstArgs.Constraints = constraints.MustParse("zones=b-zone")
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+
+	_, err := gce.StartInstanceAvailabilityZones(s.Env, s.StartInstArgs)
+
+	c.Check(err, gc.ErrorMatches, `availability zone "a-zone" not allowed by zones constraint "b-zone"`)
+}
+
 func (s *environAZSuite) TestStartInstanceAvailabilityZonesNoneFound(c *gc.C) {
 	_, err := gce.StartInstanceAvailabilityZones(s.Env, s.StartInstArgs)
 
@@ -20,6 +20,7 @@ import (
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce/google"
+	"github.com/juju/juju/storage"
 	"github.com/juju/juju/tools"
 )
 
@@ -41,6 +42,18 @@ func (env *environ) StartInstance(args environs.StartInstanceParams) (*environs.
 		return nil, errors.Trace(err)
 	}
 
+	if env.Config().EgressDefaultDeny() {
+		var apiPort int
+		if args.InstanceConfig.Controller != nil {
+			apiPort = args.InstanceConfig.Controller.Config.APIPort()
+		} else {
+			apiPort = args.InstanceConfig.APIInfo.Ports()[0]
+		}
+		if err := env.denyEgress(apiPort); err != nil {
+			return nil, errors.Annotate(err, "cannot deny egress")
+		}
+	}
+
 	raw, err := newRawInstance(env, args, spec)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -150,6 +163,18 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	rootDiskType, err := getRootDiskType(args.Constraints, args.RootDisk)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	disks[0].PersistentDiskType = rootDiskType
+
+	// An interface with no name has no access config, and so
+	// no external IP address.
+	networkInterface := "ExternalNAT"
+	if args.Constraints.HasAllocatePublicIP() && !*args.Constraints.AllocatePublicIP {
+		networkInterface = ""
+	}
 
 	// TODO(ericsnow) Use the env ID for the network name (instead of default)?
 	// TODO(ericsnow) Make the network name configurable?
@@ -159,7 +184,7 @@ func (env *environ) newRawInstance(args environs.StartInstanceParams, spec *inst
 		ID:                hostname,
 		Type:              spec.InstanceType.Name,
 		Disks:             disks,
-		NetworkInterfaces: []string{"ExternalNAT"},
+		NetworkInterfaces: []string{networkInterface},
 		Metadata:          metadata,
 		Tags:              tags,
 		// Network is omitted (left empty).
@@ -254,6 +279,33 @@ func getDisks(spec *instances.InstanceSpec, cons constraints.Value, ser, eUUID s
 	return []google.DiskSpec{dSpec}, nil
 }
 
+// getRootDiskType returns the type of persistent disk to use for the
+// root disk, as specified by the root disk storage pool or, failing
+// that, the root-disk-source constraint. If neither is specified, the
+// empty string is returned and GCE's default disk type is used.
+func getRootDiskType(cons constraints.Value, rootDisk *storage.VolumeParams) (google.DiskType, error) {
+	var diskType google.DiskType
+	switch {
+	case rootDisk != nil:
+		if rootDisk.Provider != storageProviderType {
+			return "", errors.NotSupportedf("root disk with storage provider %q", rootDisk.Provider)
+		}
+		diskType = google.DiskPersistentStandard
+		if value, ok := rootDisk.Attributes["type"].(string); ok {
+			diskType = google.DiskType(value)
+		}
+	case cons.HasRootDiskSource():
+		diskType = google.DiskType(*cons.RootDiskSource)
+	default:
+		return "", nil
+	}
+	switch diskType {
+	case google.DiskPersistentStandard, google.DiskPersistentSSD:
+		return diskType, nil
+	}
+	return "", errors.NotValidf("root disk type %q", diskType)
+}
+
 // getHardwareCharacteristics compiles hardware-related details about
 // the given instance and relative to the provided spec and returns it.
 func (env *environ) getHardwareCharacteristics(spec *instances.InstanceSpec, inst *environInstance) *instance.HardwareCharacteristics {
@@ -13,12 +13,15 @@ import (
 	"github.com/juju/version"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs/imagemetadata"
 	"github.com/juju/juju/environs/instances"
 	"github.com/juju/juju/environs/simplestreams"
 	"github.com/juju/juju/instance"
 	"github.com/juju/juju/provider/common"
 	"github.com/juju/juju/provider/gce"
+	"github.com/juju/juju/provider/gce/google"
+	"github.com/juju/juju/storage"
 )
 
 type environBrokerSuite struct {
@@ -129,6 +132,78 @@ func (s *environBrokerSuite) TestNewRawInstance(c *gc.C) {
 	c.Check(inst, jc.DeepEquals, s.BaseInstance)
 }
 
+func (s *environBrokerSuite) addInstanceSpec(c *gc.C) google.InstanceSpec {
+	for _, call := range s.FakeConn.Calls {
+		if call.FuncName == "AddInstance" {
+			return call.InstanceSpec
+		}
+	}
+	c.Fatalf("AddInstance not called")
+	return google.InstanceSpec{}
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceRootDiskSource(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.StartInstArgs.Constraints = constraints.MustParse("root-disk-source=pd-ssd")
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	spec := s.addInstanceSpec(c)
+	c.Assert(spec.Disks, gc.HasLen, 1)
+	c.Check(spec.Disks[0].PersistentDiskType, gc.Equals, google.DiskPersistentSSD)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceRootDiskPool(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+	s.StartInstArgs.Constraints = constraints.MustParse("root-disk-source=ssd-pool")
+	s.StartInstArgs.RootDisk = &storage.VolumeParams{
+		Provider:   "gce",
+		Attributes: map[string]interface{}{"type": "pd-ssd"},
+	}
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	spec := s.addInstanceSpec(c)
+	c.Assert(spec.Disks, gc.HasLen, 1)
+	c.Check(spec.Disks[0].PersistentDiskType, gc.Equals, google.DiskPersistentSSD)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceInvalidRootDiskSource(c *gc.C) {
+	s.StartInstArgs.Constraints = constraints.MustParse("root-disk-source=local-ssd")
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, gc.ErrorMatches, `root disk type "local-ssd" not valid`)
+
+	s.StartInstArgs.RootDisk = &storage.VolumeParams{Provider: "tmpfs"}
+	_, err = gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, gc.ErrorMatches, `root disk with storage provider "tmpfs" not supported`)
+}
+
+func (s *environBrokerSuite) TestNewRawInstanceAllocatePublicIP(c *gc.C) {
+	s.FakeConn.Inst = s.BaseInstance
+	s.FakeCommon.AZInstances = []common.AvailabilityZoneInstances{{
+		ZoneName:  "home-zone",
+		Instances: []instance.Id{s.Instance.Id()},
+	}}
+
+	_, err := gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.addInstanceSpec(c).NetworkInterfaces, jc.DeepEquals, []string{"ExternalNAT"})
+
+	s.FakeConn.Calls = nil
+	s.StartInstArgs.Constraints = constraints.MustParse("allocate-public-ip=false")
+	_, err = gce.NewRawInstance(s.Env, s.StartInstArgs, s.spec)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(s.addInstanceSpec(c).NetworkInterfaces, jc.DeepEquals, []string{""})
+}
+
 func (s *environBrokerSuite) TestGetMetadataUbuntu(c *gc.C) {
 	metadata, err := gce.GetMetadata(s.StartInstArgs, jujuos.Ubuntu)
 
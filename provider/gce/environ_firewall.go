@@ -4,12 +4,19 @@
 package gce
 
 import (
+	"strings"
+
 	"github.com/juju/errors"
+	"google.golang.org/api/compute/v1"
 
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/common"
+	"github.com/juju/juju/provider/gce/google"
 )
 
+var _ environs.EgressFirewaller = (*environ)(nil)
+
 // globalFirewallName returns the name to use for the global firewall.
 func (env *environ) globalFirewallName() string {
 	return common.EnvFullName(env.uuid)
@@ -38,3 +45,84 @@ func (env *environ) IngressRules() ([]network.IngressRule, error) {
 	rules, err := env.gce.IngressRules(env.globalFirewallName())
 	return rules, errors.Trace(err)
 }
+
+// OpenEgressPorts allows outgoing traffic on the given port ranges
+// for the whole environment. Must only be used if the environment was
+// setup with the FwGlobal firewall mode.
+func (env *environ) OpenEgressPorts(rules []network.EgressRule) error {
+	err := env.gce.OpenEgressPorts(env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// CloseEgressPorts denies outgoing traffic on the given port ranges
+// for the whole environment. Must only be used if the environment was
+// setup with the FwGlobal firewall mode.
+func (env *environ) CloseEgressPorts(rules []network.EgressRule) error {
+	err := env.gce.CloseEgressPorts(env.globalFirewallName(), rules...)
+	return errors.Trace(err)
+}
+
+// EgressRules returns the egress rules applicable for the whole
+// environment. Must only be used if the environment was setup with
+// the FwGlobal firewall mode.
+func (env *environ) EgressRules() ([]network.EgressRule, error) {
+	rules, err := env.gce.EgressRules(env.globalFirewallName())
+	return rules, errors.Trace(err)
+}
+
+// denyEgress denies all outbound traffic from the environment's
+// instances, other than to the API port and to the other instances
+// on the environment's network.
+func (env *environ) denyEgress(apiPort int) error {
+	cidrs, err := env.internalCIDRs()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	allowed := []network.EgressRule{
+		network.MustNewEgressRule("tcp", apiPort, apiPort),
+		network.MustNewEgressRule("tcp", 0, 65535, cidrs...),
+		network.MustNewEgressRule("udp", 0, 65535, cidrs...),
+		network.MustNewEgressRule("icmp", -1, -1, cidrs...),
+	}
+	err = env.gce.DenyEgress(env.globalFirewallName(), allowed...)
+	return errors.Trace(err)
+}
+
+// internalCIDRs returns the address ranges of the network which the
+// environment's instances are started on, in the environment's region.
+func (env *environ) internalCIDRs() ([]string, error) {
+	// Instances are always started on the default network.
+	var spec google.NetworkSpec
+	networks, err := env.gce.Networks()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var netwk *compute.Network
+	for _, n := range networks {
+		if strings.HasSuffix(n.SelfLink, "/"+spec.Path()) {
+			netwk = n
+			break
+		}
+	}
+	if netwk == nil {
+		return nil, errors.NotFoundf("network %q", spec.Path())
+	}
+	if netwk.IPv4Range != "" {
+		// A legacy network has a single range, and no subnetworks.
+		return []string{netwk.IPv4Range}, nil
+	}
+	subnets, err := env.gce.Subnetworks(env.cloud.Region)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var cidrs []string
+	for _, subnet := range subnets {
+		if subnet.Network == netwk.SelfLink {
+			cidrs = append(cidrs, subnet.IpCidrRange)
+		}
+	}
+	if len(cidrs) == 0 {
+		return nil, errors.NotFoundf("subnetworks of network %q in region %q", netwk.Name, env.cloud.Region)
+	}
+	return cidrs, nil
+}
@@ -5,8 +5,10 @@ package gce_test
 
 import (
 	jc "github.com/juju/testing/checkers"
+	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 )
 
@@ -75,3 +77,95 @@ func (s *environFirewallSuite) TestPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
 	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
 }
+
+func (s *environFirewallSuite) TestOpenEgressPortsAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	err := s.Env.OpenEgressPorts(rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenEgressPorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, rules)
+}
+
+func (s *environFirewallSuite) TestCloseEgressPortsAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	err := s.Env.CloseEgressPorts(rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CloseEgressPorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, rules)
+}
+
+func (s *environFirewallSuite) TestEgressRulesAPI(c *gc.C) {
+	fwname := gce.GlobalFirewallName(s.Env)
+	s.FakeConn.Egress = []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	rules, err := s.Env.EgressRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, s.FakeConn.Egress)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "EgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, fwname)
+}
+
+const networksURL = "https://www.googleapis.com/compute/v1/projects/sonic-youth/global/networks/"
+
+func (s *environFirewallSuite) TestDenyEgressAPI(c *gc.C) {
+	s.FakeConn.Networks_ = []*compute.Network{{
+		Name:     "default",
+		SelfLink: networksURL + "default",
+	}, {
+		Name:     "another",
+		SelfLink: networksURL + "another",
+	}}
+	s.FakeConn.Subnets = []*compute.Subnetwork{{
+		Name:        "default",
+		Network:     networksURL + "default",
+		IpCidrRange: "10.128.0.0/20",
+	}, {
+		Name:        "another",
+		Network:     networksURL + "another",
+		IpCidrRange: "192.168.0.0/24",
+	}}
+	err := gce.DenyEgress(s.Env, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Networks")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "Subnetworks")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "DenyEgress")
+	c.Check(s.FakeConn.Calls[2].FirewallName, gc.Equals, gce.GlobalFirewallName(s.Env))
+	c.Check(s.FakeConn.Calls[2].EgressRules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 17070, 17070),
+		network.MustNewEgressRule("tcp", 0, 65535, "10.128.0.0/20"),
+		network.MustNewEgressRule("udp", 0, 65535, "10.128.0.0/20"),
+		network.MustNewEgressRule("icmp", -1, -1, "10.128.0.0/20"),
+	})
+}
+
+func (s *environFirewallSuite) TestDenyEgressLegacyNetwork(c *gc.C) {
+	s.FakeConn.Networks_ = []*compute.Network{{
+		Name:      "default",
+		SelfLink:  networksURL + "default",
+		IPv4Range: "10.240.0.0/16",
+	}}
+	err := gce.DenyEgress(s.Env, 17070)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "DenyEgress")
+	c.Check(s.FakeConn.Calls[1].EgressRules[1], jc.DeepEquals, network.MustNewEgressRule("tcp", 0, 65535, "10.240.0.0/16"))
+}
+
+func (s *environFirewallSuite) TestDenyEgressNoNetwork(c *gc.C) {
+	err := gce.DenyEgress(s.Env, 17070)
+	c.Assert(err, gc.ErrorMatches, `network "global/networks/default" not found`)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+}
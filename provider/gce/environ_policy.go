@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance verifies that the provided series and constraints
@@ -17,9 +18,15 @@ func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, err := env.instancePlacementZone(args.Placement, volumeAttachmentsZone); err != nil {
+	placementZone, err := env.instancePlacementZone(args.Placement, volumeAttachmentsZone)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
 
 	if args.Constraints.HasInstanceType() {
 		if !checkInstanceType(args.Constraints) {
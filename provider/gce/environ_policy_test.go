@@ -84,6 +84,20 @@ func (s *environPolSuite) TestPrecheckInstanceAvailZone(c *gc.C) {
 	c.Check(err, jc.ErrorIsNil)
 }
 
+func (s *environPolSuite) TestPrecheckInstanceAvailZoneZonesConstraint(c *gc.C) {
+	s.FakeConn.Zones = []google.AvailabilityZone{
+		google.NewZone("a-zone", google.StatusUp, "", ""),
+	}
+
+	err := s.Env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   "zone=a-zone",
+		Constraints: constraints.MustParse("zones=b-zone"),
+	})
+
+	c.Check(err, gc.ErrorMatches, `availability zone "a-zone" not allowed by zones constraint "b-zone"`)
+}
+
 func (s *environPolSuite) TestPrecheckInstanceAvailZoneUnavailable(c *gc.C) {
 	s.FakeConn.Zones = []google.AvailabilityZone{
 		google.NewZone("a-zone", google.StatusDown, "", ""),
@@ -145,3 +145,15 @@ func (s *environSuite) TestDestroyAPI(c *gc.C) {
 		},
 	}})
 }
+
+func (s *environSuite) TestDestroyEgressDefaultDeny(c *gc.C) {
+	s.UpdateConfig(c, map[string]interface{}{"egress-default-deny": true})
+	err := s.Env.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+
+	fwname := common.EnvFullName(s.Env.Config().UUID())
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "Ports")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveEgressFirewalls")
+	c.Check(s.FakeConn.Calls[1].FirewallName, gc.Equals, fwname)
+}
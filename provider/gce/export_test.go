@@ -46,6 +46,10 @@ func GlobalFirewallName(env *environ) string {
 	return env.globalFirewallName()
 }
 
+func DenyEgress(env *environ, apiPort int) error {
+	return env.denyEgress(apiPort)
+}
+
 func ParsePlacement(env *environ, placement string) (*instPlacement, error) {
 	return env.parsePlacement(placement)
 }
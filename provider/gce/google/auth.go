@@ -4,9 +4,12 @@
 package google
 
 import (
+	"net/http"
+
 	"github.com/juju/errors"
 	"golang.org/x/oauth2"
 	goauth2 "golang.org/x/oauth2/google"
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -21,6 +24,28 @@ var (
 // the Auth's data and returns it. This includes building the
 // OAuth-wrapping network transport.
 func newConnection(creds *Credentials) (*compute.Service, error) {
+	client, err := newClient(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, err := compute.New(client)
+	return service, errors.Trace(err)
+}
+
+// newAlphaConnection opens a new low-level connection to the alpha
+// GCE API in the same way as newConnection.
+func newAlphaConnection(creds *Credentials) (*computealpha.Service, error) {
+	client, err := newClient(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	service, err := computealpha.New(client)
+	return service, errors.Trace(err)
+}
+
+// newClient returns an HTTP client which authenticates its requests
+// with the Auth's data.
+func newClient(creds *Credentials) (*http.Client, error) {
 	jsonKey := creds.JSONKey
 	if jsonKey == nil {
 		built, err := creds.buildJSONKey()
@@ -33,7 +58,5 @@ func newConnection(creds *Credentials) (*compute.Service, error) {
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
-	client := cfg.Client(oauth2.NoContext)
-	service, err := compute.New(client)
-	return service, errors.Trace(err)
+	return cfg.Client(oauth2.NoContext), nil
 }
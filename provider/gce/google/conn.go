@@ -5,6 +5,7 @@ package google
 
 import (
 	"github.com/juju/errors"
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 )
 
@@ -52,6 +53,19 @@ type rawConnectionWrapper interface {
 	// does not exist then this is a noop. The call blocks until the
 	// firewall is added or the request fails.
 	RemoveFirewall(projectID, name string) error
+	// GetEgressFirewalls sends an API request to GCE for the
+	// information about the egress firewalls with the namePrefix
+	// and returns them.
+	GetEgressFirewalls(projectID, namePrefix string) ([]*computealpha.Firewall, error)
+	// AddEgressFirewall requests GCE to add an egress firewall with
+	// the provided info. The call blocks until the firewall is added
+	// or the request fails.
+	AddEgressFirewall(projectID string, firewall *computealpha.Firewall) error
+	// UpdateEgressFirewall requests GCE to update the named egress
+	// firewall with the provided info, overwriting the existing data.
+	// The call blocks until the firewall is updated or the request
+	// fails.
+	UpdateEgressFirewall(projectID, name string, firewall *computealpha.Firewall) error
 	// ListAvailabilityZones returns the list of availability zones for a given
 	// GCE region. If none are found the the list is empty. Any failure in
 	// the low-level request is returned as an error.
@@ -65,6 +79,8 @@ type rawConnectionWrapper interface {
 	RemoveDisk(project, zone, id string) error
 	// GetDisk will return the disk correspondent to the passed id.
 	GetDisk(project, zone, id string) (*compute.Disk, error)
+	// ResizeDisk will grow the disk identified by id to sizeGb.
+	ResizeDisk(project, zone, id string, sizeGb int64) error
 	// AttachDisk will attach the disk described in attachedDisks (if it exists) into
 	// the instance with id instanceId.
 	AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error
@@ -74,6 +90,15 @@ type rawConnectionWrapper interface {
 	// InstanceDisks returns the disks attached to the instance identified
 	// by instanceId
 	InstanceDisks(project, zone, instanceId string) ([]*compute.AttachedDisk, error)
+	// CreateSnapshot will create a snapshot of the disk identified by
+	// disk, matching the specified spec.
+	CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error
+	// ListSnapshots returns a list of the snapshots in the project.
+	ListSnapshots(project string) ([]*compute.Snapshot, error)
+	// GetSnapshot will return the snapshot with the given name.
+	GetSnapshot(project, name string) (*compute.Snapshot, error)
+	// RemoveSnapshot will delete the snapshot with the given name.
+	RemoveSnapshot(project, name string) error
 	// ListMachineTypes returns a list of machines available in the project and zone provided.
 	ListMachineTypes(projectID, zone string) (*compute.MachineTypeList, error)
 	// ListSubnetworks returns a list of subnets available in the given project and region.
@@ -108,9 +133,13 @@ func Connect(connCfg ConnectionConfig, creds *Credentials) (*Connection, error)
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	alpha, err := newRawAlphaConnection(creds)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 
 	conn := &Connection{
-		raw:       &rawConn{raw},
+		raw:       &rawConn{Service: raw, alpha: alpha},
 		region:    connCfg.Region,
 		projectID: connCfg.ProjectID,
 	}
@@ -121,6 +150,10 @@ var newRawConnection = func(creds *Credentials) (*compute.Service, error) {
 	return newConnection(creds)
 }
 
+var newRawAlphaConnection = func(creds *Credentials) (*computealpha.Service, error) {
+	return newAlphaConnection(creds)
+}
+
 // TODO(ericsnow) Verify in each method that Connection.raw is set?
 
 // VerifyCredentials ensures that the authentication credentials used
@@ -59,6 +59,14 @@ func (gce *Connection) Disk(zone, name string) (*Disk, error) {
 	return NewDisk(d), nil
 }
 
+// ResizeDisk implements storage section of gceConnection.
+func (gce *Connection) ResizeDisk(zone, name string, sizeGB uint64) error {
+	if err := gce.raw.ResizeDisk(gce.projectID, zone, name, int64(sizeGB)); err != nil {
+		return errors.Annotatef(err, "cannot resize disk %q in zone %q", name, zone)
+	}
+	return nil
+}
+
 // deviceName will generate a device name from the passed
 // <zone> and <diskId>, the device name must not be confused
 // with the volume name, as it is used mainly to name the
@@ -142,3 +150,43 @@ func (gce *Connection) InstanceDisks(zone, instanceId string) ([]*AttachedDisk,
 	}
 	return att, nil
 }
+
+// snapshotURL returns the partial URL of the named snapshot, suitable
+// for use as the source of a new disk.
+func snapshotURL(name string) string {
+	return "global/snapshots/" + name
+}
+
+// CreateSnapshot implements storage section of gceConnection.
+func (gce *Connection) CreateSnapshot(zone, disk, name, description string) (*Snapshot, error) {
+	spec := &compute.Snapshot{
+		Name:        name,
+		Description: description,
+	}
+	if err := gce.raw.CreateSnapshot(gce.projectID, zone, disk, spec); err != nil {
+		return nil, errors.Annotatef(err, "cannot create snapshot %q", name)
+	}
+	snapshot, err := gce.raw.GetSnapshot(gce.projectID, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return NewSnapshot(snapshot), nil
+}
+
+// Snapshots implements storage section of gceConnection.
+func (gce *Connection) Snapshots() ([]*Snapshot, error) {
+	computeSnapshots, err := gce.raw.ListSnapshots(gce.projectID)
+	if err != nil {
+		return nil, errors.Annotate(err, "cannot list snapshots")
+	}
+	snapshots := make([]*Snapshot, len(computeSnapshots))
+	for i, snapshot := range computeSnapshots {
+		snapshots[i] = NewSnapshot(snapshot)
+	}
+	return snapshots, nil
+}
+
+// RemoveSnapshot implements storage section of gceConnection.
+func (gce *Connection) RemoveSnapshot(name string) error {
+	return gce.raw.RemoveSnapshot(gce.projectID, name)
+}
@@ -4,6 +4,8 @@
 package google_test
 
 import (
+	"time"
+
 	jc "github.com/juju/testing/checkers"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
@@ -123,6 +125,18 @@ func (s *connSuite) TestConnectionRemoveDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
 }
 
+func (s *connSuite) TestConnectionResizeDisk(c *gc.C) {
+	err := s.Conn.ResizeDisk("home-zone", fakeVolName, 20)
+	c.Check(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "ResizeDisk")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].SizeGb, gc.Equals, int64(20))
+}
+
 func (s *connSuite) TestConnectionInstanceDisks(c *gc.C) {
 	s.FakeConn.AttachedDisks = []*compute.AttachedDisk{{
 		Source:     "https://bogus/url/project/aproject/zone/azone/disk/" + fakeVolName,
@@ -142,3 +156,43 @@ func (s *connSuite) TestConnectionInstanceDisks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
 	c.Check(s.FakeConn.Calls[0].InstanceId, gc.Equals, "a-fake-instance")
 }
+
+func (s *connSuite) TestConnectionCreateSnapshot(c *gc.C) {
+	s.FakeConn.Snapshot = &compute.Snapshot{
+		Name:              "snap-1",
+		Description:       "model-uuid",
+		SourceDisk:        "https://www.googleapis.com/compute/v1/projects/spam/zones/home-zone/disks/" + fakeVolName,
+		DiskSizeGb:        10,
+		Status:            "READY",
+		CreationTimestamp: "2017-11-01T10:00:00Z",
+	}
+	snapshot, err := s.Conn.CreateSnapshot("home-zone", fakeVolName, "snap-1", "model-uuid")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(snapshot, jc.DeepEquals, &google.Snapshot{
+		Name:        "snap-1",
+		Description: "model-uuid",
+		SourceDisk:  fakeVolName,
+		Size:        10 * 1024,
+		Status:      google.SnapshotReady,
+		Created:     time.Date(2017, 11, 1, 10, 0, 0, 0, time.UTC),
+	})
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CreateSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].ZoneName, gc.Equals, "home-zone")
+	c.Check(s.FakeConn.Calls[0].ID, gc.Equals, fakeVolName)
+	c.Check(s.FakeConn.Calls[0].Snapshot.Name, gc.Equals, "snap-1")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "GetSnapshot")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "snap-1")
+}
+
+func (s *connSuite) TestConnectionRemoveSnapshot(c *gc.C) {
+	err := s.Conn.RemoveSnapshot("snap-1")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "RemoveSnapshot")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "snap-1")
+}
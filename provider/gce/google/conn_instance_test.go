@@ -106,6 +106,20 @@ func (s *instanceSuite) TestConnectionAddInstanceAPI(c *gc.C) {
 	})
 }
 
+func (s *instanceSuite) TestConnectionAddInstanceDiskType(c *gc.C) {
+	s.FakeConn.Instance = &s.RawInstanceFull
+	s.InstanceSpec.Disks[0].PersistentDiskType = google.DiskPersistentSSD
+
+	_, err := s.Conn.AddInstance(s.InstanceSpec, "a-zone")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "AddInstance")
+	disks := s.FakeConn.Calls[0].InstValue.Disks
+	c.Assert(disks, gc.HasLen, 1)
+	c.Check(disks[0].InitializeParams.DiskType, gc.Equals, "zones/a-zone/diskTypes/pd-ssd")
+}
+
 func (s *connSuite) TestConnectionAddInstanceFailed(c *gc.C) {
 	s.FakeConn.Instance = &s.RawInstanceFull
 
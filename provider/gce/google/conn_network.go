@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/rand"
 	"sort"
+	"strings"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils/set"
@@ -216,6 +217,249 @@ func (gce Connection) ClosePorts(target string, rules ...network.IngressRule) er
 	return nil
 }
 
+// The prefixes of the names of the egress firewalls of a target.
+// Each firewall allowing egress is named for its prefix, the target
+// and a hash of its destination CIDRs. The names must not start with
+// the target itself, as they would then be read as ingress firewalls.
+const (
+	egressFirewallPrefix      = "egress-"
+	allowEgressFirewallPrefix = "allow-"
+	denyEgressFirewallPrefix  = "deny-"
+)
+
+// egressFirewall represents a GCE egress firewall - if it was
+// constructed from a set of egress rules, the name is still populated
+// as it is derived from the destination CIDRs.
+type egressFirewall struct {
+	Name             string
+	DestinationCIDRs []string
+	AllowedPorts     protocolPorts
+}
+
+// newEgressFirewalls groups the egress rules by destination CIDRs,
+// returning the firewalls needed to allow them keyed by name.
+func newEgressFirewalls(prefix, target string, rules []network.EgressRule) map[string]*egressFirewall {
+	result := make(map[string]*egressFirewall)
+	for _, rule := range rules {
+		cidrs := sourcecidrs(rule.DestinationCIDRs)
+		if len(cidrs) == 0 {
+			cidrs = sourcecidrs{"0.0.0.0/0"}
+		}
+		name := prefix + target + "-" + cidrs.key()
+		fw, ok := result[name]
+		if !ok {
+			fw = &egressFirewall{
+				Name:             name,
+				DestinationCIDRs: cidrs.sorted(),
+				AllowedPorts:     make(protocolPorts),
+			}
+			result[name] = fw
+		}
+		ports := fw.AllowedPorts
+		ports[rule.Protocol] = append(ports[rule.Protocol], rule.PortRange)
+	}
+	return result
+}
+
+// egressFirewalls returns the egress firewalls of the target with
+// the given name prefix, keyed by name.
+func (gce Connection) egressFirewalls(prefix, target string) (map[string]*egressFirewall, error) {
+	firewalls, err := gce.raw.GetEgressFirewalls(gce.projectID, prefix+target+"-")
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting egress firewall rules from GCE")
+	}
+	result := make(map[string]*egressFirewall)
+	for _, fw := range firewalls {
+		if len(fw.TargetTags) != 1 || fw.TargetTags[0] != target {
+			continue
+		}
+		ports := make(protocolPorts)
+		for _, allowed := range fw.Allowed {
+			if len(allowed.Ports) == 0 {
+				ports[allowed.IPProtocol] = append(ports[allowed.IPProtocol], network.PortRange{
+					Protocol: allowed.IPProtocol,
+					FromPort: -1,
+					ToPort:   -1,
+				})
+				continue
+			}
+			for _, rangeStr := range allowed.Ports {
+				portRange, err := network.ParsePortRange(rangeStr)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				portRange.Protocol = allowed.IPProtocol
+				ports[allowed.IPProtocol] = append(ports[allowed.IPProtocol], portRange)
+			}
+		}
+		result[fw.Name] = &egressFirewall{
+			Name:             fw.Name,
+			DestinationCIDRs: fw.DestinationRanges,
+			AllowedPorts:     ports,
+		}
+	}
+	return result, nil
+}
+
+// EgressRules returns the egress rules of the firewalls allowing
+// outbound traffic from the target. The rules are returned as sorted
+// by SortEgressRules.
+func (gce Connection) EgressRules(target string) ([]network.EgressRule, error) {
+	firewalls, err := gce.egressFirewalls(egressFirewallPrefix, target)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var results []network.EgressRule
+	for _, fw := range firewalls {
+		for _, portRanges := range fw.AllowedPorts {
+			for _, p := range portRanges {
+				rule, err := network.NewEgressRule(p.Protocol, p.FromPort, p.ToPort, fw.DestinationCIDRs...)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				results = append(results, rule)
+			}
+		}
+	}
+	network.SortEgressRules(results)
+	return results, nil
+}
+
+// OpenEgressPorts adds or updates GCE egress firewalls so that
+// traffic from the target to the destination ranges specified by the
+// egress rules is allowed.
+func (gce Connection) OpenEgressPorts(target string, rules ...network.EgressRule) error {
+	return errors.Trace(gce.openEgressPorts(egressFirewallPrefix, target, rules))
+}
+
+func (gce Connection) openEgressPorts(prefix, target string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	current, err := gce.egressFirewalls(prefix, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	input := newEgressFirewalls(prefix, target, rules)
+
+	// Get the firewalls by sorted name for deterministic testing.
+	var sortedNames []string
+	for name := range input {
+		sortedNames = append(sortedNames, name)
+	}
+	sort.Strings(sortedNames)
+
+	for _, name := range sortedNames {
+		inputFirewall := input[name]
+		existingFirewall, ok := current[name]
+		if !ok {
+			spec := egressFirewallSpec(name, target, inputFirewall.DestinationCIDRs, inputFirewall.AllowedPorts)
+			if err := gce.raw.AddEgressFirewall(gce.projectID, spec); err != nil {
+				return errors.Annotatef(err, "opening egress port(s) %+v", rules)
+			}
+			continue
+		}
+		allowedPorts := existingFirewall.AllowedPorts.union(inputFirewall.AllowedPorts)
+		spec := egressFirewallSpec(name, target, existingFirewall.DestinationCIDRs, allowedPorts)
+		if err := gce.raw.UpdateEgressFirewall(gce.projectID, name, spec); err != nil {
+			return errors.Annotatef(err, "opening egress port(s) %+v", rules)
+		}
+	}
+	return nil
+}
+
+// CloseEgressPorts removes the port ranges of the egress rules from
+// the target's egress firewalls. A firewall left with no ports is
+// removed.
+func (gce Connection) CloseEgressPorts(target string, rules ...network.EgressRule) error {
+	current, err := gce.egressFirewalls(egressFirewallPrefix, target)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for name, inputFirewall := range newEgressFirewalls(egressFirewallPrefix, target, rules) {
+		existingFirewall, ok := current[name]
+		if !ok {
+			continue
+		}
+		remainingPorts := make(protocolPorts)
+		for protocol, portRanges := range existingFirewall.AllowedPorts {
+			for _, p := range portRanges {
+				if !containsPortRange(inputFirewall.AllowedPorts[protocol], p) {
+					remainingPorts[protocol] = append(remainingPorts[protocol], p)
+				}
+			}
+		}
+		if len(remainingPorts) == 0 {
+			if err := gce.raw.RemoveFirewall(gce.projectID, name); err != nil {
+				return errors.Annotatef(err, "closing egress port(s) %+v", rules)
+			}
+			continue
+		}
+		spec := egressFirewallSpec(name, target, existingFirewall.DestinationCIDRs, remainingPorts)
+		if err := gce.raw.UpdateEgressFirewall(gce.projectID, name, spec); err != nil {
+			return errors.Annotatef(err, "closing egress port(s) %+v", rules)
+		}
+	}
+	return nil
+}
+
+func containsPortRange(portRanges []network.PortRange, p network.PortRange) bool {
+	for _, portRange := range portRanges {
+		if portRange == p {
+			return true
+		}
+	}
+	return false
+}
+
+// DenyEgress ensures that all outbound traffic from the target is
+// denied, except for the traffic allowed by the given rules and by
+// the target's egress firewalls. The allowed rules are kept apart
+// from the firewalls managed by OpenEgressPorts and CloseEgressPorts.
+func (gce Connection) DenyEgress(target string, allowed ...network.EgressRule) error {
+	if err := gce.openEgressPorts(allowEgressFirewallPrefix, target, allowed); err != nil {
+		return errors.Trace(err)
+	}
+	name := denyEgressFirewallPrefix + target
+	firewalls, err := gce.raw.GetEgressFirewalls(gce.projectID, name)
+	if err != nil {
+		return errors.Annotate(err, "while getting egress firewall rules from GCE")
+	}
+	for _, fw := range firewalls {
+		if fw.Name == name {
+			return nil
+		}
+	}
+	if err := gce.raw.AddEgressFirewall(gce.projectID, denyEgressFirewallSpec(name, target)); err != nil {
+		return errors.Annotate(err, "denying egress")
+	}
+	return nil
+}
+
+// RemoveEgressFirewalls removes all of the egress firewalls of the
+// target, including those added by DenyEgress.
+func (gce Connection) RemoveEgressFirewalls(target string) error {
+	denyName := denyEgressFirewallPrefix + target
+	firewalls, err := gce.raw.GetEgressFirewalls(gce.projectID, "")
+	if err != nil {
+		return errors.Annotate(err, "while getting egress firewall rules from GCE")
+	}
+	for _, fw := range firewalls {
+		if len(fw.TargetTags) != 1 || fw.TargetTags[0] != target {
+			continue
+		}
+		if fw.Name != denyName &&
+			!strings.HasPrefix(fw.Name, egressFirewallPrefix+target+"-") &&
+			!strings.HasPrefix(fw.Name, allowEgressFirewallPrefix+target+"-") {
+			continue
+		}
+		if err := gce.raw.RemoveFirewall(gce.projectID, fw.Name); err != nil && !errors.IsNotFound(err) {
+			return errors.Annotatef(err, "removing egress firewall %q", fw.Name)
+		}
+	}
+	return nil
+}
+
 // Subnetworks returns the subnets available in this region.
 func (gce Connection) Subnetworks(region string) ([]*compute.Subnetwork, error) {
 	results, err := gce.raw.ListSubnetworks(gce.projectID, region)
@@ -10,6 +10,7 @@ import (
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	"github.com/juju/utils/set"
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
@@ -459,6 +460,210 @@ func (s *connSuite) TestSubnetworks(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Region, gc.Equals, "us-central1")
 }
 
+func (s *connSuite) TestConnectionEgressRules(c *gc.C) {
+	s.FakeConn.EgressFws = []*computealpha.Firewall{{
+		Name:              "egress-spam-a34d80f7b6",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/24"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}, {
+			IPProtocol: "icmp",
+		}},
+	}, {
+		Name:              "egress-spam-eggs-b42e18366a",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam-eggs"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+	rules, err := s.Conn.EgressRules("spam")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("icmp", -1, -1, "10.0.0.0/24"),
+		network.MustNewEgressRule("tcp", 80, 81, "10.0.0.0/24"),
+	})
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetEgressFirewalls")
+	c.Check(s.FakeConn.Calls[0].ProjectID, gc.Equals, "spam")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "egress-spam-")
+}
+
+func (s *connSuite) TestConnectionOpenEgressPortsAdd(c *gc.C) {
+	err := s.Conn.OpenEgressPorts("spam",
+		network.MustNewEgressRule("tcp", 80, 81), // leave out CIDR to check default
+		network.MustNewEgressRule("udp", 67, 67, "10.0.0.0/24"),
+	)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetEgressFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddEgressFirewall")
+	c.Check(s.FakeConn.Calls[1].EgressFw, jc.DeepEquals, &computealpha.Firewall{
+		Name:              "egress-spam-a34d80f7b6",
+		Direction:         "EGRESS",
+		Priority:          1000,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"10.0.0.0/24"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "udp",
+			Ports:      []string{"67"},
+		}},
+	})
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "AddEgressFirewall")
+	c.Check(s.FakeConn.Calls[2].EgressFw, jc.DeepEquals, &computealpha.Firewall{
+		Name:              "egress-spam-b42e18366a",
+		Direction:         "EGRESS",
+		Priority:          1000,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80-81"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionOpenEgressPortsUpdate(c *gc.C) {
+	s.FakeConn.EgressFws = []*computealpha.Firewall{{
+		Name:              "egress-spam-b42e18366a",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80"},
+		}},
+	}}
+	err := s.Conn.OpenEgressPorts("spam", network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateEgressFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "egress-spam-b42e18366a")
+	c.Check(s.FakeConn.Calls[1].EgressFw, jc.DeepEquals, &computealpha.Firewall{
+		Name:              "egress-spam-b42e18366a",
+		Direction:         "EGRESS",
+		Priority:          1000,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80", "443"},
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionCloseEgressPorts(c *gc.C) {
+	s.FakeConn.EgressFws = []*computealpha.Firewall{{
+		Name:              "egress-spam-b42e18366a",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"80", "443"},
+		}},
+	}}
+	err := s.Conn.CloseEgressPorts("spam", network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "UpdateEgressFirewall")
+	c.Check(s.FakeConn.Calls[1].EgressFw.Allowed, jc.DeepEquals, []*computealpha.FirewallAllowed{{
+		IPProtocol: "tcp",
+		Ports:      []string{"80"},
+	}})
+}
+
+func (s *connSuite) TestConnectionCloseEgressPortsRemove(c *gc.C) {
+	s.FakeConn.EgressFws = []*computealpha.Firewall{{
+		Name:              "egress-spam-b42e18366a",
+		Direction:         "EGRESS",
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"443"},
+		}},
+	}}
+	err := s.Conn.CloseEgressPorts("spam", network.MustNewEgressRule("tcp", 443, 443))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 2)
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "egress-spam-b42e18366a")
+}
+
+func (s *connSuite) TestConnectionDenyEgress(c *gc.C) {
+	err := s.Conn.DenyEgress("spam", network.MustNewEgressRule("tcp", 17070, 17070))
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 4)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetEgressFirewalls")
+	c.Check(s.FakeConn.Calls[0].Name, gc.Equals, "allow-spam-")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "AddEgressFirewall")
+	c.Check(s.FakeConn.Calls[1].EgressFw, jc.DeepEquals, &computealpha.Firewall{
+		Name:              "allow-spam-b42e18366a",
+		Direction:         "EGRESS",
+		Priority:          1000,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Allowed: []*computealpha.FirewallAllowed{{
+			IPProtocol: "tcp",
+			Ports:      []string{"17070"},
+		}},
+	})
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "GetEgressFirewalls")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "deny-spam")
+	c.Check(s.FakeConn.Calls[3].FuncName, gc.Equals, "AddEgressFirewall")
+	c.Check(s.FakeConn.Calls[3].EgressFw, jc.DeepEquals, &computealpha.Firewall{
+		Name:              "deny-spam",
+		Direction:         "EGRESS",
+		Priority:          65534,
+		TargetTags:        []string{"spam"},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*computealpha.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	})
+}
+
+func (s *connSuite) TestConnectionRemoveEgressFirewalls(c *gc.C) {
+	s.FakeConn.EgressFws = []*computealpha.Firewall{{
+		Name:       "deny-spam",
+		Direction:  "EGRESS",
+		TargetTags: []string{"spam"},
+	}, {
+		Name:       "egress-spam-b42e18366a",
+		Direction:  "EGRESS",
+		TargetTags: []string{"spam"},
+	}, {
+		Name:       "egress-spam-eggs-b42e18366a",
+		Direction:  "EGRESS",
+		TargetTags: []string{"spam-eggs"},
+	}, {
+		Name:       "spam-egress",
+		Direction:  "EGRESS",
+		TargetTags: []string{"spam"},
+	}}
+	err := s.Conn.RemoveEgressFirewalls("spam")
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 3)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "GetEgressFirewalls")
+	c.Check(s.FakeConn.Calls[1].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[1].Name, gc.Equals, "deny-spam")
+	c.Check(s.FakeConn.Calls[2].FuncName, gc.Equals, "RemoveFirewall")
+	c.Check(s.FakeConn.Calls[2].Name, gc.Equals, "egress-spam-b42e18366a")
+}
+
 func (s *connSuite) TestRandomSuffixNamer(c *gc.C) {
 	ruleset := google.NewRuleSetFromRules(
 		network.MustNewIngressRule("tcp", 80, 80),
@@ -106,6 +106,9 @@ type DiskSpec struct {
 	// characters must be a dash, lowercase letter, or digit, except the
 	// last character, which cannot be a dash.
 	Name string
+	// SourceSnapshot is the name of the snapshot from which the disk
+	// should be created, if any. (detached only)
+	SourceSnapshot string
 	// Description holds a description of the disk, it currently holds
 	// modelUUID.
 	// This field is used instead of a tag or metadata because, at the moment of writing
@@ -156,7 +159,9 @@ func (ds *DiskSpec) newAttached() *compute.AttachedDisk {
 		InitializeParams: &compute.AttachedDiskInitializeParams{
 			// DiskName (defaults to instance name)
 			DiskSizeGb: int64(ds.SizeGB()),
-			// DiskType (defaults to pd-standard, pd-ssd, local-ssd)
+			// DiskType is resolved relative to an availability zone
+			// when the API request is sent (defaults to pd-standard).
+			DiskType:    string(ds.PersistentDiskType),
 			SourceImage: ds.ImageURL,
 		},
 		// Interface (defaults to SCSI)
@@ -175,13 +180,17 @@ func (ds *DiskSpec) newDetached() (*compute.Disk, error) {
 	if ds.PersistentDiskType == DiskLocalSSD {
 		return nil, errors.New("cannot create local ssd disks detached")
 	}
-	return &compute.Disk{
+	disk := &compute.Disk{
 		Name:        ds.Name,
 		SizeGb:      int64(ds.SizeGB()),
 		SourceImage: ds.ImageURL,
 		Type:        string(ds.PersistentDiskType),
 		Description: ds.Description,
-	}, nil
+	}
+	if ds.SourceSnapshot != "" {
+		disk.SourceSnapshot = snapshotURL(ds.SourceSnapshot)
+	}
+	return disk, nil
 }
 
 // AttachedDisk represents a disk that is attached to an instance.
@@ -128,6 +128,18 @@ func (s *diskSuite) TestDiskSpecNewAttachedReadOnly(c *gc.C) {
 	})
 }
 
+func (s *diskSuite) TestDiskSpecNewAttachedPersistentDiskType(c *gc.C) {
+	s.DiskSpec.PersistentDiskType = google.DiskPersistentSSD
+	attached := google.NewAttached(s.DiskSpec)
+
+	s.checkAttached(c, attachedInfo{
+		attached: attached,
+		diskType: "PERSISTENT",
+		diskMode: "READ_WRITE",
+	})
+	c.Check(attached.InitializeParams.DiskType, gc.Equals, "pd-ssd")
+}
+
 func (s *diskSuite) TestRootDiskInstance(c *gc.C) {
 	attached := s.Instance.RootDisk()
 
@@ -231,3 +231,30 @@ func unpackMetadata(data *compute.Metadata) map[string]string {
 func formatMachineType(zone, name string) string {
 	return fmt.Sprintf("zones/%s/machineTypes/%s", zone, name)
 }
+
+func formatZoneDiskType(zone, name string) string {
+	return fmt.Sprintf("zones/%s/diskTypes/%s", zone, name)
+}
+
+// zoneDisks returns the given attached disks, with the types of any
+// disks to be created resolved relative to the given zone. Disks whose
+// type must be resolved are copied, so that the originals may be
+// resolved again for another zone.
+func zoneDisks(zone string, disks []*compute.AttachedDisk) []*compute.AttachedDisk {
+	if len(disks) == 0 {
+		return disks
+	}
+	result := make([]*compute.AttachedDisk, len(disks))
+	for i, disk := range disks {
+		result[i] = disk
+		if disk.InitializeParams == nil || disk.InitializeParams.DiskType == "" {
+			continue
+		}
+		params := *disk.InitializeParams
+		params.DiskType = formatZoneDiskType(zone, params.DiskType)
+		zoneDisk := *disk
+		zoneDisk.InitializeParams = &params
+		result[i] = &zoneDisk
+	}
+	return result
+}
@@ -6,6 +6,7 @@ package google
 import (
 	"sort"
 
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 
 	"github.com/juju/juju/network"
@@ -16,6 +17,16 @@ const (
 	networkPathRoot    = "global/networks/"
 )
 
+// The direction and priorities of egress firewalls. A firewall with
+// a lower priority value takes precedence, so the firewall denying
+// all egress only applies to traffic no other firewall allows.
+const (
+	firewallDirectionEgress = "EGRESS"
+
+	egressFirewallPriority     = 1000
+	denyEgressFirewallPriority = 65534
+)
+
 // The different kinds of network access.
 const (
 	NetworkAccessOneToOneNAT = "ONE_TO_ONE_NAT" // the default
@@ -89,6 +100,56 @@ func firewallSpec(name, target string, sourceCIDRs []string, ports protocolPorts
 	return &firewall
 }
 
+// egressFirewallSpec expands a port range set in to
+// computealpha.FirewallAllowed and returns an egress firewall for the
+// provided name, allowing traffic to the destination CIDRs.
+func egressFirewallSpec(name, target string, destinationCIDRs []string, ports protocolPorts) *computealpha.Firewall {
+	if len(destinationCIDRs) == 0 {
+		destinationCIDRs = []string{"0.0.0.0/0"}
+	}
+	firewall := computealpha.Firewall{
+		// Allowed is set below.
+		Name:              name,
+		Direction:         firewallDirectionEgress,
+		Priority:          egressFirewallPriority,
+		TargetTags:        []string{target},
+		DestinationRanges: destinationCIDRs,
+	}
+
+	var sortedProtocols []string
+	for protocol := range ports {
+		sortedProtocols = append(sortedProtocols, protocol)
+	}
+	sort.Strings(sortedProtocols)
+
+	for _, protocol := range sortedProtocols {
+		allowed := computealpha.FirewallAllowed{
+			IPProtocol: protocol,
+		}
+		// ICMP has no ports.
+		if protocol != "icmp" {
+			allowed.Ports = ports.portStrings(protocol)
+		}
+		firewall.Allowed = append(firewall.Allowed, &allowed)
+	}
+	return &firewall
+}
+
+// denyEgressFirewallSpec returns an egress firewall for the provided
+// name, denying all traffic from the target.
+func denyEgressFirewallSpec(name, target string) *computealpha.Firewall {
+	return &computealpha.Firewall{
+		Name:              name,
+		Direction:         firewallDirectionEgress,
+		Priority:          denyEgressFirewallPriority,
+		TargetTags:        []string{target},
+		DestinationRanges: []string{"0.0.0.0/0"},
+		Denied: []*computealpha.FirewallDenied{{
+			IPProtocol: "all",
+		}},
+	}
+}
+
 func extractAddresses(interfaces ...*compute.NetworkInterface) []network.Address {
 	var addresses []network.Address
 
@@ -13,6 +13,7 @@ import (
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"golang.org/x/net/context"
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 	"google.golang.org/api/googleapi"
 )
@@ -40,6 +41,10 @@ func convertRawAPIError(err error) error {
 
 type rawConn struct {
 	*compute.Service
+
+	// alpha is used for egress firewalls, which the v1 compute API
+	// does not support.
+	alpha *computealpha.Service
 }
 
 func (rc *rawConn) GetProject(projectID string) (*compute.Project, error) {
@@ -168,6 +173,59 @@ func (rc *rawConn) RemoveFirewall(projectID, name string) error {
 	return errors.Trace(convertRawAPIError(err))
 }
 
+// GetEgressFirewalls returns the egress firewalls with the
+// namePrefix. Unlike GetFirewalls, no error is returned if there
+// are none.
+func (rc *rawConn) GetEgressFirewalls(projectID, namePrefix string) ([]*computealpha.Firewall, error) {
+	call := rc.alpha.Firewalls.List(projectID)
+	firewallList, err := call.Do()
+	if err != nil {
+		return nil, errors.Annotate(err, "while getting egress firewalls from GCE")
+	}
+
+	var result []*computealpha.Firewall
+	for _, fw := range firewallList.Items {
+		if fw.Direction == firewallDirectionEgress && strings.HasPrefix(fw.Name, namePrefix) {
+			result = append(result, fw)
+		}
+	}
+	return result, nil
+}
+
+func (rc *rawConn) AddEgressFirewall(projectID string, firewall *computealpha.Firewall) error {
+	call := rc.alpha.Firewalls.Insert(projectID, firewall)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = rc.waitOperation(projectID, v1Operation(operation), attemptsLong)
+	return errors.Trace(err)
+}
+
+func (rc *rawConn) UpdateEgressFirewall(projectID, name string, firewall *computealpha.Firewall) error {
+	call := rc.alpha.Firewalls.Update(projectID, name, firewall)
+	operation, err := call.Do()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	err = rc.waitOperation(projectID, v1Operation(operation), attemptsLong)
+	return errors.Trace(err)
+}
+
+// v1Operation returns the v1 operation for the given alpha API
+// operation, so that it can be waited on. The status is left out,
+// so the operation is always checked (and any errors reported)
+// through the v1 API.
+func v1Operation(op *computealpha.Operation) *compute.Operation {
+	return &compute.Operation{
+		Name:   op.Name,
+		Zone:   op.Zone,
+		Region: op.Region,
+	}
+}
+
 func (rc *rawConn) ListAvailabilityZones(projectID, region string) ([]*compute.Zone, error) {
 	call := rc.Zones.List(projectID)
 	if region != "" {
@@ -255,6 +313,15 @@ func (rc *rawConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return disk, nil
 }
 
+func (rc *rawConn) ResizeDisk(project, zone, id string, sizeGb int64) error {
+	req := &compute.DisksResizeRequest{SizeGb: sizeGb}
+	op, err := rc.Disks.Resize(project, zone, id, req).Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not resize disk %q", id)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 func (rc *rawConn) AttachDisk(project, zone, instanceId string, disk *compute.AttachedDisk) error {
 	call := rc.Instances.AttachDisk(project, zone, instanceId, disk)
 	_, err := call.Do() // Perhaps return something from the Op
@@ -281,6 +348,48 @@ func (rc *rawConn) InstanceDisks(project, zone, instanceId string) ([]*compute.A
 	return instance.Disks, nil
 }
 
+func (rc *rawConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error {
+	call := rc.Disks.CreateSnapshot(project, zone, disk, spec)
+	op, err := call.Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not snapshot disk %q", disk)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
+func (rc *rawConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := rc.Snapshots.List(project)
+	var results []*compute.Snapshot
+	for {
+		snapshotList, err := call.Do()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		results = append(results, snapshotList.Items...)
+		if snapshotList.NextPageToken == "" {
+			break
+		}
+		call = call.PageToken(snapshotList.NextPageToken)
+	}
+	return results, nil
+}
+
+func (rc *rawConn) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+	snapshot, err := rc.Snapshots.Get(project, name).Do()
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get snapshot %q in project %q", name, project)
+	}
+	return snapshot, nil
+}
+
+func (rc *rawConn) RemoveSnapshot(project, name string) error {
+	op, err := rc.Snapshots.Delete(project, name).Do()
+	if err != nil {
+		return errors.Annotatef(err, "could not delete snapshot %q", name)
+	}
+	return errors.Trace(rc.waitOperation(project, op, attemptsLong))
+}
+
 type waitError struct {
 	op    *compute.Operation
 	cause error
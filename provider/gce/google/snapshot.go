@@ -0,0 +1,55 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package google
+
+import (
+	"time"
+
+	"google.golang.org/api/compute/v1"
+)
+
+// SnapshotStatus is the status of a GCE disk snapshot.
+type SnapshotStatus string
+
+// The snapshot statuses reported by GCE.
+const (
+	SnapshotCreating  SnapshotStatus = "CREATING"
+	SnapshotDeleting  SnapshotStatus = "DELETING"
+	SnapshotFailed    SnapshotStatus = "FAILED"
+	SnapshotReady     SnapshotStatus = "READY"
+	SnapshotUploading SnapshotStatus = "UPLOADING"
+)
+
+// Snapshot represents a gce disk snapshot.
+type Snapshot struct {
+	// Name is a unique identifier string for each snapshot.
+	Name string
+	// Description holds the description field for a snapshot, we
+	// store model UUID here, as we do for disks.
+	Description string
+	// SourceDisk is the name of the disk from which the snapshot
+	// was taken.
+	SourceDisk string
+	// Size is the size of the source disk in MiB.
+	Size uint64
+	// Status holds the status of the snapshot.
+	Status SnapshotStatus
+	// Created is the time at which the snapshot was created.
+	Created time.Time
+}
+
+// NewSnapshot returns a Snapshot representing the given compute snapshot.
+func NewSnapshot(cs *compute.Snapshot) *Snapshot {
+	s := &Snapshot{
+		Name:        cs.Name,
+		Description: cs.Description,
+		SourceDisk:  sourceToVolumeName(cs.SourceDisk),
+		Size:        gibToMib(cs.DiskSizeGb),
+		Status:      SnapshotStatus(cs.Status),
+	}
+	if created, err := time.Parse(time.RFC3339, cs.CreationTimestamp); err == nil {
+		s.Created = created
+	}
+	return s
+}
@@ -4,6 +4,7 @@
 package google
 
 import (
+	computealpha "google.golang.org/api/compute/v0.alpha"
 	"google.golang.org/api/compute/v1"
 	gc "gopkg.in/check.v1"
 
@@ -162,10 +163,13 @@ type fakeCall struct {
 	Instance     *compute.Instance
 	InstValue    compute.Instance
 	Firewall     *compute.Firewall
+	EgressFw     *computealpha.Firewall
 	InstanceId   string
 	AttachedDisk *compute.AttachedDisk
 	DeviceName   string
 	ComputeDisk  *compute.Disk
+	SizeGb       int64
+	Snapshot     *compute.Snapshot
 	Metadata     *compute.Metadata
 }
 
@@ -176,12 +180,15 @@ type fakeConn struct {
 	Instance      *compute.Instance
 	Instances     []*compute.Instance
 	Firewalls     []*compute.Firewall
+	EgressFws     []*computealpha.Firewall
 	Zones         []*compute.Zone
 	Err           error
 	FailOnCall    int
 	Disks         []*compute.Disk
 	Disk          *compute.Disk
 	AttachedDisks []*compute.AttachedDisk
+	Snapshots     []*compute.Snapshot
+	Snapshot      *compute.Snapshot
 	Networks      []*compute.Network
 	Subnetworks   []*compute.Subnetwork
 }
@@ -326,6 +333,52 @@ func (rc *fakeConn) RemoveFirewall(projectID, name string) error {
 	return err
 }
 
+func (rc *fakeConn) GetEgressFirewalls(projectID, name string) ([]*computealpha.Firewall, error) {
+	call := fakeCall{
+		FuncName:  "GetEgressFirewalls",
+		ProjectID: projectID,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.EgressFws, err
+}
+
+func (rc *fakeConn) AddEgressFirewall(projectID string, firewall *computealpha.Firewall) error {
+	call := fakeCall{
+		FuncName:  "AddEgressFirewall",
+		ProjectID: projectID,
+		EgressFw:  firewall,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) UpdateEgressFirewall(projectID, name string, firewall *computealpha.Firewall) error {
+	call := fakeCall{
+		FuncName:  "UpdateEgressFirewall",
+		ProjectID: projectID,
+		Name:      name,
+		EgressFw:  firewall,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) ListAvailabilityZones(projectID, region string) ([]*compute.Zone, error) {
 	call := fakeCall{
 		FuncName:  "ListAvailabilityZones",
@@ -404,6 +457,23 @@ func (rc *fakeConn) GetDisk(project, zone, id string) (*compute.Disk, error) {
 	return rc.Disk, err
 }
 
+func (rc *fakeConn) ResizeDisk(project, zone, id string, sizeGb int64) error {
+	call := fakeCall{
+		FuncName:  "ResizeDisk",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        id,
+		SizeGb:    sizeGb,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) AttachDisk(project, zone, instanceId string, attachedDisk *compute.AttachedDisk) error {
 	call := fakeCall{
 		FuncName:     "AttachDisk",
@@ -454,6 +524,67 @@ func (rc *fakeConn) InstanceDisks(project, zone, instanceId string) ([]*compute.
 	return rc.AttachedDisks, err
 }
 
+func (rc *fakeConn) CreateSnapshot(project, zone, disk string, spec *compute.Snapshot) error {
+	call := fakeCall{
+		FuncName:  "CreateSnapshot",
+		ProjectID: project,
+		ZoneName:  zone,
+		ID:        disk,
+		Snapshot:  spec,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
+func (rc *fakeConn) ListSnapshots(project string) ([]*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "ListSnapshots",
+		ProjectID: project,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshots, err
+}
+
+func (rc *fakeConn) GetSnapshot(project, name string) (*compute.Snapshot, error) {
+	call := fakeCall{
+		FuncName:  "GetSnapshot",
+		ProjectID: project,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return rc.Snapshot, err
+}
+
+func (rc *fakeConn) RemoveSnapshot(project, name string) error {
+	call := fakeCall{
+		FuncName:  "RemoveSnapshot",
+		ProjectID: project,
+		Name:      name,
+	}
+	rc.Calls = append(rc.Calls, call)
+
+	err := rc.Err
+	if len(rc.Calls) != rc.FailOnCall+1 {
+		err = nil
+	}
+	return err
+}
+
 func (rc *fakeConn) ListMachineTypes(projectID, zone string) (*compute.MachineTypeList, error) {
 	call := fakeCall{
 		FuncName:  "ListMachineTypes",
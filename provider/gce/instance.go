@@ -18,6 +18,7 @@ type environInstance struct {
 }
 
 var _ instance.Instance = (*environInstance)(nil)
+var _ instance.EgressFirewaller = (*environInstance)(nil)
 
 func newInstance(base *google.Instance, env *environ) *environInstance {
 	return &environInstance{
@@ -101,3 +102,38 @@ func (inst *environInstance) IngressRules(machineID string) ([]network.IngressRu
 	ports, err := inst.env.gce.IngressRules(name)
 	return ports, errors.Trace(err)
 }
+
+// OpenEgressPorts allows outgoing traffic on the given ports from the
+// instance, which should have been started with the given machine id.
+func (inst *environInstance) OpenEgressPorts(machineID string, rules []network.EgressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.OpenEgressPorts(name, rules...)
+	return errors.Trace(err)
+}
+
+// CloseEgressPorts denies outgoing traffic on the given ports from
+// the instance, which should have been started with the given
+// machine id.
+func (inst *environInstance) CloseEgressPorts(machineID string, rules []network.EgressRule) error {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = inst.env.gce.CloseEgressPorts(name, rules...)
+	return errors.Trace(err)
+}
+
+// EgressRules returns the set of egress rules applicable to the
+// instance, which should have been started with the given machine id.
+// The rules are returned as sorted by SortEgressRules.
+func (inst *environInstance) EgressRules(machineID string) ([]network.EgressRule, error) {
+	name, err := inst.env.namespace.Hostname(machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	rules, err := inst.env.gce.EgressRules(name)
+	return rules, errors.Trace(err)
+}
@@ -8,6 +8,7 @@ import (
 	gc "gopkg.in/check.v1"
 
 	"github.com/juju/juju/instance"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/provider/gce"
 	"github.com/juju/juju/provider/gce/google"
 )
@@ -58,6 +59,39 @@ func (s *instanceSuite) TestOpenPortsAPI(c *gc.C) {
 	c.Check(s.FakeConn.Calls[0].Rules, jc.DeepEquals, s.Rules)
 }
 
+func (s *instanceSuite) TestOpenEgressPortsAPI(c *gc.C) {
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	err := s.Instance.OpenEgressPorts("42", rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "OpenEgressPorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, rules)
+}
+
+func (s *instanceSuite) TestCloseEgressPortsAPI(c *gc.C) {
+	rules := []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	err := s.Instance.CloseEgressPorts("42", rules)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "CloseEgressPorts")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+	c.Check(s.FakeConn.Calls[0].EgressRules, jc.DeepEquals, rules)
+}
+
+func (s *instanceSuite) TestEgressRulesAPI(c *gc.C) {
+	s.FakeConn.Egress = []network.EgressRule{network.MustNewEgressRule("tcp", 443, 443)}
+	rules, err := s.Instance.EgressRules("42")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(rules, jc.DeepEquals, s.FakeConn.Egress)
+
+	c.Check(s.FakeConn.Calls, gc.HasLen, 1)
+	c.Check(s.FakeConn.Calls[0].FuncName, gc.Equals, "EgressRules")
+	c.Check(s.FakeConn.Calls[0].FirewallName, gc.Equals, s.InstName)
+}
+
 func (s *instanceSuite) TestClosePortsAPI(c *gc.C) {
 	err := s.Instance.ClosePorts("42", s.Rules)
 	c.Assert(err, jc.ErrorIsNil)
@@ -472,11 +472,13 @@ type fakeConnCall struct {
 	InstanceSpec google.InstanceSpec
 	FirewallName string
 	Rules        []network.IngressRule
+	EgressRules  []network.EgressRule
 	Region       string
 	Disks        []google.DiskSpec
 	VolumeName   string
 	InstanceId   string
 	Mode         string
+	Size         uint64
 	Key          string
 	Value        string
 }
@@ -487,12 +489,15 @@ type fakeConn struct {
 	Inst      *google.Instance
 	Insts     []google.Instance
 	Rules     []network.IngressRule
+	Egress    []network.EgressRule
 	Zones     []google.AvailabilityZone
 	Subnets   []*compute.Subnetwork
 	Networks_ []*compute.Network
 
 	GoogleDisks   []*google.Disk
 	GoogleDisk    *google.Disk
+	Snapshots_    []*google.Snapshot
+	Snapshot      *google.Snapshot
 	AttachedDisk  *google.AttachedDisk
 	AttachedDisks []*google.AttachedDisk
 
@@ -586,6 +591,49 @@ func (fc *fakeConn) ClosePorts(fwname string, rules ...network.IngressRule) erro
 	return fc.err()
 }
 
+func (fc *fakeConn) EgressRules(target string) ([]network.EgressRule, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "EgressRules",
+		FirewallName: target,
+	})
+	return fc.Egress, fc.err()
+}
+
+func (fc *fakeConn) OpenEgressPorts(target string, rules ...network.EgressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "OpenEgressPorts",
+		FirewallName: target,
+		EgressRules:  rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) CloseEgressPorts(target string, rules ...network.EgressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "CloseEgressPorts",
+		FirewallName: target,
+		EgressRules:  rules,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) DenyEgress(target string, allowed ...network.EgressRule) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "DenyEgress",
+		FirewallName: target,
+		EgressRules:  allowed,
+	})
+	return fc.err()
+}
+
+func (fc *fakeConn) RemoveEgressFirewalls(target string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:     "RemoveEgressFirewalls",
+		FirewallName: target,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) AvailabilityZones(region string) ([]google.AvailabilityZone, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "AvailabilityZones",
@@ -635,6 +683,16 @@ func (fc *fakeConn) RemoveDisk(zone, id string) error {
 	return fc.err()
 }
 
+func (fc *fakeConn) ResizeDisk(zone, id string, sizeGB uint64) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "ResizeDisk",
+		ZoneName: zone,
+		ID:       id,
+		Size:     sizeGB,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) Disk(zone, id string) (*google.Disk, error) {
 	fc.Calls = append(fc.Calls, fakeConnCall{
 		FuncName: "Disk",
@@ -674,6 +732,32 @@ func (fc *fakeConn) InstanceDisks(zone, instanceId string) ([]*google.AttachedDi
 	return fc.AttachedDisks, fc.err()
 }
 
+func (fc *fakeConn) CreateSnapshot(zone, disk, name, description string) (*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName:   "CreateSnapshot",
+		ZoneName:   zone,
+		VolumeName: disk,
+		ID:         name,
+		Value:      description,
+	})
+	return fc.Snapshot, fc.err()
+}
+
+func (fc *fakeConn) Snapshots() ([]*google.Snapshot, error) {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "Snapshots",
+	})
+	return fc.Snapshots_, fc.err()
+}
+
+func (fc *fakeConn) RemoveSnapshot(name string) error {
+	fc.Calls = append(fc.Calls, fakeConnCall{
+		FuncName: "RemoveSnapshot",
+		ID:       name,
+	})
+	return fc.err()
+}
+
 func (fc *fakeConn) WasCalled(funcName string) (bool, []fakeConnCall) {
 	var calls []fakeConnCall
 	called := false
@@ -98,6 +98,9 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cores=2",
 		"cpu-power=250",
 		"virt-type=kvm",
+		"zones=az1",
+		"root-disk-source=volume",
+		"allocate-public-ip=false",
 	}, " "))
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
@@ -108,6 +111,9 @@ func (s *environPolSuite) TestConstraintsValidatorUnsupported(c *gc.C) {
 		"cores",
 		"cpu-power",
 		"virt-type",
+		"zones",
+		"root-disk-source",
+		"allocate-public-ip",
 	}
 	c.Check(unsupported, jc.SameContents, expected)
 }
@@ -611,8 +611,14 @@ func (env *maasEnviron) PrecheckInstance(args environs.PrecheckInstanceParams) e
 	if args.Placement == "" {
 		return nil
 	}
-	_, err := env.parsePlacement(args.Placement)
-	return err
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return err
+	}
+	if placement.zoneName != "" {
+		return common.CheckZoneConstraint(placement.zoneName, args.Constraints)
+	}
+	return nil
 }
 
 const (
@@ -891,15 +897,23 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 		}
 		switch {
 		case placement.zoneName != "":
+			if err := common.CheckZoneConstraint(placement.zoneName, args.Constraints); err != nil {
+				return nil, errors.Trace(err)
+			}
 			availabilityZones = append(availabilityZones, placement.zoneName)
 		default:
 			nodeName = placement.nodeName
+			if args.Constraints.HasZones() {
+				// The named node must be in one of the zones
+				// allowed by the constraint.
+				availabilityZones = append(availabilityZones, *args.Constraints.Zones...)
+			}
 		}
 	}
 
 	// If no placement is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones, restricted by any zones constraint, for optimal
+	// spread across the instance distribution group.
 	if args.Placement == "" {
 		var group []instance.Id
 		var err error
@@ -922,6 +936,10 @@ func (environ *maasEnviron) StartInstance(args environs.StartInstanceParams) (
 				availabilityZones = append(availabilityZones, z.ZoneName)
 			}
 		}
+		availabilityZones, err = common.FilterZonesByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 	if len(availabilityZones) == 0 {
 		availabilityZones = []string{""}
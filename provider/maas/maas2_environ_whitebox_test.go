@@ -362,6 +362,47 @@ func (suite *maas2EnvironSuite) TestStartInstanceParams(c *gc.C) {
 	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("Bruce Sterling"))
 }
 
+func (suite *maas2EnvironSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	var env *maasEnviron
+	suite.injectController(&fakeController{
+		allocateMachineArgsCheck: func(args gomaasapi.AllocateMachineArgs) {
+			c.Assert(args, gc.DeepEquals, gomaasapi.AllocateMachineArgs{
+				AgentName: env.Config().UUID(),
+				Zone:      "bar",
+			})
+		},
+		allocateMachine: newFakeMachine("Bruce Sterling", arch.HostArch(), ""),
+		allocateMachineMatches: gomaasapi.ConstraintMatches{
+			Storage: map[string][]gomaasapi.BlockDevice{},
+		},
+		zones: []gomaasapi.Zone{&fakeZone{name: "foo"}, &fakeZone{name: "bar"}},
+	})
+	suite.setupFakeTools(c)
+	env = suite.makeEnviron(c, nil)
+	params := environs.StartInstanceParams{
+		ControllerUUID: suite.controllerUUID,
+		Constraints:    constraints.MustParse("zones=bar"),
+	}
+	result, err := jujutesting.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(result.Instance.Id(), gc.Equals, instance.Id("Bruce Sterling"))
+}
+
+func (suite *maas2EnvironSuite) TestStartInstancePlacementZonesConstraint(c *gc.C) {
+	suite.injectController(&fakeController{
+		zones: []gomaasapi.Zone{&fakeZone{name: "foo"}},
+	})
+	suite.setupFakeTools(c)
+	env := suite.makeEnviron(c, nil)
+	params := environs.StartInstanceParams{
+		ControllerUUID: suite.controllerUUID,
+		Placement:      "zone=foo",
+		Constraints:    constraints.MustParse("zones=bar"),
+	}
+	_, err := jujutesting.StartInstanceWithParams(env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zone "foo" not allowed by zones constraint "bar"`)
+}
+
 func (suite *maas2EnvironSuite) TestAcquireNodePassedAgentName(c *gc.C) {
 	var env *maasEnviron
 	suite.injectController(&fakeController{
@@ -2321,10 +2362,10 @@ func (suite *maas2EnvironSuite) TestConstraintsValidator(c *gc.C) {
 	env := suite.makeEnviron(c, controller)
 	validator, err := env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
-	cons := constraints.MustParse("arch=amd64 cpu-power=10 instance-type=foo virt-type=kvm")
+	cons := constraints.MustParse("arch=amd64 cpu-power=10 instance-type=foo virt-type=kvm root-disk-source=volume allocate-public-ip=false")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "virt-type", "root-disk-source", "allocate-public-ip"})
 }
 
 func (suite *maas2EnvironSuite) TestConstraintsValidatorVocab(c *gc.C) {
@@ -167,10 +167,10 @@ func (s *environSuite) TestConstraintsValidator(c *gc.C) {
 
 	validator, err := s.env.ConstraintsValidator()
 	c.Assert(err, jc.ErrorIsNil)
-	cons := constraints.MustParse("arch=amd64 instance-type=foo tags=bar cpu-power=10 cores=2 mem=1G virt-type=kvm")
+	cons := constraints.MustParse("arch=amd64 instance-type=foo tags=bar cpu-power=10 cores=2 mem=1G virt-type=kvm zones=az1 root-disk-source=volume allocate-public-ip=false")
 	unsupported, err := validator.Validate(cons)
 	c.Assert(err, jc.ErrorIsNil)
-	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "tags", "virt-type"})
+	c.Assert(unsupported, jc.SameContents, []string{"cpu-power", "instance-type", "tags", "virt-type", "zones", "root-disk-source", "allocate-public-ip"})
 }
 
 func (s *environSuite) TestConstraintsValidatorInsideController(c *gc.C) {
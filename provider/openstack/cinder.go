@@ -4,16 +4,24 @@
 package openstack
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"math"
+	"net/http"
 	"net/url"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/utils"
 	"gopkg.in/goose.v2/cinder"
+	gooseerrors "gopkg.in/goose.v2/errors"
 	"gopkg.in/goose.v2/identity"
 	"gopkg.in/goose.v2/nova"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/environs/tags"
 	"github.com/juju/juju/instance"
@@ -30,6 +38,12 @@ const (
 	volumeStatusDeleting  = "deleting"
 	volumeStatusError     = "error"
 	volumeStatusInUse     = "in-use"
+
+	volumeStatusErrorExtending = "error_extending"
+
+	// cinderTimeLayout is the layout of the timestamps
+	// that Cinder reports for snapshots.
+	cinderTimeLayout = "2006-01-02T15:04:05.999999"
 )
 
 // StorageProviderTypes implements storage.ProviderRegistry.
@@ -84,6 +98,7 @@ var newOpenstackStorage = func(env *Environ) (OpenstackStorage, error) {
 	return &openstackStorageAdapter{
 		cinderClient{cinder.Basic(env.volumeURL, client.TenantId(), client.Token)},
 		novaClient{env.novaUnlocked},
+		cinderActions{env.volumeURL, client.Token},
 	}, nil
 }
 
@@ -159,6 +174,8 @@ type cinderVolumeSource struct {
 }
 
 var _ storage.VolumeSource = (*cinderVolumeSource)(nil)
+var _ storage.VolumeSnapshotter = (*cinderVolumeSource)(nil)
+var _ storage.VolumeResizer = (*cinderVolumeSource)(nil)
 
 // CreateVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
@@ -280,6 +297,54 @@ func (s *cinderVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.Desc
 	return results, nil
 }
 
+// ResizeVolumes implements storage.VolumeResizer.
+func (s *cinderVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.VolumeId)
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) resizeVolume(arg storage.VolumeResizeParams) (uint64, error) {
+	cinderVolume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return 0, errors.Annotate(err, "getting volume")
+	}
+	// Cinder refuses to shrink volumes, so there is nothing to do
+	// if the volume is already large enough.
+	if size := uint64(cinderVolume.Size * 1024); size >= arg.Size {
+		return size, nil
+	}
+	// Without microversion 3.42, Cinder only extends volumes
+	// that are not attached to a server.
+	if cinderVolume.Status != volumeStatusAvailable {
+		return 0, errors.Errorf("volume %s is %s, not %s", arg.VolumeId, cinderVolume.Status, volumeStatusAvailable)
+	}
+	newSize := int(math.Ceil(float64(arg.Size) / 1024))
+	if err := s.storageAdapter.ExtendVolume(arg.VolumeId, newSize); err != nil {
+		return 0, errors.Trace(err)
+	}
+	cinderVolume, err = waitVolume(s.storageAdapter, arg.VolumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusAvailable:
+			return v.Size >= newSize, nil
+		case volumeStatusErrorExtending:
+			return false, errors.New("volume is in error_extending state")
+		}
+		return false, nil
+	})
+	if err != nil {
+		return 0, errors.Errorf("waiting for volume to be extended: %s", err)
+	}
+	return uint64(cinderVolume.Size * 1024), nil
+}
+
 // DestroyVolumes implements storage.VolumeSource.
 func (s *cinderVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
 	return destroyVolumes(s.storageAdapter, volumeIds), nil
@@ -456,6 +521,149 @@ func detachVolumes(storageAdapter OpenstackStorage, args []storage.VolumeAttachm
 	return results, nil
 }
 
+// CreateVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) CreateVolumeSnapshots(args []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	results := make([]storage.CreateVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		cinderSnapshot, err := s.storageAdapter.CreateSnapshot(cinder.CreateSnapshotSnapshotParams{
+			Name:     s.snapshotName(arg.Volume),
+			VolumeId: arg.VolumeId,
+			// Snapshot volumes even if they are attached; it is
+			// up to the user to quiesce the filesystem first.
+			Force: true,
+		})
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "snapshotting volume %s", arg.VolumeId)
+			continue
+		}
+		snapshot := cinderToJujuVolumeSnapshot(cinderSnapshot)
+		results[i].Snapshot = &snapshot
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots implements storage.VolumeSnapshotter.
+//
+// Snapshots are identified by the name given to them when they were
+// created, which is derived from the volume tag, so that they are
+// still found once the volume has been replaced by restoring one of
+// them.
+func (s *cinderVolumeSource) ListVolumeSnapshots(volumes []names.VolumeTag) ([]storage.ListVolumeSnapshotsResult, error) {
+	cinderSnapshots, err := s.storageAdapter.GetSnapshotsDetail()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	byName := make(map[string][]storage.VolumeSnapshot)
+	for i, cinderSnapshot := range cinderSnapshots {
+		byName[cinderSnapshot.Name] = append(
+			byName[cinderSnapshot.Name],
+			cinderToJujuVolumeSnapshot(&cinderSnapshots[i]),
+		)
+	}
+	results := make([]storage.ListVolumeSnapshotsResult, len(volumes))
+	for i, volume := range volumes {
+		results[i].Snapshots = byName[s.snapshotName(volume)]
+	}
+	return results, nil
+}
+
+// snapshotName returns the name given to snapshots of the volume
+// with the given tag.
+func (s *cinderVolumeSource) snapshotName(volume names.VolumeTag) string {
+	return resourceName(s.namespace, s.envName, volume.String())
+}
+
+// DeleteVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	results := make([]error, len(snapshotIds))
+	for i, snapshotId := range snapshotIds {
+		if err := s.storageAdapter.DeleteSnapshot(snapshotId); err != nil {
+			if gooseerrors.IsNotFound(err) {
+				continue
+			}
+			results[i] = errors.Annotatef(err, "deleting snapshot %s", snapshotId)
+		}
+	}
+	return results, nil
+}
+
+// RestoreVolumeSnapshots implements storage.VolumeSnapshotter.
+func (s *cinderVolumeSource) RestoreVolumeSnapshots(args []storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error) {
+	results := make([]storage.RestoreVolumeSnapshotsResult, len(args))
+	for i, arg := range args {
+		info, err := s.restoreVolumeSnapshot(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(
+				err, "restoring volume %s from snapshot %s",
+				arg.VolumeId, arg.SnapshotId,
+			)
+			continue
+		}
+		results[i].VolumeInfo = info
+	}
+	return results, nil
+}
+
+func (s *cinderVolumeSource) restoreVolumeSnapshot(arg storage.RestoreVolumeSnapshotParams) (*storage.VolumeInfo, error) {
+	oldVolume, err := s.storageAdapter.GetVolume(arg.VolumeId)
+	if err != nil {
+		return nil, errors.Annotate(err, "getting volume")
+	}
+	if oldVolume.Status == volumeStatusInUse {
+		return nil, errors.Errorf("volume %s is in use", arg.VolumeId)
+	}
+
+	var metadata interface{}
+	if len(arg.ResourceTags) > 0 {
+		metadata = arg.ResourceTags
+	}
+	cinderVolume, err := s.storageAdapter.CreateVolume(cinder.CreateVolumeVolumeParams{
+		Size:             int(math.Ceil(float64(arg.Size / 1024))),
+		Name:             resourceName(s.namespace, s.envName, arg.Volume.String()),
+		AvailabilityZone: oldVolume.AvailabilityZone,
+		SnapshotId:       arg.SnapshotId,
+		Metadata:         metadata,
+	})
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeId := cinderVolume.ID
+	cinderVolume, err = waitVolume(s.storageAdapter, volumeId, func(v *cinder.Volume) (bool, error) {
+		switch v.Status {
+		case volumeStatusAvailable:
+			return true, nil
+		case volumeStatusError:
+			return false, errors.New("volume is in error state")
+		}
+		return false, nil
+	})
+	if err != nil {
+		if err := s.storageAdapter.DeleteVolume(volumeId); err != nil {
+			logger.Warningf("destroying volume %s: %s", volumeId, err)
+		}
+		return nil, errors.Errorf("waiting for volume to be provisioned: %s", err)
+	}
+	info := cinderToJujuVolumeInfo(cinderVolume)
+	return &info, nil
+}
+
+func cinderToJujuVolumeSnapshot(snapshot *cinder.Snapshot) storage.VolumeSnapshot {
+	result := storage.VolumeSnapshot{
+		SnapshotId: snapshot.ID,
+		VolumeId:   snapshot.VolumeId,
+		Size:       uint64(snapshot.Size * 1024),
+		Status:     snapshot.Status,
+	}
+	// Cinder reports creation times without a time zone; they are UTC.
+	for _, layout := range []string{time.RFC3339Nano, cinderTimeLayout} {
+		if created, err := time.Parse(layout, snapshot.CreatedAt); err == nil {
+			result.Created = created.UTC()
+			break
+		}
+	}
+	return result
+}
+
 func cinderToJujuVolumeInfos(volumes []cinder.Volume) []storage.VolumeInfo {
 	out := make([]storage.VolumeInfo, len(volumes))
 	for i, v := range volumes {
@@ -501,6 +709,10 @@ type OpenstackStorage interface {
 	DetachVolume(serverId, attachmentId string) error
 	ListVolumeAttachments(serverId string) ([]nova.VolumeAttachment, error)
 	SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error)
+	CreateSnapshot(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	GetSnapshotsDetail() ([]cinder.Snapshot, error)
+	DeleteSnapshot(snapshotId string) error
+	ExtendVolume(volumeId string, newSize int) error
 }
 
 type endpointResolver interface {
@@ -531,6 +743,7 @@ func getVolumeEndpointURL(client endpointResolver, region string) (*url.URL, err
 type openstackStorageAdapter struct {
 	cinderClient
 	novaClient
+	cinderActions
 }
 
 type cinderClient struct {
@@ -541,6 +754,54 @@ type novaClient struct {
 	*nova.Client
 }
 
+// cinderActions makes Cinder volume action requests that the
+// goose cinder client does not support.
+type cinderActions struct {
+	endpoint *url.URL
+	token    cinder.TokenFn
+}
+
+// ExtendVolume is part of the OpenstackStorage interface.
+func (ca cinderActions) ExtendVolume(volumeId string, newSize int) error {
+	var action struct {
+		Extend struct {
+			NewSize int `json:"new_size"`
+		} `json:"os-extend"`
+	}
+	action.Extend.NewSize = newSize
+	return ca.volumeAction(volumeId, action)
+}
+
+func (ca cinderActions) volumeAction(volumeId string, action interface{}) error {
+	body, err := json.Marshal(action)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// ResolveReference replaces the last element of the endpoint
+	// path unless it has a trailing slash.
+	endpoint := *ca.endpoint
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + "/"
+	actionURL := endpoint.ResolveReference(&url.URL{
+		Path: fmt.Sprintf("volumes/%s/action", volumeId),
+	})
+	req, err := http.NewRequest("POST", actionURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Auth-Token", ca.token())
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted {
+		respBody, _ := ioutil.ReadAll(resp.Body)
+		return errors.Errorf("invalid status (%d): %s", resp.StatusCode, respBody)
+	}
+	return nil
+}
+
 // CreateVolume is part of the OpenstackStorage interface.
 func (ga *openstackStorageAdapter) CreateVolume(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
 	resp, err := ga.cinderClient.CreateVolume(args)
@@ -572,3 +833,21 @@ func (ga *openstackStorageAdapter) GetVolume(volumeId string) (*cinder.Volume, e
 func (ga *openstackStorageAdapter) SetVolumeMetadata(volumeId string, metadata map[string]string) (map[string]string, error) {
 	return ga.cinderClient.SetVolumeMetadata(volumeId, metadata)
 }
+
+// CreateSnapshot is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.CreateSnapshot(args)
+	if err != nil {
+		return nil, err
+	}
+	return &resp.Snapshot, nil
+}
+
+// GetSnapshotsDetail is part of the OpenstackStorage interface.
+func (ga *openstackStorageAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	resp, err := ga.cinderClient.GetSnapshotsDetail()
+	if err != nil {
+		return nil, err
+	}
+	return resp.Snapshots, nil
+}
@@ -463,6 +463,188 @@ func (s *cinderVolumeSourceSuite) TestCreateVolumeCleanupDestroys(c *gc.C) {
 	c.Assert(numDestroyCalls, gc.Equals, 1)
 }
 
+func (s *cinderVolumeSourceSuite) TestCreateVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		createSnapshot: func(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+			c.Check(args, jc.DeepEquals, cinder.CreateSnapshotSnapshotParams{
+				Name:     "juju-testenv-volume-123",
+				VolumeId: mockVolId,
+				Force:    true,
+			})
+			return &cinder.Snapshot{
+				ID:        "snap-0",
+				VolumeId:  args.VolumeId,
+				Size:      2,
+				Status:    "creating",
+				CreatedAt: "2017-11-01T04:13:17.000000",
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	snapshotter, ok := volSource.(storage.VolumeSnapshotter)
+	c.Assert(ok, jc.IsTrue)
+	results, err := snapshotter.CreateVolumeSnapshots([]storage.VolumeSnapshotParams{{
+		Volume:   mockVolumeTag,
+		VolumeId: mockVolId,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateVolumeSnapshotsResult{{
+		Snapshot: &storage.VolumeSnapshot{
+			SnapshotId: "snap-0",
+			VolumeId:   mockVolId,
+			Size:       2 * 1024,
+			Status:     "creating",
+			Created:    time.Date(2017, 11, 1, 4, 13, 17, 0, time.UTC),
+		},
+	}})
+}
+
+func (s *cinderVolumeSourceSuite) TestListVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getSnapshotsDetail: func() ([]cinder.Snapshot, error) {
+			return []cinder.Snapshot{
+				{ID: "snap-0", Name: "juju-testenv-volume-123", VolumeId: "vol-0", Size: 1},
+				{ID: "snap-1", Name: "juju-testenv-volume-456", VolumeId: "vol-1", Size: 2},
+				// Taken before volume 123 was restored from a snapshot.
+				{ID: "snap-2", Name: "juju-testenv-volume-123", VolumeId: "vol-old", Size: 1},
+				{ID: "snap-3", Name: "not-ours", VolumeId: "vol-0", Size: 1},
+			}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).ListVolumeSnapshots([]names.VolumeTag{
+		mockVolumeTag, names.NewVolumeTag("789"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ListVolumeSnapshotsResult{{
+		Snapshots: []storage.VolumeSnapshot{
+			{SnapshotId: "snap-0", VolumeId: "vol-0", Size: 1024},
+			{SnapshotId: "snap-2", VolumeId: "vol-old", Size: 1024},
+		},
+	}, {}})
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolumeSnapshots(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			if volumeId == "vol-new" {
+				return &cinder.Volume{ID: volumeId, Size: 2, Status: "available"}, nil
+			}
+			return &cinder.Volume{
+				ID:               volumeId,
+				Size:             2,
+				Status:           "available",
+				AvailabilityZone: "zone-1",
+			}, nil
+		},
+		createVolume: func(args cinder.CreateVolumeVolumeParams) (*cinder.Volume, error) {
+			c.Check(args, jc.DeepEquals, cinder.CreateVolumeVolumeParams{
+				Size:             2,
+				Name:             "juju-testenv-volume-123",
+				AvailabilityZone: "zone-1",
+				SnapshotId:       "snap-0",
+			})
+			return &cinder.Volume{ID: "vol-new"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Volume:     mockVolumeTag,
+		VolumeId:   mockVolId,
+		SnapshotId: "snap-0",
+		Size:       mockVolSize,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.RestoreVolumeSnapshotsResult{{
+		VolumeInfo: &storage.VolumeInfo{
+			VolumeId:   "vol-new",
+			Size:       2 * 1024,
+			Persistent: true,
+		},
+	}})
+	// The original volume is left for the caller to destroy.
+	mockAdapter.CheckCallNames(c, "GetVolume", "CreateVolume", "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestRestoreVolumeSnapshotsInUse(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Status: "in-use"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeSnapshotter).RestoreVolumeSnapshots([]storage.RestoreVolumeSnapshotParams{{
+		Volume:     mockVolumeTag,
+		VolumeId:   mockVolId,
+		SnapshotId: "snap-0",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "restoring volume 0 from snapshot snap-0: volume 0 is in use")
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumes(c *gc.C) {
+	size := 1
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: size, Status: "available"}, nil
+		},
+		extendVolume: func(volumeId string, newSize int) error {
+			size = newSize
+			return nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2*1024 + 1,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 3 * 1024}})
+	mockAdapter.CheckCalls(c, []gitjujutesting.StubCall{
+		{"GetVolume", []interface{}{mockVolId}},
+		{"ExtendVolume", []interface{}{mockVolId, 3}},
+		{"GetVolume", []interface{}{mockVolId}},
+	})
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 2, Status: "in-use"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2 * 1024}})
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
+func (s *cinderVolumeSourceSuite) TestResizeVolumesInUse(c *gc.C) {
+	mockAdapter := &mockAdapter{
+		getVolume: func(volumeId string) (*cinder.Volume, error) {
+			return &cinder.Volume{ID: volumeId, Size: 1, Status: "in-use"}, nil
+		},
+	}
+	volSource := openstack.NewCinderVolumeSource(mockAdapter)
+	results, err := volSource.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      mockVolumeTag,
+		VolumeId: mockVolId,
+		Size:     2 * 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "resizing volume 0: volume 0 is in-use, not available")
+	mockAdapter.CheckCallNames(c, "GetVolume")
+}
+
 type mockAdapter struct {
 	gitjujutesting.Stub
 	getVolume             func(string) (*cinder.Volume, error)
@@ -474,6 +656,10 @@ type mockAdapter struct {
 	detachVolume          func(string, string) error
 	listVolumeAttachments func(string) ([]nova.VolumeAttachment, error)
 	setVolumeMetadata     func(string, map[string]string) (map[string]string, error)
+	createSnapshot        func(cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error)
+	getSnapshotsDetail    func() ([]cinder.Snapshot, error)
+	deleteSnapshot        func(string) error
+	extendVolume          func(string, int) error
 }
 
 func (ma *mockAdapter) GetVolume(volumeId string) (*cinder.Volume, error) {
@@ -543,6 +729,38 @@ func (ma *mockAdapter) SetVolumeMetadata(volumeId string, metadata map[string]st
 	return nil, nil
 }
 
+func (ma *mockAdapter) CreateSnapshot(args cinder.CreateSnapshotSnapshotParams) (*cinder.Snapshot, error) {
+	ma.MethodCall(ma, "CreateSnapshot", args)
+	if ma.createSnapshot != nil {
+		return ma.createSnapshot(args)
+	}
+	return nil, errors.NotImplementedf("CreateSnapshot")
+}
+
+func (ma *mockAdapter) GetSnapshotsDetail() ([]cinder.Snapshot, error) {
+	ma.MethodCall(ma, "GetSnapshotsDetail")
+	if ma.getSnapshotsDetail != nil {
+		return ma.getSnapshotsDetail()
+	}
+	return nil, nil
+}
+
+func (ma *mockAdapter) DeleteSnapshot(snapshotId string) error {
+	ma.MethodCall(ma, "DeleteSnapshot", snapshotId)
+	if ma.deleteSnapshot != nil {
+		return ma.deleteSnapshot(snapshotId)
+	}
+	return nil
+}
+
+func (ma *mockAdapter) ExtendVolume(volumeId string, newSize int) error {
+	ma.MethodCall(ma, "ExtendVolume", volumeId, newSize)
+	if ma.extendVolume != nil {
+		return ma.extendVolume(volumeId, newSize)
+	}
+	return errors.NotImplementedf("ExtendVolume")
+}
+
 type testEndpointResolver struct {
 	authenticated   bool
 	regionEndpoints map[string]identity.ServiceURLs
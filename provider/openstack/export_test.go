@@ -462,7 +462,7 @@ func EnsureGroup(e environs.Environ, name string, rules []neutron.RuleInfoV2) (n
 	if err := switching.initFirewaller(); err != nil {
 		return neutron.SecurityGroupV2{}, err
 	}
-	return switching.fw.(*neutronFirewaller).ensureGroup(name, rules)
+	return switching.fw.(*neutronFirewaller).ensureGroup(name, rules, nil)
 }
 
 func MachineGroupRegexp(e environs.Environ, machineId string) string {
@@ -529,6 +529,8 @@ func ResolveNetwork(e environs.Environ, networkName string, external bool) (stri
 
 var PortsToRuleInfo = rulesToRuleInfo
 var SecGroupMatchesIngressRule = secGroupMatchesIngressRule
+var EgressRulesToRuleInfo = egressRulesToRuleInfo
+var SecGroupMatchesEgressRule = secGroupMatchesEgressRule
 
 var MakeServiceURL = &makeServiceURL
 
@@ -81,6 +81,43 @@ type Firewaller interface {
 
 	// InstanceIngressRules returns the ingress rules applied to the specified  instance.
 	InstanceIngressRules(inst instance.Instance, machineId string) ([]network.IngressRule, error)
+
+	// OpenEgressPorts allows outbound traffic matching the given egress
+	// rules for the whole environment.
+	OpenEgressPorts(rules []network.EgressRule) error
+
+	// CloseEgressPorts removes the given egress rules for the whole
+	// environment.
+	CloseEgressPorts(rules []network.EgressRule) error
+
+	// EgressRules returns the egress rules applied to the whole
+	// environment, excluding those created by default.
+	EgressRules() ([]network.EgressRule, error)
+
+	// OpenInstanceEgressPorts allows outbound traffic matching the given
+	// egress rules for the specified instance.
+	OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// CloseInstanceEgressPorts removes the given egress rules for the
+	// specified instance.
+	CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error
+
+	// InstanceEgressRules returns the egress rules applied to the
+	// specified instance, excluding those created by default.
+	InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error)
+
+	// OpenModelPorts opens the given port ranges in the security
+	// group shared by all of the model's machines.
+	OpenModelPorts(rules []network.IngressRule) error
+
+	// CloseModelPorts closes the given port ranges in the security
+	// group shared by all of the model's machines.
+	CloseModelPorts(rules []network.IngressRule) error
+
+	// ModelIngressRules returns the ingress rules applied to the
+	// security group shared by all of the model's machines, excluding
+	// those allowing traffic between the machines.
+	ModelIngressRules() ([]network.IngressRule, error)
 }
 
 type firewallerFactory struct {
@@ -205,6 +242,69 @@ func (f *switchingFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return f.fw.InstanceIngressRules(inst, machineId)
 }
 
+func (f *switchingFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenEgressPorts(rules)
+}
+
+func (f *switchingFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseEgressPorts(rules)
+}
+
+func (f *switchingFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.EgressRules()
+}
+
+func (f *switchingFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenInstanceEgressPorts(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseInstanceEgressPorts(inst, machineId, rules)
+}
+
+func (f *switchingFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.InstanceEgressRules(inst, machineId)
+}
+
+func (f *switchingFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.OpenModelPorts(rules)
+}
+
+func (f *switchingFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	if err := f.initFirewaller(); err != nil {
+		return errors.Trace(err)
+	}
+	return f.fw.CloseModelPorts(rules)
+}
+
+func (f *switchingFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	if err := f.initFirewaller(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return f.fw.ModelIngressRules()
+}
+
 type firewallerBase struct {
 	environ *Environ
 }
@@ -417,6 +517,12 @@ func (c *firewallerBase) jujuGroupRegexp() string {
 	return fmt.Sprintf("juju-.*-%v", cfg.UUID())
 }
 
+// modelGroupRegexp matches only the juju group, which is shared
+// by all of the model's machines.
+func (c *firewallerBase) modelGroupRegexp() string {
+	return fmt.Sprintf("^%s$", c.jujuGroupRegexp())
+}
+
 func (c *firewallerBase) globalGroupRegexp() string {
 	return fmt.Sprintf("%s-global", c.jujuGroupRegexp())
 }
@@ -449,9 +555,9 @@ func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPor
 	var machineGroup neutron.SecurityGroupV2
 	switch c.environ.Config().FirewallMode() {
 	case config.FwInstance:
-		machineGroup, err = c.ensureGroup(c.machineGroupName(controllerUUID, machineId), nil)
+		machineGroup, err = c.ensureGroup(c.machineGroupName(controllerUUID, machineId), nil, nil)
 	case config.FwGlobal:
-		machineGroup, err = c.ensureGroup(c.globalGroupName(controllerUUID), nil)
+		machineGroup, err = c.ensureGroup(c.globalGroupName(controllerUUID), nil, nil)
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -464,74 +570,142 @@ func (c *neutronFirewaller) SetUpGroups(controllerUUID, machineId string, apiPor
 }
 
 func (c *neutronFirewaller) setUpGlobalGroup(groupName string, apiPort int) (neutron.SecurityGroupV2, error) {
-	return c.ensureGroup(groupName,
-		[]neutron.RuleInfoV2{
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   22,
-				PortRangeMin:   22,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "::/0",
-				EthernetType:   "IPv6",
-			},
-			{
-				Direction:      "ingress",
-				IPProtocol:     "tcp",
-				PortRangeMax:   apiPort,
-				PortRangeMin:   apiPort,
-				RemoteIPPrefix: "0.0.0.0/0",
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "tcp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "tcp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "udp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "udp",
-				PortRangeMin: 1,
-				PortRangeMax: 65535,
-			},
-			{
-				Direction:    "ingress",
-				IPProtocol:   "icmp",
-				EthernetType: "IPv6",
-			},
-			{
-				Direction:  "ingress",
-				IPProtocol: "icmp",
-			},
-		})
+	// Access to SSH and the API port is allowed from anywhere
+	// unless it has been restricted by the model's firewall rules.
+	defaultRules := []neutron.RuleInfoV2{
+		{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   22,
+			PortRangeMin:   22,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+		},
+		{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   22,
+			PortRangeMin:   22,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   apiPort,
+			PortRangeMin:   apiPort,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+		},
+		{
+			Direction:      "ingress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   apiPort,
+			PortRangeMin:   apiPort,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+	}
+	rules := []neutron.RuleInfoV2{
+		{
+			Direction:    "ingress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "ingress",
+			IPProtocol:   "icmp",
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:  "ingress",
+			IPProtocol: "icmp",
+		},
+	}
+	if c.environ.Config().EgressDefaultDeny() {
+		// Outbound traffic is otherwise only allowed by the
+		// application egress rules in the machine groups, so
+		// machines must be allowed to reach the controller and
+		// each other.
+		rules = append(rules, egressGroupRules(apiPort)...)
+	}
+	return c.ensureGroup(groupName, rules, defaultRules)
+}
+
+// egressGroupRules returns the egress rules that allow machines in a
+// group to reach the API port, and any port on the group's machines.
+func egressGroupRules(apiPort int) []neutron.RuleInfoV2 {
+	return []neutron.RuleInfoV2{
+		{
+			Direction:      "egress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   apiPort,
+			PortRangeMin:   apiPort,
+			RemoteIPPrefix: "::/0",
+			EthernetType:   "IPv6",
+		},
+		{
+			Direction:      "egress",
+			IPProtocol:     "tcp",
+			PortRangeMax:   apiPort,
+			PortRangeMin:   apiPort,
+			RemoteIPPrefix: "0.0.0.0/0",
+		},
+		{
+			Direction:    "egress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "egress",
+			IPProtocol:   "tcp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "egress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:    "egress",
+			IPProtocol:   "udp",
+			PortRangeMin: 1,
+			PortRangeMax: 65535,
+		},
+		{
+			Direction:    "egress",
+			IPProtocol:   "icmp",
+			EthernetType: "IPv6",
+		},
+		{
+			Direction:  "egress",
+			IPProtocol: "icmp",
+		},
+	}
 }
 
 // zeroGroup holds the zero security group.
@@ -540,7 +714,12 @@ var zeroGroup neutron.SecurityGroupV2
 // ensureGroup returns the security group with name and rules.
 // If a group with name does not exist, one will be created.
 // If it exists, its permissions are set to rules.
-func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
+//
+// The defaultRules are created only for port ranges which have no
+// rules with a remote IP prefix in the group, and such rules are
+// never deleted. This leaves the firewaller free to restrict them
+// according to the model's firewall rules.
+func (c *neutronFirewaller) ensureGroup(name string, rules, defaultRules []neutron.RuleInfoV2) (neutron.SecurityGroupV2, error) {
 	neutronClient := c.environ.neutron()
 	var group neutron.SecurityGroupV2
 
@@ -567,13 +746,39 @@ func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2)
 	have := newRuleInfoSetFromRules(group.Rules)
 	want := newRuleInfoSetFromRuleInfo(rules)
 
+	defaults := newRuleInfoSetFromRuleInfo(defaultRules)
+	defaultPortRanges := make(ruleInfoSet)
+	for k := range defaults {
+		defaultPortRanges[rulePortRange(k)] = ""
+	}
+	restricted := make(ruleInfoSet)
+	for k := range have {
+		if _, ok := defaultPortRanges[rulePortRange(k)]; ok && k.RemoteIPPrefix != "" {
+			restricted[rulePortRange(k)] = ""
+		}
+	}
+	for k := range defaults {
+		if _, ok := restricted[rulePortRange(k)]; !ok {
+			want[k] = ""
+		}
+	}
+
 	// Find rules we want to delete, that we have but don't want, and
 	// delete them.
 	remove := make(ruleInfoSet)
+	denyEgress := c.environ.Config().EgressDefaultDeny()
 	for k := range have {
-		// Neutron creates 2 egress rules with any new Security Group.
-		// Keep them.
-		if _, ok := want[k]; !ok && k.Direction != "egress" {
+		if _, ok := want[k]; ok {
+			continue
+		}
+		if _, ok := restricted[rulePortRange(k)]; ok && k.RemoteIPPrefix != "" {
+			continue
+		}
+		// Neutron creates 2 egress rules with any new Security Group,
+		// which allow all outbound traffic. Keep them unless outbound
+		// traffic is denied by default. Other egress rules are managed
+		// by the firewaller.
+		if k.Direction != "egress" || (denyEgress && isDefaultEgressRule(k)) {
 			remove[k] = have[k]
 		}
 	}
@@ -617,6 +822,27 @@ func (c *neutronFirewaller) ensureGroup(name string, rules []neutron.RuleInfoV2)
 	return groupsFound[0], nil
 }
 
+// rulePortRange returns the rule with only its direction, protocol
+// and port range set.
+func rulePortRange(rule neutron.RuleInfoV2) neutron.RuleInfoV2 {
+	return neutron.RuleInfoV2{
+		Direction:    rule.Direction,
+		IPProtocol:   rule.IPProtocol,
+		PortRangeMin: rule.PortRangeMin,
+		PortRangeMax: rule.PortRangeMax,
+	}
+}
+
+// isDefaultEgressRule reports whether the rule is one of the egress
+// rules created by Neutron with a new security group.
+func isDefaultEgressRule(rule neutron.RuleInfoV2) bool {
+	return rule.Direction == "egress" &&
+		rule.IPProtocol == "" &&
+		rule.PortRangeMin == 0 &&
+		rule.PortRangeMax == 0 &&
+		rule.RemoteIPPrefix == ""
+}
+
 // ruleInfoSet represents a Security Group Rule created for a Security Group.
 // The string will be the Security Group Rule Id, if the rule has previously been
 // created.
@@ -803,6 +1029,134 @@ func (c *neutronFirewaller) InstanceIngressRules(inst instance.Instance, machine
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for opening egress ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.openEgressPortsInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in global group: %v", rules)
+	return nil
+}
+
+// CloseEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return errors.Errorf("invalid firewall mode %q for closing egress ports on model",
+			c.environ.Config().FirewallMode())
+	}
+	if err := c.closeEgressPortsInGroup(c.globalGroupRegexp(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in global group: %v", rules)
+	return nil
+}
+
+// EgressRules implements Firewaller interface.
+func (c *neutronFirewaller) EgressRules() ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwGlobal {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from model",
+			c.environ.Config().FirewallMode())
+	}
+	return c.egressRulesInGroup(c.globalGroupRegexp())
+}
+
+// OpenInstanceEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for opening egress ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if err := c.openEgressPortsInGroup(c.machineGroupRegexp(machineId), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened egress ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// CloseInstanceEgressPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return errors.Errorf("invalid firewall mode %q for closing egress ports on instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return nil
+	}
+	if err := c.closeEgressPortsInGroup(c.machineGroupRegexp(machineId), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed egress ports in security group %s-%s: %v", c.environ.Config().UUID(), machineId, rules)
+	return nil
+}
+
+// InstanceEgressRules implements Firewaller interface.
+func (c *neutronFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	if c.environ.Config().FirewallMode() != config.FwInstance {
+		return nil, errors.Errorf("invalid firewall mode %q for retrieving egress rules from instance",
+			c.environ.Config().FirewallMode())
+	}
+	// See OpenInstancePorts.
+	if securityGroups := inst.(*openstackInstance).getServerDetail().Groups; securityGroups == nil {
+		return []network.EgressRule{}, nil
+	}
+	return c.egressRulesInGroup(c.machineGroupRegexp(machineId))
+}
+
+// OpenModelPorts implements Firewaller interface.
+func (c *neutronFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	if err := c.openPortsInGroup(c.modelGroupRegexp(), rules); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("opened ports in juju group: %v", rules)
+	return nil
+}
+
+// CloseModelPorts implements Firewaller interface.
+func (c *neutronFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	// closePortsInGroup deletes only the first matching security
+	// group rule, so close each source CIDR separately.
+	var single []network.IngressRule
+	for _, rule := range rules {
+		for _, cidr := range rule.SourceCIDRs {
+			single = append(single, network.IngressRule{
+				PortRange:   rule.PortRange,
+				SourceCIDRs: []string{cidr},
+			})
+		}
+	}
+	if err := c.closePortsInGroup(c.modelGroupRegexp(), single); err != nil {
+		return errors.Trace(err)
+	}
+	logger.Infof("closed ports in juju group: %v", rules)
+	return nil
+}
+
+// ModelIngressRules implements Firewaller interface.
+func (c *neutronFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	group, err := c.matchingGroup(c.modelGroupRegexp())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var rules []neutron.SecurityGroupRuleV2
+	for _, p := range group.Rules {
+		// Rules without a remote IP prefix allow
+		// traffic between the model's machines.
+		if p.RemoteIPPrefix != "" {
+			rules = append(rules, p)
+		}
+	}
+	return secGroupRulesToIngressRules(rules)
+}
+
 // Matching a security group by name only works if each name is unqiue.  Neutron
 // security groups are not required to have unique names.  Juju constructs unique
 // names, but there are frequently multiple matches to 'default'
@@ -850,6 +1204,9 @@ func (c *neutronFirewaller) openPortsInGroup(nameRegExp string, rules []network.
 
 // secGroupMatchesIngressRule checks if supplied nova security group rule matches the ingress rule
 func secGroupMatchesIngressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.IngressRule) bool {
+	if secGroupRule.Direction == "egress" {
+		return false
+	}
 	if secGroupRule.IPProtocol == nil || *secGroupRule.PortRangeMax == 0 || *secGroupRule.PortRangeMin == 0 {
 		return false
 	}
@@ -902,9 +1259,16 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	return secGroupRulesToIngressRules(group.Rules)
+}
+
+// secGroupRulesToIngressRules returns the ingress rules for the given
+// security group rules, combining the remote IP prefixes of the rules
+// for each port range.
+func secGroupRulesToIngressRules(secGroupRules []neutron.SecurityGroupRuleV2) (rules []network.IngressRule, err error) {
 	// Keep track of all the RemoteIPPrefixes for each port range.
 	portSourceCIDRs := make(map[network.PortRange]*[]string)
-	for _, p := range group.Rules {
+	for _, p := range secGroupRules {
 		// Skip the default Security Group Rules created by Neutron
 		if p.Direction == "egress" {
 			continue
@@ -946,6 +1310,113 @@ func (c *neutronFirewaller) ingressRulesInGroup(nameRegexp string) (rules []netw
 	return rules, nil
 }
 
+func (c *neutronFirewaller) openEgressPortsInGroup(nameRegExp string, rules []network.EgressRule) error {
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range egressRulesToRuleInfo(group.Id, rules) {
+		_, err := neutronClient.CreateSecurityGroupRuleV2(rule)
+		if err != nil {
+			logger.Debugf("error creating security group rule: %v", err.Error())
+		}
+	}
+	return nil
+}
+
+// secGroupMatchesEgressRule checks if supplied neutron security group
+// rule matches the egress rule.
+func secGroupMatchesEgressRule(secGroupRule neutron.SecurityGroupRuleV2, rule network.EgressRule) bool {
+	if secGroupRule.Direction != "egress" || secGroupRule.IPProtocol == nil ||
+		secGroupRule.PortRangeMin == nil || secGroupRule.PortRangeMax == nil {
+		return false
+	}
+	portsMatch := *secGroupRule.IPProtocol == rule.Protocol &&
+		*secGroupRule.PortRangeMin == rule.FromPort &&
+		*secGroupRule.PortRangeMax == rule.ToPort
+	if !portsMatch {
+		return false
+	}
+	destinationCIDRs := rule.DestinationCIDRs
+	if len(destinationCIDRs) == 0 {
+		destinationCIDRs = []string{"0.0.0.0/0"}
+	}
+	for _, cidr := range destinationCIDRs {
+		if cidr == secGroupRule.RemoteIPPrefix {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *neutronFirewaller) closeEgressPortsInGroup(nameRegExp string, rules []network.EgressRule) error {
+	if len(rules) == 0 {
+		return nil
+	}
+	group, err := c.matchingGroup(nameRegExp)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	neutronClient := c.environ.neutron()
+	for _, rule := range rules {
+		// Each destination of the rule has its own security
+		// group rule, so delete all that match.
+		for _, p := range group.Rules {
+			if !secGroupMatchesEgressRule(p, rule) {
+				continue
+			}
+			if err := neutronClient.DeleteSecurityGroupRuleV2(p.Id); err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+	return nil
+}
+
+func (c *neutronFirewaller) egressRulesInGroup(nameRegexp string) (rules []network.EgressRule, err error) {
+	group, err := c.matchingGroup(nameRegexp)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// Keep track of all the RemoteIPPrefixes for each port range.
+	portDestinationCIDRs := make(map[network.PortRange][]string)
+	for _, p := range group.Rules {
+		// Skip ingress rules, and the default Security Group Rules
+		// created by Neutron.
+		if p.Direction != "egress" || p.IPProtocol == nil {
+			continue
+		}
+		portRange := network.PortRange{
+			Protocol: *p.IPProtocol,
+		}
+		if p.PortRangeMin != nil {
+			portRange.FromPort = *p.PortRangeMin
+		}
+		if p.PortRangeMax != nil {
+			portRange.ToPort = *p.PortRangeMax
+		}
+		remotePrefix := p.RemoteIPPrefix
+		if remotePrefix == "" {
+			remotePrefix = "0.0.0.0/0"
+		}
+		portDestinationCIDRs[portRange] = append(portDestinationCIDRs[portRange], remotePrefix)
+	}
+	for portRange, destinationCIDRs := range portDestinationCIDRs {
+		rule, err := network.NewEgressRule(
+			portRange.Protocol,
+			portRange.FromPort,
+			portRange.ToPort,
+			destinationCIDRs...)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		rules = append(rules, rule)
+	}
+	network.SortEgressRules(rules)
+	return rules, nil
+}
+
 func replaceControllerUUID(oldName, controllerUUID string) (string, error) {
 	if !extractControllerRe.MatchString(oldName) {
 		return "", errors.Errorf("unexpected security group name format for %q", oldName)
@@ -30,6 +30,9 @@ type legacyNovaFirewaller struct {
 // In addition, a specific machine security group is created for each
 // machine, so that its firewall rules can be configured per machine.
 func (c *legacyNovaFirewaller) SetUpGroups(controllerUUID, machineId string, apiPort int) ([]string, error) {
+	if c.environ.Config().EgressDefaultDeny() {
+		return nil, errors.NotSupportedf("egress-default-deny without Neutron security groups")
+	}
 	jujuGroup, err := c.setUpGlobalGroup(c.jujuGroupName(controllerUUID), apiPort)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -231,6 +234,51 @@ func (c *legacyNovaFirewaller) InstanceIngressRules(inst instance.Instance, mach
 	return c.instanceIngressRules(c.ingressRulesInGroup, machineId)
 }
 
+// OpenEgressPorts is not supported.
+func (c *legacyNovaFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// CloseEgressPorts is not supported.
+func (c *legacyNovaFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// EgressRules is not supported.
+func (c *legacyNovaFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// OpenInstanceEgressPorts is not supported.
+func (c *legacyNovaFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// CloseInstanceEgressPorts is not supported.
+func (c *legacyNovaFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// InstanceEgressRules is not supported.
+func (c *legacyNovaFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("egress rules without Neutron security groups")
+}
+
+// OpenModelPorts is not supported.
+func (c *legacyNovaFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("model firewall rules without Neutron security groups")
+}
+
+// CloseModelPorts is not supported.
+func (c *legacyNovaFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("model firewall rules without Neutron security groups")
+}
+
+// ModelIngressRules is not supported.
+func (c *legacyNovaFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("model firewall rules without Neutron security groups")
+}
+
 func (c *legacyNovaFirewaller) matchingGroup(nameRegExp string) (nova.SecurityGroup, error) {
 	re, err := regexp.Compile(nameRegExp)
 	if err != nil {
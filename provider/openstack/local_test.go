@@ -441,6 +441,66 @@ func (s *localServerSuite) TestStartInstanceWithoutPublicIP(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *localServerSuite) TestStartInstanceAllocatePublicIP(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"use-floating-ip": false})
+	inst, _ := testing.AssertStartInstanceWithConstraints(
+		c, env, s.ControllerUUID, "100", constraints.MustParse("allocate-public-ip=true"),
+	)
+	defer func() {
+		err := env.StopInstances(inst.Id())
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	c.Assert(openstack.InstanceFloatingIP(inst), gc.NotNil)
+	c.Assert(*openstack.InstanceFloatingIP(inst), gc.Equals, fmt.Sprintf("10.0.0.%v", inst.Id()))
+}
+
+func (s *localServerSuite) TestStartInstanceNoPublicIP(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"use-floating-ip": true})
+	cleanup := s.srv.Neutron.RegisterControlPoint(
+		"addFloatingIP",
+		func(sc hook.ServiceControl, args ...interface{}) error {
+			return fmt.Errorf("add floating IP should not have been called")
+		},
+	)
+	defer cleanup()
+
+	inst, _ := testing.AssertStartInstanceWithConstraints(
+		c, env, s.ControllerUUID, "100", constraints.MustParse("allocate-public-ip=false"),
+	)
+	defer func() {
+		err := env.StopInstances(inst.Id())
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	c.Assert(openstack.InstanceFloatingIP(inst), gc.IsNil)
+}
+
+func (s *localServerSuite) TestStartInstanceRootDiskSourceLocal(c *gc.C) {
+	inst, _ := testing.AssertStartInstanceWithConstraints(
+		c, s.env, s.ControllerUUID, "100", constraints.MustParse("root-disk-source=local"),
+	)
+	err := s.env.StopInstances(inst.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *localServerSuite) TestStartInstanceRootDiskSourceVolume(c *gc.C) {
+	inst, hc := testing.AssertStartInstanceWithConstraints(
+		c, s.env, s.ControllerUUID, "100", constraints.MustParse("root-disk-source=volume root-disk=20G"),
+	)
+	defer func() {
+		err := s.env.StopInstances(inst.Id())
+		c.Assert(err, jc.ErrorIsNil)
+	}()
+	c.Assert(hc.RootDisk, gc.NotNil)
+	c.Assert(*hc.RootDisk, gc.Equals, uint64(20*1024))
+}
+
+func (s *localServerSuite) TestStartInstanceInvalidRootDiskSource(c *gc.C) {
+	_, _, _, err := testing.StartInstanceWithConstraints(
+		s.env, s.ControllerUUID, "100", constraints.MustParse("root-disk-source=ssd"),
+	)
+	c.Assert(err, gc.ErrorMatches, `root disk source "ssd" not valid`)
+}
+
 func (s *localServerSuite) TestStartInstanceHardwareCharacteristics(c *gc.C) {
 	// Ensure amd64 tools are available, to ensure an amd64 image.
 	amd64Version := version.Binary{
@@ -1289,6 +1349,16 @@ func (t *localServerSuite) TestPrecheckInstanceAvailZoneUnknown(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, `invalid availability zone "test-unknown"`)
 }
 
+func (t *localServerSuite) TestPrecheckInstanceAvailZoneZonesConstraint(c *gc.C) {
+	placement := "zone=test-available"
+	err := t.env.PrecheckInstance(environs.PrecheckInstanceParams{
+		Series:      series.LatestLts(),
+		Placement:   placement,
+		Constraints: constraints.MustParse("zones=az1,az2"),
+	})
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" not allowed by zones constraint "az1,az2"`)
+}
+
 func (t *localServerSuite) TestPrecheckInstanceAvailZonesUnsupported(c *gc.C) {
 	t.srv.Nova.SetAvailabilityZones() // no availability zone support
 	placement := "zone=test-unknown"
@@ -1489,6 +1559,96 @@ func (s *localServerSuite) TestEnsureGroup(c *gc.C) {
 	c.Check(obtainedRulesThirdTime, jc.SameContents, obtainedRules)
 }
 
+func (s *localServerSuite) TestInstanceEgressRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{
+		"firewall-mode":       config.FwInstance,
+		"egress-default-deny": true,
+	})
+	inst, _ := testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	fwInst, ok := inst.(instance.EgressFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	// The rules created by Neutron that allow all outbound
+	// traffic have been removed from the machine group.
+	neutronClient := openstack.GetNeutronClient(env)
+	groups, err := neutronClient.SecurityGroupByNameV2(
+		openstack.MachineGroupName(env, s.ControllerUUID, "100"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(groups, gc.HasLen, 1)
+	for _, rule := range groups[0].Rules {
+		c.Check(rule.Direction, gc.Not(gc.Equals), "egress")
+	}
+	rules, err := fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	err = fwInst.OpenEgressPorts("100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.SameContents, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+
+	// Egress rules are not reported as ingress rules.
+	ingressRules, err := inst.IngressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(ingressRules, gc.HasLen, 0)
+
+	err = fwInst.CloseEgressPorts("100", []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rules, err = fwInst.EgressRules("100")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []network.EgressRule{
+		network.MustNewEgressRule("udp", 53, 53, "0.0.0.0/0"),
+	})
+}
+
+func (s *localServerSuite) TestModelIngressRules(c *gc.C) {
+	env := s.openEnviron(c, coretesting.Attrs{"firewall-mode": config.FwInstance})
+	fwEnv, ok := env.(environs.ModelFirewaller)
+	c.Assert(ok, jc.IsTrue)
+
+	// The juju group does not exist until an instance is started.
+	_, err := fwEnv.ModelIngressRules()
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "100")
+	sshSourceCIDRs := func() []string {
+		rules, err := fwEnv.ModelIngressRules()
+		c.Assert(err, jc.ErrorIsNil)
+		for _, rule := range rules {
+			if rule.FromPort == 22 {
+				return rule.SourceCIDRs
+			}
+		}
+		return nil
+	}
+	// SSH is open to anyone by default.
+	c.Assert(sshSourceCIDRs(), jc.SameContents, []string{"0.0.0.0/0", "::/0"})
+
+	// Restrict SSH, as the firewaller does for the model's ssh rule.
+	err = fwEnv.OpenModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = fwEnv.CloseModelPorts([]network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0", "::/0"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(sshSourceCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+
+	// Starting another instance must not reopen SSH to anyone.
+	testing.AssertStartInstance(c, env, s.ControllerUUID, "101")
+	c.Assert(sshSourceCIDRs(), jc.DeepEquals, []string{"10.0.0.0/8"})
+}
+
 // TestMatchingGroup checks that you receive the group you expected.  matchingGroup()
 // is used by the firewaller when opening and closing ports.  Unit test in response to bug 1675799.
 func (s *localServerSuite) TestMatchingGroup(c *gc.C) {
@@ -2180,6 +2340,65 @@ func (t *localServerSuite) TestStartInstanceDistributionAZNotImplemented(c *gc.C
 	c.Assert(openstack.InstanceServerDetail(inst).AvailabilityZone, gc.Equals, "")
 }
 
+func (t *localServerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	t.srv.Nova.SetAvailabilityZones(
+		nova.AvailabilityZone{
+			Name: "az1",
+			State: nova.AvailabilityZoneState{
+				Available: true,
+			},
+		},
+		nova.AvailabilityZone{
+			Name: "az2",
+			State: nova.AvailabilityZoneState{
+				Available: true,
+			},
+		},
+	)
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// az2 is the only zone allowed by the constraint, even though
+	// az1 may be less populated.
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("zones=az2"),
+	}
+	result, err := testing.StartInstanceWithParams(t.env, "1", params)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(openstack.InstanceServerDetail(result.Instance).AvailabilityZone, gc.Equals, "az2")
+}
+
+func (t *localServerSuite) TestStartInstanceZonesConstraintAZNotImplemented(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	mock := mockAvailabilityZoneAllocations{
+		err: errors.NotImplementedf("availability zones"),
+	}
+	t.PatchValue(openstack.AvailabilityZoneAllocations, mock.AvailabilityZoneAllocations)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Constraints:    constraints.MustParse("zones=az1"),
+	}
+	_, err = testing.StartInstanceWithParams(t.env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zones matching zones constraint "az1" not found`)
+}
+
+func (t *localServerSuite) TestStartInstanceAvailZoneZonesConstraint(c *gc.C) {
+	err := bootstrapEnv(c, t.env)
+	c.Assert(err, jc.ErrorIsNil)
+
+	params := environs.StartInstanceParams{
+		ControllerUUID: t.ControllerUUID,
+		Placement:      "zone=test-available",
+		Constraints:    constraints.MustParse("zones=az1"),
+	}
+	_, err = testing.StartInstanceWithParams(t.env, "1", params)
+	c.Assert(err, gc.ErrorMatches, `availability zone "test-available" not allowed by zones constraint "az1"`)
+}
+
 func (t *localServerSuite) TestInstanceTags(c *gc.C) {
 	err := bootstrapEnv(c, t.env)
 	c.Assert(err, jc.ErrorIsNil)
@@ -7,6 +7,7 @@ package openstack
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 	"strings"
 	"sync"
@@ -22,6 +23,7 @@ import (
 	"gopkg.in/goose.v2/cinder"
 	"gopkg.in/goose.v2/client"
 	gooseerrors "gopkg.in/goose.v2/errors"
+	goosehttp "gopkg.in/goose.v2/http"
 	"gopkg.in/goose.v2/identity"
 	gooselogging "gopkg.in/goose.v2/logging"
 	"gopkg.in/goose.v2/neutron"
@@ -299,6 +301,8 @@ var _ environs.Environ = (*Environ)(nil)
 var _ simplestreams.HasRegion = (*Environ)(nil)
 var _ instance.Distributor = (*Environ)(nil)
 var _ environs.InstanceTagger = (*Environ)(nil)
+var _ environs.EgressFirewaller = (*Environ)(nil)
+var _ environs.ModelFirewaller = (*Environ)(nil)
 
 type openstackInstance struct {
 	e        *Environ
@@ -307,7 +311,8 @@ type openstackInstance struct {
 
 	mu           sync.Mutex
 	serverDetail *nova.ServerDetail
-	// floatingIP is non-nil iff use-floating-ip is true.
+	// floatingIP is non-nil iff use-floating-ip is true, or the
+	// instance was started with the allocate-public-ip constraint.
 	floatingIP *string
 }
 
@@ -316,6 +321,7 @@ func (inst *openstackInstance) String() string {
 }
 
 var _ instance.Instance = (*openstackInstance)(nil)
+var _ instance.EgressFirewaller = (*openstackInstance)(nil)
 
 func (inst *openstackInstance) Refresh() error {
 	inst.mu.Lock()
@@ -462,6 +468,21 @@ func (inst *openstackInstance) IngressRules(machineId string) ([]network.Ingress
 	return inst.e.firewaller.InstanceIngressRules(inst, machineId)
 }
 
+// OpenEgressPorts is part of the instance.EgressFirewaller interface.
+func (inst *openstackInstance) OpenEgressPorts(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.OpenInstanceEgressPorts(inst, machineId, rules)
+}
+
+// CloseEgressPorts is part of the instance.EgressFirewaller interface.
+func (inst *openstackInstance) CloseEgressPorts(machineId string, rules []network.EgressRule) error {
+	return inst.e.firewaller.CloseInstanceEgressPorts(inst, machineId, rules)
+}
+
+// EgressRules is part of the instance.EgressFirewaller interface.
+func (inst *openstackInstance) EgressRules(machineId string) ([]network.EgressRule, error) {
+	return inst.e.firewaller.InstanceEgressRules(inst, machineId)
+}
+
 func (e *Environ) ecfg() *environConfig {
 	e.ecfgMutex.Lock()
 	ecfg := e.ecfgUnlocked
@@ -601,9 +622,15 @@ func (e *Environ) PrecheckInstance(args environs.PrecheckInstanceParams) error {
 	if err != nil {
 		return errors.Trace(err)
 	}
-	if _, err := e.instancePlacementZone(args.Placement, volumeAttachmentsZone); err != nil {
+	placementZone, err := e.instancePlacementZone(args.Placement, volumeAttachmentsZone)
+	if err != nil {
 		return errors.Trace(err)
 	}
+	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if !args.Constraints.HasInstanceType() {
 		return nil
 	}
@@ -942,13 +969,24 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		return nil, errors.Trace(err)
 	}
 
+	withRootVolume, err := rootDiskIsVolume(args.Constraints, args.RootDisk)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	instanceCons := args.Constraints
+	if withRootVolume {
+		// The flavor's disk is not used when booting from a volume,
+		// so the root-disk constraint does not restrict the flavor.
+		instanceCons.RootDisk = nil
+	}
+
 	series := args.Tools.OneSeries()
 	arches := args.Tools.Arches()
 	spec, err := findInstanceSpec(e, &instances.InstanceConstraint{
 		Region:      e.cloud.Region,
 		Series:      series,
 		Arches:      arches,
-		Constraints: args.Constraints,
+		Constraints: instanceCons,
 	}, args.ImageMetadata)
 	if err != nil {
 		return nil, err
@@ -1069,13 +1107,34 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		return server, nil
 	}
 
+	var blockDeviceMappings []blockDeviceMapping
+	var rootVolumeSizeGiB uint64
+	if withRootVolume {
+		rootVolumeSizeGiB = common.MinRootDiskSizeGiB(series)
+		if args.Constraints.RootDisk != nil && *args.Constraints.RootDisk > rootVolumeSizeGiB*1024 {
+			rootVolumeSizeGiB = common.MiBToGiB(*args.Constraints.RootDisk)
+		}
+		blockDeviceMappings = []blockDeviceMapping{{
+			BootIndex:           0,
+			UUID:                spec.Image.Id,
+			SourceType:          "image",
+			DestinationType:     "volume",
+			VolumeSize:          int(rootVolumeSizeGiB),
+			DeleteOnTermination: true,
+		}}
+	}
+
 	tryStartNovaInstance := func(
 		attempts utils.AttemptStrategy,
 		client *nova.Client,
 		instanceOpts nova.RunServerOpts,
 	) (server *nova.Entity, err error) {
 		for a := attempts.Start(); a.Next(); {
-			server, err = client.RunServer(instanceOpts)
+			if len(blockDeviceMappings) > 0 {
+				server, err = runServerWithBlockDevices(e.client(), instanceOpts, blockDeviceMappings)
+			} else {
+				server, err = client.RunServer(instanceOpts)
+			}
 			if err != nil {
 				break
 			}
@@ -1154,6 +1213,9 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 	}
 	logger.Infof("started instance %q", inst.Id())
 	withPublicIP := e.ecfg().useFloatingIP()
+	if args.Constraints.HasAllocatePublicIP() {
+		withPublicIP = *args.Constraints.AllocatePublicIP
+	}
 	if withPublicIP {
 		var publicIP *string
 		logger.Debugf("allocating public IP address for openstack node")
@@ -1172,12 +1234,91 @@ func (e *Environ) StartInstance(args environs.StartInstanceParams) (*environs.St
 		}
 		inst.floatingIP = publicIP
 	}
+	hc := inst.hardwareCharacteristics()
+	if withRootVolume {
+		rootDiskMiB := rootVolumeSizeGiB * 1024
+		hc.RootDisk = &rootDiskMiB
+	}
 	return &environs.StartInstanceResult{
 		Instance: inst,
-		Hardware: inst.hardwareCharacteristics(),
+		Hardware: hc,
 	}, nil
 }
 
+// The values of the root-disk-source constraint, when it does not
+// name a storage pool.
+const (
+	// rootDiskSourceLocal specifies that the root disk is the
+	// local disk of the instance's flavor. This is the default.
+	rootDiskSourceLocal = "local"
+
+	// rootDiskSourceVolume specifies that the root disk is a
+	// Cinder volume, created from the image, which is deleted
+	// when the instance is terminated.
+	rootDiskSourceVolume = "volume"
+)
+
+// rootDiskIsVolume reports whether the root disk of a new instance
+// should be a Cinder volume created from the image, rather than the
+// flavor's local disk. This is determined by the root disk storage
+// pool or, failing that, the root-disk-source constraint.
+func rootDiskIsVolume(cons constraints.Value, rootDisk *storage.VolumeParams) (bool, error) {
+	if rootDisk != nil {
+		if rootDisk.Provider != CinderProviderType {
+			return false, errors.NotSupportedf("root disk with storage provider %q", rootDisk.Provider)
+		}
+		return true, nil
+	}
+	if !cons.HasRootDiskSource() {
+		return false, nil
+	}
+	switch *cons.RootDiskSource {
+	case rootDiskSourceLocal:
+		return false, nil
+	case rootDiskSourceVolume:
+		return true, nil
+	}
+	return false, errors.NotValidf("root disk source %q", *cons.RootDiskSource)
+}
+
+// blockDeviceMapping describes a block device of a new server, as
+// in the block_device_mapping_v2 parameter of the compute API.
+type blockDeviceMapping struct {
+	BootIndex           int    `json:"boot_index"`
+	UUID                string `json:"uuid,omitempty"`
+	SourceType          string `json:"source_type,omitempty"`
+	DestinationType     string `json:"destination_type,omitempty"`
+	VolumeSize          int    `json:"volume_size,omitempty"`
+	DeleteOnTermination bool   `json:"delete_on_termination,omitempty"`
+}
+
+// runServerWithBlockDevices creates a new server in the same way as
+// nova.Client.RunServer, with the given block device mappings. The
+// goose RunServerOpts has no field for block device mappings, so the
+// request is sent with the environ's own client.
+func runServerWithBlockDevices(c client.Client, opts nova.RunServerOpts, mappings []blockDeviceMapping) (*nova.Entity, error) {
+	var req struct {
+		Server struct {
+			nova.RunServerOpts
+			BlockDeviceMappings []blockDeviceMapping `json:"block_device_mapping_v2"`
+		} `json:"server"`
+	}
+	req.Server.RunServerOpts = opts
+	req.Server.BlockDeviceMappings = mappings
+	var resp struct {
+		Server nova.Entity `json:"server"`
+	}
+	requestData := goosehttp.RequestData{
+		ReqValue:       req,
+		RespValue:      &resp,
+		ExpectedStatus: []int{http.StatusAccepted},
+	}
+	if err := c.SendRequest(client.POST, "compute", "v2", "servers", &requestData); err != nil {
+		return nil, errors.Annotatef(err, "failed to run a server with %d block device mappings", len(mappings))
+	}
+	return &resp.Server, nil
+}
+
 func (e *Environ) startInstanceAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
 	volumeAttachmentsZone, err := e.volumeAttachmentsZone(args.VolumeAttachments)
 	if err != nil {
@@ -1189,12 +1330,15 @@ func (e *Environ) startInstanceAvailabilityZones(args environs.StartInstancePara
 	}
 	var availabilityZones []string
 	if placementZone != "" {
+		if err := common.CheckZoneConstraint(placementZone, args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		availabilityZones = []string{placementZone}
 	}
 
 	// If no availability zone is specified, then automatically spread across
-	// the known zones for optimal spread across the instance distribution
-	// group.
+	// the known zones, restricted by any zones constraint, for optimal spread
+	// across the instance distribution group.
 	if len(availabilityZones) == 0 {
 		var group []instance.Id
 		var err error
@@ -1215,6 +1359,10 @@ func (e *Environ) startInstanceAvailabilityZones(args environs.StartInstancePara
 				availabilityZones = append(availabilityZones, zone.ZoneName)
 			}
 		}
+		availabilityZones, err = common.FilterZonesByConstraints(availabilityZones, args.Constraints)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 		if len(availabilityZones) == 0 {
 			// No explicitly selectable zones available, so use an unspecified zone.
 			availabilityZones = []string{""}
@@ -1642,6 +1790,30 @@ func rulesToRuleInfo(groupId string, rules []network.IngressRule) []neutron.Rule
 	return result
 }
 
+// egressRulesToRuleInfo returns the neutron security group rules for
+// the given egress rules, one for each destination CIDR.
+func egressRulesToRuleInfo(groupId string, rules []network.EgressRule) []neutron.RuleInfoV2 {
+	var result []neutron.RuleInfoV2
+	for _, r := range rules {
+		ruleInfo := neutron.RuleInfoV2{
+			Direction:     "egress",
+			ParentGroupId: groupId,
+			PortRangeMin:  r.FromPort,
+			PortRangeMax:  r.ToPort,
+			IPProtocol:    r.Protocol,
+		}
+		destinationCIDRs := r.DestinationCIDRs
+		if len(destinationCIDRs) == 0 {
+			destinationCIDRs = []string{"0.0.0.0/0"}
+		}
+		for _, cidr := range destinationCIDRs {
+			ruleInfo.RemoteIPPrefix = cidr
+			result = append(result, ruleInfo)
+		}
+	}
+	return result
+}
+
 func (e *Environ) OpenPorts(rules []network.IngressRule) error {
 	return e.firewaller.OpenPorts(rules)
 }
@@ -1654,6 +1826,36 @@ func (e *Environ) IngressRules() ([]network.IngressRule, error) {
 	return e.firewaller.IngressRules()
 }
 
+// OpenEgressPorts is part of the environs.EgressFirewaller interface.
+func (e *Environ) OpenEgressPorts(rules []network.EgressRule) error {
+	return e.firewaller.OpenEgressPorts(rules)
+}
+
+// CloseEgressPorts is part of the environs.EgressFirewaller interface.
+func (e *Environ) CloseEgressPorts(rules []network.EgressRule) error {
+	return e.firewaller.CloseEgressPorts(rules)
+}
+
+// EgressRules is part of the environs.EgressFirewaller interface.
+func (e *Environ) EgressRules() ([]network.EgressRule, error) {
+	return e.firewaller.EgressRules()
+}
+
+// OpenModelPorts is part of the environs.ModelFirewaller interface.
+func (e *Environ) OpenModelPorts(rules []network.IngressRule) error {
+	return e.firewaller.OpenModelPorts(rules)
+}
+
+// CloseModelPorts is part of the environs.ModelFirewaller interface.
+func (e *Environ) CloseModelPorts(rules []network.IngressRule) error {
+	return e.firewaller.CloseModelPorts(rules)
+}
+
+// ModelIngressRules is part of the environs.ModelFirewaller interface.
+func (e *Environ) ModelIngressRules() ([]network.IngressRule, error) {
+	return e.firewaller.ModelIngressRules()
+}
+
 func (e *Environ) Provider() environs.EnvironProvider {
 	return providerInstance
 }
@@ -659,6 +659,9 @@ func (o *OracleEnviron) ConstraintsValidator() (constraints.Validator, error) {
 		constraints.CpuPower,
 		constraints.RootDisk,
 		constraints.VirtType,
+		constraints.Zones,
+		constraints.RootDiskSource,
+		constraints.AllocatePublicIP,
 	}
 
 	// we choose to use the default validator implementation
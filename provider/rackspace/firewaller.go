@@ -92,6 +92,51 @@ func (c *rackspaceFirewaller) InstanceIngressRules(inst instance.Instance, machi
 	return configurator.FindIngressRules()
 }
 
+// OpenEgressPorts is not supported.
+func (c *rackspaceFirewaller) OpenEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenEgressPorts")
+}
+
+// CloseEgressPorts is not supported.
+func (c *rackspaceFirewaller) CloseEgressPorts(rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseEgressPorts")
+}
+
+// EgressRules is not supported.
+func (c *rackspaceFirewaller) EgressRules() ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("EgressRules")
+}
+
+// OpenInstanceEgressPorts is not supported.
+func (c *rackspaceFirewaller) OpenInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("OpenInstanceEgressPorts")
+}
+
+// CloseInstanceEgressPorts is not supported.
+func (c *rackspaceFirewaller) CloseInstanceEgressPorts(inst instance.Instance, machineId string, rules []network.EgressRule) error {
+	return errors.NotSupportedf("CloseInstanceEgressPorts")
+}
+
+// InstanceEgressRules is not supported.
+func (c *rackspaceFirewaller) InstanceEgressRules(inst instance.Instance, machineId string) ([]network.EgressRule, error) {
+	return nil, errors.NotSupportedf("InstanceEgressRules")
+}
+
+// OpenModelPorts is not supported.
+func (c *rackspaceFirewaller) OpenModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("OpenModelPorts")
+}
+
+// CloseModelPorts is not supported.
+func (c *rackspaceFirewaller) CloseModelPorts(rules []network.IngressRule) error {
+	return errors.NotSupportedf("CloseModelPorts")
+}
+
+// ModelIngressRules is not supported.
+func (c *rackspaceFirewaller) ModelIngressRules() ([]network.IngressRule, error) {
+	return nil, errors.NotSupportedf("ModelIngressRules")
+}
+
 func (c *rackspaceFirewaller) changeIngressRules(inst instance.Instance, insert bool, rules []network.IngressRule) error {
 	addresses, sshClient, err := c.getInstanceConfigurator(inst)
 	if err != nil {
@@ -36,6 +36,16 @@ func (p *environProvider) PrepareConfig(args environs.PrepareConfigParams) (*con
 	return p.EnvironProvider.PrepareConfig(args)
 }
 
+// Validate is part of the EnvironProvider interface.
+func (p *environProvider) Validate(cfg, old *config.Config) (*config.Config, error) {
+	// The rackspace firewaller does not support egress rules,
+	// so outbound traffic cannot be denied.
+	if cfg.EgressDefaultDeny() {
+		return nil, errors.NotSupportedf("%s", config.EgressDefaultDenyKey)
+	}
+	return p.EnvironProvider.Validate(cfg, old)
+}
+
 // Open is part of the EnvironProvider interface.
 func (p *environProvider) Open(args environs.OpenParams) (environs.Environ, error) {
 	args.Cloud = transformCloudSpec(args.Cloud)
@@ -42,6 +42,21 @@ func (s *providerSuite) TestValidate(c *gc.C) {
 	s.innerProvider.CheckCallNames(c, "Validate")
 }
 
+func (s *providerSuite) TestValidateEgressDefaultDeny(c *gc.C) {
+	cfg, err := config.New(config.UseDefaults, map[string]interface{}{
+		"name":                "some-name",
+		"type":                "some-type",
+		"uuid":                coretesting.ModelTag.Id(),
+		"controller-uuid":     coretesting.ControllerTag.Id(),
+		"authorized-keys":     "key",
+		"egress-default-deny": true,
+	})
+	c.Check(err, gc.IsNil)
+	_, err = s.provider.Validate(cfg, nil)
+	c.Check(err, gc.ErrorMatches, "egress-default-deny not supported")
+	s.innerProvider.CheckNoCalls(c)
+}
+
 func (s *providerSuite) TestPrepareConfig(c *gc.C) {
 	args := environs.PrepareConfigParams{
 		Cloud: environs.CloudSpec{
@@ -111,7 +111,8 @@ func (env *sessionEnviron) availZone(name string) (common.AvailabilityZone, erro
 // provided then only that one is returned. Otherwise the environment is
 // queried for available zones. In that case, the resulting list is
 // roughly ordered such that the environment's instances are spread
-// evenly across the region.
+// evenly across the region, and restricted to the zones allowed by
+// any zones constraint.
 func (env *sessionEnviron) parseAvailabilityZones(args environs.StartInstanceParams) ([]string, error) {
 	if args.Placement != "" {
 		// args.Placement will always be a zone name or empty.
@@ -119,6 +120,9 @@ func (env *sessionEnviron) parseAvailabilityZones(args environs.StartInstancePar
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if err := common.CheckZoneConstraint(placement.Name(), args.Constraints); err != nil {
+			return nil, errors.Trace(err)
+		}
 		return []string{placement.Name()}, nil
 	}
 
@@ -159,5 +163,9 @@ func (env *sessionEnviron) parseAvailabilityZones(args environs.StartInstancePar
 		return nil, errors.NotFoundf("availability zones")
 	}
 
+	zoneNames, err = common.FilterZonesByConstraints(zoneNames, args.Constraints)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	return zoneNames, nil
 }
@@ -370,6 +370,25 @@ func (s *environBrokerSuite) TestStartInstanceTriesToCreateInstanceInAllAvailabi
 	c.Assert(createVMArgs2.ComputeResource, jc.DeepEquals, s.client.computeResources[1])
 }
 
+func (s *environBrokerSuite) TestStartInstanceZonesConstraint(c *gc.C) {
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Constraints.Zones = &[]string{"z2"}
+	_, err := s.env.StartInstance(startInstArgs)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.client.CheckCallNames(c, "ComputeResources", "CreateVirtualMachine", "Close")
+	createVMArgs := s.client.Calls()[1].Args[1].(vsphereclient.CreateVirtualMachineParams)
+	c.Assert(createVMArgs.ComputeResource, jc.DeepEquals, s.client.computeResources[1])
+}
+
+func (s *environBrokerSuite) TestStartInstanceSelectZoneZonesConstraint(c *gc.C) {
+	startInstArgs := s.createStartInstanceArgs(c)
+	startInstArgs.Placement = "zone=z2"
+	startInstArgs.Constraints.Zones = &[]string{"z1"}
+	_, err := s.env.StartInstance(startInstArgs)
+	c.Assert(err, gc.ErrorMatches, `availability zone "z2" not allowed by zones constraint "z1"`)
+}
+
 func (s *environBrokerSuite) TestStartInstanceDatastore(c *gc.C) {
 	cfg := s.env.Config()
 	cfg, err := cfg.Apply(map[string]interface{}{
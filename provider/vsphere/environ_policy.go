@@ -8,6 +8,7 @@ import (
 
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/environs"
+	"github.com/juju/juju/provider/common"
 )
 
 // PrecheckInstance is part of the environs.Environ interface.
@@ -22,13 +23,18 @@ func (env *environ) PrecheckInstance(args environs.PrecheckInstanceParams) error
 
 // PrecheckInstance is part of the environs.Environ interface.
 func (env *sessionEnviron) PrecheckInstance(args environs.PrecheckInstanceParams) error {
-	_, err := env.parsePlacement(args.Placement)
-	return err
+	placement, err := env.parsePlacement(args.Placement)
+	if err != nil {
+		return err
+	}
+	return common.CheckZoneConstraint(placement.Name(), args.Constraints)
 }
 
 var unsupportedConstraints = []string{
 	constraints.Tags,
 	constraints.VirtType,
+	constraints.RootDiskSource,
+	constraints.AllocatePublicIP,
 }
 
 // ConstraintsValidator returns a Validator value which is used to
@@ -104,8 +104,32 @@ type actionDoc struct {
 
 	// Results are the structured results from the action.
 	Results map[string]interface{} `bson:"results"`
+
+	// Logs holds the progress messages logged by the action while it
+	// was running.
+	Logs []ActionMessage `bson:"messages"`
+}
+
+// ActionMessage represents a progress message logged by an action.
+type ActionMessage struct {
+	MessageValue   string    `bson:"message"`
+	TimestampValue time.Time `bson:"timestamp"`
+}
+
+// Timestamp returns the message timestamp.
+func (m ActionMessage) Timestamp() time.Time {
+	return m.TimestampValue
+}
+
+// Message returns the message string.
+func (m ActionMessage) Message() string {
+	return m.MessageValue
 }
 
+// maxActionMessages is the number of progress messages retained for
+// each action; older messages are discarded.
+const maxActionMessages = 1000
+
 // action represents an instruction to do some "action" and is expected
 // to match an action definition in a charm.
 type action struct {
@@ -162,6 +186,11 @@ func (a *action) Results() (map[string]interface{}, string) {
 	return a.doc.Results, a.doc.Message
 }
 
+// Messages returns the progress messages logged by the action.
+func (a *action) Messages() []ActionMessage {
+	return a.doc.Logs
+}
+
 // Tag implements the Entity interface and returns a names.Tag that
 // is a names.ActionTag.
 func (a *action) Tag() names.Tag {
@@ -201,6 +230,34 @@ func (a *action) Begin() (Action, error) {
 	return a.st.Action(a.Id())
 }
 
+// Log adds a timestamped progress message to the action. It asserts
+// that the action is currently running.
+func (a *action) Log(message string) error {
+	msg := ActionMessage{
+		MessageValue:   message,
+		TimestampValue: a.st.nowToTheSecond(),
+	}
+	err := a.st.db().RunTransaction([]txn.Op{
+		{
+			C:      actionsC,
+			Id:     a.doc.DocId,
+			Assert: bson.D{{"status", ActionRunning}},
+			Update: bson.D{{"$push", bson.D{
+				{"messages", bson.D{
+					{"$each", []ActionMessage{msg}},
+					{"$slice", -maxActionMessages},
+				}},
+			}}},
+		}})
+	if err == txn.ErrAborted {
+		return errors.Errorf("cannot log message to action %q: action is not running", a.Id())
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot log message to action %q", a.Id())
+	}
+	a.doc.Logs = append(a.doc.Logs, msg)
+	return nil
+}
+
 // Finish removes action from the pending queue and captures the output
 // and end state of the action.
 func (a *action) Finish(results ActionResults) (Action, error) {
@@ -370,21 +427,7 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 		return nil, errors.Trace(err)
 	}
 
-	ops := []txn.Op{{
-		C:      receiverCollectionName,
-		Id:     receiverId,
-		Assert: notDeadDoc,
-	}, {
-		C:      actionsC,
-		Id:     doc.DocId,
-		Assert: txn.DocMissing,
-		Insert: doc,
-	}, {
-		C:      actionNotificationsC,
-		Id:     ndoc.DocId,
-		Assert: txn.DocMissing,
-		Insert: ndoc,
-	}}
+	ops := enqueueActionOps(receiverCollectionName, receiverId, doc, ndoc)
 
 	buildTxn := func(attempt int) ([]txn.Op, error) {
 		if notDead, err := isNotDead(st, receiverCollectionName, receiverId); err != nil {
@@ -402,6 +445,26 @@ func (st *State) EnqueueAction(receiver names.Tag, actionName string, payload ma
 	return nil, err
 }
 
+// enqueueActionOps returns the operations needed to add the given action
+// and its notification, asserting that the receiver is not dead.
+func enqueueActionOps(receiverCollectionName string, receiverId interface{}, doc actionDoc, ndoc actionNotificationDoc) []txn.Op {
+	return []txn.Op{{
+		C:      receiverCollectionName,
+		Id:     receiverId,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionsC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}, {
+		C:      actionNotificationsC,
+		Id:     ndoc.DocId,
+		Assert: txn.DocMissing,
+		Insert: ndoc,
+	}}
+}
+
 // matchingActions finds actions that match ActionReceiver.
 func (st *State) matchingActions(ar ActionReceiver) ([]Action, error) {
 	return st.matchingActionsByReceiverId(ar.Tag().Id())
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
@@ -463,6 +464,36 @@ func (s *ActionSuite) TestComplete(c *gc.C) {
 	c.Assert(len(actions), gc.Equals, 0)
 }
 
+func (s *ActionSuite) TestLog(c *gc.C) {
+	a, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Messages may only be logged while the action is running.
+	err = a.Log("too soon")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+
+	a, err = a.Begin()
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("first")
+	c.Assert(err, jc.ErrorIsNil)
+	s.Clock.Advance(time.Minute)
+	err = a.Log("second")
+	c.Assert(err, jc.ErrorIsNil)
+
+	a, err = s.State.Action(a.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	messages := a.Messages()
+	c.Assert(messages, gc.HasLen, 2)
+	c.Assert(messages[0].Message(), gc.Equals, "first")
+	c.Assert(messages[1].Message(), gc.Equals, "second")
+	c.Assert(messages[1].Timestamp().Sub(messages[0].Timestamp()), gc.Equals, time.Minute)
+
+	_, err = a.Finish(state.ActionResults{Status: state.ActionCompleted})
+	c.Assert(err, jc.ErrorIsNil)
+	err = a.Log("too late")
+	c.Assert(err, gc.ErrorMatches, `cannot log message to action ".*": action is not running`)
+}
+
 func (s *ActionSuite) TestFindActionTagsByPrefix(c *gc.C) {
 	prefix := "feedbeef"
 	uuidMock := uuidMockHelper{}
@@ -898,6 +929,63 @@ func (r mockAR) PendingActions() ([]state.Action, error)         { return nil, n
 func (r mockAR) RunningActions() ([]state.Action, error)         { return nil, nil }
 func (r mockAR) Tag() names.Tag                                  { return names.NewUnitTag(r.id) }
 
+func (s *ActionSuite) TestPruneActionsByAge(c *gc.C) {
+	finish := func(status state.ActionStatus) state.Action {
+		action, err := s.unit.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		action, err = action.Finish(state.ActionResults{Status: status})
+		c.Assert(err, jc.ErrorIsNil)
+		return action
+	}
+	finish(state.ActionCompleted)
+	finish(state.ActionFailed)
+	s.Clock.Advance(2 * time.Hour)
+	recent := finish(state.ActionCompleted)
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, time.Hour, 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	actions, err := s.unit.Actions()
+	c.Assert(err, jc.ErrorIsNil)
+	var ids []string
+	for _, action := range actions {
+		ids = append(ids, action.Id())
+	}
+	c.Assert(ids, jc.SameContents, []string{recent.Id(), pending.Id()})
+}
+
+func (s *ActionSuite) TestPruneActionsBySize(c *gc.C) {
+	output := map[string]interface{}{"output": strings.Repeat("x", 16*1024)}
+	const count = 150
+	for i := 0; i < count; i++ {
+		action, err := s.unit.AddAction("snapshot", nil)
+		c.Assert(err, jc.ErrorIsNil)
+		_, err = action.Finish(state.ActionResults{Status: state.ActionCompleted, Results: output})
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	pending, err := s.unit.AddAction("snapshot", nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = state.PruneActions(s.State, 0, 1)
+	c.Assert(err, jc.ErrorIsNil)
+
+	completed, err := s.unit.CompletedActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(len(completed), jc.LessThan, count)
+	// Pending actions are never pruned.
+	_, err = s.State.Action(pending.Id())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ActionSuite) TestPruneActionsInvalid(c *gc.C) {
+	err := state.PruneActions(s.State, 0, 0)
+	c.Assert(err, gc.ErrorMatches, "backlog size and age constraints are both 0 not valid")
+	err = state.PruneActions(s.State, -time.Hour, 0)
+	c.Assert(err, gc.ErrorMatches, "non-positive max age not valid")
+}
+
 // TestMock verifies the mock UUID generator works as expected.
 func (s *ActionSuite) TestMock(c *gc.C) {
 	prefix := "abbadead"
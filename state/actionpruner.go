@@ -0,0 +1,196 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+)
+
+// finishedActionStatuses holds the statuses of actions that will not
+// run again, and so may be pruned.
+var finishedActionStatuses = []ActionStatus{
+	ActionCompleted,
+	ActionCancelled,
+	ActionFailed,
+}
+
+// PruneActions removes completed actions, along with their results,
+// until only those completed more recently than <maxActionAge> remain
+// and the actions collection is smaller than <maxActionsMB>. Pending
+// and running actions are never removed.
+func PruneActions(mb modelBackend, maxActionAge time.Duration, maxActionsMB int) error {
+	// As with status history, we require a raw collection to obtain
+	// the size of the collection. Take care to include model-uuid in
+	// queries where appropriate.
+	actions, closer := mb.db().GetRawCollection(actionsC)
+	defer closer()
+
+	p := actionPruner{
+		st:      mb,
+		coll:    actions,
+		maxAge:  maxActionAge,
+		maxSize: maxActionsMB,
+	}
+	if err := p.validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if err := p.pruneByAge(); err != nil {
+		return errors.Trace(err)
+	}
+	return errors.Trace(p.pruneBySize())
+}
+
+type actionPruner struct {
+	st   modelBackend
+	coll *mgo.Collection
+
+	maxAge  time.Duration
+	maxSize int
+}
+
+func (p *actionPruner) validate() error {
+	if p.maxSize < 0 {
+		return errors.NotValidf("non-positive max size")
+	}
+	if p.maxAge < 0 {
+		return errors.NotValidf("non-positive max age")
+	}
+	if p.maxSize == 0 && p.maxAge == 0 {
+		return errors.NotValidf("backlog size and age constraints are both 0")
+	}
+	return nil
+}
+
+func (p *actionPruner) pruneByAge() error {
+	if p.maxAge == 0 {
+		return nil
+	}
+	t := p.st.clock().Now().Add(-p.maxAge)
+	iter := p.coll.Find(bson.D{
+		{"model-uuid", p.st.modelUUID()},
+		{"status", bson.D{{"$in", finishedActionStatuses}}},
+		{"completed", bson.D{{"$lt", t}}},
+	}).Select(bson.M{"_id": 1}).Iter()
+
+	modelName, err := p.st.modelName()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logTemplate := fmt.Sprintf("action age pruning (%s): %%d actions deleted", modelName)
+	deleted, err := p.deleteInBatches(iter, logTemplate, noEarlyFinish)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if deleted > 0 {
+		actionLogger.Infof("action age pruning (%s): %d actions deleted", modelName, deleted)
+	}
+	return nil
+}
+
+func (p *actionPruner) pruneBySize() error {
+	if !p.st.isController() {
+		// The actions collection is shared by all models, so only
+		// prune by size in the controller; otherwise several pruners
+		// would race to delete the oldest actions.
+		return nil
+	}
+	if p.maxSize == 0 {
+		return nil
+	}
+	collMB, err := getCollectionMB(p.coll)
+	if err != nil {
+		return errors.Annotate(err, "retrieving actions collection size")
+	}
+	if collMB <= p.maxSize {
+		return nil
+	}
+	count, err := p.coll.Count()
+	if err == mgo.ErrNotFound || count <= 0 {
+		return nil
+	}
+	if err != nil {
+		return errors.Annotate(err, "counting actions")
+	}
+	// As with status history, assume that action sizes average out
+	// over large numbers to estimate how many must be removed.
+	sizePerAction := float64(collMB) / float64(count)
+	if sizePerAction == 0 {
+		return errors.New("unexpected result calculating action size")
+	}
+	toDelete := int(float64(collMB-p.maxSize) / sizePerAction)
+
+	iter := p.coll.Find(bson.D{
+		{"status", bson.D{{"$in", finishedActionStatuses}}},
+	}).Sort("completed").Limit(toDelete).Select(bson.M{"_id": 1}).Iter()
+
+	template := fmt.Sprintf("action size pruning: deleted %%d of %d (estimated)", toDelete)
+	deleted, err := p.deleteInBatches(iter, template, func() (bool, error) {
+		// Check that we still need to delete more
+		collMB, err := getCollectionMB(p.coll)
+		if err != nil {
+			return false, errors.Annotate(err, "retrieving actions collection size")
+		}
+		return collMB <= p.maxSize, nil
+	})
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	actionLogger.Infof("action size pruning finished: %d actions deleted", deleted)
+	return nil
+}
+
+func (p *actionPruner) deleteInBatches(iter *mgo.Iter, logTemplate string, shouldStop doneCheck) (int, error) {
+	var doc bson.M
+	chunk := p.coll.Bulk()
+	chunkSize := 0
+
+	lastUpdate := time.Now()
+	deleted := 0
+	for iter.Next(&doc) {
+		chunk.Remove(bson.D{{"_id", doc["_id"]}})
+		chunkSize++
+		if chunkSize == historyPruneBatchSize {
+			_, err := chunk.Run()
+			// NotFound indicates that actions were already deleted.
+			if err != nil && err != mgo.ErrNotFound {
+				return 0, errors.Annotate(err, "removing action batch")
+			}
+
+			deleted += chunkSize
+			chunk = p.coll.Bulk()
+			chunkSize = 0
+
+			done, err := shouldStop()
+			if err != nil {
+				return 0, errors.Annotate(err, "checking whether to stop")
+			}
+			if done {
+				return deleted, errors.Trace(iter.Close())
+			}
+
+			now := time.Now()
+			if now.Sub(lastUpdate) >= historyPruneProgressSeconds*time.Second {
+				actionLogger.Infof(logTemplate, deleted)
+				lastUpdate = now
+			}
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return 0, errors.Annotate(err, "reading actions to prune")
+	}
+
+	if chunkSize > 0 {
+		_, err := chunk.Run()
+		if err != nil && err != mgo.ErrNotFound {
+			return 0, errors.Annotate(err, "removing action remainder")
+		}
+	}
+	return deleted + chunkSize, nil
+}
@@ -0,0 +1,307 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jujutxn "github.com/juju/txn"
+	"gopkg.in/juju/names.v2"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// MinActionScheduleInterval is the shortest interval at which a
+// recurring action may be enqueued.
+const MinActionScheduleInterval = time.Minute
+
+// actionScheduleDoc records an action that is to be enqueued at some
+// point in the future, and optionally repeated at a fixed interval.
+// There are no calendar-based schedules; a daily run at a fixed time
+// is a start time with an interval of 24 hours.
+type actionScheduleDoc struct {
+	// DocId is the key for this document; it is a UUID.
+	DocId string `bson:"_id"`
+
+	// ModelUUID is the model identifier.
+	ModelUUID string `bson:"model-uuid"`
+
+	// Receiver is the name of the unit for which the action will be
+	// enqueued.
+	Receiver string `bson:"receiver"`
+
+	// Name identifies the action that will be enqueued.
+	Name string `bson:"name"`
+
+	// Parameters holds the action's parameters, with the charm's
+	// defaults already inserted.
+	Parameters map[string]interface{} `bson:"parameters"`
+
+	// Created is the time the schedule was added.
+	Created time.Time `bson:"created"`
+
+	// NextRun is the time at which the action will next be enqueued.
+	NextRun time.Time `bson:"next-run"`
+
+	// Interval is the time between runs of a recurring action. It is
+	// zero for an action that runs only once.
+	Interval time.Duration `bson:"interval"`
+
+	// LastActionId holds the id of the action most recently enqueued
+	// by this schedule, if any.
+	LastActionId string `bson:"last-action-id,omitempty"`
+}
+
+// ActionSchedule represents an action that will be enqueued at a
+// future time, and optionally repeated.
+type ActionSchedule struct {
+	st  *State
+	doc actionScheduleDoc
+}
+
+// Id returns the local id of the schedule.
+func (s *ActionSchedule) Id() string {
+	return s.st.localID(s.doc.DocId)
+}
+
+// Receiver returns the name of the unit on which the action will run.
+func (s *ActionSchedule) Receiver() string {
+	return s.doc.Receiver
+}
+
+// Name returns the name of the action that will be enqueued.
+func (s *ActionSchedule) Name() string {
+	return s.doc.Name
+}
+
+// Parameters returns the parameters for the action.
+func (s *ActionSchedule) Parameters() map[string]interface{} {
+	return s.doc.Parameters
+}
+
+// Created returns the time the schedule was added.
+func (s *ActionSchedule) Created() time.Time {
+	return s.doc.Created
+}
+
+// NextRun returns the time at which the action will next be enqueued.
+func (s *ActionSchedule) NextRun() time.Time {
+	return s.doc.NextRun
+}
+
+// Interval returns the time between runs of a recurring action, or
+// zero if the action will be enqueued only once.
+func (s *ActionSchedule) Interval() time.Duration {
+	return s.doc.Interval
+}
+
+// LastActionId returns the id of the action most recently enqueued by
+// the schedule, or the empty string if it has not yet run.
+func (s *ActionSchedule) LastActionId() string {
+	return s.doc.LastActionId
+}
+
+// ScheduleAction arranges for an action with the given name and payload
+// to be enqueued for the unit at the given time, and then every interval
+// thereafter if interval is non-zero. A non-zero interval must be at
+// least MinActionScheduleInterval. If at is zero, the action will
+// first be enqueued one interval from now.
+func (u *Unit) ScheduleAction(name string, payload map[string]interface{}, at time.Time, interval time.Duration) (*ActionSchedule, error) {
+	if interval < 0 {
+		return nil, errors.NotValidf("negative interval")
+	}
+	if interval > 0 && interval < MinActionScheduleInterval {
+		return nil, errors.NotValidf("interval %v shorter than %v", interval, MinActionScheduleInterval)
+	}
+	if at.IsZero() {
+		if interval == 0 {
+			return nil, errors.NotValidf("schedule with neither start time nor interval")
+		}
+		at = u.st.clock().Now().Add(interval)
+	}
+	payloadWithDefaults, err := u.validateActionPayload(name, payload)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	id, err := NewUUID()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	doc := actionScheduleDoc{
+		DocId:      u.st.docID(id.String()),
+		ModelUUID:  u.st.ModelUUID(),
+		Receiver:   u.Name(),
+		Name:       name,
+		Parameters: payloadWithDefaults,
+		Created:    u.st.nowToTheSecond(),
+		NextRun:    at.UTC().Round(time.Second),
+		Interval:   interval,
+	}
+	ops := []txn.Op{{
+		C:      unitsC,
+		Id:     u.doc.DocID,
+		Assert: notDeadDoc,
+	}, {
+		C:      actionSchedulesC,
+		Id:     doc.DocId,
+		Assert: txn.DocMissing,
+		Insert: doc,
+	}}
+	if err := u.st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return nil, ErrDead
+	} else if err != nil {
+		return nil, errors.Annotate(err, "cannot schedule action")
+	}
+	return &ActionSchedule{st: u.st, doc: doc}, nil
+}
+
+// ActionSchedule returns the action schedule with the given id.
+func (st *State) ActionSchedule(id string) (*ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var doc actionScheduleDoc
+	err := schedules.FindId(id).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("action schedule %q", id)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "cannot get action schedule %q", id)
+	}
+	return &ActionSchedule{st: st, doc: doc}, nil
+}
+
+// ActionSchedules returns all of the action schedules in the model,
+// ordered by the time at which they will next run.
+func (st *State) ActionSchedules() ([]*ActionSchedule, error) {
+	return st.findActionSchedules(nil)
+}
+
+// RemoveActionSchedule removes the action schedule with the given id.
+// Actions that the schedule has already enqueued are not affected.
+func (st *State) RemoveActionSchedule(id string) error {
+	ops := []txn.Op{{
+		C:      actionSchedulesC,
+		Id:     st.docID(id),
+		Assert: txn.DocExists,
+		Remove: true,
+	}}
+	if err := st.db().RunTransaction(ops); err == txn.ErrAborted {
+		return errors.NotFoundf("action schedule %q", id)
+	} else if err != nil {
+		return errors.Annotatef(err, "cannot remove action schedule %q", id)
+	}
+	return nil
+}
+
+// RunDueActionSchedules enqueues the actions for all schedules whose
+// next run time has passed, and returns the number of actions enqueued.
+// Recurring schedules are advanced to their next run time; runs missed
+// while the controller was unavailable are skipped. Schedules whose
+// unit is no longer alive are removed.
+func (st *State) RunDueActionSchedules() (int, error) {
+	now := st.clock().Now()
+	due, err := st.findActionSchedules(bson.D{{"next-run", bson.D{{"$lte", now}}}})
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	enqueued := 0
+	for _, schedule := range due {
+		ran, err := schedule.run(now)
+		if err != nil {
+			return enqueued, errors.Annotatef(err, "running action schedule %q", schedule.Id())
+		}
+		if ran {
+			enqueued++
+		}
+	}
+	return enqueued, nil
+}
+
+func (st *State) findActionSchedules(query bson.D) ([]*ActionSchedule, error) {
+	schedules, closer := st.db().GetCollection(actionSchedulesC)
+	defer closer()
+
+	var docs []actionScheduleDoc
+	if err := schedules.Find(query).Sort("next-run").All(&docs); err != nil {
+		return nil, errors.Annotate(err, "cannot get action schedules")
+	}
+	results := make([]*ActionSchedule, len(docs))
+	for i, doc := range docs {
+		results[i] = &ActionSchedule{st: st, doc: doc}
+	}
+	return results, nil
+}
+
+// run enqueues the scheduled action if it is due at the given time,
+// and reports whether an action was enqueued.
+func (s *ActionSchedule) run(now time.Time) (bool, error) {
+	st := s.st
+	receiver := names.NewUnitTag(s.doc.Receiver)
+	receiverCollectionName, receiverId, err := st.tagToCollectionAndId(receiver)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+
+	ran := false
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		ran = false
+		if attempt > 0 {
+			fresh, err := st.ActionSchedule(s.doc.DocId)
+			if errors.IsNotFound(err) {
+				return nil, jujutxn.ErrNoOperations
+			} else if err != nil {
+				return nil, errors.Trace(err)
+			}
+			s.doc = fresh.doc
+		}
+		if s.doc.NextRun.After(now) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		scheduleOp := txn.Op{
+			C:      actionSchedulesC,
+			Id:     s.doc.DocId,
+			Assert: bson.D{{"next-run", s.doc.NextRun}},
+		}
+
+		notDead, err := isNotDead(st, receiverCollectionName, receiverId)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if !notDead {
+			actionLogger.Infof("removing action schedule %q for dead unit %q", s.Id(), s.doc.Receiver)
+			scheduleOp.Remove = true
+			return []txn.Op{scheduleOp}, nil
+		}
+
+		doc, ndoc, err := newActionDoc(st, receiver, s.doc.Name, s.doc.Parameters)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if s.doc.Interval == 0 {
+			scheduleOp.Remove = true
+		} else {
+			scheduleOp.Update = bson.D{{"$set", bson.D{
+				{"next-run", nextScheduledRun(s.doc.NextRun, s.doc.Interval, now)},
+				{"last-action-id", st.localID(doc.DocId)},
+			}}}
+		}
+		ran = true
+		ops := enqueueActionOps(receiverCollectionName, receiverId, doc, ndoc)
+		return append(ops, scheduleOp), nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return false, errors.Trace(err)
+	}
+	return ran, nil
+}
+
+// nextScheduledRun returns the first time after now that is a whole
+// number of intervals after the given run time.
+func nextScheduledRun(last time.Time, interval time.Duration, now time.Time) time.Time {
+	missed := now.Sub(last) / interval
+	return last.Add((missed + 1) * interval)
+}
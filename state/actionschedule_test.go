@@ -0,0 +1,140 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+)
+
+func (s *ActionSuite) TestScheduleAction(c *gc.C) {
+	at := s.Clock.Now().Add(time.Hour)
+	schedule, err := s.unit.ScheduleAction("snapshot", map[string]interface{}{"outfile": "out.tar.bz2"}, at, 24*time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.Receiver(), gc.Equals, s.unit.Name())
+	c.Assert(schedule.Name(), gc.Equals, "snapshot")
+	c.Assert(schedule.Parameters(), jc.DeepEquals, map[string]interface{}{"outfile": "out.tar.bz2"})
+	c.Assert(schedule.NextRun().Equal(at.Round(time.Second)), jc.IsTrue)
+	c.Assert(schedule.Interval(), gc.Equals, 24*time.Hour)
+	c.Assert(schedule.LastActionId(), gc.Equals, "")
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 1)
+	c.Assert(schedules[0].Id(), gc.Equals, schedule.Id())
+}
+
+func (s *ActionSuite) TestScheduleActionDefaultStart(c *gc.C) {
+	schedule, err := s.unit.ScheduleAction("snapshot", nil, time.Time{}, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(s.Clock.Now().Add(time.Hour).Round(time.Second)), jc.IsTrue)
+}
+
+func (s *ActionSuite) TestScheduleActionInvalid(c *gc.C) {
+	_, err := s.unit.ScheduleAction("snapshot", nil, time.Time{}, 0)
+	c.Assert(err, gc.ErrorMatches, "schedule with neither start time nor interval not valid")
+
+	_, err = s.unit.ScheduleAction("snapshot", nil, s.Clock.Now(), -time.Hour)
+	c.Assert(err, gc.ErrorMatches, "negative interval not valid")
+
+	_, err = s.unit.ScheduleAction("snapshot", nil, s.Clock.Now(), 30*time.Second)
+	c.Assert(err, gc.ErrorMatches, "interval 30s shorter than 1m0s not valid")
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	_, err = s.unit.ScheduleAction("fakeaction", nil, s.Clock.Now(), 0)
+	c.Assert(err, gc.ErrorMatches, `action "fakeaction" not defined on unit "dummy/0"`)
+
+	_, err = s.unit.ScheduleAction("snapshot", map[string]interface{}{"outfile": 5.0}, s.Clock.Now(), 0)
+	c.Assert(err, gc.ErrorMatches, `validation failed: \(root\)\.outfile : must be of type string, given 5`)
+}
+
+func (s *ActionSuite) TestRunDueActionSchedulesOnce(c *gc.C) {
+	_, err := s.unit.ScheduleAction("snapshot", nil, s.Clock.Now().Add(time.Hour), 0)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Nothing is due yet.
+	count, err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	s.Clock.Advance(time.Hour)
+	count, err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+	c.Assert(pending[0].Name(), gc.Equals, "snapshot")
+
+	// A one-off schedule is removed once it has run.
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestRunDueActionSchedulesRecurring(c *gc.C) {
+	start := s.Clock.Now().Add(time.Minute).Round(time.Second)
+	schedule, err := s.unit.ScheduleAction("snapshot", nil, start, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Missed runs are skipped, rather than enqueued all at once.
+	s.Clock.Advance(3*time.Hour + time.Minute)
+	count, err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 1)
+
+	pending, err := s.unit.PendingActions()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(pending, gc.HasLen, 1)
+
+	schedule, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedule.NextRun().Equal(start.Add(4*time.Hour)), jc.IsTrue)
+	c.Assert(schedule.LastActionId(), gc.Equals, pending[0].Id())
+
+	count, err = s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+}
+
+func (s *ActionSuite) TestRunDueActionSchedulesDeadUnit(c *gc.C) {
+	_, err := s.unit.ScheduleAction("snapshot", nil, s.Clock.Now(), time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+
+	count, err := s.State.RunDueActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(count, gc.Equals, 0)
+
+	schedules, err := s.State.ActionSchedules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(schedules, gc.HasLen, 0)
+}
+
+func (s *ActionSuite) TestScheduleActionDeadUnit(c *gc.C) {
+	err := s.unit.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.unit.ScheduleAction("snapshot", nil, s.Clock.Now(), 0)
+	c.Assert(err, gc.Equals, state.ErrDead)
+}
+
+func (s *ActionSuite) TestRemoveActionSchedule(c *gc.C) {
+	schedule, err := s.unit.ScheduleAction("snapshot", nil, s.Clock.Now(), time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.ActionSchedule(schedule.Id())
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.State.RemoveActionSchedule(schedule.Id())
+	c.Assert(err, gc.ErrorMatches, `action schedule ".*" not found`)
+}
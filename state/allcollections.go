@@ -19,6 +19,13 @@ var (
 	txnLogSizeTests = 1000000
 )
 
+// The capped collection used for audit entries defaults to 300MB, and
+// is similarly reduced for tests.
+var (
+	auditLogSize      = 300 * 1024 * 1024
+	auditLogSizeTests = 1000000
+)
+
 // allCollections should be the single source of truth for information about
 // any collection we use. It's broken up into 4 main sections:
 //
@@ -232,6 +239,10 @@ func allCollections() collectionSchema {
 		// to ensure various IDs aren't reused.
 		sequenceC: {},
 
+		// This collection holds the source CIDRs from which well-known
+		// services, such as SSH, may be reached on the model's machines.
+		firewallRulesC: {},
+
 		// This collection holds lease data. It's currently only used to
 		// implement application leadership, but is namespaced and available
 		// for use by other clients in future.
@@ -347,9 +358,16 @@ func allCollections() collectionSchema {
 		actionsC: {
 			indexes: []mgo.Index{{
 				Key: []string{"model-uuid", "name"},
+			}, {
+				Key: []string{"model-uuid", "completed"},
 			}},
 		},
 		actionNotificationsC: {},
+		actionSchedulesC: {
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "next-run"},
+			}},
+		},
 
 		// -----
 
@@ -412,9 +430,23 @@ func allCollections() collectionSchema {
 
 		// metrics; status-history; logs; ..?
 
+		// This collection holds the audit entries recorded for API
+		// requests made by users. It is capped so that it does not
+		// grow without bound.
 		auditingC: {
 			global:    true,
 			rawAccess: true,
+			explicitCreate: &mgo.CollectionInfo{
+				Capped:   true,
+				MaxBytes: auditLogSize,
+			},
+			indexes: []mgo.Index{{
+				Key: []string{"model-uuid", "created"},
+			}, {
+				Key: []string{"origin-name", "created"},
+			}, {
+				Key: []string{"created"},
+			}},
 		},
 	}
 	if featureflag.Enabled(feature.CrossModelRelations) {
@@ -455,6 +487,7 @@ func allCollections() collectionSchema {
 const (
 	actionNotificationsC     = "actionnotifications"
 	actionresultsC           = "actionresults"
+	actionSchedulesC         = "actionschedules"
 	actionsC                 = "actions"
 	annotationsC             = "annotations"
 	autocertCacheC           = "autocertCache"
@@ -474,6 +507,7 @@ const (
 	controllerUsersC         = "controllerusers"
 	filesystemAttachmentsC   = "filesystemAttachments"
 	filesystemsC             = "filesystems"
+	firewallRulesC           = "firewallRules"
 	globalSettingsC          = "globalSettings"
 	guimetadataC             = "guimetadata"
 	guisettingsC             = "guisettings"
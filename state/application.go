@@ -6,6 +6,7 @@ package state
 import (
 	stderrors "errors"
 	"fmt"
+	"net"
 	"sort"
 	"strconv"
 	"strings"
@@ -14,6 +15,7 @@ import (
 	jujutxn "github.com/juju/txn"
 	"github.com/juju/utils/featureflag"
 	"github.com/juju/utils/series"
+	"github.com/juju/utils/set"
 	"gopkg.in/juju/charm.v6-unstable"
 	csparams "gopkg.in/juju/charmrepo.v2-unstable/csclient/params"
 	"gopkg.in/juju/names.v2"
@@ -24,6 +26,7 @@ import (
 	"github.com/juju/juju/constraints"
 	"github.com/juju/juju/core/leadership"
 	"github.com/juju/juju/feature"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/status"
 )
 
@@ -52,6 +55,45 @@ type applicationDoc struct {
 	MinUnits             int        `bson:"minunits"`
 	TxnRevno             int64      `bson:"txn-revno"`
 	MetricCredentials    []byte     `bson:"metric-credentials"`
+
+	// CharmUpgradeHold records the units held on the application's
+	// previous charm during a rolling charm upgrade.
+	CharmUpgradeHold *charmUpgradeHoldDoc `bson:"charmupgradehold,omitempty"`
+
+	// ExposedEndpoints records the sources from which the open ports
+	// of an exposed application may be accessed. If the application
+	// is exposed and there are no exposed endpoints, the open ports
+	// may be accessed from anywhere.
+	ExposedEndpoints []exposedEndpointDoc `bson:"exposed-endpoints,omitempty"`
+
+	// EgressRules records the destinations and ports to which the
+	// application's machines may send outgoing traffic, in models
+	// where outgoing traffic is denied by default.
+	EgressRules []egressRuleDoc `bson:"egress-rules,omitempty"`
+}
+
+// egressRuleDoc records one egress rule of an application.
+type egressRuleDoc struct {
+	Protocol         string   `bson:"protocol"`
+	FromPort         int      `bson:"from-port"`
+	ToPort           int      `bson:"to-port"`
+	DestinationCIDRs []string `bson:"destination-cidrs"`
+}
+
+// exposedEndpointDoc records the expose settings of one endpoint of
+// an application. The empty endpoint name denotes all endpoints.
+type exposedEndpointDoc struct {
+	Endpoint       string   `bson:"endpoint"`
+	ExposeToSpaces []string `bson:"to-spaces,omitempty"`
+	ExposeToCIDRs  []string `bson:"to-cidrs,omitempty"`
+}
+
+// charmUpgradeHoldDoc records the units of an application that are
+// held on its previous charm during a rolling charm upgrade.
+type charmUpgradeHoldDoc struct {
+	CharmURL             *charm.URL `bson:"charmurl"`
+	CharmModifiedVersion int        `bson:"charmmodifiedversion"`
+	Units                []string   `bson:"units"`
 }
 
 func newApplication(st *State, doc *applicationDoc) *Application {
@@ -288,6 +330,14 @@ func (a *Application) removeOps(asserts bson.D) ([]txn.Op, error) {
 	// so it's safe to do this additonal cleanup.
 	ops = append(ops, finalAppCharmRemoveOps(name, curl)...)
 
+	// By the time we get to here, all units have been removed,
+	// so the application's shared storage has no attachments.
+	storageInstanceOps, err := removeStorageInstancesOps(a.st, a.Tag())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	ops = append(ops, storageInstanceOps...)
+
 	globalKey := a.globalKey()
 	ops = append(ops,
 		removeEndpointBindingsOp(globalKey),
@@ -307,14 +357,51 @@ func (a *Application) IsExposed() bool {
 	return a.doc.Exposed
 }
 
-// SetExposed marks the application as exposed.
-// See ClearExposed and IsExposed.
+// ExposedEndpoint holds the sources from which the open ports of an
+// exposed application endpoint may be accessed.
+type ExposedEndpoint struct {
+	// ExposeToSpaces holds the names of the spaces whose subnets
+	// may access the open ports.
+	ExposeToSpaces []string
+
+	// ExposeToCIDRs holds the CIDRs that may access the open ports.
+	ExposeToCIDRs []string
+}
+
+// ExposedEndpoints returns the expose settings of the application,
+// keyed by endpoint name. The empty endpoint name holds the settings
+// that apply to all endpoints. If the application is not exposed,
+// ExposedEndpoints returns nil. See MergeExposeSettings.
+func (a *Application) ExposedEndpoints() map[string]ExposedEndpoint {
+	if !a.doc.Exposed {
+		return nil
+	}
+	if len(a.doc.ExposedEndpoints) == 0 {
+		// Applications exposed without any settings may
+		// be accessed from anywhere.
+		return map[string]ExposedEndpoint{
+			"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+		}
+	}
+	result := make(map[string]ExposedEndpoint)
+	for _, doc := range a.doc.ExposedEndpoints {
+		result[doc.Endpoint] = ExposedEndpoint{
+			ExposeToSpaces: doc.ExposeToSpaces,
+			ExposeToCIDRs:  doc.ExposeToCIDRs,
+		}
+	}
+	return result
+}
+
+// SetExposed marks the application as exposed, such that its open
+// ports may be accessed from anywhere. Any previous expose settings
+// are discarded. See ClearExposed and IsExposed.
 func (a *Application) SetExposed() error {
 	return a.setExposed(true)
 }
 
-// ClearExposed removes the exposed flag from the application.
-// See SetExposed and IsExposed.
+// ClearExposed removes the exposed flag, and any expose settings,
+// from the application. See SetExposed and IsExposed.
 func (a *Application) ClearExposed() error {
 	return a.setExposed(false)
 }
@@ -324,15 +411,196 @@ func (a *Application) setExposed(exposed bool) (err error) {
 		C:      applicationsC,
 		Id:     a.doc.DocID,
 		Assert: isAliveDoc,
-		Update: bson.D{{"$set", bson.D{{"exposed", exposed}}}},
+		Update: bson.D{
+			{"$set", bson.D{{"exposed", exposed}}},
+			{"$unset", bson.D{{"exposed-endpoints", nil}}},
+		},
 	}}
 	if err := a.st.db().RunTransaction(ops); err != nil {
 		return errors.Errorf("cannot set exposed flag for application %q to %v: %v", a, exposed, onAbort(err, errNotAlive))
 	}
 	a.doc.Exposed = exposed
+	a.doc.ExposedEndpoints = nil
 	return nil
 }
 
+// MergeExposeSettings marks the application as exposed, and replaces
+// the expose settings of each of the given endpoints, leaving those of
+// other endpoints untouched. The empty endpoint name applies settings
+// to all endpoints. An endpoint given no spaces or CIDRs may be
+// accessed from anywhere.
+//
+// Opened ports are not associated with endpoints, so the firewaller
+// could not restrict access to an individual endpoint's ports. Settings
+// for named endpoints are therefore rejected as not supported.
+func (a *Application) MergeExposeSettings(exposedEndpoints map[string]ExposedEndpoint) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set expose settings for application %q", a)
+	if len(exposedEndpoints) == 0 {
+		return errors.New("no expose settings specified")
+	}
+	if err := a.validateExposeSettings(exposedEndpoints); err != nil {
+		return errors.Trace(err)
+	}
+	app := &Application{st: a.st, doc: a.doc}
+	var merged []exposedEndpointDoc
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := app.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		if app.doc.Life != Alive {
+			return nil, errNotAlive
+		}
+		merged = mergeExposedEndpoints(app.doc.ExposedEndpoints, exposedEndpoints)
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     app.doc.DocID,
+			Assert: bson.D{{"life", Alive}, {"txn-revno", app.doc.TxnRevno}},
+			Update: bson.D{{"$set", bson.D{
+				{"exposed", true},
+				{"exposed-endpoints", merged},
+			}}},
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return errors.Trace(err)
+	}
+	a.doc.Exposed = true
+	a.doc.ExposedEndpoints = merged
+	return nil
+}
+
+// validateExposeSettings checks that the settings apply to all
+// endpoints, that the spaces exist, and that the CIDRs are valid.
+func (a *Application) validateExposeSettings(exposedEndpoints map[string]ExposedEndpoint) error {
+	for name, settings := range exposedEndpoints {
+		if name != "" {
+			return errors.NotSupportedf("expose settings for endpoint %q", name)
+		}
+		for _, spaceName := range settings.ExposeToSpaces {
+			if _, err := a.st.Space(spaceName); err != nil {
+				return errors.Trace(err)
+			}
+		}
+		for _, cidr := range settings.ExposeToCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// mergeExposedEndpoints returns the existing expose settings with
+// those of the given endpoints replaced, sorted by endpoint name.
+func mergeExposedEndpoints(existing []exposedEndpointDoc, exposedEndpoints map[string]ExposedEndpoint) []exposedEndpointDoc {
+	var merged []exposedEndpointDoc
+	for _, doc := range existing {
+		if _, ok := exposedEndpoints[doc.Endpoint]; !ok {
+			merged = append(merged, doc)
+		}
+	}
+	for name, settings := range exposedEndpoints {
+		doc := exposedEndpointDoc{
+			Endpoint:       name,
+			ExposeToSpaces: settings.ExposeToSpaces,
+			ExposeToCIDRs:  settings.ExposeToCIDRs,
+		}
+		if len(doc.ExposeToSpaces) == 0 && len(doc.ExposeToCIDRs) == 0 {
+			doc.ExposeToCIDRs = []string{"0.0.0.0/0"}
+		}
+		merged = append(merged, doc)
+	}
+	sort.Sort(exposedEndpointDocSlice(merged))
+	return merged
+}
+
+type exposedEndpointDocSlice []exposedEndpointDoc
+
+func (s exposedEndpointDocSlice) Len() int           { return len(s) }
+func (s exposedEndpointDocSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+func (s exposedEndpointDocSlice) Less(i, j int) bool { return s[i].Endpoint < s[j].Endpoint }
+
+// EgressRules returns the destinations and ports to which the
+// application's machines may send outgoing traffic, sorted by
+// protocol and port.
+func (a *Application) EgressRules() []network.EgressRule {
+	if len(a.doc.EgressRules) == 0 {
+		return nil
+	}
+	result := make([]network.EgressRule, len(a.doc.EgressRules))
+	for i, doc := range a.doc.EgressRules {
+		result[i] = network.EgressRule{
+			PortRange: network.PortRange{
+				Protocol: doc.Protocol,
+				FromPort: doc.FromPort,
+				ToPort:   doc.ToPort,
+			},
+			DestinationCIDRs: doc.DestinationCIDRs,
+		}
+	}
+	return result
+}
+
+// SetEgressRules replaces the application's egress rules. A rule
+// with no destination CIDRs allows outgoing traffic to anywhere.
+// Setting no rules removes all the application's egress rules.
+func (a *Application) SetEgressRules(rules []network.EgressRule) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set egress rules for application %q", a)
+	docs := make([]egressRuleDoc, len(rules))
+	for i, rule := range rules {
+		if err := rule.PortRange.Validate(); err != nil {
+			return errors.Trace(err)
+		}
+		for _, cidr := range rule.DestinationCIDRs {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				return errors.NotValidf("CIDR %q", cidr)
+			}
+		}
+		docs[i] = egressRuleDoc{
+			Protocol:         strings.ToLower(rule.Protocol),
+			FromPort:         rule.FromPort,
+			ToPort:           rule.ToPort,
+			DestinationCIDRs: rule.DestinationCIDRs,
+		}
+		if len(docs[i].DestinationCIDRs) == 0 {
+			docs[i].DestinationCIDRs = []string{"0.0.0.0/0"}
+		}
+	}
+	sort.Sort(egressRuleDocSlice(docs))
+	update := bson.D{{"$set", bson.D{{"egress-rules", docs}}}}
+	if len(docs) == 0 {
+		docs = nil
+		update = bson.D{{"$unset", bson.D{{"egress-rules", nil}}}}
+	}
+	ops := []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Assert: isAliveDoc,
+		Update: update,
+	}}
+	if err := a.st.db().RunTransaction(ops); err != nil {
+		return onAbort(err, errNotAlive)
+	}
+	a.doc.EgressRules = docs
+	return nil
+}
+
+type egressRuleDocSlice []egressRuleDoc
+
+func (s egressRuleDocSlice) Len() int      { return len(s) }
+func (s egressRuleDocSlice) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s egressRuleDocSlice) Less(i, j int) bool {
+	if s[i].Protocol != s[j].Protocol {
+		return s[i].Protocol < s[j].Protocol
+	}
+	if s[i].FromPort != s[j].FromPort {
+		return s[i].FromPort < s[j].FromPort
+	}
+	return s[i].ToPort < s[j].ToPort
+}
+
 // Charm returns the application's charm and whether units should upgrade to that
 // charm even if they are in an error state.
 func (a *Application) Charm() (ch *Charm, force bool, err error) {
@@ -363,6 +631,142 @@ func (a *Application) CharmURL() (curl *charm.URL, force bool) {
 	return a.doc.CharmURL, a.doc.ForceCharm
 }
 
+// CharmURLForUnit returns the charm URL that the named unit should be
+// running, and whether it should upgrade to that charm even if it is in
+// an error state. This is the application's charm URL, unless the unit
+// is held on the previous charm by a rolling charm upgrade.
+func (a *Application) CharmURLForUnit(unitName string) (curl *charm.URL, force bool) {
+	if hold := a.charmUpgradeHold(unitName); hold != nil {
+		return hold.CharmURL, false
+	}
+	return a.doc.CharmURL, a.doc.ForceCharm
+}
+
+// CharmModifiedVersionForUnit returns the charm modified version that
+// applies to the named unit, taking into account any rolling charm
+// upgrade holding it on the previous charm.
+func (a *Application) CharmModifiedVersionForUnit(unitName string) int {
+	if hold := a.charmUpgradeHold(unitName); hold != nil {
+		return hold.CharmModifiedVersion
+	}
+	return a.doc.CharmModifiedVersion
+}
+
+// CharmUpgradeHold returns the charm URL on which units are held by a
+// rolling charm upgrade, and the names of those units. The URL is nil
+// if there is no rolling charm upgrade in progress.
+func (a *Application) CharmUpgradeHold() (*charm.URL, []string) {
+	hold := a.doc.CharmUpgradeHold
+	if hold == nil {
+		return nil, nil
+	}
+	return hold.CharmURL, append([]string(nil), hold.Units...)
+}
+
+// charmUpgradeHold returns the rolling charm upgrade hold if it
+// applies to the named unit, and nil otherwise.
+func (a *Application) charmUpgradeHold(unitName string) *charmUpgradeHoldDoc {
+	hold := a.doc.CharmUpgradeHold
+	if hold == nil {
+		return nil
+	}
+	for _, name := range hold.Units {
+		if name == unitName {
+			return hold
+		}
+	}
+	return nil
+}
+
+// ReleaseCharmUpgrade allows the named units, held on the previous
+// charm by a rolling charm upgrade, to upgrade to the application's
+// charm. Units that are not held are ignored. Once no units remain
+// held, the rolling charm upgrade is complete.
+func (a *Application) ReleaseCharmUpgrade(unitNames ...string) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot release charm upgrade for application %q", a)
+	release := set.NewStrings(unitNames...)
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		if attempt > 0 {
+			if err := a.Refresh(); err != nil {
+				return nil, errors.Trace(err)
+			}
+		}
+		hold := a.doc.CharmUpgradeHold
+		if hold == nil {
+			return nil, jujutxn.ErrNoOperations
+		}
+		var remaining []string
+		for _, name := range hold.Units {
+			if !release.Contains(name) {
+				remaining = append(remaining, name)
+			}
+		}
+		if len(remaining) == len(hold.Units) {
+			return nil, jujutxn.ErrNoOperations
+		}
+		update := bson.D{{"$set", bson.D{{"charmupgradehold.units", remaining}}}}
+		if len(remaining) == 0 {
+			update = bson.D{{"$unset", bson.D{{"charmupgradehold", nil}}}}
+		}
+		return []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Assert: bson.D{{"charmupgradehold.units", hold.Units}},
+			Update: update,
+		}}, nil
+	}
+	if err := a.st.db().Run(buildTxn); err != nil {
+		return err
+	}
+	return a.Refresh()
+}
+
+// charmUpgradeHoldOps returns the operations needed, when changing the
+// application's charm, to hold the existing units on the current charm
+// if rolling is true, or to remove any existing hold if not. It also
+// returns the resulting hold, if any.
+func (a *Application) charmUpgradeHoldOps(rolling bool) (*charmUpgradeHoldDoc, []txn.Op, error) {
+	if !rolling {
+		if a.doc.CharmUpgradeHold == nil {
+			return nil, nil, nil
+		}
+		return nil, []txn.Op{{
+			C:      applicationsC,
+			Id:     a.doc.DocID,
+			Update: bson.D{{"$unset", bson.D{{"charmupgradehold", nil}}}},
+		}}, nil
+	}
+	if a.doc.CharmUpgradeHold != nil {
+		return nil, nil, errors.New("rolling charm upgrade already in progress")
+	}
+	units, err := a.AllUnits()
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	// Only units already running the current charm are held; any
+	// that have yet to install a charm may as well start with the
+	// new one.
+	var held []string
+	for _, unit := range units {
+		if curl, _ := unit.CharmURL(); curl != nil && curl.String() == a.doc.CharmURL.String() {
+			held = append(held, unit.Name())
+		}
+	}
+	if len(held) == 0 {
+		return nil, nil, nil
+	}
+	hold := &charmUpgradeHoldDoc{
+		CharmURL:             a.doc.CharmURL,
+		CharmModifiedVersion: a.doc.CharmModifiedVersion,
+		Units:                held,
+	}
+	return hold, []txn.Op{{
+		C:      applicationsC,
+		Id:     a.doc.DocID,
+		Update: bson.D{{"$set", bson.D{{"charmupgradehold", hold}}}},
+	}}, nil
+}
+
 // Channel identifies the charm store channel from which the application's
 // charm was deployed. It is only needed when interacting with the charm
 // store.
@@ -869,6 +1273,12 @@ type SetCharmConfig struct {
 	// unaffected; the storage constraints will only be used for
 	// provisioning new storage instances.
 	StorageConstraints map[string]StorageConstraints
+
+	// Rolling holds the application's existing units on their current
+	// charm until they are released with ReleaseCharmUpgrade, so that
+	// they can be upgraded a few at a time. Without it, any units
+	// still held by an earlier rolling upgrade are released.
+	Rolling bool
 }
 
 // SetCharm changes the charm for the application.
@@ -934,6 +1344,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	}
 
 	var newCharmModifiedVersion int
+	var newCharmUpgradeHold *charmUpgradeHoldDoc
 	channel := string(cfg.Channel)
 	acopy := &Application{a.st, a.doc}
 	buildTxn := func(attempt int) ([]txn.Op, error) {
@@ -958,6 +1369,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 		// structure. We increment the version only when we change the
 		// charm URL.
 		newCharmModifiedVersion = a.doc.CharmModifiedVersion
+		newCharmUpgradeHold = a.doc.CharmUpgradeHold
 
 		ops := []txn.Op{{
 			C:  applicationsC,
@@ -991,6 +1403,13 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 			}
 			ops = append(ops, chng...)
 			newCharmModifiedVersion++
+
+			hold, holdOps, err := a.charmUpgradeHoldOps(cfg.Rolling)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			ops = append(ops, holdOps...)
+			newCharmUpgradeHold = hold
 		}
 
 		return ops, nil
@@ -1002,6 +1421,7 @@ func (a *Application) SetCharm(cfg SetCharmConfig) (err error) {
 	a.doc.Channel = channel
 	a.doc.ForceCharm = cfg.ForceUnits
 	a.doc.CharmModifiedVersion = newCharmModifiedVersion
+	a.doc.CharmUpgradeHold = newCharmUpgradeHold
 	return nil
 }
 
@@ -1086,6 +1506,11 @@ type applicationAddUnitOpsArgs struct {
 	cons          constraints.Value
 	storageCons   map[string]StorageConstraints
 	attachStorage []names.StorageTag
+
+	// sharedStorage holds the application's shared storage
+	// instances that are being created along with the unit,
+	// and whose attachment counts already account for it.
+	sharedStorage []*storageInstance
 }
 
 // addApplicationUnitOps is just like addUnitOps but explicitly takes a
@@ -1186,6 +1611,38 @@ func (a *Application) addUnitOpsWithCons(args applicationAddUnitOpsArgs) (string
 		numStorageAttachments++
 		storageCounts[si.StorageName()]++
 	}
+
+	// Attach the application's shared storage to the unit. Shared
+	// storage is owned by the application, so it does not count
+	// towards the unit's storage.
+	for _, si := range args.sharedStorage {
+		storageOps = append(storageOps, createStorageAttachmentOp(si.StorageTag(), unitTag))
+		numStorageAttachments++
+	}
+	sharedStorage, err := a.st.storageInstances(bson.D{{"owner", a.Tag().String()}})
+	if err != nil {
+		return "", nil, errors.Trace(err)
+	}
+	for _, si := range sharedStorage {
+		if si.Life() != Alive {
+			continue
+		}
+		ops, err := a.st.attachStorageOps(
+			si,
+			unitTag,
+			a.doc.Series,
+			charm,
+			machineAssignable,
+		)
+		if err != nil {
+			return "", nil, errors.Annotatef(
+				err, "attaching %s",
+				names.ReadableString(si.StorageTag()),
+			)
+		}
+		storageOps = append(storageOps, ops...)
+		numStorageAttachments++
+	}
 	for name, count := range storageCounts {
 		charmStorage := charm.Meta().Storage[name]
 		if err := validateCharmStorageCountChange(charmStorage, 0, count); err != nil {
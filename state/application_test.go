@@ -19,6 +19,7 @@ import (
 	"gopkg.in/mgo.v2/txn"
 
 	"github.com/juju/juju/constraints"
+	"github.com/juju/juju/network"
 	"github.com/juju/juju/resource/resourcetesting"
 	"github.com/juju/juju/state"
 	"github.com/juju/juju/state/testing"
@@ -482,6 +483,92 @@ func (s *ApplicationSuite) TestSetCharmWithDyingService(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 }
 
+func (s *ApplicationSuite) addUnitWithCharm(c *gc.C) *state.Unit {
+	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(s.charm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	return unit
+}
+
+func (s *ApplicationSuite) TestSetCharmRolling(c *gc.C) {
+	unit0 := s.addUnitWithCharm(c)
+	unit1 := s.addUnitWithCharm(c)
+	// A unit yet to install its charm is not held.
+	unit2, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	oldVersion := s.mysql.CharmModifiedVersion()
+
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch, Rolling: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	check := func(app *state.Application) {
+		curl, held := app.CharmUpgradeHold()
+		c.Assert(curl, gc.DeepEquals, s.charm.URL())
+		c.Assert(held, jc.SameContents, []string{unit0.Name(), unit1.Name()})
+
+		curl, _ = app.CharmURLForUnit(unit0.Name())
+		c.Assert(curl, gc.DeepEquals, s.charm.URL())
+		c.Assert(app.CharmModifiedVersionForUnit(unit0.Name()), gc.Equals, oldVersion)
+		curl, _ = app.CharmURLForUnit(unit2.Name())
+		c.Assert(curl, gc.DeepEquals, sch.URL())
+		c.Assert(app.CharmModifiedVersionForUnit(unit2.Name()), gc.Equals, oldVersion+1)
+	}
+	check(s.mysql)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	check(s.mysql)
+}
+
+func (s *ApplicationSuite) TestReleaseCharmUpgrade(c *gc.C) {
+	unit0 := s.addUnitWithCharm(c)
+	unit1 := s.addUnitWithCharm(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: sch, Rolling: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.mysql.ReleaseCharmUpgrade(unit0.Name(), "mysql/99")
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.mysql.CharmURLForUnit(unit0.Name())
+	c.Assert(curl, gc.DeepEquals, sch.URL())
+	curl, held := s.mysql.CharmUpgradeHold()
+	c.Assert(curl, gc.DeepEquals, s.charm.URL())
+	c.Assert(held, jc.DeepEquals, []string{unit1.Name()})
+
+	// Releasing the last held unit completes the upgrade.
+	err = s.mysql.ReleaseCharmUpgrade(unit1.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	curl, held = s.mysql.CharmUpgradeHold()
+	c.Assert(curl, gc.IsNil)
+	c.Assert(held, gc.HasLen, 0)
+
+	// Releasing with no upgrade in progress is a no-op.
+	err = s.mysql.ReleaseCharmUpgrade(unit1.Name())
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *ApplicationSuite) TestSetCharmRollingInProgress(c *gc.C) {
+	s.addUnitWithCharm(c)
+	sch := s.AddMetaCharm(c, "mysql", metaBase, 2)
+	err := s.mysql.SetCharm(state.SetCharmConfig{Charm: sch, Rolling: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	sch3 := s.AddMetaCharm(c, "mysql", metaBase, 3)
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: sch3, Rolling: true})
+	c.Assert(err, gc.ErrorMatches, `cannot upgrade application "mysql" to charm "local:quantal/quantal-mysql-3": rolling charm upgrade already in progress`)
+
+	// Rolling back without --rolling releases the held units.
+	err = s.mysql.SetCharm(state.SetCharmConfig{Charm: s.charm})
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ := s.mysql.CharmUpgradeHold()
+	c.Assert(curl, gc.IsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	curl, _ = s.mysql.CharmUpgradeHold()
+	c.Assert(curl, gc.IsNil)
+}
+
 func (s *ApplicationSuite) TestSequenceUnitIdsAfterDestroy(c *gc.C) {
 	unit, err := s.mysql.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -1393,6 +1480,129 @@ func (s *ApplicationSuite) TestServiceExposed(c *gc.C) {
 	c.Assert(err, gc.ErrorMatches, notAliveErr)
 }
 
+func (s *ApplicationSuite) TestMergeExposeSettings(c *gc.C) {
+	_, err := s.State.AddSpace("office", "", nil, false)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+
+	// An endpoint given no spaces or CIDRs may be accessed from anywhere.
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{"": {}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.IsExposed(), jc.IsTrue)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"office"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"office"}, ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	// Clearing the exposed flag discards the settings.
+	err = s.mysql.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), gc.IsNil)
+
+	// Exposing without settings allows access from anywhere.
+	err = s.mysql.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.ExposedEndpoints(), jc.DeepEquals, map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"0.0.0.0/0"}},
+	})
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsInvalid(c *gc.C) {
+	for i, test := range []struct {
+		settings map[string]state.ExposedEndpoint
+		err      string
+	}{{
+		settings: map[string]state.ExposedEndpoint{"foo": {}},
+		err:      `expose settings for endpoint "foo" not supported`,
+	}, {
+		settings: map[string]state.ExposedEndpoint{"server": {ExposeToCIDRs: []string{"10.0.0.0/8"}}},
+		err:      `expose settings for endpoint "server" not supported`,
+	}, {
+		settings: map[string]state.ExposedEndpoint{"": {ExposeToSpaces: []string{"nowhere"}}},
+		err:      `space "nowhere" not found`,
+	}, {
+		settings: map[string]state.ExposedEndpoint{"": {ExposeToCIDRs: []string{"10.0.0.0"}}},
+		err:      `CIDR "10.0.0.0" not valid`,
+	}, {
+		err: `no expose settings specified`,
+	}} {
+		c.Logf("test %d", i)
+		err := s.mysql.MergeExposeSettings(test.settings)
+		c.Check(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": `+test.err)
+	}
+	c.Assert(s.mysql.IsExposed(), jc.IsFalse)
+}
+
+func (s *ApplicationSuite) TestMergeExposeSettingsNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.MergeExposeSettings(map[string]state.ExposedEndpoint{"": {}})
+	c.Assert(err, gc.ErrorMatches, `cannot set expose settings for application "mysql": not found or not alive`)
+}
+
+func (s *ApplicationSuite) TestSetEgressRules(c *gc.C) {
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("TCP", 5432, 5433, "10.0.0.0/8"),
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	expected := []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+		network.MustNewEgressRule("tcp", 5432, 5433, "10.0.0.0/8"),
+	}
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), jc.DeepEquals, expected)
+
+	// Setting no rules removes them.
+	err = s.mysql.SetEgressRules(nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Refresh()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesInvalid(c *gc.C) {
+	err := s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("icmp", 1, 1),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": invalid protocol "icmp", expected "tcp" or "udp"`)
+
+	err = s.mysql.SetEgressRules([]network.EgressRule{{
+		PortRange:        network.PortRange{Protocol: "tcp", FromPort: 80, ToPort: 80},
+		DestinationCIDRs: []string{"10.0.0.0"},
+	}})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": CIDR "10.0.0.0" not valid`)
+	c.Assert(s.mysql.EgressRules(), gc.IsNil)
+}
+
+func (s *ApplicationSuite) TestSetEgressRulesNotAlive(c *gc.C) {
+	_, err := s.mysql.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.Destroy()
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.mysql.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, gc.ErrorMatches, `cannot set egress rules for application "mysql": not found or not alive`)
+}
+
 func (s *ApplicationSuite) TestAddUnit(c *gc.C) {
 	// Check that principal units can be added on their own.
 	unitZero, err := s.mysql.AddUnit(state.AddUnitParams{})
@@ -0,0 +1,141 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"time"
+
+	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils"
+	"github.com/juju/version"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/audit"
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+	coretesting "github.com/juju/juju/testing"
+)
+
+type auditSuite struct {
+	statetesting.StateSuite
+}
+
+var _ = gc.Suite(&auditSuite{})
+
+func (s *auditSuite) putEntries(c *gc.C, entries ...audit.AuditEntry) {
+	put := s.State.PutAuditEntryFn()
+	for _, entry := range entries {
+		err := put(entry)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+}
+
+func (s *auditSuite) entry(origin, operation string, timestamp time.Time) audit.AuditEntry {
+	return audit.AuditEntry{
+		JujuServerVersion: version.MustParse("2.3.0"),
+		ModelUUID:         s.State.ModelUUID(),
+		Timestamp:         timestamp,
+		RemoteAddress:     "10.0.0.1",
+		OriginType:        "user",
+		OriginName:        origin,
+		Operation:         operation,
+		Data:              map[string]interface{}{"request-id": 1},
+	}
+}
+
+func (s *auditSuite) TestAuditEntries(c *gc.C) {
+	t0 := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	first := s.entry("user-admin", "Client:v1 - FullStatus", t0)
+	second := s.entry("user-bob", "Application:v4 - Deploy", t0.Add(time.Minute))
+	third := s.entry("user-admin", "Application:v4 - Destroy", t0.Add(2*time.Minute))
+	other := s.entry("user-admin", "Client:v1 - FullStatus", t0.Add(3*time.Minute))
+	other.ModelUUID = utils.MustNewUUID().String()
+	s.putEntries(c, first, second, third, other)
+
+	for i, test := range []struct {
+		about    string
+		filter   audit.Filter
+		expected []audit.AuditEntry
+	}{{
+		about:    "no filter",
+		expected: []audit.AuditEntry{first, second, third, other},
+	}, {
+		about:    "by user",
+		filter:   audit.Filter{OriginName: "user-bob"},
+		expected: []audit.AuditEntry{second},
+	}, {
+		about:    "by model",
+		filter:   audit.Filter{ModelUUID: s.State.ModelUUID()},
+		expected: []audit.AuditEntry{first, second, third},
+	}, {
+		about:    "by operation",
+		filter:   audit.Filter{Operation: "application"},
+		expected: []audit.AuditEntry{second, third},
+	}, {
+		about: "by time range",
+		filter: audit.Filter{
+			After:  t0,
+			Before: t0.Add(3 * time.Minute),
+		},
+		expected: []audit.AuditEntry{second, third},
+	}, {
+		about:    "limited",
+		filter:   audit.Filter{OriginName: "user-admin", Limit: 2},
+		expected: []audit.AuditEntry{third, other},
+	}} {
+		c.Logf("test %d: %s", i, test.about)
+		entries, err := s.State.AuditEntries(test.filter)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(entries, gc.HasLen, len(test.expected))
+		for j, entry := range entries {
+			expected := test.expected[j]
+			c.Check(entry.Timestamp.Equal(expected.Timestamp), jc.IsTrue)
+			entry.Timestamp = expected.Timestamp
+			c.Check(entry, jc.DeepEquals, expected)
+		}
+	}
+}
+
+func (s *auditSuite) TestAuditEntriesInvalidFilter(c *gc.C) {
+	_, err := s.State.AuditEntries(audit.Filter{ModelUUID: "foo"})
+	c.Assert(err, gc.ErrorMatches, `ModelUUID "foo" not valid`)
+}
+
+func (s *auditSuite) TestAuditTailer(c *gc.C) {
+	t0 := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	old := s.entry("user-admin", "Client:v1 - FullStatus", t0)
+	first := s.entry("user-admin", "Client:v1 - FullStatus", t0.Add(time.Minute))
+	other := s.entry("user-admin", "Client:v1 - FullStatus", t0.Add(2*time.Minute))
+	other.ModelUUID = utils.MustNewUUID().String()
+	s.putEntries(c, old, first, other)
+
+	tailer, err := state.NewAuditTailer(s.State, state.AuditTailerParams{
+		StartTime: t0.Add(time.Second),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	s.assertTailed(c, tailer, first)
+
+	second := s.entry("user-bob", "Application:v4 - Deploy", t0.Add(3*time.Minute))
+	s.putEntries(c, second)
+	s.assertTailed(c, tailer, second)
+
+	err = tailer.Stop()
+	c.Assert(err, jc.ErrorIsNil)
+	_, ok := <-tailer.Entries()
+	c.Assert(ok, jc.IsFalse)
+}
+
+func (s *auditSuite) assertTailed(c *gc.C, tailer state.AuditTailer, expected audit.AuditEntry) {
+	select {
+	case entry, ok := <-tailer.Entries():
+		c.Assert(ok, jc.IsTrue)
+		c.Check(entry.Timestamp.Equal(expected.Timestamp), jc.IsTrue)
+		entry.Timestamp = expected.Timestamp
+		c.Check(entry, jc.DeepEquals, expected)
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for audit entry")
+	}
+}
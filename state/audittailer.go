@@ -0,0 +1,191 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/audit"
+	stateaudit "github.com/juju/juju/state/internal/audit"
+)
+
+// AuditTailer allows for retrieval of the audit entries recorded for
+// a model. It first returns any matching entries already recorded,
+// and then waits for additional entries as they are recorded.
+type AuditTailer interface {
+	// Entries returns the channel through which the AuditTailer
+	// returns audit entries. It will be closed when the tailer stops.
+	Entries() <-chan audit.AuditEntry
+
+	// Dying returns a channel which will be closed as the
+	// AuditTailer stops.
+	Dying() <-chan struct{}
+
+	// Stop is used to request that the AuditTailer stops. It blocks
+	// until the AuditTailer has stopped.
+	Stop() error
+
+	// Err returns the error that caused the AuditTailer to stop. If
+	// it hasn't stopped or stopped without error nil will be
+	// returned.
+	Err() error
+}
+
+// AuditTailerParams specifies the audit entries an AuditTailer
+// should return.
+type AuditTailerParams struct {
+	// StartTime, if set, causes only entries recorded at or after
+	// this time to be returned.
+	StartTime time.Time
+}
+
+// auditTailTimeout is how long the tailer waits for new entries
+// before checking whether it should stop.
+var auditTailTimeout = 5 * time.Second
+
+// auditTailRetryDelay is how long the tailer waits before querying
+// again when the tailable cursor is not valid, which happens when
+// there were no matching entries.
+var auditTailRetryDelay = time.Second
+
+// NewAuditTailer returns an AuditTailer which returns the audit
+// entries recorded for the model.
+func NewAuditTailer(st ModelSessioner, params AuditTailerParams) (AuditTailer, error) {
+	session := st.MongoSession().Copy()
+	t := &auditTailer{
+		modelUUID: st.ModelUUID(),
+		session:   session,
+		coll:      session.DB(jujuDB).C(auditingC),
+		params:    params,
+		entryCh:   make(chan audit.AuditEntry),
+	}
+	go func() {
+		err := t.loop()
+		t.tomb.Kill(errors.Cause(err))
+		close(t.entryCh)
+		session.Close()
+		t.tomb.Done()
+	}()
+	return t, nil
+}
+
+type auditTailer struct {
+	tomb      tomb.Tomb
+	modelUUID string
+	session   *mgo.Session
+	coll      *mgo.Collection
+	params    AuditTailerParams
+	entryCh   chan audit.AuditEntry
+}
+
+// Entries implements the AuditTailer interface.
+func (t *auditTailer) Entries() <-chan audit.AuditEntry {
+	return t.entryCh
+}
+
+// Dying implements the AuditTailer interface.
+func (t *auditTailer) Dying() <-chan struct{} {
+	return t.tomb.Dying()
+}
+
+// Stop implements the AuditTailer interface.
+func (t *auditTailer) Stop() error {
+	t.tomb.Kill(nil)
+	return t.tomb.Wait()
+}
+
+// Err implements the AuditTailer interface.
+func (t *auditTailer) Err() error {
+	return t.tomb.Err()
+}
+
+func (t *auditTailer) query(created bson.D) *mgo.Iter {
+	sel := bson.D{{"model-uuid", t.modelUUID}}
+	if len(created) > 0 {
+		sel = append(sel, bson.DocElem{"created", created})
+	}
+	// The audit log is a capped collection, so a tailable cursor
+	// returns entries in insertion order and then waits for more.
+	return t.coll.Find(sel).Sort("$natural").Tail(auditTailTimeout)
+}
+
+func (t *auditTailer) loop() error {
+	// NOTE: don't trace or annotate the errors returned
+	// from this method as the error may be tomb.ErrDying, and
+	// the tomb code is sensitive about equality.
+	var created bson.D
+	if !t.params.StartTime.IsZero() {
+		created = bson.D{{"$gte", t.params.StartTime.UnixNano()}}
+	}
+	iter := t.query(created)
+	defer func() {
+		iter.Close()
+	}()
+
+	// Several entries may be recorded with the same timestamp, so
+	// when querying again the tailer resumes from the timestamp of
+	// the last entry it returned, and skips the entries with that
+	// timestamp that it has already returned.
+	var lastCreated int64
+	seen := make(map[bson.ObjectId]bool)
+	var raw bson.Raw
+	for {
+		for iter.Next(&raw) {
+			var doc struct {
+				Id      bson.ObjectId `bson:"_id"`
+				Created int64         `bson:"created"`
+			}
+			if err := raw.Unmarshal(&doc); err != nil {
+				logger.Warningf("audit entry deserialization failed (possible DB corruption), %v", err)
+				continue
+			}
+			if seen[doc.Id] {
+				continue
+			}
+			entry, err := stateaudit.DecodeAuditEntry(raw)
+			if err != nil {
+				logger.Warningf("audit entry deserialization failed (possible DB corruption), %v", err)
+				continue
+			}
+			select {
+			case <-t.tomb.Dying():
+				return tomb.ErrDying
+			case t.entryCh <- entry:
+				if doc.Created != lastCreated {
+					lastCreated = doc.Created
+					seen = make(map[bson.ObjectId]bool)
+				}
+				seen[doc.Id] = true
+				created = bson.D{{"$gte", lastCreated}}
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return errors.Trace(err)
+		}
+		if !iter.Timeout() {
+			// The cursor is no longer valid, so we must wait a
+			// little and query again from where we left off.
+			if err := iter.Close(); err != nil {
+				return errors.Trace(err)
+			}
+			select {
+			case <-t.tomb.Dying():
+				return tomb.ErrDying
+			case <-time.After(auditTailRetryDelay):
+			}
+			iter = t.query(created)
+			continue
+		}
+		select {
+		case <-t.tomb.Dying():
+			return tomb.ErrDying
+		default:
+		}
+	}
+}
@@ -81,11 +81,22 @@ func StoreArchive(stor filestorage.FileStorage, meta *Metadata, file io.Reader)
 	return nil
 }
 
+// CreateOptions holds the optional settings for creating a backup.
+type CreateOptions struct {
+	// EncryptionKey, if set, is used to encrypt the backup archive
+	// before it is stored.
+	EncryptionKey string
+
+	// Remote, if set, is the storage to which a copy of the archive
+	// is uploaded once it has been stored.
+	Remote RemoteStorage
+}
+
 // Backups is an abstraction around all juju backup-related functionality.
 type Backups interface {
 	// Create creates and stores a new juju backup archive. It updates
 	// the provided metadata.
-	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error
+	Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, opts CreateOptions) error
 
 	// Add stores the backup archive and returns its new ID.
 	Add(archive io.Reader, meta *Metadata) (string, error)
@@ -119,7 +130,7 @@ func NewBackups(stor filestorage.FileStorage) Backups {
 
 // Create creates and stores a new juju backup archive and updates the
 // provided metadata.
-func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
+func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo, opts CreateOptions) error {
 	// TODO(fwereade): 2016-03-17 lp:1558657
 	meta.Started = time.Now().UTC()
 
@@ -147,6 +158,13 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 	if err != nil {
 		return errors.Annotate(err, "while creating backup archive")
 	}
+	if opts.EncryptionKey != "" {
+		result, err = encryptResult(result, opts.EncryptionKey)
+		if err != nil {
+			return errors.Annotate(err, "while encrypting backup archive")
+		}
+		meta.Encrypted = true
+	}
 	defer result.archiveFile.Close()
 
 	// Finalize the metadata.
@@ -161,9 +179,25 @@ func (b *backups) Create(meta *Metadata, paths *Paths, dbInfo *DBInfo) error {
 		return errors.Annotate(err, "while storing backup archive")
 	}
 
+	if opts.Remote != nil {
+		if err := b.upload(opts.Remote, meta); err != nil {
+			return errors.Annotate(err, "while uploading backup archive")
+		}
+	}
+
 	return nil
 }
 
+// upload copies the stored archive for the backup to remote storage.
+func (b *backups) upload(remote RemoteStorage, meta *Metadata) error {
+	_, archive, err := b.storage.Get(meta.ID())
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer archive.Close()
+	return errors.Trace(remote.Put(remoteArchiveName(meta), archive, meta.Size()))
+}
+
 // Add stores the backup archive and returns its new ID.
 func (b *backups) Add(archive io.Reader, meta *Metadata) (string, error) {
 	// Store the archive.
@@ -75,8 +75,13 @@ func (b *backups) Restore(backupId string, dbInfo *DBInfo, args RestoreArgs) (na
 	}
 
 	defer backupReader.Close()
+	archive, err := decryptedArchive(meta, backupReader, args.EncryptionKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer archive.Close()
 
-	workspace, err := NewArchiveWorkspaceReader(backupReader)
+	workspace, err := NewArchiveWorkspaceReader(archive)
 	if err != nil {
 		return nil, errors.Annotate(err, "cannot unpack backup file")
 	}
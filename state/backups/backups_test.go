@@ -5,11 +5,13 @@ package backups_test
 
 import (
 	"bytes"
+	"io"
 	"io/ioutil"
 	"time" // Only used for time types.
 
 	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
+	"github.com/juju/utils/filestorage"
 	"github.com/juju/utils/set"
 	gc "gopkg.in/check.v1"
 
@@ -58,7 +60,7 @@ func (s *backupsSuite) checkFailure(c *gc.C, expected string) {
 	dbInfo := backups.DBInfo{"a", "b", "c", targets, mongo.Mongo32wt}
 	meta := backupstesting.NewMetadataStarted()
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.CreateOptions{})
 
 	c.Check(err, gc.ErrorMatches, expected)
 }
@@ -98,7 +100,7 @@ func (s *backupsSuite) TestCreateOkay(c *gc.C) {
 	meta := backupstesting.NewMetadataStarted()
 	backupstesting.SetOrigin(meta, "<model ID>", "<machine ID>", "<hostname>")
 	meta.Notes = "some notes"
-	err := s.api.Create(meta, &paths, &dbInfo)
+	err := s.api.Create(meta, &paths, &dbInfo, backups.CreateOptions{})
 
 	// Test the call values.
 	s.Storage.CheckCalled(c, "spam", meta, archiveFile, "Add", "Metadata")
@@ -188,3 +190,148 @@ func (s *backupsSuite) TestStoreArchive(c *gc.C) {
 	c.Assert(meta.ID(), gc.Equals, "spam")
 	c.Assert(meta.Stored(), jc.DeepEquals, stored)
 }
+
+type fakeRemoteStorage struct {
+	name string
+	data string
+	size int64
+	err  error
+}
+
+func (r *fakeRemoteStorage) Put(name string, archive io.Reader, size int64) error {
+	data, err := ioutil.ReadAll(archive)
+	if err != nil {
+		return err
+	}
+	r.name, r.data, r.size = name, string(data), size
+	return r.err
+}
+
+func (s *backupsSuite) patchCreate(c *gc.C, archive string) {
+	archiveFile := ioutil.NopCloser(bytes.NewBufferString(archive))
+	result := backups.NewTestCreateResult(archiveFile, int64(len(archive)), "<checksum>")
+	_, testCreate := backups.NewTestCreate(result)
+	s.PatchValue(backups.RunCreate, testCreate)
+	s.PatchValue(backups.TestGetFilesToBackUp, func(root string, paths *backups.Paths, oldmachine string) ([]string, error) {
+		return []string{"<some file>"}, nil
+	})
+	s.PatchValue(backups.GetDBDumper, func(info *backups.DBInfo) (backups.DBDumper, error) {
+		return nil, nil
+	})
+}
+
+func (s *backupsSuite) createWithOptions(c *gc.C, opts backups.CreateOptions) (*backups.Metadata, error) {
+	paths := backups.Paths{DataDir: "/var/lib/juju"}
+	dbInfo := backups.DBInfo{"a", "b", "c", set.NewStrings("juju"), mongo.Mongo32wt}
+	meta := backupstesting.NewMetadataStarted()
+	err := s.api.Create(meta, &paths, &dbInfo, opts)
+	return meta, err
+}
+
+func (s *backupsSuite) TestCreateEncrypted(c *gc.C) {
+	s.patchCreate(c, "<compressed tarball>")
+	// The encrypted archive is closed once Create returns, so read it
+	// as it is stored.
+	var data []byte
+	s.PatchValue(backups.StoreArchiveRef, func(stor filestorage.FileStorage, meta *backups.Metadata, file io.Reader) error {
+		var err error
+		data, err = ioutil.ReadAll(file)
+		return err
+	})
+
+	meta, err := s.createWithOptions(c, backups.CreateOptions{EncryptionKey: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(meta.Encrypted, jc.IsTrue)
+	c.Check(meta.Checksum(), gc.Not(gc.Equals), "<checksum>")
+	c.Check(meta.Size(), gc.Equals, int64(len(data)))
+
+	var decrypted bytes.Buffer
+	err = backups.DecryptArchive(&decrypted, bytes.NewReader(data), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted.String(), gc.Equals, "<compressed tarball>")
+}
+
+func (s *backupsSuite) TestCreateUpload(c *gc.C) {
+	s.patchCreate(c, "<compressed tarball>")
+	s.setStored("spam")
+	s.Storage.File = ioutil.NopCloser(bytes.NewBufferString("<stored tarball>"))
+	remote := &fakeRemoteStorage{}
+
+	meta, err := s.createWithOptions(c, backups.CreateOptions{Remote: remote})
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Check(s.Storage.Calls, jc.DeepEquals, []string{"Add", "Metadata", "Get"})
+	c.Check(s.Storage.IDArg, gc.Equals, "spam")
+	c.Check(remote.name, gc.Equals, meta.Started.Format(backups.FilenameTemplate))
+	c.Check(remote.data, gc.Equals, "<stored tarball>")
+	c.Check(remote.size, gc.Equals, meta.Size())
+}
+
+func (s *backupsSuite) TestCreateUploadFailure(c *gc.C) {
+	s.patchCreate(c, "<compressed tarball>")
+	s.setStored("spam")
+	s.Storage.File = ioutil.NopCloser(bytes.NewBufferString("<stored tarball>"))
+	remote := &fakeRemoteStorage{err: errors.New("failed!")}
+
+	_, err := s.createWithOptions(c, backups.CreateOptions{Remote: remote})
+	c.Check(err, gc.ErrorMatches, "while uploading backup archive: failed!")
+}
+
+func (s *backupsSuite) TestCreateEncryptedRoundTrip(c *gc.C) {
+	archive, err := backupstesting.NewArchiveBasic(backupstesting.NewMetadata())
+	c.Assert(err, jc.ErrorIsNil)
+	s.patchCreate(c, archive.String())
+	var data []byte
+	s.PatchValue(backups.StoreArchiveRef, func(stor filestorage.FileStorage, meta *backups.Metadata, file io.Reader) error {
+		var err error
+		data, err = ioutil.ReadAll(file)
+		return err
+	})
+
+	meta, err := s.createWithOptions(c, backups.CreateOptions{EncryptionKey: "sekrit"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(meta.Encrypted, jc.IsTrue)
+
+	// Restore opens the stored archive in the same way.
+	decrypted, err := backups.DecryptedArchive(meta, bytes.NewReader(data), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	defer decrypted.Close()
+	restored, err := ioutil.ReadAll(decrypted)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(restored), gc.Equals, archive.String())
+
+	ad, err := backups.NewArchiveDataReader(bytes.NewReader(restored))
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = ad.Metadata()
+	c.Check(err, jc.ErrorIsNil)
+}
+
+func (s *backupsSuite) TestDecryptedArchiveWrongKey(c *gc.C) {
+	var encrypted bytes.Buffer
+	err := backups.EncryptArchive(&encrypted, bytes.NewBufferString("<compressed tarball>"), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	meta := backupstesting.NewMetadata()
+	meta.Encrypted = true
+
+	_, err = backups.DecryptedArchive(meta, &encrypted, "wrong")
+	c.Check(err, gc.ErrorMatches, `while decrypting backup archive: backup archive failed authentication \(wrong key\?\)`)
+}
+
+func (s *backupsSuite) TestDecryptedArchiveMissingKey(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+	meta.Encrypted = true
+
+	_, err := backups.DecryptedArchive(meta, bytes.NewBufferString("<encrypted>"), "")
+	c.Check(err, gc.ErrorMatches, `backup ".*" is encrypted; the encryption key is needed to restore it`)
+}
+
+func (s *backupsSuite) TestDecryptedArchiveNotEncrypted(c *gc.C) {
+	meta := backupstesting.NewMetadata()
+
+	archive, err := backups.DecryptedArchive(meta, bytes.NewBufferString("<compressed tarball>"), "")
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadAll(archive)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(string(data), gc.Equals, "<compressed tarball>")
+}
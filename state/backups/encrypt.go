@@ -0,0 +1,255 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"bufio"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"hash"
+	"io"
+	"io/ioutil"
+	"os"
+
+	"github.com/juju/errors"
+	jujuhash "github.com/juju/utils/hash"
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// EncryptedFilenameSuffix is appended to the filename of backup
+// archives that have been encrypted.
+const EncryptedFilenameSuffix = ".enc"
+
+// The layout of an encrypted backup archive is:
+//
+//	magic | salt | iv | AES-256-CTR ciphertext | HMAC-SHA256
+//
+// The encryption and MAC keys are derived from the user-supplied key
+// using PBKDF2, with the salt stored in the archive. The MAC covers
+// everything that precedes it.
+const (
+	encryptedMagic   = "JUJUBKP1"
+	saltSize         = 32
+	keyIterations    = 100000
+	encryptionKeyLen = 32
+	macKeyLen        = 32
+)
+
+var headerSize = len(encryptedMagic) + saltSize + aes.BlockSize
+
+// IsEncryptedArchive reports whether the archive starts with the
+// header written by EncryptArchive. The archive is read from its
+// current position, so callers will usually want to seek back
+// afterwards.
+func IsEncryptedArchive(archive io.Reader) (bool, error) {
+	magic := make([]byte, len(encryptedMagic))
+	if _, err := io.ReadFull(archive, magic); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return false, nil
+		}
+		return false, errors.Trace(err)
+	}
+	return string(magic) == encryptedMagic, nil
+}
+
+func deriveKeys(key string, salt []byte) (encKey, macKey []byte) {
+	derived := pbkdf2.Key([]byte(key), salt, keyIterations, encryptionKeyLen+macKeyLen, sha256.New)
+	return derived[:encryptionKeyLen], derived[encryptionKeyLen:]
+}
+
+// EncryptArchive writes an encrypted copy of the archive to dst,
+// using the provided key.
+func EncryptArchive(dst io.Writer, archive io.Reader, key string) error {
+	if key == "" {
+		return errors.New("missing encryption key")
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, encryptedMagic)
+	salt := header[len(encryptedMagic) : len(encryptedMagic)+saltSize]
+	iv := header[len(encryptedMagic)+saltSize:]
+	if _, err := io.ReadFull(rand.Reader, header[len(encryptedMagic):]); err != nil {
+		return errors.Annotate(err, "while generating salt")
+	}
+	encKey, macKey := deriveKeys(key, salt)
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	out := io.MultiWriter(dst, mac)
+	if _, err := out.Write(header); err != nil {
+		return errors.Trace(err)
+	}
+	writer := &cipher.StreamWriter{S: cipher.NewCTR(block, iv), W: out}
+	if _, err := io.Copy(writer, archive); err != nil {
+		return errors.Annotate(err, "while encrypting archive")
+	}
+	if _, err := dst.Write(mac.Sum(nil)); err != nil {
+		return errors.Trace(err)
+	}
+	return nil
+}
+
+// DecryptArchive writes the decrypted contents of an archive written
+// by EncryptArchive to dst. The archive is authenticated only once it
+// has been read in full, so if an error is returned anything written
+// to dst must be discarded.
+func DecryptArchive(dst io.Writer, archive io.Reader, key string) error {
+	if key == "" {
+		return errors.New("missing encryption key")
+	}
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(archive, header); err != nil {
+		return errors.Annotate(err, "while reading encryption header")
+	}
+	if string(header[:len(encryptedMagic)]) != encryptedMagic {
+		return errors.New("backup archive is not encrypted")
+	}
+	salt := header[len(encryptedMagic) : len(encryptedMagic)+saltSize]
+	iv := header[len(encryptedMagic)+saltSize:]
+	encKey, macKey := deriveKeys(key, salt)
+
+	block, err := aes.NewCipher(encKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mac := hmac.New(sha256.New, macKey)
+	mac.Write(header)
+
+	// The MAC sits at the end of the archive, so hold back enough of
+	// the stream to be sure it is not decrypted as content.
+	body := &macTrailerReader{r: bufio.NewReader(archive), mac: mac}
+	reader := &cipher.StreamReader{S: cipher.NewCTR(block, iv), R: body}
+	if _, err := io.Copy(dst, reader); err != nil {
+		return errors.Annotate(err, "while decrypting archive")
+	}
+	if len(body.trailer) != sha256.Size || !hmac.Equal(body.trailer, mac.Sum(nil)) {
+		return errors.New("backup archive failed authentication (wrong key?)")
+	}
+	return nil
+}
+
+// macTrailerReader passes through everything it reads, except for the
+// final sha256.Size bytes of the stream, which it keeps in trailer.
+// Everything passed through is also written to the MAC.
+type macTrailerReader struct {
+	r       *bufio.Reader
+	mac     hash.Hash
+	trailer []byte
+	eof     bool
+}
+
+// Read implements io.Reader.
+func (m *macTrailerReader) Read(p []byte) (int, error) {
+	if m.eof {
+		return 0, io.EOF
+	}
+	// Peek far enough ahead that we always know whether the bytes we
+	// hand out belong to the trailer.
+	want := len(p) + sha256.Size
+	if want > m.r.Size() {
+		want = m.r.Size()
+	}
+	buf, err := m.r.Peek(want)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return 0, errors.Trace(err)
+	}
+	n := len(buf) - sha256.Size
+	if err == io.EOF {
+		m.eof = true
+		if n < 0 {
+			m.trailer = append([]byte(nil), buf...)
+			return 0, io.EOF
+		}
+		m.trailer = append([]byte(nil), buf[n:]...)
+	}
+	if n <= 0 {
+		return 0, nil
+	}
+	if n > len(p) {
+		n = len(p)
+	}
+	copy(p, buf[:n])
+	m.mac.Write(p[:n])
+	if _, err := m.r.Discard(n); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return n, nil
+}
+
+// encryptResult replaces the archive in the result with an encrypted
+// copy, updating the size and checksum to match.
+func encryptResult(result *createResult, key string) (_ *createResult, err error) {
+	defer result.archiveFile.Close()
+
+	encrypted, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating encrypted archive")
+	}
+	// As with the unencrypted archive, the file is removed straight
+	// away and we keep the open handle.
+	os.Remove(encrypted.Name())
+	defer func() {
+		if err != nil {
+			encrypted.Close()
+		}
+	}()
+
+	hasher := jujuhash.NewHashingWriter(encrypted, sha1.New())
+	if err := EncryptArchive(hasher, result.archiveFile, key); err != nil {
+		return nil, errors.Trace(err)
+	}
+	info, err := encrypted.Stat()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err := encrypted.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &createResult{
+		archiveFile: encrypted,
+		size:        info.Size(),
+		checksum:    hasher.Base64Sum(),
+	}, nil
+}
+
+// decryptedArchive returns the archive for the backup with the given
+// metadata, decrypted with the key if the backup is encrypted. The
+// returned archive must be closed by the caller.
+func decryptedArchive(meta *Metadata, archive io.Reader, key string) (_ io.ReadCloser, err error) {
+	if !meta.Encrypted {
+		return ioutil.NopCloser(archive), nil
+	}
+	if key == "" {
+		return nil, errors.Errorf("backup %q is encrypted; the encryption key is needed to restore it", meta.ID())
+	}
+
+	decrypted, err := ioutil.TempFile("", tempPrefix)
+	if err != nil {
+		return nil, errors.Annotate(err, "while creating decrypted archive")
+	}
+	// As with the encrypted archive, the file is removed straight
+	// away and we keep the open handle.
+	os.Remove(decrypted.Name())
+	defer func() {
+		if err != nil {
+			decrypted.Close()
+		}
+	}()
+
+	if err := DecryptArchive(decrypted, archive, key); err != nil {
+		return nil, errors.Annotate(err, "while decrypting backup archive")
+	}
+	if _, err := decrypted.Seek(0, os.SEEK_SET); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return decrypted, nil
+}
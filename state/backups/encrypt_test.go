@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type encryptSuite struct {
+	testing.IsolationSuite
+}
+
+var _ = gc.Suite(&encryptSuite{})
+
+func (s *encryptSuite) encrypt(c *gc.C, data, key string) []byte {
+	var buf bytes.Buffer
+	err := backups.EncryptArchive(&buf, strings.NewReader(data), key)
+	c.Assert(err, jc.ErrorIsNil)
+	return buf.Bytes()
+}
+
+func (s *encryptSuite) TestRoundTrip(c *gc.C) {
+	// Use enough data to span several reads of the decrypting reader.
+	data := strings.Repeat("<compressed tarball>", 5000)
+	encrypted := s.encrypt(c, data, "sekrit")
+	c.Assert(bytes.Contains(encrypted, []byte("<compressed tarball>")), jc.IsFalse)
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted.String(), gc.Equals, data)
+}
+
+func (s *encryptSuite) TestRoundTripEmpty(c *gc.C) {
+	encrypted := s.encrypt(c, "", "sekrit")
+
+	var decrypted bytes.Buffer
+	err := backups.DecryptArchive(&decrypted, bytes.NewReader(encrypted), "sekrit")
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(decrypted.Len(), gc.Equals, 0)
+}
+
+func (s *encryptSuite) TestEncryptMissingKey(c *gc.C) {
+	err := backups.EncryptArchive(&bytes.Buffer{}, strings.NewReader("data"), "")
+	c.Check(err, gc.ErrorMatches, "missing encryption key")
+}
+
+func (s *encryptSuite) TestDecryptWrongKey(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+
+	err := backups.DecryptArchive(&bytes.Buffer{}, bytes.NewReader(encrypted), "wrong")
+	c.Check(err, gc.ErrorMatches, `backup archive failed authentication \(wrong key\?\)`)
+}
+
+func (s *encryptSuite) TestDecryptTampered(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+	encrypted[len(encrypted)/2] ^= 0xff
+
+	err := backups.DecryptArchive(&bytes.Buffer{}, bytes.NewReader(encrypted), "sekrit")
+	c.Check(err, gc.ErrorMatches, `backup archive failed authentication \(wrong key\?\)`)
+}
+
+func (s *encryptSuite) TestDecryptTruncated(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+
+	err := backups.DecryptArchive(&bytes.Buffer{}, bytes.NewReader(encrypted[:len(encrypted)-40]), "sekrit")
+	c.Check(err, gc.ErrorMatches, `backup archive failed authentication \(wrong key\?\)`)
+}
+
+func (s *encryptSuite) TestDecryptNotEncrypted(c *gc.C) {
+	data := strings.Repeat("x", 100)
+
+	err := backups.DecryptArchive(&bytes.Buffer{}, strings.NewReader(data), "sekrit")
+	c.Check(err, gc.ErrorMatches, "backup archive is not encrypted")
+}
+
+func (s *encryptSuite) TestIsEncryptedArchive(c *gc.C) {
+	encrypted := s.encrypt(c, "<compressed tarball>", "sekrit")
+
+	ok, err := backups.IsEncryptedArchive(bytes.NewReader(encrypted))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsTrue)
+
+	ok, err = backups.IsEncryptedArchive(strings.NewReader("<compressed tarball>"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsFalse)
+
+	ok, err = backups.IsEncryptedArchive(strings.NewReader(""))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(ok, jc.IsFalse)
+}
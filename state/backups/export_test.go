@@ -30,6 +30,7 @@ var (
 	RunCommand            = &runCommandFn
 	ReplaceableFolders    = &replaceableFolders
 	MongoInstalledVersion = &mongoInstalledVersion
+	DecryptedArchive      = decryptedArchive
 )
 
 var _ filestorage.DocStorage = (*backupsDocStorage)(nil)
@@ -68,6 +68,10 @@ type Metadata struct {
 	// Notes is an optional user-supplied annotation.
 	Notes string
 
+	// Encrypted records whether the stored archive was encrypted with
+	// a user-supplied key.
+	Encrypted bool
+
 	// TODO(wallyworld) - remove these ASAP
 	// These are only used by the restore CLI when re-bootstrapping.
 	// We will use a better solution but the way restore currently
@@ -16,4 +16,8 @@ type RestoreArgs struct {
 	NewInstId      instance.Id
 	NewInstTag     names.Tag
 	NewInstSeries  string
+
+	// EncryptionKey is used to decrypt the backup archive, if it
+	// is encrypted.
+	EncryptionKey string
 }
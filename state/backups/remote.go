@@ -0,0 +1,85 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"net/url"
+	"sync"
+
+	"github.com/juju/errors"
+)
+
+// RemoteStorage is a backup storage target outside of the controller,
+// to which copies of backup archives may be uploaded.
+type RemoteStorage interface {
+	// Put uploads the archive, which is size bytes long, under the
+	// given name.
+	Put(name string, archive io.Reader, size int64) error
+}
+
+// RemoteStorageParams holds the information needed to open a
+// RemoteStorage.
+type RemoteStorageParams struct {
+	// Location identifies where archives will be uploaded. Its
+	// scheme selects the remote storage implementation.
+	Location *url.URL
+
+	// Credentials holds the implementation-specific credentials used
+	// to access the remote storage.
+	Credentials map[string]string
+}
+
+// RemoteStorageFunc opens a RemoteStorage.
+type RemoteStorageFunc func(RemoteStorageParams) (RemoteStorage, error)
+
+var (
+	remoteStorageMu    sync.Mutex
+	remoteStorageTypes = make(map[string]RemoteStorageFunc)
+)
+
+// RegisterRemoteStorage makes a remote storage implementation
+// available for locations with the given URL scheme. It panics if
+// the scheme has already been registered.
+func RegisterRemoteStorage(scheme string, open RemoteStorageFunc) {
+	remoteStorageMu.Lock()
+	defer remoteStorageMu.Unlock()
+	if _, ok := remoteStorageTypes[scheme]; ok {
+		panic(errors.Errorf("remote backup storage %q already registered", scheme))
+	}
+	remoteStorageTypes[scheme] = open
+}
+
+// OpenRemoteStorage returns the RemoteStorage for the given location,
+// which must be a URL with a registered scheme.
+func OpenRemoteStorage(location string, credentials map[string]string) (RemoteStorage, error) {
+	u, err := url.Parse(location)
+	if err != nil {
+		return nil, errors.Annotatef(err, "invalid backup storage location %q", location)
+	}
+	remoteStorageMu.Lock()
+	open, ok := remoteStorageTypes[u.Scheme]
+	remoteStorageMu.Unlock()
+	if !ok {
+		return nil, errors.NotSupportedf("backup storage location %q", location)
+	}
+	stor, err := open(RemoteStorageParams{
+		Location:    u,
+		Credentials: credentials,
+	})
+	if err != nil {
+		return nil, errors.Annotatef(err, "opening backup storage %q", location)
+	}
+	return stor, nil
+}
+
+// remoteArchiveName returns the name under which the backup archive
+// is uploaded to remote storage.
+func remoteArchiveName(meta *Metadata) string {
+	name := meta.Started.Format(FilenameTemplate)
+	if meta.Encrypted {
+		name += EncryptedFilenameSuffix
+	}
+	return name
+}
@@ -0,0 +1,126 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/amz.v3/aws"
+)
+
+const (
+	// S3Scheme is the URL scheme for S3-compatible remote backup
+	// storage. Locations take the form
+	//   s3://<bucket>[/<prefix>][?endpoint=<url>&region=<region>]
+	S3Scheme = "s3"
+
+	// S3AccessKey is the credential attribute holding the S3
+	// access key.
+	S3AccessKey = "access-key"
+
+	// S3SecretKey is the credential attribute holding the S3
+	// secret key.
+	S3SecretKey = "secret-key"
+
+	defaultS3Endpoint = "https://s3.amazonaws.com"
+	defaultS3Region   = "us-east-1"
+
+	// s3SignatureExpiry is how long a signed upload request is
+	// valid for. S3 only checks this when the upload starts.
+	s3SignatureExpiry = 15 * time.Minute
+)
+
+func init() {
+	RegisterRemoteStorage(S3Scheme, newS3Storage)
+}
+
+// s3Storage is a RemoteStorage that uploads archives to an
+// S3-compatible object store, using path-style requests so that
+// non-AWS implementations work without DNS configuration.
+type s3Storage struct {
+	endpoint *url.URL
+	region   string
+	bucket   string
+	prefix   string
+	auth     aws.Auth
+
+	client *http.Client
+	now    func() time.Time
+}
+
+func newS3Storage(args RemoteStorageParams) (RemoteStorage, error) {
+	loc := args.Location
+	if loc.Host == "" {
+		return nil, errors.NotValidf("missing bucket")
+	}
+	endpoint := loc.Query().Get("endpoint")
+	if endpoint == "" {
+		endpoint = defaultS3Endpoint
+	}
+	endpointURL, err := url.Parse(endpoint)
+	if err != nil || endpointURL.Host == "" {
+		return nil, errors.NotValidf("endpoint %q", endpoint)
+	}
+	region := loc.Query().Get("region")
+	if region == "" {
+		region = defaultS3Region
+	}
+	accessKey := args.Credentials[S3AccessKey]
+	secretKey := args.Credentials[S3SecretKey]
+	if accessKey == "" || secretKey == "" {
+		return nil, errors.NotValidf("missing %s or %s", S3AccessKey, S3SecretKey)
+	}
+	return &s3Storage{
+		endpoint: endpointURL,
+		region:   region,
+		bucket:   loc.Host,
+		prefix:   strings.Trim(loc.Path, "/"),
+		auth:     aws.Auth{AccessKey: accessKey, SecretKey: secretKey},
+		client:   http.DefaultClient,
+		now:      time.Now,
+	}, nil
+}
+
+// Put is part of the RemoteStorage interface.
+func (s *s3Storage) Put(name string, archive io.Reader, size int64) error {
+	target := *s.endpoint
+	target.Path = "/" + path.Join(s.bucket, s.prefix, name)
+	req, err := http.NewRequest("PUT", target.String(), ioutil.NopCloser(archive))
+	if err != nil {
+		return errors.Trace(err)
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", "application/octet-stream")
+	if err := s.sign(req); err != nil {
+		return errors.Annotatef(err, "signing upload of %q", name)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Annotatef(err, "uploading %q", name)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("uploading %q: %s: %s", name, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// sign signs the request with an AWS signature version 4 in its
+// query string. Query signing leaves the payload unsigned, so that
+// the archive can be streamed rather than read twice.
+func (s *s3Storage) sign(req *http.Request) error {
+	// The signer takes the signing time from the request's Date
+	// header, which it removes once the request is signed.
+	req.Header.Set("Date", s.now().UTC().Format(aws.ISO8601BasicFormat))
+	return aws.SignV4URL(req, s.auth, s.region, "s3", s3SignatureExpiry)
+}
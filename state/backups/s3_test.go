@@ -0,0 +1,111 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backups_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state/backups"
+)
+
+type s3Suite struct {
+	testing.IsolationSuite
+
+	server   *httptest.Server
+	requests []*http.Request
+	bodies   []string
+	status   int
+}
+
+var _ = gc.Suite(&s3Suite{})
+
+func (s *s3Suite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.requests = nil
+	s.bodies = nil
+	s.status = http.StatusOK
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := ioutil.ReadAll(req.Body)
+		c.Check(err, jc.ErrorIsNil)
+		s.requests = append(s.requests, req)
+		s.bodies = append(s.bodies, string(body))
+		w.WriteHeader(s.status)
+		if s.status != http.StatusOK {
+			w.Write([]byte("<Error>AccessDenied</Error>"))
+		}
+	}))
+	s.AddCleanup(func(*gc.C) { s.server.Close() })
+}
+
+func (s *s3Suite) open(c *gc.C, location string) backups.RemoteStorage {
+	stor, err := backups.OpenRemoteStorage(location, map[string]string{
+		backups.S3AccessKey: "AKID",
+		backups.S3SecretKey: "sekrit",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return stor
+}
+
+func (s *s3Suite) TestPut(c *gc.C) {
+	stor := s.open(c, "s3://bucket/some/prefix?region=eu-west-1&endpoint="+s.server.URL)
+
+	err := stor.Put("juju-backup-20170101-120000.tar.gz", strings.NewReader("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	req := s.requests[0]
+	c.Check(req.Method, gc.Equals, "PUT")
+	c.Check(req.URL.Path, gc.Equals, "/bucket/some/prefix/juju-backup-20170101-120000.tar.gz")
+	c.Check(req.ContentLength, gc.Equals, int64(9))
+	query := req.URL.Query()
+	c.Check(query.Get("X-Amz-Algorithm"), gc.Equals, "AWS4-HMAC-SHA256")
+	c.Check(query.Get("X-Amz-Credential"), gc.Matches, `AKID/\d{8}/eu-west-1/s3/aws4_request`)
+	c.Check(query.Get("X-Amz-SignedHeaders"), gc.Equals, "host")
+	c.Check(query.Get("X-Amz-Signature"), gc.Matches, `[0-9a-f]{64}`)
+	c.Check(req.Header.Get("Authorization"), gc.Equals, "")
+	c.Check(s.bodies[0], gc.Equals, "<archive>")
+}
+
+func (s *s3Suite) TestPutNoPrefix(c *gc.C) {
+	stor := s.open(c, "s3://bucket?endpoint="+s.server.URL)
+
+	err := stor.Put("archive.tar.gz", strings.NewReader("<archive>"), 9)
+	c.Assert(err, jc.ErrorIsNil)
+
+	c.Assert(s.requests, gc.HasLen, 1)
+	c.Check(s.requests[0].URL.Path, gc.Equals, "/bucket/archive.tar.gz")
+	c.Check(s.requests[0].URL.Query().Get("X-Amz-Credential"), gc.Matches, `.*/us-east-1/s3/aws4_request`)
+}
+
+func (s *s3Suite) TestPutError(c *gc.C) {
+	s.status = http.StatusForbidden
+	stor := s.open(c, "s3://bucket?endpoint="+s.server.URL)
+
+	err := stor.Put("archive.tar.gz", strings.NewReader("<archive>"), 9)
+	c.Check(err, gc.ErrorMatches, `uploading "archive.tar.gz": 403 Forbidden: <Error>AccessDenied</Error>`)
+}
+
+func (s *s3Suite) TestOpenMissingCredentials(c *gc.C) {
+	_, err := backups.OpenRemoteStorage("s3://bucket", nil)
+	c.Check(err, gc.ErrorMatches, `opening backup storage "s3://bucket": missing access-key or secret-key not valid`)
+}
+
+func (s *s3Suite) TestOpenMissingBucket(c *gc.C) {
+	_, err := backups.OpenRemoteStorage("s3:///prefix", nil)
+	c.Check(err, gc.ErrorMatches, `opening backup storage "s3:///prefix": missing bucket not valid`)
+}
+
+func (s *s3Suite) TestOpenUnknownScheme(c *gc.C) {
+	_, err := backups.OpenRemoteStorage("ftp://example.com/backups", nil)
+	c.Check(err, jc.Satisfies, errors.IsNotSupported)
+	c.Check(err, gc.ErrorMatches, `backup storage location "ftp://example.com/backups" not supported`)
+}
@@ -44,9 +44,10 @@ type storageMetaDoc struct {
 
 	// backup
 
-	Started  int64  `bson:"started,minsize"`
-	Finished int64  `bson:"finished,minsize"`
-	Notes    string `bson:"notes,omitempty"`
+	Started   int64  `bson:"started,minsize"`
+	Finished  int64  `bson:"finished,minsize"`
+	Notes     string `bson:"notes,omitempty"`
+	Encrypted bool   `bson:"encrypted,omitempty"`
 
 	// origin
 
@@ -124,6 +125,7 @@ func docAsMetadata(doc *storageMetaDoc) *Metadata {
 	meta := NewMetadata()
 	meta.Started = metadocUnixToTime(doc.Started)
 	meta.Notes = doc.Notes
+	meta.Encrypted = doc.Encrypted
 
 	meta.Origin.Model = doc.Model
 	meta.Origin.Machine = doc.Machine
@@ -175,6 +177,7 @@ func newStorageMetaDoc(meta *Metadata) storageMetaDoc {
 		doc.Finished = metadocTimeToUnix(*meta.Finished)
 	}
 	doc.Notes = meta.Notes
+	doc.Encrypted = meta.Encrypted
 
 	doc.Model = meta.Origin.Model
 	doc.Machine = meta.Origin.Machine
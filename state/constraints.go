@@ -28,6 +28,10 @@ type constraintsDoc struct {
 	Tags         *[]string
 	Spaces       *[]string
 	VirtType     *string
+	Zones        *[]string
+
+	RootDiskSource   *string
+	AllocatePublicIP *bool
 }
 
 func (doc constraintsDoc) value() constraints.Value {
@@ -42,6 +46,10 @@ func (doc constraintsDoc) value() constraints.Value {
 		Tags:         doc.Tags,
 		Spaces:       doc.Spaces,
 		VirtType:     doc.VirtType,
+		Zones:        doc.Zones,
+
+		RootDiskSource:   doc.RootDiskSource,
+		AllocatePublicIP: doc.AllocatePublicIP,
 	}
 	return result
 }
@@ -58,6 +66,10 @@ func newConstraintsDoc(cons constraints.Value) constraintsDoc {
 		Tags:         cons.Tags,
 		Spaces:       cons.Spaces,
 		VirtType:     cons.VirtType,
+		Zones:        cons.Zones,
+
+		RootDiskSource:   cons.RootDiskSource,
+		AllocatePublicIP: cons.AllocatePublicIP,
 	}
 	return result
 }
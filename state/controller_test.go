@@ -4,6 +4,8 @@
 package state_test
 
 import (
+	"time"
+
 	gitjujutesting "github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
@@ -25,12 +27,21 @@ func (s *ControllerSuite) TestControllerAndModelConfigInitialisation(c *gc.C) {
 	c.Assert(err, jc.ErrorIsNil)
 
 	optional := map[string]bool{
-		controller.IdentityURL:         true,
-		controller.IdentityPublicKey:   true,
-		controller.AutocertURLKey:      true,
-		controller.AutocertDNSNameKey:  true,
-		controller.AllowModelAccessKey: true,
-		controller.MongoMemoryProfile:  true,
+		controller.IdentityURL:              true,
+		controller.IdentityPublicKey:        true,
+		controller.AutocertURLKey:           true,
+		controller.AutocertDNSNameKey:       true,
+		controller.AllowModelAccessKey:      true,
+		controller.MongoMemoryProfile:       true,
+		controller.MaxAuditLogSize:          true,
+		controller.BackupSchedule:           true,
+		controller.BackupRetentionCount:     true,
+		controller.BackupRetentionAge:       true,
+		controller.AuditForwardEnabled:      true,
+		controller.AuditFwdSyslogHost:       true,
+		controller.AuditFwdSyslogCACert:     true,
+		controller.AuditFwdSyslogClientCert: true,
+		controller.AuditFwdSyslogClientKey:  true,
 	}
 	for _, controllerAttr := range controller.ControllerOnlyConfigAttributes {
 		v, ok := controllerSettings.Get(controllerAttr)
@@ -62,3 +73,32 @@ func (s *ControllerSuite) TestPing(c *gc.C) {
 	gitjujutesting.MgoServer.Restart()
 	c.Assert(s.Controller.Ping(), gc.NotNil)
 }
+
+func (s *ControllerSuite) TestScheduledBackupStatusDefault(c *gc.C) {
+	status, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, state.ScheduledBackupStatus{})
+}
+
+func (s *ControllerSuite) TestSetScheduledBackupStatus(c *gc.C) {
+	now := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	expected := state.ScheduledBackupStatus{
+		LastAttempt:  now,
+		LastSuccess:  now,
+		LastBackupID: "backup-id",
+	}
+	err := s.State.SetScheduledBackupStatus(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err := s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, expected)
+
+	expected.LastAttempt = now.Add(time.Hour)
+	expected.LastFailure = now.Add(time.Hour)
+	expected.LastError = "boom"
+	err = s.State.SetScheduledBackupStatus(expected)
+	c.Assert(err, jc.ErrorIsNil)
+	status, err = s.State.ScheduledBackupStatus()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(status, jc.DeepEquals, expected)
+}
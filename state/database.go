@@ -189,6 +189,14 @@ func (schema collectionSchema) Create(
 					spec.MaxBytes = maxSize * 1024 * 1024
 				}
 			}
+			// Likewise for the audit log collection.
+			if name == auditingC && settings != nil {
+				if _, ok := (*settings)[controller.MaxAuditLogSize]; ok {
+					maxSize := settings.MaxAuditLogSizeMB()
+					logger.Infof("overriding max audit log collection size: %dM", maxSize)
+					spec.MaxBytes = maxSize * 1024 * 1024
+				}
+			}
 			if err := createCollection(rawCollection, spec); err != nil {
 				message := fmt.Sprintf("cannot create collection %q", name)
 				return maybeUnauthorized(err, message)
@@ -1016,6 +1016,32 @@ func (st *State) SetFilesystemInfo(tag names.FilesystemTag, info FilesystemInfo)
 	return st.db().Run(buildTxn)
 }
 
+// SetFilesystemSize records the size, in MiB, of the specified filesystem
+// after it has been grown to fill its resized backing volume.
+func (st *State) SetFilesystemSize(tag names.FilesystemTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size for filesystem %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		fs, err := st.filesystemByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		info, err := fs.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if info.Size == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      filesystemsC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"info.size", info.Size}},
+			Update: bson.D{{"$set", bson.D{{"info.size", size}}}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
 func validateFilesystemInfoChange(newInfo, oldInfo FilesystemInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
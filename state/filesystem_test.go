@@ -130,6 +130,37 @@ func (s *FilesystemStateSuite) TestSetFilesystemInfoImmutable(c *gc.C) {
 	s.assertFilesystemInfo(c, filesystemTag, filesystemInfoSet)
 }
 
+func (s *FilesystemStateSuite) TestSetFilesystemSize(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "rootfs")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	filesystem := s.storageInstanceFilesystem(c, storageTag)
+	filesystemTag := filesystem.FilesystemTag()
+
+	err = s.State.SetFilesystemSize(filesystemTag, 456)
+	c.Assert(err, gc.ErrorMatches, `cannot set size for filesystem "0/0": filesystem "0/0" not provisioned`)
+
+	machine := unitMachine(c, s.State, u)
+	err = machine.SetProvisioned("inst-id", "fake_nonce", nil)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.SetFilesystemInfo(filesystemTag, state.FilesystemInfo{Size: 123, FilesystemId: "fs-id"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchFilesystem(filesystemTag)
+	defer testing.AssertStop(c, w)
+	wc := testing.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange() // initial
+
+	err = s.State.SetFilesystemSize(filesystemTag, 456)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+	s.assertFilesystemInfo(c, filesystemTag, state.FilesystemInfo{
+		Size:         456,
+		Pool:         "rootfs",
+		FilesystemId: "fs-id",
+	})
+}
+
 func (s *FilesystemStateSuite) TestSetFilesystemInfoNoFilesystemId(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "filesystem", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -0,0 +1,169 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"net"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// WellKnownServiceType identifies a service, provided on the model's
+// machines, to which access may be controlled by a firewall rule.
+type WellKnownServiceType string
+
+const (
+	// SSHRule controls access to SSH (port 22) on every machine
+	// in the model.
+	SSHRule = WellKnownServiceType("ssh")
+
+	// JujuControllerRule controls access to the API port on the
+	// model's controller machines. It may only be set on the
+	// controller model.
+	JujuControllerRule = WellKnownServiceType("juju-controller")
+)
+
+// Validate returns an error if the service is not known.
+func (v WellKnownServiceType) Validate() error {
+	switch v {
+	case SSHRule, JujuControllerRule:
+		return nil
+	}
+	return errors.NotValidf("well known service type %q", v)
+}
+
+// FirewallRule holds the source CIDRs from which a well-known
+// service may be reached.
+type FirewallRule struct {
+	WellKnownService WellKnownServiceType
+	WhitelistCIDRs   []string
+}
+
+type firewallRulesDoc struct {
+	Id               string   `bson:"_id"`
+	WellKnownService string   `bson:"well-known-service"`
+	WhitelistCIDRs   []string `bson:"whitelist-source-cidrs"`
+}
+
+func (doc firewallRulesDoc) toRule() *FirewallRule {
+	return &FirewallRule{
+		WellKnownService: WellKnownServiceType(doc.WellKnownService),
+		WhitelistCIDRs:   doc.WhitelistCIDRs,
+	}
+}
+
+// FirewallRules provides access to the model's firewall rules in state.
+type FirewallRules struct {
+	st *State
+}
+
+// NewFirewallRules creates a FirewallRules instance backed by a state.
+func NewFirewallRules(st *State) *FirewallRules {
+	return &FirewallRules{st: st}
+}
+
+// Save stores the specified firewall rule, replacing any existing
+// rule for the same service.
+func (fw *FirewallRules) Save(rule FirewallRule) error {
+	if err := rule.WellKnownService.Validate(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(rule.WhitelistCIDRs) == 0 {
+		return errors.NotValidf("firewall rule for %q with no whitelist CIDRs", rule.WellKnownService)
+	}
+	if rule.WellKnownService == JujuControllerRule && !fw.st.IsController() {
+		return errors.NotValidf("firewall rule for %q outside the controller model", rule.WellKnownService)
+	}
+	for _, cidr := range rule.WhitelistCIDRs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return errors.NotValidf("CIDR %q", cidr)
+		}
+	}
+	serviceStr := string(rule.WellKnownService)
+	doc := firewallRulesDoc{
+		Id:               fw.st.docID(serviceStr),
+		WellKnownService: serviceStr,
+		WhitelistCIDRs:   rule.WhitelistCIDRs,
+	}
+	buildTxn := func(int) ([]txn.Op, error) {
+		model, err := fw.st.Model()
+		if err != nil {
+			return nil, errors.Annotate(err, "failed to load model")
+		}
+		if err := checkModelActive(fw.st); err != nil {
+			return nil, errors.Trace(err)
+		}
+
+		_, err = fw.Rule(rule.WellKnownService)
+		if err != nil && !errors.IsNotFound(err) {
+			return nil, errors.Trace(err)
+		}
+		var ops []txn.Op
+		if err == nil {
+			ops = []txn.Op{{
+				C:      firewallRulesC,
+				Id:     doc.Id,
+				Assert: txn.DocExists,
+				Update: bson.D{
+					{"$set", bson.D{{"whitelist-source-cidrs", rule.WhitelistCIDRs}}},
+				},
+			}, model.assertActiveOp()}
+		} else {
+			ops = []txn.Op{{
+				C:      firewallRulesC,
+				Id:     doc.Id,
+				Assert: txn.DocMissing,
+				Insert: doc,
+			}, model.assertActiveOp()}
+		}
+		return ops, nil
+	}
+	if err := fw.st.db().Run(buildTxn); err != nil {
+		return errors.Annotatef(err, "failed to save firewall rule for %q", rule.WellKnownService)
+	}
+	return nil
+}
+
+// Rule returns the firewall rule for the specified service.
+func (fw *FirewallRules) Rule(service WellKnownServiceType) (*FirewallRule, error) {
+	coll, closer := fw.st.db().GetCollection(firewallRulesC)
+	defer closer()
+
+	var doc firewallRulesDoc
+	err := coll.FindId(string(service)).One(&doc)
+	if err == mgo.ErrNotFound {
+		return nil, errors.NotFoundf("firewall rule for %q", service)
+	}
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return doc.toRule(), nil
+}
+
+// AllRules returns all the firewall rules in the model, sorted by
+// service.
+func (fw *FirewallRules) AllRules() ([]*FirewallRule, error) {
+	coll, closer := fw.st.db().GetCollection(firewallRulesC)
+	defer closer()
+
+	var docs []firewallRulesDoc
+	err := coll.Find(nil).Sort("well-known-service").All(&docs)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	result := make([]*FirewallRule, len(docs))
+	for i, doc := range docs {
+		result[i] = doc.toRule()
+	}
+	return result, nil
+}
+
+// WatchFirewallRules returns a NotifyWatcher that notifies of changes
+// to the model's firewall rules.
+func (st *State) WatchFirewallRules() NotifyWatcher {
+	return newNotifyCollWatcher(st, firewallRulesC, isLocalID(st))
+}
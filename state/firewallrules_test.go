@@ -0,0 +1,146 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/state"
+	statetesting "github.com/juju/juju/state/testing"
+)
+
+type firewallRulesSuite struct {
+	ConnSuite
+	rules *state.FirewallRules
+}
+
+var _ = gc.Suite(&firewallRulesSuite{})
+
+func (s *firewallRulesSuite) SetUpTest(c *gc.C) {
+	s.ConnSuite.SetUpTest(c)
+	s.rules = state.NewFirewallRules(s.State)
+}
+
+func (s *firewallRulesSuite) TestSaveInvalid(c *gc.C) {
+	err := s.rules.Save(state.FirewallRule{
+		WellKnownService: "telnet",
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, `well known service type "telnet" not valid`)
+
+	err = s.rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0"},
+	})
+	c.Assert(err, gc.ErrorMatches, `CIDR "10.0.0.0" not valid`)
+
+	err = s.rules.Save(state.FirewallRule{WellKnownService: state.SSHRule})
+	c.Assert(err, gc.ErrorMatches, `firewall rule for "ssh" with no whitelist CIDRs not valid`)
+}
+
+func (s *firewallRulesSuite) TestSaveJujuControllerRuleHostedModel(c *gc.C) {
+	st := s.Factory.MakeModel(c, nil)
+	defer st.Close()
+	rules := state.NewFirewallRules(st)
+
+	err := rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, gc.ErrorMatches, `firewall rule for "juju-controller" outside the controller model not valid`)
+	c.Assert(err, jc.Satisfies, errors.IsNotValid)
+
+	// The ssh rule applies to every model.
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *firewallRulesSuite) TestSaveAndRule(c *gc.C) {
+	_, err := s.rules.Rule(state.SSHRule)
+	c.Assert(err, jc.Satisfies, errors.IsNotFound)
+
+	err = s.rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rule, err := s.rules.Rule(state.SSHRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule, jc.DeepEquals, &state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+
+	// Saving again replaces the existing rule.
+	err = s.rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"172.16.0.0/12"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	rule, err = s.rules.Rule(state.SSHRule)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rule.WhitelistCIDRs, jc.DeepEquals, []string{"172.16.0.0/12"})
+}
+
+func (s *firewallRulesSuite) TestAllRules(c *gc.C) {
+	rules, err := s.rules.AllRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+
+	for _, rule := range []state.FirewallRule{{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}, {
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	}} {
+		err := s.rules.Save(rule)
+		c.Assert(err, jc.ErrorIsNil)
+	}
+	rules, err = s.rules.AllRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, jc.DeepEquals, []*state.FirewallRule{{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	}, {
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	}})
+
+	// Rules are per model.
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	rules, err = state.NewFirewallRules(otherSt).AllRules()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
+func (s *firewallRulesSuite) TestWatchFirewallRules(c *gc.C) {
+	w := s.State.WatchFirewallRules()
+	defer statetesting.AssertStop(c, w)
+	wc := statetesting.NewNotifyWatcherC(c, s.State, w)
+	wc.AssertOneChange()
+
+	err := s.rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertOneChange()
+
+	// Changes in other models are not reported.
+	otherSt := s.Factory.MakeModel(c, nil)
+	defer otherSt.Close()
+	err = state.NewFirewallRules(otherSt).Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertNoChange()
+}
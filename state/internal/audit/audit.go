@@ -4,8 +4,12 @@
 package audit
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/version"
+	"gopkg.in/mgo.v2/bson"
 
 	"github.com/juju/juju/audit"
 	"github.com/juju/juju/mongo/utils"
@@ -26,6 +30,11 @@ type auditEntryDoc struct {
 	// unmarshaled via time.Time::UnmarshalText.
 	Timestamp string `bson:"timestamp"`
 
+	// Created is the Timestamp in nanoseconds since the Unix epoch.
+	// It is stored so that entries can be queried and sorted by
+	// time.
+	Created int64 `bson:"created"`
+
 	// RemoteAddress is the IP of the machine from which the
 	// audit-event was triggered.
 	RemoteAddress string `bson:"remote-address"`
@@ -75,6 +84,7 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		JujuServerVersion: auditEntry.JujuServerVersion,
 		ModelUUID:         auditEntry.ModelUUID,
 		Timestamp:         string(timeAsBlob),
+		Created:           auditEntry.Timestamp.UnixNano(),
 		RemoteAddress:     auditEntry.RemoteAddress,
 		OriginType:        auditEntry.OriginType,
 		OriginName:        auditEntry.OriginName,
@@ -82,3 +92,90 @@ func auditEntryDocFromAuditEntry(auditEntry audit.AuditEntry) (auditEntryDoc, er
 		Data:              utils.EscapeKeys(auditEntry.Data),
 	}, nil
 }
+
+// FindAuditEntries returns the audit entries which match the given
+// filter, oldest first. findDocs is used to query the audit
+// collection; it must unmarshal at most limit documents, sorted by
+// the given field, into result.
+func FindAuditEntries(
+	collectionName string,
+	filter audit.Filter,
+	findDocs func(collectionName string, query bson.D, sort string, limit int, result interface{}) error,
+) ([]audit.AuditEntry, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var docs []auditEntryDoc
+	// Query newest first so that the limit selects the most recent
+	// entries; they are put back into chronological order below.
+	if err := findDocs(collectionName, filterQuery(filter), "-created", filter.Limit, &docs); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	entries := make([]audit.AuditEntry, len(docs))
+	for i, doc := range docs {
+		entry, err := auditEntryFromAuditEntryDoc(doc)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		entries[len(docs)-1-i] = entry
+	}
+	return entries, nil
+}
+
+func filterQuery(filter audit.Filter) bson.D {
+	query := bson.D{}
+	if filter.ModelUUID != "" {
+		query = append(query, bson.DocElem{"model-uuid", filter.ModelUUID})
+	}
+	if filter.OriginName != "" {
+		query = append(query, bson.DocElem{"origin-name", filter.OriginName})
+	}
+	if filter.Operation != "" {
+		query = append(query, bson.DocElem{"operation", bson.RegEx{
+			Pattern: regexp.QuoteMeta(filter.Operation),
+			Options: "i",
+		}})
+	}
+	created := bson.D{}
+	if !filter.After.IsZero() {
+		created = append(created, bson.DocElem{"$gt", filter.After.UnixNano()})
+	}
+	if !filter.Before.IsZero() {
+		created = append(created, bson.DocElem{"$lt", filter.Before.UnixNano()})
+	}
+	if len(created) > 0 {
+		query = append(query, bson.DocElem{"created", created})
+	}
+	return query
+}
+
+func auditEntryFromAuditEntryDoc(doc auditEntryDoc) (audit.AuditEntry, error) {
+	var timestamp time.Time
+	if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+		return audit.AuditEntry{}, errors.Annotatef(err, "parsing timestamp %q", doc.Timestamp)
+	}
+
+	return audit.AuditEntry{
+		JujuServerVersion: doc.JujuServerVersion,
+		ModelUUID:         doc.ModelUUID,
+		Timestamp:         timestamp,
+		RemoteAddress:     doc.RemoteAddress,
+		OriginType:        doc.OriginType,
+		OriginName:        doc.OriginName,
+		Operation:         doc.Operation,
+		Data:              utils.UnescapeKeys(doc.Data),
+	}, nil
+}
+
+// DecodeAuditEntry converts a raw document from the audit collection
+// into an AuditEntry.
+func DecodeAuditEntry(raw bson.Raw) (audit.AuditEntry, error) {
+	var doc auditEntryDoc
+	if err := raw.Unmarshal(&doc); err != nil {
+		return audit.AuditEntry{}, errors.Trace(err)
+	}
+	entry, err := auditEntryFromAuditEntryDoc(doc)
+	return entry, errors.Trace(err)
+}
@@ -4,6 +4,8 @@
 package audit_test
 
 import (
+	"time"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
 	jc "github.com/juju/testing/checkers"
@@ -58,6 +60,7 @@ func (*AuditSuite) TestPutAuditEntry_PersistAuditEntry(c *gc.C) {
 			"juju-server-version": requested.JujuServerVersion,
 			"model-uuid":          requested.ModelUUID,
 			"timestamp":           string(requestedTimeBlob),
+			"created":             requested.Timestamp.UnixNano(),
 			"remote-address":      "8.8.8.8",
 			"origin-type":         requested.OriginType,
 			"origin-name":         requested.OriginName,
@@ -111,3 +114,113 @@ func (*AuditSuite) TestPutAuditEntry_ValidateAuditEntry(c *gc.C) {
 	err := putAuditEntry(auditEntry)
 	c.Check(err, gc.ErrorMatches, validationErr.Error())
 }
+
+func (*AuditSuite) TestFindAuditEntries_BuildsQuery(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	after := coretesting.NonZeroTime().UTC()
+	before := after.Add(time.Hour)
+
+	var findDocsCalled bool
+	findDocs := func(collectionName string, query bson.D, sort string, limit int, result interface{}) error {
+		findDocsCalled = true
+		c.Check(collectionName, gc.Equals, "audit.log")
+		c.Check(sort, gc.Equals, "-created")
+		c.Check(limit, gc.Equals, 5)
+		c.Check(query, jc.DeepEquals, bson.D{
+			{"model-uuid", modelUUID},
+			{"origin-name", "user-bob"},
+			{"operation", bson.RegEx{Pattern: `Client:v1 - Full\.Status`, Options: "i"}},
+			{"created", bson.D{
+				{"$gt", after.UnixNano()},
+				{"$lt", before.UnixNano()},
+			}},
+		})
+		return nil
+	}
+
+	entries, err := stateaudit.FindAuditEntries("audit.log", audit.Filter{
+		ModelUUID:  modelUUID,
+		OriginName: "user-bob",
+		Operation:  "Client:v1 - Full.Status",
+		After:      after,
+		Before:     before,
+		Limit:      5,
+	}, findDocs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entries, gc.HasLen, 0)
+	c.Assert(findDocsCalled, jc.IsTrue)
+}
+
+func (*AuditSuite) TestFindAuditEntries_ReturnsOldestFirst(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	first := coretesting.NonZeroTime().UTC()
+	second := first.Add(time.Minute)
+
+	findDocs := func(_ string, query bson.D, _ string, _ int, result interface{}) error {
+		c.Check(query, gc.HasLen, 0)
+		// Round trip the documents through BSON so that they are
+		// unmarshalled into the unexported document type.
+		out, err := bson.Marshal(bson.M{"docs": []bson.M{
+			auditDoc(c, modelUUID, second, map[string]interface{}{"a": "b"}),
+			auditDoc(c, modelUUID, first, nil),
+		}})
+		c.Assert(err, jc.ErrorIsNil)
+		var wrapper struct {
+			Docs bson.Raw `bson:"docs"`
+		}
+		c.Assert(bson.Unmarshal(out, &wrapper), jc.ErrorIsNil)
+		return wrapper.Docs.Unmarshal(result)
+	}
+
+	entries, err := stateaudit.FindAuditEntries("audit.log", audit.Filter{}, findDocs)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(entries, gc.HasLen, 2)
+	c.Check(entries[0].Timestamp.Equal(first), jc.IsTrue)
+	c.Check(entries[1].Timestamp.Equal(second), jc.IsTrue)
+	c.Check(entries[1].Data, jc.DeepEquals, map[string]interface{}{"a": "b"})
+	c.Check(entries[0].ModelUUID, gc.Equals, modelUUID)
+	c.Check(entries[0].Operation, gc.Equals, "status")
+}
+
+func (*AuditSuite) TestFindAuditEntries_ValidatesFilter(c *gc.C) {
+	_, err := stateaudit.FindAuditEntries("audit.log", audit.Filter{Limit: -1}, nil)
+	c.Check(err, gc.ErrorMatches, "negative Limit not valid")
+}
+
+func auditDoc(c *gc.C, modelUUID string, timestamp time.Time, data map[string]interface{}) bson.M {
+	blob, err := timestamp.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	return bson.M{
+		"juju-server-version": version.MustParse("1.0.0"),
+		"model-uuid":          modelUUID,
+		"timestamp":           string(blob),
+		"created":             timestamp.UnixNano(),
+		"remote-address":      "8.8.8.8",
+		"origin-type":         "user",
+		"origin-name":         "bob",
+		"operation":           "status",
+		"data":                data,
+	}
+}
+
+func (*AuditSuite) TestDecodeAuditEntry(c *gc.C) {
+	modelUUID := utils.MustNewUUID().String()
+	timestamp := coretesting.NonZeroTime().UTC()
+	data, err := bson.Marshal(auditDoc(c, modelUUID, timestamp, map[string]interface{}{"\uff04a": "b"}))
+	c.Assert(err, jc.ErrorIsNil)
+
+	entry, err := stateaudit.DecodeAuditEntry(bson.Raw{Kind: 3, Data: data})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Check(entry.Timestamp.Equal(timestamp), jc.IsTrue)
+	entry.Timestamp = timestamp
+	c.Check(entry, jc.DeepEquals, audit.AuditEntry{
+		JujuServerVersion: version.MustParse("1.0.0"),
+		ModelUUID:         modelUUID,
+		Timestamp:         timestamp,
+		RemoteAddress:     "8.8.8.8",
+		OriginType:        "user",
+		OriginName:        "bob",
+		Operation:         "status",
+		Data:              map[string]interface{}{"$a": "b"},
+	})
+}
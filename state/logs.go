@@ -312,8 +312,12 @@ type LogRecord struct {
 // LogTailerParams specifies the filtering a LogTailer should apply to
 // logs in order to decide which to return.
 type LogTailerParams struct {
-	StartID       int64
-	StartTime     time.Time
+	StartID   int64
+	StartTime time.Time
+	// EndTime, if set, excludes logs recorded after it. As no newer
+	// logs can match, the tailer stops once the logs collection has
+	// been read, as if NoTail were set.
+	EndTime       time.Time
 	MinLevel      loggo.Level
 	InitialLines  int
 	NoTail        bool
@@ -321,7 +325,10 @@ type LogTailerParams struct {
 	ExcludeEntity []string
 	IncludeModule []string
 	ExcludeModule []string
-	Oplog         *mgo.Collection // For testing only
+	// MessagePattern, if set, is a regular expression, in Go's
+	// regexp syntax, which log messages must match.
+	MessagePattern string
+	Oplog          *mgo.Collection // For testing only
 }
 
 // oplogOverlap is used to decide on the initial oplog timestamp to
@@ -357,12 +364,24 @@ type LogTailerState interface {
 // NewLogTailer returns a LogTailer which filters according to the
 // parameters given.
 func NewLogTailer(st LogTailerState, params LogTailerParams) (LogTailer, error) {
+	// The message pattern is matched here rather than by MongoDB,
+	// whose regular expressions are PCRE, so that it means the same
+	// as when it was validated.
+	var messageRegexp *regexp.Regexp
+	if params.MessagePattern != "" {
+		var err error
+		messageRegexp, err = regexp.Compile(params.MessagePattern)
+		if err != nil {
+			return nil, errors.NotValidf("message pattern %q", params.MessagePattern)
+		}
+	}
 	session := st.MongoSession().Copy()
 	t := &logTailer{
 		modelUUID:       st.ModelUUID(),
 		session:         session,
 		logsColl:        session.DB(logsDB).C(logCollectionName(st.ModelUUID())).With(session),
 		params:          params,
+		messageRegexp:   messageRegexp,
 		logCh:           make(chan *LogRecord),
 		recentIds:       newRecentIdTracker(maxRecentLogIds),
 		maxInitialLines: maxInitialLines,
@@ -383,6 +402,7 @@ type logTailer struct {
 	session         *mgo.Session
 	logsColl        *mgo.Collection
 	params          LogTailerParams
+	messageRegexp   *regexp.Regexp
 	logCh           chan *LogRecord
 	lastID          int64
 	lastTime        time.Time
@@ -420,13 +440,19 @@ func (t *logTailer) loop() error {
 		return err
 	}
 
-	if t.params.NoTail {
+	if t.params.NoTail || !t.params.EndTime.IsZero() {
 		return nil
 	}
 
 	return t.tailOplog()
 }
 
+// matches reports whether the log document's message matches the
+// message pattern, if there is one.
+func (t *logTailer) matches(doc *logDoc) bool {
+	return t.messageRegexp == nil || t.messageRegexp.MatchString(doc.Message)
+}
+
 func (t *logTailer) processReversed(query *mgo.Query) error {
 	// We must sort by exactly the fields in the index and exactly reversed
 	// so that Mongo will use the index and not try to sort in memory.
@@ -442,7 +468,11 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 			t.params.InitialLines, maxInitialLines)
 	}
 	query.Sort("-t", "-_id")
-	query.Limit(t.params.InitialLines)
+	if t.messageRegexp == nil {
+		// Without a message pattern every document is returned,
+		// otherwise we read on until enough of them match.
+		query.Limit(t.params.InitialLines)
+	}
 	iter := query.Iter()
 	queue := make([]logDoc, t.params.InitialLines)
 	cur := t.params.InitialLines
@@ -453,6 +483,9 @@ func (t *logTailer) processReversed(query *mgo.Query) error {
 			return errors.Trace(tomb.ErrDying)
 		default:
 		}
+		if !t.matches(&doc) {
+			continue
+		}
 		cur--
 		queue[cur] = doc
 		if cur == 0 {
@@ -508,6 +541,9 @@ func (t *logTailer) processCollection() error {
 	deserialisationFailures := 0
 	iter := query.Sort("t", "_id").Iter()
 	for iter.Next(&doc) {
+		if !t.matches(&doc) {
+			continue
+		}
 		rec, err := logDocToRecord(t.modelUUID, &doc)
 		if err != nil {
 			if deserialisationFailures == 0 {
@@ -593,6 +629,9 @@ func (t *logTailer) tailOplog() error {
 				}
 				continue
 			}
+			if !t.matches(doc) {
+				continue
+			}
 			rec, err := logDocToRecord(t.modelUUID, doc)
 			if err != nil {
 				if deserialisationFailures == 0 {
@@ -617,8 +656,15 @@ func (t *logTailer) tailOplog() error {
 
 func (t *logTailer) paramsToSelector(params LogTailerParams, prefix string) bson.D {
 	sel := bson.D{}
+	timeSel := bson.M{}
 	if !params.StartTime.IsZero() {
-		sel = append(sel, bson.DocElem{"t", bson.M{"$gte": params.StartTime.UnixNano()}})
+		timeSel["$gte"] = params.StartTime.UnixNano()
+	}
+	if !params.EndTime.IsZero() {
+		timeSel["$lte"] = params.EndTime.UnixNano()
+	}
+	if len(timeSel) > 0 {
+		sel = append(sel, bson.DocElem{"t", timeSel})
 	}
 	if params.MinLevel > loggo.UNSPECIFIED {
 		sel = append(sel, bson.DocElem{"v", bson.M{"$gte": int(params.MinLevel)}})
@@ -386,6 +386,36 @@ func (s *LogTailerSuite) TestTimeFiltering(c *gc.C) {
 
 }
 
+func (s *LogTailerSuite) TestEndTimeFiltering(c *gc.C) {
+	threshT := coretesting.NonZeroTime()
+	want := logTemplate{Message: "want"}
+	s.writeLogsT(c, s.otherUUID, threshT.Add(-5*time.Second), threshT, 5, want)
+	s.writeLogsT(c,
+		s.otherUUID,
+		threshT.Add(time.Millisecond), threshT.Add(5*time.Second), 5,
+		logTemplate{Message: "dont want"},
+	)
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		EndTime: threshT,
+		Oplog:   s.oplogColl,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+	s.assertTailer(c, tailer, 5, want)
+
+	// No later logs can match, so the tailer stops without
+	// tailing the oplog.
+	select {
+	case _, ok := <-tailer.Logs():
+		if ok {
+			c.Fatal("shouldn't be any further logs")
+		}
+	case <-time.After(coretesting.LongWait):
+		c.Fatal("timed out waiting for logs channel to close")
+	}
+}
+
 func (s *LogTailerSuite) TestOplogTransition(c *gc.C) {
 	// Ensure that logs aren't repeated as the log tailer moves from
 	// reading from the logs collection to tailing the oplog.
@@ -781,6 +811,48 @@ func (s *LogTailerSuite) TestIncludeExcludeModule(c *gc.C) {
 	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
 }
 
+func (s *LogTailerSuite) TestMessagePattern(c *gc.C) {
+	hook := logTemplate{Message: `running "config-changed" hook`}
+	hookFailed := logTemplate{Message: `hook "install" failed: exit status 1`}
+	other := logTemplate{Message: "connection established"}
+	writeLogs := func() {
+		s.writeLogs(c, s.otherUUID, 2, hook)
+		s.writeLogs(c, s.otherUUID, 1, other)
+		s.writeLogs(c, s.otherUUID, 1, hookFailed)
+	}
+	params := state.LogTailerParams{
+		MessagePattern: `hook "[a-z-]+" failed`,
+	}
+	assert := func(tailer state.LogTailer) {
+		s.assertTailer(c, tailer, 1, hookFailed)
+	}
+	s.checkLogTailerFiltering(c, s.otherState, params, writeLogs, assert)
+}
+
+func (s *LogTailerSuite) TestMessagePatternInitialLines(c *gc.C) {
+	expected := logTemplate{Message: "want"}
+	s.writeLogs(c, s.otherUUID, 3, expected)
+	s.writeLogs(c, s.otherUUID, 5, logTemplate{Message: "dont want"})
+
+	tailer, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		InitialLines:   2,
+		MessagePattern: "^want$",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	defer tailer.Stop()
+
+	// Should see the last 2 matching lines, even though the most
+	// recent lines don't match.
+	s.assertTailer(c, tailer, 2, expected)
+}
+
+func (s *LogTailerSuite) TestMessagePatternNotValid(c *gc.C) {
+	_, err := state.NewLogTailer(s.otherState, state.LogTailerParams{
+		MessagePattern: "hook (",
+	})
+	c.Assert(err, gc.ErrorMatches, `message pattern "hook \(" not valid`)
+}
+
 func (s *LogTailerSuite) checkLogTailerFiltering(
 	c *gc.C,
 	st *state.State,
@@ -25,16 +25,28 @@ import (
 // ExportConfig allows certain aspects of the model to be skipped
 // during the export. The intent of this is to be able to get a partial
 // export to support other API calls, like status.
+//
+// TODO: the pinned juju/description revision has no fields for expose
+// settings, egress rules, firewall rules, action schedules, or the
+// zones, root-disk-source and allocate-public-ip constraints. Full
+// exports refuse models using them, and the Skip flags for them only
+// exist for partial exports. Export them, and drop those flags, once
+// juju/description can represent them.
 type ExportConfig struct {
-	SkipActions            bool
-	SkipAnnotations        bool
-	SkipCloudImageMetadata bool
-	SkipCredentials        bool
-	SkipIPAddresses        bool
-	SkipSettings           bool
-	SkipSSHHostKeys        bool
-	SkipStatusHistory      bool
-	SkipLinkLayerDevices   bool
+	SkipActions                bool
+	SkipAnnotations            bool
+	SkipCloudImageMetadata     bool
+	SkipCredentials            bool
+	SkipIPAddresses            bool
+	SkipSettings               bool
+	SkipSSHHostKeys            bool
+	SkipStatusHistory          bool
+	SkipLinkLayerDevices       bool
+	SkipExposeSettings         bool
+	SkipFirewallRules          bool
+	SkipEgressRules            bool
+	SkipUnsupportedConstraints bool
+	SkipCharmUpgradeHolds      bool
 }
 
 // ExportPartial the current model for the State optionally skipping
@@ -168,6 +180,10 @@ func (st *State) exportImpl(cfg ExportConfig) (description.Model, error) {
 		return nil, errors.Trace(err)
 	}
 
+	if err := export.firewallRules(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	if err := export.remoteApplications(); err != nil {
 		return nil, errors.Trace(err)
 	}
@@ -650,6 +666,17 @@ type addApplicationContext struct {
 	endpoingBindings map[string]bindingsMap
 }
 
+// exposedToAnywhere reports whether the given expose settings allow
+// access from anywhere, as the exposed flag alone does.
+func exposedToAnywhere(docs []exposedEndpointDoc) bool {
+	for _, doc := range docs {
+		if !set.NewStrings(doc.ExposeToCIDRs...).Contains("0.0.0.0/0") {
+			return false
+		}
+	}
+	return true
+}
+
 func (e *exporter) addApplication(ctx addApplicationContext) error {
 	application := ctx.application
 	appName := application.Name()
@@ -658,6 +685,32 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 	leadershipKey := leadershipSettingsKey(appName)
 	storageConstraintsKey := application.storageConstraintsKey()
 
+	// The model description has no place for expose settings, and
+	// exporting the exposed flag alone would open the application's
+	// ports to everywhere. Settings that allow access from anywhere
+	// are no different from the flag; partial exports may skip any
+	// other settings, exporting the application as not exposed.
+	exposed := application.doc.Exposed
+	if exposed && !exposedToAnywhere(application.doc.ExposedEndpoints) {
+		if !e.cfg.SkipExposeSettings {
+			return errors.NotSupportedf("exporting restricted expose settings of application %q", appName)
+		}
+		e.logger.Warningf("application %q has restricted expose settings, exporting it as not exposed", appName)
+		exposed = false
+	}
+	// Nor is there a place for egress rules, without which the
+	// application would lose outgoing access in the target model.
+	if len(application.doc.EgressRules) > 0 && !e.cfg.SkipEgressRules {
+		return errors.NotSupportedf("migrating egress rules of application %q", appName)
+	}
+
+	// Units held by a rolling charm upgrade would all upgrade at
+	// once in the target model, which would have no record of the
+	// hold. Partial exports, such as bundles, may ignore the hold.
+	if application.doc.CharmUpgradeHold != nil && !e.cfg.SkipCharmUpgradeHolds {
+		return errors.NotSupportedf("migrating application %q during a rolling charm upgrade", appName)
+	}
+
 	applicationSettingsDoc, found := e.modelSettings[settingsKey]
 	if !found && !e.cfg.SkipSettings {
 		return errors.Errorf("missing settings for application %q", appName)
@@ -677,7 +730,7 @@ func (e *exporter) addApplication(ctx addApplicationContext) error {
 		Channel:              application.doc.Channel,
 		CharmModifiedVersion: application.doc.CharmModifiedVersion,
 		ForceCharm:           application.doc.ForceCharm,
-		Exposed:              application.doc.Exposed,
+		Exposed:              exposed,
 		MinUnits:             application.doc.MinUnits,
 		EndpointBindings:     map[string]string(ctx.endpoingBindings[globalKey]),
 		Settings:             applicationSettingsDoc.Settings,
@@ -1077,10 +1130,36 @@ func (e *exporter) cloudimagemetadata() error {
 	return nil
 }
 
+func (e *exporter) firewallRules() error {
+	if e.cfg.SkipFirewallRules {
+		return nil
+	}
+	// The description format has no place for firewall rules, and
+	// dropping them would silently reopen access to the model's
+	// well-known services.
+	rules, err := NewFirewallRules(e.st).AllRules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rules) > 0 {
+		return errors.NotSupportedf("migrating models with firewall rules")
+	}
+	return nil
+}
+
 func (e *exporter) actions() error {
 	if e.cfg.SkipActions {
 		return nil
 	}
+	// The description format has no place for action schedules, and
+	// dropping them would silently stop the scheduled actions running.
+	schedules, err := e.st.ActionSchedules()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(schedules) > 0 {
+		return errors.NotSupportedf("migrating models with action schedules")
+	}
 	actions, err := e.st.AllActions()
 	if err != nil {
 		return errors.Trace(err)
@@ -1429,6 +1508,21 @@ func (e *exporter) constraintsArgs(globalKey string) (description.ConstraintsArg
 		}
 		return nil
 	}
+	// The description format has no place for zones, root-disk-source
+	// or allocate-public-ip, and silently dropping them would change
+	// how the migrated model's machines are provisioned. Partial
+	// exports, such as bundles, may leave them out.
+	if !e.cfg.SkipUnsupportedConstraints {
+		if zones := optionalStringSlice("zones"); len(zones) > 0 {
+			return description.ConstraintsArgs{}, errors.NotSupportedf("migrating zones constraints")
+		}
+		if optionalString("rootdisksource") != "" {
+			return description.ConstraintsArgs{}, errors.NotSupportedf("migrating root-disk-source constraints")
+		}
+		if doc["allocatepublicip"] != nil {
+			return description.ConstraintsArgs{}, errors.NotSupportedf("migrating allocate-public-ip constraints")
+		}
+	}
 	result := description.ConstraintsArgs{
 		Architecture: optionalString("arch"),
 		Container:    optionalString("container"),
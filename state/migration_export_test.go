@@ -377,6 +377,133 @@ func (s *MigrationExportSuite) TestApplicationsWithVirtConstraint(c *gc.C) {
 	s.assertMigrateApplications(c, constraints.MustParse("arch=amd64 mem=8G virt-type=kvm"))
 }
 
+func (s *MigrationExportSuite) TestApplicationsWithExposeSettings(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*exporting restricted expose settings of application "mysql" not supported`)
+
+	// Partial exports may skip the settings, but must not open the
+	// application to everywhere.
+	model, err := s.State.ExportPartial(state.ExportConfig{SkipExposeSettings: true})
+	c.Assert(err, jc.ErrorIsNil)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Exposed(), jc.IsFalse)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithExposeSettingsToAnywhere(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"0.0.0.0/0", "10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	model, err := s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+	applications := model.Applications()
+	c.Assert(applications, gc.HasLen, 1)
+	c.Assert(applications[0].Exposed(), jc.IsTrue)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithZonesConstraint(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetConstraints(constraints.MustParse("zones=az1,az2"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating zones constraints not supported`)
+
+	// Partial exports, such as bundles, may leave out the zones.
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipUnsupportedConstraints: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithRootDiskSourceConstraint(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetConstraints(constraints.MustParse("root-disk-source=volume"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating root-disk-source constraints not supported`)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipUnsupportedConstraints: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithAllocatePublicIPConstraint(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetConstraints(constraints.MustParse("allocate-public-ip=false"))
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating allocate-public-ip constraints not supported`)
+
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipUnsupportedConstraints: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithEgressRules(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	err := application.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating egress rules of application "mysql" not supported`)
+
+	// Partial exports, such as bundles, may skip the egress rules.
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipEgressRules: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+}
+
+func (s *MigrationExportSuite) TestApplicationsWithCharmUpgradeHold(c *gc.C) {
+	application := s.Factory.MakeApplication(c, nil)
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	ch, _, err := application.Charm()
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(ch.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	newCharm := s.Factory.MakeCharm(c, &factory.CharmParams{Name: ch.Meta().Name})
+	err = application.SetCharm(state.SetCharmConfig{Charm: newCharm, Rolling: true})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating application "mysql" during a rolling charm upgrade not supported`)
+
+	// Partial exports, such as bundles, may ignore the hold.
+	model, err := s.State.ExportPartial(state.ExportConfig{
+		SkipCharmUpgradeHolds: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(model.Applications(), gc.HasLen, 1)
+
+	// Once the upgrade is complete the application is exported.
+	err = application.ReleaseCharmUpgrade(unit.Name())
+	c.Assert(err, jc.ErrorIsNil)
+	err = unit.SetCharmURL(newCharm.URL())
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.Export()
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 func (s *MigrationExportSuite) assertMigrateApplications(c *gc.C, cons constraints.Value) {
 	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
 		Settings: map[string]interface{}{
@@ -918,6 +1045,41 @@ func (s *MigrationExportSuite) TestActionsSkipped(c *gc.C) {
 	c.Assert(actions, gc.HasLen, 0)
 }
 
+func (s *MigrationExportSuite) TestActionSchedulesNotSupported(c *gc.C) {
+	application := s.Factory.MakeApplication(c, &factory.ApplicationParams{
+		Charm: s.Factory.MakeCharm(c, &factory.CharmParams{Name: "dummy"}),
+	})
+	unit := s.Factory.MakeUnit(c, &factory.UnitParams{Application: application})
+	_, err := unit.ScheduleAction("snapshot", nil, time.Time{}, time.Hour)
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating models with action schedules not supported`)
+
+	// Partial exports that leave out actions are unaffected.
+	_, err = s.State.ExportPartial(state.ExportConfig{
+		SkipActions: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
+func (s *MigrationExportSuite) TestFirewallRulesNotSupported(c *gc.C) {
+	err := state.NewFirewallRules(s.State).Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	_, err = s.State.Export()
+	c.Assert(err, gc.ErrorMatches, `.*migrating models with firewall rules not supported`)
+
+	// Partial exports that leave out firewall rules are unaffected.
+	_, err = s.State.ExportPartial(state.ExportConfig{
+		SkipFirewallRules: true,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+}
+
 type goodToken struct{}
 
 // Check implements leadership.Token
@@ -158,6 +158,14 @@ func (s *MigrationSuite) TestKnownCollections(c *gc.C) {
 		// Recreated whilst migrating actions.
 		actionNotificationsC,
 
+		// Models with action schedules are refused for export, as
+		// the description format cannot represent them.
+		actionSchedulesC,
+
+		// Models with firewall rules are refused for export, as
+		// the description format cannot represent them.
+		firewallRulesC,
+
 		// Global settings store controller specific configuration settings
 		// and are not to be migrated.
 		globalSettingsC,
@@ -353,6 +361,14 @@ func (s *MigrationSuite) TestApplicationDocFields(c *gc.C) {
 		// RelationCount is handled by the number of times the application name
 		// appears in relation endpoints.
 		"RelationCount",
+		// Applications in a rolling charm upgrade are not
+		// exported, as the model description cannot hold it.
+		"CharmUpgradeHold",
+		// Expose settings are not supported by the model
+		// description, so applications with them are not exported.
+		"ExposedEndpoints",
+		// Nor are egress rules.
+		"EgressRules",
 	)
 	migrated := set.NewStrings(
 		"Name",
@@ -529,6 +545,11 @@ func (s *MigrationSuite) TestConstraintsDocFields(c *gc.C) {
 		"Tags",
 		"Spaces",
 		"VirtType",
+		// Zones, RootDiskSource and AllocatePublicIP constraints
+		// cannot yet be migrated, and are rejected on export.
+		"Zones",
+		"RootDiskSource",
+		"AllocatePublicIP",
 	)
 	s.AssertExportedFields(c, constraintsDoc{}, fields)
 }
@@ -661,6 +682,8 @@ func (s *MigrationSuite) TestSSHHostKeyDocFields(c *gc.C) {
 func (s *MigrationSuite) TestActionDocFields(c *gc.C) {
 	ignored := set.NewStrings(
 		"ModelUUID",
+		// Progress messages are transient and are not migrated.
+		"Logs",
 	)
 	migrated := set.NewStrings(
 		"DocId",
@@ -0,0 +1,107 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package state
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"gopkg.in/mgo.v2"
+	"gopkg.in/mgo.v2/bson"
+	"gopkg.in/mgo.v2/txn"
+)
+
+// scheduledBackupStatusKey is the key for the document recording the
+// outcome of scheduled controller backups.
+const scheduledBackupStatusKey = "scheduledBackupStatus"
+
+// ScheduledBackupStatus records the outcome of the most recent
+// scheduled backups of the controller.
+type ScheduledBackupStatus struct {
+	// LastAttempt is when a scheduled backup was last started.
+	LastAttempt time.Time
+
+	// LastSuccess is when the last successful scheduled backup was
+	// started.
+	LastSuccess time.Time
+
+	// LastBackupID is the ID of the last successful scheduled backup.
+	LastBackupID string
+
+	// LastFailure is when the last failed scheduled backup was
+	// started.
+	LastFailure time.Time
+
+	// LastError is the error from the last failed scheduled backup.
+	LastError string
+}
+
+type scheduledBackupStatusDoc struct {
+	LastAttempt  time.Time `bson:"last-attempt"`
+	LastSuccess  time.Time `bson:"last-success"`
+	LastBackupID string    `bson:"last-backup-id"`
+	LastFailure  time.Time `bson:"last-failure"`
+	LastError    string    `bson:"last-error"`
+}
+
+// ScheduledBackupStatus returns the outcome of the most recent
+// scheduled backups. If no scheduled backup has ever been attempted,
+// the zero value is returned.
+func (st *State) ScheduledBackupStatus() (ScheduledBackupStatus, error) {
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+
+	var doc scheduledBackupStatusDoc
+	err := controllers.Find(bson.D{{"_id", scheduledBackupStatusKey}}).One(&doc)
+	if err == mgo.ErrNotFound {
+		return ScheduledBackupStatus{}, nil
+	} else if err != nil {
+		return ScheduledBackupStatus{}, errors.Annotate(err, "cannot get scheduled backup status")
+	}
+	return ScheduledBackupStatus{
+		LastAttempt:  doc.LastAttempt.UTC(),
+		LastSuccess:  doc.LastSuccess.UTC(),
+		LastBackupID: doc.LastBackupID,
+		LastFailure:  doc.LastFailure.UTC(),
+		LastError:    doc.LastError,
+	}, nil
+}
+
+// SetScheduledBackupStatus records the outcome of the most recent
+// scheduled backups.
+func (st *State) SetScheduledBackupStatus(status ScheduledBackupStatus) error {
+	doc := scheduledBackupStatusDoc{
+		LastAttempt:  status.LastAttempt.UTC(),
+		LastSuccess:  status.LastSuccess.UTC(),
+		LastBackupID: status.LastBackupID,
+		LastFailure:  status.LastFailure.UTC(),
+		LastError:    status.LastError,
+	}
+	controllers, closer := st.db().GetCollection(controllersC)
+	defer closer()
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		count, err := controllers.FindId(scheduledBackupStatusKey).Count()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if count == 0 {
+			return []txn.Op{{
+				C:      controllersC,
+				Id:     scheduledBackupStatusKey,
+				Assert: txn.DocMissing,
+				Insert: &doc,
+			}}, nil
+		}
+		return []txn.Op{{
+			C:      controllersC,
+			Id:     scheduledBackupStatusKey,
+			Assert: txn.DocExists,
+			Update: bson.D{{"$set", &doc}},
+		}}, nil
+	}
+	if err := st.db().Run(buildTxn); err != nil {
+		return errors.Annotate(err, "cannot set scheduled backup status")
+	}
+	return nil
+}
@@ -1256,12 +1256,24 @@ func (st *State) AddApplication(args AddApplicationArgs) (_ *Application, err er
 			ops = append(ops, resOps...)
 		}
 
+		// Collect shared storage addition operations. The shared
+		// storage instances are attached to each of the units.
+		sharedStorageOps, sharedStorage, err := createSharedStorageOps(
+			st, app.ApplicationTag(), args.Charm.Meta(),
+			args.Storage, args.Series, args.NumUnits,
+		)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ops = append(ops, sharedStorageOps...)
+
 		// Collect unit-adding operations.
 		for x := 0; x < args.NumUnits; x++ {
 			unitName, unitOps, err := app.addApplicationUnitOps(applicationAddUnitOpsArgs{
 				cons:          args.Constraints,
 				storageCons:   args.Storage,
 				attachStorage: args.AttachStorage,
+				sharedStorage: sharedStorage,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
@@ -2189,6 +2201,23 @@ func (st *State) PutAuditEntryFn() func(audit.AuditEntry) error {
 	return stateaudit.PutAuditEntryFn(auditingC, insert)
 }
 
+// AuditEntries returns the recorded audit entries which match the
+// given filter, oldest first.
+func (st *State) AuditEntries(filter audit.Filter) ([]audit.AuditEntry, error) {
+	find := func(collectionName string, query bson.D, sort string, limit int, result interface{}) error {
+		collection, closeCollection := st.db().GetCollection(collectionName)
+		defer closeCollection()
+
+		q := collection.Find(query).Sort(sort)
+		if limit > 0 {
+			q = q.Limit(limit)
+		}
+		return errors.Trace(q.All(result))
+	}
+	entries, err := stateaudit.FindAuditEntries(auditingC, filter, find)
+	return entries, errors.Trace(err)
+}
+
 // SetSLA sets the SLA on the current connected model.
 func (st *State) SetSLA(level, owner string, credentials []byte) error {
 	model, err := st.Model()
@@ -719,8 +719,9 @@ func createStorageOps(
 		}
 	}
 
-	// TODO(axw) create storage attachments for each shared storage
-	// instance owned by the service.
+	// Storage attachments for shared storage instances are created
+	// when units are added to the application; see createSharedStorageOps
+	// and Application.addUnitOpsWithCons.
 	//
 	// TODO(axw) prevent creation of shared storage after service
 	// creation, because the only sane time to add storage attachments
@@ -729,6 +730,44 @@ func createStorageOps(
 	return ops, instanceCounts, numStorageAttachments, nil
 }
 
+// createSharedStorageOps returns txn.Ops for creating the shared storage
+// instances for a new application, along with the storage instances that
+// will be created. The instances' attachment counts are initialised to
+// numUnits, as the instances will be attached to each of the units that
+// are added along with the application.
+func createSharedStorageOps(
+	st *State,
+	applicationTag names.ApplicationTag,
+	charmMeta *charm.Meta,
+	cons map[string]StorageConstraints,
+	series string,
+	numUnits int,
+) ([]txn.Op, []*storageInstance, error) {
+	ops, instanceCounts, _, err := createStorageOps(
+		st, applicationTag, charmMeta, cons, series, nil,
+	)
+	if err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	var instances []*storageInstance
+	for _, op := range ops {
+		doc, ok := op.Insert.(*storageInstanceDoc)
+		if !ok {
+			continue
+		}
+		doc.AttachmentCount = numUnits
+		instances = append(instances, &storageInstance{st, *doc})
+	}
+	for name, count := range instanceCounts {
+		incRefOp, err := increfEntityStorageOp(st, applicationTag, name, count)
+		if err != nil {
+			return nil, nil, errors.Trace(err)
+		}
+		ops = append(ops, incRefOp)
+	}
+	return ops, instances, nil
+}
+
 // unitAssignedMachineStorageOps returns ops for creating volumes, filesystems
 // and their attachments to the machine that the specified unit is assigned to,
 // corresponding to the specified storage instance.
@@ -1550,11 +1589,9 @@ func validateStorageConstraintsAgainstCharm(
 			return errors.Errorf("charm %q has no store called %q", charmMeta.Name, name)
 		}
 		if charmStorage.Shared {
-			// TODO(axw) implement shared storage support.
-			return errors.Errorf(
-				"charm %q store %q: shared storage support not implemented",
-				charmMeta.Name, name,
-			)
+			if err := validateSharedStoragePool(st, cons.Pool, storageKind(charmStorage.Type)); err != nil {
+				return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
+			}
 		}
 		if err := validateCharmStorageCount(charmStorage, cons.Count); err != nil {
 			return errors.Annotatef(err, "charm %q store %q", charmMeta.Name, name)
@@ -1630,6 +1667,25 @@ func validateCharmStorageCount(charmStorage charm.Storage, count uint64) error {
 	return nil
 }
 
+// validateSharedStoragePool validates that the specified storage pool
+// may be used for shared storage. Shared storage is only supported for
+// filesystems, by providers whose filesystems may be attached to
+// multiple machines at once.
+func validateSharedStoragePool(st *State, poolName string, kind storage.StorageKind) error {
+	if kind != storage.StorageKindFilesystem {
+		return errors.NotSupportedf("shared %s storage", kind)
+	}
+	_, provider, err := poolStorageProvider(st, poolName)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	shared, ok := provider.(storage.SharedFilesystemProvider)
+	if !ok || !shared.SharedFilesystems() {
+		return errors.NotSupportedf("shared storage in pool %q", poolName)
+	}
+	return nil
+}
+
 // validateStoragePool validates the storage pool for the model.
 // If machineId is non-nil, the storage scope will be validated against
 // the machineId; if the storage is not machine-scoped, then the machineId
@@ -949,6 +949,86 @@ func (s *StorageStateSuite) TestAddUnitAttachStorage(c *gc.C) {
 	c.Assert(owner, gc.Equals, u2.UnitTag())
 }
 
+func (s *StorageStateSuite) setupSharedStorageCharm(c *gc.C, kind string) *state.Charm {
+	pm := poolmanager.New(state.NewStateSettings(s.State), provider.CommonStorageProviders())
+	_, err := pm.Create("nfs-pool", provider.NFSProviderType, map[string]interface{}{
+		"server": "nfs.example.com",
+		"export": "/srv/juju",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return s.createStorageCharm(c, "storage-shared-"+kind, charm.Storage{
+		Name:     "data",
+		Type:     charm.StorageType(kind),
+		Shared:   true,
+		CountMin: 1,
+		CountMax: 1,
+	})
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorage(c *gc.C) {
+	ch := s.setupSharedStorageCharm(c, "filesystem")
+	app, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("nfs-pool", 1024, 1),
+		},
+		NumUnits: 2,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u2, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// The storage instance is owned by the application, and
+	// attached to each of its units.
+	storageTag := names.NewStorageTag("data/0")
+	storageInstance, err := s.State.StorageInstance(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	owner, hasOwner := storageInstance.Owner()
+	c.Assert(hasOwner, jc.IsTrue)
+	c.Assert(owner, gc.Equals, app.Tag())
+
+	storageAttachments, err := s.State.StorageAttachments(storageTag)
+	c.Assert(err, jc.ErrorIsNil)
+	unitNames := set.NewStrings()
+	for _, a := range storageAttachments {
+		unitNames.Add(a.Unit().Id())
+	}
+	c.Assert(unitNames.SortedValues(), jc.DeepEquals, []string{
+		"shared/0", "shared/1", u2.Name(),
+	})
+
+	all, err := s.State.AllStorageInstances()
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(all, gc.HasLen, 1)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorageBlock(c *gc.C) {
+	ch := s.setupSharedStorageCharm(c, "block")
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("nfs-pool", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot add application "shared": charm "storage-shared-block" store "data": shared block storage not supported`)
+}
+
+func (s *StorageStateSuite) TestAddApplicationSharedStorageUnsupportedPool(c *gc.C) {
+	ch := s.setupSharedStorageCharm(c, "filesystem")
+	_, err := s.State.AddApplication(state.AddApplicationArgs{
+		Name:  "shared",
+		Charm: ch,
+		Storage: map[string]state.StorageConstraints{
+			"data": makeStorageCons("rootfs", 1024, 1),
+		},
+	})
+	c.Assert(err, gc.ErrorMatches,
+		`cannot add application "shared": charm "storage-shared-filesystem" store "data": shared storage in pool "rootfs" not supported`)
+}
+
 func (s *StorageStateSuite) TestConcurrentDestroyStorageInstanceRemoveStorageAttachmentsRemovesInstance(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := u.Destroy()
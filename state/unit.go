@@ -2097,6 +2097,9 @@ func (u *Unit) findCleanMachineQuery(requireEmpty bool, cons *constraints.Value)
 	if cons.Tags != nil && len(*cons.Tags) > 0 {
 		suitableTerms = append(suitableTerms, bson.DocElem{"tags", bson.D{{"$all", *cons.Tags}}})
 	}
+	if cons.HasZones() {
+		suitableTerms = append(suitableTerms, bson.DocElem{"availzone", bson.D{{"$in", *cons.Zones}}})
+	}
 	if len(suitableTerms) > 0 {
 		instanceDataCollection, closer := db.GetCollection(instanceDataC)
 		defer closer()
@@ -2303,6 +2306,17 @@ type ActionSpecsByName map[string]charm.ActionSpec
 // this Unit, and returns its ID.  Note that the use of spec.InsertDefaults
 // mutates payload.
 func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, error) {
+	payloadWithDefaults, err := u.validateActionPayload(name, payload)
+	if err != nil {
+		return nil, err
+	}
+	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+}
+
+// validateActionPayload checks that the named action is defined for the
+// unit and that the payload is valid for it, and returns the payload
+// with the action's default parameter values inserted.
+func (u *Unit) validateActionPayload(name string, payload map[string]interface{}) (map[string]interface{}, error) {
 	if len(name) == 0 {
 		return nil, errors.New("no action name given")
 	}
@@ -2324,11 +2338,7 @@ func (u *Unit) AddAction(name string, payload map[string]interface{}) (Action, e
 	if err != nil {
 		return nil, err
 	}
-	payloadWithDefaults, err := spec.InsertDefaults(payload)
-	if err != nil {
-		return nil, err
-	}
-	return u.st.EnqueueAction(u.Tag(), name, payloadWithDefaults)
+	return spec.InsertDefaults(payload)
 }
 
 // ActionSpecs gets the ActionSpec map for the Unit's charm.
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/juju/errors"
 	"github.com/juju/loggo"
@@ -1121,3 +1122,115 @@ func AddModelEnvironVersion(st *State) error {
 	}
 	return st.db().RunTransaction(ops)
 }
+
+// ConvertAuditLogToCapped ensures that every audit entry has a created
+// field, and that the audit log collection is capped at the size given
+// in controller config.
+func ConvertAuditLogToCapped(st *State) error {
+	coll, closer := st.db().GetRawCollection(auditingC)
+	defer closer()
+
+	var doc struct {
+		Id        bson.ObjectId `bson:"_id"`
+		Timestamp string        `bson:"timestamp"`
+	}
+	// Documents in a capped collection cannot grow, so the created
+	// field must be added before the collection is converted.
+	iter := coll.Find(bson.D{{"created", bson.D{{"$exists", false}}}}).Iter()
+	for iter.Next(&doc) {
+		var timestamp time.Time
+		if err := timestamp.UnmarshalText([]byte(doc.Timestamp)); err != nil {
+			upgradesLogger.Warningf("audit entry %v has invalid timestamp %q", doc.Id, doc.Timestamp)
+			continue
+		}
+		err := coll.UpdateId(doc.Id, bson.D{{"$set", bson.D{{"created", timestamp.UnixNano()}}}})
+		if err != nil {
+			return errors.Annotatef(err, "updating audit entry %v", doc.Id)
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+
+	config, err := st.ControllerConfig()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	size := config.MaxAuditLogSizeMB() * 1024 * 1024
+
+	names, err := coll.Database.CollectionNames()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if !set.NewStrings(names...).Contains(coll.Name) {
+		err := coll.Create(&mgo.CollectionInfo{Capped: true, MaxBytes: size})
+		if err != nil {
+			return errors.Annotate(err, "creating audit log collection")
+		}
+	} else {
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		if err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &stats); err != nil {
+			return errors.Annotate(err, "reading audit log collection stats")
+		}
+		if stats.Capped {
+			return nil
+		}
+		if err := coll.Database.Run(bson.D{
+			{"convertToCapped", coll.Name},
+			{"size", size},
+		}, nil); err != nil {
+			return errors.Annotate(err, "converting audit log to capped collection")
+		}
+	}
+	// Converting the collection drops its indexes, and a newly
+	// created collection has none.
+	for _, index := range allCollections()[auditingC].indexes {
+		if err := coll.EnsureIndex(index); err != nil {
+			return errors.Annotate(err, "creating audit log index")
+		}
+	}
+	return nil
+}
+
+// AddActionPruneSettings adds the model settings to control action
+// pruning if they are missing.
+func AddActionPruneSettings(st *State) error {
+	coll, closer := st.db().GetRawCollection(settingsC)
+	defer closer()
+
+	models, err := st.AllModels()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var ids []string
+	for _, m := range models {
+		ids = append(ids, m.UUID()+":e")
+	}
+
+	iter := coll.Find(bson.M{"_id": bson.M{"$in": ids}}).Iter()
+	var ops []txn.Op
+	var doc settingsDoc
+	for iter.Next(&doc) {
+		settingsChanged :=
+			maybeUpdateSettings(doc.Settings, config.MaxActionResultsAge, config.DefaultActionResultsAge)
+		settingsChanged =
+			maybeUpdateSettings(doc.Settings, config.MaxActionResultsSize, config.DefaultActionResultsSize) || settingsChanged
+		if settingsChanged {
+			ops = append(ops, txn.Op{
+				C:      settingsC,
+				Id:     doc.DocID,
+				Assert: txn.DocExists,
+				Update: bson.M{"$set": bson.M{"settings": doc.Settings}},
+			})
+		}
+	}
+	if err := iter.Close(); err != nil {
+		return errors.Trace(err)
+	}
+	if len(ops) > 0 {
+		return errors.Trace(st.runRawTransaction(ops))
+	}
+	return nil
+}
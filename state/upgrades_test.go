@@ -1805,3 +1805,123 @@ func (s *upgradesSuite) TestAddModelEnvironVersion(c *gc.C) {
 		expectUpgradedData{models, expectedModels},
 	)
 }
+
+func (s *upgradesSuite) TestConvertAuditLogToCapped(c *gc.C) {
+	coll, closer := s.state.db().GetRawCollection(auditingC)
+	defer closer()
+
+	err := coll.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+	timestamp := time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	blob, err := timestamp.MarshalText()
+	c.Assert(err, jc.ErrorIsNil)
+	err = coll.Insert(bson.M{
+		"model-uuid": s.state.ModelUUID(),
+		"timestamp":  string(blob),
+		"operation":  "status",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	assertCapped := func() {
+		var stats struct {
+			Capped bool `bson:"capped"`
+		}
+		err := coll.Database.Run(bson.D{{"collStats", coll.Name}}, &stats)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(stats.Capped, jc.IsTrue)
+
+		var docs []bson.M
+		err = coll.Find(nil).Select(bson.M{"_id": 0, "created": 1}).All(&docs)
+		c.Assert(err, jc.ErrorIsNil)
+		c.Assert(docs, jc.DeepEquals, []bson.M{{"created": timestamp.UnixNano()}})
+	}
+
+	err = ConvertAuditLogToCapped(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCapped()
+
+	// Check it's idempotent.
+	err = ConvertAuditLogToCapped(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+	assertCapped()
+}
+
+func (s *upgradesSuite) TestConvertAuditLogToCappedCreatesCollection(c *gc.C) {
+	coll, closer := s.state.db().GetRawCollection(auditingC)
+	defer closer()
+
+	err := coll.DropCollection()
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = ConvertAuditLogToCapped(s.state)
+	c.Assert(err, jc.ErrorIsNil)
+
+	var stats struct {
+		Capped bool `bson:"capped"`
+	}
+	err = coll.Database.Run(bson.D{{"collStats", coll.Name}}, &stats)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(stats.Capped, jc.IsTrue)
+}
+
+func (s *upgradesSuite) TestAddActionPruneSettings(c *gc.C) {
+	settingsColl, settingsCloser := s.state.db().GetRawCollection(settingsC)
+	defer settingsCloser()
+	_, err := settingsColl.RemoveAll(nil)
+	c.Assert(err, jc.ErrorIsNil)
+
+	m1 := s.makeModel(c, "m1", testing.Attrs{
+		"max-action-results-age":  "96h",
+		"max-action-results-size": "4G",
+	})
+	defer m1.Close()
+
+	// This model is missing the settings entirely.
+	m2 := s.makeModel(c, "m2", testing.Attrs{})
+	defer m2.Close()
+	settingsKey := m2.ModelUUID() + ":e"
+	err = settingsColl.UpdateId(settingsKey, bson.M{"$unset": bson.M{
+		"settings.max-action-results-age":  "",
+		"settings.max-action-results-size": "",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = settingsColl.Insert(bson.M{
+		"_id": "someothersettingshouldnotbetouched",
+		// non-model setting: should not be touched
+		"settings": bson.M{"key": "value"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	cfg1, err := m1.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	expected1 := cfg1.AllAttrs()
+	expected1["resource-tags"] = ""
+
+	cfg2, err := m2.ModelConfig()
+	c.Assert(err, jc.ErrorIsNil)
+	expected2 := cfg2.AllAttrs()
+	expected2["max-action-results-age"] = "336h"
+	expected2["max-action-results-size"] = "5G"
+	expected2["resource-tags"] = ""
+
+	expectedSettings := bsonMById{
+		{
+			"_id":        m1.ModelUUID() + ":e",
+			"settings":   bson.M(expected1),
+			"model-uuid": m1.ModelUUID(),
+		}, {
+			"_id":        m2.ModelUUID() + ":e",
+			"settings":   bson.M(expected2),
+			"model-uuid": m2.ModelUUID(),
+		}, {
+			"_id":      "someothersettingshouldnotbetouched",
+			"settings": bson.M{"key": "value"},
+		},
+	}
+	sort.Sort(expectedSettings)
+
+	s.assertUpgradedData(c, AddActionPruneSettings,
+		expectUpgradedData{settingsColl, expectedSettings},
+	)
+}
@@ -49,6 +49,11 @@ type Volume interface {
 
 	// Detachable reports whether or not the volume is detachable.
 	Detachable() bool
+
+	// PendingSize returns the size, in MiB, that the volume has been
+	// requested to grow to. PendingSize returns true if a resize is
+	// pending, otherwise false.
+	PendingSize() (uint64, bool)
 }
 
 // VolumeAttachment describes an attachment of a volume to a machine.
@@ -94,6 +99,11 @@ type volumeDoc struct {
 	Info            *VolumeInfo   `bson:"info,omitempty"`
 	Params          *VolumeParams `bson:"params,omitempty"`
 
+	// PendingSize is the size, in MiB, that the volume has been
+	// requested to grow to. It is cleared once the storage
+	// provisioner has resized the volume.
+	PendingSize uint64 `bson:"pendingsize,omitempty"`
+
 	// MachineId is the ID of the machine that a non-detachable
 	// volume is initially attached to. We use this to identify
 	// the volume as being non-detachable, and to determine
@@ -197,6 +207,14 @@ func (v *volume) Params() (VolumeParams, bool) {
 	return *v.doc.Params, true
 }
 
+// PendingSize is required to implement Volume.
+func (v *volume) PendingSize() (uint64, bool) {
+	if v.doc.PendingSize == 0 {
+		return 0, false
+	}
+	return v.doc.PendingSize, true
+}
+
 // Status is required to implement StatusGetter.
 func (v *volume) Status() (status.StatusInfo, error) {
 	return v.st.VolumeStatus(v.VolumeTag())
@@ -1022,6 +1040,142 @@ func (st *State) SetVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error)
 	return st.db().Run(buildTxn)
 }
 
+// RestoreVolumeInfo replaces the VolumeInfo for the specified volume
+// after the volume has been replaced by the provider with one restored
+// from a snapshot. Unlike SetVolumeInfo, the volume ID may change; the
+// volume must be provisioned, and must not be attached to any machine.
+func (st *State) RestoreVolumeInfo(tag names.VolumeTag, info VolumeInfo) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot restore info for volume %q", tag.Id())
+	if info.VolumeId == "" {
+		return errors.New("volume ID not set")
+	}
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		oldInfo, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.doc.AttachmentCount > 0 {
+			return nil, errors.New("volume is attached")
+		}
+		info.Pool = oldInfo.Pool
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: bson.D{
+				{"life", Alive},
+				{"attachmentcount", 0},
+				{"info.volumeid", oldInfo.VolumeId},
+			},
+			Update: bson.D{{"$set", bson.D{{"info", &info}}}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// ResizeVolume requests that the specified volume be grown to the given
+// size, in MiB. The volume must be provisioned, and the new size must be
+// larger than the volume's current size. The volume is resized by the
+// storage provisioner, which records the new size with SetVolumeSize.
+func (st *State) ResizeVolume(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot resize volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if v.Life() != Alive {
+			return nil, errors.New("volume is not alive")
+		}
+		info, err := v.Info()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if size <= info.Size {
+			return nil, errors.Errorf(
+				"new size %dMiB must be larger than current size %dMiB",
+				size, info.Size,
+			)
+		}
+		if pending, ok := v.PendingSize(); ok && pending == size {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:  volumesC,
+			Id: tag.Id(),
+			Assert: bson.D{
+				{"life", Alive},
+				{"info.size", info.Size},
+			},
+			Update: bson.D{{"$set", bson.D{{"pendingsize", size}}}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// SetVolumeSize records the size, in MiB, of the specified volume after
+// it has been resized. Any pending resize that the new size satisfies
+// is cleared.
+func (st *State) SetVolumeSize(tag names.VolumeTag, size uint64) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot set size for volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if _, err := v.Info(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		assert := bson.D{{"info", bson.D{{"$exists", true}}}}
+		update := bson.D{{"$set", bson.D{{"info.size", size}}}}
+		if pending, ok := v.PendingSize(); ok {
+			assert = append(assert, bson.DocElem{"pendingsize", pending})
+			if size >= pending {
+				update = append(update, bson.DocElem{"$unset", bson.D{{"pendingsize", nil}}})
+			}
+		} else {
+			assert = append(assert, bson.DocElem{"pendingsize", bson.D{{"$exists", false}}})
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: assert,
+			Update: update,
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
+// CancelVolumeResize clears any pending resize of the specified volume,
+// leaving its size unchanged. It is used by the storage provisioner when
+// the volume cannot be resized.
+func (st *State) CancelVolumeResize(tag names.VolumeTag) (err error) {
+	defer errors.DeferredAnnotatef(&err, "cannot cancel resize of volume %q", tag.Id())
+	buildTxn := func(attempt int) ([]txn.Op, error) {
+		v, err := st.volumeByTag(tag)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		pending, ok := v.PendingSize()
+		if !ok {
+			return nil, jujutxn.ErrNoOperations
+		}
+		return []txn.Op{{
+			C:      volumesC,
+			Id:     tag.Id(),
+			Assert: bson.D{{"pendingsize", pending}},
+			Update: bson.D{{"$unset", bson.D{{"pendingsize", nil}}}},
+		}}, nil
+	}
+	return st.db().Run(buildTxn)
+}
+
 func validateVolumeInfoChange(newInfo, oldInfo VolumeInfo) error {
 	if newInfo.Pool != oldInfo.Pool {
 		return errors.Errorf(
@@ -161,6 +161,106 @@ func (s *VolumeStateSuite) TestSetVolumeInfo(c *gc.C) {
 	s.assertVolumeInfo(c, volumeTag, volumeInfoSet)
 }
 
+func (s *VolumeStateSuite) TestRestoreVolumeInfo(c *gc.C) {
+	volume, machine := s.setupModelScopedVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	restoredInfo := state.VolumeInfo{VolumeId: "vol-1", Size: 2048, Persistent: true}
+	err = s.State.RestoreVolumeInfo(volumeTag, restoredInfo)
+	c.Assert(err, gc.ErrorMatches, `cannot restore info for volume "0": volume is attached`)
+
+	err = s.State.DetachVolume(machine.MachineTag(), volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.RemoveVolumeAttachment(machine.MachineTag(), volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.RestoreVolumeInfo(volumeTag, restoredInfo)
+	c.Assert(err, jc.ErrorIsNil)
+	restoredInfo.Pool = "modelscoped"
+	s.assertVolumeInfo(c, volumeTag, restoredInfo)
+}
+
+func (s *VolumeStateSuite) TestRestoreVolumeInfoUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err = s.State.RestoreVolumeInfo(volume.VolumeTag(), state.VolumeInfo{VolumeId: "vol-1"})
+	c.Assert(err, gc.ErrorMatches, `cannot restore info for volume "0/0": volume "0/0" not provisioned`)
+}
+
+func (s *VolumeStateSuite) TestResizeVolume(c *gc.C) {
+	volume, _ := s.setupModelScopedVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volumeTag, 1024)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0": new size 1024MiB must be larger than current size 1024MiB`)
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	size, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(size, gc.Equals, uint64(2048))
+
+	// A partial resize leaves the resize pending.
+	err = s.State.SetVolumeSize(volumeTag, 1536)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	_, ok = volume.PendingSize()
+	c.Assert(ok, jc.IsTrue)
+
+	err = s.State.SetVolumeSize(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	_, ok = volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "modelscoped",
+		Size:     2048,
+	})
+}
+
+func (s *VolumeStateSuite) TestCancelVolumeResize(c *gc.C) {
+	volume, _ := s.setupModelScopedVolumeAttachment(c)
+	volumeTag := volume.VolumeTag()
+	err := s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Cancelling when no resize is pending is not an error.
+	err = s.State.CancelVolumeResize(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.CancelVolumeResize(volumeTag)
+	c.Assert(err, jc.ErrorIsNil)
+	volume = s.volume(c, volumeTag)
+	_, ok := volume.PendingSize()
+	c.Assert(ok, jc.IsFalse)
+	s.assertVolumeInfo(c, volumeTag, state.VolumeInfo{
+		VolumeId: "vol-0",
+		Pool:     "modelscoped",
+		Size:     1024,
+	})
+}
+
+func (s *VolumeStateSuite) TestResizeVolumeUnprovisioned(c *gc.C) {
+	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
+	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+	volume := s.storageInstanceVolume(c, storageTag)
+
+	err = s.State.ResizeVolume(volume.VolumeTag(), 2048)
+	c.Assert(err, gc.ErrorMatches, `cannot resize volume "0/0": volume "0/0" not provisioned`)
+}
+
 func (s *VolumeStateSuite) TestSetVolumeInfoNoVolumeId(c *gc.C) {
 	_, u, storageTag := s.setupSingleStorage(c, "block", "loop-pool")
 	err := s.State.AssignUnit(u, state.AssignCleanEmpty)
@@ -275,6 +375,31 @@ func (s *VolumeStateSuite) TestWatchVolumeAttachment(c *gc.C) {
 	wc.AssertOneChange()
 }
 
+func (s *VolumeStateSuite) TestWatchModelVolumeResizes(c *gc.C) {
+	app := s.setupMixedScopeStorageApplication(c, "block")
+	u, err := app.AddUnit(state.AddUnitParams{})
+	c.Assert(err, jc.ErrorIsNil)
+	err = s.State.AssignUnit(u, state.AssignCleanEmpty)
+	c.Assert(err, jc.ErrorIsNil)
+
+	w := s.State.WatchModelVolumeResizes()
+	defer testing.AssertStop(c, w)
+	wc := testing.NewStringsWatcherC(c, s.State, w)
+	wc.AssertChangeInSingleEvent("0", "1") // initial
+	wc.AssertNoChange()
+
+	volumeTag := names.NewVolumeTag("0")
+	err = s.State.SetVolumeInfo(volumeTag, state.VolumeInfo{VolumeId: "vol-0", Size: 1024})
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+
+	err = s.State.ResizeVolume(volumeTag, 2048)
+	c.Assert(err, jc.ErrorIsNil)
+	wc.AssertChangeInSingleEvent("0")
+	wc.AssertNoChange()
+}
+
 func (s *VolumeStateSuite) TestWatchModelVolumes(c *gc.C) {
 	app := s.setupMixedScopeStorageApplication(c, "block")
 	addUnit := func() {
@@ -242,6 +242,35 @@ func (st *State) watchModelMachinestorage(collection string) StringsWatcher {
 	return newLifecycleWatcher(st, collection, members, filter, nil)
 }
 
+// WatchModelVolumeResizes returns a StringsWatcher that notifies of
+// changes to all model-scoped volumes, so that pending resizes may
+// be processed.
+func (st *State) WatchModelVolumeResizes() StringsWatcher {
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return !strings.Contains(k, "/")
+	}
+	return newCollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
+// WatchMachineVolumeResizes returns a StringsWatcher that notifies of
+// changes to all volumes scoped to the specified machine, so that
+// pending resizes may be processed.
+func (st *State) WatchMachineVolumeResizes(m names.MachineTag) StringsWatcher {
+	prefix := m.Id() + "/"
+	filter := func(id interface{}) bool {
+		k, err := st.strictLocalID(id.(string))
+		if err != nil {
+			return false
+		}
+		return strings.HasPrefix(k, prefix)
+	}
+	return newCollectionWatcher(st, colWCfg{col: volumesC, filter: filter})
+}
+
 // WatchMachineVolumes returns a StringsWatcher that notifies of changes to
 // the lifecycles of all volumes scoped to the specified machine.
 func (st *State) WatchMachineVolumes(m names.MachineTag) StringsWatcher {
@@ -1410,6 +1439,12 @@ func (st *State) WatchFilesystemAttachment(m names.MachineTag, f names.Filesyste
 	return newEntityWatcher(st, filesystemAttachmentsC, st.docID(id))
 }
 
+// WatchFilesystem returns a watcher for observing changes
+// to a filesystem, such as changes to its size.
+func (st *State) WatchFilesystem(f names.FilesystemTag) NotifyWatcher {
+	return newEntityWatcher(st, filesystemsC, st.docID(f.Id()))
+}
+
 // WatchConfigSettings returns a watcher for observing changes to the
 // unit's service configuration settings. The unit must have a charm URL
 // set before this method is called, and the returned watcher will be
@@ -0,0 +1,53 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package watchertest
+
+import "gopkg.in/tomb.v1"
+
+// NotifyWatcher is an implementation of state.NotifyWatcher that can
+// be manipulated, for testing.
+type NotifyWatcher struct {
+	T tomb.Tomb
+	C chan struct{}
+}
+
+// NewNotifyWatcher returns a new NotifyWatcher that returns the given
+// channel in its "Changes" method. NewNotifyWatcher takes ownership of
+// the channel, closing it when it is stopped.
+func NewNotifyWatcher(ch chan struct{}) *NotifyWatcher {
+	w := &NotifyWatcher{C: ch}
+	go func() {
+		defer w.T.Done()
+		defer w.T.Kill(nil)
+		defer close(ch)
+		<-w.T.Dying()
+	}()
+	return w
+}
+
+// Changes is part of the state.NotifyWatcher interface.
+func (w *NotifyWatcher) Changes() <-chan struct{} {
+	return w.C
+}
+
+// Err is part of the state.NotifyWatcher interface.
+func (w *NotifyWatcher) Err() error {
+	return w.T.Err()
+}
+
+// Stop is part of the state.NotifyWatcher interface.
+func (w *NotifyWatcher) Stop() error {
+	w.Kill()
+	return w.Wait()
+}
+
+// Kill is part of the state.NotifyWatcher interface.
+func (w *NotifyWatcher) Kill() {
+	w.T.Kill(nil)
+}
+
+// Wait is part of the state.NotifyWatcher interface.
+func (w *NotifyWatcher) Wait() error {
+	return w.T.Wait()
+}
@@ -81,6 +81,18 @@ type Provider interface {
 	ValidateConfig(*Config) error
 }
 
+// SharedFilesystemProvider is an optional interface that may be
+// implemented by a model-scoped Provider whose filesystems may be
+// attached to multiple machines at once. Attachments to shared
+// filesystems are made by the machines themselves, rather than
+// by the model storage provisioner.
+type SharedFilesystemProvider interface {
+	// SharedFilesystems reports whether or not the filesystems
+	// created by the provider may be attached to multiple
+	// machines at once.
+	SharedFilesystems() bool
+}
+
 // VolumeSource provides an interface for creating, destroying, describing,
 // attaching and detaching volumes in the environment. A VolumeSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -127,6 +139,41 @@ type VolumeSource interface {
 	DetachVolumes(params []VolumeAttachmentParams) ([]error, error)
 }
 
+// VolumeSnapshotter is an optional interface that may be implemented
+// by a VolumeSource whose volumes support point-in-time snapshots.
+type VolumeSnapshotter interface {
+	// CreateVolumeSnapshots creates snapshots of the volumes with the
+	// specified parameters.
+	CreateVolumeSnapshots(params []VolumeSnapshotParams) ([]CreateVolumeSnapshotsResult, error)
+
+	// ListVolumeSnapshots lists the snapshots of each of the volumes
+	// with the specified tags. Snapshots are identified by the tag of
+	// the volume they were taken from, rather than its provider ID, so
+	// that they are still listed after the volume has been restored.
+	ListVolumeSnapshots(volumes []names.VolumeTag) ([]ListVolumeSnapshotsResult, error)
+
+	// DeleteVolumeSnapshots deletes the snapshots with the specified
+	// provider snapshot IDs.
+	DeleteVolumeSnapshots(snapshotIds []string) ([]error, error)
+
+	// RestoreVolumeSnapshots creates replacements for volumes from
+	// their snapshots, returning information about the new volumes.
+	// The volumes being replaced must not be attached to any machine.
+	// They are left intact; it is up to the caller to destroy them
+	// once the replacements have been recorded.
+	RestoreVolumeSnapshots(params []RestoreVolumeSnapshotParams) ([]RestoreVolumeSnapshotsResult, error)
+}
+
+// VolumeResizer is an optional interface that may be implemented
+// by a VolumeSource whose volumes may be grown after creation.
+type VolumeResizer interface {
+	// ResizeVolumes grows the volumes with the specified parameters,
+	// returning the new size of each volume. Volumes may not be
+	// shrunk; ResizeVolumes must be idempotent, and succeed without
+	// change if a volume is already at least the requested size.
+	ResizeVolumes(params []VolumeResizeParams) ([]ResizeVolumesResult, error)
+}
+
 // FilesystemSource provides an interface for creating, destroying and
 // describing filesystems in the environment. A FilesystemSource is
 // configured in a particular way, and corresponds to a storage "pool".
@@ -160,6 +207,16 @@ type FilesystemSource interface {
 	DetachFilesystems(params []FilesystemAttachmentParams) ([]error, error)
 }
 
+// FilesystemResizer is an optional interface that may be implemented
+// by a FilesystemSource whose filesystems may be grown to fill their
+// backing volumes, once those volumes have been resized.
+type FilesystemResizer interface {
+	// ResizeFilesystems grows the filesystems with the specified
+	// parameters to fill their backing volumes, returning the new
+	// size of each filesystem.
+	ResizeFilesystems(params []FilesystemResizeParams) ([]ResizeFilesystemsResult, error)
+}
+
 // VolumeParams is a fully specified set of parameters for volume creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -233,6 +290,66 @@ type AttachmentParams struct {
 	ReadOnly bool
 }
 
+// VolumeSnapshotParams is a set of parameters for creating a snapshot
+// of a volume.
+type VolumeSnapshotParams struct {
+	// Volume is the tag of the volume to snapshot.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// to snapshot.
+	VolumeId string
+
+	// ResourceTags is a set of tags to set on the created snapshot,
+	// if the storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// RestoreVolumeSnapshotParams is a set of parameters for replacing a
+// volume with a new volume created from one of its snapshots.
+type RestoreVolumeSnapshotParams struct {
+	// Volume is the tag of the volume to restore.
+	Volume names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// being replaced.
+	VolumeId string
+
+	// SnapshotId is the unique provider-supplied ID for the snapshot
+	// from which the new volume is to be created.
+	SnapshotId string
+
+	// Size is the size of the volume being replaced, in MiB.
+	Size uint64
+
+	// Attributes is the set of provider-specific attributes that
+	// the volume was created with, derived from the storage pool
+	// configuration.
+	Attributes map[string]interface{}
+
+	// ResourceTags is a set of tags to set on the new volume, if the
+	// storage provider supports tags.
+	ResourceTags map[string]string
+}
+
+// VolumeResizeParams is a set of parameters for growing a volume.
+type VolumeResizeParams struct {
+	// Tag is the tag of the volume to resize.
+	Tag names.VolumeTag
+
+	// VolumeId is the unique provider-supplied ID for the volume
+	// to resize.
+	VolumeId string
+
+	// Size is the requested size of the volume, in MiB.
+	Size uint64
+
+	// Attributes is the set of provider-specific attributes that
+	// the volume was created with, derived from the storage pool
+	// configuration.
+	Attributes map[string]interface{}
+}
+
 // FilesystemParams is a fully specified set of parameters for filesystem creation,
 // derived from one or more of user-specified storage constraints, a
 // storage pool definition, and charm storage metadata.
@@ -258,6 +375,20 @@ type FilesystemParams struct {
 	ResourceTags map[string]string
 }
 
+// FilesystemResizeParams is a set of parameters for growing a filesystem
+// to fill its backing volume.
+type FilesystemResizeParams struct {
+	// Tag is the tag of the filesystem to resize.
+	Tag names.FilesystemTag
+
+	// Volume is the tag of the volume that backs the filesystem.
+	Volume names.VolumeTag
+
+	// FilesystemId is the unique provider-supplied ID for the
+	// filesystem to resize.
+	FilesystemId string
+}
+
 // FilesystemAttachmentParams is a set of parameters for filesystem attachment
 // or detachment.
 type FilesystemAttachmentParams struct {
@@ -274,6 +405,10 @@ type FilesystemAttachmentParams struct {
 	// Path is the path at which the filesystem is to be mounted on the machine that
 	// this attachment corresponds to.
 	Path string
+
+	// Attributes is a set of provider-specific options for storage
+	// attachment, as defined in the filesystem's storage pool.
+	Attributes map[string]interface{}
 }
 
 // CreateVolumesResult contains the result of a VolumeSource.CreateVolumes call
@@ -299,6 +434,39 @@ type AttachVolumesResult struct {
 	Error            error
 }
 
+// CreateVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.CreateVolumeSnapshots call for one volume.
+// Snapshot should only be used if Error is nil.
+type CreateVolumeSnapshotsResult struct {
+	Snapshot *VolumeSnapshot
+	Error    error
+}
+
+// ListVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.ListVolumeSnapshots call for one volume.
+// Snapshots should only be used if Error is nil.
+type ListVolumeSnapshotsResult struct {
+	Snapshots []VolumeSnapshot
+	Error     error
+}
+
+// RestoreVolumeSnapshotsResult contains the result of a
+// VolumeSnapshotter.RestoreVolumeSnapshots call for one volume.
+// VolumeInfo describes the replacement volume, and should only be
+// used if Error is nil.
+type RestoreVolumeSnapshotsResult struct {
+	VolumeInfo *VolumeInfo
+	Error      error
+}
+
+// ResizeVolumesResult contains the result of a VolumeResizer.ResizeVolumes
+// call for one volume. Size is the new size of the volume in MiB, and
+// should only be used if Error is nil.
+type ResizeVolumesResult struct {
+	Size  uint64
+	Error error
+}
+
 // CreateFilesystemsResult contains the result of a FilesystemSource.CreateFilesystems call
 // for one filesystem. Filesystem should only be used if Error is nil.
 type CreateFilesystemsResult struct {
@@ -312,3 +480,12 @@ type AttachFilesystemsResult struct {
 	FilesystemAttachment *FilesystemAttachment
 	Error                error
 }
+
+// ResizeFilesystemsResult contains the result of a
+// FilesystemResizer.ResizeFilesystems call for one filesystem. Size is
+// the new size of the filesystem in MiB, and should only be used if
+// Error is nil.
+type ResizeFilesystemsResult struct {
+	Size  uint64
+	Error error
+}
@@ -14,6 +14,8 @@ var (
 
 	commonStorageProviders = map[storage.ProviderType]storage.Provider{
 		LoopProviderType:   &loopProvider{logAndExec},
+		LVMProviderType:    &lvmProvider{logAndExec},
+		NFSProviderType:    &nfsProvider{logAndExec},
 		RootfsProviderType: &rootfsProvider{logAndExec},
 		TmpfsProviderType:  &tmpfsProvider{logAndExec},
 	}
@@ -28,5 +30,11 @@ func CommonStorageProviders() storage.ProviderRegistry {
 // ValidateConfig performs storage provider config validation, including
 // any common validation.
 func ValidateConfig(p storage.Provider, cfg *storage.Config) error {
+	// Volume-backed filesystems are managed by Juju, so the
+	// attributes controlling their creation are common to all
+	// providers that support volumes.
+	if _, err := newManagedFilesystemConfig(cfg.Attrs()); err != nil {
+		return errors.Trace(err)
+	}
 	return p.ValidateConfig(cfg)
 }
@@ -31,11 +31,32 @@ func (s *providerCommonSuite) TestCommonProvidersExported(c *gc.C) {
 	}
 	c.Assert(common, jc.SameContents, []storage.ProviderType{
 		provider.LoopProviderType,
+		provider.LVMProviderType,
+		provider.NFSProviderType,
 		provider.RootfsProviderType,
 		provider.TmpfsProviderType,
 	})
 }
 
+func (s *providerCommonSuite) TestValidateConfigManagedFilesystem(c *gc.C) {
+	p := provider.LoopProvider(nil)
+	validate := func(attrs map[string]interface{}) error {
+		cfg, err := storage.NewConfig("name", provider.LoopProviderType, attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		return provider.ValidateConfig(p, cfg)
+	}
+	c.Assert(validate(map[string]interface{}{
+		provider.FilesystemType: "xfs",
+		provider.MountOptions:   "noatime,nodiratime",
+	}), jc.ErrorIsNil)
+	c.Assert(validate(map[string]interface{}{
+		provider.FilesystemType: "zfs",
+	}), gc.ErrorMatches, "validating managed filesystem config: filesystem-type: .*")
+	c.Assert(validate(map[string]interface{}{
+		provider.MountOptions: "noatime, nodiratime",
+	}), gc.ErrorMatches, `mount-options "noatime, nodiratime" containing whitespace not valid`)
+}
+
 // testDetachFilesystems is a test-case for detaching filesystems that use
 // the common "maybeUnmount" method.
 func testDetachFilesystems(c *gc.C, commands *mockRunCommand, source storage.FilesystemSource, mounted bool) {
@@ -4,15 +4,30 @@
 package dummy
 
 import (
+	"fmt"
+	"sync"
+
 	"github.com/juju/errors"
 	"github.com/juju/testing"
+	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
 )
 
+var (
+	_ storage.VolumeSnapshotter = (*VolumeSource)(nil)
+	_ storage.VolumeResizer     = (*VolumeSource)(nil)
+)
+
 // VolumeSource is an implementation of storage.VolumeSource, suitable for
 // testing. Each method's default behaviour may be overridden by setting
 // the corresponding Func field.
+//
+// VolumeSource also implements storage.VolumeSnapshotter; by default,
+// snapshots are recorded in memory.
+//
+// VolumeSource also implements storage.VolumeResizer; by default,
+// volumes are resized to the requested size.
 type VolumeSource struct {
 	testing.Stub
 
@@ -23,6 +38,24 @@ type VolumeSource struct {
 	ValidateVolumeParamsFunc func(storage.VolumeParams) error
 	AttachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error)
 	DetachVolumesFunc        func([]storage.VolumeAttachmentParams) ([]error, error)
+
+	CreateVolumeSnapshotsFunc  func([]storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error)
+	ListVolumeSnapshotsFunc    func([]names.VolumeTag) ([]storage.ListVolumeSnapshotsResult, error)
+	DeleteVolumeSnapshotsFunc  func([]string) ([]error, error)
+	RestoreVolumeSnapshotsFunc func([]storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error)
+
+	ResizeVolumesFunc func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
+
+	mu        sync.Mutex
+	snapshots []volumeSnapshot
+	nextId    int
+}
+
+// volumeSnapshot records a snapshot along with the tag of the volume
+// from which it was taken.
+type volumeSnapshot struct {
+	volume   names.VolumeTag
+	snapshot storage.VolumeSnapshot
 }
 
 // CreateVolumes is defined on storage.VolumeSource.
@@ -87,3 +120,117 @@ func (s *VolumeSource) DetachVolumes(params []storage.VolumeAttachmentParams) ([
 	}
 	return nil, errors.NotImplementedf("DetachVolumes")
 }
+
+// CreateVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) CreateVolumeSnapshots(params []storage.VolumeSnapshotParams) ([]storage.CreateVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "CreateVolumeSnapshots", params)
+	if s.CreateVolumeSnapshotsFunc != nil {
+		return s.CreateVolumeSnapshotsFunc(params)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]storage.CreateVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		snapshot := storage.VolumeSnapshot{
+			SnapshotId: fmt.Sprintf("snap-%d", s.nextId),
+			VolumeId:   p.VolumeId,
+			Status:     "completed",
+		}
+		s.nextId++
+		s.snapshots = append(s.snapshots, volumeSnapshot{p.Volume, snapshot})
+		results[i].Snapshot = &snapshot
+	}
+	return results, nil
+}
+
+// ListVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) ListVolumeSnapshots(volumes []names.VolumeTag) ([]storage.ListVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "ListVolumeSnapshots", volumes)
+	if s.ListVolumeSnapshotsFunc != nil {
+		return s.ListVolumeSnapshotsFunc(volumes)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]storage.ListVolumeSnapshotsResult, len(volumes))
+	for i, volume := range volumes {
+		for _, vs := range s.snapshots {
+			if vs.volume == volume {
+				results[i].Snapshots = append(results[i].Snapshots, vs.snapshot)
+			}
+		}
+	}
+	return results, nil
+}
+
+// DeleteVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) DeleteVolumeSnapshots(snapshotIds []string) ([]error, error) {
+	s.MethodCall(s, "DeleteVolumeSnapshots", snapshotIds)
+	if s.DeleteVolumeSnapshotsFunc != nil {
+		return s.DeleteVolumeSnapshotsFunc(snapshotIds)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, id := range snapshotIds {
+		// Deleting a snapshot that does not exist is not an error.
+		for j, vs := range s.snapshots {
+			if vs.snapshot.SnapshotId == id {
+				s.snapshots = append(s.snapshots[:j], s.snapshots[j+1:]...)
+				break
+			}
+		}
+	}
+	return make([]error, len(snapshotIds)), nil
+}
+
+// RestoreVolumeSnapshots is defined on storage.VolumeSnapshotter.
+func (s *VolumeSource) RestoreVolumeSnapshots(params []storage.RestoreVolumeSnapshotParams) ([]storage.RestoreVolumeSnapshotsResult, error) {
+	s.MethodCall(s, "RestoreVolumeSnapshots", params)
+	if s.RestoreVolumeSnapshotsFunc != nil {
+		return s.RestoreVolumeSnapshotsFunc(params)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	results := make([]storage.RestoreVolumeSnapshotsResult, len(params))
+	for i, p := range params {
+		vs, ok := s.findSnapshot(p.SnapshotId)
+		if !ok {
+			results[i].Error = errors.NotFoundf("snapshot %q", p.SnapshotId)
+			continue
+		}
+		if vs.volume != p.Volume {
+			results[i].Error = errors.Errorf(
+				"snapshot %q was not taken from %s",
+				p.SnapshotId, names.ReadableString(p.Volume),
+			)
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId:   fmt.Sprintf("%s-%s", p.VolumeId, p.SnapshotId),
+			Size:       p.Size,
+			Persistent: true,
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes is defined on storage.VolumeResizer.
+func (s *VolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	s.MethodCall(s, "ResizeVolumes", params)
+	if s.ResizeVolumesFunc != nil {
+		return s.ResizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
+func (s *VolumeSource) findSnapshot(id string) (volumeSnapshot, bool) {
+	for _, vs := range s.snapshots {
+		if vs.snapshot.SnapshotId == id {
+			return vs, true
+		}
+	}
+	return volumeSnapshot{}, false
+}
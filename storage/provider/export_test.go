@@ -33,6 +33,28 @@ func LoopProvider(
 	return &loopProvider{run}
 }
 
+func LVMProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &lvmProvider{run}
+}
+
+func NFSProvider(
+	run func(string, ...string) (string, error),
+) storage.Provider {
+	return &nfsProvider{run}
+}
+
+func NFSFilesystemSource(
+	run func(string, ...string) (string, error),
+) (storage.FilesystemSource, *MockDirFuncs) {
+	dirFuncs := &MockDirFuncs{
+		osDirFuncs{run},
+		set.NewStrings(),
+	}
+	return &nfsFilesystemSource{run, dirFuncs}, dirFuncs
+}
+
 func NewMockManagedFilesystemSource(
 	run func(string, ...string) (string, error),
 	volumeBlockDevices map[names.VolumeTag]storage.BlockDevice,
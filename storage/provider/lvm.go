@@ -0,0 +1,363 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// LVMProviderType is the type of the storage provider that
+	// creates logical volumes in an existing LVM volume group.
+	LVMProviderType = storage.ProviderType("lvm")
+
+	// LVMVolumeGroup is the storage pool attribute that specifies
+	// the name of the volume group in which to create logical
+	// volumes. The volume group must already exist on the machine.
+	LVMVolumeGroup = "volume-group"
+
+	// LVMThinPool is the storage pool attribute that specifies the
+	// name of a thin pool logical volume within the volume group.
+	// If specified, thinly provisioned logical volumes are created
+	// in the thin pool.
+	LVMThinPool = "thin-pool"
+)
+
+// lvmNameRegexp matches valid LVM volume group and logical
+// volume names.
+var lvmNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9+_.][a-zA-Z0-9+_.-]*$`)
+
+var lvmConfigFields = schema.Fields{
+	LVMVolumeGroup: schema.String(),
+	LVMThinPool:    schema.String(),
+}
+
+var lvmConfigChecker = schema.FieldMap(
+	lvmConfigFields,
+	schema.Defaults{
+		LVMThinPool: "",
+	},
+)
+
+// lvmConfig holds the configuration for an lvm storage pool.
+type lvmConfig struct {
+	volumeGroup string
+	thinPool    string
+}
+
+func newLVMConfig(attrs map[string]interface{}) (*lvmConfig, error) {
+	out, err := lvmConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating lvm storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &lvmConfig{
+		volumeGroup: coerced[LVMVolumeGroup].(string),
+		thinPool:    coerced[LVMThinPool].(string),
+	}
+	if !lvmNameRegexp.MatchString(cfg.volumeGroup) {
+		return nil, errors.NotValidf("%s %q", LVMVolumeGroup, cfg.volumeGroup)
+	}
+	if cfg.thinPool != "" && !lvmNameRegexp.MatchString(cfg.thinPool) {
+		return nil, errors.NotValidf("%s %q", LVMThinPool, cfg.thinPool)
+	}
+	return cfg, nil
+}
+
+// lvmProvider creates volume sources which create logical
+// volumes in an existing LVM volume group.
+type lvmProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var _ storage.Provider = (*lvmProvider)(nil)
+
+// ValidateConfig is defined on the Provider interface.
+func (*lvmProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newLVMConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (p *lvmProvider) VolumeSource(sourceConfig *storage.Config) (storage.VolumeSource, error) {
+	cfg, err := newLVMConfig(sourceConfig.Attrs())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &lvmVolumeSource{
+		p.run,
+		cfg.volumeGroup,
+		cfg.thinPool,
+	}, nil
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (*lvmProvider) FilesystemSource(providerConfig *storage.Config) (storage.FilesystemSource, error) {
+	return nil, errors.NotSupportedf("filesystems")
+}
+
+// Supports is defined on the Provider interface.
+func (*lvmProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindBlock
+}
+
+// Scope is defined on the Provider interface.
+func (*lvmProvider) Scope() storage.Scope {
+	return storage.ScopeMachine
+}
+
+// Dynamic is defined on the Provider interface.
+func (*lvmProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*lvmProvider) DefaultPools() []*storage.Config {
+	// The volume group must be specified, so
+	// there are no sensible default pools.
+	return nil
+}
+
+// lvmVolumeSource is a storage.VolumeSource that creates
+// logical volumes in an LVM volume group.
+type lvmVolumeSource struct {
+	run         runCommandFunc
+	volumeGroup string
+	thinPool    string
+}
+
+var (
+	_ storage.VolumeSource  = (*lvmVolumeSource)(nil)
+	_ storage.VolumeResizer = (*lvmVolumeSource)(nil)
+)
+
+// CreateVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) CreateVolumes(args []storage.VolumeParams) ([]storage.CreateVolumesResult, error) {
+	results := make([]storage.CreateVolumesResult, len(args))
+	for i, arg := range args {
+		volume, err := s.createVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotate(err, "creating volume")
+			continue
+		}
+		results[i].Volume = &volume
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) createVolume(params storage.VolumeParams) (storage.Volume, error) {
+	volumeId := params.Tag.String()
+	args := []string{"--yes", "-n", volumeId}
+	if s.thinPool != "" {
+		args = append(args,
+			"-V", fmt.Sprintf("%dm", params.Size),
+			"-T", path.Join(s.volumeGroup, s.thinPool),
+		)
+	} else {
+		args = append(args,
+			"-L", fmt.Sprintf("%dm", params.Size),
+			s.volumeGroup,
+		)
+	}
+	if _, err := s.run("lvcreate", args...); err != nil {
+		return storage.Volume{}, errors.Annotatef(err, "creating logical volume %q", volumeId)
+	}
+	return storage.Volume{
+		params.Tag,
+		storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     params.Size,
+		},
+	}, nil
+}
+
+// logicalVolume returns the path of the logical volume with the
+// specified ID, relative to /dev.
+func (s *lvmVolumeSource) logicalVolume(volumeId string) string {
+	return path.Join(s.volumeGroup, volumeId)
+}
+
+// ListVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ListVolumes() ([]string, error) {
+	volumes, err := s.logicalVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	volumeIds := make([]string, 0, len(volumes))
+	for volumeId := range volumes {
+		volumeIds = append(volumeIds, volumeId)
+	}
+	return volumeIds, nil
+}
+
+// DescribeVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DescribeVolumes(volumeIds []string) ([]storage.DescribeVolumesResult, error) {
+	volumes, err := s.logicalVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.DescribeVolumesResult, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		size, ok := volumes[volumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("logical volume %q", s.logicalVolume(volumeId))
+			continue
+		}
+		results[i].VolumeInfo = &storage.VolumeInfo{
+			VolumeId: volumeId,
+			Size:     size,
+		}
+	}
+	return results, nil
+}
+
+// logicalVolumes returns the sizes, in MiB, of the Juju-managed
+// logical volumes in the volume group, keyed by volume ID.
+func (s *lvmVolumeSource) logicalVolumes() (map[string]uint64, error) {
+	stdout, err := s.run(
+		"lvs", "--noheadings", "--nosuffix", "--units", "m",
+		"-o", "lv_name,lv_size", s.volumeGroup,
+	)
+	if err != nil {
+		return nil, errors.Annotatef(err, "listing logical volumes in %q", s.volumeGroup)
+	}
+	volumes := make(map[string]uint64)
+	for _, line := range strings.Split(stdout, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if _, err := names.ParseVolumeTag(fields[0]); err != nil {
+			// Not created by Juju.
+			continue
+		}
+		size, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return nil, errors.Errorf("unexpected size %q for logical volume %q", fields[1], fields[0])
+		}
+		volumes[fields[0]] = uint64(size)
+	}
+	return volumes, nil
+}
+
+// DestroyVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error) {
+	results := make([]error, len(volumeIds))
+	for i, volumeId := range volumeIds {
+		if err := s.destroyVolume(volumeId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", volumeId)
+		}
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) destroyVolume(volumeId string) error {
+	if _, err := names.ParseVolumeTag(volumeId); err != nil {
+		return errors.Errorf("invalid lvm volume ID %q", volumeId)
+	}
+	if _, err := s.run("lvremove", "-f", s.logicalVolume(volumeId)); err != nil {
+		if strings.Contains(err.Error(), "Failed to find logical volume") {
+			// The logical volume has already been removed.
+			return nil
+		}
+		return errors.Annotate(err, "removing logical volume")
+	}
+	return nil
+}
+
+// ValidateVolumeParams is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) ValidateVolumeParams(params storage.VolumeParams) error {
+	// ValidateVolumeParams may be called on a machine other than the
+	// machine where the logical volume will be created, so we cannot
+	// check the volume group until we get to CreateVolumes.
+	return nil
+}
+
+// AttachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) AttachVolumes(args []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
+	results := make([]storage.AttachVolumesResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachVolume(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching volume %v", arg.Volume.Id())
+			continue
+		}
+		results[i].VolumeAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *lvmVolumeSource) attachVolume(arg storage.VolumeAttachmentParams) (*storage.VolumeAttachment, error) {
+	logicalVolume := s.logicalVolume(arg.VolumeId)
+	permission := "rw"
+	if arg.ReadOnly {
+		permission = "r"
+	}
+	// Logical volumes are activated when created, but may
+	// have been deactivated by a previous detachment.
+	if _, err := s.run("lvchange", "-ay", "-p", permission, logicalVolume); err != nil {
+		return nil, errors.Annotatef(err, "activating logical volume %q", logicalVolume)
+	}
+	return &storage.VolumeAttachment{
+		arg.Volume,
+		arg.Machine,
+		storage.VolumeAttachmentInfo{
+			DeviceLink: path.Join("/dev", logicalVolume),
+			ReadOnly:   arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachVolumes is defined on the VolumeSource interface.
+func (s *lvmVolumeSource) DetachVolumes(args []storage.VolumeAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		logicalVolume := s.logicalVolume(arg.VolumeId)
+		if _, err := s.run("lvchange", "-an", logicalVolume); err != nil {
+			results[i] = errors.Annotatef(err, "deactivating logical volume %q", logicalVolume)
+		}
+	}
+	return results, nil
+}
+
+// ResizeVolumes is defined on the VolumeResizer interface.
+func (s *lvmVolumeSource) ResizeVolumes(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	volumes, err := s.logicalVolumes()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	results := make([]storage.ResizeVolumesResult, len(args))
+	for i, arg := range args {
+		logicalVolume := s.logicalVolume(arg.VolumeId)
+		size, ok := volumes[arg.VolumeId]
+		if !ok {
+			results[i].Error = errors.NotFoundf("logical volume %q", logicalVolume)
+			continue
+		}
+		if size >= arg.Size {
+			// lvextend fails if the logical volume is already
+			// at least the requested size, e.g. because a previous
+			// resize succeeded but was not recorded.
+			results[i].Size = size
+			continue
+		}
+		if _, err := s.run("lvextend", "-L", fmt.Sprintf("%dm", arg.Size), logicalVolume); err != nil {
+			results[i].Error = errors.Annotatef(err, "resizing volume %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = arg.Size
+	}
+	return results, nil
+}
@@ -0,0 +1,249 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"github.com/juju/errors"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&lvmSuite{})
+
+type lvmSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *lvmSuite) TearDownTest(c *gc.C) {
+	if s.commands != nil {
+		s.commands.assertDrained()
+	}
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *lvmSuite) lvmProvider(c *gc.C) storage.Provider {
+	s.commands = &mockRunCommand{c: c}
+	return provider.LVMProvider(s.commands.run)
+}
+
+func (s *lvmSuite) lvmVolumeSource(c *gc.C, attrs map[string]interface{}) storage.VolumeSource {
+	p := s.lvmProvider(c)
+	cfg, err := storage.NewConfig("name", provider.LVMProviderType, attrs)
+	c.Assert(err, jc.ErrorIsNil)
+	source, err := p.VolumeSource(cfg)
+	c.Assert(err, jc.ErrorIsNil)
+	return source
+}
+
+func (s *lvmSuite) TestValidateConfig(c *gc.C) {
+	p := s.lvmProvider(c)
+	validate := func(attrs map[string]interface{}) error {
+		cfg, err := storage.NewConfig("name", provider.LVMProviderType, attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		return p.ValidateConfig(cfg)
+	}
+	c.Assert(validate(map[string]interface{}{
+		"volume-group": "vg0",
+	}), jc.ErrorIsNil)
+	c.Assert(validate(map[string]interface{}{
+		"volume-group": "vg0",
+		"thin-pool":    "thinpool",
+	}), jc.ErrorIsNil)
+	c.Assert(validate(map[string]interface{}{}), gc.ErrorMatches,
+		"validating lvm storage config: volume-group: expected string, got nothing")
+	c.Assert(validate(map[string]interface{}{
+		"volume-group": "-vg0",
+	}), gc.ErrorMatches, `volume-group "-vg0" not valid`)
+	c.Assert(validate(map[string]interface{}{
+		"volume-group": "vg0",
+		"thin-pool":    "thin pool",
+	}), gc.ErrorMatches, `thin-pool "thin pool" not valid`)
+}
+
+func (s *lvmSuite) TestSupports(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsTrue)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsFalse)
+}
+
+func (s *lvmSuite) TestScope(c *gc.C) {
+	p := s.lvmProvider(c)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeMachine)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *lvmSuite) TestCreateVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvcreate", "--yes", "-n", "volume-0", "-L", "2m", "vg0")
+	cmd := s.commands.expect("lvcreate", "--yes", "-n", "volume-1", "-L", "4m", "vg0")
+	cmd.respond("", errors.New("Volume group \"vg0\" has insufficient free space"))
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 2,
+	}, {
+		Tag:  names.NewVolumeTag("1"),
+		Size: 4,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.CreateVolumesResult{
+		Volume: &storage.Volume{
+			names.NewVolumeTag("0"),
+			storage.VolumeInfo{
+				VolumeId: "volume-0",
+				Size:     2,
+			},
+		},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`creating volume: creating logical volume "volume-1": Volume group "vg0" has insufficient free space`)
+}
+
+func (s *lvmSuite) TestCreateVolumesThinPool(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{
+		"volume-group": "vg0",
+		"thin-pool":    "thinpool",
+	})
+	s.commands.expect("lvcreate", "--yes", "-n", "volume-0", "-V", "1024m", "-T", "vg0/thinpool")
+
+	results, err := source.CreateVolumes([]storage.VolumeParams{{
+		Tag:  names.NewVolumeTag("0"),
+		Size: 1024,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *lvmSuite) TestDescribeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_name,lv_size", "vg0")
+	cmd.respond("  root     10240.00\n  volume-0 1024.00\n", nil)
+
+	results, err := source.DescribeVolumes([]string{"volume-0", "volume-1"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.DeepEquals, storage.DescribeVolumesResult{
+		VolumeInfo: &storage.VolumeInfo{VolumeId: "volume-0", Size: 1024},
+	})
+	c.Assert(results[1].Error, gc.ErrorMatches, `logical volume "vg0/volume-1" not found`)
+}
+
+func (s *lvmSuite) TestDestroyVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvremove", "-f", "vg0/volume-0")
+	cmd := s.commands.expect("lvremove", "-f", "vg0/volume-1")
+	cmd.respond("", errors.New(`Failed to find logical volume "vg0/volume-1"`))
+
+	results, err := source.DestroyVolumes([]string{"volume-0", "volume-1", "root"})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], jc.ErrorIsNil)
+	c.Assert(results[2], gc.ErrorMatches, `destroying "root": invalid lvm volume ID "root"`)
+}
+
+func (s *lvmSuite) TestAttachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "-ay", "-p", "rw", "vg0/volume-0")
+	s.commands.expect("lvchange", "-ay", "-p", "r", "vg0/volume-1")
+
+	results, err := source.AttachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}, {
+		Volume:   names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachVolumesResult{{
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("0"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-0",
+			},
+		},
+	}, {
+		VolumeAttachment: &storage.VolumeAttachment{
+			names.NewVolumeTag("1"),
+			names.NewMachineTag("0"),
+			storage.VolumeAttachmentInfo{
+				DeviceLink: "/dev/vg0/volume-1",
+				ReadOnly:   true,
+			},
+		},
+	}})
+}
+
+func (s *lvmSuite) TestDetachVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	s.commands.expect("lvchange", "-an", "vg0/volume-0")
+
+	results, err := source.DetachVolumes([]storage.VolumeAttachmentParams{{
+		Volume:   names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
+
+func (s *lvmSuite) TestResizeVolumes(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_name,lv_size", "vg0")
+	cmd.respond("  volume-0 1024.00\n", nil)
+	s.commands.expect("lvextend", "-L", "2048m", "vg0/volume-0")
+
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeVolumesResult{{Size: 2048}})
+}
+
+func (s *lvmSuite) TestResizeVolumesAlreadyLargeEnough(c *gc.C) {
+	source := s.lvmVolumeSource(c, map[string]interface{}{"volume-group": "vg0"})
+	cmd := s.commands.expect("lvs", "--noheadings", "--nosuffix", "--units", "m", "-o", "lv_name,lv_size", "vg0")
+	cmd.respond("  volume-0 2048.00\n  volume-1 4096.00\n", nil)
+
+	// No lvextend is expected: both logical volumes are
+	// already at least as large as requested.
+	results, err := source.(storage.VolumeResizer).ResizeVolumes([]storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("0"),
+		VolumeId: "volume-0",
+		Size:     2048,
+	}, {
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "volume-1",
+		Size:     2048,
+	}, {
+		Tag:      names.NewVolumeTag("2"),
+		VolumeId: "volume-2",
+		Size:     2048,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeVolumesResult{Size: 2048})
+	c.Assert(results[1], jc.DeepEquals, storage.ResizeVolumesResult{Size: 4096})
+	c.Assert(results[2].Error, gc.ErrorMatches, `logical volume "vg0/volume-2" not found`)
+}
@@ -6,20 +6,73 @@ package provider
 import (
 	"path"
 	"path/filepath"
+	"strings"
 	"unicode"
 
 	"github.com/juju/errors"
+	"github.com/juju/schema"
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/storage"
 )
 
 const (
+	// FilesystemType is the storage pool attribute that specifies
+	// the type of filesystem to create on volume-backed managed
+	// filesystems. If unspecified, ext4 filesystems are created.
+	FilesystemType = "filesystem-type"
+
+	// MountOptions is the storage pool attribute that specifies
+	// a comma-separated list of options to use when mounting
+	// volume-backed managed filesystems.
+	MountOptions = "mount-options"
+
 	// defaultFilesystemType is the default filesystem type
 	// to create for volume-backed managed filesystems.
 	defaultFilesystemType = "ext4"
 )
 
+var managedFilesystemConfigFields = schema.Fields{
+	FilesystemType: schema.OneOf(
+		schema.Const("ext4"),
+		schema.Const("xfs"),
+		schema.Const("btrfs"),
+	),
+	MountOptions: schema.String(),
+}
+
+var managedFilesystemConfigChecker = schema.FieldMap(
+	managedFilesystemConfigFields,
+	schema.Defaults{
+		FilesystemType: defaultFilesystemType,
+		MountOptions:   "",
+	},
+)
+
+// managedFilesystemConfig holds the storage pool attributes that
+// control how volume-backed managed filesystems are created and
+// mounted.
+type managedFilesystemConfig struct {
+	filesystemType string
+	mountOptions   string
+}
+
+func newManagedFilesystemConfig(attrs map[string]interface{}) (*managedFilesystemConfig, error) {
+	out, err := managedFilesystemConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating managed filesystem config")
+	}
+	coerced := out.(map[string]interface{})
+	mountOptions := coerced[MountOptions].(string)
+	if strings.IndexFunc(mountOptions, unicode.IsSpace) >= 0 {
+		return nil, errors.NotValidf("%s %q containing whitespace", MountOptions, mountOptions)
+	}
+	return &managedFilesystemConfig{
+		filesystemType: coerced[FilesystemType].(string),
+		mountOptions:   mountOptions,
+	}, nil
+}
+
 // managedFilesystemSource is an implementation of storage.FilesystemSource
 // that manages filesystems on volumes attached to the host machine.
 //
@@ -82,6 +135,10 @@ func (s *managedFilesystemSource) CreateFilesystems(args []storage.FilesystemPar
 }
 
 func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams) (*storage.Filesystem, error) {
+	config, err := newManagedFilesystemConfig(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -96,7 +153,7 @@ func (s *managedFilesystemSource) createFilesystem(arg storage.FilesystemParams)
 		}
 		devicePath = partitionDevicePath(devicePath)
 	}
-	if err := createFilesystem(s.run, devicePath); err != nil {
+	if err := createFilesystem(s.run, devicePath, config.filesystemType); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &storage.Filesystem{
@@ -136,6 +193,10 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if !ok {
 		return nil, errors.Errorf("filesystem %v is not yet provisioned", arg.Filesystem.Id())
 	}
+	config, err := newManagedFilesystemConfig(arg.Attributes)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
 	blockDevice, err := s.backingVolumeBlockDevice(filesystem.Volume)
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -144,7 +205,7 @@ func (s *managedFilesystemSource) attachFilesystem(arg storage.FilesystemAttachm
 	if isDiskDevice(devicePath) {
 		devicePath = partitionDevicePath(devicePath)
 	}
-	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, arg.ReadOnly); err != nil {
+	if err := mountFilesystem(s.run, s.dirFuncs, devicePath, arg.Path, config.mountOptions, arg.ReadOnly); err != nil {
 		return nil, errors.Trace(err)
 	}
 	return &storage.FilesystemAttachment{
@@ -168,6 +229,41 @@ func (s *managedFilesystemSource) DetachFilesystems(args []storage.FilesystemAtt
 	return results, nil
 }
 
+// ResizeFilesystems is defined on storage.FilesystemResizer.
+func (s *managedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		size, err := s.resizeFilesystem(arg)
+		if err != nil {
+			results[i].Error = err
+			continue
+		}
+		results[i].Size = size
+	}
+	return results, nil
+}
+
+func (s *managedFilesystemSource) resizeFilesystem(arg storage.FilesystemResizeParams) (uint64, error) {
+	if _, ok := s.filesystems[arg.Tag]; !ok {
+		return 0, errors.Errorf("filesystem %v is not yet provisioned", arg.Tag.Id())
+	}
+	blockDevice, err := s.backingVolumeBlockDevice(arg.Volume)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	devicePath := devicePath(blockDevice)
+	if isDiskDevice(devicePath) {
+		if err := growPartition(s.run, devicePath); err != nil {
+			return 0, errors.Trace(err)
+		}
+		devicePath = partitionDevicePath(devicePath)
+	}
+	if err := growFilesystem(s.run, devicePath); err != nil {
+		return 0, errors.Trace(err)
+	}
+	return blockDevice.Size, nil
+}
+
 func destroyPartitions(run runCommandFunc, devicePath string) error {
 	logger.Debugf("destroying partitions on %q", devicePath)
 	if _, err := run("sgdisk", "--zap-all", devicePath); err != nil {
@@ -186,9 +282,9 @@ func createPartition(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
-func createFilesystem(run runCommandFunc, devicePath string) error {
-	logger.Debugf("attempting to create filesystem on %q", devicePath)
-	mkfscmd := "mkfs." + defaultFilesystemType
+func createFilesystem(run runCommandFunc, devicePath, filesystemType string) error {
+	logger.Debugf("attempting to create %s filesystem on %q", filesystemType, devicePath)
+	mkfscmd := "mkfs." + filesystemType
 	_, err := run(mkfscmd, devicePath)
 	if err != nil {
 		return errors.Annotatef(err, "%s failed", mkfscmd)
@@ -197,7 +293,55 @@ func createFilesystem(run runCommandFunc, devicePath string) error {
 	return nil
 }
 
-func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint string, readOnly bool) error {
+// growPartition grows the single partition (1) on the disk with the
+// specified device path to fill the disk.
+func growPartition(run runCommandFunc, devicePath string) error {
+	logger.Debugf("growing partition on %q", devicePath)
+	if output, err := run("growpart", devicePath, "1"); err != nil {
+		// growpart exits non-zero if the partition
+		// already fills the disk; that is not an error.
+		if strings.Contains(output, "NOCHANGE") {
+			return nil
+		}
+		return errors.Annotate(err, "growpart failed")
+	}
+	return nil
+}
+
+// growFilesystem grows the filesystem on the specified device to
+// fill the device. XFS and Btrfs filesystems can only be grown
+// while mounted, so they are grown via their mount point.
+func growFilesystem(run runCommandFunc, devicePath string) error {
+	logger.Debugf("attempting to grow filesystem on %q", devicePath)
+	filesystemType, err := run("blkid", "-o", "value", "-s", "TYPE", devicePath)
+	if err != nil {
+		return errors.Annotate(err, "blkid failed")
+	}
+	switch filesystemType = strings.TrimSpace(filesystemType); filesystemType {
+	case "xfs", "btrfs":
+		mountPoint, err := run("findmnt", "-n", "-o", "TARGET", "--source", devicePath)
+		if err != nil {
+			return errors.Annotatef(err, "finding mount point of %s filesystem", filesystemType)
+		}
+		mountPoint = strings.TrimSpace(mountPoint)
+		if filesystemType == "xfs" {
+			_, err = run("xfs_growfs", mountPoint)
+		} else {
+			_, err = run("btrfs", "filesystem", "resize", "max", mountPoint)
+		}
+		if err != nil {
+			return errors.Annotatef(err, "growing %s filesystem failed", filesystemType)
+		}
+	default:
+		if _, err := run("resize2fs", devicePath); err != nil {
+			return errors.Annotate(err, "resize2fs failed")
+		}
+	}
+	logger.Infof("grew filesystem on %q", devicePath)
+	return nil
+}
+
+func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoint, mountOptions string, readOnly bool) error {
 	logger.Debugf("attempting to mount filesystem on %q at %q", devicePath, mountPoint)
 	if err := dirFuncs.mkDirAll(mountPoint, 0755); err != nil {
 		return errors.Annotate(err, "creating mount point")
@@ -210,9 +354,16 @@ func mountFilesystem(run runCommandFunc, dirFuncs dirFuncs, devicePath, mountPoi
 		logger.Debugf("filesystem on %q already mounted at %q", mountSource, mountPoint)
 		return nil
 	}
-	var args []string
+	var options []string
 	if readOnly {
-		args = append(args, "-o", "ro")
+		options = append(options, "ro")
+	}
+	if mountOptions != "" {
+		options = append(options, mountOptions)
+	}
+	var args []string
+	if len(options) > 0 {
+		args = append(args, "-o", strings.Join(options, ","))
 	}
 	args = append(args, devicePath, mountPoint)
 	if _, err := run("mount", args...); err != nil {
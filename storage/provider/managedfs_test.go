@@ -5,7 +5,9 @@ package provider_test
 
 import (
 	"path/filepath"
+	"strings"
 
+	"github.com/juju/errors"
 	jc "github.com/juju/testing/checkers"
 	gc "gopkg.in/check.v1"
 	"gopkg.in/juju/names.v2"
@@ -112,19 +114,62 @@ func (s *managedfsSuite) TestCreateFilesystemsNoBlockDevice(c *gc.C) {
 	c.Assert(results[0].Error, gc.ErrorMatches, "backing-volume 0 is not yet attached")
 }
 
+func (s *managedfsSuite) TestCreateFilesystemsFilesystemType(c *gc.C) {
+	source := s.initSource(c)
+	s.commands.expect("mkfs.xfs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       2,
+	}
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       2,
+		Attributes: map[string]interface{}{provider.FilesystemType: "xfs"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, jc.ErrorIsNil)
+}
+
+func (s *managedfsSuite) TestCreateFilesystemsInvalidFilesystemType(c *gc.C) {
+	source := s.initSource(c)
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       2,
+	}
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0/0"),
+		Volume:     names.NewVolumeTag("0"),
+		Size:       2,
+		Attributes: map[string]interface{}{provider.FilesystemType: "zfs"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results[0].Error, gc.ErrorMatches, "validating managed filesystem config: filesystem-type: .*")
+}
+
 func (s *managedfsSuite) TestAttachFilesystems(c *gc.C) {
-	s.testAttachFilesystems(c, false, false)
+	s.testAttachFilesystems(c, false, false, "")
 }
 
 func (s *managedfsSuite) TestAttachFilesystemsReadOnly(c *gc.C) {
-	s.testAttachFilesystems(c, true, false)
+	s.testAttachFilesystems(c, true, false, "")
 }
 
 func (s *managedfsSuite) TestAttachFilesystemsReattach(c *gc.C) {
-	s.testAttachFilesystems(c, true, true)
+	s.testAttachFilesystems(c, true, true, "")
+}
+
+func (s *managedfsSuite) TestAttachFilesystemsMountOptions(c *gc.C) {
+	s.testAttachFilesystems(c, false, false, "noatime,nobarrier")
 }
 
-func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool) {
+func (s *managedfsSuite) TestAttachFilesystemsReadOnlyMountOptions(c *gc.C) {
+	s.testAttachFilesystems(c, true, false, "noatime")
+}
+
+func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool, mountOptions string) {
 	const testMountPoint = "/in/the/place"
 
 	source := s.initSource(c)
@@ -135,9 +180,16 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 		cmd.respond("headers\n/different/to/rootfs", nil)
 	} else {
 		cmd.respond("headers\n/same/as/rootfs", nil)
-		var args []string
+		var options []string
 		if readOnly {
-			args = append(args, "-o", "ro")
+			options = append(options, "ro")
+		}
+		if mountOptions != "" {
+			options = append(options, mountOptions)
+		}
+		var args []string
+		if len(options) > 0 {
+			args = append(args, "-o", strings.Join(options, ","))
 		}
 		args = append(args, "/dev/sda1", testMountPoint)
 		s.commands.expect("mount", args...)
@@ -161,7 +213,8 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 			InstanceId: "inst-ance",
 			ReadOnly:   readOnly,
 		},
-		Path: testMountPoint,
+		Path:       testMountPoint,
+		Attributes: map[string]interface{}{provider.MountOptions: mountOptions},
 	}})
 	c.Assert(err, jc.ErrorIsNil)
 	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
@@ -176,6 +229,99 @@ func (s *managedfsSuite) testAttachFilesystems(c *gc.C, readOnly, reattach bool)
 	}})
 }
 
+func (s *managedfsSuite) TestResizeFilesystems(c *gc.C) {
+	source := s.initSource(c)
+	// sda's partition is grown before the filesystem.
+	s.commands.expect("growpart", "/dev/sda", "1")
+	cmd := s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/sda1")
+	cmd.respond("ext4\n", nil)
+	s.commands.expect("resize2fs", "/dev/sda1")
+	// xvdf1's partition already fills the disk.
+	cmd = s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/xvdf1")
+	cmd.respond("ext4\n", nil)
+	s.commands.expect("resize2fs", "/dev/xvdf1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.blockDevices[names.NewVolumeTag("1")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       6,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	s.filesystems[names.NewFilesystemTag("0/1")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}, {
+		Tag:    names.NewFilesystemTag("0/1"),
+		Volume: names.NewVolumeTag("1"),
+	}, {
+		Tag:    names.NewFilesystemTag("0/2"),
+		Volume: names.NewVolumeTag("2"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 3)
+	c.Assert(results[0], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 4})
+	c.Assert(results[1], jc.DeepEquals, storage.ResizeFilesystemsResult{Size: 6})
+	c.Assert(results[2].Error, gc.ErrorMatches, "filesystem 0/2 is not yet provisioned")
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsPartitionUnchanged(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("growpart", "/dev/sda", "1")
+	cmd.respond("NOCHANGE: partition 1 is size 4096. it cannot be grown", errors.New("exit status 1"))
+	cmd = s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/sda1")
+	cmd.respond("ext4\n", nil)
+	s.commands.expect("resize2fs", "/dev/sda1")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "sda",
+		Size:       4,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{Size: 4}})
+}
+
+func (s *managedfsSuite) TestResizeFilesystemsXFS(c *gc.C) {
+	source := s.initSource(c)
+	cmd := s.commands.expect("blkid", "-o", "value", "-s", "TYPE", "/dev/xvdf1")
+	cmd.respond("xfs\n", nil)
+	cmd = s.commands.expect("findmnt", "-n", "-o", "TARGET", "--source", "/dev/xvdf1")
+	cmd.respond("/srv/data\n", nil)
+	s.commands.expect("xfs_growfs", "/srv/data")
+
+	s.blockDevices[names.NewVolumeTag("0")] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       4,
+	}
+	s.filesystems[names.NewFilesystemTag("0/0")] = storage.Filesystem{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}
+	results, err := source.(storage.FilesystemResizer).ResizeFilesystems([]storage.FilesystemResizeParams{{
+		Tag:    names.NewFilesystemTag("0/0"),
+		Volume: names.NewVolumeTag("0"),
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.ResizeFilesystemsResult{{Size: 4}})
+}
+
 func (s *managedfsSuite) TestDetachFilesystems(c *gc.C) {
 	source := s.initSource(c)
 	testDetachFilesystems(c, s.commands, source, true)
@@ -0,0 +1,347 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider
+
+import (
+	"path"
+	"strings"
+
+	"github.com/juju/errors"
+	"github.com/juju/schema"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+)
+
+const (
+	// NFSProviderType is the type of the storage provider that
+	// creates filesystems as directories exported by an NFS
+	// server. NFS filesystems may be shared by multiple units
+	// of an application.
+	NFSProviderType = storage.ProviderType("nfs")
+
+	// NFSServer is the storage pool attribute that specifies
+	// the hostname or address of the NFS server.
+	NFSServer = "server"
+
+	// NFSExport is the storage pool attribute that specifies
+	// the absolute path of the directory exported by the NFS
+	// server, in which filesystems are created.
+	NFSExport = "export"
+)
+
+var nfsConfigFields = schema.Fields{
+	NFSServer:    schema.String(),
+	NFSExport:    schema.String(),
+	MountOptions: schema.String(),
+}
+
+var nfsConfigChecker = schema.FieldMap(
+	nfsConfigFields,
+	schema.Defaults{
+		MountOptions: "",
+	},
+)
+
+// nfsConfig holds the configuration for an nfs storage pool.
+type nfsConfig struct {
+	server       string
+	export       string
+	mountOptions string
+}
+
+func newNFSConfig(attrs map[string]interface{}) (*nfsConfig, error) {
+	out, err := nfsConfigChecker.Coerce(attrs, nil)
+	if err != nil {
+		return nil, errors.Annotate(err, "validating nfs storage config")
+	}
+	coerced := out.(map[string]interface{})
+	cfg := &nfsConfig{
+		server:       coerced[NFSServer].(string),
+		export:       coerced[NFSExport].(string),
+		mountOptions: coerced[MountOptions].(string),
+	}
+	if cfg.server == "" || strings.ContainsAny(cfg.server, ":/") {
+		return nil, errors.NotValidf("%s %q", NFSServer, cfg.server)
+	}
+	if !path.IsAbs(cfg.export) {
+		return nil, errors.NotValidf("%s %q (must be an absolute path)", NFSExport, cfg.export)
+	}
+	return cfg, nil
+}
+
+// source returns the NFS mount source for the specified
+// path, relative to the export directory.
+func (cfg *nfsConfig) source(relPath string) string {
+	return cfg.server + ":" + path.Join(cfg.export, relPath)
+}
+
+// nfsProvider creates filesystem sources which create filesystems
+// as directories exported by an existing NFS server.
+type nfsProvider struct {
+	// run is a function used for running commands on the local machine.
+	run runCommandFunc
+}
+
+var (
+	_ storage.Provider                 = (*nfsProvider)(nil)
+	_ storage.SharedFilesystemProvider = (*nfsProvider)(nil)
+)
+
+// ValidateConfig is defined on the Provider interface.
+func (*nfsProvider) ValidateConfig(cfg *storage.Config) error {
+	_, err := newNFSConfig(cfg.Attrs())
+	return errors.Trace(err)
+}
+
+// VolumeSource is defined on the Provider interface.
+func (*nfsProvider) VolumeSource(providerConfig *storage.Config) (storage.VolumeSource, error) {
+	return nil, errors.NotSupportedf("volumes")
+}
+
+// FilesystemSource is defined on the Provider interface.
+func (p *nfsProvider) FilesystemSource(sourceConfig *storage.Config) (storage.FilesystemSource, error) {
+	// The NFS server and export are taken from the parameters of
+	// each operation, as filesystem sources are shared by all of
+	// the pools for a provider.
+	return &nfsFilesystemSource{
+		p.run,
+		&osDirFuncs{p.run},
+	}, nil
+}
+
+// Supports is defined on the Provider interface.
+func (*nfsProvider) Supports(k storage.StorageKind) bool {
+	return k == storage.StorageKindFilesystem
+}
+
+// Scope is defined on the Provider interface.
+func (*nfsProvider) Scope() storage.Scope {
+	return storage.ScopeEnviron
+}
+
+// Dynamic is defined on the Provider interface.
+func (*nfsProvider) Dynamic() bool {
+	return true
+}
+
+// DefaultPools is defined on the Provider interface.
+func (*nfsProvider) DefaultPools() []*storage.Config {
+	// The NFS server and export must be specified,
+	// so there are no sensible default pools.
+	return nil
+}
+
+// SharedFilesystems is defined on the SharedFilesystemProvider interface.
+func (*nfsProvider) SharedFilesystems() bool {
+	return true
+}
+
+// nfsFilesystemSource is a storage.FilesystemSource that creates
+// filesystems as directories within an NFS export, and mounts
+// them on the machines to which they are attached.
+//
+// The model storage provisioner never mounts the export, as the
+// server and export are supplied by users and the controller must
+// not mount arbitrary NFS sources. Creating a filesystem only
+// records its NFS mount source as the filesystem ID; the directory
+// is created by the machine to which the filesystem is attached.
+// Destroying a filesystem leaves the directory on the NFS server,
+// for the server's administrator to remove.
+type nfsFilesystemSource struct {
+	run      runCommandFunc
+	dirFuncs dirFuncs
+}
+
+var _ storage.FilesystemSource = (*nfsFilesystemSource)(nil)
+
+// ValidateFilesystemParams is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) ValidateFilesystemParams(params storage.FilesystemParams) error {
+	if _, err := newNFSConfig(params.Attributes); err != nil {
+		return errors.Trace(err)
+	}
+	if params.ResourceTags[tags.JujuModel] == "" {
+		return errors.New("model UUID not specified")
+	}
+	return nil
+}
+
+// CreateFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) CreateFilesystems(args []storage.FilesystemParams) ([]storage.CreateFilesystemsResult, error) {
+	results := make([]storage.CreateFilesystemsResult, len(args))
+	for i, arg := range args {
+		filesystem, err := s.createFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "creating filesystem %s", arg.Tag.Id())
+			continue
+		}
+		results[i].Filesystem = filesystem
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) createFilesystem(params storage.FilesystemParams) (*storage.Filesystem, error) {
+	if err := s.ValidateFilesystemParams(params); err != nil {
+		return nil, errors.Trace(err)
+	}
+	// ValidateFilesystemParams has validated the config.
+	cfg, _ := newNFSConfig(params.Attributes)
+
+	// Filesystems are created in a directory named after the
+	// model, so that multiple models may share an export. The
+	// directory is created when the filesystem is first attached.
+	relPath := path.Join(params.ResourceTags[tags.JujuModel], params.Tag.String())
+	return &storage.Filesystem{
+		params.Tag,
+		names.VolumeTag{},
+		storage.FilesystemInfo{
+			FilesystemId: cfg.source(relPath),
+			// NFS exports have no per-directory quota,
+			// so we report the size that was requested.
+			Size: params.Size,
+		},
+	}, nil
+}
+
+// DestroyFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DestroyFilesystems(filesystemIds []string) ([]error, error) {
+	results := make([]error, len(filesystemIds))
+	for i, filesystemId := range filesystemIds {
+		if err := s.destroyFilesystem(filesystemId); err != nil {
+			results[i] = errors.Annotatef(err, "destroying %q", filesystemId)
+		}
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) destroyFilesystem(filesystemId string) error {
+	if _, _, err := parseNFSFilesystemId(filesystemId); err != nil {
+		return errors.Trace(err)
+	}
+	// Removing the directory would require mounting the export
+	// on the controller, so it is left on the NFS server.
+	logger.Infof("leaving directory %q on NFS server", filesystemId)
+	return nil
+}
+
+// parseNFSFilesystemId parses an NFS filesystem ID, returning the
+// NFS server and the absolute path of the filesystem's directory.
+// The directory is always two levels below the export directory,
+// as created by createFilesystem.
+func parseNFSFilesystemId(filesystemId string) (server, dir string, _ error) {
+	sep := strings.Index(filesystemId, ":")
+	if sep <= 0 {
+		return "", "", errors.Errorf("invalid nfs filesystem ID %q", filesystemId)
+	}
+	server, dir = filesystemId[:sep], filesystemId[sep+1:]
+	if !path.IsAbs(dir) || path.Clean(dir) != dir || path.Dir(dir) == "/" {
+		return "", "", errors.Errorf("invalid nfs filesystem ID %q", filesystemId)
+	}
+	return server, dir, nil
+}
+
+// ensureDirectory creates the directory for the filesystem with
+// the specified ID, if it does not already exist. This is done on
+// the machine to which the filesystem is being attached, by
+// mounting the export directory and creating the directory within
+// it.
+func (s *nfsFilesystemSource) ensureDirectory(filesystemId, mountOptions string) error {
+	server, dir, err := parseNFSFilesystemId(filesystemId)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	exportDir := path.Dir(path.Dir(dir))
+	relPath := strings.TrimPrefix(dir, exportDir)
+	return s.withMountedExport(server+":"+exportDir, mountOptions, func(mountDir string) error {
+		_, err := s.run("mkdir", "-p", path.Join(mountDir, relPath))
+		return errors.Annotate(err, "creating directory")
+	})
+}
+
+// withMountedExport mounts the specified NFS source at a temporary
+// directory, and calls f with the path of the directory. The source
+// is unmounted, and the temporary directory removed, before returning.
+func (s *nfsFilesystemSource) withMountedExport(source, mountOptions string, f func(dir string) error) (err error) {
+	dir, err := s.run("mktemp", "-d")
+	if err != nil {
+		return errors.Annotate(err, "creating temporary directory")
+	}
+	dir = strings.TrimSpace(dir)
+	defer func() {
+		if _, rmErr := s.run("rmdir", dir); rmErr != nil && err == nil {
+			err = errors.Annotate(rmErr, "removing temporary directory")
+		}
+	}()
+	mountArgs := []string{"-t", "nfs"}
+	if mountOptions != "" {
+		mountArgs = append(mountArgs, "-o", mountOptions)
+	}
+	if _, err := s.run("mount", append(mountArgs, source, dir)...); err != nil {
+		return errors.Annotatef(err, "mounting %q", source)
+	}
+	defer func() {
+		if _, umountErr := s.run("umount", dir); umountErr != nil && err == nil {
+			err = errors.Annotatef(umountErr, "unmounting %q", source)
+		}
+	}()
+	return f(dir)
+}
+
+// AttachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) AttachFilesystems(args []storage.FilesystemAttachmentParams) ([]storage.AttachFilesystemsResult, error) {
+	results := make([]storage.AttachFilesystemsResult, len(args))
+	for i, arg := range args {
+		attachment, err := s.attachFilesystem(arg)
+		if err != nil {
+			results[i].Error = errors.Annotatef(err, "attaching filesystem %s", arg.Filesystem.Id())
+			continue
+		}
+		results[i].FilesystemAttachment = attachment
+	}
+	return results, nil
+}
+
+func (s *nfsFilesystemSource) attachFilesystem(arg storage.FilesystemAttachmentParams) (*storage.FilesystemAttachment, error) {
+	if arg.Path == "" {
+		return nil, errNoMountPoint
+	}
+	// The pool attributes are only needed for the mount options;
+	// the NFS source is recorded in the filesystem ID.
+	var mountOptions string
+	if len(arg.Attributes) > 0 {
+		cfg, err := newNFSConfig(arg.Attributes)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		mountOptions = cfg.mountOptions
+	}
+	if err := s.ensureDirectory(arg.FilesystemId, mountOptions); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if err := mountFilesystem(
+		s.run, s.dirFuncs, arg.FilesystemId, arg.Path, mountOptions, arg.ReadOnly,
+	); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &storage.FilesystemAttachment{
+		arg.Filesystem,
+		arg.Machine,
+		storage.FilesystemAttachmentInfo{
+			arg.Path,
+			arg.ReadOnly,
+		},
+	}, nil
+}
+
+// DetachFilesystems is defined on the FilesystemSource interface.
+func (s *nfsFilesystemSource) DetachFilesystems(args []storage.FilesystemAttachmentParams) ([]error, error) {
+	results := make([]error, len(args))
+	for i, arg := range args {
+		if err := maybeUnmount(s.run, s.dirFuncs, arg.Path); err != nil {
+			results[i] = err
+		}
+	}
+	return results, nil
+}
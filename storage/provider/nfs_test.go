@@ -0,0 +1,237 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package provider_test
+
+import (
+	"errors"
+
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/names.v2"
+
+	"github.com/juju/juju/environs/tags"
+	"github.com/juju/juju/storage"
+	"github.com/juju/juju/storage/provider"
+	"github.com/juju/juju/testing"
+)
+
+var _ = gc.Suite(&nfsSuite{})
+
+type nfsSuite struct {
+	testing.BaseSuite
+	commands *mockRunCommand
+}
+
+func (s *nfsSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.commands = &mockRunCommand{c: c}
+}
+
+func (s *nfsSuite) TearDownTest(c *gc.C) {
+	s.commands.assertDrained()
+	s.BaseSuite.TearDownTest(c)
+}
+
+func (s *nfsSuite) nfsFilesystemSource(c *gc.C) storage.FilesystemSource {
+	source, _ := provider.NFSFilesystemSource(s.commands.run)
+	return source
+}
+
+var nfsAttrs = map[string]interface{}{
+	"server": "nfs.example.com",
+	"export": "/srv/juju",
+}
+
+func (s *nfsSuite) TestValidateConfig(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	validate := func(attrs map[string]interface{}) error {
+		cfg, err := storage.NewConfig("name", provider.NFSProviderType, attrs)
+		c.Assert(err, jc.ErrorIsNil)
+		return p.ValidateConfig(cfg)
+	}
+	c.Assert(validate(nfsAttrs), jc.ErrorIsNil)
+	c.Assert(validate(map[string]interface{}{
+		"server":        "10.0.0.1",
+		"export":        "/srv/juju",
+		"mount-options": "vers=4,hard",
+	}), jc.ErrorIsNil)
+	c.Assert(validate(map[string]interface{}{
+		"export": "/srv/juju",
+	}), gc.ErrorMatches, "validating nfs storage config: server: expected string, got nothing")
+	c.Assert(validate(map[string]interface{}{
+		"server": "nfs.example.com:/srv",
+		"export": "/srv/juju",
+	}), gc.ErrorMatches, `server "nfs.example.com:/srv" not valid`)
+	c.Assert(validate(map[string]interface{}{
+		"server": "nfs.example.com",
+		"export": "srv/juju",
+	}), gc.ErrorMatches, `export "srv/juju" \(must be an absolute path\) not valid`)
+}
+
+func (s *nfsSuite) TestSupports(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	c.Assert(p.Supports(storage.StorageKindBlock), jc.IsFalse)
+	c.Assert(p.Supports(storage.StorageKindFilesystem), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestScope(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	c.Assert(p.Scope(), gc.Equals, storage.ScopeEnviron)
+	c.Assert(p.Dynamic(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestSharedFilesystems(c *gc.C) {
+	p := provider.NFSProvider(s.commands.run)
+	shared, ok := p.(storage.SharedFilesystemProvider)
+	c.Assert(ok, jc.IsTrue)
+	c.Assert(shared.SharedFilesystems(), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestCreateFilesystems(c *gc.C) {
+	// No commands are expected: the export must not be
+	// mounted by the model storage provisioner.
+	source := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:          names.NewFilesystemTag("0"),
+		Size:         1024,
+		Provider:     provider.NFSProviderType,
+		Attributes:   nfsAttrs,
+		ResourceTags: map[string]string{tags.JujuModel: "deadbeef"},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.CreateFilesystemsResult{{
+		Filesystem: &storage.Filesystem{
+			Tag: names.NewFilesystemTag("0"),
+			FilesystemInfo: storage.FilesystemInfo{
+				FilesystemId: "nfs.example.com:/srv/juju/deadbeef/filesystem-0",
+				Size:         1024,
+			},
+		},
+	}})
+}
+
+func (s *nfsSuite) TestCreateFilesystemsNoModelUUID(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	results, err := source.CreateFilesystems([]storage.FilesystemParams{{
+		Tag:        names.NewFilesystemTag("0"),
+		Size:       1024,
+		Provider:   provider.NFSProviderType,
+		Attributes: nfsAttrs,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 1)
+	c.Assert(results[0].Error, gc.ErrorMatches, "creating filesystem 0: model UUID not specified")
+}
+
+func (s *nfsSuite) TestDestroyFilesystems(c *gc.C) {
+	// No commands are expected: the directory is left on
+	// the NFS server, rather than mounting the export on
+	// the controller to remove it.
+	source := s.nfsFilesystemSource(c)
+	results, err := source.DestroyFilesystems([]string{
+		"nfs.example.com:/srv/juju/deadbeef/filesystem-0",
+		"filesystem-1",
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0], jc.ErrorIsNil)
+	c.Assert(results[1], gc.ErrorMatches, `destroying "filesystem-1": invalid nfs filesystem ID "filesystem-1"`)
+}
+
+func (s *nfsSuite) TestAttachFilesystems(c *gc.C) {
+	const testMountPoint = "/srv/data"
+	source, dirFuncs := provider.NFSFilesystemSource(s.commands.run)
+	cmd := s.commands.expect("mktemp", "-d")
+	cmd.respond("/tmp/nfs123\n", nil)
+	s.commands.expect("mount", "-t", "nfs", "-o", "vers=4", "nfs.example.com:/srv/juju", "/tmp/nfs123")
+	s.commands.expect("mkdir", "-p", "/tmp/nfs123/deadbeef/filesystem-0")
+	s.commands.expect("umount", "/tmp/nfs123")
+	s.commands.expect("rmdir", "/tmp/nfs123")
+	cmd = s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\n/dev/sda1", nil)
+	s.commands.expect("mount", "-o", "ro,vers=4",
+		"nfs.example.com:/srv/juju/deadbeef/filesystem-0", testMountPoint,
+	)
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/juju/deadbeef/filesystem-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine:  names.NewMachineTag("0"),
+			ReadOnly: true,
+		},
+		Path: testMountPoint,
+		Attributes: map[string]interface{}{
+			"server":        "nfs.example.com",
+			"export":        "/srv/juju",
+			"mount-options": "vers=4",
+		},
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []storage.AttachFilesystemsResult{{
+		FilesystemAttachment: &storage.FilesystemAttachment{
+			names.NewFilesystemTag("0"),
+			names.NewMachineTag("0"),
+			storage.FilesystemAttachmentInfo{
+				Path:     testMountPoint,
+				ReadOnly: true,
+			},
+		},
+	}})
+	c.Assert(dirFuncs.Dirs.Contains(testMountPoint), jc.IsTrue)
+}
+
+func (s *nfsSuite) TestAttachFilesystemsMountFails(c *gc.C) {
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("mktemp", "-d")
+	cmd.respond("/tmp/nfs123\n", nil)
+	cmd = s.commands.expect("mount", "-t", "nfs", "nfs.example.com:/srv/juju", "/tmp/nfs123")
+	cmd.respond("", errors.New("access denied by server"))
+	s.commands.expect("rmdir", "/tmp/nfs123")
+
+	results, err := source.AttachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/juju/deadbeef/filesystem-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: "/srv/data",
+	}, {
+		Filesystem:   names.NewFilesystemTag("1"),
+		FilesystemId: "nfs.example.com:/filesystem-1",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: "/srv/data1",
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, gc.HasLen, 2)
+	c.Assert(results[0].Error, gc.ErrorMatches,
+		`attaching filesystem 0: mounting "nfs.example.com:/srv/juju": access denied by server`)
+	c.Assert(results[1].Error, gc.ErrorMatches,
+		`attaching filesystem 1: invalid nfs filesystem ID "nfs.example.com:/filesystem-1"`)
+}
+
+func (s *nfsSuite) TestDetachFilesystems(c *gc.C) {
+	const testMountPoint = "/srv/data"
+	source := s.nfsFilesystemSource(c)
+	cmd := s.commands.expect("df", "--output=source", "/srv")
+	cmd.respond("headers\n/dev/sda1", nil)
+	cmd = s.commands.expect("df", "--output=source", testMountPoint)
+	cmd.respond("headers\nnfs.example.com:/srv/juju/deadbeef/filesystem-0", nil)
+	s.commands.expect("umount", testMountPoint)
+
+	results, err := source.DetachFilesystems([]storage.FilesystemAttachmentParams{{
+		Filesystem:   names.NewFilesystemTag("0"),
+		FilesystemId: "nfs.example.com:/srv/juju/deadbeef/filesystem-0",
+		AttachmentParams: storage.AttachmentParams{
+			Machine: names.NewMachineTag("0"),
+		},
+		Path: testMountPoint,
+	}})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(results, jc.DeepEquals, []error{nil})
+}
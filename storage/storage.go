@@ -47,4 +47,10 @@ type StorageAttachmentInfo struct {
 	// for a filesystem-kind storage attachment, and the device path
 	// for a block-kind.
 	Location string
+
+	// Size is the size of the attached storage in MiB, if it is
+	// known: the size of the filesystem for a filesystem-kind
+	// storage attachment, and the size of the block device for
+	// a block-kind.
+	Size uint64
 }
@@ -3,7 +3,11 @@
 
 package storage
 
-import "gopkg.in/juju/names.v2"
+import (
+	"time"
+
+	"gopkg.in/juju/names.v2"
+)
 
 // Volume identifies and describes a volume (disk, logical volume, etc.)
 type Volume struct {
@@ -36,6 +40,26 @@ type VolumeInfo struct {
 	Persistent bool
 }
 
+// VolumeSnapshot describes a point-in-time snapshot of a volume.
+type VolumeSnapshot struct {
+	// SnapshotId is a unique provider-supplied ID for the snapshot.
+	SnapshotId string
+
+	// VolumeId is the provider-supplied ID of the volume from which
+	// the snapshot was taken.
+	VolumeId string
+
+	// Size is the size of the snapshotted volume, in MiB.
+	Size uint64
+
+	// Status is the provider-specific status of the snapshot,
+	// e.g. "pending" or "completed".
+	Status string
+
+	// Created is the time at which the snapshot was started.
+	Created time.Time
+}
+
 // VolumeAttachment identifies and describes machine-specific volume
 // attachment information, including how the volume is exposed on the
 // machine.
@@ -33,6 +33,8 @@ type StateBackend interface {
 	AddUpdateStatusHookSettings() error
 	CorrectRelationUnitCounts() error
 	AddModelEnvironVersion() error
+	ConvertAuditLogToCapped() error
+	AddActionPruneSettings() error
 }
 
 // Model is an interface providing access to the details of a model within the
@@ -127,6 +129,14 @@ func (s stateBackend) AddModelEnvironVersion() error {
 	return state.AddModelEnvironVersion(s.st)
 }
 
+func (s stateBackend) ConvertAuditLogToCapped() error {
+	return state.ConvertAuditLogToCapped(s.st)
+}
+
+func (s stateBackend) AddActionPruneSettings() error {
+	return state.AddActionPruneSettings(s.st)
+}
+
 type modelShim struct {
 	st *state.State
 	m  *state.Model
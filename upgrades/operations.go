@@ -23,6 +23,7 @@ var stateUpgradeOperations = func() []Operation {
 		upgradeToVersion{version.MustParse("2.1.0"), stateStepsFor21()},
 		upgradeToVersion{version.MustParse("2.2.0"), stateStepsFor22()},
 		upgradeToVersion{version.MustParse("2.2.1"), stateStepsFor221()},
+		upgradeToVersion{version.MustParse("2.3.0"), stateStepsFor23()},
 	}
 	return steps
 }
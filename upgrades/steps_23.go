@@ -0,0 +1,24 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package upgrades
+
+// stateStepsFor23 returns upgrade steps for Juju 2.3.0 that manipulate state directly.
+func stateStepsFor23() []Step {
+	return []Step{
+		&upgradeStep{
+			description: "convert audit log to capped collection",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().ConvertAuditLogToCapped()
+			},
+		},
+		&upgradeStep{
+			description: "add action pruning settings",
+			targets:     []Target{DatabaseMaster},
+			run: func(context Context) error {
+				return context.State().AddActionPruneSettings()
+			},
+		},
+	}
+}
@@ -0,0 +1,101 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+
+	apiactionscheduler "github.com/juju/juju/api/actionscheduler"
+	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/catacomb"
+)
+
+// Facade represents an API that enqueues scheduled actions.
+type Facade interface {
+	RunDueActions() error
+}
+
+// Config holds all necessary attributes to start an action scheduler
+// worker.
+type Config struct {
+	Facade Facade
+	Clock  clock.Clock
+
+	// Interval is how often the worker checks for due actions, and so
+	// determines how promptly scheduled actions are enqueued.
+	Interval time.Duration
+}
+
+// Validate will err unless basic requirements for a valid
+// config are met.
+func (c *Config) Validate() error {
+	if c.Facade == nil {
+		return errors.New("missing Facade")
+	}
+	if c.Clock == nil {
+		return errors.New("missing Clock")
+	}
+	if c.Interval <= 0 {
+		return errors.New("non-positive Interval")
+	}
+	return nil
+}
+
+// New returns a worker.Worker that enqueues scheduled actions.
+func New(conf Config) (worker.Worker, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	w := &Worker{
+		config: conf,
+	}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &w.catacomb,
+		Work: w.loop,
+	})
+	return w, errors.Trace(err)
+}
+
+// NewFacade returns a new action scheduler facade.
+func NewFacade(caller base.APICaller) Facade {
+	return apiactionscheduler.NewFacade(caller)
+}
+
+// Worker enqueues scheduled actions once they are due.
+type Worker struct {
+	catacomb catacomb.Catacomb
+	config   Config
+}
+
+// Kill is defined on worker.Worker.
+func (w *Worker) Kill() {
+	w.catacomb.Kill(nil)
+}
+
+// Wait is defined on worker.Worker.
+func (w *Worker) Wait() error {
+	return w.catacomb.Wait()
+}
+
+func (w *Worker) loop() error {
+	timer := w.config.Clock.NewTimer(w.config.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-w.catacomb.Dying():
+			return w.catacomb.ErrDying()
+
+		case <-timer.Chan():
+			if err := w.config.Facade.RunDueActions(); err != nil {
+				return errors.Annotate(err, "running scheduled actions")
+			}
+			timer.Reset(w.config.Interval)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package actionscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/actionscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type actionSchedulerSuite struct {
+	coretesting.BaseSuite
+}
+
+var _ = gc.Suite(&actionSchedulerSuite{})
+
+func (s *actionSchedulerSuite) startWorker(c *gc.C, facade *fakeFacade) (worker.Worker, *testing.Clock) {
+	clock := testing.NewClock(time.Time{})
+	w, err := actionscheduler.New(actionscheduler.Config{
+		Facade:   facade,
+		Clock:    clock,
+		Interval: time.Minute,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	return w, clock
+}
+
+func (s *actionSchedulerSuite) TestValidate(c *gc.C) {
+	_, err := actionscheduler.New(actionscheduler.Config{
+		Facade: newFakeFacade(nil),
+		Clock:  testing.NewClock(time.Time{}),
+	})
+	c.Assert(err, gc.ErrorMatches, "non-positive Interval")
+}
+
+func (s *actionSchedulerSuite) TestRunsDueActionsAtInterval(c *gc.C) {
+	facade := newFakeFacade(nil)
+	w, clock := s.startWorker(c, facade)
+	defer workertest.CleanKill(c, w)
+
+	for i := 0; i < 2; i++ {
+		err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+		c.Assert(err, jc.ErrorIsNil)
+		select {
+		case <-facade.called:
+		case <-time.After(coretesting.LongWait):
+			c.Fatal("timed out waiting for call to RunDueActions")
+		}
+	}
+}
+
+func (s *actionSchedulerSuite) TestWontRunBeforeInterval(c *gc.C) {
+	facade := newFakeFacade(nil)
+	w, _ := s.startWorker(c, facade)
+	defer workertest.CleanKill(c, w)
+
+	select {
+	case <-facade.called:
+		c.Fatal("called before firing timer")
+	case <-time.After(coretesting.ShortWait):
+	}
+}
+
+func (s *actionSchedulerSuite) TestFacadeError(c *gc.C) {
+	facade := newFakeFacade(errors.New("boom"))
+	w, clock := s.startWorker(c, facade)
+
+	err := clock.WaitAdvance(time.Minute, coretesting.LongWait, 1)
+	c.Assert(err, jc.ErrorIsNil)
+	err = workertest.CheckKilled(c, w)
+	c.Assert(err, gc.ErrorMatches, "running scheduled actions: boom")
+}
+
+type fakeFacade struct {
+	called chan struct{}
+	err    error
+}
+
+func newFakeFacade(err error) *fakeFacade {
+	return &fakeFacade{
+		called: make(chan struct{}, 1),
+		err:    err,
+	}
+}
+
+// RunDueActions implements Facade.
+func (f *fakeFacade) RunDueActions() error {
+	f.called <- struct{}{}
+	return f.err
+}
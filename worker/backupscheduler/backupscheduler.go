@@ -0,0 +1,241 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+// Package backupscheduler provides a worker that creates backups of
+// the controller on a schedule defined in controller config, and
+// prunes old scheduled backups according to a retention policy.
+package backupscheduler
+
+import (
+	"sort"
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/loggo"
+	"github.com/juju/utils/clock"
+	"gopkg.in/juju/worker.v1"
+	"gopkg.in/tomb.v1"
+
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	jworker "github.com/juju/juju/worker"
+)
+
+var logger = loggo.GetLogger("juju.worker.backupscheduler")
+
+// ScheduledBackupNotes are the notes attached to backups created by
+// the scheduler. Only backups with these notes are ever pruned.
+const ScheduledBackupNotes = "scheduled backup"
+
+// Backend exposes the controller state needed by the backup
+// scheduler.
+type Backend interface {
+	WatchControllerConfig() state.NotifyWatcher
+	ControllerConfig() (controller.Config, error)
+	ScheduledBackupStatus() (state.ScheduledBackupStatus, error)
+	SetScheduledBackupStatus(state.ScheduledBackupStatus) error
+}
+
+// Backups creates, lists and removes backups of the controller.
+type Backups interface {
+	// Create creates a new backup with the given notes, and returns
+	// its metadata.
+	Create(notes string) (*backups.Metadata, error)
+
+	// List returns the metadata of all stored backups.
+	List() ([]*backups.Metadata, error)
+
+	// Remove removes the identified backup.
+	Remove(id string) error
+}
+
+// Config holds the dependencies of the backup scheduler.
+type Config struct {
+	Backend Backend
+	Backups Backups
+	Clock   clock.Clock
+}
+
+// Validate returns an error if the config cannot be used to start a
+// backup scheduler.
+func (config Config) Validate() error {
+	if config.Backend == nil {
+		return errors.NotValidf("nil Backend")
+	}
+	if config.Backups == nil {
+		return errors.NotValidf("nil Backups")
+	}
+	if config.Clock == nil {
+		return errors.NotValidf("nil Clock")
+	}
+	return nil
+}
+
+// New returns a worker which creates a backup of the controller each
+// time the interval set by the backup-schedule controller config
+// elapses, and then prunes scheduled backups that fall outside the
+// configured retention policy. This worker is intended to run just
+// once, on the MongoDB master.
+func New(config Config) (worker.Worker, error) {
+	if err := config.Validate(); err != nil {
+		return nil, errors.Trace(err)
+	}
+	w := &scheduler{config: config}
+	return jworker.NewSimpleWorker(w.loop), nil
+}
+
+type scheduler struct {
+	config Config
+	policy policy
+}
+
+// policy holds the schedule and retention settings from controller
+// config.
+type policy struct {
+	interval  time.Duration
+	keepCount int
+	maxAge    time.Duration
+}
+
+func (w *scheduler) loop(stopCh <-chan struct{}) error {
+	controllerConfigWatcher := w.config.Backend.WatchControllerConfig()
+	defer worker.Stop(controllerConfigWatcher)
+
+	var (
+		controllerConfigChanges = controllerConfigWatcher.Changes()
+		// next is nil, blocking forever, until we have config
+		// that enables scheduled backups.
+		next <-chan time.Time
+	)
+	for {
+		select {
+		case <-stopCh:
+			return tomb.ErrDying
+		case _, ok := <-controllerConfigChanges:
+			if !ok {
+				return errors.New("controller configuration watcher closed")
+			}
+			controllerConfig, err := w.config.Backend.ControllerConfig()
+			if err != nil {
+				return errors.Annotate(err, "cannot load controller configuration")
+			}
+			newPolicy := policy{
+				interval:  controllerConfig.BackupSchedule(),
+				keepCount: controllerConfig.BackupRetentionCount(),
+				maxAge:    controllerConfig.BackupRetentionAge(),
+			}
+			if newPolicy != w.policy {
+				logger.Infof(
+					"scheduled backup config: interval %v, keep %d, max age %v",
+					newPolicy.interval, newPolicy.keepCount, newPolicy.maxAge,
+				)
+				w.policy = newPolicy
+			}
+			next, err = w.nextBackup()
+			if err != nil {
+				return errors.Trace(err)
+			}
+		case <-next:
+			if err := w.backup(); err != nil {
+				return errors.Trace(err)
+			}
+			var err error
+			next, err = w.nextBackup()
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+	}
+}
+
+// nextBackup returns a channel that will fire when the next scheduled
+// backup is due, or nil if scheduled backups are disabled. Backups are
+// scheduled relative to the last attempt so that restarting the worker
+// does not cause an immediate backup.
+func (w *scheduler) nextBackup() (<-chan time.Time, error) {
+	if w.policy.interval <= 0 {
+		return nil, nil
+	}
+	status, err := w.config.Backend.ScheduledBackupStatus()
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	delay := status.LastAttempt.Add(w.policy.interval).Sub(w.config.Clock.Now())
+	if delay < 0 {
+		delay = 0
+	}
+	return w.config.Clock.After(delay), nil
+}
+
+// backup creates a backup, records the outcome and then prunes old
+// backups. A failure to create or prune a backup is recorded and
+// logged, but does not stop the worker.
+func (w *scheduler) backup() error {
+	status, err := w.config.Backend.ScheduledBackupStatus()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	now := w.config.Clock.Now()
+	status.LastAttempt = now
+
+	logger.Infof("creating scheduled backup")
+	meta, err := w.config.Backups.Create(ScheduledBackupNotes)
+	if err != nil {
+		logger.Errorf("scheduled backup failed: %v", err)
+		status.LastFailure = now
+		status.LastError = err.Error()
+	} else {
+		logger.Infof("created scheduled backup %q", meta.ID())
+		status.LastSuccess = now
+		status.LastBackupID = meta.ID()
+	}
+	if err := w.config.Backend.SetScheduledBackupStatus(status); err != nil {
+		return errors.Trace(err)
+	}
+
+	if err := w.prune(now); err != nil {
+		logger.Errorf("pruning scheduled backups: %v", err)
+	}
+	return nil
+}
+
+// prune removes scheduled backups beyond the retention count, and
+// those started before the retention age. Backups created by hand are
+// left alone.
+func (w *scheduler) prune(now time.Time) error {
+	if w.policy.keepCount <= 0 && w.policy.maxAge <= 0 {
+		return nil
+	}
+	all, err := w.config.Backups.List()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	var scheduled []*backups.Metadata
+	for _, meta := range all {
+		if meta.Notes == ScheduledBackupNotes {
+			scheduled = append(scheduled, meta)
+		}
+	}
+	sort.Sort(byStartedDesc(scheduled))
+
+	minStarted := now.Add(-w.policy.maxAge)
+	for i, meta := range scheduled {
+		tooMany := w.policy.keepCount > 0 && i >= w.policy.keepCount
+		tooOld := w.policy.maxAge > 0 && meta.Started.Before(minStarted)
+		if !tooMany && !tooOld {
+			continue
+		}
+		logger.Infof("removing scheduled backup %q", meta.ID())
+		if err := w.config.Backups.Remove(meta.ID()); err != nil {
+			return errors.Annotatef(err, "removing backup %q", meta.ID())
+		}
+	}
+	return nil
+}
+
+type byStartedDesc []*backups.Metadata
+
+func (b byStartedDesc) Len() int           { return len(b) }
+func (b byStartedDesc) Swap(i, j int)      { b[i], b[j] = b[j], b[i] }
+func (b byStartedDesc) Less(i, j int) bool { return b[i].Started.After(b[j].Started) }
@@ -0,0 +1,234 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	gc "gopkg.in/check.v1"
+	"gopkg.in/juju/worker.v1"
+
+	apiservertesting "github.com/juju/juju/apiserver/testing"
+	"github.com/juju/juju/controller"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+	coretesting "github.com/juju/juju/testing"
+	"github.com/juju/juju/worker/backupscheduler"
+	"github.com/juju/juju/worker/workertest"
+)
+
+type SchedulerSuite struct {
+	coretesting.BaseSuite
+
+	clock   *testing.Clock
+	start   time.Time
+	backend *fakeBackend
+	backups *fakeBackups
+}
+
+var _ = gc.Suite(&SchedulerSuite{})
+
+func (s *SchedulerSuite) SetUpTest(c *gc.C) {
+	s.BaseSuite.SetUpTest(c)
+	s.start = time.Date(2017, 6, 1, 12, 0, 0, 0, time.UTC)
+	s.clock = testing.NewClock(s.start)
+	s.backend = &fakeBackend{
+		watcher: apiservertesting.NewFakeNotifyWatcher(),
+		config:  controller.Config{},
+		status: state.ScheduledBackupStatus{
+			LastAttempt: s.start.Add(-30 * time.Minute),
+		},
+	}
+	s.backups = &fakeBackups{
+		clock: s.clock,
+		existing: []*backups.Metadata{
+			newMetadata("manual", s.start.Add(-5*time.Hour), "before upgrade"),
+			newMetadata("oldest", s.start.Add(-3*time.Hour), backupscheduler.ScheduledBackupNotes),
+			newMetadata("older", s.start.Add(-2*time.Hour), backupscheduler.ScheduledBackupNotes),
+			newMetadata("old", s.start.Add(-1*time.Hour), backupscheduler.ScheduledBackupNotes),
+		},
+	}
+}
+
+func (s *SchedulerSuite) startWorker(c *gc.C) worker.Worker {
+	w, err := backupscheduler.New(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.AddCleanup(func(c *gc.C) { workertest.CleanKill(c, w) })
+	return w
+}
+
+func (s *SchedulerSuite) waitAlarm(c *gc.C) {
+	select {
+	case <-s.clock.Alarms():
+	case <-time.After(coretesting.LongWait):
+		c.Fatalf("timed out waiting for scheduler to wait")
+	}
+}
+
+func (s *SchedulerSuite) TestValidate(c *gc.C) {
+	_, err := backupscheduler.New(backupscheduler.Config{
+		Backups: s.backups,
+		Clock:   s.clock,
+	})
+	c.Check(err, jc.Satisfies, errors.IsNotValid)
+	c.Check(err, gc.ErrorMatches, "nil Backend not valid")
+
+	_, err = backupscheduler.New(backupscheduler.Config{
+		Backend: s.backend,
+		Clock:   s.clock,
+	})
+	c.Check(err, gc.ErrorMatches, "nil Backups not valid")
+
+	_, err = backupscheduler.New(backupscheduler.Config{
+		Backend: s.backend,
+		Backups: s.backups,
+	})
+	c.Check(err, gc.ErrorMatches, "nil Clock not valid")
+}
+
+func (s *SchedulerSuite) TestDisabled(c *gc.C) {
+	s.startWorker(c)
+	select {
+	case <-s.clock.Alarms():
+		c.Fatalf("unexpected backup scheduled")
+	case <-time.After(coretesting.ShortWait):
+	}
+	s.backups.stub.CheckNoCalls(c)
+}
+
+func (s *SchedulerSuite) TestBackupScheduledFromLastAttempt(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "1h"
+	s.startWorker(c)
+	s.waitAlarm(c)
+
+	s.clock.Advance(29 * time.Minute)
+	time.Sleep(coretesting.ShortWait)
+	s.backups.stub.CheckNoCalls(c)
+
+	s.clock.Advance(time.Minute)
+	s.waitAlarm(c)
+	s.backups.stub.CheckCallNames(c, "Create")
+	s.backups.stub.CheckCall(c, 0, "Create", backupscheduler.ScheduledBackupNotes)
+	due := s.start.Add(30 * time.Minute)
+	c.Assert(s.backend.status, jc.DeepEquals, state.ScheduledBackupStatus{
+		LastAttempt:  due,
+		LastSuccess:  due,
+		LastBackupID: "new",
+	})
+
+	// The next backup is due an hour later.
+	s.clock.Advance(time.Hour)
+	s.waitAlarm(c)
+	s.backups.stub.CheckCallNames(c, "Create", "Create")
+}
+
+func (s *SchedulerSuite) TestPruneByCount(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "1h"
+	s.backend.config[controller.BackupRetentionCount] = 2
+	s.startWorker(c)
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	s.waitAlarm(c)
+
+	s.backups.stub.CheckCallNames(c, "Create", "List", "Remove", "Remove")
+	s.backups.stub.CheckCall(c, 2, "Remove", "older")
+	s.backups.stub.CheckCall(c, 3, "Remove", "oldest")
+}
+
+func (s *SchedulerSuite) TestPruneByAge(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "1h"
+	s.backend.config[controller.BackupRetentionAge] = "150m"
+	s.startWorker(c)
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	s.waitAlarm(c)
+
+	s.backups.stub.CheckCallNames(c, "Create", "List", "Remove")
+	s.backups.stub.CheckCall(c, 2, "Remove", "oldest")
+}
+
+func (s *SchedulerSuite) TestBackupFailure(c *gc.C) {
+	s.backend.config[controller.BackupSchedule] = "1h"
+	s.backend.config[controller.BackupRetentionCount] = 1
+	s.backups.stub.SetErrors(errors.New("boom"))
+	s.startWorker(c)
+	s.waitAlarm(c)
+	s.clock.Advance(30 * time.Minute)
+	s.waitAlarm(c)
+
+	// Pruning still goes ahead, but only removes scheduled backups.
+	s.backups.stub.CheckCallNames(c, "Create", "List", "Remove", "Remove")
+	s.backups.stub.CheckCall(c, 2, "Remove", "older")
+	s.backups.stub.CheckCall(c, 3, "Remove", "oldest")
+	due := s.start.Add(30 * time.Minute)
+	c.Assert(s.backend.status, jc.DeepEquals, state.ScheduledBackupStatus{
+		LastAttempt: due,
+		LastFailure: due,
+		LastError:   "boom",
+	})
+}
+
+func newMetadata(id string, started time.Time, notes string) *backups.Metadata {
+	meta := backups.NewMetadata()
+	meta.SetID(id)
+	meta.Started = started
+	meta.Notes = notes
+	return meta
+}
+
+type fakeBackend struct {
+	watcher *apiservertesting.FakeNotifyWatcher
+	config  controller.Config
+	status  state.ScheduledBackupStatus
+}
+
+func (b *fakeBackend) WatchControllerConfig() state.NotifyWatcher {
+	return b.watcher
+}
+
+func (b *fakeBackend) ControllerConfig() (controller.Config, error) {
+	return b.config, nil
+}
+
+func (b *fakeBackend) ScheduledBackupStatus() (state.ScheduledBackupStatus, error) {
+	return b.status, nil
+}
+
+func (b *fakeBackend) SetScheduledBackupStatus(status state.ScheduledBackupStatus) error {
+	b.status = status
+	return nil
+}
+
+type fakeBackups struct {
+	stub     testing.Stub
+	clock    *testing.Clock
+	existing []*backups.Metadata
+}
+
+func (b *fakeBackups) Create(notes string) (*backups.Metadata, error) {
+	b.stub.AddCall("Create", notes)
+	if err := b.stub.NextErr(); err != nil {
+		return nil, err
+	}
+	meta := newMetadata("new", b.clock.Now(), notes)
+	b.existing = append(b.existing, meta)
+	return meta, nil
+}
+
+func (b *fakeBackups) List() ([]*backups.Metadata, error) {
+	b.stub.AddCall("List")
+	return b.existing, b.stub.NextErr()
+}
+
+func (b *fakeBackups) Remove(id string) error {
+	b.stub.AddCall("Remove", id)
+	return b.stub.NextErr()
+}
@@ -0,0 +1,84 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package backupscheduler
+
+import (
+	"github.com/juju/errors"
+	"github.com/juju/replicaset"
+
+	"github.com/juju/juju/mongo"
+	"github.com/juju/juju/state"
+	"github.com/juju/juju/state/backups"
+)
+
+// NewStateBackups returns a Backups that creates backups of the
+// controller from the given machine, in the same way as the Backups
+// API facade.
+func NewStateBackups(st *state.State, paths backups.Paths, machineID string) Backups {
+	return &stateBackups{
+		st:        st,
+		paths:     paths,
+		machineID: machineID,
+	}
+}
+
+type stateBackups struct {
+	st        *state.State
+	paths     backups.Paths
+	machineID string
+}
+
+// Create is part of the Backups interface.
+func (b *stateBackups) Create(notes string) (*backups.Metadata, error) {
+	session := b.st.MongoSession().Copy()
+	defer session.Close()
+
+	// Don't go if HA isn't ready.
+	if err := replicaset.WaitUntilReady(session, 60); err != nil {
+		return nil, errors.Annotatef(err, "HA not ready")
+	}
+
+	v, err := b.st.MongoVersion()
+	if err != nil {
+		return nil, errors.Annotatef(err, "discovering mongo version")
+	}
+	mongoVersion, err := mongo.NewVersion(v)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	dbInfo, err := backups.NewDBInfo(b.st.MongoConnectionInfo(), session, mongoVersion)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	machine, err := b.st.Machine(b.machineID)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta, err := backups.NewMetadataState(b.st, b.machineID, machine.Series())
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	meta.Notes = notes
+
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	if err := backups.NewBackups(stor).Create(meta, &b.paths, dbInfo, backups.CreateOptions{}); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return meta, nil
+}
+
+// List is part of the Backups interface.
+func (b *stateBackups) List() ([]*backups.Metadata, error) {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return backups.NewBackups(stor).List()
+}
+
+// Remove is part of the Backups interface.
+func (b *stateBackups) Remove(id string) error {
+	stor := backups.NewStorage(b.st)
+	defer stor.Close()
+	return backups.NewBackups(stor).Remove(id)
+}
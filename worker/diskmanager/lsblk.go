@@ -28,6 +28,7 @@ const (
 
 	typeDisk = "disk"
 	typeLoop = "loop"
+	typeLVM  = "lvm"
 )
 
 func init() {
@@ -92,11 +93,12 @@ func listBlockDevices() ([]storage.BlockDevice, error) {
 			}
 		}
 
-		// We may later want to expand this, e.g. to handle lvm,
-		// dmraid, crypt, etc., but this is enough to cover bases
-		// for now.
+		// We may later want to expand this, e.g. to handle dmraid,
+		// crypt, etc., but this is enough to cover bases for now.
+		// Logical volumes are included so that volumes created by
+		// the lvm storage provider may be matched.
 		switch deviceType {
-		case typeDisk, typeLoop:
+		case typeDisk, typeLoop, typeLVM:
 		default:
 			logger.Tracef("ignoring %q type device: %+v", deviceType, dev)
 			continue
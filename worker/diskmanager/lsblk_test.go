@@ -236,5 +236,8 @@ EOF`)
 	}, {
 		DeviceName: "loop0",
 		Size:       243,
+	}, {
+		DeviceName: "whatever",
+		Size:       243,
 	}})
 }
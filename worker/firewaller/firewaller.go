@@ -34,6 +34,9 @@ import (
 type FirewallerAPI interface {
 	WatchModelMachines() (watcher.StringsWatcher, error)
 	WatchOpenedPorts() (watcher.StringsWatcher, error)
+	WatchModelFirewallRules() (watcher.NotifyWatcher, error)
+	WatchSubnets() (watcher.StringsWatcher, error)
+	ModelIngressRules() ([]network.IngressRule, error)
 	Machine(tag names.MachineTag) (*firewaller.Machine, error)
 	Unit(tag names.UnitTag) (*firewaller.Unit, error)
 	Relation(tag names.RelationTag) (*firewaller.Relation, error)
@@ -79,6 +82,11 @@ type Config struct {
 	EnvironFirewaller  EnvironFirewaller
 	EnvironInstances   EnvironInstances
 
+	// EgressDefaultDeny records whether the model denies outgoing
+	// traffic by default, such that the firewaller must allow the
+	// outgoing traffic required by applications' egress rules.
+	EgressDefaultDeny bool
+
 	NewCrossModelFacadeFunc newCrossModelFacadeFunc
 
 	Clock clock.Clock
@@ -121,13 +129,19 @@ type Firewaller struct {
 
 	machinesWatcher      watcher.StringsWatcher
 	portsWatcher         watcher.StringsWatcher
+	firewallRulesWatcher watcher.NotifyWatcher
+	subnetsWatcher       watcher.StringsWatcher
 	machineds            map[names.MachineTag]*machineData
+	machineChange        chan *machineData
 	unitsChange          chan *unitsChange
 	unitds               map[names.UnitTag]*unitData
 	applicationids       map[names.ApplicationTag]*applicationData
 	exposedChange        chan *exposedChange
 	globalMode           bool
 	globalIngressRuleRef map[string]int // map of rule names to count of occurrences
+	egressDefaultDeny    bool
+	globalEgressRuleRef  map[string]int // map of egress rule names to count of occurrences
+	modelRulesFlushed    bool
 
 	modelUUID                   string
 	newRemoteFirewallerAPIFunc  newCrossModelFacadeFunc
@@ -152,9 +166,11 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 		remoteRelationsApi:          cfg.RemoteRelationsApi,
 		environFirewaller:           cfg.EnvironFirewaller,
 		environInstances:            cfg.EnvironInstances,
+		egressDefaultDeny:           cfg.EgressDefaultDeny,
 		newRemoteFirewallerAPIFunc:  cfg.NewCrossModelFacadeFunc,
 		modelUUID:                   cfg.ModelUUID,
 		machineds:                   make(map[names.MachineTag]*machineData),
+		machineChange:               make(chan *machineData),
 		unitsChange:                 make(chan *unitsChange),
 		unitds:                      make(map[names.UnitTag]*unitData),
 		applicationids:              make(map[names.ApplicationTag]*applicationData),
@@ -170,6 +186,7 @@ func NewFirewaller(cfg Config) (worker.Worker, error) {
 	case config.FwGlobal:
 		fw.globalMode = true
 		fw.globalIngressRuleRef = make(map[string]int)
+		fw.globalEgressRuleRef = make(map[string]int)
 	default:
 		return nil, errors.Errorf("invalid firewall-mode %q", cfg.Mode)
 	}
@@ -216,6 +233,24 @@ func (fw *Firewaller) setUp() error {
 		return errors.Trace(err)
 	}
 
+	fw.firewallRulesWatcher, err = fw.firewallerApi.WatchModelFirewallRules()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("model firewall rules not supported by the controller")
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start model firewall rules watcher")
+	} else if err := fw.catacomb.Add(fw.firewallRulesWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
+	fw.subnetsWatcher, err = fw.firewallerApi.WatchSubnets()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("watching subnets not supported by the controller")
+	} else if err != nil {
+		return errors.Annotatef(err, "failed to start subnets watcher")
+	} else if err := fw.catacomb.Add(fw.subnetsWatcher); err != nil {
+		return errors.Trace(err)
+	}
+
 	if featureflag.Enabled(feature.CrossModelRelations) {
 		fw.remoteRelationsWatcher, err = fw.remoteRelationsApi.WatchRemoteRelations()
 		if err != nil {
@@ -238,6 +273,17 @@ func (fw *Firewaller) loop() error {
 	}
 	var reconciled bool
 	portsChange := fw.portsWatcher.Changes()
+	var firewallRulesChange watcher.NotifyChannel
+	if fw.firewallRulesWatcher != nil {
+		firewallRulesChange = fw.firewallRulesWatcher.Changes()
+	}
+	var subnetsChange watcher.StringsChannel
+	if fw.subnetsWatcher != nil {
+		subnetsChange = fw.subnetsWatcher.Changes()
+	}
+	if err := fw.flushModel(); err != nil {
+		return errors.Annotate(err, "cannot change model firewall ports")
+	}
 	for {
 		select {
 		case <-fw.catacomb.Dying():
@@ -276,6 +322,43 @@ func (fw *Firewaller) loop() error {
 					return errors.Trace(err)
 				}
 			}
+		case _, ok := <-firewallRulesChange:
+			if !ok {
+				return errors.New("model firewall rules watcher closed")
+			}
+			if err := fw.flushModel(); err != nil {
+				return errors.Annotate(err, "cannot change model firewall ports")
+			}
+			if err := fw.modelFirewallRulesChanged(); err != nil {
+				return errors.Trace(err)
+			}
+		case _, ok := <-subnetsChange:
+			if !ok {
+				return errors.New("subnets watcher closed")
+			}
+			// The subnets of the spaces to which applications
+			// are exposed may have changed, so have the exposed
+			// applications recheck their source CIDRs.
+			for _, applicationd := range fw.applicationids {
+				if applicationd.exposed {
+					applicationd.subnetsChanged()
+				}
+			}
+		case machined := <-fw.machineChange:
+			if fw.machineds[machined.tag] != machined {
+				// The machine has since been forgotten.
+				continue
+			}
+			if !fw.modelRulesFlushed {
+				// The model's security group may have been
+				// created when the machine was provisioned.
+				if err := fw.flushModel(); err != nil {
+					return errors.Annotate(err, "cannot change model firewall ports")
+				}
+			}
+			if err := fw.flushMachine(machined); err != nil {
+				return errors.Annotate(err, "cannot change firewall ports")
+			}
 		case change, ok := <-fw.remoteRelationsWatcher.Changes():
 			if !ok {
 				return errors.New("remote relations watcher closed")
@@ -304,6 +387,8 @@ func (fw *Firewaller) loop() error {
 			}
 		case change := <-fw.exposedChange:
 			change.applicationd.exposed = change.exposed
+			change.applicationd.exposedCIDRs = change.cidrs
+			change.applicationd.egressRules = change.egressRules
 			unitds := []*unitData{}
 			for _, unitd := range change.applicationd.unitds {
 				unitds = append(unitds, unitd)
@@ -389,6 +474,10 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	} else if err != nil {
 		return errors.Annotate(err, "cannot watch machine units")
 	}
+	machined.modelRules, err = m.FirewallRules()
+	if err != nil {
+		return errors.Annotatef(err, "cannot get model firewall rules for %q", tag)
+	}
 	unitw, err := m.WatchUnits()
 	if err != nil {
 		return errors.Trace(err)
@@ -404,6 +493,16 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	if err := fw.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	// Model firewall rules can only be applied once the machine is
+	// provisioned, so we watch for that.
+	machinew, err := m.Watch()
+	if errors.IsNotSupported(err) {
+		machinew = nil
+	} else if err != nil {
+		return errors.Trace(err)
+	} else if err := fw.catacomb.Add(machinew); err != nil {
+		return errors.Trace(err)
+	}
 	select {
 	case <-fw.catacomb.Dying():
 		return fw.catacomb.ErrDying()
@@ -413,6 +512,11 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 		}
 		fw.machineds[tag] = machined
 		err = fw.unitsChanged(&unitsChange{machined, change})
+		if err == nil {
+			// The model's firewall rules apply even if
+			// the machine has no units.
+			err = fw.flushMachine(machined)
+		}
 		if err != nil {
 			delete(fw.machineds, tag)
 			return errors.Annotatef(err, "cannot respond to units changes for %q", tag)
@@ -422,7 +526,7 @@ func (fw *Firewaller) startMachine(tag names.MachineTag) error {
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &machined.catacomb,
 		Work: func() error {
-			return machined.watchLoop(unitw)
+			return machined.watchLoop(unitw, machinew)
 		},
 	})
 	if err != nil {
@@ -490,22 +594,32 @@ func (fw *Firewaller) startUnit(unit *firewaller.Unit, machineTag names.MachineT
 // startApplication creates a new data value for tracking details of the
 // application and starts watching the application for exposure changes.
 func (fw *Firewaller) startApplication(app *firewaller.Application) error {
-	exposed, err := app.IsExposed()
+	exposed, cidrs, err := app.ExposeInfo()
 	if err != nil {
 		return err
 	}
+	var egressRules []network.EgressRule
+	if fw.egressDefaultDeny {
+		egressRules, err = app.EgressRules()
+		if err != nil {
+			return err
+		}
+	}
 	applicationd := &applicationData{
-		fw:          fw,
-		application: app,
-		exposed:     exposed,
-		unitds:      make(map[names.UnitTag]*unitData),
+		fw:            fw,
+		application:   app,
+		exposed:       exposed,
+		exposedCIDRs:  set.NewStrings(cidrs...),
+		egressRules:   egressRules,
+		unitds:        make(map[names.UnitTag]*unitData),
+		subnetsChange: make(chan struct{}, 1),
 	}
 	fw.applicationids[app.Tag()] = applicationd
 
 	err = catacomb.Invoke(catacomb.Plan{
 		Site: &applicationd.catacomb,
 		Work: func() error {
-			return applicationd.watchLoop(exposed)
+			return applicationd.watchLoop(exposed, applicationd.exposedCIDRs, egressRules)
 		},
 	})
 	if err != nil {
@@ -545,6 +659,39 @@ func (fw *Firewaller) reconcileGlobal() error {
 			return err
 		}
 	}
+	return fw.reconcileGlobalEgress(machines)
+}
+
+// reconcileGlobalEgress compares the initially started watcher for
+// machines, units and applications with the egress rules applied
+// globally and allows and denies the appropriate outgoing traffic for
+// the whole environment.
+func (fw *Firewaller) reconcileGlobalEgress(machines []*machineData) error {
+	if !fw.egressDefaultDeny {
+		return nil
+	}
+	egressFirewaller, ok := fw.environFirewaller.(environs.EgressFirewaller)
+	if !ok {
+		logger.Warningf("egress rules not supported by the environment")
+		return nil
+	}
+	initialRules, err := egressFirewaller.EgressRules()
+	if err != nil {
+		return err
+	}
+	toOpen, toClose := diffEgressRules(initialRules, gatherEgressRules(machines...))
+	if len(toOpen) > 0 {
+		logger.Infof("opening global egress ports %v", toOpen)
+		if err := egressFirewaller.OpenEgressPorts(toOpen); err != nil {
+			return err
+		}
+	}
+	if len(toClose) > 0 {
+		logger.Infof("closing global egress ports %v", toClose)
+		if err := egressFirewaller.CloseEgressPorts(toClose); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -602,6 +749,42 @@ func (fw *Firewaller) reconcileInstances() error {
 				return err
 			}
 		}
+		if err := fw.reconcileInstanceEgress(machined, instances[0]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reconcileInstanceEgress compares the egress rules required by the
+// units on the machine with those of its instance, and allows and
+// denies the appropriate outgoing traffic for the instance.
+func (fw *Firewaller) reconcileInstanceEgress(machined *machineData, inst instance.Instance) error {
+	if !fw.egressDefaultDeny {
+		return nil
+	}
+	egressFirewaller, ok := inst.(instance.EgressFirewaller)
+	if !ok {
+		logger.Warningf("egress rules not supported by instance of %q", machined.tag)
+		return nil
+	}
+	machineId := machined.tag.Id()
+	initialRules, err := egressFirewaller.EgressRules(machineId)
+	if err != nil {
+		return err
+	}
+	toOpen, toClose := diffEgressRules(initialRules, machined.egressRules)
+	if len(toOpen) > 0 {
+		logger.Infof("opening instance egress port ranges %v for %q", toOpen, machined.tag)
+		if err := egressFirewaller.OpenEgressPorts(machineId, toOpen); err != nil {
+			return err
+		}
+	}
+	if len(toClose) > 0 {
+		logger.Infof("closing instance egress port ranges %v for %q", toClose, machined.tag)
+		if err := egressFirewaller.CloseEgressPorts(machineId, toClose); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -749,9 +932,47 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 	toOpen, toClose := diffRanges(machined.ingressRules, want)
 	machined.ingressRules = want
 	if fw.globalMode {
-		return fw.flushGlobalPorts(toOpen, toClose)
+		err = fw.flushGlobalPorts(toOpen, toClose)
+	} else {
+		err = fw.flushInstancePorts(machined, toOpen, toClose)
+	}
+	if err != nil {
+		return err
+	}
+	return fw.flushMachineEgress(machined)
+}
+
+// flushMachineEgress allows and denies outgoing traffic for the passed
+// machine, if the model denies outgoing traffic by default.
+func (fw *Firewaller) flushMachineEgress(machined *machineData) error {
+	if !fw.egressDefaultDeny {
+		return nil
+	}
+	want := gatherEgressRules(machined)
+	toOpen, toClose := diffEgressRules(machined.egressRules, want)
+	machined.egressRules = want
+	if fw.globalMode {
+		return fw.flushGlobalEgressPorts(toOpen, toClose)
+	}
+	return fw.flushInstanceEgressPorts(machined, toOpen, toClose)
+}
+
+// gatherEgressRules returns the egress rules required by the
+// applications of the units on the specified machines.
+func gatherEgressRules(machines ...*machineData) []network.EgressRule {
+	var want []network.EgressRule
+	for _, machined := range machines {
+		seen := make(map[names.ApplicationTag]bool)
+		for _, unitd := range machined.unitds {
+			applicationTag := unitd.applicationd.application.Tag()
+			if seen[applicationTag] {
+				continue
+			}
+			seen[applicationTag] = true
+			want = append(want, unitd.applicationd.egressRules...)
+		}
 	}
-	return fw.flushInstancePorts(machined, toOpen, toClose)
+	return want
 }
 
 // gatherIngressRules returns the ingress rules to open and close
@@ -759,6 +980,7 @@ func (fw *Firewaller) flushMachine(machined *machineData) error {
 func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.IngressRule, error) {
 	var want []network.IngressRule
 	for _, machined := range machines {
+		want = append(want, machined.modelRules...)
 		for unitTag, portRanges := range machined.definedPorts {
 			unitd, known := machined.unitds[unitTag]
 			if !known {
@@ -767,11 +989,14 @@ func (fw *Firewaller) gatherIngressRules(machines ...*machineData) ([]network.In
 			}
 
 			cidrs := set.NewStrings()
-			// If the unit is exposed, allow access from everywhere.
+			// If the unit is exposed, allow access from the sources
+			// it is exposed to.
 			if unitd.applicationd.exposed {
-				cidrs.Add("0.0.0.0/0")
-			} else {
-				// Not exposed, so add any ingress rules required by remote relations.
+				cidrs = cidrs.Union(unitd.applicationd.exposedCIDRs)
+			}
+			if !cidrs.Contains("0.0.0.0/0") {
+				// Not exposed to everywhere, so add any ingress rules
+				// required by remote relations.
 				if err := fw.updateForRemoteRelationIngress(unitd.applicationd.application.Tag(), cidrs); err != nil {
 					return nil, errors.Trace(err)
 				}
@@ -870,6 +1095,14 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	}
 	machineId := machined.tag.Id()
 	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// Model firewall rules may require ports to be opened
+		// before the machine is provisioned. Forget the rules
+		// so that they are all applied once it is.
+		logger.Debugf("%q not provisioned, deferring port changes", machined.tag)
+		machined.ingressRules = nil
+		return nil
+	}
 	if err != nil {
 		return err
 	}
@@ -897,6 +1130,102 @@ func (fw *Firewaller) flushInstancePorts(machined *machineData, toOpen, toClose
 	return nil
 }
 
+// flushGlobalEgressPorts allows and denies outgoing traffic globally
+// in the environment, keeping a reference count for rules as
+// flushGlobalPorts does.
+func (fw *Firewaller) flushGlobalEgressPorts(rawOpen, rawClose []network.EgressRule) error {
+	var toOpen, toClose []network.EgressRule
+	for _, rule := range rawOpen {
+		ruleName := rule.String()
+		if fw.globalEgressRuleRef[ruleName] == 0 {
+			toOpen = append(toOpen, rule)
+		}
+		fw.globalEgressRuleRef[ruleName]++
+	}
+	for _, rule := range rawClose {
+		ruleName := rule.String()
+		fw.globalEgressRuleRef[ruleName]--
+		if fw.globalEgressRuleRef[ruleName] == 0 {
+			toClose = append(toClose, rule)
+			delete(fw.globalEgressRuleRef, ruleName)
+		}
+	}
+	if len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	egressFirewaller, ok := fw.environFirewaller.(environs.EgressFirewaller)
+	if !ok {
+		logger.Warningf("egress rules not supported by the environment")
+		return nil
+	}
+	if len(toOpen) > 0 {
+		if err := egressFirewaller.OpenEgressPorts(toOpen); err != nil {
+			return err
+		}
+		network.SortEgressRules(toOpen)
+		logger.Infof("opened egress port ranges %v in environment", toOpen)
+	}
+	if len(toClose) > 0 {
+		if err := egressFirewaller.CloseEgressPorts(toClose); err != nil {
+			return err
+		}
+		network.SortEgressRules(toClose)
+		logger.Infof("closed egress port ranges %v in environment", toClose)
+	}
+	return nil
+}
+
+// flushInstanceEgressPorts allows and denies outgoing traffic from
+// the machine's instance.
+func (fw *Firewaller) flushInstanceEgressPorts(machined *machineData, toOpen, toClose []network.EgressRule) error {
+	if len(toOpen) == 0 && len(toClose) == 0 {
+		return nil
+	}
+	m, err := machined.machine()
+	if params.IsCodeNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instanceId, err := m.InstanceId()
+	if params.IsCodeNotProvisioned(err) {
+		// Forget the rules so that they are all applied
+		// once the machine is provisioned.
+		logger.Debugf("%q not provisioned, deferring egress port changes", machined.tag)
+		machined.egressRules = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	instances, err := fw.environInstances.Instances([]instance.Id{instanceId})
+	if err != nil {
+		return err
+	}
+	egressFirewaller, ok := instances[0].(instance.EgressFirewaller)
+	if !ok {
+		logger.Warningf("egress rules not supported by instance of %q", machined.tag)
+		return nil
+	}
+	machineId := machined.tag.Id()
+	if len(toOpen) > 0 {
+		if err := egressFirewaller.OpenEgressPorts(machineId, toOpen); err != nil {
+			return err
+		}
+		network.SortEgressRules(toOpen)
+		logger.Infof("opened egress port ranges %v on %q", toOpen, machined.tag)
+	}
+	if len(toClose) > 0 {
+		if err := egressFirewaller.CloseEgressPorts(machineId, toClose); err != nil {
+			return err
+		}
+		network.SortEgressRules(toClose)
+		logger.Infof("closed egress port ranges %v on %q", toClose, machined.tag)
+	}
+	return nil
+}
+
 // machineLifeChanged starts watching new machines when the firewaller
 // is starting, or when new machines come to life, and stops watching
 // machines that are dying.
@@ -921,11 +1250,94 @@ func (fw *Firewaller) machineLifeChanged(tag names.MachineTag) error {
 	return nil
 }
 
+// flushModel restricts the access to well-known services, which the
+// provider allows from anywhere by default, according to the model's
+// firewall rules. Only the port ranges of the rules are changed.
+func (fw *Firewaller) flushModel() error {
+	modelFirewaller, ok := fw.environFirewaller.(environs.ModelFirewaller)
+	if !ok {
+		fw.modelRulesFlushed = true
+		return nil
+	}
+	want, err := fw.firewallerApi.ModelIngressRules()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("model ingress rules not supported by the controller")
+		fw.modelRulesFlushed = true
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	have, err := modelFirewaller.ModelIngressRules()
+	if errors.IsNotFound(err) {
+		// The provider sets up the model's security group when
+		// the first machine is provisioned, so try again then.
+		logger.Debugf("cannot get model ingress rules yet: %v", err)
+		return nil
+	} else if errors.IsNotSupported(err) {
+		logger.Debugf("model ingress rules not supported by the environment: %v", err)
+		fw.modelRulesFlushed = true
+		return nil
+	} else if err != nil {
+		return errors.Trace(err)
+	}
+	fw.modelRulesFlushed = true
+
+	ruleRanges := make(map[network.PortRange]bool)
+	for _, rule := range want {
+		ruleRanges[rule.PortRange] = true
+	}
+	var current []network.IngressRule
+	for _, rule := range have {
+		if ruleRanges[rule.PortRange] {
+			current = append(current, rule)
+		}
+	}
+	toOpen, toClose := diffRanges(current, want)
+	if len(toOpen) > 0 {
+		if err := modelFirewaller.OpenModelPorts(toOpen); err != nil {
+			return errors.Trace(err)
+		}
+		network.SortIngressRules(toOpen)
+		logger.Infof("opened model port ranges %v in environment", toOpen)
+	}
+	if len(toClose) > 0 {
+		if err := modelFirewaller.CloseModelPorts(toClose); err != nil {
+			return errors.Trace(err)
+		}
+		network.SortIngressRules(toClose)
+		logger.Infof("closed model port ranges %v in environment", toClose)
+	}
+	return nil
+}
+
+// modelFirewallRulesChanged updates the ingress rules required by the
+// model's firewall rules on all known machines.
+func (fw *Firewaller) modelFirewallRulesChanged() error {
+	for _, machined := range fw.machineds {
+		m, err := machined.machine()
+		if params.IsCodeNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Trace(err)
+		}
+		machined.modelRules, err = m.FirewallRules()
+		if err != nil {
+			return errors.Annotatef(err, "cannot get model firewall rules for %q", machined.tag)
+		}
+		if err := fw.flushMachine(machined); err != nil {
+			return errors.Annotate(err, "cannot change firewall ports")
+		}
+	}
+	return nil
+}
+
 // forgetMachine cleans the machine data after the machine is removed.
 func (fw *Firewaller) forgetMachine(machined *machineData) error {
 	for _, unitd := range machined.unitds {
 		fw.forgetUnit(unitd)
 	}
+	machined.modelRules = nil
 	if err := fw.flushMachine(machined); err != nil {
 		return errors.Trace(err)
 	}
@@ -994,17 +1406,29 @@ type machineData struct {
 	ingressRules []network.IngressRule
 	// ports defined by units on this machine
 	definedPorts map[names.UnitTag]portRanges
+	// rules required by the model's firewall rules
+	modelRules []network.IngressRule
+	// egress rules applied for the applications of units on this machine
+	egressRules []network.EgressRule
 }
 
 func (md *machineData) machine() (*firewaller.Machine, error) {
 	return md.fw.firewallerApi.Machine(md.tag)
 }
 
-// watchLoop watches the machine for units added or removed.
-func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
+// watchLoop watches the machine for units added or removed and,
+// if machinew is not nil, for changes to the machine itself.
+func (md *machineData) watchLoop(unitw watcher.StringsWatcher, machinew watcher.NotifyWatcher) error {
 	if err := md.catacomb.Add(unitw); err != nil {
 		return errors.Trace(err)
 	}
+	var machineChange watcher.NotifyChannel
+	if machinew != nil {
+		if err := md.catacomb.Add(machinew); err != nil {
+			return errors.Trace(err)
+		}
+		machineChange = machinew.Changes()
+	}
 	for {
 		select {
 		case <-md.catacomb.Dying():
@@ -1018,6 +1442,15 @@ func (md *machineData) watchLoop(unitw watcher.StringsWatcher) error {
 				return md.catacomb.ErrDying()
 			case md.fw.unitsChange <- &unitsChange{md, change}:
 			}
+		case _, ok := <-machineChange:
+			if !ok {
+				return errors.New("machine watcher closed")
+			}
+			select {
+			case <-md.catacomb.Dying():
+				return md.catacomb.ErrDying()
+			case md.fw.machineChange <- md:
+			}
 		}
 	}
 }
@@ -1041,23 +1474,46 @@ type unitData struct {
 	machined     *machineData
 }
 
-// exposedChange contains the changed exposed flag for one specific application.
+// exposedChange contains the changed exposed flag, source CIDRs and
+// egress rules for one specific application.
 type exposedChange struct {
 	applicationd *applicationData
 	exposed      bool
+	cidrs        set.Strings
+	egressRules  []network.EgressRule
 }
 
 // applicationData holds application details and watches exposure changes.
 type applicationData struct {
-	catacomb    catacomb.Catacomb
-	fw          *Firewaller
-	application *firewaller.Application
-	exposed     bool
-	unitds      map[names.UnitTag]*unitData
+	catacomb     catacomb.Catacomb
+	fw           *Firewaller
+	application  *firewaller.Application
+	exposed      bool
+	exposedCIDRs set.Strings
+	egressRules  []network.EgressRule
+	unitds       map[names.UnitTag]*unitData
+
+	// subnetsChange is signalled when the model's subnets change,
+	// as the source CIDRs of the spaces the application is exposed
+	// to may have changed.
+	subnetsChange chan struct{}
+}
+
+// subnetsChanged notifies the application's watch loop that the
+// model's subnets have changed. It does not block.
+func (ad *applicationData) subnetsChanged() {
+	select {
+	case ad.subnetsChange <- struct{}{}:
+	default:
+		// A check is already pending.
+	}
 }
 
-// watchLoop watches the application's exposed flag for changes.
-func (ad *applicationData) watchLoop(exposed bool) error {
+// watchLoop watches the application's exposed flag, source CIDRs and,
+// if the model denies outgoing traffic by default, egress rules for
+// changes. The source CIDRs are also rechecked when the model's
+// subnets change.
+func (ad *applicationData) watchLoop(exposed bool, cidrs set.Strings, egressRules []network.EgressRule) error {
 	appWatcher, err := ad.application.Watch()
 	if err != nil {
 		if params.IsCodeNotFound(err) {
@@ -1082,20 +1538,32 @@ func (ad *applicationData) watchLoop(exposed bool) error {
 				}
 				return nil
 			}
-			change, err := ad.application.IsExposed()
+		case <-ad.subnetsChange:
+		}
+		changedExposed, changedCIDRs, err := ad.application.ExposeInfo()
+		if err != nil {
+			return errors.Trace(err)
+		}
+		changed := set.NewStrings(changedCIDRs...)
+		changedEgressRules := egressRules
+		if ad.fw.egressDefaultDeny {
+			changedEgressRules, err = ad.application.EgressRules()
 			if err != nil {
 				return errors.Trace(err)
 			}
-			if change == exposed {
-				continue
-			}
+		}
+		if changedExposed == exposed && changed.Difference(cidrs).IsEmpty() && cidrs.Difference(changed).IsEmpty() &&
+			egressRulesEqual(changedEgressRules, egressRules) {
+			continue
+		}
 
-			exposed = change
-			select {
-			case <-ad.catacomb.Dying():
-				return ad.catacomb.ErrDying()
-			case ad.fw.exposedChange <- &exposedChange{ad, change}:
-			}
+		exposed = changedExposed
+		cidrs = changed
+		egressRules = changedEgressRules
+		select {
+		case <-ad.catacomb.Dying():
+			return ad.catacomb.ErrDying()
+		case ad.fw.exposedChange <- &exposedChange{ad, exposed, cidrs, egressRules}:
 		}
 	}
 }
@@ -1186,6 +1654,35 @@ func diffRanges(currentRules, wantedRules []network.IngressRule) (toOpen, toClos
 	return toOpen, toClose
 }
 
+// diffEgressRules returns the egress rules to open and close to get
+// from the current rules to the wanted rules, merging destinations as
+// diffRanges does for sources.
+func diffEgressRules(currentRules, wantedRules []network.EgressRule) (toOpen, toClose []network.EgressRule) {
+	asIngress := func(rules []network.EgressRule) []network.IngressRule {
+		result := make([]network.IngressRule, len(rules))
+		for i, rule := range rules {
+			result[i] = network.IngressRule{PortRange: rule.PortRange, SourceCIDRs: rule.DestinationCIDRs}
+		}
+		return result
+	}
+	asEgress := func(rules []network.IngressRule) []network.EgressRule {
+		var result []network.EgressRule
+		for _, rule := range rules {
+			result = append(result, network.EgressRule{PortRange: rule.PortRange, DestinationCIDRs: rule.SourceCIDRs})
+		}
+		return result
+	}
+	ingressOpen, ingressClose := diffRanges(asIngress(currentRules), asIngress(wantedRules))
+	return asEgress(ingressOpen), asEgress(ingressClose)
+}
+
+// egressRulesEqual returns whether the two sets of egress rules
+// allow the same outgoing traffic.
+func egressRulesEqual(a, b []network.EgressRule) bool {
+	toOpen, toClose := diffEgressRules(a, b)
+	return len(toOpen) == 0 && len(toClose) == 0
+}
+
 // relationLifeChanged manages the workers to process ingress changes for
 // the specified relation.
 func (fw *Firewaller) relationLifeChanged(tag names.RelationTag) error {
@@ -24,6 +24,7 @@ import (
 	"github.com/juju/juju/api/remoterelations"
 	"github.com/juju/juju/apiserver/params"
 	"github.com/juju/juju/core/crossmodel"
+	"github.com/juju/juju/environs"
 	"github.com/juju/juju/environs/config"
 	"github.com/juju/juju/feature"
 	"github.com/juju/juju/instance"
@@ -52,6 +53,7 @@ type firewallerBaseSuite struct {
 	remoteRelations      *remoterelations.Client
 	crossmodelFirewaller *crossmodelrelations.Client
 	mockClock            *mockClock
+	egressDefaultDeny    bool
 }
 
 func (s *firewallerBaseSuite) SetUpSuite(c *gc.C) {
@@ -83,6 +85,7 @@ func (s *firewallerBaseSuite) setUpTest(c *gc.C, firewallMode string) {
 
 	s.JujuConnSuite.SetUpTest(c)
 	s.charm = s.AddTestingCharm(c, "dummy")
+	s.egressDefaultDeny = false
 
 	// Create a manager machine and login to the API.
 	var err error
@@ -154,6 +157,56 @@ func (s *firewallerBaseSuite) assertEnvironPorts(c *gc.C, expected []network.Ing
 	}
 }
 
+// assertModelPorts retrieves the model-wide ingress rules of the
+// environment and compares them to the expected.
+func (s *firewallerBaseSuite) assertModelPorts(c *gc.C, expected []network.IngressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := s.Environ.(environs.ModelFirewaller).ModelIngressRules()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortIngressRules(got)
+		network.SortIngressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
+// assertEgressRules polls the egress rules returned by get until they
+// match the expected rules.
+func (s *firewallerBaseSuite) assertEgressRules(c *gc.C, get func() ([]network.EgressRule, error), expected []network.EgressRule) {
+	s.BackingState.StartSync()
+	start := time.Now()
+	for {
+		got, err := get()
+		if err != nil {
+			c.Fatal(err)
+			return
+		}
+		network.SortEgressRules(got)
+		network.SortEgressRules(expected)
+		if reflect.DeepEqual(got, expected) {
+			c.Succeed()
+			return
+		}
+		if time.Since(start) > coretesting.LongWait {
+			c.Fatalf("timed out: expected %q; got %q", expected, got)
+			return
+		}
+		time.Sleep(coretesting.ShortWait)
+	}
+}
+
 func (s *firewallerBaseSuite) addUnit(c *gc.C, app *state.Application) (*state.Unit, *state.Machine) {
 	u, err := app.AddUnit(state.AddUnitParams{})
 	c.Assert(err, jc.ErrorIsNil)
@@ -212,7 +265,8 @@ func (s *InstanceModeSuite) newFirewaller(c *gc.C) worker.Worker {
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
-		Clock: s.mockClock,
+		Clock:             s.mockClock,
+		EgressDefaultDeny: s.egressDefaultDeny,
 	}
 	fw, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -245,6 +299,71 @@ func (s *InstanceModeSuite) TestNotExposedApplication(c *gc.C) {
 	s.assertPorts(c, inst, m.Id(), nil)
 }
 
+func (s *InstanceModeSuite) TestEgressRules(c *gc.C) {
+	s.egressDefaultDeny = true
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	egressRules := func() ([]network.EgressRule, error) {
+		return inst.(instance.EgressFirewaller).EgressRules(m.Id())
+	}
+
+	s.assertEgressRules(c, egressRules, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0"),
+	})
+
+	// Changing the application's rules replaces those on the instance.
+	err = app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, egressRules, []network.EgressRule{
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+		network.MustNewEgressRule("udp", 53, 53, "10.0.0.0/8"),
+	})
+
+	// Removing the unit denies outgoing traffic again.
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, egressRules, nil)
+}
+
+func (s *InstanceModeSuite) TestEgressRulesWithoutDefaultDeny(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetEgressRules([]network.EgressRule{
+		network.MustNewEgressRule("tcp", 443, 443),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	// Once the ingress rules are in place, the egress rules
+	// are known to have been ignored.
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+	rules, err := inst.(instance.EgressFirewaller).EgressRules(m.Id())
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(rules, gc.HasLen, 0)
+}
+
 func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -274,6 +393,113 @@ func (s *InstanceModeSuite) TestExposedApplication(c *gc.C) {
 	})
 }
 
+func (s *InstanceModeSuite) TestExposedApplicationToCIDRs(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+
+	err := app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8", "192.168.1.0/24"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Changing the expose settings changes the source CIDRs.
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToCIDRs: []string{"10.0.0.0/8"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "10.0.0.0/8"),
+	})
+
+	err = app.ClearExposed()
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), nil)
+}
+
+func (s *InstanceModeSuite) TestExposedApplicationToSpaces(c *gc.C) {
+	_, err := s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.1.0/24"})
+	c.Assert(err, jc.ErrorIsNil)
+	_, err = s.State.AddSpace("office", "", []string{"192.168.1.0/24"}, false)
+	c.Assert(err, jc.ErrorIsNil)
+
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err = app.MergeExposeSettings(map[string]state.ExposedEndpoint{
+		"": {ExposeToSpaces: []string{"office"}},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	inst := s.startInstance(c, m)
+
+	err = u.OpenPort("tcp", 8080)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.1.0/24"),
+	})
+
+	// Adding a subnet to the space allows access from it too.
+	_, err = s.State.AddSubnet(state.SubnetInfo{CIDR: "192.168.2.0/24", SpaceName: "office"})
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertPorts(c, inst, m.Id(), []network.IngressRule{
+		network.MustNewIngressRule("tcp", 8080, 8080, "192.168.1.0/24", "192.168.2.0/24"),
+	})
+}
+
+func (s *InstanceModeSuite) TestModelIngressRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	// The provider allows SSH from anywhere by default.
+	s.assertModelPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "0.0.0.0/0"),
+	})
+
+	// The ssh rule restricts it to the whitelist.
+	rules := state.NewFirewallRules(s.State)
+	err := rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8", "192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertModelPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8", "192.168.1.0/24"),
+	})
+
+	// Changing the rules replaces the whitelists.
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"172.16.0.0/12"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := s.ControllerConfig.APIPort()
+	s.assertModelPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "172.16.0.0/12"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "192.168.1.0/24"),
+	})
+}
+
 func (s *InstanceModeSuite) TestMultipleExposedApplications(c *gc.C) {
 	fw := s.newFirewaller(c)
 	defer statetesting.AssertKillAndWait(c, fw)
@@ -914,6 +1140,7 @@ func (s *GlobalModeSuite) newFirewaller(c *gc.C) worker.Worker {
 		NewCrossModelFacadeFunc: func(*api.Info) (firewaller.CrossModelFirewallerFacadeCloser, error) {
 			return s.crossmodelFirewaller, nil
 		},
+		EgressDefaultDeny: s.egressDefaultDeny,
 	}
 	fw, err := firewaller.NewFirewaller(cfg)
 	c.Assert(err, jc.ErrorIsNil)
@@ -977,6 +1204,112 @@ func (s *GlobalModeSuite) TestGlobalMode(c *gc.C) {
 	s.assertEnvironPorts(c, nil)
 }
 
+func (s *GlobalModeSuite) TestModelFirewallRules(c *gc.C) {
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	app := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app.SetExposed()
+	c.Assert(err, jc.ErrorIsNil)
+	u, m := s.addUnit(c, app)
+	s.startInstance(c, m)
+	err = u.OpenPort("tcp", 80)
+	c.Assert(err, jc.ErrorIsNil)
+
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+	})
+
+	// The ssh rule applies to all machines, and the juju-controller
+	// rule to the API port on the controller machine.
+	rules := state.NewFirewallRules(s.State)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"10.0.0.0/8"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.JujuControllerRule,
+		WhitelistCIDRs:   []string{"192.168.1.0/24"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	apiPort := s.ControllerConfig.APIPort()
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "10.0.0.0/8"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "192.168.1.0/24"),
+	})
+
+	// Changing a rule replaces its source CIDRs.
+	err = rules.Save(state.FirewallRule{
+		WellKnownService: state.SSHRule,
+		WhitelistCIDRs:   []string{"172.16.0.0/12"},
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "172.16.0.0/12"),
+		network.MustNewIngressRule("tcp", 80, 80, "0.0.0.0/0"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "192.168.1.0/24"),
+	})
+
+	// Rules remain in place while any machine needs them.
+	err = u.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	err = m.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEnvironPorts(c, []network.IngressRule{
+		network.MustNewIngressRule("tcp", 22, 22, "172.16.0.0/12"),
+		network.MustNewIngressRule("tcp", apiPort, apiPort, "192.168.1.0/24"),
+	})
+}
+
+func (s *GlobalModeSuite) TestEgressRules(c *gc.C) {
+	s.egressDefaultDeny = true
+	fw := s.newFirewaller(c)
+	defer statetesting.AssertKillAndWait(c, fw)
+
+	rule := network.MustNewEgressRule("tcp", 443, 443, "0.0.0.0/0")
+	app1 := s.AddTestingApplication(c, "wordpress", s.charm)
+	err := app1.SetEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+	u1, m1 := s.addUnit(c, app1)
+	s.startInstance(c, m1)
+
+	app2 := s.AddTestingApplication(c, "moinmoin", s.charm)
+	err = app2.SetEgressRules([]network.EgressRule{
+		rule,
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	u2, m2 := s.addUnit(c, app2)
+	s.startInstance(c, m2)
+
+	egressRules := s.Environ.(environs.EgressFirewaller).EgressRules
+	s.assertEgressRules(c, egressRules, []network.EgressRule{
+		rule,
+		network.MustNewEgressRule("tcp", 5432, 5432, "10.0.0.0/8"),
+	})
+
+	// A rule used by another application remains in place.
+	err = app2.SetEgressRules([]network.EgressRule{rule})
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, egressRules, []network.EgressRule{rule})
+	err = u1.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u1.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, egressRules, []network.EgressRule{rule})
+
+	// Removing the last unit needing a rule removes it.
+	err = u2.EnsureDead()
+	c.Assert(err, jc.ErrorIsNil)
+	err = u2.Remove()
+	c.Assert(err, jc.ErrorIsNil)
+	s.assertEgressRules(c, egressRules, nil)
+}
+
 func (s *GlobalModeSuite) TestStartWithUnexposedApplication(c *gc.C) {
 	m, err := s.State.AddMachine("quantal", state.JobHostUnits)
 	c.Assert(err, jc.ErrorIsNil)
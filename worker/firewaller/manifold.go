@@ -108,6 +108,7 @@ func (cfg ManifoldConfig) start(context dependency.Context) (worker.Worker, erro
 		EnvironFirewaller:  environ,
 		EnvironInstances:   environ,
 		Mode:               mode,
+		EgressDefaultDeny:  environ.Config().EgressDefaultDeny(),
 		NewCrossModelFacadeFunc: crossmodelFirewallerFacadeFunc(cfg.NewControllerConnection),
 	})
 	if err != nil {
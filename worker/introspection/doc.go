@@ -9,4 +9,7 @@
 //   - prints out all the goroutines in the agent
 // * `/debug/pprof/heap?debug=1`
 //   - prints out the heap profile
+// * `/metrics`
+//   - prints out the agent's metrics in Prometheus text format; for unit
+//     agents, this includes the hooks and actions run by the uniter
 package introspection
@@ -24,10 +24,18 @@ type ManifoldConfig struct {
 	// to which log records will be forwarded.
 	Sinks []LogSinkSpec
 
+	// AuditSinks are the named functions that open the underlying
+	// sinks to which audit records will be forwarded.
+	AuditSinks []LogSinkSpec
+
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
 
+	// OpenAuditStream is the function that will be used for the
+	// audit stream.
+	OpenAuditStream LogStreamFn
+
 	// OpenLogForwarder opens each log forwarder that will be used.
 	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
 }
@@ -42,6 +50,13 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 		}
 	}
 
+	openAuditStream := config.OpenAuditStream
+	if openAuditStream == nil {
+		openAuditStream = func(caller base.APICaller, cfg params.LogStreamConfig, controllerUUID string) (LogStream, error) {
+			return logstream.OpenAuditStream(caller, cfg, controllerUUID)
+		}
+	}
+
 	openForwarder := config.OpenLogForwarder
 	if openForwarder == nil {
 		openForwarder = NewLogForwarder
@@ -64,12 +79,15 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 			}
 
 			orchestrator, err := newOrchestratorForController(OrchestratorArgs{
-				ControllerUUID:   controllerCfg.ControllerUUID(),
-				LogForwardConfig: agentFacade,
-				Caller:           apiCaller,
-				Sinks:            config.Sinks,
-				OpenLogStream:    openLogStream,
-				OpenLogForwarder: openForwarder,
+				ControllerUUID:     controllerCfg.ControllerUUID(),
+				LogForwardConfig:   agentFacade,
+				AuditForwardConfig: agentFacade,
+				Caller:             apiCaller,
+				Sinks:              config.Sinks,
+				AuditSinks:         config.AuditSinks,
+				OpenLogStream:      openLogStream,
+				OpenAuditStream:    openAuditStream,
+				OpenLogForwarder:   openForwarder,
 			})
 			return orchestrator, errors.Annotate(err, "creating log forwarding orchestrator")
 		},
@@ -5,12 +5,16 @@ package logforwarder
 
 import (
 	"github.com/juju/errors"
+	worker "gopkg.in/juju/worker.v1"
 
 	"github.com/juju/juju/api/base"
+	"github.com/juju/juju/worker/catacomb"
 )
 
+// orchestrator runs the log forwarder and the audit forwarder, if
+// each has been configured.
 type orchestrator struct {
-	*LogForwarder // For now its just a single forwarder.
+	catacomb catacomb.Catacomb
 }
 
 // OrchestratorArgs holds the info needed to open a log forwarding
@@ -22,6 +26,9 @@ type OrchestratorArgs struct {
 	// LogForwardConfig is the API used to access log forward config.
 	LogForwardConfig LogForwardConfig
 
+	// AuditForwardConfig is the API used to access audit forward config.
+	AuditForwardConfig AuditForwardConfig
+
 	// Caller is the API caller that will be used.
 	Caller base.APICaller
 
@@ -29,30 +36,96 @@ type OrchestratorArgs struct {
 	// to which log records will be forwarded.
 	Sinks []LogSinkSpec
 
+	// AuditSinks are the named functions that open the underlying sinks
+	// to which audit records will be forwarded.
+	AuditSinks []LogSinkSpec
+
 	// OpenLogStream is the function that will be used to for the
 	// log stream.
 	OpenLogStream LogStreamFn
 
+	// OpenAuditStream is the function that will be used for the
+	// audit stream.
+	OpenAuditStream LogStreamFn
+
 	// OpenLogForwarder opens each log forwarder that will be used.
 	OpenLogForwarder func(OpenLogForwarderArgs) (*LogForwarder, error)
 }
 
 func newOrchestratorForController(args OrchestratorArgs) (*orchestrator, error) {
-	// For now we work with only 1 forwarder. Later we can have a proper
-	// orchestrator that spawns a sub-worker for each log sink.
-	if len(args.Sinks) == 0 {
-		return nil, nil
-	}
+	// For now we work with only 1 forwarder per stream. Later we can
+	// have a proper orchestrator that spawns a sub-worker for each
+	// log sink.
 	if len(args.Sinks) > 1 {
 		return nil, errors.Errorf("multiple log forwarding targets not supported (yet)")
 	}
-	lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
-		ControllerUUID:   args.ControllerUUID,
-		LogForwardConfig: args.LogForwardConfig,
-		Caller:           args.Caller,
-		Name:             args.Sinks[0].Name,
-		OpenSink:         args.Sinks[0].OpenFn,
-		OpenLogStream:    args.OpenLogStream,
+	if len(args.AuditSinks) > 1 {
+		return nil, errors.Errorf("multiple audit forwarding targets not supported (yet)")
+	}
+
+	var forwarders []worker.Worker
+	stopForwarders := func() {
+		for _, w := range forwarders {
+			w.Kill()
+			w.Wait()
+		}
+	}
+	if len(args.Sinks) == 1 {
+		lf, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: args.LogForwardConfig,
+			Caller:           args.Caller,
+			Name:             args.Sinks[0].Name,
+			OpenSink:         args.Sinks[0].OpenFn,
+			OpenLogStream:    args.OpenLogStream,
+		})
+		if err != nil {
+			return nil, errors.Annotate(err, "opening log forwarder")
+		}
+		forwarders = append(forwarders, lf)
+	}
+	if len(args.AuditSinks) == 1 {
+		af, err := args.OpenLogForwarder(OpenLogForwarderArgs{
+			ControllerUUID:   args.ControllerUUID,
+			LogForwardConfig: auditForwardConfig{args.AuditForwardConfig},
+			Caller:           args.Caller,
+			Name:             args.AuditSinks[0].Name,
+			OpenSink:         args.AuditSinks[0].OpenFn,
+			OpenLogStream:    args.OpenAuditStream,
+		})
+		if err != nil {
+			stopForwarders()
+			return nil, errors.Annotate(err, "opening audit forwarder")
+		}
+		forwarders = append(forwarders, af)
+	}
+	if len(forwarders) == 0 {
+		return nil, nil
+	}
+
+	o := &orchestrator{}
+	err := catacomb.Invoke(catacomb.Plan{
+		Site: &o.catacomb,
+		Work: o.loop,
+		Init: forwarders,
 	})
-	return &orchestrator{lf}, errors.Annotate(err, "opening log forwarder")
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	return o, nil
+}
+
+func (o *orchestrator) loop() error {
+	<-o.catacomb.Dying()
+	return o.catacomb.ErrDying()
+}
+
+// Kill implements Worker.Kill()
+func (o *orchestrator) Kill() {
+	o.catacomb.Kill(nil)
+}
+
+// Wait implements Worker.Wait()
+func (o *orchestrator) Wait() error {
+	return o.catacomb.Wait()
 }
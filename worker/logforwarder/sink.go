@@ -18,6 +18,30 @@ type LogForwardConfig interface {
 	LogForwardConfig() (*syslog.RawConfig, bool, error)
 }
 
+// AuditForwardConfig provides access to the audit forwarding config
+// for a controller.
+type AuditForwardConfig interface {
+	// WatchForLogForwardConfigChanges return a NotifyWatcher waiting for
+	// the forwarding configuration to change. The audit forwarding
+	// config is read from controller config, which does not change
+	// while the agent runs, so the watcher only triggers reading it.
+	WatchForLogForwardConfigChanges() (watcher.NotifyWatcher, error)
+
+	// AuditForwardConfig returns the current audit forward configuration.
+	AuditForwardConfig() (*syslog.RawConfig, bool, error)
+}
+
+// auditForwardConfig adapts an AuditForwardConfig so that it may be
+// used to drive a LogForwarder.
+type auditForwardConfig struct {
+	AuditForwardConfig
+}
+
+// LogForwardConfig is part of the LogForwardConfig interface.
+func (c auditForwardConfig) LogForwardConfig() (*syslog.RawConfig, bool, error) {
+	return c.AuditForwardConfig.AuditForwardConfig()
+}
+
 type LogSinkSpec struct {
 	// Name is the name of the log sink.
 	Name string
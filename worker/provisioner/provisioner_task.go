@@ -621,6 +621,14 @@ func constructStartInstanceParams(
 		}
 	}
 
+	var rootDisk *storage.VolumeParams
+	if provisioningInfo.RootDisk != nil {
+		rootDisk = &storage.VolumeParams{
+			Provider:   storage.ProviderType(provisioningInfo.RootDisk.Provider),
+			Attributes: provisioningInfo.RootDisk.Attributes,
+		}
+	}
+
 	var subnetsToZones map[network.Id][]string
 	if provisioningInfo.SubnetsToZones != nil {
 		// Convert subnet provider ids from string to network.Id.
@@ -660,6 +668,7 @@ func constructStartInstanceParams(
 		DistributionGroup: machine.DistributionGroup,
 		Volumes:           volumes,
 		VolumeAttachments: volumeAttachments,
+		RootDisk:          rootDisk,
 		SubnetsToZones:    subnetsToZones,
 		EndpointBindings:  endpointBindings,
 		ImageMetadata:     possibleImageMetadata,
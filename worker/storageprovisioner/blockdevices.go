@@ -9,6 +9,7 @@ import (
 	"gopkg.in/juju/names.v2"
 
 	"github.com/juju/juju/apiserver/params"
+	"github.com/juju/juju/storage"
 )
 
 // machineBlockDevicesChanged is called when the block devices of the scoped
@@ -58,6 +59,25 @@ func machineBlockDevicesChanged(ctx *context) error {
 			volumeTags = append(volumeTags, filesystem.Volume)
 		}
 	}
+	// We must also query volumes backing provisioned filesystems,
+	// so that we can grow the filesystems when the volumes are
+	// resized.
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume == (names.VolumeTag{}) {
+			// Filesystem is not volume-backed.
+			continue
+		}
+		var found bool
+		for _, tag := range volumeTags {
+			if filesystem.Volume == tag {
+				found = true
+				break
+			}
+		}
+		if !found {
+			volumeTags = append(volumeTags, filesystem.Volume)
+		}
+	}
 	if len(volumeTags) == 0 {
 		return nil
 	}
@@ -118,6 +138,9 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 					updatePendingFilesystemAttachment(ctx, id, params)
 				}
 			}
+			if err := growFilesystems(ctx, volumeTags[i], result.Result); err != nil {
+				return errors.Trace(err)
+			}
 		} else if params.IsCodeNotProvisioned(result.Error) || params.IsCodeNotFound(result.Error) {
 			// Either the volume (attachment) isn't provisioned,
 			// or the corresponding block device is not yet known.
@@ -133,3 +156,71 @@ func refreshVolumeBlockDevices(ctx *context, volumeTags []names.VolumeTag) error
 	}
 	return nil
 }
+
+// growFilesystems grows any provisioned filesystems backed by the
+// specified volume that are smaller than the volume's block device,
+// which happens when the volume has been resized.
+func growFilesystems(ctx *context, volumeTag names.VolumeTag, blockDevice storage.BlockDevice) error {
+	var args []storage.FilesystemResizeParams
+	for _, filesystem := range ctx.filesystems {
+		if filesystem.Volume != volumeTag {
+			continue
+		}
+		if filesystem.Size >= blockDevice.Size {
+			continue
+		}
+		args = append(args, storage.FilesystemResizeParams{
+			Tag:          filesystem.Tag,
+			Volume:       filesystem.Volume,
+			FilesystemId: filesystem.FilesystemId,
+		})
+	}
+	if len(args) == 0 {
+		return nil
+	}
+	resizer, ok := ctx.managedFilesystemSource.(storage.FilesystemResizer)
+	if !ok {
+		logger.Warningf("managed filesystem source does not support resizing filesystems")
+		return nil
+	}
+	logger.Debugf("growing filesystems: %v", args)
+	results, err := resizer.ResizeFilesystems(args)
+	if err != nil {
+		return errors.Annotate(err, "growing filesystems")
+	}
+	var sizes []params.EntitySize
+	for i, result := range results {
+		tag := args[i].Tag
+		if result.Error != nil {
+			// The filesystem will be grown when the block
+			// devices are next seen to have changed.
+			logger.Errorf("growing %s: %v", names.ReadableString(tag), result.Error)
+			continue
+		}
+		filesystem := ctx.filesystems[tag]
+		filesystem.Size = result.Size
+		ctx.filesystems[tag] = filesystem
+		sizes = append(sizes, params.EntitySize{
+			Tag:  tag.String(),
+			Size: result.Size,
+		})
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Filesystems.SetFilesystemSizes(sizes)
+	if errors.IsNotSupported(err) {
+		return nil
+	} else if err != nil {
+		return errors.Annotate(err, "publishing filesystem sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of filesystem %s to state: %v",
+				sizes[i].Tag, result.Error,
+			)
+		}
+	}
+	return nil
+}
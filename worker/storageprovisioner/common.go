@@ -119,6 +119,19 @@ func setStatus(ctx *context, statuses []params.EntityStatusArgs) {
 
 var errNonDynamic = errors.New("non-dynamic storage provider")
 
+// isSharedFilesystemProvider reports whether or not the storage provider
+// with the specified type creates shared filesystems. Attachments to
+// shared filesystems are made by the machine-level worker, and the
+// status of a shared filesystem is not affected by its attachments.
+func isSharedFilesystemProvider(registry storage.ProviderRegistry, providerType storage.ProviderType) bool {
+	provider, err := registry.StorageProvider(providerType)
+	if err != nil {
+		return false
+	}
+	shared, ok := provider.(storage.SharedFilesystemProvider)
+	return ok && shared.SharedFilesystems()
+}
+
 // volumeSource returns a volume source given a name, provider type,
 // environment config and storage directory.
 //
@@ -160,7 +160,14 @@ func updatePendingFilesystemAttachment(
 	var incomplete bool
 	filesystem, ok := ctx.filesystems[params.Filesystem]
 	if !ok {
-		incomplete = true
+		// Shared filesystems are provisioned by the model-level
+		// worker, so the machine-level worker does not observe
+		// them; the filesystem ID is taken from the attachment
+		// params, which is empty until the filesystem has been
+		// provisioned.
+		if !isSharedFilesystemProvider(ctx.config.Registry, params.Provider) {
+			incomplete = true
+		}
 	} else {
 		params.FilesystemId = filesystem.FilesystemId
 		if filesystem.Volume != (names.VolumeTag{}) {
@@ -484,5 +491,6 @@ func filesystemAttachmentParamsFromParams(in params.FilesystemAttachmentParams)
 		Filesystem:   filesystemTag,
 		FilesystemId: in.FilesystemId,
 		Path:         in.MountPoint,
+		Attributes:   in.Attributes,
 	}, nil
 }
@@ -144,13 +144,14 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 		if err != nil {
 			return errors.Annotatef(err, "attaching filesystems from source %q", sourceName)
 		}
+		sourceStatuses := make([]params.EntityStatusArgs, 0, len(results))
 		for i, result := range results {
 			p := filesystemAttachmentParams[i]
-			statuses = append(statuses, params.EntityStatusArgs{
+			sourceStatuses = append(sourceStatuses, params.EntityStatusArgs{
 				Tag:    p.Filesystem.String(),
 				Status: status.Attached.String(),
 			})
-			entityStatus := &statuses[len(statuses)-1]
+			entityStatus := &sourceStatuses[len(sourceStatuses)-1]
 			if result.Error != nil {
 				// Reschedule the filesystem attachment.
 				id := params.MachineStorageId{
@@ -175,6 +176,9 @@ func attachFilesystems(ctx *context, ops map[params.MachineStorageId]*attachFile
 			}
 			filesystemAttachments = append(filesystemAttachments, *result.FilesystemAttachment)
 		}
+		if !isSharedFilesystemProvider(ctx.config.Registry, storage.ProviderType(sourceName)) {
+			statuses = append(statuses, sourceStatuses...)
+		}
 	}
 	scheduleOperations(ctx, reschedule...)
 	setStatus(ctx, statuses)
@@ -289,9 +293,10 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 		if err != nil {
 			return errors.Annotatef(err, "detaching filesystems from source %q", sourceName)
 		}
+		sourceStatuses := make([]params.EntityStatusArgs, 0, len(errs))
 		for i, err := range errs {
 			p := filesystemAttachmentParams[i]
-			statuses = append(statuses, params.EntityStatusArgs{
+			sourceStatuses = append(sourceStatuses, params.EntityStatusArgs{
 				Tag: p.Filesystem.String(),
 				// TODO(axw) when we support multiple
 				// attachment, we'll have to check if
@@ -303,7 +308,7 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 				MachineTag:    p.Machine.String(),
 				AttachmentTag: p.Filesystem.String(),
 			}
-			entityStatus := &statuses[len(statuses)-1]
+			entityStatus := &sourceStatuses[len(sourceStatuses)-1]
 			if err != nil {
 				reschedule = append(reschedule, ops[id])
 				entityStatus.Status = status.Detaching.String()
@@ -318,6 +323,9 @@ func detachFilesystems(ctx *context, ops map[params.MachineStorageId]*detachFile
 			}
 			remove = append(remove, id)
 		}
+		if !isSharedFilesystemProvider(ctx.config.Registry, storage.ProviderType(sourceName)) {
+			statuses = append(statuses, sourceStatuses...)
+		}
 	}
 	scheduleOperations(ctx, reschedule...)
 	setStatus(ctx, statuses)
@@ -90,15 +90,19 @@ func (w *mockAttachmentsWatcher) Changes() watcher.MachineStorageIdsChannel {
 
 type mockVolumeAccessor struct {
 	volumesWatcher         *mockStringsWatcher
+	resizesWatcher         *mockStringsWatcher
 	attachmentsWatcher     *mockAttachmentsWatcher
 	blockDevicesWatcher    *mockNotifyWatcher
 	provisionedMachines    map[string]instance.Id
 	provisionedVolumes     map[string]params.Volume
 	provisionedAttachments map[params.MachineStorageId]params.VolumeAttachment
 	blockDevices           map[params.MachineStorageId]storage.BlockDevice
+	pendingResizes         map[string]uint64
 
 	setVolumeInfo           func([]params.Volume) ([]params.ErrorResult, error)
 	setVolumeAttachmentInfo func([]params.VolumeAttachment) ([]params.ErrorResult, error)
+	setVolumeSizes          func([]params.EntitySize) ([]params.ErrorResult, error)
+	cancelVolumeResizes     func([]names.VolumeTag) ([]params.ErrorResult, error)
 }
 
 func (m *mockVolumeAccessor) provisionVolume(tag names.VolumeTag) params.Volume {
@@ -225,15 +229,55 @@ func (v *mockVolumeAccessor) SetVolumeAttachmentInfo(volumeAttachments []params.
 	return make([]params.ErrorResult, len(volumeAttachments)), nil
 }
 
+func (w *mockVolumeAccessor) WatchVolumeResizes() (watcher.StringsWatcher, error) {
+	return w.resizesWatcher, nil
+}
+
+func (v *mockVolumeAccessor) VolumeResizeParams(volumes []names.VolumeTag) ([]params.VolumeResizeParamsResult, error) {
+	var result []params.VolumeResizeParamsResult
+	for _, tag := range volumes {
+		size, ok := v.pendingResizes[tag.String()]
+		if !ok {
+			result = append(result, params.VolumeResizeParamsResult{
+				Error: common.ServerError(errors.NotFoundf("pending resize of volume %q", tag.Id())),
+			})
+			continue
+		}
+		result = append(result, params.VolumeResizeParamsResult{Result: params.VolumeResizeParams{
+			VolumeTag: tag.String(),
+			VolumeId:  "vol-" + tag.Id(),
+			Size:      size,
+			Provider:  "dummy",
+		}})
+	}
+	return result, nil
+}
+
+func (v *mockVolumeAccessor) SetVolumeSizes(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+	if v.setVolumeSizes != nil {
+		return v.setVolumeSizes(sizes)
+	}
+	return make([]params.ErrorResult, len(sizes)), nil
+}
+
+func (v *mockVolumeAccessor) CancelVolumeResizes(tags []names.VolumeTag) ([]params.ErrorResult, error) {
+	if v.cancelVolumeResizes != nil {
+		return v.cancelVolumeResizes(tags)
+	}
+	return make([]params.ErrorResult, len(tags)), nil
+}
+
 func newMockVolumeAccessor() *mockVolumeAccessor {
 	return &mockVolumeAccessor{
 		volumesWatcher:         newMockStringsWatcher(),
+		resizesWatcher:         newMockStringsWatcher(),
 		attachmentsWatcher:     newMockAttachmentsWatcher(),
 		blockDevicesWatcher:    newMockNotifyWatcher(),
 		provisionedMachines:    make(map[string]instance.Id),
 		provisionedVolumes:     make(map[string]params.Volume),
 		provisionedAttachments: make(map[params.MachineStorageId]params.VolumeAttachment),
 		blockDevices:           make(map[params.MachineStorageId]storage.BlockDevice),
+		pendingResizes:         make(map[string]uint64),
 	}
 }
 
@@ -246,6 +290,7 @@ type mockFilesystemAccessor struct {
 
 	setFilesystemInfo           func([]params.Filesystem) ([]params.ErrorResult, error)
 	setFilesystemAttachmentInfo func([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+	setFilesystemSizes          func([]params.EntitySize) ([]params.ErrorResult, error)
 }
 
 func (m *mockFilesystemAccessor) provisionFilesystem(tag names.FilesystemTag) params.Filesystem {
@@ -349,6 +394,13 @@ func (f *mockFilesystemAccessor) SetFilesystemAttachmentInfo(filesystemAttachmen
 	return make([]params.ErrorResult, len(filesystemAttachments)), nil
 }
 
+func (f *mockFilesystemAccessor) SetFilesystemSizes(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+	if f.setFilesystemSizes != nil {
+		return f.setFilesystemSizes(sizes)
+	}
+	return make([]params.ErrorResult, len(sizes)), nil
+}
+
 func newMockFilesystemAccessor() *mockFilesystemAccessor {
 	return &mockFilesystemAccessor{
 		filesystemsWatcher:     newMockStringsWatcher(),
@@ -431,6 +483,7 @@ type dummyProvider struct {
 	detachFilesystemsFunc        func([]storage.FilesystemAttachmentParams) ([]error, error)
 	destroyVolumesFunc           func([]string) ([]error, error)
 	destroyFilesystemsFunc       func([]string) ([]error, error)
+	resizeVolumesFunc            func([]storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error)
 	validateVolumeParamsFunc     func(storage.VolumeParams) error
 	validateFilesystemParamsFunc func(storage.FilesystemParams) error
 }
@@ -506,6 +559,18 @@ func (s *dummyVolumeSource) DestroyVolumes(volumeIds []string) ([]error, error)
 	return make([]error, len(volumeIds)), nil
 }
 
+// ResizeVolumes grows volumes.
+func (s *dummyVolumeSource) ResizeVolumes(params []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+	if s.provider.resizeVolumesFunc != nil {
+		return s.provider.resizeVolumesFunc(params)
+	}
+	results := make([]storage.ResizeVolumesResult, len(params))
+	for i, p := range params {
+		results[i].Size = p.Size
+	}
+	return results, nil
+}
+
 // AttachVolumes attaches volumes to machines.
 func (s *dummyVolumeSource) AttachVolumes(params []storage.VolumeAttachmentParams) ([]storage.AttachVolumesResult, error) {
 	if s.provider != nil && s.provider.attachVolumesFunc != nil {
@@ -678,6 +743,19 @@ func (s *mockManagedFilesystemSource) DetachFilesystems(params []storage.Filesys
 	return nil, errors.NotImplementedf("DetachFilesystems")
 }
 
+func (s *mockManagedFilesystemSource) ResizeFilesystems(args []storage.FilesystemResizeParams) ([]storage.ResizeFilesystemsResult, error) {
+	results := make([]storage.ResizeFilesystemsResult, len(args))
+	for i, arg := range args {
+		blockDevice, ok := s.blockDevices[arg.Volume]
+		if !ok {
+			results[i].Error = errors.Errorf("filesystem %v's backing-volume is not attached", arg.Tag.Id())
+			continue
+		}
+		results[i].Size = blockDevice.Size
+	}
+	return results, nil
+}
+
 type mockMachineAccessor struct {
 	instanceIds map[names.MachineTag]instance.Id
 	watcher     *mockNotifyWatcher
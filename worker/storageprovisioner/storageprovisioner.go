@@ -85,6 +85,22 @@ type VolumeAccessor interface {
 	// SetVolumeAttachmentInfo records the details of newly provisioned
 	// volume attachments.
 	SetVolumeAttachmentInfo([]params.VolumeAttachment) ([]params.ErrorResult, error)
+
+	// WatchVolumeResizes watches for changes to volumes that this
+	// storage provisioner is responsible for, so that pending resizes
+	// may be processed.
+	WatchVolumeResizes() (watcher.StringsWatcher, error)
+
+	// VolumeResizeParams returns the parameters for resizing the
+	// volumes with the specified tags.
+	VolumeResizeParams([]names.VolumeTag) ([]params.VolumeResizeParamsResult, error)
+
+	// SetVolumeSizes records the sizes of resized volumes.
+	SetVolumeSizes([]params.EntitySize) ([]params.ErrorResult, error)
+
+	// CancelVolumeResizes clears the pending resizes of the volumes
+	// with the specified tags, which cannot be carried out.
+	CancelVolumeResizes([]names.VolumeTag) ([]params.ErrorResult, error)
 }
 
 // FilesystemAccessor defines an interface used to allow a storage provisioner
@@ -119,6 +135,10 @@ type FilesystemAccessor interface {
 	// SetFilesystemAttachmentInfo records the details of newly provisioned
 	// filesystem attachments.
 	SetFilesystemAttachmentInfo([]params.FilesystemAttachment) ([]params.ErrorResult, error)
+
+	// SetFilesystemSizes records the sizes of filesystems that have
+	// been grown to fill their resized backing volumes.
+	SetFilesystemSizes([]params.EntitySize) ([]params.ErrorResult, error)
 }
 
 // MachineAccessor defines an interface used to allow a storage provisioner
@@ -200,6 +220,7 @@ func (w *storageProvisioner) loop() error {
 		volumesChanges               watcher.StringsChannel
 		filesystemsChanges           watcher.StringsChannel
 		volumeAttachmentsChanges     watcher.MachineStorageIdsChannel
+		volumeResizesChanges         watcher.StringsChannel
 		filesystemAttachmentsChanges watcher.MachineStorageIdsChannel
 		machineBlockDevicesChanges   <-chan struct{}
 	)
@@ -254,6 +275,20 @@ func (w *storageProvisioner) loop() error {
 	}
 	filesystemAttachmentsChanges = filesystemAttachmentsWatcher.Changes()
 
+	// Controllers that predate volume resizing will not support
+	// watching for resizes; in that case we leave the channel nil.
+	volumeResizesWatcher, err := w.config.Volumes.WatchVolumeResizes()
+	if errors.IsNotSupported(err) {
+		logger.Debugf("volume resizing not supported by the controller")
+	} else if err != nil {
+		return errors.Annotate(err, "watching volume resizes")
+	} else {
+		if err := w.catacomb.Add(volumeResizesWatcher); err != nil {
+			return errors.Trace(err)
+		}
+		volumeResizesChanges = volumeResizesWatcher.Changes()
+	}
+
 	ctx := context{
 		kill:                                 w.catacomb.Kill,
 		addWorker:                            w.catacomb.Add,
@@ -299,6 +334,13 @@ func (w *storageProvisioner) loop() error {
 			if err := volumeAttachmentsChanged(&ctx, changes); err != nil {
 				return errors.Trace(err)
 			}
+		case changes, ok := <-volumeResizesChanges:
+			if !ok {
+				return errors.New("volume resizes watcher closed")
+			}
+			if err := volumeResizesChanged(&ctx, changes); err != nil {
+				return errors.Trace(err)
+			}
 		case changes, ok := <-filesystemsChanges:
 			if !ok {
 				return errors.New("filesystems watcher closed")
@@ -340,6 +382,7 @@ func processSchedule(ctx *context) error {
 	destroyVolumeOps := make(map[names.VolumeTag]*destroyVolumeOp)
 	attachVolumeOps := make(map[params.MachineStorageId]*attachVolumeOp)
 	detachVolumeOps := make(map[params.MachineStorageId]*detachVolumeOp)
+	resizeVolumeOps := make(map[names.VolumeTag]*resizeVolumeOp)
 	createFilesystemOps := make(map[names.FilesystemTag]*createFilesystemOp)
 	destroyFilesystemOps := make(map[names.FilesystemTag]*destroyFilesystemOp)
 	attachFilesystemOps := make(map[params.MachineStorageId]*attachFilesystemOp)
@@ -356,6 +399,8 @@ func processSchedule(ctx *context) error {
 			attachVolumeOps[key.(params.MachineStorageId)] = op
 		case *detachVolumeOp:
 			detachVolumeOps[key.(params.MachineStorageId)] = op
+		case *resizeVolumeOp:
+			resizeVolumeOps[key.(resizeVolumeKey).tag] = op
 		case *createFilesystemOp:
 			createFilesystemOps[key.(names.FilesystemTag)] = op
 		case *destroyFilesystemOp:
@@ -386,6 +431,11 @@ func processSchedule(ctx *context) error {
 			return errors.Annotate(err, "attaching volumes")
 		}
 	}
+	if len(resizeVolumeOps) > 0 {
+		if err := resizeVolumes(ctx, resizeVolumeOps); err != nil {
+			return errors.Annotate(err, "resizing volumes")
+		}
+	}
 	if len(destroyFilesystemOps) > 0 {
 		if err := destroyFilesystems(ctx, destroyFilesystemOps); err != nil {
 			return errors.Annotate(err, "destroying filesystems")
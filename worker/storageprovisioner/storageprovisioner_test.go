@@ -1702,6 +1702,125 @@ func (s *storageProvisionerSuite) TestDestroyFilesystems(c *gc.C) {
 	assertNoEvent(c, removedChan, "filesystems removed")
 }
 
+func (s *storageProvisionerSuite) TestResizeVolumes(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	resizedChan := make(chan interface{}, 1)
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		resizedChan <- args
+		results := make([]storage.ResizeVolumesResult, len(args))
+		for i, arg := range args {
+			results[i].Size = arg.Size
+		}
+		return results, nil
+	}
+
+	sizesSet := make(chan interface{}, 1)
+	volumeAccessor.setVolumeSizes = func(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+		sizesSet <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// Volume 2 has no pending resize, and should be ignored.
+	volumeAccessor.resizesWatcher.changes <- []string{"1", "2"}
+
+	resized := waitChannel(c, resizedChan, "waiting for volume to be resized")
+	c.Assert(resized, jc.DeepEquals, []storage.VolumeResizeParams{{
+		Tag:      names.NewVolumeTag("1"),
+		VolumeId: "vol-1",
+		Size:     2048,
+	}})
+	sizes := waitChannel(c, sizesSet, "waiting for volume sizes to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.EntitySize{{
+		Tag:  "volume-1",
+		Size: 2048,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestResizeVolumesNotSupported(c *gc.C) {
+	volumeAccessor := newMockVolumeAccessor()
+	volumeAccessor.provisionVolume(names.NewVolumeTag("1"))
+	volumeAccessor.pendingResizes["volume-1"] = 2048
+
+	// Non-dynamic volumes cannot be resized.
+	s.provider.dynamic = false
+	s.provider.resizeVolumesFunc = func(args []storage.VolumeResizeParams) ([]storage.ResizeVolumesResult, error) {
+		c.Fatalf("unexpected call to ResizeVolumes")
+		return nil, nil
+	}
+
+	cancelled := make(chan interface{}, 1)
+	volumeAccessor.cancelVolumeResizes = func(tags []names.VolumeTag) ([]params.ErrorResult, error) {
+		cancelled <- tags
+		return make([]params.ErrorResult, len(tags)), nil
+	}
+
+	args := &workerArgs{volumes: volumeAccessor, registry: s.registry}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	volumeAccessor.resizesWatcher.changes <- []string{"1"}
+	tags := waitChannel(c, cancelled, "waiting for volume resize to be cancelled")
+	c.Assert(tags, jc.DeepEquals, []names.VolumeTag{names.NewVolumeTag("1")})
+	c.Assert(args.statusSetter.args, jc.DeepEquals, []params.EntityStatusArgs{{
+		Tag:    "volume-1",
+		Status: "error",
+		Info:   `resizing "dummy" volumes not supported`,
+	}})
+}
+
+func (s *storageProvisionerSuite) TestGrowVolumeBackedFilesystem(c *gc.C) {
+	filesystemAccessor := newMockFilesystemAccessor()
+	filesystemAccessor.provisionedFilesystems["filesystem-0-0"] = params.Filesystem{
+		FilesystemTag: "filesystem-0-0",
+		VolumeTag:     "volume-0-0",
+		Info: params.FilesystemInfo{
+			FilesystemId: "xvdf1",
+			Size:         123,
+		},
+	}
+	sizesSet := make(chan interface{}, 1)
+	filesystemAccessor.setFilesystemSizes = func(sizes []params.EntitySize) ([]params.ErrorResult, error) {
+		sizesSet <- sizes
+		return make([]params.ErrorResult, len(sizes)), nil
+	}
+
+	args := &workerArgs{
+		scope:       names.NewMachineTag("0"),
+		filesystems: filesystemAccessor,
+		registry:    s.registry,
+	}
+	worker := newStorageProvisioner(c, args)
+	defer func() { c.Assert(worker.Wait(), gc.IsNil) }()
+	defer worker.Kill()
+
+	// The backing volume has been resized, so its block
+	// device is larger than the filesystem.
+	args.volumes.blockDevices[params.MachineStorageId{
+		MachineTag:    "machine-0",
+		AttachmentTag: "volume-0-0",
+	}] = storage.BlockDevice{
+		DeviceName: "xvdf1",
+		Size:       246,
+	}
+	filesystemAccessor.filesystemsWatcher.changes <- []string{"0/0"}
+
+	sizes := waitChannel(c, sizesSet, "waiting for filesystem sizes to be set")
+	c.Assert(sizes, jc.DeepEquals, []params.EntitySize{{
+		Tag:  "filesystem-0-0",
+		Size: 246,
+	}})
+	assertNoEvent(c, sizesSet, "filesystem sizes set")
+}
+
 func newStorageProvisioner(c *gc.C, args *workerArgs) worker.Worker {
 	if args == nil {
 		args = &workerArgs{}
@@ -95,6 +95,42 @@ func volumeAttachmentsChanged(ctx *context, watcherIds []watcher.MachineStorageI
 	return nil
 }
 
+// volumeResizesChanged is called when volumes with the provided IDs
+// have been seen to have changed, and may have a resize pending.
+func volumeResizesChanged(ctx *context, changes []string) error {
+	tags := make([]names.VolumeTag, len(changes))
+	for i, change := range changes {
+		tags[i] = names.NewVolumeTag(change)
+	}
+	results, err := ctx.config.Volumes.VolumeResizeParams(tags)
+	if err != nil {
+		return errors.Annotate(err, "getting volume resize params")
+	}
+	ops := make([]scheduleOp, 0, len(results))
+	for i, result := range results {
+		if params.IsCodeNotFound(result.Error) {
+			// There is no resize pending for the volume.
+			continue
+		} else if result.Error != nil {
+			return errors.Annotatef(
+				result.Error, "getting resize params for %s",
+				names.ReadableString(tags[i]),
+			)
+		}
+		args, err := volumeResizeParamsFromParams(result.Result)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		logger.Debugf("scheduling resize of %s to %dMiB", names.ReadableString(args.Tag), args.Size)
+		ops = append(ops, &resizeVolumeOp{
+			provider: storage.ProviderType(result.Result.Provider),
+			args:     args,
+		})
+	}
+	scheduleOperations(ctx, ops...)
+	return nil
+}
+
 // processDyingVolumes processes the VolumeResults for Dying volumes,
 // removing them from provisioning-pending as necessary.
 func processDyingVolumes(ctx *context, tags []names.Tag) error {
@@ -481,6 +517,19 @@ func volumeParamsFromParams(in params.VolumeParams) (storage.VolumeParams, error
 	}, nil
 }
 
+func volumeResizeParamsFromParams(in params.VolumeResizeParams) (storage.VolumeResizeParams, error) {
+	volumeTag, err := names.ParseVolumeTag(in.VolumeTag)
+	if err != nil {
+		return storage.VolumeResizeParams{}, errors.Trace(err)
+	}
+	return storage.VolumeResizeParams{
+		Tag:        volumeTag,
+		VolumeId:   in.VolumeId,
+		Size:       in.Size,
+		Attributes: in.Attributes,
+	}, nil
+}
+
 func volumeAttachmentParamsFromParams(in params.VolumeAttachmentParams) (storage.VolumeAttachmentParams, error) {
 	machineTag, err := names.ParseMachineTag(in.MachineTag)
 	if err != nil {
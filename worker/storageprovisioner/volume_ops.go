@@ -265,6 +265,106 @@ func destroyVolumes(ctx *context, ops map[names.VolumeTag]*destroyVolumeOp) erro
 	return nil
 }
 
+// resizeVolumes grows volumes with the specified parameters.
+func resizeVolumes(ctx *context, ops map[names.VolumeTag]*resizeVolumeOp) error {
+	opsBySource := make(map[storage.ProviderType][]*resizeVolumeOp)
+	for _, op := range ops {
+		opsBySource[op.provider] = append(opsBySource[op.provider], op)
+	}
+	var reschedule []scheduleOp
+	var sizes []params.EntitySize
+	var statuses []params.EntityStatusArgs
+	var cancel []names.VolumeTag
+	for providerType, ops := range opsBySource {
+		sourceName := string(providerType)
+		source, err := volumeSource(
+			ctx.config.StorageDir, sourceName, providerType, ctx.config.Registry,
+		)
+		var resizer storage.VolumeResizer
+		if err == nil {
+			resizer, _ = source.(storage.VolumeResizer)
+		} else if errors.Cause(err) != errNonDynamic {
+			return errors.Annotate(err, "getting volume source")
+		}
+		if resizer == nil {
+			// The volumes cannot be resized, so the resize would
+			// otherwise remain pending forever.
+			err := errors.NotSupportedf("resizing %q volumes", providerType)
+			logger.Warningf("%v", err)
+			for _, op := range ops {
+				statuses = append(statuses, params.EntityStatusArgs{
+					Tag:    op.args.Tag.String(),
+					Status: status.Error.String(),
+					Info:   err.Error(),
+				})
+				cancel = append(cancel, op.args.Tag)
+			}
+			continue
+		}
+		args := make([]storage.VolumeResizeParams, len(ops))
+		for i, op := range ops {
+			args[i] = op.args
+		}
+		logger.Debugf("resizing volumes from %q: %v", sourceName, args)
+		results, err := resizer.ResizeVolumes(args)
+		if err != nil {
+			return errors.Annotatef(err, "resizing volumes from source %q", sourceName)
+		}
+		for i, result := range results {
+			tag := args[i].Tag
+			if result.Error != nil {
+				// Failed to resize volume; reschedule.
+				reschedule = append(reschedule, ops[i])
+				logger.Debugf(
+					"failed to resize %s: %v",
+					names.ReadableString(tag), result.Error,
+				)
+				continue
+			}
+			sizes = append(sizes, params.EntitySize{
+				Tag:  tag.String(),
+				Size: result.Size,
+			})
+			if volume, ok := ctx.volumes[tag]; ok {
+				volume.Size = result.Size
+				ctx.volumes[tag] = volume
+			}
+		}
+	}
+	scheduleOperations(ctx, reschedule...)
+	setStatus(ctx, statuses)
+	if len(cancel) > 0 {
+		errorResults, err := ctx.config.Volumes.CancelVolumeResizes(cancel)
+		if err != nil {
+			return errors.Annotate(err, "cancelling volume resizes")
+		}
+		for i, result := range errorResults {
+			if result.Error != nil {
+				logger.Errorf(
+					"cancelling resize of %s: %v",
+					names.ReadableString(cancel[i]), result.Error,
+				)
+			}
+		}
+	}
+	if len(sizes) == 0 {
+		return nil
+	}
+	errorResults, err := ctx.config.Volumes.SetVolumeSizes(sizes)
+	if err != nil {
+		return errors.Annotate(err, "publishing volume sizes to state")
+	}
+	for i, result := range errorResults {
+		if result.Error != nil {
+			logger.Errorf(
+				"publishing size of volume %s to state: %v",
+				sizes[i].Tag, result.Error,
+			)
+		}
+	}
+	return nil
+}
+
 // detachVolumes destroys volume attachments with the specified parameters.
 func detachVolumes(ctx *context, ops map[params.MachineStorageId]*detachVolumeOp) error {
 	volumeAttachmentParams := make([]storage.VolumeAttachmentParams, 0, len(ops))
@@ -472,6 +572,23 @@ func (op *destroyVolumeOp) key() interface{} {
 	return op.tag
 }
 
+// resizeVolumeKey is the schedule key for resizeVolumeOp. It is
+// distinct from the volume tag, so that a resize does not replace
+// any pending create or destroy operation for the same volume.
+type resizeVolumeKey struct {
+	tag names.VolumeTag
+}
+
+type resizeVolumeOp struct {
+	exponentialBackoff
+	provider storage.ProviderType
+	args     storage.VolumeResizeParams
+}
+
+func (op *resizeVolumeOp) key() interface{} {
+	return resizeVolumeKey{op.args.Tag}
+}
+
 type attachVolumeOp struct {
 	exponentialBackoff
 	args storage.VolumeAttachmentParams
@@ -0,0 +1,18 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"github.com/juju/juju/worker/uniter/resolver"
+)
+
+var NewMetricsRunnerFactory = newMetricsRunnerFactory
+
+func NewMetricsResolver(r resolver.Resolver, metrics *Metrics, unit string) resolver.Resolver {
+	return &metricsResolver{
+		Resolver: r,
+		metrics:  metrics,
+		unit:     unit,
+	}
+}
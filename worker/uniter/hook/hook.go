@@ -16,8 +16,18 @@ const (
 	LeaderElected         hooks.Kind = "leader-elected"
 	LeaderDeposed         hooks.Kind = "leader-deposed"
 	LeaderSettingsChanged hooks.Kind = "leader-settings-changed"
+
+	// StorageResized is run when the storage attached to the unit has
+	// been grown, so that the charm may make use of the new space.
+	StorageResized hooks.Kind = "storage-resized"
 )
 
+// IsStorage returns whether the specified hook kind is associated with
+// a storage instance, including the storage-resized hook.
+func IsStorage(kind hooks.Kind) bool {
+	return kind.IsStorage() || kind == StorageResized
+}
+
 // Info holds details required to execute a hook. Not all fields are
 // relevant to all Kind values.
 type Info struct {
@@ -37,6 +47,11 @@ type Info struct {
 
 	// StorageId is the ID of the storage instance relevant to the hook.
 	StorageId string `yaml:"storage-id,omitempty"`
+
+	// StorageSize is the size, in MiB, of the storage instance identified
+	// by StorageId at the time the hook was queued. It is only set when
+	// Kind indicates a storage-attached or storage-resized hook.
+	StorageSize uint64 `yaml:"storage-size,omitempty"`
 }
 
 // Validate returns an error if the info is not valid.
@@ -52,7 +67,7 @@ func (hi Info) Validate() error {
 		return nil
 	case hooks.Action:
 		return fmt.Errorf("hooks.Kind Action is deprecated")
-	case hooks.StorageAttached, hooks.StorageDetaching:
+	case hooks.StorageAttached, hooks.StorageDetaching, StorageResized:
 		if !names.IsValidStorage(hi.StorageId) {
 			return fmt.Errorf("invalid storage ID %q", hi.StorageId)
 		}
@@ -31,6 +31,10 @@ type ManifoldConfig struct {
 	CharmDirName          string
 	HookRetryStrategyName string
 	TranslateResolverErr  func(error) error
+
+	// Metrics records the hooks and actions run by the uniter. It
+	// should be registered with the agent's prometheus.Registerer.
+	Metrics *Metrics
 }
 
 // Manifold returns a dependency manifold that runs a uniter worker,
@@ -102,6 +106,7 @@ func Manifold(config ManifoldConfig) dependency.Manifold {
 				NewOperationExecutor: operation.NewExecutor,
 				TranslateResolverErr: config.TranslateResolverErr,
 				Clock:                manifoldConfig.Clock,
+				Metrics:              manifoldConfig.Metrics,
 			})
 			if err != nil {
 				return nil, errors.Trace(err)
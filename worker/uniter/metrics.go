@@ -0,0 +1,225 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/utils/clock"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+const (
+	uniterMetricsNamespace = "juju"
+	uniterMetricsSubsystem = "uniter"
+
+	unitLabel   = "unit"
+	hookLabel   = "hook"
+	actionLabel = "action"
+)
+
+// hookDurationBuckets covers everything from trivial hooks up to
+// long-running installs and upgrades.
+var hookDurationBuckets = []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600, 1800}
+
+// Metrics is a prometheus.Collector that records the hooks and
+// actions run by uniters, and the work done by their resolver loops.
+// A single Metrics may be shared by several uniters; all metrics are
+// labelled with the name of the unit.
+type Metrics struct {
+	hooksTotal              *prometheus.CounterVec
+	hookFailuresTotal       *prometheus.CounterVec
+	hookConsecutiveFailures *prometheus.GaugeVec
+	hookDuration            *prometheus.HistogramVec
+	actionsTotal            *prometheus.CounterVec
+	actionDuration          *prometheus.HistogramVec
+	resolverLoopsTotal      *prometheus.CounterVec
+}
+
+// NewMetrics returns a new Metrics, which should be registered with
+// the agent's prometheus.Registerer.
+func NewMetrics() *Metrics {
+	hookLabels := []string{unitLabel, hookLabel}
+	actionLabels := []string{unitLabel, actionLabel}
+	return &Metrics{
+		hooksTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "hooks_total",
+			Help:      "Number of hooks run, including those that failed.",
+		}, hookLabels),
+		hookFailuresTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "hook_failures_total",
+			Help:      "Number of hooks that failed.",
+		}, hookLabels),
+		hookConsecutiveFailures: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "hook_consecutive_failures",
+			Help:      "Number of times a hook has failed since it last succeeded.",
+		}, hookLabels),
+		hookDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "hook_duration_seconds",
+			Help:      "Time taken to run hooks in seconds.",
+			Buckets:   hookDurationBuckets,
+		}, hookLabels),
+		actionsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "actions_total",
+			Help:      "Number of actions run.",
+		}, actionLabels),
+		actionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "action_duration_seconds",
+			Help:      "Time taken to run actions in seconds.",
+			Buckets:   hookDurationBuckets,
+		}, actionLabels),
+		resolverLoopsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: uniterMetricsNamespace,
+			Subsystem: uniterMetricsSubsystem,
+			Name:      "resolver_loop_iterations_total",
+			Help:      "Number of times the resolver has been asked for the next operation.",
+		}, []string{unitLabel}),
+	}
+}
+
+// Describe is part of the prometheus.Collector interface.
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.hooksTotal.Describe(ch)
+	m.hookFailuresTotal.Describe(ch)
+	m.hookConsecutiveFailures.Describe(ch)
+	m.hookDuration.Describe(ch)
+	m.actionsTotal.Describe(ch)
+	m.actionDuration.Describe(ch)
+	m.resolverLoopsTotal.Describe(ch)
+}
+
+// Collect is part of the prometheus.Collector interface.
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.hooksTotal.Collect(ch)
+	m.hookFailuresTotal.Collect(ch)
+	m.hookConsecutiveFailures.Collect(ch)
+	m.hookDuration.Collect(ch)
+	m.actionsTotal.Collect(ch)
+	m.actionDuration.Collect(ch)
+	m.resolverLoopsTotal.Collect(ch)
+}
+
+func (m *Metrics) observeHook(unit, hookName string, duration time.Duration, failed bool) {
+	m.hooksTotal.WithLabelValues(unit, hookName).Inc()
+	m.hookDuration.WithLabelValues(unit, hookName).Observe(duration.Seconds())
+	consecutive := m.hookConsecutiveFailures.WithLabelValues(unit, hookName)
+	if failed {
+		m.hookFailuresTotal.WithLabelValues(unit, hookName).Inc()
+		consecutive.Inc()
+	} else {
+		consecutive.Set(0)
+	}
+}
+
+func (m *Metrics) observeAction(unit, actionName string, duration time.Duration) {
+	m.actionsTotal.WithLabelValues(unit, actionName).Inc()
+	m.actionDuration.WithLabelValues(unit, actionName).Observe(duration.Seconds())
+}
+
+func (m *Metrics) observeResolverLoop(unit string) {
+	m.resolverLoopsTotal.WithLabelValues(unit).Inc()
+}
+
+// metricsRunnerFactory is a runner.Factory that records the hooks and
+// actions run by the runners it creates.
+type metricsRunnerFactory struct {
+	runner.Factory
+	metrics *Metrics
+	clock   clock.Clock
+	unit    string
+}
+
+func newMetricsRunnerFactory(factory runner.Factory, metrics *Metrics, clock clock.Clock, unit string) runner.Factory {
+	return &metricsRunnerFactory{
+		Factory: factory,
+		metrics: metrics,
+		clock:   clock,
+		unit:    unit,
+	}
+}
+
+// NewHookRunner is part of the runner.Factory interface.
+func (f *metricsRunnerFactory) NewHookRunner(hookInfo hook.Info) (runner.Runner, error) {
+	r, err := f.Factory.NewHookRunner(hookInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsRunner{Runner: r, factory: f}, nil
+}
+
+// NewActionRunner is part of the runner.Factory interface.
+func (f *metricsRunnerFactory) NewActionRunner(actionId string) (runner.Runner, error) {
+	r, err := f.Factory.NewActionRunner(actionId)
+	if err != nil {
+		// The error is returned unwrapped, as the caller
+		// inspects it to decide whether to fail the action.
+		return nil, err
+	}
+	return &metricsRunner{Runner: r, factory: f}, nil
+}
+
+type metricsRunner struct {
+	runner.Runner
+	factory *metricsRunnerFactory
+}
+
+// RunHook is part of the runner.Runner interface.
+func (r *metricsRunner) RunHook(name string) error {
+	start := r.factory.clock.Now()
+	err := r.Runner.RunHook(name)
+	cause := errors.Cause(err)
+	if context.IsMissingHookError(cause) {
+		// Missing hooks are not run, so there is nothing to record.
+		return err
+	}
+	failed := err != nil && cause != context.ErrReboot && cause != context.ErrRequeueAndReboot
+	r.factory.metrics.observeHook(r.factory.unit, name, r.factory.clock.Now().Sub(start), failed)
+	return err
+}
+
+// RunAction is part of the runner.Runner interface.
+func (r *metricsRunner) RunAction(name string) error {
+	start := r.factory.clock.Now()
+	err := r.Runner.RunAction(name)
+	r.factory.metrics.observeAction(r.factory.unit, name, r.factory.clock.Now().Sub(start))
+	return err
+}
+
+// metricsResolver is a resolver.Resolver that counts the iterations
+// of the resolver loop.
+type metricsResolver struct {
+	resolver.Resolver
+	metrics *Metrics
+	unit    string
+}
+
+// NextOp is part of the resolver.Resolver interface.
+func (r *metricsResolver) NextOp(
+	localState resolver.LocalState,
+	remoteState remotestate.Snapshot,
+	opFactory operation.Factory,
+) (operation.Operation, error) {
+	r.metrics.observeResolverLoop(r.unit)
+	return r.Resolver.NextOp(localState, remoteState, opFactory)
+}
@@ -0,0 +1,234 @@
+// Copyright 2017 Canonical Ltd.
+// Licensed under the AGPLv3, see LICENCE file for details.
+
+package uniter_test
+
+import (
+	"time"
+
+	"github.com/juju/errors"
+	"github.com/juju/testing"
+	jc "github.com/juju/testing/checkers"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	gc "gopkg.in/check.v1"
+
+	"github.com/juju/juju/worker/uniter"
+	"github.com/juju/juju/worker/uniter/hook"
+	"github.com/juju/juju/worker/uniter/operation"
+	"github.com/juju/juju/worker/uniter/remotestate"
+	"github.com/juju/juju/worker/uniter/resolver"
+	"github.com/juju/juju/worker/uniter/runner"
+	"github.com/juju/juju/worker/uniter/runner/context"
+)
+
+type metricsSuite struct {
+	testing.IsolationSuite
+
+	clock    *testing.Clock
+	metrics  *uniter.Metrics
+	registry *prometheus.Registry
+	runner   *fakeRunner
+	factory  runner.Factory
+}
+
+var _ = gc.Suite(&metricsSuite{})
+
+func (s *metricsSuite) SetUpTest(c *gc.C) {
+	s.IsolationSuite.SetUpTest(c)
+	s.clock = testing.NewClock(time.Time{})
+	s.metrics = uniter.NewMetrics()
+	s.registry = prometheus.NewPedanticRegistry()
+	err := s.registry.Register(s.metrics)
+	c.Assert(err, jc.ErrorIsNil)
+	s.runner = &fakeRunner{clock: s.clock, hookErrors: make(map[string]error)}
+	s.factory = uniter.NewMetricsRunnerFactory(
+		&fakeRunnerFactory{runner: s.runner}, s.metrics, s.clock, "mysql/0",
+	)
+}
+
+func (s *metricsSuite) runHook(c *gc.C, name string) error {
+	r, err := s.factory.NewHookRunner(hook.Info{})
+	c.Assert(err, jc.ErrorIsNil)
+	return r.RunHook(name)
+}
+
+func (s *metricsSuite) TestHookSucceeded(c *gc.C) {
+	s.runner.duration = 3 * time.Second
+	err := s.runHook(c, "config-changed")
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels := map[string]string{"unit": "mysql/0", "hook": "config-changed"}
+	families := s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_hooks_total", labels), gc.Equals, 1.0)
+	c.Assert(findMetric(families, "juju_uniter_hook_failures_total", labels), gc.IsNil)
+	c.Assert(gaugeValue(c, families, "juju_uniter_hook_consecutive_failures", labels), gc.Equals, 0.0)
+
+	duration := findMetric(families, "juju_uniter_hook_duration_seconds", labels)
+	c.Assert(duration, gc.NotNil)
+	c.Assert(duration.GetHistogram().GetSampleCount(), gc.Equals, uint64(1))
+	c.Assert(duration.GetHistogram().GetSampleSum(), gc.Equals, 3.0)
+}
+
+func (s *metricsSuite) TestHookFailed(c *gc.C) {
+	s.runner.hookErrors["update-status"] = errors.New("exit status 1")
+	for i := 0; i < 3; i++ {
+		err := s.runHook(c, "update-status")
+		c.Assert(err, gc.ErrorMatches, "exit status 1")
+	}
+
+	labels := map[string]string{"unit": "mysql/0", "hook": "update-status"}
+	families := s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_hooks_total", labels), gc.Equals, 3.0)
+	c.Assert(counterValue(c, families, "juju_uniter_hook_failures_total", labels), gc.Equals, 3.0)
+	c.Assert(gaugeValue(c, families, "juju_uniter_hook_consecutive_failures", labels), gc.Equals, 3.0)
+
+	// A successful run resets the consecutive failure count,
+	// but not the failure total.
+	delete(s.runner.hookErrors, "update-status")
+	err := s.runHook(c, "update-status")
+	c.Assert(err, jc.ErrorIsNil)
+
+	families = s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_hooks_total", labels), gc.Equals, 4.0)
+	c.Assert(counterValue(c, families, "juju_uniter_hook_failures_total", labels), gc.Equals, 3.0)
+	c.Assert(gaugeValue(c, families, "juju_uniter_hook_consecutive_failures", labels), gc.Equals, 0.0)
+}
+
+func (s *metricsSuite) TestHookRebootNotFailure(c *gc.C) {
+	s.runner.hookErrors["install"] = context.ErrReboot
+	err := s.runHook(c, "install")
+	c.Assert(err, gc.Equals, context.ErrReboot)
+
+	labels := map[string]string{"unit": "mysql/0", "hook": "install"}
+	families := s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_hooks_total", labels), gc.Equals, 1.0)
+	c.Assert(findMetric(families, "juju_uniter_hook_failures_total", labels), gc.IsNil)
+}
+
+func (s *metricsSuite) TestMissingHookNotRecorded(c *gc.C) {
+	s.runner.hookErrors["stop"] = context.NewMissingHookError("stop")
+	err := s.runHook(c, "stop")
+	c.Assert(context.IsMissingHookError(err), jc.IsTrue)
+
+	labels := map[string]string{"unit": "mysql/0", "hook": "stop"}
+	families := s.gather(c)
+	c.Assert(findMetric(families, "juju_uniter_hooks_total", labels), gc.IsNil)
+}
+
+func (s *metricsSuite) TestAction(c *gc.C) {
+	s.runner.duration = 10 * time.Second
+	r, err := s.factory.NewActionRunner("666")
+	c.Assert(err, jc.ErrorIsNil)
+	err = r.RunAction("backup")
+	c.Assert(err, jc.ErrorIsNil)
+
+	labels := map[string]string{"unit": "mysql/0", "action": "backup"}
+	families := s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_actions_total", labels), gc.Equals, 1.0)
+	duration := findMetric(families, "juju_uniter_action_duration_seconds", labels)
+	c.Assert(duration, gc.NotNil)
+	c.Assert(duration.GetHistogram().GetSampleSum(), gc.Equals, 10.0)
+}
+
+func (s *metricsSuite) TestActionRunnerError(c *gc.C) {
+	badAction := runner.NewBadActionError("666", "kaboom")
+	factory := uniter.NewMetricsRunnerFactory(
+		&fakeRunnerFactory{actionErr: badAction}, s.metrics, s.clock, "mysql/0",
+	)
+	_, err := factory.NewActionRunner("666")
+	c.Assert(err, gc.Equals, badAction)
+}
+
+func (s *metricsSuite) TestResolverLoop(c *gc.C) {
+	r := uniter.NewMetricsResolver(&fakeResolver{}, s.metrics, "mysql/0")
+	for i := 0; i < 2; i++ {
+		_, err := r.NextOp(resolver.LocalState{}, remotestate.Snapshot{}, nil)
+		c.Assert(err, gc.Equals, resolver.ErrNoOperation)
+	}
+
+	labels := map[string]string{"unit": "mysql/0"}
+	families := s.gather(c)
+	c.Assert(counterValue(c, families, "juju_uniter_resolver_loop_iterations_total", labels), gc.Equals, 2.0)
+}
+
+func (s *metricsSuite) gather(c *gc.C) []*dto.MetricFamily {
+	families, err := s.registry.Gather()
+	c.Assert(err, jc.ErrorIsNil)
+	return families
+}
+
+// findMetric returns the metric with the given name and labels, or
+// nil if there is no such metric.
+func findMetric(families []*dto.MetricFamily, name string, labels map[string]string) *dto.Metric {
+	for _, family := range families {
+		if family.GetName() != name {
+			continue
+		}
+	metrics:
+		for _, metric := range family.Metric {
+			if len(metric.Label) != len(labels) {
+				continue
+			}
+			for _, label := range metric.Label {
+				if labels[label.GetName()] != label.GetValue() {
+					continue metrics
+				}
+			}
+			return metric
+		}
+	}
+	return nil
+}
+
+func counterValue(c *gc.C, families []*dto.MetricFamily, name string, labels map[string]string) float64 {
+	metric := findMetric(families, name, labels)
+	c.Assert(metric, gc.NotNil, gc.Commentf("metric %s%v not found", name, labels))
+	return metric.GetCounter().GetValue()
+}
+
+func gaugeValue(c *gc.C, families []*dto.MetricFamily, name string, labels map[string]string) float64 {
+	metric := findMetric(families, name, labels)
+	c.Assert(metric, gc.NotNil, gc.Commentf("metric %s%v not found", name, labels))
+	return metric.GetGauge().GetValue()
+}
+
+type fakeRunnerFactory struct {
+	runner.Factory
+	runner    *fakeRunner
+	actionErr error
+}
+
+func (f *fakeRunnerFactory) NewHookRunner(hook.Info) (runner.Runner, error) {
+	return f.runner, nil
+}
+
+func (f *fakeRunnerFactory) NewActionRunner(string) (runner.Runner, error) {
+	if f.actionErr != nil {
+		return nil, f.actionErr
+	}
+	return f.runner, nil
+}
+
+type fakeRunner struct {
+	runner.Runner
+	clock      *testing.Clock
+	duration   time.Duration
+	hookErrors map[string]error
+}
+
+func (r *fakeRunner) RunHook(name string) error {
+	r.clock.Advance(r.duration)
+	return r.hookErrors[name]
+}
+
+func (r *fakeRunner) RunAction(name string) error {
+	r.clock.Advance(r.duration)
+	return nil
+}
+
+type fakeResolver struct{}
+
+func (*fakeResolver) NextOp(resolver.LocalState, remotestate.Snapshot, operation.Factory) (operation.Operation, error) {
+	return nil, resolver.ErrNoOperation
+}
@@ -506,6 +506,14 @@ func (ctx *HookContext) SetActionMessage(message string) error {
 	return nil
 }
 
+// LogActionMessage records a progress message for the action.
+func (ctx *HookContext) LogActionMessage(message string) error {
+	if ctx.actionData == nil {
+		return errors.New("not running an action")
+	}
+	return ctx.state.LogActionMessage(ctx.actionData.Tag, message)
+}
+
 // SetActionFailed sets the fail state of the action.
 func (ctx *HookContext) SetActionFailed() error {
 	if ctx.actionData == nil {
@@ -235,7 +235,7 @@ func (a *Attachments) removeStorageAttachment(tag names.StorageTag) error {
 }
 
 func (a *Attachments) storageStateForHook(hi hook.Info) (*stateFile, error) {
-	if !hi.Kind.IsStorage() {
+	if !hook.IsStorage(hi.Kind) {
 		return nil, errors.Errorf("not a storage hook: %#v", hi)
 	}
 	storageAttachment, ok := a.storageAttachments[names.NewStorageTag(hi.StorageId)]
@@ -197,4 +197,36 @@ func (s *stateSuite) TestValidateHook(c *gc.C) {
 	assertValidates(true, hooks.StorageDetaching)
 	assertValidateFails(false, hooks.StorageDetaching, `inappropriate "storage-detaching" hook for storage "data/0": storage not attached`)
 	assertValidateFails(true, hooks.StorageAttached, `inappropriate "storage-attached" hook for storage "data/0": storage already attached`)
+	assertValidates(true, hook.StorageResized)
+	assertValidateFails(false, hook.StorageResized, `inappropriate "storage-resized" hook for storage "data/0": storage not attached`)
+}
+
+func (s *stateSuite) TestCommitHookRecordsSize(c *gc.C) {
+	dir := c.MkDir()
+	state, err := storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	stateFile := filepath.Join(dir, "data-0")
+
+	err = state.CommitHook(hook.Info{
+		Kind:        hooks.StorageAttached,
+		StorageId:   "data-0",
+		StorageSize: 1024,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	data, err := ioutil.ReadFile(stateFile)
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(string(data), gc.Equals, "attached: true\nsize: 1024\n")
+
+	err = state.CommitHook(hook.Info{
+		Kind:        hook.StorageResized,
+		StorageId:   "data-0",
+		StorageSize: 2048,
+	})
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(2048))
+
+	state, err = storage.ReadStateFile(dir, names.NewStorageTag("data/0"))
+	c.Assert(err, jc.ErrorIsNil)
+	c.Assert(storage.StateAttached(state), jc.IsTrue)
+	c.Assert(storage.StateSize(state), gc.Equals, uint64(2048))
 }
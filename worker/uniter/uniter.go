@@ -101,6 +101,9 @@ type Uniter struct {
 	// downloader is the downloader that should be used to get the charm
 	// archive.
 	downloader charm.Downloader
+
+	// metrics records the hooks and actions run by the uniter.
+	metrics *Metrics
 }
 
 // UniterParams hold all the necessary parameters for a new Uniter.
@@ -117,6 +120,9 @@ type UniterParams struct {
 	NewOperationExecutor NewExecutorFunc
 	TranslateResolverErr func(error) error
 	Clock                clock.Clock
+	// Metrics records the hooks and actions run by the uniter. If it
+	// is nil, the metrics are recorded but not exposed.
+	Metrics *Metrics
 	// TODO (mattyw, wallyworld, fwereade) Having the observer here make this approach a bit more legitimate, but it isn't.
 	// the observer is only a stop gap to be used in tests. A better approach would be to have the uniter tests start hooks
 	// that write to files, and have the tests watch the output to know that hooks have finished.
@@ -133,6 +139,10 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 	if translateResolverErr == nil {
 		translateResolverErr = func(err error) error { return err }
 	}
+	metrics := uniterParams.Metrics
+	if metrics == nil {
+		metrics = NewMetrics()
+	}
 
 	u := &Uniter{
 		st:                   uniterParams.UniterFacade,
@@ -147,6 +157,7 @@ func NewUniter(uniterParams *UniterParams) (*Uniter, error) {
 		observer:             uniterParams.Observer,
 		clock:                uniterParams.Clock,
 		downloader:           uniterParams.Downloader,
+		metrics:              metrics,
 	}
 	err := catacomb.Invoke(catacomb.Plan{
 		Site: &u.catacomb,
@@ -295,6 +306,11 @@ func (u *Uniter) loop(unitTag names.UnitTag) (err error) {
 				u.commands, watcher.CommandCompleted,
 			),
 		})
+		uniterResolver = &metricsResolver{
+			Resolver: uniterResolver,
+			metrics:  u.metrics,
+			unit:     unitTag.Id(),
+		}
 
 		// We should not do anything until there has been a change
 		// to the remote state. The watcher will trigger at least
@@ -476,6 +492,7 @@ func (u *Uniter) init(unitTag names.UnitTag) (err error) {
 	if err != nil {
 		return errors.Trace(err)
 	}
+	runnerFactory = newMetricsRunnerFactory(runnerFactory, u.metrics, u.clock, unitTag.Id())
 	u.operationFactory = operation.NewFactory(operation.FactoryParams{
 		Deployer:       deployer,
 		RunnerFactory:  runnerFactory,